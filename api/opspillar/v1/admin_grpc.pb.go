@@ -19,13 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Admin_CreateUsers_FullMethodName = "/api.opspillar.v1.Admin/CreateUsers"
-	Admin_UpdateUsers_FullMethodName = "/api.opspillar.v1.Admin/UpdateUsers"
-	Admin_DeleteUsers_FullMethodName = "/api.opspillar.v1.Admin/DeleteUsers"
-	Admin_GetUsers_FullMethodName    = "/api.opspillar.v1.Admin/GetUsers"
-	Admin_ListUsers_FullMethodName   = "/api.opspillar.v1.Admin/ListUsers"
-	Admin_Login_FullMethodName       = "/api.opspillar.v1.Admin/Login"
-	Admin_Logout_FullMethodName      = "/api.opspillar.v1.Admin/Logout"
+	Admin_CreateUsers_FullMethodName              = "/api.opspillar.v1.Admin/CreateUsers"
+	Admin_UpdateUsers_FullMethodName              = "/api.opspillar.v1.Admin/UpdateUsers"
+	Admin_DeleteUsers_FullMethodName              = "/api.opspillar.v1.Admin/DeleteUsers"
+	Admin_GetUsers_FullMethodName                 = "/api.opspillar.v1.Admin/GetUsers"
+	Admin_ListUsers_FullMethodName                = "/api.opspillar.v1.Admin/ListUsers"
+	Admin_Login_FullMethodName                    = "/api.opspillar.v1.Admin/Login"
+	Admin_Logout_FullMethodName                   = "/api.opspillar.v1.Admin/Logout"
+	Admin_RefreshToken_FullMethodName             = "/api.opspillar.v1.Admin/RefreshToken"
+	Admin_ListSessions_FullMethodName             = "/api.opspillar.v1.Admin/ListSessions"
+	Admin_SSOAuthURL_FullMethodName               = "/api.opspillar.v1.Admin/SSOAuthURL"
+	Admin_SSOLogin_FullMethodName                 = "/api.opspillar.v1.Admin/SSOLogin"
+	Admin_RevokeSessions_FullMethodName           = "/api.opspillar.v1.Admin/RevokeSessions"
+	Admin_ChangePassword_FullMethodName           = "/api.opspillar.v1.Admin/ChangePassword"
+	Admin_CreatePasswordResetToken_FullMethodName = "/api.opspillar.v1.Admin/CreatePasswordResetToken"
+	Admin_ResetPassword_FullMethodName            = "/api.opspillar.v1.Admin/ResetPassword"
+	Admin_EnrollMFA_FullMethodName                = "/api.opspillar.v1.Admin/EnrollMFA"
+	Admin_ConfirmMFA_FullMethodName               = "/api.opspillar.v1.Admin/ConfirmMFA"
+	Admin_DisableMFA_FullMethodName               = "/api.opspillar.v1.Admin/DisableMFA"
 )
 
 // AdminClient is the client API for Admin service.
@@ -39,6 +50,27 @@ type AdminClient interface {
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersReply, error)
 	Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginReply, error)
 	Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutReply, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*RefreshTokenReply, error)
+	ListSessions(ctx context.Context, in *ListSessionsReq, opts ...grpc.CallOption) (*ListSessionsReply, error)
+	// SSOAuthURL starts single sign-on, state, nonce and PKCE are kept by client.
+	SSOAuthURL(ctx context.Context, in *SSOAuthURLReq, opts ...grpc.CallOption) (*SSOAuthURLReply, error)
+	// SSOLogin redeems the authorization code of single sign-on.
+	SSOLogin(ctx context.Context, in *SSOLoginReq, opts ...grpc.CallOption) (*LoginReply, error)
+	// RevokeSessions revokes all tokens of a user issued before now.
+	RevokeSessions(ctx context.Context, in *RevokeSessionsReq, opts ...grpc.CallOption) (*RevokeSessionsReply, error)
+	// ChangePassword changes the password of a local user with the old one, no token is
+	// required so expired passwords can be changed.
+	ChangePassword(ctx context.Context, in *ChangePasswordReq, opts ...grpc.CallOption) (*ChangePasswordReply, error)
+	// CreatePasswordResetToken issues a one-time reset token, admin only.
+	CreatePasswordResetToken(ctx context.Context, in *CreatePasswordResetTokenReq, opts ...grpc.CallOption) (*CreatePasswordResetTokenReply, error)
+	// ResetPassword sets a new password with a reset token and revokes sessions of the user.
+	ResetPassword(ctx context.Context, in *ResetPasswordReq, opts ...grpc.CallOption) (*ResetPasswordReply, error)
+	// EnrollMFA creates a pending TOTP secret and recovery codes of the current user or of
+	// another user by admin, it is enabled by ConfirmMFA or a login with a code of it.
+	EnrollMFA(ctx context.Context, in *EnrollMFAReq, opts ...grpc.CallOption) (*EnrollMFAReply, error)
+	ConfirmMFA(ctx context.Context, in *ConfirmMFAReq, opts ...grpc.CallOption) (*ConfirmMFAReply, error)
+	// DisableMFA disables MFA of the current user with a code, or of another user by admin.
+	DisableMFA(ctx context.Context, in *DisableMFAReq, opts ...grpc.CallOption) (*DisableMFAReply, error)
 }
 
 type adminClient struct {
@@ -119,6 +151,116 @@ func (c *adminClient) Logout(ctx context.Context, in *LogoutReq, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *adminClient) RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*RefreshTokenReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshTokenReply)
+	err := c.cc.Invoke(ctx, Admin_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListSessions(ctx context.Context, in *ListSessionsReq, opts ...grpc.CallOption) (*ListSessionsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsReply)
+	err := c.cc.Invoke(ctx, Admin_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SSOAuthURL(ctx context.Context, in *SSOAuthURLReq, opts ...grpc.CallOption) (*SSOAuthURLReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SSOAuthURLReply)
+	err := c.cc.Invoke(ctx, Admin_SSOAuthURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SSOLogin(ctx context.Context, in *SSOLoginReq, opts ...grpc.CallOption) (*LoginReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, Admin_SSOLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RevokeSessions(ctx context.Context, in *RevokeSessionsReq, opts ...grpc.CallOption) (*RevokeSessionsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSessionsReply)
+	err := c.cc.Invoke(ctx, Admin_RevokeSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ChangePassword(ctx context.Context, in *ChangePasswordReq, opts ...grpc.CallOption) (*ChangePasswordReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordReply)
+	err := c.cc.Invoke(ctx, Admin_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CreatePasswordResetToken(ctx context.Context, in *CreatePasswordResetTokenReq, opts ...grpc.CallOption) (*CreatePasswordResetTokenReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePasswordResetTokenReply)
+	err := c.cc.Invoke(ctx, Admin_CreatePasswordResetToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResetPassword(ctx context.Context, in *ResetPasswordReq, opts ...grpc.CallOption) (*ResetPasswordReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordReply)
+	err := c.cc.Invoke(ctx, Admin_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) EnrollMFA(ctx context.Context, in *EnrollMFAReq, opts ...grpc.CallOption) (*EnrollMFAReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollMFAReply)
+	err := c.cc.Invoke(ctx, Admin_EnrollMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ConfirmMFA(ctx context.Context, in *ConfirmMFAReq, opts ...grpc.CallOption) (*ConfirmMFAReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmMFAReply)
+	err := c.cc.Invoke(ctx, Admin_ConfirmMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DisableMFA(ctx context.Context, in *DisableMFAReq, opts ...grpc.CallOption) (*DisableMFAReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableMFAReply)
+	err := c.cc.Invoke(ctx, Admin_DisableMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 // All implementations must embed UnimplementedAdminServer
 // for forward compatibility.
@@ -130,6 +272,27 @@ type AdminServer interface {
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersReply, error)
 	Login(context.Context, *LoginReq) (*LoginReply, error)
 	Logout(context.Context, *LogoutReq) (*LogoutReply, error)
+	RefreshToken(context.Context, *RefreshTokenReq) (*RefreshTokenReply, error)
+	ListSessions(context.Context, *ListSessionsReq) (*ListSessionsReply, error)
+	// SSOAuthURL starts single sign-on, state, nonce and PKCE are kept by client.
+	SSOAuthURL(context.Context, *SSOAuthURLReq) (*SSOAuthURLReply, error)
+	// SSOLogin redeems the authorization code of single sign-on.
+	SSOLogin(context.Context, *SSOLoginReq) (*LoginReply, error)
+	// RevokeSessions revokes all tokens of a user issued before now.
+	RevokeSessions(context.Context, *RevokeSessionsReq) (*RevokeSessionsReply, error)
+	// ChangePassword changes the password of a local user with the old one, no token is
+	// required so expired passwords can be changed.
+	ChangePassword(context.Context, *ChangePasswordReq) (*ChangePasswordReply, error)
+	// CreatePasswordResetToken issues a one-time reset token, admin only.
+	CreatePasswordResetToken(context.Context, *CreatePasswordResetTokenReq) (*CreatePasswordResetTokenReply, error)
+	// ResetPassword sets a new password with a reset token and revokes sessions of the user.
+	ResetPassword(context.Context, *ResetPasswordReq) (*ResetPasswordReply, error)
+	// EnrollMFA creates a pending TOTP secret and recovery codes of the current user or of
+	// another user by admin, it is enabled by ConfirmMFA or a login with a code of it.
+	EnrollMFA(context.Context, *EnrollMFAReq) (*EnrollMFAReply, error)
+	ConfirmMFA(context.Context, *ConfirmMFAReq) (*ConfirmMFAReply, error)
+	// DisableMFA disables MFA of the current user with a code, or of another user by admin.
+	DisableMFA(context.Context, *DisableMFAReq) (*DisableMFAReply, error)
 	mustEmbedUnimplementedAdminServer()
 }
 
@@ -161,6 +324,39 @@ func (UnimplementedAdminServer) Login(context.Context, *LoginReq) (*LoginReply,
 func (UnimplementedAdminServer) Logout(context.Context, *LogoutReq) (*LogoutReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAdminServer) RefreshToken(context.Context, *RefreshTokenReq) (*RefreshTokenReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedAdminServer) ListSessions(context.Context, *ListSessionsReq) (*ListSessionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAdminServer) SSOAuthURL(context.Context, *SSOAuthURLReq) (*SSOAuthURLReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SSOAuthURL not implemented")
+}
+func (UnimplementedAdminServer) SSOLogin(context.Context, *SSOLoginReq) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SSOLogin not implemented")
+}
+func (UnimplementedAdminServer) RevokeSessions(context.Context, *RevokeSessionsReq) (*RevokeSessionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessions not implemented")
+}
+func (UnimplementedAdminServer) ChangePassword(context.Context, *ChangePasswordReq) (*ChangePasswordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedAdminServer) CreatePasswordResetToken(context.Context, *CreatePasswordResetTokenReq) (*CreatePasswordResetTokenReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePasswordResetToken not implemented")
+}
+func (UnimplementedAdminServer) ResetPassword(context.Context, *ResetPasswordReq) (*ResetPasswordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAdminServer) EnrollMFA(context.Context, *EnrollMFAReq) (*EnrollMFAReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollMFA not implemented")
+}
+func (UnimplementedAdminServer) ConfirmMFA(context.Context, *ConfirmMFAReq) (*ConfirmMFAReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmMFA not implemented")
+}
+func (UnimplementedAdminServer) DisableMFA(context.Context, *DisableMFAReq) (*DisableMFAReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableMFA not implemented")
+}
 func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
 func (UnimplementedAdminServer) testEmbeddedByValue()               {}
 
@@ -308,6 +504,204 @@ func _Admin_Logout_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RefreshToken(ctx, req.(*RefreshTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListSessions(ctx, req.(*ListSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SSOAuthURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SSOAuthURLReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SSOAuthURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SSOAuthURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SSOAuthURL(ctx, req.(*SSOAuthURLReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SSOLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SSOLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SSOLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SSOLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SSOLogin(ctx, req.(*SSOLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RevokeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RevokeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RevokeSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RevokeSessions(ctx, req.(*RevokeSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ChangePassword(ctx, req.(*ChangePasswordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CreatePasswordResetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePasswordResetTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreatePasswordResetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_CreatePasswordResetToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreatePasswordResetToken(ctx, req.(*CreatePasswordResetTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResetPassword(ctx, req.(*ResetPasswordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_EnrollMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollMFAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).EnrollMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_EnrollMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).EnrollMFA(ctx, req.(*EnrollMFAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ConfirmMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmMFAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ConfirmMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ConfirmMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ConfirmMFA(ctx, req.(*ConfirmMFAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DisableMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableMFAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DisableMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DisableMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DisableMFA(ctx, req.(*DisableMFAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +737,50 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _Admin_Logout_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _Admin_RefreshToken_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Admin_ListSessions_Handler,
+		},
+		{
+			MethodName: "SSOAuthURL",
+			Handler:    _Admin_SSOAuthURL_Handler,
+		},
+		{
+			MethodName: "SSOLogin",
+			Handler:    _Admin_SSOLogin_Handler,
+		},
+		{
+			MethodName: "RevokeSessions",
+			Handler:    _Admin_RevokeSessions_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _Admin_ChangePassword_Handler,
+		},
+		{
+			MethodName: "CreatePasswordResetToken",
+			Handler:    _Admin_CreatePasswordResetToken_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _Admin_ResetPassword_Handler,
+		},
+		{
+			MethodName: "EnrollMFA",
+			Handler:    _Admin_EnrollMFA_Handler,
+		},
+		{
+			MethodName: "ConfirmMFA",
+			Handler:    _Admin_ConfirmMFA_Handler,
+		},
+		{
+			MethodName: "DisableMFA",
+			Handler:    _Admin_DisableMFA_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "opspillar/v1/admin.proto",
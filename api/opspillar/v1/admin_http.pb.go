@@ -19,21 +19,53 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationAdminChangePassword = "/api.opspillar.v1.Admin/ChangePassword"
+const OperationAdminConfirmMFA = "/api.opspillar.v1.Admin/ConfirmMFA"
+const OperationAdminCreatePasswordResetToken = "/api.opspillar.v1.Admin/CreatePasswordResetToken"
 const OperationAdminCreateUsers = "/api.opspillar.v1.Admin/CreateUsers"
 const OperationAdminDeleteUsers = "/api.opspillar.v1.Admin/DeleteUsers"
+const OperationAdminDisableMFA = "/api.opspillar.v1.Admin/DisableMFA"
+const OperationAdminEnrollMFA = "/api.opspillar.v1.Admin/EnrollMFA"
 const OperationAdminGetUsers = "/api.opspillar.v1.Admin/GetUsers"
+const OperationAdminListSessions = "/api.opspillar.v1.Admin/ListSessions"
 const OperationAdminListUsers = "/api.opspillar.v1.Admin/ListUsers"
 const OperationAdminLogin = "/api.opspillar.v1.Admin/Login"
 const OperationAdminLogout = "/api.opspillar.v1.Admin/Logout"
+const OperationAdminRefreshToken = "/api.opspillar.v1.Admin/RefreshToken"
+const OperationAdminResetPassword = "/api.opspillar.v1.Admin/ResetPassword"
+const OperationAdminRevokeSessions = "/api.opspillar.v1.Admin/RevokeSessions"
+const OperationAdminSSOAuthURL = "/api.opspillar.v1.Admin/SSOAuthURL"
+const OperationAdminSSOLogin = "/api.opspillar.v1.Admin/SSOLogin"
 const OperationAdminUpdateUsers = "/api.opspillar.v1.Admin/UpdateUsers"
 
 type AdminHTTPServer interface {
+	// ChangePassword ChangePassword changes the password of a local user with the old one, no token is
+	// required so expired passwords can be changed.
+	ChangePassword(context.Context, *ChangePasswordReq) (*ChangePasswordReply, error)
+	ConfirmMFA(context.Context, *ConfirmMFAReq) (*ConfirmMFAReply, error)
+	// CreatePasswordResetToken CreatePasswordResetToken issues a one-time reset token, admin only.
+	CreatePasswordResetToken(context.Context, *CreatePasswordResetTokenReq) (*CreatePasswordResetTokenReply, error)
 	CreateUsers(context.Context, *CreateUsersRequest) (*CreateUsersReply, error)
 	DeleteUsers(context.Context, *DeleteUsersRequest) (*DeleteUsersReply, error)
+	// DisableMFA DisableMFA disables MFA of the current user with a code, or of another user by admin.
+	DisableMFA(context.Context, *DisableMFAReq) (*DisableMFAReply, error)
+	// EnrollMFA EnrollMFA creates a pending TOTP secret and recovery codes of the current user or of
+	// another user by admin, it is enabled by ConfirmMFA or a login with a code of it.
+	EnrollMFA(context.Context, *EnrollMFAReq) (*EnrollMFAReply, error)
 	GetUsers(context.Context, *GetUsersRequest) (*GetUsersReply, error)
+	ListSessions(context.Context, *ListSessionsReq) (*ListSessionsReply, error)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersReply, error)
 	Login(context.Context, *LoginReq) (*LoginReply, error)
 	Logout(context.Context, *LogoutReq) (*LogoutReply, error)
+	RefreshToken(context.Context, *RefreshTokenReq) (*RefreshTokenReply, error)
+	// ResetPassword ResetPassword sets a new password with a reset token and revokes sessions of the user.
+	ResetPassword(context.Context, *ResetPasswordReq) (*ResetPasswordReply, error)
+	// RevokeSessions RevokeSessions revokes all tokens of a user issued before now.
+	RevokeSessions(context.Context, *RevokeSessionsReq) (*RevokeSessionsReply, error)
+	// SSOAuthURL SSOAuthURL starts single sign-on, state, nonce and PKCE are kept by client.
+	SSOAuthURL(context.Context, *SSOAuthURLReq) (*SSOAuthURLReply, error)
+	// SSOLogin SSOLogin redeems the authorization code of single sign-on.
+	SSOLogin(context.Context, *SSOLoginReq) (*LoginReply, error)
 	UpdateUsers(context.Context, *UpdateUsersRequest) (*UpdateUsersReply, error)
 }
 
@@ -46,6 +78,17 @@ func RegisterAdminHTTPServer(s *http.Server, srv AdminHTTPServer) {
 	r.POST("/api/v1/users/list", _Admin_ListUsers0_HTTP_Handler(srv))
 	r.POST("/api/v1/users/login", _Admin_Login0_HTTP_Handler(srv))
 	r.POST("/api/v1/users/logout", _Admin_Logout0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/refresh", _Admin_RefreshToken0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/sessions", _Admin_ListSessions0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/sso/auth-url", _Admin_SSOAuthURL0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/sso/login", _Admin_SSOLogin0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/revoke-sessions", _Admin_RevokeSessions0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/password", _Admin_ChangePassword0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/password/reset-token", _Admin_CreatePasswordResetToken0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/password/reset", _Admin_ResetPassword0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/mfa/enroll", _Admin_EnrollMFA0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/mfa/confirm", _Admin_ConfirmMFA0_HTTP_Handler(srv))
+	r.POST("/api/v1/users/mfa/disable", _Admin_DisableMFA0_HTTP_Handler(srv))
 }
 
 func _Admin_CreateUsers0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
@@ -202,13 +245,266 @@ func _Admin_Logout0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) err
 	}
 }
 
+func _Admin_RefreshToken0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RefreshTokenReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminRefreshToken)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RefreshToken(ctx, req.(*RefreshTokenReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RefreshTokenReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_ListSessions0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListSessionsReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminListSessions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListSessions(ctx, req.(*ListSessionsReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListSessionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_SSOAuthURL0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SSOAuthURLReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminSSOAuthURL)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SSOAuthURL(ctx, req.(*SSOAuthURLReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*SSOAuthURLReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_SSOLogin0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in SSOLoginReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminSSOLogin)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.SSOLogin(ctx, req.(*SSOLoginReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*LoginReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_RevokeSessions0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RevokeSessionsReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminRevokeSessions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RevokeSessions(ctx, req.(*RevokeSessionsReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RevokeSessionsReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_ChangePassword0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ChangePasswordReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminChangePassword)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ChangePassword(ctx, req.(*ChangePasswordReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ChangePasswordReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_CreatePasswordResetToken0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreatePasswordResetTokenReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminCreatePasswordResetToken)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreatePasswordResetToken(ctx, req.(*CreatePasswordResetTokenReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreatePasswordResetTokenReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_ResetPassword0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ResetPasswordReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminResetPassword)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResetPassword(ctx, req.(*ResetPasswordReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ResetPasswordReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_EnrollMFA0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in EnrollMFAReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminEnrollMFA)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.EnrollMFA(ctx, req.(*EnrollMFAReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*EnrollMFAReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_ConfirmMFA0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ConfirmMFAReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminConfirmMFA)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ConfirmMFA(ctx, req.(*ConfirmMFAReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ConfirmMFAReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Admin_DisableMFA0_HTTP_Handler(srv AdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DisableMFAReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationAdminDisableMFA)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DisableMFA(ctx, req.(*DisableMFAReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DisableMFAReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type AdminHTTPClient interface {
+	ChangePassword(ctx context.Context, req *ChangePasswordReq, opts ...http.CallOption) (rsp *ChangePasswordReply, err error)
+	ConfirmMFA(ctx context.Context, req *ConfirmMFAReq, opts ...http.CallOption) (rsp *ConfirmMFAReply, err error)
+	CreatePasswordResetToken(ctx context.Context, req *CreatePasswordResetTokenReq, opts ...http.CallOption) (rsp *CreatePasswordResetTokenReply, err error)
 	CreateUsers(ctx context.Context, req *CreateUsersRequest, opts ...http.CallOption) (rsp *CreateUsersReply, err error)
 	DeleteUsers(ctx context.Context, req *DeleteUsersRequest, opts ...http.CallOption) (rsp *DeleteUsersReply, err error)
+	DisableMFA(ctx context.Context, req *DisableMFAReq, opts ...http.CallOption) (rsp *DisableMFAReply, err error)
+	EnrollMFA(ctx context.Context, req *EnrollMFAReq, opts ...http.CallOption) (rsp *EnrollMFAReply, err error)
 	GetUsers(ctx context.Context, req *GetUsersRequest, opts ...http.CallOption) (rsp *GetUsersReply, err error)
+	ListSessions(ctx context.Context, req *ListSessionsReq, opts ...http.CallOption) (rsp *ListSessionsReply, err error)
 	ListUsers(ctx context.Context, req *ListUsersRequest, opts ...http.CallOption) (rsp *ListUsersReply, err error)
 	Login(ctx context.Context, req *LoginReq, opts ...http.CallOption) (rsp *LoginReply, err error)
 	Logout(ctx context.Context, req *LogoutReq, opts ...http.CallOption) (rsp *LogoutReply, err error)
+	RefreshToken(ctx context.Context, req *RefreshTokenReq, opts ...http.CallOption) (rsp *RefreshTokenReply, err error)
+	ResetPassword(ctx context.Context, req *ResetPasswordReq, opts ...http.CallOption) (rsp *ResetPasswordReply, err error)
+	RevokeSessions(ctx context.Context, req *RevokeSessionsReq, opts ...http.CallOption) (rsp *RevokeSessionsReply, err error)
+	SSOAuthURL(ctx context.Context, req *SSOAuthURLReq, opts ...http.CallOption) (rsp *SSOAuthURLReply, err error)
+	SSOLogin(ctx context.Context, req *SSOLoginReq, opts ...http.CallOption) (rsp *LoginReply, err error)
 	UpdateUsers(ctx context.Context, req *UpdateUsersRequest, opts ...http.CallOption) (rsp *UpdateUsersReply, err error)
 }
 
@@ -220,6 +516,45 @@ func NewAdminHTTPClient(client *http.Client) AdminHTTPClient {
 	return &AdminHTTPClientImpl{client}
 }
 
+func (c *AdminHTTPClientImpl) ChangePassword(ctx context.Context, in *ChangePasswordReq, opts ...http.CallOption) (*ChangePasswordReply, error) {
+	var out ChangePasswordReply
+	pattern := "/api/v1/users/password"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminChangePassword))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) ConfirmMFA(ctx context.Context, in *ConfirmMFAReq, opts ...http.CallOption) (*ConfirmMFAReply, error) {
+	var out ConfirmMFAReply
+	pattern := "/api/v1/users/mfa/confirm"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminConfirmMFA))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) CreatePasswordResetToken(ctx context.Context, in *CreatePasswordResetTokenReq, opts ...http.CallOption) (*CreatePasswordResetTokenReply, error) {
+	var out CreatePasswordResetTokenReply
+	pattern := "/api/v1/users/password/reset-token"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminCreatePasswordResetToken))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *AdminHTTPClientImpl) CreateUsers(ctx context.Context, in *CreateUsersRequest, opts ...http.CallOption) (*CreateUsersReply, error) {
 	var out CreateUsersReply
 	pattern := "/api/v1/users/create"
@@ -246,6 +581,32 @@ func (c *AdminHTTPClientImpl) DeleteUsers(ctx context.Context, in *DeleteUsersRe
 	return &out, nil
 }
 
+func (c *AdminHTTPClientImpl) DisableMFA(ctx context.Context, in *DisableMFAReq, opts ...http.CallOption) (*DisableMFAReply, error) {
+	var out DisableMFAReply
+	pattern := "/api/v1/users/mfa/disable"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminDisableMFA))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) EnrollMFA(ctx context.Context, in *EnrollMFAReq, opts ...http.CallOption) (*EnrollMFAReply, error) {
+	var out EnrollMFAReply
+	pattern := "/api/v1/users/mfa/enroll"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminEnrollMFA))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *AdminHTTPClientImpl) GetUsers(ctx context.Context, in *GetUsersRequest, opts ...http.CallOption) (*GetUsersReply, error) {
 	var out GetUsersReply
 	pattern := "/api/v1/users/{id}"
@@ -259,6 +620,19 @@ func (c *AdminHTTPClientImpl) GetUsers(ctx context.Context, in *GetUsersRequest,
 	return &out, nil
 }
 
+func (c *AdminHTTPClientImpl) ListSessions(ctx context.Context, in *ListSessionsReq, opts ...http.CallOption) (*ListSessionsReply, error) {
+	var out ListSessionsReply
+	pattern := "/api/v1/users/sessions"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminListSessions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *AdminHTTPClientImpl) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...http.CallOption) (*ListUsersReply, error) {
 	var out ListUsersReply
 	pattern := "/api/v1/users/list"
@@ -298,6 +672,71 @@ func (c *AdminHTTPClientImpl) Logout(ctx context.Context, in *LogoutReq, opts ..
 	return &out, nil
 }
 
+func (c *AdminHTTPClientImpl) RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...http.CallOption) (*RefreshTokenReply, error) {
+	var out RefreshTokenReply
+	pattern := "/api/v1/users/refresh"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminRefreshToken))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) ResetPassword(ctx context.Context, in *ResetPasswordReq, opts ...http.CallOption) (*ResetPasswordReply, error) {
+	var out ResetPasswordReply
+	pattern := "/api/v1/users/password/reset"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminResetPassword))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) RevokeSessions(ctx context.Context, in *RevokeSessionsReq, opts ...http.CallOption) (*RevokeSessionsReply, error) {
+	var out RevokeSessionsReply
+	pattern := "/api/v1/users/revoke-sessions"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminRevokeSessions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) SSOAuthURL(ctx context.Context, in *SSOAuthURLReq, opts ...http.CallOption) (*SSOAuthURLReply, error) {
+	var out SSOAuthURLReply
+	pattern := "/api/v1/users/sso/auth-url"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminSSOAuthURL))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *AdminHTTPClientImpl) SSOLogin(ctx context.Context, in *SSOLoginReq, opts ...http.CallOption) (*LoginReply, error) {
+	var out LoginReply
+	pattern := "/api/v1/users/sso/login"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationAdminSSOLogin))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *AdminHTTPClientImpl) UpdateUsers(ctx context.Context, in *UpdateUsersRequest, opts ...http.CallOption) (*UpdateUsersReply, error) {
 	var out UpdateUsersReply
 	pattern := "/api/v1/users/update"
@@ -19,11 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Teams_CreateTeams_FullMethodName = "/api.opspillar.v1.Teams/CreateTeams"
-	Teams_UpdateTeams_FullMethodName = "/api.opspillar.v1.Teams/UpdateTeams"
-	Teams_DeleteTeams_FullMethodName = "/api.opspillar.v1.Teams/DeleteTeams"
-	Teams_GetTeams_FullMethodName    = "/api.opspillar.v1.Teams/GetTeams"
-	Teams_ListTeams_FullMethodName   = "/api.opspillar.v1.Teams/ListTeams"
+	Teams_CreateTeams_FullMethodName       = "/api.opspillar.v1.Teams/CreateTeams"
+	Teams_UpdateTeams_FullMethodName       = "/api.opspillar.v1.Teams/UpdateTeams"
+	Teams_DeleteTeams_FullMethodName       = "/api.opspillar.v1.Teams/DeleteTeams"
+	Teams_GetTeams_FullMethodName          = "/api.opspillar.v1.Teams/GetTeams"
+	Teams_ListTeams_FullMethodName         = "/api.opspillar.v1.Teams/ListTeams"
+	Teams_ListTeamMembers_FullMethodName   = "/api.opspillar.v1.Teams/ListTeamMembers"
+	Teams_AddTeamMembers_FullMethodName    = "/api.opspillar.v1.Teams/AddTeamMembers"
+	Teams_RemoveTeamMembers_FullMethodName = "/api.opspillar.v1.Teams/RemoveTeamMembers"
 )
 
 // TeamsClient is the client API for Teams service.
@@ -35,6 +38,12 @@ type TeamsClient interface {
 	DeleteTeams(ctx context.Context, in *DeleteTeamsRequest, opts ...grpc.CallOption) (*DeleteTeamsReply, error)
 	GetTeams(ctx context.Context, in *GetTeamsRequest, opts ...grpc.CallOption) (*GetTeamsReply, error)
 	ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...grpc.CallOption) (*ListTeamsReply, error)
+	ListTeamMembers(ctx context.Context, in *ListTeamMembersRequest, opts ...grpc.CallOption) (*ListTeamMembersReply, error)
+	// AddTeamMembers adds members or maintainers, or changes their roles.
+	// leader, maintainers and admins can manage members.
+	AddTeamMembers(ctx context.Context, in *AddTeamMembersRequest, opts ...grpc.CallOption) (*AddTeamMembersReply, error)
+	// RemoveTeamMembers can not remove the leader.
+	RemoveTeamMembers(ctx context.Context, in *RemoveTeamMembersRequest, opts ...grpc.CallOption) (*RemoveTeamMembersReply, error)
 }
 
 type teamsClient struct {
@@ -95,6 +104,36 @@ func (c *teamsClient) ListTeams(ctx context.Context, in *ListTeamsRequest, opts
 	return out, nil
 }
 
+func (c *teamsClient) ListTeamMembers(ctx context.Context, in *ListTeamMembersRequest, opts ...grpc.CallOption) (*ListTeamMembersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTeamMembersReply)
+	err := c.cc.Invoke(ctx, Teams_ListTeamMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamsClient) AddTeamMembers(ctx context.Context, in *AddTeamMembersRequest, opts ...grpc.CallOption) (*AddTeamMembersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTeamMembersReply)
+	err := c.cc.Invoke(ctx, Teams_AddTeamMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamsClient) RemoveTeamMembers(ctx context.Context, in *RemoveTeamMembersRequest, opts ...grpc.CallOption) (*RemoveTeamMembersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTeamMembersReply)
+	err := c.cc.Invoke(ctx, Teams_RemoveTeamMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TeamsServer is the server API for Teams service.
 // All implementations must embed UnimplementedTeamsServer
 // for forward compatibility.
@@ -104,6 +143,12 @@ type TeamsServer interface {
 	DeleteTeams(context.Context, *DeleteTeamsRequest) (*DeleteTeamsReply, error)
 	GetTeams(context.Context, *GetTeamsRequest) (*GetTeamsReply, error)
 	ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsReply, error)
+	ListTeamMembers(context.Context, *ListTeamMembersRequest) (*ListTeamMembersReply, error)
+	// AddTeamMembers adds members or maintainers, or changes their roles.
+	// leader, maintainers and admins can manage members.
+	AddTeamMembers(context.Context, *AddTeamMembersRequest) (*AddTeamMembersReply, error)
+	// RemoveTeamMembers can not remove the leader.
+	RemoveTeamMembers(context.Context, *RemoveTeamMembersRequest) (*RemoveTeamMembersReply, error)
 	mustEmbedUnimplementedTeamsServer()
 }
 
@@ -129,6 +174,15 @@ func (UnimplementedTeamsServer) GetTeams(context.Context, *GetTeamsRequest) (*Ge
 func (UnimplementedTeamsServer) ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTeams not implemented")
 }
+func (UnimplementedTeamsServer) ListTeamMembers(context.Context, *ListTeamMembersRequest) (*ListTeamMembersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTeamMembers not implemented")
+}
+func (UnimplementedTeamsServer) AddTeamMembers(context.Context, *AddTeamMembersRequest) (*AddTeamMembersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTeamMembers not implemented")
+}
+func (UnimplementedTeamsServer) RemoveTeamMembers(context.Context, *RemoveTeamMembersRequest) (*RemoveTeamMembersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTeamMembers not implemented")
+}
 func (UnimplementedTeamsServer) mustEmbedUnimplementedTeamsServer() {}
 func (UnimplementedTeamsServer) testEmbeddedByValue()               {}
 
@@ -240,6 +294,60 @@ func _Teams_ListTeams_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Teams_ListTeamMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTeamMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamsServer).ListTeamMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Teams_ListTeamMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamsServer).ListTeamMembers(ctx, req.(*ListTeamMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Teams_AddTeamMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTeamMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamsServer).AddTeamMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Teams_AddTeamMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamsServer).AddTeamMembers(ctx, req.(*AddTeamMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Teams_RemoveTeamMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTeamMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamsServer).RemoveTeamMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Teams_RemoveTeamMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamsServer).RemoveTeamMembers(ctx, req.(*RemoveTeamMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Teams_ServiceDesc is the grpc.ServiceDesc for Teams service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +375,18 @@ var Teams_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTeams",
 			Handler:    _Teams_ListTeams_Handler,
 		},
+		{
+			MethodName: "ListTeamMembers",
+			Handler:    _Teams_ListTeamMembers_Handler,
+		},
+		{
+			MethodName: "AddTeamMembers",
+			Handler:    _Teams_AddTeamMembers_Handler,
+		},
+		{
+			MethodName: "RemoveTeamMembers",
+			Handler:    _Teams_RemoveTeamMembers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "opspillar/v1/teams.proto",
@@ -19,17 +19,26 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationTeamsAddTeamMembers = "/api.opspillar.v1.Teams/AddTeamMembers"
 const OperationTeamsCreateTeams = "/api.opspillar.v1.Teams/CreateTeams"
 const OperationTeamsDeleteTeams = "/api.opspillar.v1.Teams/DeleteTeams"
 const OperationTeamsGetTeams = "/api.opspillar.v1.Teams/GetTeams"
+const OperationTeamsListTeamMembers = "/api.opspillar.v1.Teams/ListTeamMembers"
 const OperationTeamsListTeams = "/api.opspillar.v1.Teams/ListTeams"
+const OperationTeamsRemoveTeamMembers = "/api.opspillar.v1.Teams/RemoveTeamMembers"
 const OperationTeamsUpdateTeams = "/api.opspillar.v1.Teams/UpdateTeams"
 
 type TeamsHTTPServer interface {
+	// AddTeamMembers AddTeamMembers adds members or maintainers, or changes their roles.
+	// leader, maintainers and admins can manage members.
+	AddTeamMembers(context.Context, *AddTeamMembersRequest) (*AddTeamMembersReply, error)
 	CreateTeams(context.Context, *CreateTeamsRequest) (*CreateTeamsReply, error)
 	DeleteTeams(context.Context, *DeleteTeamsRequest) (*DeleteTeamsReply, error)
 	GetTeams(context.Context, *GetTeamsRequest) (*GetTeamsReply, error)
+	ListTeamMembers(context.Context, *ListTeamMembersRequest) (*ListTeamMembersReply, error)
 	ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsReply, error)
+	// RemoveTeamMembers RemoveTeamMembers can not remove the leader.
+	RemoveTeamMembers(context.Context, *RemoveTeamMembersRequest) (*RemoveTeamMembersReply, error)
 	UpdateTeams(context.Context, *UpdateTeamsRequest) (*UpdateTeamsReply, error)
 }
 
@@ -40,6 +49,9 @@ func RegisterTeamsHTTPServer(s *http.Server, srv TeamsHTTPServer) {
 	r.POST("/api/v1/teams/delete", _Teams_DeleteTeams0_HTTP_Handler(srv))
 	r.GET("/api/v1/teams/{id}", _Teams_GetTeams0_HTTP_Handler(srv))
 	r.POST("/api/v1/teams/list", _Teams_ListTeams0_HTTP_Handler(srv))
+	r.POST("/api/v1/teams/members/list", _Teams_ListTeamMembers0_HTTP_Handler(srv))
+	r.POST("/api/v1/teams/members/add", _Teams_AddTeamMembers0_HTTP_Handler(srv))
+	r.POST("/api/v1/teams/members/remove", _Teams_RemoveTeamMembers0_HTTP_Handler(srv))
 }
 
 func _Teams_CreateTeams0_HTTP_Handler(srv TeamsHTTPServer) func(ctx http.Context) error {
@@ -152,11 +164,80 @@ func _Teams_ListTeams0_HTTP_Handler(srv TeamsHTTPServer) func(ctx http.Context)
 	}
 }
 
+func _Teams_ListTeamMembers0_HTTP_Handler(srv TeamsHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListTeamMembersRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationTeamsListTeamMembers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListTeamMembers(ctx, req.(*ListTeamMembersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListTeamMembersReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Teams_AddTeamMembers0_HTTP_Handler(srv TeamsHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AddTeamMembersRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationTeamsAddTeamMembers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AddTeamMembers(ctx, req.(*AddTeamMembersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AddTeamMembersReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Teams_RemoveTeamMembers0_HTTP_Handler(srv TeamsHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RemoveTeamMembersRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationTeamsRemoveTeamMembers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RemoveTeamMembers(ctx, req.(*RemoveTeamMembersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RemoveTeamMembersReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type TeamsHTTPClient interface {
+	AddTeamMembers(ctx context.Context, req *AddTeamMembersRequest, opts ...http.CallOption) (rsp *AddTeamMembersReply, err error)
 	CreateTeams(ctx context.Context, req *CreateTeamsRequest, opts ...http.CallOption) (rsp *CreateTeamsReply, err error)
 	DeleteTeams(ctx context.Context, req *DeleteTeamsRequest, opts ...http.CallOption) (rsp *DeleteTeamsReply, err error)
 	GetTeams(ctx context.Context, req *GetTeamsRequest, opts ...http.CallOption) (rsp *GetTeamsReply, err error)
+	ListTeamMembers(ctx context.Context, req *ListTeamMembersRequest, opts ...http.CallOption) (rsp *ListTeamMembersReply, err error)
 	ListTeams(ctx context.Context, req *ListTeamsRequest, opts ...http.CallOption) (rsp *ListTeamsReply, err error)
+	RemoveTeamMembers(ctx context.Context, req *RemoveTeamMembersRequest, opts ...http.CallOption) (rsp *RemoveTeamMembersReply, err error)
 	UpdateTeams(ctx context.Context, req *UpdateTeamsRequest, opts ...http.CallOption) (rsp *UpdateTeamsReply, err error)
 }
 
@@ -168,6 +249,19 @@ func NewTeamsHTTPClient(client *http.Client) TeamsHTTPClient {
 	return &TeamsHTTPClientImpl{client}
 }
 
+func (c *TeamsHTTPClientImpl) AddTeamMembers(ctx context.Context, in *AddTeamMembersRequest, opts ...http.CallOption) (*AddTeamMembersReply, error) {
+	var out AddTeamMembersReply
+	pattern := "/api/v1/teams/members/add"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationTeamsAddTeamMembers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *TeamsHTTPClientImpl) CreateTeams(ctx context.Context, in *CreateTeamsRequest, opts ...http.CallOption) (*CreateTeamsReply, error) {
 	var out CreateTeamsReply
 	pattern := "/api/v1/teams/create"
@@ -207,6 +301,19 @@ func (c *TeamsHTTPClientImpl) GetTeams(ctx context.Context, in *GetTeamsRequest,
 	return &out, nil
 }
 
+func (c *TeamsHTTPClientImpl) ListTeamMembers(ctx context.Context, in *ListTeamMembersRequest, opts ...http.CallOption) (*ListTeamMembersReply, error) {
+	var out ListTeamMembersReply
+	pattern := "/api/v1/teams/members/list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationTeamsListTeamMembers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *TeamsHTTPClientImpl) ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...http.CallOption) (*ListTeamsReply, error) {
 	var out ListTeamsReply
 	pattern := "/api/v1/teams/list"
@@ -220,6 +327,19 @@ func (c *TeamsHTTPClientImpl) ListTeams(ctx context.Context, in *ListTeamsReques
 	return &out, nil
 }
 
+func (c *TeamsHTTPClientImpl) RemoveTeamMembers(ctx context.Context, in *RemoveTeamMembersRequest, opts ...http.CallOption) (*RemoveTeamMembersReply, error) {
+	var out RemoveTeamMembersReply
+	pattern := "/api/v1/teams/members/remove"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationTeamsRemoveTeamMembers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *TeamsHTTPClientImpl) UpdateTeams(ctx context.Context, in *UpdateTeamsRequest, opts ...http.CallOption) (*UpdateTeamsReply, error) {
 	var out UpdateTeamsReply
 	pattern := "/api/v1/teams/update"
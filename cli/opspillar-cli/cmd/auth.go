@@ -0,0 +1,35 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage authorization rules and groups",
+	Long: `Manage casbin rules and user to role groups, admin only except can-i.
+A rule grants an action on resources v1/{org}/{type}/{team}/{instance}/{user} to a subject,
+an omitted section matches any value. Team scoped resources of the default organization
+are v1/default/{type}/{team}/{instance}/{user}.
+
+Example:
+  opspillar auth rules --sub dev-team
+  opspillar auth grant --sub dev-team --type applications --team dev-team
+  opspillar auth add-group --user alice --role dev-team
+  opspillar auth audits
+  opspillar auth can-i write hostgroups/web-01`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// authAuditsCmd represents the auth audits command
+var authAuditsCmd = &cobra.Command{
+	Use:   "audits",
+	Short: "List changes of rules and groups",
+	Long: `List changes of rules and groups, newest first.
+For example:
+  opspillar auth audits
+  opspillar auth audits --sub dev-team --operator admin`,
+	Run: func(cmd *cobra.Command, args []string) {
+		subs, _ := cmd.Flags().GetStringSlice("sub")
+		operators, _ := cmd.Flags().GetStringSlice("operator")
+		page, _ := cmd.Flags().GetUint32("page")
+		pageSize, _ := cmd.Flags().GetUint32("page-size")
+		pageToken, _ := cmd.Flags().GetString("page-token")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.ListAuthzAudits(ctx, &pb.ListAuthzAuditsRequest{
+			Page:      page,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+			Subs:      subs,
+			Operators: operators,
+		})
+		if err != nil {
+			log.Fatalf("failed to list audits: %v", err)
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", reply.Message)
+			fmt.Printf("  Code: %d\n", reply.Code)
+			fmt.Printf("  Action: %s\n", reply.Action)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Time", "Operator", "Operation", "Subject", "Resource", "Action", "Role"})
+		table.SetAutoFormatHeaders(true)
+		for _, a := range reply.Audits {
+			table.Append([]string{
+				time.Unix(a.CreatedAt, 0).Format(time.DateTime),
+				a.Operator,
+				a.Operation,
+				a.Sub,
+				a.Resource,
+				a.Action,
+				a.Role,
+			})
+		}
+		table.Render()
+		if reply.NextPageToken != "" {
+			fmt.Printf("more audits: --page-token %s\n", reply.NextPageToken)
+		}
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authAuditsCmd)
+	authAuditsCmd.Flags().StringSlice("sub", []string{}, "Filter by subjects (comma-separated)")
+	authAuditsCmd.Flags().StringSlice("operator", []string{}, "Filter by operators (comma-separated)")
+	authAuditsCmd.Flags().Uint32("page", 1, "Page number")
+	authAuditsCmd.Flags().Uint32("page-size", 50, "Page size")
+	authAuditsCmd.Flags().String("page-token", "", "Continue after the page of a previous listing")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// authCanICmd represents the auth can-i command
+var authCanICmd = &cobra.Command{
+	Use:   "can-i <action> <kind>[/<name>]",
+	Short: "Check whether an action is allowed",
+	Long: `Check an action on a resource as the server does and show the matched rule
+and the groups granting it. Checking as another subject requires admin.
+For example:
+  opspillar auth can-i write hostgroups/web-01
+  opspillar auth can-i read applications/shop --as alice
+  opspillar auth can-i write envs`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		kind, name, _ := strings.Cut(args[1], "/")
+		as, _ := cmd.Flags().GetString("as")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.CanI(ctx, &pb.CanIRequest{
+			Action: args[0],
+			Kind:   kind,
+			Name:   name,
+			As:     as,
+		})
+		if err != nil {
+			log.Fatalf("failed to check: %v", err)
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+			return
+		}
+		if reply.Allowed {
+			fmt.Println("yes")
+		} else {
+			fmt.Println("no")
+		}
+		fmt.Printf("Subject: %s\n", reply.Sub)
+		fmt.Printf("Resource: %s\n", reply.Resource)
+		if reply.Rule != nil {
+			fmt.Printf("Rule: %s, %s, %s\n", reply.Rule.Sub, reply.Rule.Resource, reply.Rule.Action)
+			fmt.Printf("Via: %s\n", strings.Join(reply.Chain, " -> "))
+		} else {
+			fmt.Println("Rule: none matched")
+		}
+		fmt.Printf("Roles: %s\n", strings.Join(reply.Roles, ", "))
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authCanICmd)
+	authCanICmd.Flags().String("as", "", "Check as a user or sa:{service account}, admin only")
+}
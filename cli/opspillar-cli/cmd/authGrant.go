@@ -0,0 +1,100 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+func ruleFromFlags(cmd *cobra.Command) *pb.AuthzRule {
+	rule := &pb.AuthzRule{}
+	rule.Sub, _ = cmd.Flags().GetString("sub")
+	rule.Org, _ = cmd.Flags().GetString("org")
+	rule.ResType, _ = cmd.Flags().GetString("type")
+	rule.Team, _ = cmd.Flags().GetString("team")
+	rule.ResInst, _ = cmd.Flags().GetString("inst")
+	rule.User, _ = cmd.Flags().GetString("user")
+	rule.Action, _ = cmd.Flags().GetString("action")
+	return rule
+}
+
+func addRuleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("sub", "", "Subject, a user, a role or sa:{service account}")
+	cmd.Flags().String("org", "", "Organization name, any if omitted")
+	cmd.Flags().String("type", "", "Resource type, any if omitted")
+	cmd.Flags().String("team", "", "Team name, any if omitted")
+	cmd.Flags().String("inst", "", "Resource instance, any if omitted")
+	cmd.Flags().String("user", "", "User name, any if omitted")
+	cmd.Flags().String("action", "write", "Action, write or read. write implies read")
+	cmd.MarkFlagRequired("sub")
+}
+
+// authGrantCmd represents the auth grant command
+var authGrantCmd = &cobra.Command{
+	Use:   "grant",
+	Short: "Grant a rule",
+	Long: `Grant an action on resources to a subject.
+For example:
+  opspillar auth grant --sub dev-team --type applications --team dev-team
+  opspillar auth grant --sub sa:deployer --type hostgroups
+  opspillar auth grant --sub deployers --org acme --type hostgroups`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.GrantRules(ctx, &pb.GrantRulesRequest{
+			Rules: []*pb.AuthzRule{ruleFromFlags(cmd)},
+		})
+		if err != nil {
+			log.Fatalf("failed to grant rule: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+// authRevokeCmd represents the auth revoke command
+var authRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke a rule",
+	Long: `Revoke a rule, the sections must be the same as granted.
+The rule of admin-team can not be revoked.
+For example:
+  opspillar auth revoke --sub dev-team --type applications --team dev-team`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.RevokeRules(ctx, &pb.RevokeRulesRequest{
+			Rules: []*pb.AuthzRule{ruleFromFlags(cmd)},
+		})
+		if err != nil {
+			log.Fatalf("failed to revoke rule: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authGrantCmd)
+	authCmd.AddCommand(authRevokeCmd)
+	addRuleFlags(authGrantCmd)
+	addRuleFlags(authRevokeCmd)
+}
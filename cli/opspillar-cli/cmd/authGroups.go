@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// authGroupsCmd represents the auth groups command
+var authGroupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "List groups of users and roles",
+	Long: `List user to role groups, of a user with --user or of a role with --role.
+For example:
+  opspillar auth groups
+  opspillar auth groups --role admin-team`,
+	Run: func(cmd *cobra.Command, args []string) {
+		user, _ := cmd.Flags().GetString("user")
+		role, _ := cmd.Flags().GetString("role")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.ListGroups(ctx, &pb.ListGroupsRequest{User: user, Role: role})
+		if err != nil {
+			log.Fatalf("failed to list groups: %v", err)
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", reply.Message)
+			fmt.Printf("  Code: %d\n", reply.Code)
+			fmt.Printf("  Action: %s\n", reply.Action)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"User", "Role"})
+		table.SetAutoFormatHeaders(true)
+		for _, g := range reply.Groups {
+			table.Append([]string{g.User, g.Role})
+		}
+		table.Render()
+	},
+}
+
+// authAddGroupCmd represents the auth add-group command
+var authAddGroupCmd = &cobra.Command{
+	Use:   "add-group",
+	Short: "Add a user to a role",
+	Long: `Add an existing user or service account to a role with rules or a role of a team,
+team:leader, team:maintainer or team:member. Users join teams by team add-member.
+For example:
+  opspillar auth add-group --user alice --role dev-team:member
+  opspillar auth add-group --user sa:deployer --role deployers`,
+	Run: func(cmd *cobra.Command, args []string) {
+		user, _ := cmd.Flags().GetString("user")
+		role, _ := cmd.Flags().GetString("role")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.AddGroups(ctx, &pb.AddGroupsRequest{
+			Groups: []*pb.AuthzGroup{{User: user, Role: role}},
+		})
+		if err != nil {
+			log.Fatalf("failed to add group: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+// authRemoveGroupCmd represents the auth remove-group command
+var authRemoveGroupCmd = &cobra.Command{
+	Use:   "remove-group",
+	Short: "Remove a user from a role",
+	Long: `Remove a user from a role, admin can not be removed from admin-team.
+For example:
+  opspillar auth remove-group --user alice --role dev-team:member`,
+	Run: func(cmd *cobra.Command, args []string) {
+		user, _ := cmd.Flags().GetString("user")
+		role, _ := cmd.Flags().GetString("role")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.RemoveGroups(ctx, &pb.RemoveGroupsRequest{
+			Groups: []*pb.AuthzGroup{{User: user, Role: role}},
+		})
+		if err != nil {
+			log.Fatalf("failed to remove group: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authGroupsCmd)
+	authCmd.AddCommand(authAddGroupCmd)
+	authCmd.AddCommand(authRemoveGroupCmd)
+	authGroupsCmd.Flags().String("user", "", "Filter by user")
+	authGroupsCmd.Flags().String("role", "", "Filter by role")
+	for _, c := range []*cobra.Command{authAddGroupCmd, authRemoveGroupCmd} {
+		c.Flags().String("user", "", "User name, or sa:{service account}")
+		c.Flags().String("role", "", "Role or role of a team as team:member")
+		c.MarkFlagRequired("user")
+		c.MarkFlagRequired("role")
+	}
+}
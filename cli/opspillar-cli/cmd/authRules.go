@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// authRulesCmd represents the auth rules command
+var authRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List authorization rules",
+	Long: `List casbin rules, of a subject with --sub.
+For example:
+  opspillar auth rules
+  opspillar auth rules --sub admin-team`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sub, _ := cmd.Flags().GetString("sub")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAuthzClient(conn)
+		reply, err := client.ListRules(ctx, &pb.ListRulesRequest{Sub: sub})
+		if err != nil {
+			log.Fatalf("failed to list rules: %v", err)
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", reply.Message)
+			fmt.Printf("  Code: %d\n", reply.Code)
+			fmt.Printf("  Action: %s\n", reply.Action)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Subject", "Resource", "Action"})
+		table.SetAutoFormatHeaders(true)
+		for _, r := range reply.Rules {
+			table.Append([]string{r.Sub, r.Resource, r.Action})
+		}
+		table.Render()
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authRulesCmd)
+	authRulesCmd.Flags().String("sub", "", "Filter by subject")
+}
@@ -4,6 +4,11 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +24,27 @@ var createCmd = &cobra.Command{
 
 var yamlFile string
 var outFile string
+var createPartial bool
+
+// printCommitted prints the ids of the committed items and the errors of the items not
+// committed in partial mode by their index in the request.
+func printCommitted[T interface{ GetId() uint32 }](items []T, errs map[uint32]string) {
+	if len(items) > 0 {
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = strconv.FormatUint(uint64(item.GetId()), 10)
+		}
+		fmt.Printf("Ids: %s\n", strings.Join(ids, ", "))
+	}
+	indexes := make([]uint32, 0, len(errs))
+	for i := range errs {
+		indexes = append(indexes, i)
+	}
+	sort.Slice(indexes, func(a, b int) bool { return indexes[a] < indexes[b] })
+	for _, i := range indexes {
+		fmt.Printf("Error [%d]: %s\n", i, errs[i])
+	}
+}
 
 func init() {
 	rootCmd.AddCommand(createCmd)
@@ -30,6 +56,7 @@ func init() {
 	// createCmd.PersistentFlags().String("foo", "", "A help for foo")
 	createCmd.PersistentFlags().StringVarP(&yamlFile, "yaml", "f", "", "Name of the resource file to create")
 	createCmd.PersistentFlags().StringVarP(&outFile, "out", "o", "", "Name of the template file to create")
+	createCmd.PersistentFlags().BoolVar(&createPartial, "partial", false, "Create the valid resources and report the errors of the others")
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
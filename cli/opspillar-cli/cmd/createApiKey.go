@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// createApiKeyCmd represents the create apikey command
+var createApiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Create an api key of a service account",
+	Long: `Create an api key of a service account. The key is only shown once.
+Scopes are <resource>[:read|write], resource is the api service name such as
+hostgroups or applications, or * for all.
+
+Use the key with OPSPILLAR_API_KEY environment variable, or X-API-Key header.
+
+Examples:
+  opspillar create apikey --sa 1 --name ci-key --scopes "*"
+  opspillar create apikey --sa 1 --name readonly --scopes "*:read" --expire-days 90`,
+	Aliases: []string{"apikeys", "key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewServiceAccountsClient(conn)
+
+		saId, _ := cmd.Flags().GetUint32("sa")
+		name, _ := cmd.Flags().GetString("name")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		desc, _ := cmd.Flags().GetString("desc")
+		expireDays, _ := cmd.Flags().GetUint32("expire-days")
+
+		resp, err := client.CreateApiKey(ctx, &pb.CreateApiKeyRequest{
+			ApiKey: &pb.ApiKey{
+				ServiceAccountId: saId,
+				Name:             name,
+				Scopes:           scopes,
+				Description:      desc,
+				ExpireDays:       expireDays,
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to create api key: %v", err)
+		}
+		if resp.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", resp.Message)
+			fmt.Printf("  Code: %d\n", resp.Code)
+			fmt.Printf("  Action: %s\n", resp.Action)
+			return
+		}
+
+		fmt.Printf("ID: %d\n", resp.ApiKey.Id)
+		fmt.Printf("Key: %s\n", resp.ApiKey.Key)
+		if resp.ApiKey.ExpiresAt > 0 {
+			fmt.Printf("Expires: %s\n", time.Unix(resp.ApiKey.ExpiresAt, 0).Format(time.DateTime))
+		}
+		fmt.Println("Save the key now, it can not be shown again.")
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createApiKeyCmd)
+	createApiKeyCmd.Flags().Uint32("sa", 0, "Service account ID of the api key")
+	createApiKeyCmd.Flags().String("name", "", "Name of the api key")
+	createApiKeyCmd.Flags().StringSlice("scopes", []string{}, "Scopes of the api key (comma-separated)")
+	createApiKeyCmd.Flags().String("desc", "", "Description of the api key")
+	createApiKeyCmd.Flags().Uint32("expire-days", 0, "Days before the api key expires, 0 never expires")
+}
@@ -24,7 +24,8 @@ Application belongs to a product and team.
 
 Examples:
   opspillar create application --name web-app --desc "Web Application" --product 1 --team 1
-  opspillar create application --name api-service --desc "API Service" --product 2 --team 1`,
+  opspillar create application --name api-service --desc "API Service" --product 2 --team 1
+  opspillar create application --name web-app --product-id 1 --team-id 1 --owner-id 1 --attr tier=gold`,
 	Aliases: []string{"application", "applications", "apps"},
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, conn, err := NewConnection(true)
@@ -49,6 +50,7 @@ Examples:
 				FeaturesId:   []uint32{},
 				TagsId:       []uint32{},
 				HostgroupsId: []uint32{},
+				Attributes:   map[string]string{},
 			}
 			apps := []*pb.Application{app}
 
@@ -89,6 +91,7 @@ Examples:
 			uintFeatures, _ := cmd.Flags().GetUintSlice("features-id")
 			uintTags, _ := cmd.Flags().GetUintSlice("tags-id")
 			uintHostgroups, _ := cmd.Flags().GetUintSlice("hostgroups-id")
+			attrs, _ := cmd.Flags().GetStringToString("attr")
 
 			featuresId := toUint32Slice(uintFeatures)
 			tagsId := toUint32Slice(uintTags)
@@ -106,11 +109,14 @@ Examples:
 						FeaturesId:   featuresId,
 						TagsId:       tagsId,
 						HostgroupsId: hostgroupsId,
+						Attributes:   attrs,
 					},
 				},
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateApplications(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create applications: %v", err)
@@ -120,6 +126,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Apps, resp.Errors)
 		}
 	},
 }
@@ -135,4 +142,5 @@ func init() {
 	createApplicationCmd.Flags().UintSlice("features-id", []uint{}, "IDs of features this application requires")
 	createApplicationCmd.Flags().UintSlice("tags-id", []uint{}, "IDs of tags for this application")
 	createApplicationCmd.Flags().UintSlice("hostgroups-id", []uint{}, "IDs of hostgroups for this application")
+	createApplicationCmd.Flags().StringToString("attr", map[string]string{}, "Custom attribute values, name=value")
 }
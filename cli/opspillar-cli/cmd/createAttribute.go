@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// createAttributeCmd represents the createAttribute command
+var createAttributeCmd = &cobra.Command{
+	Use:   "attr",
+	Short: "Create a new custom attribute",
+	Long: `Create a custom attribute of applications or hostgroups, admin only.
+Types are string, int, bool and enum, values are checked when entities are created or updated.
+
+Examples:
+  opspillar create attr --kind applications --name tier --type enum --enum gold,silver --required
+  opspillar create attr --kind hostgroups --name rack --type string --pattern "r[0-9]+"`,
+	Aliases: []string{"attrs", "attribute", "attributes"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		kind, _ := cmd.Flags().GetString("kind")
+		name, _ := cmd.Flags().GetString("name")
+		typ, _ := cmd.Flags().GetString("type")
+		required, _ := cmd.Flags().GetBool("required")
+		enums, _ := cmd.Flags().GetStringSlice("enum")
+		pattern, _ := cmd.Flags().GetString("pattern")
+		desc, _ := cmd.Flags().GetString("desc")
+
+		client := pb.NewAttributesClient(conn)
+		resp, err := client.CreateAttributes(ctx, &pb.CreateAttributesRequest{
+			Attributes: []*pb.Attribute{
+				{
+					Kind:        kind,
+					Name:        name,
+					Type:        typ,
+					Required:    required,
+					EnumValues:  enums,
+					Pattern:     pattern,
+					Description: desc,
+				},
+			},
+			Partial: createPartial,
+		})
+		if err != nil {
+			log.Fatalf("failed to create attributes: %v", err)
+		}
+
+		if resp != nil {
+			fmt.Printf("Code: %d\n", resp.Code)
+			fmt.Printf("Message: %s\n", resp.Message)
+			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Attributes, resp.Errors)
+		}
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createAttributeCmd)
+	createAttributeCmd.Flags().String("kind", "", "Entity kind of the attribute, applications or hostgroups")
+	createAttributeCmd.Flags().String("name", "", "Name of the attribute")
+	createAttributeCmd.Flags().String("type", "string", "Type of the attribute, string, int, bool or enum")
+	createAttributeCmd.Flags().Bool("required", false, "Entities must have the attribute")
+	createAttributeCmd.Flags().StringSlice("enum", []string{}, "Allowed values of enum attributes")
+	createAttributeCmd.Flags().String("pattern", "", "Regular expression the whole value must match")
+	createAttributeCmd.Flags().String("desc", "", "Description of the attribute")
+	createAttributeCmd.MarkFlagRequired("kind")
+	createAttributeCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// createBreakGlassCmd represents the create breakglass command
+var createBreakGlassCmd = &cobra.Command{
+	Use:   "breakglass",
+	Short: "Create a break-glass credential for emergency access",
+	Long: `Create a break-glass credential acting as an existing user. The secret is only shown once.
+A credential must be one-time, or expire some minutes after its first use, or expire in some days.
+Every use of the credential is audited, see "opspillar get breakglass --audit".
+
+Use the secret with OPSPILLAR_BREAK_GLASS environment variable, or X-Break-Glass header.
+
+Examples:
+  opspillar create breakglass --name db-outage --principal admin --one-time
+  opspillar create breakglass --name oncall --principal sre --expire-minutes 60 --expire-days 30`,
+	Aliases: []string{"bg"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewBreakGlassClient(conn)
+
+		name, _ := cmd.Flags().GetString("name")
+		principal, _ := cmd.Flags().GetString("principal")
+		oneTime, _ := cmd.Flags().GetBool("one-time")
+		expireMinutes, _ := cmd.Flags().GetUint32("expire-minutes")
+		expireDays, _ := cmd.Flags().GetUint32("expire-days")
+		desc, _ := cmd.Flags().GetString("desc")
+
+		resp, err := client.CreateBreakGlass(ctx, &pb.CreateBreakGlassRequest{
+			Credential: &pb.BreakGlassCredential{
+				Name:          name,
+				Principal:     principal,
+				OneTime:       oneTime,
+				ExpireMinutes: expireMinutes,
+				ExpireDays:    expireDays,
+				Description:   desc,
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to create break-glass credential: %v", err)
+		}
+		if resp.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", resp.Message)
+			fmt.Printf("  Code: %d\n", resp.Code)
+			fmt.Printf("  Action: %s\n", resp.Action)
+			return
+		}
+
+		fmt.Printf("ID: %d\n", resp.Credential.Id)
+		fmt.Printf("Secret: %s\n", resp.Credential.Secret)
+		if resp.Credential.ExpiresAt > 0 {
+			fmt.Printf("Expires: %s\n", time.Unix(resp.Credential.ExpiresAt, 0).Format(time.DateTime))
+		}
+		fmt.Println("Save the secret now, it can not be shown again.")
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createBreakGlassCmd)
+	createBreakGlassCmd.Flags().String("name", "", "Name of the credential")
+	createBreakGlassCmd.Flags().String("principal", "", "User the credential acts as")
+	createBreakGlassCmd.Flags().Bool("one-time", false, "Credential can be used by one request only")
+	createBreakGlassCmd.Flags().Uint32("expire-minutes", 0, "Minutes after the first use before the credential expires")
+	createBreakGlassCmd.Flags().Uint32("expire-days", 0, "Days before the credential expires, 0 never expires")
+	createBreakGlassCmd.Flags().String("desc", "", "Description of the credential")
+}
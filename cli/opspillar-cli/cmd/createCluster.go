@@ -86,6 +86,8 @@ Examples:
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateClusters(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create clusters: %v", err)
@@ -95,6 +97,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Clusters, resp.Errors)
 		}
 	},
 }
@@ -87,6 +87,8 @@ Examples:
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateDatacenters(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create datacenters: %v", err)
@@ -96,6 +98,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Datacenters, resp.Errors)
 		}
 	},
 }
@@ -85,6 +85,8 @@ Examples:
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateEnvs(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create environments: %v", err)
@@ -94,6 +96,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Envs, resp.Errors)
 		}
 	},
 }
@@ -88,6 +88,8 @@ Examples:
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateFeatures(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create features: %v", err)
@@ -97,6 +99,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Features, resp.Errors)
 		}
 	},
 }
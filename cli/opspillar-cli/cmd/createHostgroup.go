@@ -24,7 +24,8 @@ Hostgroup belongs to a cluster.
 
 Examples:
   opspillar create hostgroup --name web-servers --desc "Web Servers" --cluster cluster1
-  opspillar create hostgroup --name db-servers --desc "Database Servers" --cluster cluster1`,
+  opspillar create hostgroup --name db-servers --desc "Database Servers" --cluster cluster1
+  opspillar create hostgroup --name web-servers --cluster 1 --team 1 --product 1 --env 1 --dc 1 --attr rack=r12`,
 	Aliases: []string{"hg", "hgs", "hostgroups"},
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, conn, err := NewConnection(true)
@@ -51,6 +52,7 @@ Examples:
 				TagsId:          []uint32{},
 				ShareProductsId: []uint32{},
 				ShareTeamsId:    []uint32{},
+				Attributes:      map[string]string{},
 			}
 			hostgroups := []*pb.Hostgroup{hostgroup}
 
@@ -93,6 +95,7 @@ Examples:
 			tagId, _ := cmd.Flags().GetUint32("tag")
 			shareProductId, _ := cmd.Flags().GetUint32("share-product")
 			shareTeamId, _ := cmd.Flags().GetUint32("share-team")
+			attrs, _ := cmd.Flags().GetStringToString("attr")
 
 			req = &pb.CreateHostgroupsRequest{
 				Hostgroups: []*pb.Hostgroup{
@@ -108,11 +111,14 @@ Examples:
 						TagsId:          []uint32{tagId},
 						ShareProductsId: []uint32{shareProductId},
 						ShareTeamsId:    []uint32{shareTeamId},
+						Attributes:      attrs,
 					},
 				},
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateHostgroups(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create hostgroups: %v", err)
@@ -122,6 +128,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Hostgroups, resp.Errors)
 		}
 	},
 }
@@ -139,4 +146,5 @@ func init() {
 	createHostgroupCmd.Flags().Uint32("tag", 0, "ID of the tag this hostgroup belongs to")
 	createHostgroupCmd.Flags().Uint32("share-product", 0, "ID of the product this hostgroup shares with")
 	createHostgroupCmd.Flags().Uint32("share-team", 0, "ID of the team this hostgroup shares with")
+	createHostgroupCmd.Flags().StringToString("attr", map[string]string{}, "Custom attribute values, name=value")
 }
@@ -0,0 +1,66 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// createOrganizationCmd represents the createOrganization command
+var createOrganizationCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Create a new organization",
+	Long: `Create a new organization, admin only.
+Organization is a namespace of teams and products, its admins can write all resources of it.
+
+Examples:
+  opspillar create org --name acme --admins alice,bob --desc "Acme Corp"`,
+	Aliases: []string{"orgs", "organization", "organizations"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		name, _ := cmd.Flags().GetString("name")
+		desc, _ := cmd.Flags().GetString("desc")
+		admins, _ := cmd.Flags().GetStringSlice("admins")
+
+		client := pb.NewOrganizationsClient(conn)
+		resp, err := client.CreateOrganizations(ctx, &pb.CreateOrganizationsRequest{
+			Organizations: []*pb.Organization{
+				{
+					Name:        name,
+					Description: desc,
+					Admins:      admins,
+				},
+			},
+			Partial: createPartial,
+		})
+		if err != nil {
+			log.Fatalf("failed to create organizations: %v", err)
+		}
+
+		if resp != nil {
+			fmt.Printf("Code: %d\n", resp.Code)
+			fmt.Printf("Message: %s\n", resp.Message)
+			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Organizations, resp.Errors)
+		}
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createOrganizationCmd)
+	createOrganizationCmd.Flags().String("name", "", "Name of the organization")
+	createOrganizationCmd.Flags().String("desc", "", "Description of the organization")
+	createOrganizationCmd.Flags().StringSlice("admins", []string{}, "User names of the organization admins")
+	createOrganizationCmd.MarkFlagRequired("name")
+}
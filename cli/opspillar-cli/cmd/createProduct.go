@@ -76,6 +76,7 @@ Examples:
 			name, _ := cmd.Flags().GetString("name")
 			code, _ := cmd.Flags().GetString("code")
 			desc, _ := cmd.Flags().GetString("desc")
+			orgId, _ := cmd.Flags().GetUint32("org-id")
 
 			req = &pb.CreateProductsRequest{
 				Products: []*pb.Product{
@@ -83,11 +84,14 @@ Examples:
 						Name:        name,
 						Code:        code,
 						Description: desc,
+						OrgId:       orgId,
 					},
 				},
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateProducts(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create products: %v", err)
@@ -97,6 +101,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Products, resp.Errors)
 		}
 	},
 }
@@ -106,4 +111,5 @@ func init() {
 	createProductCmd.Flags().String("name", "", "Name of the product")
 	createProductCmd.Flags().String("code", "", "Code of the product")
 	createProductCmd.Flags().String("desc", "", "Description of the product")
+	createProductCmd.Flags().Uint32("org-id", 0, "Organization ID of the product, the selected organization if omitted")
 }
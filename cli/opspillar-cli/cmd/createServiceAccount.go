@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// createServiceAccountCmd represents the create serviceaccount command
+var createServiceAccountCmd = &cobra.Command{
+	Use:   "serviceaccount",
+	Short: "Create a new service account",
+	Long: `Create a service account for automation, such as CI pipelines.
+Service account can write resources of its team, binding a role requires admin.
+
+Examples:
+  opspillar create sa --name ci --team 1 --desc "CI pipeline"
+  opspillar create sa --name deployer --team 1 --role admin`,
+	Aliases: []string{"sa", "serviceaccounts"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewServiceAccountsClient(conn)
+
+		name, _ := cmd.Flags().GetString("name")
+		teamId, _ := cmd.Flags().GetUint32("team")
+		role, _ := cmd.Flags().GetString("role")
+		desc, _ := cmd.Flags().GetString("desc")
+
+		resp, err := client.CreateServiceAccounts(ctx, &pb.CreateServiceAccountsRequest{
+			ServiceAccounts: []*pb.ServiceAccount{
+				{
+					Name:        name,
+					TeamId:      teamId,
+					Role:        role,
+					Description: desc,
+				},
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to create service accounts: %v", err)
+		}
+
+		if resp != nil {
+			fmt.Printf("Code: %d\n", resp.Code)
+			fmt.Printf("Message: %s\n", resp.Message)
+			fmt.Printf("Action: %s\n", resp.Action)
+		}
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createServiceAccountCmd)
+	createServiceAccountCmd.Flags().String("name", "", "Name of the service account")
+	createServiceAccountCmd.Flags().Uint32("team", 0, "Team ID of the service account")
+	createServiceAccountCmd.Flags().String("role", "", "Casbin role of the service account")
+	createServiceAccountCmd.Flags().String("desc", "", "Description of the service account")
+}
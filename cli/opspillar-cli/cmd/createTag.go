@@ -87,6 +87,8 @@ Examples:
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateTags(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create tags: %v", err)
@@ -96,6 +98,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Tags, resp.Errors)
 		}
 	},
 }
@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// createTagKeyCmd represents the createTagKey command
+var createTagKeyCmd = &cobra.Command{
+	Use:   "tagkey",
+	Short: "Register a tag key",
+	Long: `Register a tag key governing values of its tags and the entities using them, admin only.
+Tags of required keys must be on applications or hostgroups of the kinds.
+
+Examples:
+  opspillar create tagkey --key cost-center --pattern "cc[0-9]+" --kinds applications,hostgroups --required
+  opspillar create tagkey --key runtime --values prod,staging,dev`,
+	Aliases: []string{"tagkeys", "tag-key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		key, _ := cmd.Flags().GetString("key")
+		values, _ := cmd.Flags().GetStringSlice("values")
+		pattern, _ := cmd.Flags().GetString("pattern")
+		kinds, _ := cmd.Flags().GetStringSlice("kinds")
+		required, _ := cmd.Flags().GetBool("required")
+		desc, _ := cmd.Flags().GetString("desc")
+
+		client := pb.NewTagKeysClient(conn)
+		resp, err := client.CreateTagKeys(ctx, &pb.CreateTagKeysRequest{
+			TagKeys: []*pb.TagKey{
+				{
+					Key:           key,
+					Description:   desc,
+					AllowedValues: values,
+					Pattern:       pattern,
+					Kinds:         kinds,
+					Required:      required,
+				},
+			},
+			Partial: createPartial,
+		})
+		if err != nil {
+			log.Fatalf("failed to create tag keys: %v", err)
+		}
+
+		if resp != nil {
+			fmt.Printf("Code: %d\n", resp.Code)
+			fmt.Printf("Message: %s\n", resp.Message)
+			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.TagKeys, resp.Errors)
+		}
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createTagKeyCmd)
+	createTagKeyCmd.Flags().String("key", "", "Tag key")
+	createTagKeyCmd.Flags().StringSlice("values", []string{}, "Allowed values of tags, any if empty")
+	createTagKeyCmd.Flags().String("pattern", "", "Regular expression the whole value must match")
+	createTagKeyCmd.Flags().StringSlice("kinds", []string{}, "Entity kinds tags are used on, applications or hostgroups, all if empty")
+	createTagKeyCmd.Flags().Bool("required", false, "Entities of kinds must have a tag of the key")
+	createTagKeyCmd.Flags().String("desc", "", "Description of the tag key")
+	createTagKeyCmd.MarkFlagRequired("key")
+}
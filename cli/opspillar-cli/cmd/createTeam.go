@@ -78,6 +78,7 @@ Examples:
 			code, _ := cmd.Flags().GetString("code")
 			desc, _ := cmd.Flags().GetString("desc")
 			leaderId, _ := cmd.Flags().GetUint32("leader")
+			orgId, _ := cmd.Flags().GetUint32("org-id")
 
 			req = &pb.CreateTeamsRequest{
 				Teams: []*pb.Team{
@@ -86,11 +87,14 @@ Examples:
 						Code:        code,
 						Description: desc,
 						LeaderId:    leaderId,
+						OrgId:       orgId,
 					},
 				},
 			}
 		}
 
+		req.Partial = createPartial
+
 		resp, err := client.CreateTeams(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to create teams: %v", err)
@@ -100,6 +104,7 @@ Examples:
 			fmt.Printf("Code: %d\n", resp.Code)
 			fmt.Printf("Message: %s\n", resp.Message)
 			fmt.Printf("Action: %s\n", resp.Action)
+			printCommitted(resp.Teams, resp.Errors)
 		}
 
 	},
@@ -111,4 +116,5 @@ func init() {
 	createTeamCmd.Flags().String("code", "", "Code of the team")
 	createTeamCmd.Flags().String("desc", "", "Description of the team")
 	createTeamCmd.Flags().Uint32("leaderId", 0, "UserId of the Leader of the team")
+	createTeamCmd.Flags().Uint32("org-id", 0, "Organization ID of the team, the selected organization if omitted")
 }
@@ -97,6 +97,8 @@ func createUser(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	req.Partial = createPartial
+
 	resp, err := client.CreateUsers(ctx, req)
 	if err != nil {
 		log.Fatalf("could not create user: %v", err)
@@ -105,6 +107,7 @@ func createUser(cmd *cobra.Command, args []string) {
 		fmt.Printf("Code: %d\n", resp.Code)
 		fmt.Printf("Message: %s\n", resp.Message)
 		fmt.Printf("Action: %s\n", resp.Action)
+		printCommitted(resp.Users, resp.Errors)
 	}
 }
 
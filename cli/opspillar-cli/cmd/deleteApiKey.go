@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// deleteApiKeyCmd represents the delete apikey command
+var deleteApiKeyCmd = &cobra.Command{
+	Use:   "apikey [ids...]",
+	Short: "Revoke one or more api keys by their IDs",
+	Long: `Revoke api keys, they are rejected at once.
+For example:
+  opspillar delete apikey 1 2`,
+	Args:    cobra.MinimumNArgs(1),
+	Aliases: []string{"apikeys", "key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid api key ID '%s': %v\n", arg, err)
+				return
+			}
+			ids = append(ids, uint32(id))
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewServiceAccountsClient(conn)
+
+		reply, err := client.RevokeApiKeys(ctx, &pb.RevokeApiKeysRequest{
+			Ids: ids,
+		})
+		if err != nil {
+			log.Fatalf("failed to revoke api keys: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+		}
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteApiKeyCmd)
+}
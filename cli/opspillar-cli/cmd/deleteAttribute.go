@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteAttributeCmd represents the deleteAttribute command
+var deleteAttributeCmd = &cobra.Command{
+	Use:   "attr [ids...]",
+	Short: "Delete one or more custom attributes by their IDs",
+	Long: `Delete custom attributes with their values on entities, admin only.
+For example:
+  opspillar delete attr 1 2`,
+	Args:    cobra.MinimumNArgs(1),
+	Aliases: []string{"attrs", "attribute", "attributes"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid attribute ID '%s': %v\n", arg, err)
+				return
+			}
+			ids = append(ids, uint32(id))
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAttributesClient(conn)
+		reply, err := client.DeleteAttributes(ctx, &pb.DeleteAttributesRequest{Ids: ids})
+		if err != nil {
+			log.Fatalf("failed to delete attributes: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+		}
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteAttributeCmd)
+}
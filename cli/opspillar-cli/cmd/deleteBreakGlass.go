@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// deleteBreakGlassCmd represents the delete breakglass command
+var deleteBreakGlassCmd = &cobra.Command{
+	Use:   "breakglass [ids...]",
+	Short: "Delete one or more break-glass credentials by their IDs",
+	Long: `Delete break-glass credentials, they are rejected at once. Their audits are kept.
+For example:
+  opspillar delete breakglass 1 2`,
+	Args:    cobra.MinimumNArgs(1),
+	Aliases: []string{"bg"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid break-glass credential ID '%s': %v\n", arg, err)
+				return
+			}
+			ids = append(ids, uint32(id))
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewBreakGlassClient(conn)
+
+		reply, err := client.DeleteBreakGlass(ctx, &pb.DeleteBreakGlassRequest{
+			Ids: ids,
+		})
+		if err != nil {
+			log.Fatalf("failed to delete break-glass credentials: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+		}
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteBreakGlassCmd)
+}
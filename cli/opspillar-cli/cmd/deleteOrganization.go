@@ -0,0 +1,59 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteOrganizationCmd represents the deleteOrganization command
+var deleteOrganizationCmd = &cobra.Command{
+	Use:   "org [ids...]",
+	Short: "Delete one or more organizations by their IDs",
+	Long: `Delete organizations without teams and products, admin only.
+The default organization can not be deleted.
+For example:
+  opspillar delete org 2 3`,
+	Args:    cobra.MinimumNArgs(1),
+	Aliases: []string{"orgs", "organization", "organizations"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid organization ID '%s': %v\n", arg, err)
+				return
+			}
+			ids = append(ids, uint32(id))
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewOrganizationsClient(conn)
+		reply, err := client.DeleteOrganizations(ctx, &pb.DeleteOrganizationsRequest{Ids: ids})
+		if err != nil {
+			log.Fatalf("failed to delete organizations: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+		}
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteOrganizationCmd)
+}
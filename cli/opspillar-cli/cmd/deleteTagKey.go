@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteTagKeyCmd represents the deleteTagKey command
+var deleteTagKeyCmd = &cobra.Command{
+	Use:   "tagkey [ids...]",
+	Short: "Delete one or more tag keys by their IDs",
+	Long: `Unregister tag keys, admin only. Tags of the keys are kept.
+For example:
+  opspillar delete tagkey 1 2`,
+	Args:    cobra.MinimumNArgs(1),
+	Aliases: []string{"tagkeys", "tag-key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid tag key ID '%s': %v\n", arg, err)
+				return
+			}
+			ids = append(ids, uint32(id))
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewTagKeysClient(conn)
+		reply, err := client.DeleteTagKeys(ctx, &pb.DeleteTagKeysRequest{Ids: ids})
+		if err != nil {
+			log.Fatalf("failed to delete tag keys: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+		}
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteTagKeyCmd)
+}
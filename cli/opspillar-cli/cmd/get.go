@@ -5,6 +5,9 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -43,6 +46,27 @@ func validateFormat(format string) error {
 	return fmt.Errorf("invalid format %q, valid values are: table, yaml, text", format)
 }
 
+// timeFlagLayouts are the local time layouts accepted by time range flags, besides unix seconds.
+var timeFlagLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// getTimeFlag returns a time flag as unix seconds, 0 when not set.
+func getTimeFlag(cmd *cobra.Command, name string) int64 {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return 0
+	}
+	if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return sec
+	}
+	for _, layout := range timeFlagLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t.Unix()
+		}
+	}
+	log.Fatalf("invalid --%s %q, use unix seconds, 2006-01-02 or 2006-01-02 15:04:05", name, value)
+	return 0
+}
+
 func init() {
 	rootCmd.AddCommand(getCmd)
 
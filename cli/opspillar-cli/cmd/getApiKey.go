@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// getApiKeyCmd represents the get apikey command
+var getApiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Get api keys of service accounts",
+	Long: `Get api keys of service accounts, keys themselves are never shown.
+
+Examples:
+  opspillar get apikey          # List all
+  opspillar get apikey --sa 1   # Filter by service account IDs`,
+	Aliases: []string{"apikeys", "key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("connect to server failed: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewServiceAccountsClient(conn)
+
+		sas, _ := cmd.Flags().GetUintSlice("sa")
+		saIds := make([]uint32, len(sas))
+		for i, id := range sas {
+			saIds[i] = uint32(id)
+		}
+
+		reply, err := client.ListApiKeys(ctx, &pb.ListApiKeysRequest{ServiceAccountIds: saIds})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", reply.Message)
+			fmt.Printf("  Code: %d\n", reply.Code)
+			fmt.Printf("  Action: %s\n", reply.Action)
+			return
+		}
+
+		formatTime := func(t int64) string {
+			if t == 0 {
+				return "-"
+			}
+			return time.Unix(t, 0).Format(time.DateTime)
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "SA", "Name", "Prefix", "Scopes", "Expires", "Last Used"})
+		table.SetAutoFormatHeaders(true)
+		for _, k := range reply.ApiKeys {
+			table.Append([]string{
+				fmt.Sprint(k.Id),
+				fmt.Sprint(k.ServiceAccountId),
+				k.Name,
+				k.Prefix,
+				strings.Join(k.Scopes, ","),
+				formatTime(k.ExpiresAt),
+				formatTime(k.LastUsedAt),
+			})
+		}
+		table.Render()
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getApiKeyCmd)
+	getApiKeyCmd.Flags().UintSlice("sa", []uint{}, "Filter by service account IDs (comma-separated)")
+}
@@ -24,8 +24,14 @@ Examples:
   opspillar get app --names app1,app2                  # Filter by names
   opspillar get app --ids 1,2,3                       # Filter by IDs
   opspillar get app --is-stateful true                # Filter stateful
+  opspillar get app --attr tier=gold                  # Filter by custom attributes
   opspillar get app --page 1 --page-size 10           # With pagination
-  opspillar get app --names web --clusters 1 --format yaml   # Combined filters`,
+  opspillar get app --names web --clusters 1 --format yaml   # Combined filters
+  opspillar get app --names web --name-match prefix  # Names starting with web
+  opspillar get app --sort-by created_at --desc      # Newest first
+  opspillar get app --updated-before 2024-01-01      # Not updated since a date
+  opspillar get app --exclude-products 3 --no-features  # Outside product 3, without features
+  opspillar get app -l "product in (meta,ads),hostgroup=web"  # Select by names`,
 	Aliases: []string{"apps", "applications"},
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, conn, err := NewConnection(true)
@@ -48,6 +54,19 @@ Examples:
 		uintFeatures, _ := cmd.Flags().GetUintSlice("features")
 		uintTags, _ := cmd.Flags().GetUintSlice("tags")
 		uintHostgroups, _ := cmd.Flags().GetUintSlice("hostgroups")
+		attrs, _ := cmd.Flags().GetStringToString("attr")
+		nameMatch, _ := cmd.Flags().GetString("name-match")
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		sortDesc, _ := cmd.Flags().GetBool("desc")
+		createdAfter := getTimeFlag(cmd, "created-after")
+		createdBefore := getTimeFlag(cmd, "created-before")
+		updatedAfter := getTimeFlag(cmd, "updated-after")
+		updatedBefore := getTimeFlag(cmd, "updated-before")
+		excludeTeams, _ := cmd.Flags().GetUintSlice("exclude-teams")
+		excludeProducts, _ := cmd.Flags().GetUintSlice("exclude-products")
+		noTags, _ := cmd.Flags().GetBool("no-tags")
+		noFeatures, _ := cmd.Flags().GetBool("no-features")
+		selector, _ := cmd.Flags().GetString("selector")
 
 		// 转换为uint32
 		ids := toUint32Slice(uintIds)
@@ -58,18 +77,33 @@ Examples:
 		hostgroupsId := toUint32Slice(uintHostgroups)
 
 		var allApps []*pb.Application
+		var pageToken string
 		for {
 			req := &pb.ListApplicationsRequest{
-				Page:         page,
-				PageSize:     pageSize,
-				Names:        names,
-				IsStateful:   isStateful,
-				Ids:          ids,
-				ProductsId:   productsId,
-				TeamsId:      teamsId,
-				FeaturesId:   featuresId,
-				TagsId:       tagsId,
-				HostgroupsId: hostgroupsId,
+				Page:              page,
+				PageSize:          pageSize,
+				PageToken:         pageToken,
+				Names:             names,
+				IsStateful:        isStateful,
+				Ids:               ids,
+				ProductsId:        productsId,
+				TeamsId:           teamsId,
+				FeaturesId:        featuresId,
+				TagsId:            tagsId,
+				HostgroupsId:      hostgroupsId,
+				Attributes:        attributeFilters(attrs),
+				NameMatch:         nameMatch,
+				SortBy:            sortBy,
+				SortDesc:          sortDesc,
+				CreatedAfter:      createdAfter,
+				CreatedBefore:     createdBefore,
+				UpdatedAfter:      updatedAfter,
+				UpdatedBefore:     updatedBefore,
+				ExcludeTeamsId:    toUint32Slice(excludeTeams),
+				ExcludeProductsId: toUint32Slice(excludeProducts),
+				NoTags:            noTags,
+				NoFeatures:        noFeatures,
+				Selector:          selector,
 			}
 
 			resp, err := client.ListApplications(ctx, req)
@@ -87,11 +121,10 @@ Examples:
 			}
 			allApps = append(allApps, resp.Apps...)
 
-			if len(resp.Apps) < int(pageSize) {
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		// 转换为ApplicationReadable
@@ -214,6 +247,7 @@ Examples:
 				CreatedBy:   app.CreatedBy,
 				UpdatedAt:   app.UpdatedAt,
 				UpdatedBy:   app.UpdatedBy,
+				Attributes:  app.Attributes,
 			}
 
 			// Use cached product name
@@ -255,7 +289,7 @@ Examples:
 			table.SetHeader([]string{
 				"ID", "Name", "Description", "Owner", "Stateful",
 				"Product", "Team",
-				"Features", "Tags", "Hostgroups", "CreatedBy", "CreatedAt", "UpdatedBy", "UpdatedAt",
+				"Features", "Tags", "Hostgroups", "Attributes", "CreatedBy", "CreatedAt", "UpdatedBy", "UpdatedAt",
 			})
 			table.SetAutoFormatHeaders(false)
 			for _, app := range readableApps {
@@ -270,6 +304,7 @@ Examples:
 					strings.Join(app.Features, ", "),
 					strings.Join(app.Tags, ", "),
 					strings.Join(app.Hostgroups, ", "),
+					formatAttributes(app.Attributes),
 					app.CreatedBy,
 					time.Unix(app.CreatedAt, 0).Local().Format("2006-01-02 15:04:05"),
 					app.UpdatedBy,
@@ -294,6 +329,7 @@ Examples:
 					"Features:    %s\n"+
 					"Tags:        %s\n"+
 					"Hostgroups:  %s\n"+
+					"Attributes:  %s\n"+
 					"CreatedBy:   %s\n"+
 					"CreatedAt:   %s\n"+
 					"UpdatedBy:   %s\n"+
@@ -303,6 +339,7 @@ Examples:
 					strings.Join(app.Features, ", "),
 					strings.Join(app.Tags, ", "),
 					strings.Join(app.Hostgroups, ", "),
+					formatAttributes(app.Attributes),
 					app.CreatedBy,
 					time.Unix(app.CreatedAt, 0).Local().Format("2006-01-02 15:04:05"),
 					app.UpdatedBy,
@@ -326,4 +363,17 @@ func init() {
 	getAppCmd.Flags().UintSlice("features", []uint{}, "Filter by feature IDs")
 	getAppCmd.Flags().UintSlice("tags", []uint{}, "Filter by tag IDs")
 	getAppCmd.Flags().UintSlice("hostgroups", []uint{}, "Filter by hostgroup IDs")
+	getAppCmd.Flags().StringToString("attr", map[string]string{}, "Filter by custom attribute values, name=value")
+	getAppCmd.Flags().String("name-match", "", "How names are matched: <Empty> for contains, prefix or exact")
+	getAppCmd.Flags().String("sort-by", "", "Sort by id, name, created_at or updated_at")
+	getAppCmd.Flags().Bool("desc", false, "Sort in descending order")
+	getAppCmd.Flags().String("created-after", "", "Filter created at or after a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getAppCmd.Flags().String("created-before", "", "Filter created before a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getAppCmd.Flags().String("updated-after", "", "Filter updated at or after a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getAppCmd.Flags().String("updated-before", "", "Filter updated before a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getAppCmd.Flags().UintSlice("exclude-teams", []uint{}, "Exclude team IDs")
+	getAppCmd.Flags().UintSlice("exclude-products", []uint{}, "Exclude product IDs")
+	getAppCmd.Flags().Bool("no-tags", false, "Only without tags")
+	getAppCmd.Flags().Bool("no-features", false, "Only without features")
+	getAppCmd.Flags().StringP("selector", "l", "", "Label selector like team=infra,product in (a,b),!feature:gpu. keys: team, product, hostgroup, tag:<key>, feature:<name>")
 }
@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// getAttributeCmd represents the getAttribute command
+var getAttributeCmd = &cobra.Command{
+	Use:   "attr",
+	Short: "Get custom attributes from the system",
+	Long: `Get custom attribute definitions of applications and hostgroups.
+
+Examples:
+  opspillar get attr                       # List all
+  opspillar get attr --kinds applications  # Filter by entity kinds
+  opspillar get attr --names tier          # Filter by names`,
+	Aliases: []string{"attrs", "attribute", "attributes"},
+	Run: func(cmd *cobra.Command, args []string) {
+		kinds, _ := cmd.Flags().GetStringSlice("kinds")
+		names, _ := cmd.Flags().GetStringSlice("names")
+		ids, _ := cmd.Flags().GetUintSlice("ids")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("connect to server failed: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAttributesClient(conn)
+
+		var allAttrs []*pb.Attribute
+		currentPage := GetPage
+		var pageToken string
+		for {
+			reply, err := client.ListAttributes(ctx, &pb.ListAttributesRequest{
+				Page:      currentPage,
+				PageSize:  GetPageSize,
+				PageToken: pageToken,
+				Kinds:     kinds,
+				Names:     names,
+				Ids:       toUint32Slice(ids),
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if reply.Code != 0 {
+				fmt.Printf("Response details:\n")
+				fmt.Printf("  Message: %s\n", reply.Message)
+				fmt.Printf("  Code: %d\n", reply.Code)
+				fmt.Printf("  Action: %s\n", reply.Action)
+				return
+			}
+			allAttrs = append(allAttrs, reply.Attributes...)
+			if reply.NextPageToken == "" {
+				break
+			}
+			pageToken = reply.NextPageToken
+		}
+
+		switch GetFormat {
+		case "table":
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"ID", "Kind", "Name", "Type", "Required", "Enum Values", "Pattern", "Description"})
+			for _, a := range allAttrs {
+				table.Append([]string{
+					fmt.Sprint(a.Id),
+					a.Kind,
+					a.Name,
+					a.Type,
+					fmt.Sprint(a.Required),
+					strings.Join(a.EnumValues, ","),
+					a.Pattern,
+					a.Description,
+				})
+			}
+			table.Render()
+
+		case "yaml":
+			data, err := yaml.Marshal(allAttrs)
+			if err != nil {
+				log.Fatalf("serialize yaml failed: %v", err)
+			}
+			fmt.Println(string(data))
+
+		case "text":
+			if len(allAttrs) == 0 {
+				fmt.Println("No attributes found")
+				return
+			}
+			for _, a := range allAttrs {
+				fmt.Printf("ID: %d, Kind: %s, Name: %s, Type: %s, Required: %t, Enum Values: %s, Pattern: %s, Description: %s\n",
+					a.Id, a.Kind, a.Name, a.Type, a.Required, strings.Join(a.EnumValues, ","), a.Pattern, a.Description)
+			}
+
+		default:
+			fmt.Println("unknown format")
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getAttributeCmd)
+
+	getAttributeCmd.Flags().StringSlice("kinds", []string{}, "Filter by entity kinds, applications or hostgroups")
+	getAttributeCmd.Flags().StringSlice("names", []string{}, "Filter by attribute names")
+	getAttributeCmd.Flags().UintSlice("ids", []uint{}, "Filter by attribute IDs")
+}
+
+// attributeFilters converts name=value flags to name:value filters of list requests.
+func attributeFilters(attrs map[string]string) []string {
+	filters := make([]string, 0, len(attrs))
+	for name, value := range attrs {
+		filters = append(filters, name+":"+value)
+	}
+	sort.Strings(filters)
+	return filters
+}
+
+// formatAttributes shows attribute values as name=value sorted by name.
+func formatAttributes(attrs map[string]string) string {
+	pairs := make([]string, 0, len(attrs))
+	for name, value := range attrs {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
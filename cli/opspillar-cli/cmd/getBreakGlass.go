@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// getBreakGlassCmd represents the get breakglass command
+var getBreakGlassCmd = &cobra.Command{
+	Use:   "breakglass",
+	Short: "Get break-glass credentials or their audits",
+	Long: `Get break-glass credentials, secrets are never shown.
+With --audit, list uses of the credentials, newest first.
+
+Examples:
+  opspillar get breakglass                  # List all
+  opspillar get breakglass --audit          # List audits
+  opspillar get breakglass --audit --id 1   # Filter audits by credential IDs`,
+	Aliases: []string{"bg"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("connect to server failed: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewBreakGlassClient(conn)
+
+		formatTime := func(t int64) string {
+			if t == 0 {
+				return "-"
+			}
+			return time.Unix(t, 0).Format(time.DateTime)
+		}
+
+		if audit, _ := cmd.Flags().GetBool("audit"); audit {
+			ids, _ := cmd.Flags().GetUintSlice("id")
+			credIds := make([]uint32, len(ids))
+			for i, id := range ids {
+				credIds[i] = uint32(id)
+			}
+			reply, err := client.ListBreakGlassAudits(ctx, &pb.ListBreakGlassAuditsRequest{
+				Page:          GetPage,
+				PageSize:      GetPageSize,
+				CredentialIds: credIds,
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if reply.Code != 0 {
+				fmt.Printf("Response details:\n")
+				fmt.Printf("  Message: %s\n", reply.Message)
+				fmt.Printf("  Code: %d\n", reply.Code)
+				fmt.Printf("  Action: %s\n", reply.Action)
+				return
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Time", "Credential", "Principal", "Operation", "IP", "Allowed", "Reason"})
+			table.SetAutoFormatHeaders(true)
+			for _, a := range reply.Audits {
+				table.Append([]string{
+					formatTime(a.CreatedAt),
+					a.Name,
+					a.Principal,
+					a.Operation,
+					a.Ip,
+					fmt.Sprint(a.Allowed),
+					a.Reason,
+				})
+			}
+			table.Render()
+			return
+		}
+
+		reply, err := client.ListBreakGlass(ctx, &pb.ListBreakGlassRequest{})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", reply.Message)
+			fmt.Printf("  Code: %d\n", reply.Code)
+			fmt.Printf("  Action: %s\n", reply.Action)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Name", "Principal", "Prefix", "One Time", "Expire Minutes", "Expires", "First Used", "Created By"})
+		table.SetAutoFormatHeaders(true)
+		for _, c := range reply.Credentials {
+			table.Append([]string{
+				fmt.Sprint(c.Id),
+				c.Name,
+				c.Principal,
+				c.Prefix,
+				fmt.Sprint(c.OneTime),
+				fmt.Sprint(c.ExpireMinutes),
+				formatTime(c.ExpiresAt),
+				formatTime(c.FirstUsedAt),
+				c.CreatedBy,
+			})
+		}
+		table.Render()
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getBreakGlassCmd)
+	getBreakGlassCmd.Flags().Bool("audit", false, "List audits of the credentials")
+	getBreakGlassCmd.Flags().UintSlice("id", []uint{}, "Filter audits by credential IDs (comma-separated)")
+}
@@ -43,12 +43,14 @@ Examples:
 		}
 
 		var allClusters []*pb.Cluster
+		var pageToken string
 		for {
 			req := &pb.ListClustersRequest{
-				Page:     page,
-				PageSize: pageSize,
-				Names:    names,
-				Ids:      ids,
+				Page:      page,
+				PageSize:  pageSize,
+				PageToken: pageToken,
+				Names:     names,
+				Ids:       ids,
 			}
 
 			resp, err := client.ListClusters(ctx, req)
@@ -67,12 +69,11 @@ Examples:
 			// 添加当前页的集群到结果集
 			allClusters = append(allClusters, resp.Clusters...)
 
-			// 如果返回的集群数量小于页大小，说明已经是最后一页
-			if len(resp.Clusters) < int(pageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		switch GetFormat {
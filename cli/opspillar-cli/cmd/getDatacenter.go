@@ -43,12 +43,14 @@ Examples:
 		}
 
 		var allDatacenters []*pb.Datacenter
+		var pageToken string
 		for {
 			req := &pb.ListDatacentersRequest{
-				Page:     page,
-				PageSize: pageSize,
-				Names:    names,
-				Ids:      ids,
+				Page:      page,
+				PageSize:  pageSize,
+				PageToken: pageToken,
+				Names:     names,
+				Ids:       ids,
 			}
 
 			resp, err := client.ListDatacenters(ctx, req)
@@ -67,12 +69,11 @@ Examples:
 			// 添加当前页的数据中心到结果集
 			allDatacenters = append(allDatacenters, resp.Datacenters...)
 
-			// 如果返回的数据中心数量小于页大小，说明已经是最后一页
-			if len(resp.Datacenters) < int(pageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		switch GetFormat {
@@ -43,12 +43,14 @@ Examples:
 		}
 
 		var allEnvs []*pb.Env
+		var pageToken string
 		for {
 			req := &pb.ListEnvsRequest{
-				Page:     page,
-				PageSize: pageSize,
-				Names:    names,
-				Ids:      ids,
+				Page:      page,
+				PageSize:  pageSize,
+				PageToken: pageToken,
+				Names:     names,
+				Ids:       ids,
 			}
 
 			resp, err := client.ListEnvs(ctx, req)
@@ -67,12 +69,11 @@ Examples:
 			// 添加当前页的环境到结果集
 			allEnvs = append(allEnvs, resp.Envs...)
 
-			// 如果返回的环境数量小于页大小，说明已经是最后一页
-			if len(resp.Envs) < int(pageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		switch GetFormat {
@@ -44,13 +44,15 @@ Examples:
 		}
 
 		var allFeatures []*pb.Feature
+		var pageToken string
 		for {
 			req := &pb.ListFeaturesRequest{
-				Page:     page,
-				PageSize: pageSize,
-				Names:    names,
-				Kvs:      kvs,
-				Ids:      ids,
+				Page:      page,
+				PageSize:  pageSize,
+				PageToken: pageToken,
+				Names:     names,
+				Kvs:       kvs,
+				Ids:       ids,
 			}
 
 			resp, err := client.ListFeatures(ctx, req)
@@ -69,12 +71,11 @@ Examples:
 			// 添加当前页的特性到结果集
 			allFeatures = append(allFeatures, resp.Features...)
 
-			// 如果返回的特性数量小于页大小，说明已经是最后一页
-			if len(resp.Features) < int(pageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		switch GetFormat {
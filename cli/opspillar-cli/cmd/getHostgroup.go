@@ -24,7 +24,13 @@ Examples:
   opspillar get hostgroup --names web,api                # Filter by names
   opspillar get hostgroup --ids 1,2,3                   # Filter by IDs
   opspillar get hostgroup --clusters 1 --teams 2        # Filter by cluster and team
-  opspillar get hostgroup --products 1 --format yaml    # Custom format`,
+  opspillar get hostgroup --attr rack=r12               # Filter by custom attributes
+  opspillar get hostgroup --products 1 --format yaml    # Custom format
+  opspillar get hostgroup --names web --name-match prefix  # Names starting with web
+  opspillar get hostgroup --sort-by updated_at --desc   # Recently updated first
+  opspillar get hostgroup --created-after 2024-01-01    # Created since a date
+  opspillar get hostgroup --exclude-teams 2 --no-tags   # Not owned by team 2, without tags
+  opspillar get hostgroup -l "team=infra,tag:sla=9999,!feature:gpu"  # Select by names`,
 	Aliases: []string{"hg", "hostgroups", "hgs"},
 	Run: func(cmd *cobra.Command, args []string) {
 		page := GetPage
@@ -42,6 +48,19 @@ Examples:
 		tags, _ := cmd.Flags().GetUintSlice("tags")
 		shareProducts, _ := cmd.Flags().GetUintSlice("share-products")
 		shareTeams, _ := cmd.Flags().GetUintSlice("share-teams")
+		attrs, _ := cmd.Flags().GetStringToString("attr")
+		nameMatch, _ := cmd.Flags().GetString("name-match")
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		sortDesc, _ := cmd.Flags().GetBool("desc")
+		createdAfter := getTimeFlag(cmd, "created-after")
+		createdBefore := getTimeFlag(cmd, "created-before")
+		updatedAfter := getTimeFlag(cmd, "updated-after")
+		updatedBefore := getTimeFlag(cmd, "updated-before")
+		excludeTeams, _ := cmd.Flags().GetUintSlice("exclude-teams")
+		excludeProducts, _ := cmd.Flags().GetUintSlice("exclude-products")
+		noTags, _ := cmd.Flags().GetBool("no-tags")
+		noFeatures, _ := cmd.Flags().GetBool("no-features")
+		selector, _ := cmd.Flags().GetString("selector")
 
 		// 转换所有 uint 切片到 uint32
 		ids := toUint32Slice(uintIds)
@@ -65,21 +84,36 @@ Examples:
 
 		client := pb.NewHostgroupsClient(conn)
 
+		var pageToken string
 		for {
 			req := &pb.ListHostgroupsRequest{
-				Page:            page,
-				PageSize:        pageSize,
-				Names:           names,
-				Ids:             ids,
-				ClustersId:      clustersIds,
-				DatacentersId:   datacentersIds,
-				EnvsId:          envsIds,
-				ProductsId:      productsIds,
-				TeamsId:         teamsIds,
-				FeaturesId:      featuresIds,
-				TagsId:          tagsIds,
-				ShareProductsId: shareProductsIds,
-				ShareTeamsId:    shareTeamsIds,
+				Page:              page,
+				PageSize:          pageSize,
+				PageToken:         pageToken,
+				Names:             names,
+				Ids:               ids,
+				ClustersId:        clustersIds,
+				DatacentersId:     datacentersIds,
+				EnvsId:            envsIds,
+				ProductsId:        productsIds,
+				TeamsId:           teamsIds,
+				FeaturesId:        featuresIds,
+				TagsId:            tagsIds,
+				ShareProductsId:   shareProductsIds,
+				ShareTeamsId:      shareTeamsIds,
+				Attributes:        attributeFilters(attrs),
+				NameMatch:         nameMatch,
+				SortBy:            sortBy,
+				SortDesc:          sortDesc,
+				CreatedAfter:      createdAfter,
+				CreatedBefore:     createdBefore,
+				UpdatedAfter:      updatedAfter,
+				UpdatedBefore:     updatedBefore,
+				ExcludeTeamsId:    toUint32Slice(excludeTeams),
+				ExcludeProductsId: toUint32Slice(excludeProducts),
+				NoTags:            noTags,
+				NoFeatures:        noFeatures,
+				Selector:          selector,
 			}
 
 			resp, err := client.ListHostgroups(ctx, req)
@@ -97,11 +131,10 @@ Examples:
 
 			allHostgroups = append(allHostgroups, resp.Hostgroups...)
 
-			if len(resp.Hostgroups) < int(pageSize) {
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		// convert to readableHostgroups
@@ -272,6 +305,7 @@ Examples:
 				CreatedBy:     hg.CreatedBy,
 				UpdatedAt:     hg.UpdatedAt,
 				UpdatedBy:     hg.UpdatedBy,
+				Attributes:    hg.Attributes,
 			}
 
 			readable.Cluster = clusterCache[hg.ClusterId]
@@ -309,7 +343,7 @@ Examples:
 		case "table":
 			table := tablewriter.NewWriter(os.Stdout)
 			table.SetHeader([]string{"ID", "Name", "Description", "Cluster", "Datacenter", "Env",
-				"Product", "Team", "Features", "Tags", "ShareProducts", "ShareTeams", "CreatedBy", "CreatedAt", "UpdatedBy", "UpdatedAt"})
+				"Product", "Team", "Features", "Tags", "ShareProducts", "ShareTeams", "Attributes", "CreatedBy", "CreatedAt", "UpdatedBy", "UpdatedAt"})
 			table.SetAutoFormatHeaders(false)
 			for _, hg := range readableHostgroups {
 				table.Append([]string{
@@ -325,6 +359,7 @@ Examples:
 					strings.Join(hg.Tags, ", "),
 					strings.Join(hg.ShareProducts, ", "),
 					strings.Join(hg.ShareTeams, ", "),
+					formatAttributes(hg.Attributes),
 					hg.CreatedBy,
 					time.Unix(hg.CreatedAt, 0).Local().Format("2006-01-02 15:04:05"),
 					hg.UpdatedBy,
@@ -350,6 +385,7 @@ Examples:
 				fmt.Printf("Tags:          [%s]\n", strings.Join(hg.Tags, ", "))
 				fmt.Printf("ShareProducts: [%s]\n", strings.Join(hg.ShareProducts, ", "))
 				fmt.Printf("ShareTeams:    [%s]\n", strings.Join(hg.ShareTeams, ", "))
+				fmt.Printf("Attributes:    [%s]\n", formatAttributes(hg.Attributes))
 				fmt.Printf("CreatedBy:     %s\n", hg.CreatedBy)
 				fmt.Printf("CreatedAt:     %s\n", time.Unix(hg.CreatedAt, 0).Local().Format("2006-01-02 15:04:05"))
 				fmt.Printf("UpdatedBy:     %s\n", hg.UpdatedBy)
@@ -377,4 +413,17 @@ func init() {
 	getHostgroupCmd.Flags().UintSlice("tags", []uint{}, "Filter by tag IDs")
 	getHostgroupCmd.Flags().UintSlice("share-products", []uint{}, "Filter by shared product IDs")
 	getHostgroupCmd.Flags().UintSlice("share-teams", []uint{}, "Filter by shared team IDs")
+	getHostgroupCmd.Flags().StringToString("attr", map[string]string{}, "Filter by custom attribute values, name=value")
+	getHostgroupCmd.Flags().String("name-match", "", "How names are matched: <Empty> for contains, prefix or exact")
+	getHostgroupCmd.Flags().String("sort-by", "", "Sort by id, name, created_at or updated_at")
+	getHostgroupCmd.Flags().Bool("desc", false, "Sort in descending order")
+	getHostgroupCmd.Flags().String("created-after", "", "Filter created at or after a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getHostgroupCmd.Flags().String("created-before", "", "Filter created before a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getHostgroupCmd.Flags().String("updated-after", "", "Filter updated at or after a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getHostgroupCmd.Flags().String("updated-before", "", "Filter updated before a time, unix seconds or 2006-01-02[ 15:04:05]")
+	getHostgroupCmd.Flags().UintSlice("exclude-teams", []uint{}, "Exclude team IDs")
+	getHostgroupCmd.Flags().UintSlice("exclude-products", []uint{}, "Exclude product IDs")
+	getHostgroupCmd.Flags().Bool("no-tags", false, "Only without tags")
+	getHostgroupCmd.Flags().Bool("no-features", false, "Only without features")
+	getHostgroupCmd.Flags().StringP("selector", "l", "", "Label selector like team=infra,product in (a,b),!feature:gpu. keys: team, product, cluster, datacenter, env, tag:<key>, feature:<name>")
 }
@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// getOrganizationCmd represents the getOrganization command
+var getOrganizationCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Get organizations from the system",
+	Long: `Get organizations with their admins from the system.
+
+Examples:
+  opspillar get org                        # List all
+  opspillar get org --names acme,default   # Filter by names
+  opspillar get org --ids 1,2              # Filter by IDs`,
+	Aliases: []string{"orgs", "organization", "organizations"},
+	Run: func(cmd *cobra.Command, args []string) {
+		names, _ := cmd.Flags().GetStringSlice("names")
+		ids, _ := cmd.Flags().GetUintSlice("ids")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("connect to server failed: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewOrganizationsClient(conn)
+
+		var allOrgs []*pb.Organization
+		currentPage := GetPage
+		var pageToken string
+		for {
+			reply, err := client.ListOrganizations(ctx, &pb.ListOrganizationsRequest{
+				Page:      currentPage,
+				PageSize:  GetPageSize,
+				PageToken: pageToken,
+				Names:     names,
+				Ids:       toUint32Slice(ids),
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if reply.Code != 0 {
+				fmt.Printf("Response details:\n")
+				fmt.Printf("  Message: %s\n", reply.Message)
+				fmt.Printf("  Code: %d\n", reply.Code)
+				fmt.Printf("  Action: %s\n", reply.Action)
+				return
+			}
+			allOrgs = append(allOrgs, reply.Organizations...)
+			if reply.NextPageToken == "" {
+				break
+			}
+			pageToken = reply.NextPageToken
+		}
+
+		switch GetFormat {
+		case "table":
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"ID", "Name", "Admins", "Description"})
+			for _, o := range allOrgs {
+				table.Append([]string{
+					fmt.Sprint(o.Id),
+					o.Name,
+					strings.Join(o.Admins, ","),
+					o.Description,
+				})
+			}
+			table.Render()
+
+		case "yaml":
+			data, err := yaml.Marshal(allOrgs)
+			if err != nil {
+				log.Fatalf("serialize yaml failed: %v", err)
+			}
+			fmt.Println(string(data))
+
+		case "text":
+			if len(allOrgs) == 0 {
+				fmt.Println("No organizations found")
+				return
+			}
+			for _, o := range allOrgs {
+				fmt.Printf("ID: %d, Name: %s, Admins: %s, Description: %s\n",
+					o.Id, o.Name, strings.Join(o.Admins, ","), o.Description)
+			}
+
+		default:
+			fmt.Println("unknown format")
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getOrganizationCmd)
+
+	getOrganizationCmd.Flags().StringSlice("names", []string{}, "Filter by organization names")
+	getOrganizationCmd.Flags().UintSlice("ids", []uint{}, "Filter by organization IDs")
+}
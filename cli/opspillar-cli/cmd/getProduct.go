@@ -52,14 +52,16 @@ Examples:
 		// 创建一个切片存储所有产品
 		var allProducts []*pb.Product
 		currentPage := page
+		var pageToken string
 
 		for {
 			req := &pb.ListProductsRequest{
-				Page:     currentPage,
-				PageSize: pageSize,
-				Names:    names,
-				Codes:    codes,
-				Ids:      idsUint32,
+				Page:      currentPage,
+				PageSize:  pageSize,
+				PageToken: pageToken,
+				Names:     names,
+				Codes:     codes,
+				Ids:       idsUint32,
 			}
 
 			reply, err := client.ListProducts(ctx, req)
@@ -79,12 +81,11 @@ Examples:
 			// 添加当前页的产品到总列表
 			allProducts = append(allProducts, reply.Products...)
 
-			// 如果返回的产品数量小于页大小，说明已经是最后一页
-			if len(reply.Products) < int(pageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if reply.NextPageToken == "" {
 				break
 			}
-
-			currentPage++
+			pageToken = reply.NextPageToken
 		}
 
 		// 根据格式输出结果
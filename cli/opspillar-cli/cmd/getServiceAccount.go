@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// getServiceAccountCmd represents the get serviceaccount command
+var getServiceAccountCmd = &cobra.Command{
+	Use:   "serviceaccount",
+	Short: "Get service accounts",
+	Long: `Get service accounts from the system.
+
+Examples:
+  opspillar get sa                    # List all
+  opspillar get sa --names ci,deploy  # Filter by names
+  opspillar get sa --teams 1          # Filter by team IDs`,
+	Aliases: []string{"sa", "serviceaccounts"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("connect to server failed: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewServiceAccountsClient(conn)
+
+		names, _ := cmd.Flags().GetStringSlice("names")
+		teams, _ := cmd.Flags().GetUintSlice("teams")
+		teamIds := make([]uint32, len(teams))
+		for i, id := range teams {
+			teamIds[i] = uint32(id)
+		}
+
+		var accounts []*pb.ServiceAccount
+		currentPage := GetPage
+		var pageToken string
+		for {
+			reply, err := client.ListServiceAccounts(ctx, &pb.ListServiceAccountsRequest{
+				Page:      currentPage,
+				PageSize:  GetPageSize,
+				PageToken: pageToken,
+				Names:     names,
+				TeamIds:   teamIds,
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if reply.Code != 0 {
+				fmt.Printf("Response details:\n")
+				fmt.Printf("  Message: %s\n", reply.Message)
+				fmt.Printf("  Code: %d\n", reply.Code)
+				fmt.Printf("  Action: %s\n", reply.Action)
+				return
+			}
+			accounts = append(accounts, reply.ServiceAccounts...)
+			if reply.NextPageToken == "" {
+				break
+			}
+			pageToken = reply.NextPageToken
+		}
+
+		switch GetFormat {
+		case "yaml":
+			data, err := yaml.Marshal(accounts)
+			if err != nil {
+				log.Fatalf("serialize yaml failed: %v", err)
+			}
+			fmt.Println(string(data))
+		case "text":
+			if len(accounts) == 0 {
+				fmt.Println("No service accounts found")
+				return
+			}
+			for _, sa := range accounts {
+				fmt.Printf("ID: %d, Name: %s, Team: %d, Role: %s, Description: %s\n",
+					sa.Id, sa.Name, sa.TeamId, sa.Role, sa.Description)
+			}
+		case "table":
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"ID", "Name", "Team", "Role", "Created By", "Description"})
+			table.SetAutoFormatHeaders(true)
+			for _, sa := range accounts {
+				table.Append([]string{
+					fmt.Sprint(sa.Id),
+					sa.Name,
+					fmt.Sprint(sa.TeamId),
+					sa.Role,
+					sa.CreatedBy,
+					sa.Description,
+				})
+			}
+			table.Render()
+		default:
+			fmt.Println("unknown format")
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getServiceAccountCmd)
+	getServiceAccountCmd.Flags().StringSlice("names", []string{}, "Filter by service account names (comma-separated)")
+	getServiceAccountCmd.Flags().UintSlice("teams", []uint{}, "Filter by team IDs (comma-separated)")
+}
@@ -49,13 +49,15 @@ Examples:
 		}
 
 		var allTags []*pb.Tag
+		var pageToken string
 		for {
 			req := &pb.ListTagsRequest{
-				Page:     page,
-				PageSize: pageSize,
-				Keys:     keys,
-				Kvs:      kvs,
-				Ids:      ids,
+				Page:      page,
+				PageSize:  pageSize,
+				PageToken: pageToken,
+				Keys:      keys,
+				Kvs:       kvs,
+				Ids:       ids,
 			}
 
 			resp, err := client.ListTags(ctx, req)
@@ -74,12 +76,11 @@ Examples:
 			// 添加当前页的标签到结果集
 			allTags = append(allTags, resp.Tags...)
 
-			// 如果返回的标签数量小于页大小，说明已经是最后一页
-			if len(resp.Tags) < int(pageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		switch GetFormat {
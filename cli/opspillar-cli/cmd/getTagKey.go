@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// getTagKeyCmd represents the getTagKey command
+var getTagKeyCmd = &cobra.Command{
+	Use:   "tagkey",
+	Short: "Get registered tag keys from the system",
+	Long: `Get registered tag keys with their allowed values and kinds.
+
+Examples:
+  opspillar get tagkey                     # List all
+  opspillar get tagkey --keys cost-center  # Filter by keys`,
+	Aliases: []string{"tagkeys", "tag-key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		keys, _ := cmd.Flags().GetStringSlice("keys")
+		ids, _ := cmd.Flags().GetUintSlice("ids")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("connect to server failed: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewTagKeysClient(conn)
+
+		var allKeys []*pb.TagKey
+		currentPage := GetPage
+		var pageToken string
+		for {
+			reply, err := client.ListTagKeys(ctx, &pb.ListTagKeysRequest{
+				Page:      currentPage,
+				PageSize:  GetPageSize,
+				PageToken: pageToken,
+				Keys:      keys,
+				Ids:       toUint32Slice(ids),
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if reply.Code != 0 {
+				fmt.Printf("Response details:\n")
+				fmt.Printf("  Message: %s\n", reply.Message)
+				fmt.Printf("  Code: %d\n", reply.Code)
+				fmt.Printf("  Action: %s\n", reply.Action)
+				return
+			}
+			allKeys = append(allKeys, reply.TagKeys...)
+			if reply.NextPageToken == "" {
+				break
+			}
+			pageToken = reply.NextPageToken
+		}
+
+		switch GetFormat {
+		case "table":
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"ID", "Key", "Allowed Values", "Pattern", "Kinds", "Required", "Description"})
+			for _, k := range allKeys {
+				table.Append([]string{
+					fmt.Sprint(k.Id),
+					k.Key,
+					strings.Join(k.AllowedValues, ","),
+					k.Pattern,
+					strings.Join(k.Kinds, ","),
+					fmt.Sprint(k.Required),
+					k.Description,
+				})
+			}
+			table.Render()
+
+		case "yaml":
+			data, err := yaml.Marshal(allKeys)
+			if err != nil {
+				log.Fatalf("serialize yaml failed: %v", err)
+			}
+			fmt.Println(string(data))
+
+		case "text":
+			if len(allKeys) == 0 {
+				fmt.Println("No tag keys found")
+				return
+			}
+			for _, k := range allKeys {
+				fmt.Printf("ID: %d, Key: %s, Allowed Values: %s, Pattern: %s, Kinds: %s, Required: %t, Description: %s\n",
+					k.Id, k.Key, strings.Join(k.AllowedValues, ","), k.Pattern, strings.Join(k.Kinds, ","), k.Required, k.Description)
+			}
+
+		default:
+			fmt.Println("unknown format")
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getTagKeyCmd)
+
+	getTagKeyCmd.Flags().StringSlice("keys", []string{}, "Filter by tag keys")
+	getTagKeyCmd.Flags().UintSlice("ids", []uint{}, "Filter by tag key IDs")
+}
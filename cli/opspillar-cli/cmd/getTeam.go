@@ -45,12 +45,14 @@ Examples:
 		// 创建一个切片存储所有团队
 		var allTeams []*pb.Team
 		currentPage := GetPage
+		var pageToken string
 
 		for {
 
 			req := &pb.ListTeamsRequest{
 				Page:      currentPage,
 				PageSize:  GetPageSize,
+				PageToken: pageToken,
 				Names:     teamNames,
 				Codes:     teamCodes,
 				LeadersId: teamLeadersId,
@@ -74,12 +76,11 @@ Examples:
 			// 添加当前页的团队到总列表
 			allTeams = append(allTeams, reply.Teams...)
 
-			// 如果返回的团队数量小于页大小，说明已经是最后一页
-			if len(reply.Teams) < int(GetPageSize) {
+			// 没有下一页的 token，说明已经是最后一页
+			if reply.NextPageToken == "" {
 				break
 			}
-
-			currentPage++
+			pageToken = reply.NextPageToken
 		}
 
 		// convert teams to TeamReadable
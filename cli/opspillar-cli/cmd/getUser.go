@@ -47,10 +47,12 @@ Examples:
 		emails, _ := cmd.Flags().GetStringSlice("email")
 
 		var allUsers []*v1.User
+		var pageToken string
 		for {
 			req := &v1.ListUsersRequest{
 				Page:      page,
 				PageSize:  pageSize,
+				PageToken: pageToken,
 				UserNames: names,
 				Ids:       ids,
 				Phones:    phones,
@@ -72,11 +74,10 @@ Examples:
 			}
 			allUsers = append(allUsers, resp.Users...)
 
-			if len(resp.Users) < int(pageSize) {
+			if resp.NextPageToken == "" {
 				break
 			}
-
-			page++
+			pageToken = resp.NextPageToken
 		}
 
 		switch GetFormat {
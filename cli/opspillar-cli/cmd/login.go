@@ -26,15 +26,22 @@ var loginCmd = &cobra.Command{
 Examples:
   opspillar login --username admin --password admin123   # Login with username and password
   opspillar login -u admin -p admin123                  # Login with short flags
+  opspillar login --sso                                 # Login with single sign-on in browser
+  opspillar login -u admin --mfa-code 123456            # Login with a TOTP code, prompted if omitted
 
 The login command will store your authentication token in ~/.opspillar/config.yaml
 which will be used for subsequent commands.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		sso, _ := cmd.Flags().GetBool("sso")
 		// Get username and password from flags
 		username, _ := cmd.Flags().GetString("username")
 		password, _ := cmd.Flags().GetString("password")
+		if !sso && username == "" {
+			fmt.Println("Username is required, or login with --sso")
+			return
+		}
 		// if password is empty, prompt for password
-		if password == "" {
+		if !sso && password == "" {
 			fmt.Print("Password: ")
 			bytePassword, err := term.ReadPassword(int(syscall.Stdin))
 			if err != nil {
@@ -55,14 +62,31 @@ which will be used for subsequent commands.`,
 
 		client := pb.NewAdminClient(conn)
 
-		// Call login API
-		resp, err := client.Login(ctx, &pb.LoginReq{
-			UserName: username,
-			Password: password,
-		})
+		var resp *pb.LoginReply
+		if sso {
+			resp, err = ssoLogin(ctx, client)
+		} else {
+			// Call login API
+			mfaCode, _ := cmd.Flags().GetString("mfa-code")
+			req := &pb.LoginReq{
+				UserName: username,
+				Password: password,
+				Device:   clientDevice(),
+				MfaCode:  mfaCode,
+			}
+			resp, err = client.Login(ctx, req)
+			// second step, the server asks for a code of the authenticator app
+			if err == nil && resp.MfaRequired && mfaCode == "" {
+				if req.MfaCode, err = readMFACode(); err != nil {
+					fmt.Println("Failed to read MFA code")
+					return
+				}
+				resp, err = client.Login(ctx, req)
+			}
+		}
 
 		if err != nil {
-			fmt.Printf("Connect to server failed: %v\n", err)
+			fmt.Printf("Login failed: %v\n", err)
 			return
 		}
 
@@ -71,48 +95,54 @@ which will be used for subsequent commands.`,
 			return
 		}
 
-		// Save token to config file
-		configPath := strings.Replace(cfgFile, "~", os.Getenv("HOME"), 1)
-		configDir := filepath.Dir(configPath)
-
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			fmt.Printf("Failed to create config directory: %v\n", err)
+		if err := saveLogin(resp.User); err != nil {
+			fmt.Println(err)
 			return
 		}
 
-		// Read existing config if it exists
-		existingConfig := make(map[string]string)
-		if existingData, err := os.ReadFile(configPath); err == nil {
-			if err := yaml.Unmarshal(existingData, &existingConfig); err != nil {
-				fmt.Printf("Failed to parse existing config: %v\n", err)
-				return
-			}
-		}
+		fmt.Println("Login successful")
+	},
+}
 
-		// Update token field
-		existingConfig["token"] = resp.User.Token
-		// Save user info
-		user, err := yaml.Marshal(resp.User)
-		if err != nil {
-			fmt.Printf("Failed to marshal user: %v\n", err)
-			return
-		}
-		existingConfig["user"] = string(user)
+// saveLogin stores tokens and user info in config file
+func saveLogin(loginUser *pb.User) error {
+	configPath := strings.Replace(cfgFile, "~", os.Getenv("HOME"), 1)
+	configDir := filepath.Dir(configPath)
 
-		// Marshal updated config
-		data, err := yaml.Marshal(existingConfig)
-		if err != nil {
-			fmt.Printf("Failed to marshal config: %v\n", err)
-			return
-		}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("Failed to create config directory: %v", err)
+	}
 
-		if err := os.WriteFile(configPath, data, 0644); err != nil {
-			fmt.Printf("Failed to write config file: %v\n", err)
-			return
+	// Read existing config if it exists
+	existingConfig := make(map[string]string)
+	if existingData, err := os.ReadFile(configPath); err == nil {
+		if err := yaml.Unmarshal(existingData, &existingConfig); err != nil {
+			return fmt.Errorf("Failed to parse existing config: %v", err)
 		}
-
-		fmt.Println("Login successful")
-	},
+	}
+
+	// Update token field
+	existingConfig["token"] = loginUser.Token
+	existingConfig["refresh_token"] = loginUser.RefreshToken
+	loginUser.Token = ""
+	loginUser.RefreshToken = ""
+	// Save user info
+	user, err := yaml.Marshal(loginUser)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal user: %v", err)
+	}
+	existingConfig["user"] = string(user)
+
+	// Marshal updated config
+	data, err := yaml.Marshal(existingConfig)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal config: %v", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("Failed to write config file: %v", err)
+	}
+	return nil
 }
 
 func init() {
@@ -129,5 +159,6 @@ func init() {
 	// loginCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	loginCmd.Flags().StringP("username", "u", "", "Username")
 	loginCmd.Flags().StringP("password", "p", "", "Password")
-	loginCmd.MarkFlagRequired("username")
+	loginCmd.Flags().Bool("sso", false, "Login with single sign-on in browser")
+	loginCmd.Flags().String("mfa-code", "", "TOTP or recovery code")
 }
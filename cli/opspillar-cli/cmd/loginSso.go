@@ -0,0 +1,135 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// ssoTimeout is how long to wait for login in browser
+const ssoTimeout = 5 * time.Minute
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// openBrowser opens url in browser, errors are ignored as url is printed too
+func openBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	_ = cmd.Start()
+}
+
+// ssoLogin runs authorization code flow with PKCE, the provider redirects
+// back to a listener on loopback.
+func ssoLogin(ctx context.Context, client pb.AdminClient) (*pb.LoginReply, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	urlResp, err := client.SSOAuthURL(ctx, &pb.SSOAuthURLReq{
+		RedirectUri:   redirectURI,
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(challenge[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if urlResp.Code != 0 {
+		return &pb.LoginReply{Code: urlResp.Code, Message: urlResp.Message, Action: urlResp.Action}, nil
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	done := make(chan result, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case q.Get("state") != state:
+			res.err = errors.New("state mismatch")
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("%s: %s", q.Get("error"), q.Get("error_description"))
+		default:
+			res.code = q.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, "Login failed: "+res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Login finished, you can close this window.")
+		}
+		select {
+		case done <- res:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	fmt.Printf("Open the url to login if browser is not opened:\n  %s\n", urlResp.Url)
+	openBrowser(urlResp.Url)
+
+	var res result
+	select {
+	case res = <-done:
+	case <-time.After(ssoTimeout):
+		return nil, errors.New("timeout waiting for login in browser")
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return client.SSOLogin(ctx, &pb.SSOLoginReq{
+		Code:         res.code,
+		CodeVerifier: verifier,
+		RedirectUri:  redirectURI,
+		Nonce:        nonce,
+		Device:       clientDevice(),
+	})
+}
@@ -57,7 +57,7 @@ Example:
 			Id: user.Id,
 		})
 		if err != nil {
-			fmt.Printf("Connect to server failed: %v\n", err)
+			fmt.Printf("Logout failed: %v\n", err)
 			return
 		}
 
@@ -80,6 +80,7 @@ Example:
 
 		// Delete token
 		delete(existingConfig, "token")
+		delete(existingConfig, "refresh_token")
 		delete(existingConfig, "user")
 
 		// Write updated config
@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// orgCmd represents the org command
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Select the organization of requests",
+	Long: `Select the organization of requests. Lists are filtered by the selected organization,
+teams and products are created in it. The --org flag overrides the selection.
+
+Example:
+  opspillar org use acme
+  opspillar org use --clear
+  opspillar get team --org acme`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+	},
+}
+
+// orgUseCmd represents the org use command
+var orgUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Save the selected organization in config",
+	Long: `Save the selected organization in config, show it without arguments.
+For example:
+  opspillar org use acme
+  opspillar org use --clear`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clearOrg, _ := cmd.Flags().GetBool("clear")
+		if len(args) == 0 && !clearOrg {
+			if org := selectedOrg(); org != "" {
+				fmt.Println(org)
+			} else {
+				fmt.Println("No organization selected, requests work on all organizations")
+			}
+			return
+		}
+
+		configPath := strings.Replace(cfgFile, "~", os.Getenv("HOME"), 1)
+		if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+			log.Fatalf("failed to create config directory: %v", err)
+		}
+		existingConfig := make(map[string]string)
+		if existingData, err := os.ReadFile(configPath); err == nil {
+			if err := yaml.Unmarshal(existingData, &existingConfig); err != nil {
+				log.Fatalf("failed to parse config: %v", err)
+			}
+		}
+		if clearOrg {
+			delete(existingConfig, "org")
+		} else {
+			existingConfig["org"] = args[0]
+		}
+		data, err := yaml.Marshal(existingConfig)
+		if err != nil {
+			log.Fatalf("failed to marshal config: %v", err)
+		}
+		if err := os.WriteFile(configPath, data, 0600); err != nil {
+			log.Fatalf("failed to write config: %v", err)
+		}
+		if clearOrg {
+			fmt.Println("Organization selection cleared")
+		} else {
+			fmt.Printf("Switched to organization %s\n", args[0])
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orgCmd)
+	orgCmd.AddCommand(orgUseCmd)
+	orgUseCmd.Flags().Bool("clear", false, "Clear the selection, requests work on all organizations")
+}
@@ -5,15 +5,24 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
+	pb "opspillar/api/opspillar/v1"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/golang-jwt/jwt"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v2"
 )
 
@@ -35,16 +44,108 @@ func Execute() {
 
 var cfgFile string
 var serverAddr string
+var orgName string
 
 func init() {
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "~/.opspillar/config.yaml", "config file")
 	rootCmd.PersistentFlags().StringVarP(&serverAddr, "server", "s", "127.0.0.1:9000", "Appix server address")
+	rootCmd.PersistentFlags().StringVar(&orgName, "org", "", "organization of requests, the one of 'org use' if not set")
 
 }
 
+// selectedOrg is the organization of requests, the flag first and then the config file
+func selectedOrg() string {
+	if orgName != "" {
+		return orgName
+	}
+	configPath := strings.Replace(cfgFile, "~", os.Getenv("HOME"), 1)
+	config := make(map[string]string)
+	if data, err := os.ReadFile(configPath); err == nil {
+		_ = yaml.Unmarshal(data, &config)
+	}
+	return config["org"]
+}
+
 func NewConnection(withToken bool) (context.Context, *grpc.ClientConn, error) {
+	ctx, conn, err := newConnection(withToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Lists are filtered by the selected organization, teams and products are created in it
+	if org := selectedOrg(); org != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-org", org)
+	}
+	return ctx, conn, nil
+}
+
+// dial creates the gRPC client of server, errors of its requests are rendered by renderErrors
+func dial() (*grpc.ClientConn, error) {
+	return grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(renderErrors))
+}
+
+// renderErrors turns status errors into their kind and message, followed by the fields at
+// fault, like "not found: tag 9 not found" instead of "rpc error: code = NotFound desc = ..."
+func renderErrors(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+	// the reason is the kind of domain errors, other errors only have a code
+	se := kerrors.FromError(err)
+	kind := se.Reason
+	if kind == "" {
+		kind = s.Code().String()
+	}
+	var b strings.Builder
+	for i, r := range kind {
+		switch {
+		case r == '_':
+			r = ' '
+		case i > 0 && unicode.IsUpper(r) && unicode.IsLower(rune(kind[i-1])):
+			b.WriteRune(' ')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	b.WriteString(": " + s.Message())
+
+	names := make([]string, 0, len(se.Metadata))
+	for name := range se.Metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString("\n  " + name + ": " + se.Metadata[name])
+	}
+	return errors.New(b.String())
+}
+
+func newConnection(withToken bool) (context.Context, *grpc.ClientConn, error) {
 	ctx := context.Background()
+	if secret := os.Getenv(breakGlassEnv); withToken && secret != "" {
+		// Emergency access with a break-glass credential, every request is audited
+		conn, err := dial()
+		if err != nil {
+			fmt.Printf("Failed to connect to server: %v\n", err)
+			return nil, nil, err
+		}
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("x-break-glass", secret))
+		return ctx, conn, nil
+	}
+	if apiKey := os.Getenv(apiKeyEnv); withToken && apiKey != "" {
+		// Service accounts authenticate with api key
+		conn, err := dial()
+		if err != nil {
+			fmt.Printf("Failed to connect to server: %v\n", err)
+			return nil, nil, err
+		}
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("x-api-key", apiKey))
+		return ctx, conn, nil
+	}
 	if withToken {
 		// Read token from config file
 		configPath := strings.Replace(cfgFile, "~", os.Getenv("HOME"), 1)
@@ -63,13 +164,31 @@ func NewConnection(withToken bool) (context.Context, *grpc.ClientConn, error) {
 			return nil, nil, fmt.Errorf("no active session found")
 		}
 
+		// Create gRPC client
+		conn, err := dial()
+		if err != nil {
+			fmt.Printf("Failed to connect to server: %v\n", err)
+			return nil, nil, err
+		}
+
+		// Refresh token before it expires
+		if existingConfig["refresh_token"] != "" && tokenExpiring(token) {
+			refreshed, err := refreshToken(ctx, conn, configPath, existingConfig)
+			if err != nil {
+				fmt.Printf("Failed to refresh token: %v\n", err)
+			} else {
+				token = refreshed
+			}
+		}
+
 		// Create context with token
 		md := metadata.Pairs("Authorization", "Bearer "+token)
 		ctx = metadata.NewOutgoingContext(ctx, md)
+		return ctx, conn, nil
 	}
 
 	// Create gRPC client
-	conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := dial()
 	if err != nil {
 		fmt.Printf("Failed to connect to server: %v\n", err)
 		return nil, nil, err
@@ -77,6 +196,60 @@ func NewConnection(withToken bool) (context.Context, *grpc.ClientConn, error) {
 	return ctx, conn, nil
 }
 
+// apiKeyEnv is the environment variable of api key, used instead of login session
+const apiKeyEnv = "OPSPILLAR_API_KEY"
+
+// breakGlassEnv is the environment variable of break-glass credential, used before api key and login session
+const breakGlassEnv = "OPSPILLAR_BREAK_GLASS"
+
+// refreshBefore is how long before expiration the access token is refreshed
+const refreshBefore = time.Minute
+
+// tokenExpiring checks exp of token without verifying the signature
+func tokenExpiring(token string) bool {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return false
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return false
+	}
+	return time.Until(time.Unix(int64(exp), 0)) < refreshBefore
+}
+
+// refreshToken exchanges the refresh token in config and saves the new tokens
+func refreshToken(ctx context.Context, conn *grpc.ClientConn, configPath string, config map[string]string) (string, error) {
+	client := pb.NewAdminClient(conn)
+	resp, err := client.RefreshToken(ctx, &pb.RefreshTokenReq{
+		RefreshToken: config["refresh_token"],
+		Device:       clientDevice(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Code != 0 {
+		return "", errors.New(resp.Message)
+	}
+
+	config["token"] = resp.User.Token
+	config["refresh_token"] = resp.User.RefreshToken
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return "", err
+	}
+	return resp.User.Token, nil
+}
+
+// clientDevice is shown in sessions of user
+func clientDevice() string {
+	hostname, _ := os.Hostname()
+	return "opspillar-cli@" + hostname
+}
+
 // toUint32Slice: []uint to  []uint32
 func toUint32Slice(slice []uint) []uint32 {
 	result := make([]uint32, len(slice))
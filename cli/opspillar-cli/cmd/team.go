@@ -0,0 +1,31 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// teamCmd represents the team command
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Manage team members",
+	Long: `Manage members of a team. Maintainers and the leader can modify the team's resources,
+the leader is changed by updating the team.
+
+Example:
+  opspillar team members 1
+  opspillar team add-member 1 --user 2 --role maintainer
+  opspillar team remove-member 1 --user 2`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+}
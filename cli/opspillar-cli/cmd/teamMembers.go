@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+func parseTeamId(arg string) (uint32, bool) {
+	id, err := strconv.ParseUint(arg, 10, 32)
+	if err != nil {
+		fmt.Printf("Invalid team ID '%s': %v\n", arg, err)
+		return 0, false
+	}
+	return uint32(id), true
+}
+
+// teamMembersCmd represents the team members command
+var teamMembersCmd = &cobra.Command{
+	Use:   "members [team id]",
+	Short: "List members of a team",
+	Long: `List members of a team, the leader first.
+For example:
+  opspillar team members 1`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		teamId, ok := parseTeamId(args[0])
+		if !ok {
+			return
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewTeamsClient(conn)
+		reply, err := client.ListTeamMembers(ctx, &pb.ListTeamMembersRequest{TeamId: teamId})
+		if err != nil {
+			log.Fatalf("failed to list team members: %v", err)
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", reply.Message)
+			fmt.Printf("  Code: %d\n", reply.Code)
+			fmt.Printf("  Action: %s\n", reply.Action)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"User ID", "User", "Role", "Added"})
+		table.SetAutoFormatHeaders(true)
+		for _, m := range reply.Members {
+			added := "-"
+			if m.CreatedAt > 0 {
+				added = time.Unix(m.CreatedAt, 0).Format(time.DateTime)
+			}
+			table.Append([]string{fmt.Sprint(m.UserId), m.UserName, m.Role, added})
+		}
+		table.Render()
+	},
+}
+
+// teamAddMemberCmd represents the team add-member command
+var teamAddMemberCmd = &cobra.Command{
+	Use:   "add-member [team id]",
+	Short: "Add users to a team",
+	Long: `Add users to a team as members or maintainers, roles of existing members are changed.
+For example:
+  opspillar team add-member 1 --user 2,3
+  opspillar team add-member 1 --user 2 --role maintainer`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		teamId, ok := parseTeamId(args[0])
+		if !ok {
+			return
+		}
+		users, _ := cmd.Flags().GetUintSlice("user")
+		role, _ := cmd.Flags().GetString("role")
+		members := make([]*pb.TeamMember, len(users))
+		for i, u := range users {
+			members[i] = &pb.TeamMember{UserId: uint32(u), Role: role}
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewTeamsClient(conn)
+		reply, err := client.AddTeamMembers(ctx, &pb.AddTeamMembersRequest{TeamId: teamId, Members: members})
+		if err != nil {
+			log.Fatalf("failed to add team members: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+// teamRemoveMemberCmd represents the team remove-member command
+var teamRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member [team id]",
+	Short: "Remove users from a team",
+	Long: `Remove users from a team, the leader can not be removed.
+For example:
+  opspillar team remove-member 1 --user 2`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		teamId, ok := parseTeamId(args[0])
+		if !ok {
+			return
+		}
+		users, _ := cmd.Flags().GetUintSlice("user")
+		userIds := make([]uint32, len(users))
+		for i, u := range users {
+			userIds[i] = uint32(u)
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewTeamsClient(conn)
+		reply, err := client.RemoveTeamMembers(ctx, &pb.RemoveTeamMembersRequest{TeamId: teamId, UserIds: userIds})
+		if err != nil {
+			log.Fatalf("failed to remove team members: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+func init() {
+	teamCmd.AddCommand(teamMembersCmd)
+	teamCmd.AddCommand(teamAddMemberCmd)
+	teamCmd.AddCommand(teamRemoveMemberCmd)
+	for _, c := range []*cobra.Command{teamAddMemberCmd, teamRemoveMemberCmd} {
+		c.Flags().UintSlice("user", []uint{}, "User IDs (comma-separated)")
+		c.MarkFlagRequired("user")
+	}
+	teamAddMemberCmd.Flags().String("role", "member", "Role, member or maintainer")
+}
@@ -4,7 +4,15 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
+	"log"
+	"sort"
+
+	pb "opspillar/api/opspillar/v1"
+
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // updateCmd represents the update command
@@ -19,6 +27,85 @@ var updateCmd = &cobra.Command{
 
 var updateFile string
 var updateOnline bool
+var updatePartial bool
+
+// lookupPageSize is the page size of lists resolving names given on the command line, one
+// page holds all of them.
+const lookupPageSize = 100
+
+// changedMask returns the update mask of the fields whose flags were set, fields maps flag
+// names to field names. It is nil when no flag was set.
+func changedMask(cmd *cobra.Command, fields map[string]string) *fieldmaskpb.FieldMask {
+	var paths []string
+	for flag, field := range fields {
+		if cmd.Flags().Changed(flag) {
+			paths = append(paths, field)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Strings(paths)
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+// tagIds returns the ids of tags given as key:value.
+func tagIds(ctx context.Context, conn *grpc.ClientConn, kvs []string) []uint32 {
+	if len(kvs) == 0 {
+		return nil
+	}
+	resp, err := pb.NewTagsClient(conn).ListTags(ctx, &pb.ListTagsRequest{
+		Page: 1, PageSize: lookupPageSize, Kvs: kvs})
+	if err != nil {
+		log.Fatalf("failed to list tags: %v", err)
+	}
+	if resp.Code != 0 {
+		log.Fatalf("failed to list tags: %s", resp.Message)
+	}
+	ids := make([]uint32, 0, len(kvs))
+	for _, kv := range kvs {
+		id, found := uint32(0), false
+		for _, tag := range resp.Tags {
+			if tag.Key+":"+tag.Value == kv {
+				id, found = tag.Id, true
+			}
+		}
+		if !found {
+			log.Fatalf("tag %s not found", kv)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// featureIds returns the ids of features given as name:value.
+func featureIds(ctx context.Context, conn *grpc.ClientConn, kvs []string) []uint32 {
+	if len(kvs) == 0 {
+		return nil
+	}
+	resp, err := pb.NewFeaturesClient(conn).ListFeatures(ctx, &pb.ListFeaturesRequest{
+		Page: 1, PageSize: lookupPageSize, Kvs: kvs})
+	if err != nil {
+		log.Fatalf("failed to list features: %v", err)
+	}
+	if resp.Code != 0 {
+		log.Fatalf("failed to list features: %s", resp.Message)
+	}
+	ids := make([]uint32, 0, len(kvs))
+	for _, kv := range kvs {
+		id, found := uint32(0), false
+		for _, ft := range resp.Features {
+			if ft.Name+":"+ft.Value == kv {
+				id, found = ft.Id, true
+			}
+		}
+		if !found {
+			log.Fatalf("feature %s not found", kv)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
 
 func init() {
 	rootCmd.AddCommand(updateCmd)
@@ -30,6 +117,7 @@ func init() {
 	// updateCmd.PersistentFlags().String("foo", "", "A help for foo")
 	updateCmd.PersistentFlags().StringVarP(&updateFile, "yaml", "f", "", "YAML file to update (cannot be used with --edit)")
 	updateCmd.PersistentFlags().BoolVarP(&updateOnline, "edit", "e", false, "Edit online (cannot be used with --yaml)")
+	updateCmd.PersistentFlags().BoolVar(&updatePartial, "partial", false, "Update the valid resources and report the errors of the others")
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
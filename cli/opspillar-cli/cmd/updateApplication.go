@@ -4,6 +4,7 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -17,9 +18,17 @@ import (
 
 // updateApplicationCmd represents the updateApplication command
 var updateApplicationCmd = &cobra.Command{
-	Use:     "app",
-	Short:   "Update application",
-	Long:    `Update one or more applications with the specified ID and fields.`,
+	Use:   "app [NAME...]",
+	Short: "Update application",
+	Long: `Update one or more applications with the specified ID and fields.
+
+Examples:
+  # Update via command line flags, only the given fields are changed
+  opspillar update app --id 1 --desc "New description"
+  opspillar update app shop --team-id 2
+
+  # Add and remove tags and features of applications by name, other relations are kept
+  opspillar update app shop cart --add-tag env:prod --remove-feature gpu:v100`,
 	Aliases: []string{"application", "applications", "app", "apps"},
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, conn, err := NewConnection(true)
@@ -30,7 +39,7 @@ var updateApplicationCmd = &cobra.Command{
 
 		client := pb.NewApplicationsClient(conn)
 
-		var applications []*pb.Application
+		req := &pb.UpdateApplicationsRequest{}
 		if updateOnline {
 			// Get existing application data
 			id, _ := cmd.Flags().GetUint32("id")
@@ -87,7 +96,7 @@ var updateApplicationCmd = &cobra.Command{
 			}
 
 			// Parse updated YAML
-			if err := yaml.Unmarshal(updatedData, &applications); err != nil {
+			if err := yaml.Unmarshal(updatedData, &req.Apps); err != nil {
 				log.Fatalf("failed to parse updated yaml: %v", err)
 			}
 
@@ -98,56 +107,70 @@ var updateApplicationCmd = &cobra.Command{
 				log.Fatalf("failed to read yaml file: %v", err)
 			}
 
-			if err := yaml.Unmarshal(data, &applications); err != nil {
+			if err := yaml.Unmarshal(data, &req.Apps); err != nil {
 				log.Fatalf("failed to parse yaml: %v", err)
 			}
 		} else {
 			// Command line update
-			id, _ := cmd.Flags().GetUint32("id")
-			if id == 0 {
-				log.Fatal("id is required for command line update")
-			}
-			name, _ := cmd.Flags().GetString("name")
-			desc, _ := cmd.Flags().GetString("desc")
-			ownerId, _ := cmd.Flags().GetUint32("ownerId")
-			isStateful, _ := cmd.Flags().GetBool("isStateful")
-			productId, _ := cmd.Flags().GetUint32("productId")
-			teamId, _ := cmd.Flags().GetUint32("teamId")
-			featuresId, _ := cmd.Flags().GetUintSlice("featuresId")
-			tagsId, _ := cmd.Flags().GetUintSlice("tagsId")
-			hostgroupsId, _ := cmd.Flags().GetUintSlice("hostgroupsId")
-
-			// convert featuresId, tagsId, and hostgroupsId to uint32
-			var _featuresId, _tagsId, _hostgroupsId []uint32
-			for _, id := range featuresId {
-				_featuresId = append(_featuresId, uint32(id))
-			}
-			for _, id := range tagsId {
-				_tagsId = append(_tagsId, uint32(id))
-			}
-			for _, id := range hostgroupsId {
-				_hostgroupsId = append(_hostgroupsId, uint32(id))
-			}
-
-			applications = []*pb.Application{
-				{
-					Id:           id,
-					Name:         name,
-					Description:  desc,
-					OwnerId:      ownerId,
-					IsStateful:   isStateful,
-					ProductId:    productId,
-					TeamId:       teamId,
-					FeaturesId:   _featuresId,
-					TagsId:       _tagsId,
-					HostgroupsId: _hostgroupsId,
-				},
+			ids := appIdsByName(ctx, client, args)
+			if id, _ := cmd.Flags().GetUint32("id"); id != 0 {
+				ids = append(ids, id)
+			}
+			if len(ids) == 0 {
+				log.Fatal("id or name is required for command line update")
+			}
+			addTags, _ := cmd.Flags().GetStringSlice("add-tag")
+			removeTags, _ := cmd.Flags().GetStringSlice("remove-tag")
+			addFeatures, _ := cmd.Flags().GetStringSlice("add-feature")
+			removeFeatures, _ := cmd.Flags().GetStringSlice("remove-feature")
+			if len(addTags)+len(removeTags)+len(addFeatures)+len(removeFeatures) > 0 {
+				req.Ids = ids
+				req.Add = &pb.AppRelations{
+					TagsId:     tagIds(ctx, conn, addTags),
+					FeaturesId: featureIds(ctx, conn, addFeatures),
+				}
+				req.Remove = &pb.AppRelations{
+					TagsId:     tagIds(ctx, conn, removeTags),
+					FeaturesId: featureIds(ctx, conn, removeFeatures),
+				}
+			} else {
+				if len(ids) > 1 {
+					log.Fatal("only one application can be updated by flags, use --add-* and --remove-* for more")
+				}
+				name, _ := cmd.Flags().GetString("name")
+				desc, _ := cmd.Flags().GetString("desc")
+				ownerId, _ := cmd.Flags().GetUint32("ownerId")
+				isStateful, _ := cmd.Flags().GetBool("is-stateful")
+				productId, _ := cmd.Flags().GetUint32("product-id")
+				teamId, _ := cmd.Flags().GetUint32("team-id")
+				featuresId, _ := cmd.Flags().GetUintSlice("features-id")
+				tagsId, _ := cmd.Flags().GetUintSlice("tags-id")
+				hostgroupsId, _ := cmd.Flags().GetUintSlice("hostgroups-id")
+				attrs, _ := cmd.Flags().GetStringToString("attr")
+
+				req.Apps = []*pb.Application{
+					{
+						Id:           ids[0],
+						Name:         name,
+						Description:  desc,
+						OwnerId:      ownerId,
+						IsStateful:   isStateful,
+						ProductId:    productId,
+						TeamId:       teamId,
+						FeaturesId:   toUint32Slice(featuresId),
+						TagsId:       toUint32Slice(tagsId),
+						HostgroupsId: toUint32Slice(hostgroupsId),
+						Attributes:   attrs,
+					},
+				}
+				req.UpdateMask = changedMask(cmd, appFlagFields)
+				if req.UpdateMask == nil {
+					log.Fatal("nothing to update")
+				}
 			}
 		}
 
-		req := &pb.UpdateApplicationsRequest{
-			Apps: applications,
-		}
+		req.Partial = updatePartial
 
 		reply, err := client.UpdateApplications(ctx, req)
 		if err != nil {
@@ -158,10 +181,53 @@ var updateApplicationCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Apps, reply.Errors)
 		}
 	},
 }
 
+// appFlagFields maps update flags to application fields.
+var appFlagFields = map[string]string{
+	"name":          "name",
+	"desc":          "description",
+	"ownerId":       "owner_id",
+	"is-stateful":   "is_stateful",
+	"product-id":    "product_id",
+	"team-id":       "team_id",
+	"features-id":   "features_id",
+	"tags-id":       "tags_id",
+	"hostgroups-id": "hostgroups_id",
+	"attr":          "attributes",
+}
+
+// appIdsByName returns the ids of applications named exactly names.
+func appIdsByName(ctx context.Context, client pb.ApplicationsClient, names []string) []uint32 {
+	if len(names) == 0 {
+		return nil
+	}
+	resp, err := client.ListApplications(ctx, &pb.ListApplicationsRequest{
+		Page: 1, PageSize: lookupPageSize, Names: names, NameMatch: "exact"})
+	if err != nil {
+		log.Fatalf("failed to list applications: %v", err)
+	}
+	if resp.Code != 0 {
+		log.Fatalf("failed to list applications: %s", resp.Message)
+	}
+	ids := make([]uint32, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, app := range resp.Apps {
+			if app.Name == name {
+				ids, found = append(ids, app.Id), true
+			}
+		}
+		if !found {
+			log.Fatalf("application %s not found", name)
+		}
+	}
+	return ids
+}
+
 func init() {
 	updateCmd.AddCommand(updateApplicationCmd)
 
@@ -175,4 +241,9 @@ func init() {
 	updateApplicationCmd.Flags().UintSlice("features-id", []uint{}, "New application features ID")
 	updateApplicationCmd.Flags().UintSlice("tags-id", []uint{}, "New application tags ID")
 	updateApplicationCmd.Flags().UintSlice("hostgroups-id", []uint{}, "New application hostgroups ID")
+	updateApplicationCmd.Flags().StringToString("attr", map[string]string{}, "New custom attribute values, name=value")
+	updateApplicationCmd.Flags().StringSlice("add-tag", []string{}, "Tags to add, key:value")
+	updateApplicationCmd.Flags().StringSlice("remove-tag", []string{}, "Tags to remove, key:value")
+	updateApplicationCmd.Flags().StringSlice("add-feature", []string{}, "Features to add, name:value")
+	updateApplicationCmd.Flags().StringSlice("remove-feature", []string{}, "Features to remove, name:value")
 }
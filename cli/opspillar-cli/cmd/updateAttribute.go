@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// updateAttributeCmd represents the updateAttribute command
+var updateAttributeCmd = &cobra.Command{
+	Use:   "attr",
+	Short: "Update custom attribute",
+	Long: `Update the definition of a custom attribute, admin only. Kinds and names can not be changed.
+
+Examples:
+  opspillar update attr --id 1 --required=false
+  opspillar update attr --id 1 --enum gold,silver,bronze`,
+	Aliases: []string{"attrs", "attribute", "attributes"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			fmt.Printf("Failed to connect to server: %v\n", err)
+			return
+		}
+		defer conn.Close()
+		client := pb.NewAttributesClient(conn)
+
+		id, _ := cmd.Flags().GetUint32("id")
+		if id == 0 {
+			log.Fatal("id is required for command line update")
+		}
+		getResp, err := client.GetAttributes(ctx, &pb.GetAttributesRequest{Id: id})
+		if err != nil {
+			log.Fatalf("failed to get attribute: %v", err)
+		}
+		if getResp.Code != 0 {
+			log.Fatalf("failed to get attribute: %s", getResp.Message)
+		}
+		// unchanged fields keep their values
+		attr := getResp.Attribute
+		if cmd.Flags().Changed("type") {
+			attr.Type, _ = cmd.Flags().GetString("type")
+		}
+		if cmd.Flags().Changed("required") {
+			attr.Required, _ = cmd.Flags().GetBool("required")
+		}
+		if cmd.Flags().Changed("enum") {
+			attr.EnumValues, _ = cmd.Flags().GetStringSlice("enum")
+		}
+		if cmd.Flags().Changed("pattern") {
+			attr.Pattern, _ = cmd.Flags().GetString("pattern")
+		}
+		if cmd.Flags().Changed("desc") {
+			attr.Description, _ = cmd.Flags().GetString("desc")
+		}
+
+		reply, err := client.UpdateAttributes(ctx, &pb.UpdateAttributesRequest{
+			Attributes: []*pb.Attribute{attr},
+			Partial:    updatePartial,
+		})
+		if err != nil {
+			log.Fatalf("failed to update attribute: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Attributes, reply.Errors)
+		}
+	},
+}
+
+func init() {
+	updateCmd.AddCommand(updateAttributeCmd)
+
+	updateAttributeCmd.Flags().Uint32("id", 0, "Attribute ID to update")
+	updateAttributeCmd.Flags().String("type", "", "New type, string, int, bool or enum")
+	updateAttributeCmd.Flags().Bool("required", false, "Entities must have the attribute")
+	updateAttributeCmd.Flags().StringSlice("enum", []string{}, "Allowed values of enum attributes")
+	updateAttributeCmd.Flags().String("pattern", "", "Regular expression the whole value must match")
+	updateAttributeCmd.Flags().String("desc", "", "New attribute description")
+}
@@ -121,6 +121,7 @@ var updateClusterCmd = &cobra.Command{
 
 		req := &pb.UpdateClustersRequest{
 			Clusters: clusters,
+			Partial:  updatePartial,
 		}
 
 		reply, err := client.UpdateClusters(ctx, req)
@@ -132,6 +133,7 @@ var updateClusterCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Clusters, reply.Errors)
 		}
 	},
 }
@@ -121,6 +121,7 @@ var updateDatacenterCmd = &cobra.Command{
 
 		req := &pb.UpdateDatacentersRequest{
 			Datacenters: datacenters,
+			Partial:     updatePartial,
 		}
 
 		reply, err := client.UpdateDatacenters(ctx, req)
@@ -132,6 +133,7 @@ var updateDatacenterCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Datacenters, reply.Errors)
 		}
 	},
 }
@@ -119,7 +119,8 @@ var updateEnvCmd = &cobra.Command{
 		}
 
 		req := &pb.UpdateEnvsRequest{
-			Envs: envs,
+			Envs:    envs,
+			Partial: updatePartial,
 		}
 
 		reply, err := client.UpdateEnvs(ctx, req)
@@ -131,6 +132,7 @@ var updateEnvCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Envs, reply.Errors)
 		}
 	},
 }
@@ -120,6 +120,7 @@ var updateFeatureCmd = &cobra.Command{
 
 		req := &pb.UpdateFeaturesRequest{
 			Features: features,
+			Partial:  updatePartial,
 		}
 
 		reply, err := client.UpdateFeatures(ctx, req)
@@ -131,6 +132,7 @@ var updateFeatureCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Features, reply.Errors)
 		}
 	},
 }
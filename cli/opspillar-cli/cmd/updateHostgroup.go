@@ -4,6 +4,7 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -17,13 +18,17 @@ import (
 
 // updateHostgroupCmd represents the updateHostgroup command
 var updateHostgroupCmd = &cobra.Command{
-	Use:   "hostgroup",
+	Use:   "hostgroup [NAME...]",
 	Short: "Update hostgroup information",
 	Long: `Update hostgroup information. Can update via command line flags, YAML file, or interactive editor.
 	
 Examples:
-  # Update via command line flags
+  # Update via command line flags, only the given fields are changed
   opspillar update hostgroup --id 1 --name "New Name" --desc "New description"
+  opspillar update hostgroup web --desc "New description"
+
+  # Add and remove tags and features of hostgroups by name, other relations are kept
+  opspillar update hg web db --add-tag env:prod --remove-feature gpu:v100
 
   # Update via YAML file
   opspillar update hostgroup --yaml hostgroups.yaml
@@ -39,7 +44,7 @@ Examples:
 		defer conn.Close()
 		client := pb.NewHostgroupsClient(conn)
 
-		var hostgroups []*pb.Hostgroup
+		req := &pb.UpdateHostgroupsRequest{}
 		if updateOnline {
 			// Get existing hostgroup data
 			id, _ := cmd.Flags().GetUint32("id")
@@ -96,7 +101,7 @@ Examples:
 			}
 
 			// Parse updated YAML
-			if err := yaml.Unmarshal(updatedData, &hostgroups); err != nil {
+			if err := yaml.Unmarshal(updatedData, &req.Hostgroups); err != nil {
 				log.Fatalf("failed to parse updated yaml: %v", err)
 			}
 
@@ -107,54 +112,80 @@ Examples:
 				log.Fatalf("failed to read yaml file: %v", err)
 			}
 
-			if err := yaml.Unmarshal(data, &hostgroups); err != nil {
+			if err := yaml.Unmarshal(data, &req.Hostgroups); err != nil {
 				log.Fatalf("failed to parse yaml: %v", err)
 			}
 		} else {
 			// Command line update
-			id, _ := cmd.Flags().GetUint32("id")
-			if id == 0 {
-				log.Fatal("id is required for command line update")
-			}
-			name, _ := cmd.Flags().GetString("name")
-			desc, _ := cmd.Flags().GetString("desc")
-			clusterId, _ := cmd.Flags().GetUint32("cluster-id")
-			datacenterId, _ := cmd.Flags().GetUint32("datacenter-id")
-			envId, _ := cmd.Flags().GetUint32("env-id")
-			productId, _ := cmd.Flags().GetUint32("product-id")
-			teamId, _ := cmd.Flags().GetUint32("team-id")
-			uintFeatures, _ := cmd.Flags().GetUintSlice("features-id")
-			uintTags, _ := cmd.Flags().GetUintSlice("tags-id")
-			uintShareProducts, _ := cmd.Flags().GetUintSlice("share-products-id")
-			uintShareTeams, _ := cmd.Flags().GetUintSlice("share-teams-id")
-
-			featuresId := toUint32Slice(uintFeatures)
-			tagsId := toUint32Slice(uintTags)
-			shareProductsId := toUint32Slice(uintShareProducts)
-			shareTeamsId := toUint32Slice(uintShareTeams)
-
-			hostgroups = []*pb.Hostgroup{
-				{
-					Id:              id,
-					Name:            name,
-					Description:     desc,
-					ClusterId:       clusterId,
-					DatacenterId:    datacenterId,
-					EnvId:           envId,
-					ProductId:       productId,
-					TeamId:          teamId,
-					FeaturesId:      featuresId,
-					TagsId:          tagsId,
-					ShareProductsId: shareProductsId,
-					ShareTeamsId:    shareTeamsId,
-				},
+			ids := hostgroupIdsByName(ctx, client, args)
+			if id, _ := cmd.Flags().GetUint32("id"); id != 0 {
+				ids = append(ids, id)
 			}
-		}
+			if len(ids) == 0 {
+				log.Fatal("id or name is required for command line update")
+			}
+			addTags, _ := cmd.Flags().GetStringSlice("add-tag")
+			removeTags, _ := cmd.Flags().GetStringSlice("remove-tag")
+			addFeatures, _ := cmd.Flags().GetStringSlice("add-feature")
+			removeFeatures, _ := cmd.Flags().GetStringSlice("remove-feature")
+			if len(addTags)+len(removeTags)+len(addFeatures)+len(removeFeatures) > 0 {
+				req.Ids = ids
+				req.Add = &pb.HostgroupRelations{
+					TagsId:     tagIds(ctx, conn, addTags),
+					FeaturesId: featureIds(ctx, conn, addFeatures),
+				}
+				req.Remove = &pb.HostgroupRelations{
+					TagsId:     tagIds(ctx, conn, removeTags),
+					FeaturesId: featureIds(ctx, conn, removeFeatures),
+				}
+			} else {
+				if len(ids) > 1 {
+					log.Fatal("only one hostgroup can be updated by flags, use --add-* and --remove-* for more")
+				}
+				name, _ := cmd.Flags().GetString("name")
+				desc, _ := cmd.Flags().GetString("desc")
+				clusterId, _ := cmd.Flags().GetUint32("cluster-id")
+				datacenterId, _ := cmd.Flags().GetUint32("dc-id")
+				envId, _ := cmd.Flags().GetUint32("env-id")
+				productId, _ := cmd.Flags().GetUint32("product-id")
+				teamId, _ := cmd.Flags().GetUint32("team-id")
+				uintFeatures, _ := cmd.Flags().GetUintSlice("features-id")
+				uintTags, _ := cmd.Flags().GetUintSlice("tags-id")
+				uintShareProducts, _ := cmd.Flags().GetUintSlice("share-products-id")
+				uintShareTeams, _ := cmd.Flags().GetUintSlice("share-teams-id")
+				attrs, _ := cmd.Flags().GetStringToString("attr")
+
+				featuresId := toUint32Slice(uintFeatures)
+				tagsId := toUint32Slice(uintTags)
+				shareProductsId := toUint32Slice(uintShareProducts)
+				shareTeamsId := toUint32Slice(uintShareTeams)
 
-		req := &pb.UpdateHostgroupsRequest{
-			Hostgroups: hostgroups,
+				req.Hostgroups = []*pb.Hostgroup{
+					{
+						Id:              ids[0],
+						Name:            name,
+						Description:     desc,
+						ClusterId:       clusterId,
+						DatacenterId:    datacenterId,
+						EnvId:           envId,
+						ProductId:       productId,
+						TeamId:          teamId,
+						FeaturesId:      featuresId,
+						TagsId:          tagsId,
+						ShareProductsId: shareProductsId,
+						ShareTeamsId:    shareTeamsId,
+						Attributes:      attrs,
+					},
+				}
+				req.UpdateMask = changedMask(cmd, hostgroupFlagFields)
+				if req.UpdateMask == nil {
+					log.Fatal("nothing to update")
+				}
+			}
 		}
 
+		req.Partial = updatePartial
+
 		reply, err := client.UpdateHostgroups(ctx, req)
 		if err != nil {
 			log.Fatalf("failed to update hostgroup: %v", err)
@@ -164,10 +195,55 @@ Examples:
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Hostgroups, reply.Errors)
 		}
 	},
 }
 
+// hostgroupFlagFields maps update flags to hostgroup fields.
+var hostgroupFlagFields = map[string]string{
+	"name":              "name",
+	"desc":              "description",
+	"cluster-id":        "cluster_id",
+	"dc-id":             "datacenter_id",
+	"env-id":            "env_id",
+	"product-id":        "product_id",
+	"team-id":           "team_id",
+	"features-id":       "features_id",
+	"tags-id":           "tags_id",
+	"share-products-id": "share_products_id",
+	"share-teams-id":    "share_teams_id",
+	"attr":              "attributes",
+}
+
+// hostgroupIdsByName returns the ids of hostgroups named exactly names.
+func hostgroupIdsByName(ctx context.Context, client pb.HostgroupsClient, names []string) []uint32 {
+	if len(names) == 0 {
+		return nil
+	}
+	resp, err := client.ListHostgroups(ctx, &pb.ListHostgroupsRequest{
+		Page: 1, PageSize: lookupPageSize, Names: names, NameMatch: "exact"})
+	if err != nil {
+		log.Fatalf("failed to list hostgroups: %v", err)
+	}
+	if resp.Code != 0 {
+		log.Fatalf("failed to list hostgroups: %s", resp.Message)
+	}
+	ids := make([]uint32, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, hg := range resp.Hostgroups {
+			if hg.Name == name {
+				ids, found = append(ids, hg.Id), true
+			}
+		}
+		if !found {
+			log.Fatalf("hostgroup %s not found", name)
+		}
+	}
+	return ids
+}
+
 func init() {
 	updateCmd.AddCommand(updateHostgroupCmd)
 
@@ -183,4 +259,9 @@ func init() {
 	updateHostgroupCmd.Flags().UintSlice("tags-id", []uint{}, "New tag IDs")
 	updateHostgroupCmd.Flags().UintSlice("share-products-id", []uint{}, "New shared product IDs")
 	updateHostgroupCmd.Flags().UintSlice("share-teams-id", []uint{}, "New shared team IDs")
+	updateHostgroupCmd.Flags().StringToString("attr", map[string]string{}, "New custom attribute values, name=value")
+	updateHostgroupCmd.Flags().StringSlice("add-tag", []string{}, "Tags to add, key:value")
+	updateHostgroupCmd.Flags().StringSlice("remove-tag", []string{}, "Tags to remove, key:value")
+	updateHostgroupCmd.Flags().StringSlice("add-feature", []string{}, "Features to add, name:value")
+	updateHostgroupCmd.Flags().StringSlice("remove-feature", []string{}, "Features to remove, name:value")
 }
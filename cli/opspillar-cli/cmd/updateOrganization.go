@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// updateOrganizationCmd represents the updateOrganization command
+var updateOrganizationCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Update organization",
+	Long: `Update the description and admins of an organization, names can not be changed.
+Admins of the organization may update it.
+
+Examples:
+  opspillar update org --id 2 --admins alice,carol`,
+	Aliases: []string{"orgs", "organization", "organizations"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			fmt.Printf("Failed to connect to server: %v\n", err)
+			return
+		}
+		defer conn.Close()
+		client := pb.NewOrganizationsClient(conn)
+
+		id, _ := cmd.Flags().GetUint32("id")
+		if id == 0 {
+			log.Fatal("id is required for command line update")
+		}
+		getResp, err := client.GetOrganizations(ctx, &pb.GetOrganizationsRequest{Id: id})
+		if err != nil {
+			log.Fatalf("failed to get organization: %v", err)
+		}
+		if getResp.Code != 0 {
+			log.Fatalf("failed to get organization: %s", getResp.Message)
+		}
+		// unchanged fields keep their values
+		org := getResp.Organization
+		if cmd.Flags().Changed("desc") {
+			org.Description, _ = cmd.Flags().GetString("desc")
+		}
+		if cmd.Flags().Changed("admins") {
+			org.Admins, _ = cmd.Flags().GetStringSlice("admins")
+		}
+
+		reply, err := client.UpdateOrganizations(ctx, &pb.UpdateOrganizationsRequest{
+			Organizations: []*pb.Organization{org},
+			Partial:       updatePartial,
+		})
+		if err != nil {
+			log.Fatalf("failed to update organization: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Organizations, reply.Errors)
+		}
+	},
+}
+
+func init() {
+	updateCmd.AddCommand(updateOrganizationCmd)
+
+	updateOrganizationCmd.Flags().Uint32("id", 0, "Organization ID to update")
+	updateOrganizationCmd.Flags().String("desc", "", "New organization description")
+	updateOrganizationCmd.Flags().StringSlice("admins", []string{}, "User names of the organization admins")
+}
@@ -122,6 +122,7 @@ var updateProductCmd = &cobra.Command{
 
 		req := &pb.UpdateProductsRequest{
 			Products: products,
+			Partial:  updatePartial,
 		}
 
 		reply, err := client.UpdateProducts(ctx, req)
@@ -133,6 +134,7 @@ var updateProductCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Products, reply.Errors)
 		}
 	},
 }
@@ -126,7 +126,8 @@ var updateTagCmd = &cobra.Command{
 		}
 
 		req := &pb.UpdateTagsRequest{
-			Tags: tags,
+			Tags:    tags,
+			Partial: updatePartial,
 		}
 
 		reply, err := client.UpdateTags(ctx, req)
@@ -138,6 +139,7 @@ var updateTagCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Tags, reply.Errors)
 		}
 	},
 }
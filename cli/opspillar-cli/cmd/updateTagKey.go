@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	pb "opspillar/api/opspillar/v1"
+)
+
+// updateTagKeyCmd represents the updateTagKey command
+var updateTagKeyCmd = &cobra.Command{
+	Use:   "tagkey",
+	Short: "Update tag key",
+	Long: `Update a registered tag key, admin only. Keys can not be changed.
+
+Examples:
+  opspillar update tagkey --id 1 --required=false
+  opspillar update tagkey --id 1 --values prod,staging`,
+	Aliases: []string{"tagkeys", "tag-key"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			fmt.Printf("Failed to connect to server: %v\n", err)
+			return
+		}
+		defer conn.Close()
+		client := pb.NewTagKeysClient(conn)
+
+		id, _ := cmd.Flags().GetUint32("id")
+		if id == 0 {
+			log.Fatal("id is required for command line update")
+		}
+		getResp, err := client.GetTagKeys(ctx, &pb.GetTagKeysRequest{Id: id})
+		if err != nil {
+			log.Fatalf("failed to get tag key: %v", err)
+		}
+		if getResp.Code != 0 {
+			log.Fatalf("failed to get tag key: %s", getResp.Message)
+		}
+		// unchanged fields keep their values
+		key := getResp.TagKey
+		if cmd.Flags().Changed("values") {
+			key.AllowedValues, _ = cmd.Flags().GetStringSlice("values")
+		}
+		if cmd.Flags().Changed("pattern") {
+			key.Pattern, _ = cmd.Flags().GetString("pattern")
+		}
+		if cmd.Flags().Changed("kinds") {
+			key.Kinds, _ = cmd.Flags().GetStringSlice("kinds")
+		}
+		if cmd.Flags().Changed("required") {
+			key.Required, _ = cmd.Flags().GetBool("required")
+		}
+		if cmd.Flags().Changed("desc") {
+			key.Description, _ = cmd.Flags().GetString("desc")
+		}
+
+		reply, err := client.UpdateTagKeys(ctx, &pb.UpdateTagKeysRequest{
+			TagKeys: []*pb.TagKey{key},
+			Partial: updatePartial,
+		})
+		if err != nil {
+			log.Fatalf("failed to update tag key: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.TagKeys, reply.Errors)
+		}
+	},
+}
+
+func init() {
+	updateCmd.AddCommand(updateTagKeyCmd)
+
+	updateTagKeyCmd.Flags().Uint32("id", 0, "Tag key ID to update")
+	updateTagKeyCmd.Flags().StringSlice("values", []string{}, "Allowed values of tags, any if empty")
+	updateTagKeyCmd.Flags().String("pattern", "", "Regular expression the whole value must match")
+	updateTagKeyCmd.Flags().StringSlice("kinds", []string{}, "Entity kinds tags are used on, all if empty")
+	updateTagKeyCmd.Flags().Bool("required", false, "Entities of kinds must have a tag of the key")
+	updateTagKeyCmd.Flags().String("desc", "", "New tag key description")
+}
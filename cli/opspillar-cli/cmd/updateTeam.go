@@ -128,7 +128,8 @@ var updateTeamCmd = &cobra.Command{
 		}
 
 		req := &pb.UpdateTeamsRequest{
-			Teams: teams,
+			Teams:   teams,
+			Partial: updatePartial,
 		}
 
 		reply, err := client.UpdateTeams(ctx, req)
@@ -140,6 +141,7 @@ var updateTeamCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Teams, reply.Errors)
 		}
 	},
 }
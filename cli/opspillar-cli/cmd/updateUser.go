@@ -128,7 +128,8 @@ var updateUserCmd = &cobra.Command{
 		}
 
 		req := &pb.UpdateUsersRequest{
-			Users: users,
+			Users:   users,
+			Partial: updatePartial,
 		}
 
 		reply, err := client.UpdateUsers(ctx, req) // 使用 UpdateUsers API
@@ -140,6 +141,7 @@ var updateUserCmd = &cobra.Command{
 			fmt.Printf("Action: %s\n", reply.Action)
 			fmt.Printf("Code: %d\n", reply.Code)
 			fmt.Printf("Message: %s\n", reply.Message)
+			printCommitted(reply.Users, reply.Errors)
 		}
 	},
 }
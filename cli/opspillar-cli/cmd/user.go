@@ -0,0 +1,31 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage user sessions and credentials",
+	Long: `Manage user sessions and credentials.
+
+Example:
+  opspillar user sessions
+  opspillar user revoke-sessions 2
+  opspillar user passwd
+  opspillar user mfa-enroll`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+}
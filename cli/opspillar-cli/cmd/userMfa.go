@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// printMFAEnrollment shows the secret and recovery codes, they are not shown again.
+func printMFAEnrollment(enrollment *pb.MFAEnrollment) {
+	fmt.Println("Add this uri to an authenticator app, or enter the secret manually:")
+	fmt.Printf("  %s\n", enrollment.ProvisioningUri)
+	fmt.Printf("  secret: %s\n", enrollment.Secret)
+	fmt.Println("Recovery codes, each replaces a code once if the device is lost, keep them safe:")
+	for _, code := range enrollment.RecoveryCodes {
+		fmt.Printf("  %s\n", code)
+	}
+}
+
+// readMFACode prompts for a code, codes are not secret once used.
+func readMFACode() (string, error) {
+	fmt.Print("MFA code: ")
+	code, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && code == "" {
+		return "", err
+	}
+	return strings.TrimSpace(code), nil
+}
+
+// userMfaEnrollCmd represents the user mfa-enroll command
+var userMfaEnrollCmd = &cobra.Command{
+	Use:   "mfa-enroll [id]",
+	Short: "Enroll TOTP multi-factor authentication",
+	Long: `Create a TOTP secret and recovery codes, the enrollment takes effect after a code of
+the authenticator app is confirmed. Logins ask for a code after it.
+An admin enrolls another user by id and hands the enrollment over, the user confirms it
+with a code at the next login.
+For example:
+  opspillar user mfa-enroll
+  opspillar user mfa-enroll 3`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var id uint64
+		if len(args) == 1 {
+			var err error
+			if id, err = strconv.ParseUint(args[0], 10, 32); err != nil {
+				log.Fatalf("invalid user id: %s", args[0])
+			}
+		}
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAdminClient(conn)
+		reply, err := client.EnrollMFA(ctx, &pb.EnrollMFAReq{Id: uint32(id)})
+		if err != nil {
+			log.Fatalf("failed to enroll MFA: %v", err)
+		}
+		if reply.Code != 0 {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+			return
+		}
+		printMFAEnrollment(reply.Enrollment)
+		if id != 0 {
+			return
+		}
+
+		code, err := readMFACode()
+		if err != nil {
+			fmt.Println("Failed to read code, confirm later with `opspillar user mfa-confirm <code>`")
+			return
+		}
+		confirm, err := client.ConfirmMFA(ctx, &pb.ConfirmMFAReq{MfaCode: code})
+		if err != nil {
+			log.Fatalf("failed to confirm MFA: %v", err)
+		}
+		fmt.Printf("Action: %s\n", confirm.Action)
+		fmt.Printf("Code: %d\n", confirm.Code)
+		fmt.Printf("Message: %s\n", confirm.Message)
+	},
+}
+
+// userMfaConfirmCmd represents the user mfa-confirm command
+var userMfaConfirmCmd = &cobra.Command{
+	Use:   "mfa-confirm [code]",
+	Short: "Confirm the TOTP enrollment with a code",
+	Long: `Enable the enrolled TOTP secret with a code of the authenticator app.
+For example:
+  opspillar user mfa-confirm 123456`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAdminClient(conn)
+		reply, err := client.ConfirmMFA(ctx, &pb.ConfirmMFAReq{MfaCode: args[0]})
+		if err != nil {
+			log.Fatalf("failed to confirm MFA: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+// userMfaDisableCmd represents the user mfa-disable command
+var userMfaDisableCmd = &cobra.Command{
+	Use:   "mfa-disable [id]",
+	Short: "Disable multi-factor authentication",
+	Long: `Disable your MFA with a TOTP or recovery code. With a user id, disable MFA of a user
+who lost the device, admin only.
+For example:
+  opspillar user mfa-disable --code 123456
+  opspillar user mfa-disable 3`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var id uint64
+		var err error
+		if len(args) == 1 {
+			id, err = strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid user ID '%s': %v\n", args[0], err)
+				return
+			}
+		}
+		code, _ := cmd.Flags().GetString("code")
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAdminClient(conn)
+		reply, err := client.DisableMFA(ctx, &pb.DisableMFAReq{Id: uint32(id), MfaCode: code})
+		if err != nil {
+			log.Fatalf("failed to disable MFA: %v", err)
+		}
+		fmt.Printf("Action: %s\n", reply.Action)
+		fmt.Printf("Code: %d\n", reply.Code)
+		fmt.Printf("Message: %s\n", reply.Message)
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userMfaEnrollCmd)
+	userCmd.AddCommand(userMfaConfirmCmd)
+	userCmd.AddCommand(userMfaDisableCmd)
+	userMfaDisableCmd.Flags().String("code", "", "TOTP or recovery code, required to disable your own MFA")
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v2"
+)
+
+// savedUserName is the user name of the last login in config file.
+func savedUserName() string {
+	configPath := strings.Replace(cfgFile, "~", os.Getenv("HOME"), 1)
+	existingConfig := make(map[string]string)
+	existingData, err := os.ReadFile(configPath)
+	if err != nil {
+		return ""
+	}
+	if err := yaml.Unmarshal(existingData, &existingConfig); err != nil {
+		return ""
+	}
+	user := &pb.User{}
+	if err := yaml.Unmarshal([]byte(existingConfig["user"]), user); err != nil {
+		return ""
+	}
+	return user.UserName
+}
+
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println("")
+	return string(password), err
+}
+
+// readNewPassword prompts for a new password twice.
+func readNewPassword() (string, error) {
+	password, err := readPassword("New password: ")
+	if err != nil {
+		return "", err
+	}
+	again, err := readPassword("Retype new password: ")
+	if err != nil {
+		return "", err
+	}
+	if password != again {
+		return "", fmt.Errorf("passwords do not match")
+	}
+	return password, nil
+}
+
+// userPasswdCmd represents the user passwd command
+var userPasswdCmd = &cobra.Command{
+	Use:   "passwd [id]",
+	Short: "Change, reset or issue a reset token of a password",
+	Long: `Change your password with the old one, it works with expired passwords. Users with
+MFA also give a code with --mfa-code.
+With a user id, issue a one-time reset token for the user, admin only.
+With --reset-token, set a new password with the token issued by an admin,
+sessions of the user are revoked.
+For example:
+  opspillar user passwd
+  opspillar user passwd -u alice
+  opspillar user passwd 3
+  opspillar user passwd --reset-token <token>`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		resetToken, _ := cmd.Flags().GetString("reset-token")
+		if len(args) == 1 {
+			id, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid user ID '%s': %v\n", args[0], err)
+				return
+			}
+			createResetToken(uint32(id))
+			return
+		}
+
+		var oldPassword, username string
+		mfaCode, _ := cmd.Flags().GetString("mfa-code")
+		if resetToken == "" {
+			username, _ = cmd.Flags().GetString("username")
+			if username == "" {
+				username = savedUserName()
+			}
+			if username == "" {
+				fmt.Println("Username is required, login first or set --username")
+				return
+			}
+			var err error
+			oldPassword, err = readPassword("Old password: ")
+			if err != nil {
+				fmt.Println("Failed to read password")
+				return
+			}
+		}
+		newPassword, err := readNewPassword()
+		if err != nil {
+			fmt.Printf("Failed to read password: %v\n", err)
+			return
+		}
+
+		ctx, conn, err := NewConnection(false)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+		client := pb.NewAdminClient(conn)
+
+		var action, message string
+		var code int32
+		if resetToken != "" {
+			resp, err := client.ResetPassword(ctx, &pb.ResetPasswordReq{
+				Token:       resetToken,
+				NewPassword: newPassword,
+			})
+			if err != nil {
+				log.Fatalf("failed to reset password: %v", err)
+			}
+			action, code, message = resp.Action, resp.Code, resp.Message
+		} else {
+			resp, err := client.ChangePassword(ctx, &pb.ChangePasswordReq{
+				UserName:    username,
+				OldPassword: oldPassword,
+				NewPassword: newPassword,
+				MfaCode:     mfaCode,
+			})
+			if err != nil {
+				log.Fatalf("failed to change password: %v", err)
+			}
+			action, code, message = resp.Action, resp.Code, resp.Message
+		}
+		fmt.Printf("Action: %s\n", action)
+		fmt.Printf("Code: %d\n", code)
+		fmt.Printf("Message: %s\n", message)
+	},
+}
+
+func createResetToken(id uint32) {
+	ctx, conn, err := NewConnection(true)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewAdminClient(conn)
+	resp, err := client.CreatePasswordResetToken(ctx, &pb.CreatePasswordResetTokenReq{Id: id})
+	if err != nil {
+		log.Fatalf("failed to create reset token: %v", err)
+	}
+	if resp.Code != 0 {
+		fmt.Printf("Action: %s\n", resp.Action)
+		fmt.Printf("Code: %d\n", resp.Code)
+		fmt.Printf("Message: %s\n", resp.Message)
+		return
+	}
+	fmt.Printf("Reset token of %s, shown only once:\n", resp.UserName)
+	fmt.Printf("  %s\n", resp.Token)
+	fmt.Printf("Expires at %s, redeem with:\n", time.Unix(resp.ExpiresAt, 0).Format(time.DateTime))
+	fmt.Printf("  opspillar user passwd --reset-token <token>\n")
+}
+
+func init() {
+	userCmd.AddCommand(userPasswdCmd)
+	userPasswdCmd.Flags().StringP("username", "u", "", "Username, default the logged in user")
+	userPasswdCmd.Flags().String("reset-token", "", "Reset token issued by an admin")
+	userPasswdCmd.Flags().String("mfa-code", "", "TOTP or recovery code, required if MFA is enabled")
+}
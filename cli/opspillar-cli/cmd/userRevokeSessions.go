@@ -0,0 +1,56 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/spf13/cobra"
+)
+
+// userRevokeSessionsCmd represents the user revoke-sessions command
+var userRevokeSessionsCmd = &cobra.Command{
+	Use:   "revoke-sessions [id]",
+	Short: "Revoke all sessions of a user",
+	Long: `Revoke all tokens issued to a user. The user must login again.
+For example:
+  opspillar user revoke-sessions 2`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			fmt.Printf("Invalid user ID '%s': %v\n", args[0], err)
+			return
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAdminClient(conn)
+
+		reply, err := client.RevokeSessions(ctx, &pb.RevokeSessionsReq{
+			Id: uint32(id),
+		})
+		if err != nil {
+			log.Fatalf("failed to revoke sessions: %v", err)
+		}
+
+		if reply != nil {
+			fmt.Printf("Action: %s\n", reply.Action)
+			fmt.Printf("Code: %d\n", reply.Code)
+			fmt.Printf("Message: %s\n", reply.Message)
+		}
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userRevokeSessionsCmd)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// userSessionsCmd represents the user sessions command
+var userSessionsCmd = &cobra.Command{
+	Use:   "sessions [id]",
+	Short: "List sessions of a user",
+	Long: `List login sessions of a user, default the current user.
+For example:
+  opspillar user sessions
+  opspillar user sessions 2`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var id uint64
+		if len(args) == 1 {
+			var err error
+			id, err = strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid user ID '%s': %v\n", args[0], err)
+				return
+			}
+		}
+
+		ctx, conn, err := NewConnection(true)
+		if err != nil {
+			log.Fatalf("did not connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewAdminClient(conn)
+
+		resp, err := client.ListSessions(ctx, &pb.ListSessionsReq{
+			UserId: uint32(id),
+		})
+		if err != nil {
+			log.Fatalf("failed to list sessions: %v", err)
+		}
+		if resp.Code != 0 {
+			fmt.Printf("Response details:\n")
+			fmt.Printf("  Message: %s\n", resp.Message)
+			fmt.Printf("  Code: %d\n", resp.Code)
+			fmt.Printf("  Action: %s\n", resp.Action)
+			return
+		}
+
+		formatTime := func(t int64) string {
+			return time.Unix(t, 0).Format(time.DateTime)
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"ID", "Device", "IP", "Created", "Last Used", "Expires", "Current",
+		})
+		table.SetAutoFormatHeaders(true)
+		for _, session := range resp.Sessions {
+			current := ""
+			if session.Current {
+				current = "*"
+			}
+			table.Append([]string{
+				fmt.Sprint(session.Id),
+				session.Device,
+				session.Ip,
+				formatTime(session.CreatedAt),
+				formatTime(session.LastUsedAt),
+				formatTime(session.ExpiresAt),
+				current,
+			})
+		}
+		table.Render()
+	},
+}
+
+func init() {
+	userCmd.AddCommand(userSessionsCmd)
+}
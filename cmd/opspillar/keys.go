@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const keysUsage = "usage: opspillar [-conf config.yaml] keys rotate | list"
+
+// runKeys runs `keys rotate|list` on keys signing tokens. after rotation new tokens
+// are signed with the new key within a minute, tokens signed before are valid until they expire.
+func runKeys(c *conf.Data, admin *conf.Admin, args []string, logger log.Logger) error {
+	if len(args) != 1 {
+		return errors.New(keysUsage)
+	}
+	alg, err := data.SigningAlgorithm(admin)
+	if err != nil {
+		return err
+	}
+	db, err := sqldb.OpenDataGorm(c)
+	if err != nil {
+		return err
+	}
+	keys, err := sqldb.NewSigningKeysRepoGorm(db, logger)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "rotate":
+		if alg == repo.SigningAlgHS256 {
+			return errors.New("jwt_algorithm HS256 signs with jwt_secret, set RS256 or EdDSA to rotate keys")
+		}
+		key, err := data.RotateSigningKeys(ctx, keys, alg, data.SigningKeyOverlap(admin))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s %s\n", key.Algorithm, key.Kid)
+		return nil
+	case "list":
+		list, err := keys.ListSigningKeys(ctx, nil, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		for i, k := range list {
+			state := "signing"
+			if i > 0 {
+				state = "verifying"
+			}
+			if k.ExpiresAt > 0 {
+				state += " until " + time.Unix(k.ExpiresAt, 0).Format(time.RFC3339)
+			}
+			fmt.Printf("%s %-6s created %s %s\n", k.Kid, k.Algorithm, time.Unix(k.CreatedAt, 0).Format(time.RFC3339), state)
+		}
+		return nil
+	}
+	return errors.New(keysUsage)
+}
@@ -7,6 +7,9 @@ import (
 	"os"
 
 	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+	"opspillar/internal/server"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/config"
@@ -38,7 +41,7 @@ func init() {
 	flag.BoolVar(&showVersion, "version", false, "show version")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, ds *server.DirectorySyncServer) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -48,6 +51,7 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 		kratos.Server(
 			gs,
 			hs,
+			ds,
 		),
 	)
 }
@@ -90,6 +94,22 @@ func main() {
 		)
 	}
 
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(bc.Data, flag.Args()[1:], logger); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "keys" {
+		if err := runKeys(bc.Data, bc.Admin, flag.Args()[1:], logger); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := validateAdminConfig(bc.Admin); err != nil {
 		panic(err)
 	}
@@ -113,14 +133,15 @@ func validateAdminConfig(conf *conf.Admin) error {
 	if conf.AdminPassword == "" {
 		return errors.New("admin user or password is empty")
 	}
-	if conf.JwtSecret == "" {
+	alg, err := data.SigningAlgorithm(conf)
+	if err != nil {
+		return err
+	}
+	if alg == repo.SigningAlgHS256 && conf.JwtSecret == "" {
 		return errors.New("jwt secret is empty")
 	}
 	if conf.JwtExpireHours == 0 {
 		return errors.New("jwt expire hours is empty")
 	}
-	if conf.EmergencyHeader == "" {
-		return errors.New("emergency header is empty")
-	}
 	return nil
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const migrateUsage = "usage: opspillar [-conf config.yaml] migrate up [version] | down [steps] | force <version> | status"
+
+// runMigrate runs `migrate up|down|force|status` against the configured database.
+func runMigrate(c *conf.Data, args []string, logger log.Logger) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	data, err := sqldb.OpenDataGorm(c)
+	if err != nil {
+		return err
+	}
+	m, err := sqldb.NewMigrator(data, logger)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var arg uint64
+	if len(args) > 1 {
+		arg, err = strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return errors.New(migrateUsage)
+		}
+	}
+
+	switch args[0] {
+	case "up":
+		versions, err := m.Up(ctx, uint32(arg))
+		for _, v := range versions {
+			fmt.Printf("applied %d\n", v)
+		}
+		return err
+	case "down":
+		if arg == 0 {
+			arg = 1
+		}
+		versions, err := m.Down(ctx, int(arg))
+		for _, v := range versions {
+			fmt.Printf("reverted %d\n", v)
+		}
+		return err
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		if err := m.Force(ctx, uint32(arg)); err != nil {
+			return err
+		}
+		fmt.Printf("forced %d\n", arg)
+		return nil
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range status {
+			state := "pending"
+			if st.Applied {
+				state = "applied " + time.Unix(st.AppliedAt, 0).Format(time.RFC3339)
+			}
+			if st.Unknown {
+				state += " (unknown to this binary)"
+			}
+			if st.Dirty {
+				state += " (dirty, failed halfway)"
+			}
+			fmt.Printf("%04d %-30s %s\n", st.Version, st.Name, state)
+		}
+		return nil
+	}
+	return errors.New(migrateUsage)
+}
@@ -34,36 +34,47 @@ func wireApp(confServer *conf.Server, confData *conf.Data, admin *conf.Admin, au
 		cleanup()
 		return nil, nil, err
 	}
-	authzRepo, err := sqldb.NewAuthzRepoGorm(authz, dataGorm, logger)
+	authzRepo, cleanup2, err := sqldb.NewAuthzRepoGorm(authz, dataGorm, logger)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
 	appTagsRepo, err := sqldb.NewAppTagsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	hostgroupTagsRepo, err := sqldb.NewHostgroupTagsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tagKeysRepo, err := sqldb.NewTagKeysRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	txManager := sqldb.NewTxManagerGorm(dataGorm, logger)
-	tagsUsecase := biz.NewTagsUsecase(tagsRepo, authzRepo, logger, appTagsRepo, hostgroupTagsRepo, txManager)
+	tagsUsecase := biz.NewTagsUsecase(tagsRepo, authzRepo, logger, appTagsRepo, hostgroupTagsRepo, tagKeysRepo, txManager)
 	tagsService := service.NewTagsService(tagsUsecase, logger)
 	featuresRepo, err := sqldb.NewFeaturesRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	hostgroupFeaturesRepo, err := sqldb.NewHostgroupFeaturesRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	appFeaturesRepo, err := sqldb.NewAppFeaturesRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
@@ -71,47 +82,81 @@ func wireApp(confServer *conf.Server, confData *conf.Data, admin *conf.Admin, au
 	featuresService := service.NewFeaturesService(featuresUsecase, logger)
 	teamsRepo, err := sqldb.NewTeamsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	hostgroupsRepo, err := sqldb.NewHostgroupsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	hostgroupTeamsRepo, err := sqldb.NewHostgroupTeamsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	applicationsRepo, err := sqldb.NewApplicationsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	serviceAccountsRepo, err := sqldb.NewServiceAccountsRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	teamsUsecase := biz.NewTeamsUsecase(teamsRepo, authzRepo, hostgroupsRepo, hostgroupTeamsRepo, applicationsRepo, logger, txManager)
+	teamMembersRepo, err := sqldb.NewTeamMembersRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	adminRepo, err := sqldb.NewAdminRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	organizationsRepo, err := sqldb.NewOrganizationsRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	teamsUsecase := biz.NewTeamsUsecase(teamsRepo, organizationsRepo, authzRepo, teamMembersRepo, adminRepo, hostgroupsRepo, hostgroupTeamsRepo, applicationsRepo, serviceAccountsRepo, logger, txManager)
 	teamsService := service.NewTeamsService(teamsUsecase, logger)
 	productsRepo, err := sqldb.NewProductsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	hostgroupProductsRepo, err := sqldb.NewHostgroupProductsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	productsUsecase := biz.NewProductsUsecase(productsRepo, authzRepo, hostgroupsRepo, applicationsRepo, hostgroupProductsRepo, logger, txManager)
+	productsUsecase := biz.NewProductsUsecase(productsRepo, organizationsRepo, authzRepo, hostgroupsRepo, applicationsRepo, hostgroupProductsRepo, logger, txManager)
 	productsService := service.NewProductsService(productsUsecase, logger)
 	envsRepo, err := sqldb.NewEnvsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	envsUsecase := biz.NewEnvsUsecase(envsRepo, authzRepo, hostgroupsRepo, logger, txManager)
 	envsService := service.NewEnvsService(envsUsecase, logger)
+	organizationsUsecase := biz.NewOrganizationsUsecase(organizationsRepo, authzRepo, adminRepo, teamsRepo, productsRepo, logger, txManager)
+	organizationsService := service.NewOrganizationsService(organizationsUsecase, logger)
 	clustersRepo, err := sqldb.NewClustersRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
@@ -119,6 +164,7 @@ func wireApp(confServer *conf.Server, confData *conf.Data, admin *conf.Admin, au
 	clustersService := service.NewClustersService(clustersUsecase, logger)
 	datacentersRepo, err := sqldb.NewDatacentersRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
@@ -126,25 +172,96 @@ func wireApp(confServer *conf.Server, confData *conf.Data, admin *conf.Admin, au
 	datacentersService := service.NewDatacentersService(datacentersUsecase, logger)
 	appHostgroupsRepo, err := sqldb.NewAppHostgroupsRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	adminRepo, err := sqldb.NewAdminRepoGorm(dataGorm, logger)
+	attributesRepo, err := sqldb.NewAttributesRepoGorm(dataGorm, logger)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	hostgroupsUsecase := biz.NewHostgroupsUsecase(hostgroupsRepo, hostgroupTeamsRepo, hostgroupProductsRepo, hostgroupTagsRepo, hostgroupFeaturesRepo, clustersRepo, datacentersRepo, envsRepo, featuresRepo, tagsRepo, teamsRepo, productsRepo, appHostgroupsRepo, authzRepo, adminRepo, logger, txManager)
+	hostgroupsUsecase := biz.NewHostgroupsUsecase(hostgroupsRepo, hostgroupTeamsRepo, hostgroupProductsRepo, hostgroupTagsRepo, hostgroupFeaturesRepo, attributesRepo, tagKeysRepo, clustersRepo, datacentersRepo, envsRepo, featuresRepo, tagsRepo, teamsRepo, organizationsRepo, productsRepo, appHostgroupsRepo, authzRepo, adminRepo, logger, txManager)
 	hostgroupsService := service.NewHostgroupsService(hostgroupsUsecase, logger)
-	applicationsUsecase := biz.NewApplicationsUsecase(applicationsRepo, appTagsRepo, appFeaturesRepo, appHostgroupsRepo, productsRepo, teamsRepo, featuresRepo, tagsRepo, hostgroupsRepo, hostgroupFeaturesRepo, authzRepo, adminRepo, logger, txManager)
+	applicationsUsecase := biz.NewApplicationsUsecase(applicationsRepo, appTagsRepo, appFeaturesRepo, appHostgroupsRepo, productsRepo, teamsRepo, organizationsRepo, featuresRepo, tagsRepo, hostgroupsRepo, hostgroupFeaturesRepo, attributesRepo, tagKeysRepo, authzRepo, adminRepo, logger, txManager)
 	applicationsService := service.NewApplicationsService(applicationsUsecase, logger)
-	tokenRepo := data.NewJwtMemRepo(admin)
-	adminUsecase := biz.NewAdminUsecase(admin, adminRepo, tokenRepo, authzRepo, teamsRepo, applicationsRepo, txManager, logger)
+	attributesUsecase := biz.NewAttributesUsecase(attributesRepo, authzRepo, logger, txManager)
+	attributesService := service.NewAttributesService(attributesUsecase, logger)
+	tagKeysUsecase := biz.NewTagKeysUsecase(tagKeysRepo, authzRepo, logger, txManager)
+	tagKeysService := service.NewTagKeysService(tagKeysUsecase, logger)
+	tokenRevocationRepo, cleanup3, err := data.NewTokenRevocationRepo(confData, dataGorm, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	signingKeysRepo, err := sqldb.NewSigningKeysRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tokenRepo, err := data.NewJwtMemRepo(admin, tokenRevocationRepo, signingKeysRepo)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	sessionsRepo, err := sqldb.NewSessionsRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ssoProvider := data.NewOIDCProvider(admin)
+	directoryProvider := data.NewLDAPProvider(admin)
+	adminUsecase := biz.NewAdminUsecase(admin, adminRepo, tokenRepo, authzRepo, sessionsRepo, ssoProvider, directoryProvider, teamsRepo, teamMembersRepo, applicationsRepo, txManager, logger)
 	adminService := service.NewAdminService(adminUsecase, logger)
-	grpcServer := server.NewGRPCServer(confServer, admin, tagsService, featuresService, teamsService, productsService, envsService, clustersService, datacentersService, hostgroupsService, applicationsService, adminService, logger)
-	httpServer := server.NewHTTPServer(confServer, admin, tagsService, featuresService, teamsService, productsService, envsService, clustersService, datacentersService, hostgroupsService, applicationsService, adminService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	apiKeysRepo, err := sqldb.NewApiKeysRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	serviceAccountsUsecase := biz.NewServiceAccountsUsecase(serviceAccountsRepo, apiKeysRepo, teamsRepo, organizationsRepo, authzRepo, txManager, logger)
+	serviceAccountsService := service.NewServiceAccountsService(serviceAccountsUsecase, logger)
+	breakGlassRepo, err := sqldb.NewBreakGlassRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	breakGlassUsecase := biz.NewBreakGlassUsecase(breakGlassRepo, adminRepo, authzRepo, txManager, logger)
+	breakGlassService := service.NewBreakGlassService(breakGlassUsecase, logger)
+	authzAuditsRepo, err := sqldb.NewAuthzAuditsRepoGorm(dataGorm, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	authzUsecase, err := biz.NewAuthzUsecase(authz, authzRepo, authzAuditsRepo, adminRepo, serviceAccountsRepo, teamsRepo, organizationsRepo, hostgroupsRepo, applicationsRepo, txManager, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	authzService := service.NewAuthzService(authzUsecase, logger)
+	rateLimiter := server.NewRateLimiter(confServer)
+	grpcServer := server.NewGRPCServer(confServer, tokenRepo, rateLimiter, serviceAccountsUsecase, breakGlassUsecase, tagsService, featuresService, teamsService, productsService, envsService, organizationsService, clustersService, datacentersService, hostgroupsService, applicationsService, attributesService, tagKeysService, adminService, serviceAccountsService, breakGlassService, authzService, logger)
+	httpServer := server.NewHTTPServer(confServer, tokenRepo, rateLimiter, serviceAccountsUsecase, breakGlassUsecase, tagsService, featuresService, teamsService, productsService, envsService, organizationsService, clustersService, datacentersService, hostgroupsService, applicationsService, attributesService, tagKeysService, adminService, serviceAccountsService, breakGlassService, authzService, logger)
+	directorySyncServer := server.NewDirectorySyncServer(admin, adminUsecase, logger)
+	app := newApp(logger, grpcServer, httpServer, directorySyncServer)
 	return app, func() {
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"golang.org/x/crypto/bcrypt"
@@ -39,15 +40,19 @@ func CheckPassword(hashedPassword, password string) bool {
 }
 
 type AdminUsecase struct {
-	adminRepo repo.AdminRepo
-	tokenRepo repo.TokenRepo
-	authzRepo repo.AuthzRepo
-	teamsRepo repo.TeamsRepo
-	txm       repo.TxManager
-	log       *log.Helper
-	conf      *conf.Admin
-	adminUser *repo.User
-	required  []requiredBy
+	adminRepo    repo.AdminRepo
+	tokenRepo    repo.TokenRepo
+	authzRepo    repo.AuthzRepo
+	sessionsRepo repo.SessionsRepo
+	sso          repo.SSOProvider
+	directory    repo.DirectoryProvider
+	teamsRepo    repo.TeamsRepo
+	members      teamMembership
+	txm          repo.TxManager
+	log          *log.Helper
+	conf         *conf.Admin
+	adminUser    *repo.User
+	required     []requiredBy
 }
 
 func NewAdminUsecase(
@@ -55,22 +60,31 @@ func NewAdminUsecase(
 	adminRepo repo.AdminRepo,
 	tokenRepo repo.TokenRepo,
 	authzRepo repo.AuthzRepo,
+	sessionsRepo repo.SessionsRepo,
+	sso repo.SSOProvider,
+	directory repo.DirectoryProvider,
 	teamsRepo repo.TeamsRepo,
+	teamMembersRepo repo.TeamMembersRepo,
 	appsRepo repo.ApplicationsRepo,
 	txm repo.TxManager,
 	logger log.Logger,
 ) *AdminUsecase {
 
 	uc := &AdminUsecase{
-		adminRepo: adminRepo,
-		tokenRepo: tokenRepo,
-		authzRepo: authzRepo,
-		teamsRepo: teamsRepo,
-		txm:       txm,
-		log:       log.NewHelper(logger),
-		conf:      conf,
+		adminRepo:    adminRepo,
+		tokenRepo:    tokenRepo,
+		authzRepo:    authzRepo,
+		sessionsRepo: sessionsRepo,
+		sso:          sso,
+		directory:    directory,
+		teamsRepo:    teamsRepo,
+		members:      teamMembership{authzrepo: authzRepo, memberRepo: teamMembersRepo},
+		txm:          txm,
+		log:          log.NewHelper(logger),
+		conf:         conf,
 		required: []requiredBy{
 			{name: "team", inst: teamsRepo},
+			{name: "team_member", inst: teamMembersRepo},
 			{name: "app", inst: appsRepo},
 		},
 	}
@@ -86,8 +100,9 @@ func NewAdminUsecase(
 		// create admin user
 		md5pass, err := HashPassword(conf.AdminPassword)
 		_user := &repo.User{
-			UserName: AdminUser,
-			Password: md5pass,
+			UserName:          AdminUser,
+			Password:          md5pass,
+			PasswordChangedAt: time.Now().Unix(),
 		}
 		if err != nil {
 			panic(err)
@@ -185,16 +200,34 @@ func getUsername(ctx context.Context) (string, error) {
 	return usernameStr, nil
 }
 
-func (s *AdminUsecase) enforceUserAdmin(team, user, sub string) error {
-	ires := repo.NewResource4Sv1("users", team, user, sub)
-	can, e := s.authzRepo.Enforce(context.Background(), nil, &repo.AuthenRequest{
-		Sub:      sub,
-		Resource: ires,
+// enforceUserAdmin checks operator can write users, granted to admins and by rules on users.
+// The resource has no user, users own the resources of their name and would pass.
+func (s *AdminUsecase) enforceUserAdmin(ctx context.Context, operator string) error {
+	can, e := s.authzRepo.Enforce(ctx, nil, &repo.AuthenRequest{
+		Sub:      operator,
+		Resource: repo.NewResource4Sv1("users", "", "", ""),
 		Action:   repo.ActWrite,
 	})
 	if e != nil {
 		return e
 	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// enforceAdminOfUser checks operator can write the user of username. Users own resources
+// of their name, so checking the operator's own user would pass for everyone.
+func (s *AdminUsecase) enforceAdminOfUser(ctx context.Context, operator, username string) error {
+	can, err := s.authzRepo.Enforce(ctx, nil, &repo.AuthenRequest{
+		Sub:      operator,
+		Resource: repo.NewResource4Sv1("users", "", username, username),
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return err
+	}
 	if !can {
 		return errors.New("no permission")
 	}
@@ -204,7 +237,7 @@ func (s *AdminUsecase) enforceUserAdmin(team, user, sub string) error {
 // CreateUsers is
 func (s *AdminUsecase) CreateUsers(ctx context.Context, users []*User) error {
 	if err := s.validate(true, users); err != nil {
-		return errors.Join(errors.New("CreateUsers failed"), err)
+		return errors.Join(errors.New("CreateUsers failed"), invalidArgument(err))
 	}
 	if len(users) == 0 {
 		return errors.Join(errors.New("CreateUsers failed"), errors.New("no user to create"))
@@ -218,15 +251,21 @@ func (s *AdminUsecase) CreateUsers(ctx context.Context, users []*User) error {
 	if err != nil {
 		return errors.Join(errors.New("CreateUsers failed"), err)
 	}
+	for _, user := range repoUsers {
+		user.PasswordChangedAt = time.Now().Unix()
+	}
 
 	err = s.txm.RunInTX(func(tx repo.TX) error {
-		if e := s.enforceUserAdmin("", "", usernameStr); err != nil {
+		if e := s.enforceUserAdmin(ctx, usernameStr); e != nil {
 			return e
 		}
 		e := s.adminRepo.CreateUsers(ctx, tx, repoUsers)
 		if e != nil {
 			return e
 		}
+		for i, user := range repoUsers {
+			users[i].Id = user.Id
+		}
 
 		// create user policy
 		for _, user := range repoUsers {
@@ -251,7 +290,7 @@ func (s *AdminUsecase) CreateUsers(ctx context.Context, users []*User) error {
 // UpdateUsers is
 func (s *AdminUsecase) UpdateUsers(ctx context.Context, users []*User) error {
 	if err := s.validate(false, users); err != nil {
-		return errors.Join(errors.New("UpdateUsers failed"), err)
+		return errors.Join(errors.New("UpdateUsers failed"), invalidArgument(err))
 	}
 	if len(users) == 0 {
 		return errors.Join(errors.New("UpdateUsers failed"), errors.New("no user to update"))
@@ -261,6 +300,10 @@ func (s *AdminUsecase) UpdateUsers(ctx context.Context, users []*User) error {
 	if err != nil {
 		return errors.Join(errors.New("UpdateUsers failed"), err)
 	}
+	// passwords are always written by UpdateUsers
+	for _, user := range repoUsers {
+		user.PasswordChangedAt = time.Now().Unix()
+	}
 
 	usernameStr, err := getUsername(ctx)
 	if err != nil {
@@ -268,11 +311,24 @@ func (s *AdminUsecase) UpdateUsers(ctx context.Context, users []*User) error {
 	}
 
 	err = s.txm.RunInTX(func(tx repo.TX) error {
-		if err := s.enforceUserAdmin("", "", usernameStr); err != nil {
+		if err := s.enforceUserAdmin(ctx, usernameStr); err != nil {
 			return err
 		}
+		// a stored password written back unchanged, like by a masked update, is not a change
+		for _, user := range repoUsers {
+			if !strings.HasPrefix(user.Password, BcryptPrefix) {
+				continue
+			}
+			stored, err := s.adminRepo.GetUsers(ctx, tx, user.Id)
+			if err != nil {
+				return err
+			}
+			if stored.Password == user.Password {
+				user.PasswordChangedAt = stored.PasswordChangedAt
+			}
+		}
 
-		err = s.adminRepo.UpdateUsers(ctx, tx, repoUsers)
+		err = conflicting(s.adminRepo.UpdateUsers(ctx, tx, repoUsers))
 		if err != nil {
 			return err
 		}
@@ -301,7 +357,7 @@ func (s *AdminUsecase) DeleteUsers(ctx context.Context, tx repo.TX, ids []uint32
 		return errors.Join(errors.New("DeleteUsers failed"), err)
 	}
 	err = s.txm.RunInTX(func(tx repo.TX) error {
-		if err := s.enforceUserAdmin("", "", usernameStr); err != nil {
+		if err := s.enforceUserAdmin(ctx, usernameStr); err != nil {
 			return err
 		}
 		for _, r := range s.required {
@@ -310,7 +366,7 @@ func (s *AdminUsecase) DeleteUsers(ctx context.Context, tx repo.TX, ids []uint32
 				return err
 			}
 			if c > 0 {
-				return fmt.Errorf("some %s requires", r.name)
+				return FailedPrecondition("some %s requires", r.name)
 			}
 		}
 		err = s.adminRepo.DeleteUsers(ctx, tx, ids)
@@ -347,20 +403,60 @@ func (s *AdminUsecase) GetUsers(ctx context.Context, id uint32) (*User, error) {
 	if err != nil {
 		return nil, errors.Join(errors.New("GetUsers failed"), err)
 	}
+	readable, err := s.filterReadable(ctx, nil, []*repo.User{user})
+	if err != nil {
+		return nil, errors.Join(errors.New("GetUsers failed"), err)
+	}
+	if len(readable) == 0 {
+		return nil, errors.Join(errors.New("GetUsers failed"), ErrPermissionDenied)
+	}
 	return ToBizUser(user), nil
 }
 
 // ListUsers is
-func (s *AdminUsecase) ListUsers(ctx context.Context, tx repo.TX, filter *ListUsersFilter) ([]*User, error) {
-	repoUsers, err := s.adminRepo.ListUsers(ctx, tx, ToDBUsersFilter(filter))
+func (s *AdminUsecase) ListUsers(ctx context.Context, tx repo.TX, filter *ListUsersFilter) ([]*User, *PageInfo, error) {
+	if filter == nil {
+		// lists all
+		filter = &ListUsersFilter{}
+	}
+	if _, err := DecodePageToken(filter.PageToken); err != nil {
+		return nil, nil, err
+	}
+	dbFilter := ToDBUsersFilter(filter)
+	repoUsers, err := s.adminRepo.ListUsers(ctx, tx, dbFilter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// totals count users readable by the caller only
+	page, err := listPage(repoUsers, dbFilter.PageSize, func(u *repo.User) uint32 { return u.Id },
+		filter.WithTotal, func() ([]*repo.User, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			rows, err := s.adminRepo.ListUsers(ctx, tx, &all)
+			if err != nil {
+				return nil, err
+			}
+			return s.filterReadable(ctx, tx, rows)
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	repoUsers, err = s.filterReadable(ctx, tx, repoUsers)
+	if err != nil {
+		return nil, nil, err
 	}
-	return ToBizUsers(repoUsers), nil
+	return ToBizUsers(repoUsers), page, nil
+}
+
+// filterReadable keeps users readable by the current user, users see themselves and admins see all.
+func (s *AdminUsecase) filterReadable(ctx context.Context, tx repo.TX, users []*repo.User) ([]*repo.User, error) {
+	return filterReadable(ctx, s.authzRepo, tx, users, func(u *repo.User) ([]repo.IResource, error) {
+		return []repo.IResource{repo.NewResource4Sv1("users", "", u.UserName, u.UserName)}, nil
+	})
 }
 
-// Login is
-func (s *AdminUsecase) Login(ctx context.Context, username, password string) (*User, error) {
+// Login is, mfaCode is the TOTP or recovery code of local users with MFA.
+func (s *AdminUsecase) Login(ctx context.Context, username, password, mfaCode string, client *ClientInfo) (*User, error) {
 	if username == "" || password == "" {
 		return nil, errors.New("username or password is empty")
 	}
@@ -373,28 +469,44 @@ func (s *AdminUsecase) Login(ctx context.Context, username, password string) (*U
 	if err != nil {
 		return nil, err
 	}
-	if len(users) == 0 || len(users) > 1 {
-		return nil, errors.New("user not found")
-	} else {
+	if len(users) > 1 {
+		return nil, NotFound("user not found")
+	}
+	if len(users) == 1 {
 		user = users[0]
 	}
-	if !CheckPassword(user.Password, password) {
-		return nil, errors.New("password is incorrect")
+	// users of directory and unknown users bind to the directory
+	if s.directory != nil && (user == nil || user.Source == repo.UserSourceLDAP) {
+		user, err = s.loginLDAP(ctx, username, password)
+		if err != nil {
+			return nil, err
+		}
+	} else if user == nil {
+		return nil, NotFound("user not found")
+	} else {
+		now := time.Now()
+		if err := s.verifyPassword(ctx, user, password, now); err != nil {
+			return nil, err
+		}
+		if err := s.loginMFA(ctx, user, mfaCode, now); err != nil {
+			return nil, err
+		}
+		if s.passwordExpired(user, now) {
+			return nil, ErrPasswordExpired
+		}
+		s.resetFailedLogins(ctx, user)
 	}
 
-	_idstr := strconv.Itoa(int(user.Id))
-
-	token, err := s.tokenRepo.CreateToken(ctx, repo.TokenClaims{
-		string(data.CtxUserId):   _idstr,
-		string(data.CtxUserName): user.UserName,
-	})
+	session := &repo.Session{}
+	if client != nil {
+		session.Device = client.Device
+		session.IP = client.IP
+	}
+	bizUser, err := s.issueTokens(ctx, nil, user, session)
 	if err != nil {
 		return nil, err
 	}
 
-	bizUser := ToBizUser(user)
-	bizUser.Token = token
-
 	return bizUser, nil
 }
 
@@ -417,5 +529,40 @@ func (s *AdminUsecase) Logout(ctx context.Context, id uint32) error {
 		}
 	}
 
+	if sid := getSessionId(ctx); sid != 0 {
+		err := s.sessionsRepo.DeleteSessions(ctx, nil, []uint32{sid})
+		if err != nil {
+			s.log.Warnf("delete session %d failed: %v", sid, err)
+		}
+	}
+
 	return s.adminRepo.Logout(ctx, id)
 }
+
+// RevokeSessions revokes all sessions and tokens of user. users can revoke their own sessions,
+// others require user admin permission.
+func (s *AdminUsecase) RevokeSessions(ctx context.Context, id uint32) error {
+	_id := strconv.Itoa(int(id))
+	if _id != ctx.Value(data.CtxUserId) {
+		usernameStr, err := getUsername(ctx)
+		if err != nil {
+			return errors.Join(errors.New("RevokeSessions failed"), err)
+		}
+		user, err := s.adminRepo.GetUsers(ctx, nil, id)
+		if err != nil {
+			return errors.Join(errors.New("RevokeSessions failed"), err)
+		}
+		if err := s.enforceAdminOfUser(ctx, usernameStr, user.UserName); err != nil {
+			return errors.Join(errors.New("RevokeSessions failed"), err)
+		}
+	} else if _, err := s.adminRepo.GetUsers(ctx, nil, id); err != nil {
+		return errors.Join(errors.New("RevokeSessions failed"), err)
+	}
+	if err := s.sessionsRepo.DeleteUserSessions(ctx, nil, []uint32{id}); err != nil {
+		return errors.Join(errors.New("RevokeSessions failed"), err)
+	}
+	if err := s.tokenRepo.RevokeUserTokens(ctx, _id); err != nil {
+		return errors.Join(errors.New("RevokeSessions failed"), err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+)
+
+// loginLDAP binds to the directory, provisions the user on first login
+// and syncs teams from the groups of user.
+func (s *AdminUsecase) loginLDAP(ctx context.Context, username, password string) (*repo.User, error) {
+	dirUser, err := s.directory.Authenticate(ctx, username, password)
+	if errors.Is(err, repo.ErrInvalidCredentials) {
+		return nil, errors.New("password is incorrect")
+	}
+	if err != nil {
+		return nil, errors.Join(errors.New("Login failed"), err)
+	}
+
+	var user *repo.User
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		mapping, e := s.ldapGroupTeams(ctx, tx)
+		if e != nil {
+			return e
+		}
+		user, e = s.provisionLDAPUser(ctx, tx, dirUser)
+		if e != nil {
+			return e
+		}
+		return s.syncExternalGroups(ctx, tx, user, dirUser.Groups, mapping)
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("Login failed"), err)
+	}
+	return user, nil
+}
+
+// provisionLDAPUser creates user on first login and enables user found in the directory again.
+// users are matched by name, the dn changes when user is moved in the directory.
+func (s *AdminUsecase) provisionLDAPUser(ctx context.Context, tx repo.TX, dirUser *repo.DirectoryUser) (*repo.User, error) {
+	users, err := s.adminRepo.ListUsers(ctx, tx, &repo.UsersFilter{
+		UserName: []string{dirUser.UserName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		user := users[0]
+		if user.Source != repo.UserSourceLDAP {
+			return nil, AlreadyExists("user name is taken by a %s user", user.Source)
+		}
+		if user.Disabled {
+			if err := s.adminRepo.DisableUsers(ctx, tx, []uint32{user.Id}, false); err != nil {
+				return nil, err
+			}
+			user.Disabled = false
+		}
+		return user, nil
+	}
+
+	user := &repo.User{
+		UserName:   dirUser.UserName,
+		Email:      dirUser.Email,
+		Source:     repo.UserSourceLDAP,
+		ExternalId: dirUser.DN,
+	}
+	if err := s.createExternalUser(ctx, tx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ldapGroupTeams returns ldap.group_teams resolved to teams of the default organization.
+func (s *AdminUsecase) ldapGroupTeams(ctx context.Context, tx repo.TX) (map[string]*groupTeam, error) {
+	return s.groupTeams(ctx, tx, "ldap", s.conf.GetLdap().GetGroupTeams())
+}
+
+// SyncDirectory creates users of the directory, syncs their teams and disables
+// users removed from the directory. sessions of disabled users are revoked.
+func (s *AdminUsecase) SyncDirectory(ctx context.Context) error {
+	if s.directory == nil {
+		return repo.ErrDirectoryNotConfigured
+	}
+	dirUsers, err := s.directory.ListUsers(ctx)
+	if err != nil {
+		return errors.Join(errors.New("SyncDirectory failed"), err)
+	}
+	users, err := s.adminRepo.ListUsers(ctx, nil, &repo.UsersFilter{
+		Sources: []string{repo.UserSourceLDAP},
+	})
+	if err != nil {
+		return errors.Join(errors.New("SyncDirectory failed"), err)
+	}
+	// a wrong base dn or filter must not disable everyone
+	if len(dirUsers) == 0 && len(users) > 0 {
+		return errors.New("SyncDirectory failed: no user found in directory")
+	}
+
+	existing := map[string]*repo.User{}
+	for _, user := range users {
+		existing[user.UserName] = user
+	}
+	found := map[string]bool{}
+	var disabled []uint32
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		mapping, err := s.ldapGroupTeams(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for _, dirUser := range dirUsers {
+			found[dirUser.UserName] = true
+			user, ok := existing[dirUser.UserName]
+			if !ok || user.Disabled {
+				if user, err = s.provisionLDAPUser(ctx, tx, dirUser); err != nil {
+					// eg: name is taken by a local user
+					s.log.Warnf("sync ldap user %s: %v", dirUser.UserName, err)
+					continue
+				}
+			}
+			if err := s.syncExternalGroups(ctx, tx, user, dirUser.Groups, mapping); err != nil {
+				return err
+			}
+		}
+		for _, user := range users {
+			if !found[user.UserName] && !user.Disabled {
+				disabled = append(disabled, user.Id)
+				s.log.Infof("disable ldap user %s", user.UserName)
+			}
+		}
+		return s.adminRepo.DisableUsers(ctx, tx, disabled, true)
+	})
+	if err != nil {
+		return errors.Join(errors.New("SyncDirectory failed"), err)
+	}
+
+	if len(disabled) > 0 {
+		if err := s.sessionsRepo.DeleteUserSessions(ctx, nil, disabled); err != nil {
+			return errors.Join(errors.New("SyncDirectory failed"), err)
+		}
+	}
+	for _, id := range disabled {
+		if err := s.tokenRepo.RevokeUserTokens(ctx, fmt.Sprint(id)); err != nil {
+			return errors.Join(errors.New("SyncDirectory failed"), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package biz
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+)
+
+const (
+	DefaultMFAIssuer = "OpsPillar"
+	// TOTP of RFC 6238 with the parameters authenticator apps default to
+	totpDigits = 6
+	totpPeriod = 30
+	// codes of the previous and the next step are accepted for clock skew
+	totpSkew         = 1
+	mfaRecoveryCodes = 10
+)
+
+var ErrMFARequired = PermissionDenied("MFA code is required")
+var ErrMFACodeIncorrect = PermissionDenied("MFA code is incorrect")
+
+// ErrMFAEnrollmentRequired refuses logins of users required to use MFA without it, a secret
+// handed out after only the password would go to whoever knows the password.
+var ErrMFAEnrollmentRequired = FailedPrecondition("MFA enrollment is required, enroll with `opspillar-cli user mfa-enroll` " +
+	"while logged in or ask an admin to enroll you")
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAEnrollment is returned once to the user who enrolls.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+	RecoveryCodes   []string
+}
+
+// MFARequiredError asks to login again with an MFA code.
+type MFARequiredError struct{}
+
+func (e *MFARequiredError) Error() string {
+	return ErrMFARequired.Error()
+}
+
+func (e *MFARequiredError) Is(target error) bool {
+	return target == ErrMFARequired
+}
+
+// TOTPCode is the code of secret at t.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return totpCode(secret, t.Unix()/totpPeriod)
+}
+
+func totpCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// matchTOTP returns the time step of code around now, 0 if it matches none.
+func matchTOTP(secret, code string, now time.Time) int64 {
+	step := now.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		c, err := totpCode(secret, step+i)
+		if err == nil && subtle.ConstantTimeCompare([]byte(c), []byte(code)) == 1 {
+			return step + i
+		}
+	}
+	return 0
+}
+
+// normalizeMFACode drops separators users may type, recovery codes are shown as xxxx-xxxx.
+func normalizeMFACode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+func (s *AdminUsecase) mfaIssuer() string {
+	if issuer := s.conf.GetMfaIssuer(); issuer != "" {
+		return issuer
+	}
+	return DefaultMFAIssuer
+}
+
+// newMFAEnrollment creates a secret and recovery codes, only hashes of the codes are stored.
+func (s *AdminUsecase) newMFAEnrollment(username string, now time.Time) (*MFAEnrollment, []*repo.MFARecoveryCode, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return nil, nil, err
+	}
+	secret := totpEncoding.EncodeToString(b)
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", s.mfaIssuer())
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", strconv.Itoa(totpDigits))
+	q.Set("period", strconv.Itoa(totpPeriod))
+	enrollment := &MFAEnrollment{
+		Secret: secret,
+		ProvisioningURI: fmt.Sprintf("otpauth://totp/%s:%s?%s",
+			url.PathEscape(s.mfaIssuer()), url.PathEscape(username), q.Encode()),
+	}
+	codes := make([]*repo.MFARecoveryCode, 0, mfaRecoveryCodes)
+	for i := 0; i < mfaRecoveryCodes; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(b))
+		enrollment.RecoveryCodes = append(enrollment.RecoveryCodes, code[:4]+"-"+code[4:])
+		codes = append(codes, &repo.MFARecoveryCode{Hash: hashRefreshToken(code), CreatedAt: now.Unix()})
+	}
+	return enrollment, codes, nil
+}
+
+// mfaRequired is true for local users of admin-team if require_admin_mfa is set.
+func (s *AdminUsecase) mfaRequired(ctx context.Context, user *repo.User) bool {
+	if !s.conf.GetRequireAdminMfa() || !isLocalUser(user) {
+		return false
+	}
+	// groups are filtered by either user or role
+	groups, err := s.authzRepo.ListGroup(ctx, nil, &repo.GroupFilter{User: user.UserName})
+	if err != nil {
+		// fail closed, a second factor is asked rather than skipped
+		s.log.Warnf("list groups of %s failed: %v", user.UserName, err)
+		return true
+	}
+	for _, g := range groups {
+		if g.Role == AdminTeam {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMFACode accepts a TOTP code of the user once, and a recovery code if allowed.
+func (s *AdminUsecase) checkMFACode(ctx context.Context, user *repo.User, code string, recovery bool, now time.Time) error {
+	code = normalizeMFACode(code)
+	if len(code) == totpDigits {
+		if step := matchTOTP(user.MFASecret, code, now); step != 0 {
+			ok, err := s.adminRepo.UseMFAStep(ctx, nil, user.Id, step)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+		}
+		return ErrMFACodeIncorrect
+	}
+	if !recovery || code == "" {
+		return ErrMFACodeIncorrect
+	}
+	err := s.adminRepo.UseMFARecoveryCode(ctx, nil, user.Id, hashRefreshToken(code), now.Unix())
+	if errors.Is(err, repo.ErrInvalidRecoveryCode) {
+		return ErrMFACodeIncorrect
+	}
+	if err == nil {
+		s.log.Warnf("user %s logged in with a recovery code", user.UserName)
+	}
+	return err
+}
+
+// loginMFA is the second step of local logins. Users with MFA login with a TOTP or recovery
+// code, users required to use MFA confirm a pending enrollment with a code of it, those not
+// enrolled are refused. Wrong codes count as failed logins.
+func (s *AdminUsecase) loginMFA(ctx context.Context, user *repo.User, code string, now time.Time) error {
+	if !user.MFAEnabled && !s.mfaRequired(ctx, user) {
+		return nil
+	}
+	if !user.MFAEnabled && user.MFASecret == "" {
+		return ErrMFAEnrollmentRequired
+	}
+	if code == "" {
+		return &MFARequiredError{}
+	}
+	err := s.checkMFACode(ctx, user, code, user.MFAEnabled, now)
+	if errors.Is(err, ErrMFACodeIncorrect) {
+		s.countFailedLogin(ctx, user, now)
+	}
+	if err != nil || user.MFAEnabled {
+		return err
+	}
+	return s.adminRepo.EnableMFA(ctx, nil, user.Id, user.MFASecret)
+}
+
+// currentLocalUser is the local user of ctx, MFA is managed by the users themselves.
+func (s *AdminUsecase) currentLocalUser(ctx context.Context) (*repo.User, error) {
+	username, err := getUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userByName(ctx, nil, username)
+	if err != nil {
+		return nil, err
+	}
+	if !isLocalUser(user) {
+		return nil, FailedPrecondition("MFA of %s user is managed by its provider", user.Source)
+	}
+	return user, nil
+}
+
+// otherLocalUser is the local user of id, the current user must be admin of the user.
+func (s *AdminUsecase) otherLocalUser(ctx context.Context, id uint32) (*repo.User, error) {
+	operator, err := getUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.adminRepo.GetUsers(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enforceAdminOfUser(ctx, operator, user.UserName); err != nil {
+		return nil, err
+	}
+	if !isLocalUser(user) {
+		return nil, FailedPrecondition("MFA of %s user is managed by its provider", user.Source)
+	}
+	return user, nil
+}
+
+// EnrollMFA creates a pending secret and recovery codes of the current user, a pending
+// secret of a previous enrollment is replaced. Admins enroll other users by id and hand the
+// enrollment over, the user confirms it with a code at login.
+func (s *AdminUsecase) EnrollMFA(ctx context.Context, id uint32) (*MFAEnrollment, error) {
+	var user *repo.User
+	var err error
+	if id == 0 || strconv.Itoa(int(id)) == ctx.Value(data.CtxUserId) {
+		user, err = s.currentLocalUser(ctx)
+	} else {
+		user, err = s.otherLocalUser(ctx, id)
+	}
+	if err != nil {
+		return nil, errors.Join(errors.New("EnrollMFA failed"), err)
+	}
+	if user.MFAEnabled {
+		return nil, errors.Join(errors.New("EnrollMFA failed"), errors.New("MFA is already enabled, disable it first"))
+	}
+	enrollment, codes, err := s.newMFAEnrollment(user.UserName, time.Now())
+	if err != nil {
+		return nil, errors.Join(errors.New("EnrollMFA failed"), err)
+	}
+	if err := s.adminRepo.SetMFA(ctx, nil, user.Id, enrollment.Secret, codes); err != nil {
+		return nil, errors.Join(errors.New("EnrollMFA failed"), err)
+	}
+	if user.UserName != ctx.Value(data.CtxUserName) {
+		s.log.Warnf("MFA of %s enrolled by %s", user.UserName, ctx.Value(data.CtxUserName))
+	}
+	return enrollment, nil
+}
+
+// ConfirmMFA enables the pending secret of the current user with a code of it.
+func (s *AdminUsecase) ConfirmMFA(ctx context.Context, code string) error {
+	user, err := s.currentLocalUser(ctx)
+	if err != nil {
+		return errors.Join(errors.New("ConfirmMFA failed"), err)
+	}
+	if user.MFAEnabled {
+		return errors.Join(errors.New("ConfirmMFA failed"), errors.New("MFA is already enabled"))
+	}
+	if user.MFASecret == "" {
+		return errors.Join(errors.New("ConfirmMFA failed"), errors.New("MFA is not enrolled"))
+	}
+	if err := s.checkMFACode(ctx, user, code, false, time.Now()); err != nil {
+		return errors.Join(errors.New("ConfirmMFA failed"), err)
+	}
+	if err := s.adminRepo.EnableMFA(ctx, nil, user.Id, user.MFASecret); err != nil {
+		return errors.Join(errors.New("ConfirmMFA failed"), err)
+	}
+	return nil
+}
+
+// DisableMFA disables MFA of the current user with a code, users required to use MFA
+// can not disable it. Admins disable MFA of others who lost their device, logins of those
+// required are refused with ErrMFAEnrollmentRequired until they enroll again while logged in
+// or an admin enrolls them.
+func (s *AdminUsecase) DisableMFA(ctx context.Context, id uint32, code string) error {
+	if id == 0 || strconv.Itoa(int(id)) == ctx.Value(data.CtxUserId) {
+		user, err := s.currentLocalUser(ctx)
+		if err != nil {
+			return errors.Join(errors.New("DisableMFA failed"), err)
+		}
+		if !user.MFAEnabled {
+			return errors.Join(errors.New("DisableMFA failed"), errors.New("MFA is not enabled"))
+		}
+		if s.mfaRequired(ctx, user) {
+			return errors.Join(errors.New("DisableMFA failed"), FailedPrecondition("MFA is required for %s", AdminTeam))
+		}
+		if err := s.checkMFACode(ctx, user, code, true, time.Now()); err != nil {
+			return errors.Join(errors.New("DisableMFA failed"), err)
+		}
+		id = user.Id
+	} else {
+		operator, err := getUsername(ctx)
+		if err != nil {
+			return errors.Join(errors.New("DisableMFA failed"), err)
+		}
+		user, err := s.adminRepo.GetUsers(ctx, nil, id)
+		if err != nil {
+			return errors.Join(errors.New("DisableMFA failed"), err)
+		}
+		if err := s.enforceAdminOfUser(ctx, operator, user.UserName); err != nil {
+			return errors.Join(errors.New("DisableMFA failed"), err)
+		}
+	}
+	if err := s.adminRepo.SetMFA(ctx, nil, id, "", nil); err != nil {
+		return errors.Join(errors.New("DisableMFA failed"), err)
+	}
+	return nil
+}
@@ -13,6 +13,14 @@ type User struct {
 	Email    string
 	Phone    string
 	Token    string
+	// RefreshToken is only returned by Login and RefreshToken
+	RefreshToken string
+	Source       string
+	Disabled     bool
+	// unix seconds
+	PasswordChangedAt int64
+	LockedUntil       int64
+	MFAEnabled        bool
 }
 
 type ListUsersFilter struct {
@@ -22,6 +30,11 @@ type ListUsersFilter struct {
 	UserNames []string
 	Emails    []string
 	Phones    []string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
 
 func (lf *ListUsersFilter) Validate() error {
@@ -40,6 +53,9 @@ func (lf *ListUsersFilter) Validate() error {
 		len(lf.Phones) > MaxFilterValues {
 		return ErrFilterValuesExceedMax
 	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -54,6 +70,7 @@ func ToDBUsersFilter(filter *ListUsersFilter) *repo.UsersFilter {
 		Phone:    filter.Phones,
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
 	}
 }
 
@@ -73,21 +90,36 @@ func (m *User) Validate(opts *UserOptions) error {
 			return errors.New("invalid email format")
 		}
 	}
-	if opts.StrictPasswordPolicy {
-		// Check password policy
-		hasNumber := strings.ContainsAny(m.Password, "0123456789")
-		hasLetter := strings.ContainsAny(m.Password, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
-		hasSpecial := strings.ContainsAny(m.Password, "!@#$%^&*()_+-=[]{}|;:,.<>?")
-		if !hasNumber || !hasLetter || !hasSpecial {
-			return errors.New("password must contain at least one number, one letter and one special character")
-		}
-		if len(m.Password) < 8 {
-			return errors.New("password must be at least 8 characters long")
+	// a stored hash is kept as is, the policy applied when it was set
+	if opts.StrictPasswordPolicy && !strings.HasPrefix(m.Password, BcryptPrefix) {
+		if err := ValidatePassword(m.Password, true); err != nil {
+			return invalidField("password", err)
 		}
 	}
 	return nil
 }
 
+// ValidatePassword requires a password, strict policy also requires complexity.
+func ValidatePassword(password string, strict bool) error {
+	if password == "" {
+		return errors.New("password is empty")
+	}
+	if !strict {
+		return nil
+	}
+	// Check password policy
+	hasNumber := strings.ContainsAny(password, "0123456789")
+	hasLetter := strings.ContainsAny(password, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	hasSpecial := strings.ContainsAny(password, "!@#$%^&*()_+-=[]{}|;:,.<>?")
+	if !hasNumber || !hasLetter || !hasSpecial {
+		return errors.New("password must contain at least one number, one letter and one special character")
+	}
+	if len(password) < 8 {
+		return errors.New("password must be at least 8 characters long")
+	}
+	return nil
+}
+
 func ToRepoUsers(users []*User) ([]*repo.User, error) {
 	repoUsers := make([]*repo.User, 0, len(users))
 	for _, user := range users {
@@ -126,6 +158,12 @@ func ToBizUser(user *repo.User) *User {
 		Password: user.Password,
 		Email:    user.Email,
 		Phone:    user.Phone,
+		Source:   user.Source,
+		Disabled: user.Disabled,
+
+		PasswordChangedAt: user.PasswordChangedAt,
+		LockedUntil:       user.LockedUntil,
+		MFAEnabled:        user.MFAEnabled,
 	}
 }
 
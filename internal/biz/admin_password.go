@@ -0,0 +1,259 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"opspillar/internal/data/repo"
+)
+
+const (
+	DefaultMaxFailedLogins       = 5
+	DefaultLockoutMinutes        = 15
+	DefaultResetTokenExpireHours = 24
+)
+
+var ErrUserLocked = PermissionDenied("user is locked after too many failed logins")
+var ErrPasswordIncorrect = PermissionDenied("password is incorrect")
+var ErrPasswordExpired = FailedPrecondition("password expired, change it with `opspillar user passwd`")
+
+// PasswordResetToken is returned once to the admin who issued it.
+type PasswordResetToken struct {
+	UserId    uint32
+	UserName  string
+	Token     string
+	ExpiresAt int64
+}
+
+func (s *AdminUsecase) maxFailedLogins() int32 {
+	max := s.conf.GetMaxFailedLogins()
+	if max == 0 {
+		max = DefaultMaxFailedLogins
+	}
+	return max
+}
+
+func (s *AdminUsecase) lockoutDuration() time.Duration {
+	minutes := s.conf.GetLockoutMinutes()
+	if minutes <= 0 {
+		minutes = DefaultLockoutMinutes
+	}
+	return time.Minute * time.Duration(minutes)
+}
+
+func (s *AdminUsecase) resetTokenTTL() time.Duration {
+	hours := s.conf.GetResetTokenExpireHours()
+	if hours <= 0 {
+		hours = DefaultResetTokenExpireHours
+	}
+	return time.Hour * time.Duration(hours)
+}
+
+func isLocalUser(user *repo.User) bool {
+	return user.Source == "" || user.Source == repo.UserSourceLocal
+}
+
+// passwordExpired is true if the password of a local user is older than password_expire_days,
+// the password of admin is set by config and never expires.
+func (s *AdminUsecase) passwordExpired(user *repo.User, now time.Time) bool {
+	days := s.conf.GetPasswordExpireDays()
+	if days <= 0 || !isLocalUser(user) || user.UserName == AdminUser || user.PasswordChangedAt == 0 {
+		return false
+	}
+	return now.After(time.Unix(user.PasswordChangedAt, 0).Add(time.Hour * 24 * time.Duration(days)))
+}
+
+// verifyPassword checks password of a local user, failures are counted and lock the user.
+func (s *AdminUsecase) verifyPassword(ctx context.Context, user *repo.User, password string, now time.Time) error {
+	if user.LockedUntil > now.Unix() {
+		return fmt.Errorf("%w, try again after %s", ErrUserLocked, time.Unix(user.LockedUntil, 0).Format(time.DateTime))
+	}
+	if CheckPassword(user.Password, password) {
+		return nil
+	}
+	s.countFailedLogin(ctx, user, now)
+	return ErrPasswordIncorrect
+}
+
+// resetFailedLogins clears the failures of a user once all checks of a login passed, a right
+// password alone does not clear the failures of wrong MFA codes.
+func (s *AdminUsecase) resetFailedLogins(ctx context.Context, user *repo.User) {
+	if user.FailedLogins == 0 {
+		return
+	}
+	if err := s.adminRepo.ResetFailedLogins(ctx, nil, user.Id); err != nil {
+		s.log.Warnf("reset failed logins of %s failed: %v", user.UserName, err)
+	}
+}
+
+// countFailedLogin counts a wrong password or MFA code, the user is locked when failures
+// reach max_failed_logins.
+func (s *AdminUsecase) countFailedLogin(ctx context.Context, user *repo.User, now time.Time) {
+	max := s.maxFailedLogins()
+	if max < 0 {
+		return
+	}
+	lockUntil := now.Add(s.lockoutDuration()).Unix()
+	locked, err := s.adminRepo.AddFailedLogin(ctx, nil, user.Id, max, lockUntil)
+	if err != nil {
+		s.log.Warnf("count failed login of %s failed: %v", user.UserName, err)
+	}
+	if locked {
+		s.log.Warnf("user %s locked until %s", user.UserName, time.Unix(lockUntil, 0).Format(time.DateTime))
+	}
+}
+
+// passwordSettable rejects users whose password is not managed here.
+func passwordSettable(user *repo.User) error {
+	if user.UserName == AdminUser {
+		return errors.New("password of admin is set by admin_password of config")
+	}
+	if !isLocalUser(user) {
+		return FailedPrecondition("password of %s user is managed by its provider", user.Source)
+	}
+	if user.Disabled {
+		return ErrUserDisabled
+	}
+	return nil
+}
+
+func (s *AdminUsecase) userByName(ctx context.Context, tx repo.TX, username string) (*repo.User, error) {
+	users, err := s.adminRepo.ListUsers(ctx, tx, &repo.UsersFilter{UserName: []string{username}})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, NotFound("user not found")
+	}
+	return users[0], nil
+}
+
+// ChangePassword changes the password of a local user with the old one, and a code if
+// MFA is enabled. It does not require a token, users with expired passwords can not login.
+func (s *AdminUsecase) ChangePassword(ctx context.Context, username, oldPassword, newPassword, mfaCode string) error {
+	if username == "" || oldPassword == "" {
+		return errors.Join(errors.New("ChangePassword failed"), errors.New("username or old password is empty"))
+	}
+	if err := ValidatePassword(newPassword, s.conf.StrictPasswordPolicy); err != nil {
+		return errors.Join(errors.New("ChangePassword failed"), invalidField("new_password", err))
+	}
+	if newPassword == oldPassword {
+		return errors.Join(errors.New("ChangePassword failed"), errors.New("new password is the same as the old one"))
+	}
+	user, err := s.userByName(ctx, nil, username)
+	if err != nil {
+		return errors.Join(errors.New("ChangePassword failed"), err)
+	}
+	if err := passwordSettable(user); err != nil {
+		return errors.Join(errors.New("ChangePassword failed"), err)
+	}
+	now := time.Now()
+	if err := s.verifyPassword(ctx, user, oldPassword, now); err != nil {
+		return errors.Join(errors.New("ChangePassword failed"), err)
+	}
+	if user.MFAEnabled {
+		if mfaCode == "" {
+			return errors.Join(errors.New("ChangePassword failed"), ErrMFARequired)
+		}
+		if err := s.checkMFACode(ctx, user, mfaCode, true, now); err != nil {
+			if errors.Is(err, ErrMFACodeIncorrect) {
+				s.countFailedLogin(ctx, user, now)
+			}
+			return errors.Join(errors.New("ChangePassword failed"), err)
+		}
+	}
+	s.resetFailedLogins(ctx, user)
+	hash, err := HashPassword(newPassword)
+	if err != nil {
+		return errors.Join(errors.New("ChangePassword failed"), err)
+	}
+	if err := s.adminRepo.SetPassword(ctx, nil, user.Id, hash, now.Unix()); err != nil {
+		return errors.Join(errors.New("ChangePassword failed"), err)
+	}
+	return nil
+}
+
+// CreatePasswordResetToken issues a one-time token to reset the password of a user,
+// it replaces unused tokens of the user. It requires user admin permission.
+func (s *AdminUsecase) CreatePasswordResetToken(ctx context.Context, id uint32) (*PasswordResetToken, error) {
+	operator, err := getUsername(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("CreatePasswordResetToken failed"), err)
+	}
+	user, err := s.adminRepo.GetUsers(ctx, nil, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("CreatePasswordResetToken failed"), err)
+	}
+	if err := s.enforceAdminOfUser(ctx, operator, user.UserName); err != nil {
+		return nil, errors.Join(errors.New("CreatePasswordResetToken failed"), err)
+	}
+	if err := passwordSettable(user); err != nil {
+		return nil, errors.Join(errors.New("CreatePasswordResetToken failed"), err)
+	}
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, errors.Join(errors.New("CreatePasswordResetToken failed"), err)
+	}
+	now := time.Now()
+	t := &repo.PasswordResetToken{
+		UserId:    user.Id,
+		Hash:      hash,
+		ExpiresAt: now.Add(s.resetTokenTTL()).Unix(),
+		CreatedBy: operator,
+		CreatedAt: now.Unix(),
+	}
+	if err := s.adminRepo.CreatePasswordResetToken(ctx, nil, t); err != nil {
+		return nil, errors.Join(errors.New("CreatePasswordResetToken failed"), err)
+	}
+	return &PasswordResetToken{
+		UserId:    user.Id,
+		UserName:  user.UserName,
+		Token:     token,
+		ExpiresAt: t.ExpiresAt,
+	}, nil
+}
+
+// ResetPassword sets a new password with a reset token and unlocks the user. Sessions of
+// the user are revoked, whoever knew the old password is logged out.
+func (s *AdminUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" {
+		return errors.Join(errors.New("ResetPassword failed"), repo.ErrInvalidResetToken)
+	}
+	if err := ValidatePassword(newPassword, s.conf.StrictPasswordPolicy); err != nil {
+		return errors.Join(errors.New("ResetPassword failed"), invalidField("new_password", err))
+	}
+	hash, err := HashPassword(newPassword)
+	if err != nil {
+		return errors.Join(errors.New("ResetPassword failed"), err)
+	}
+	now := time.Now()
+	var userId uint32
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		t, err := s.adminRepo.UsePasswordResetToken(ctx, tx, hashRefreshToken(token), now.Unix())
+		if err != nil {
+			return err
+		}
+		user, err := s.adminRepo.GetUsers(ctx, tx, t.UserId)
+		if err != nil {
+			return err
+		}
+		if err := passwordSettable(user); err != nil {
+			return err
+		}
+		userId = user.Id
+		return s.adminRepo.SetPassword(ctx, tx, user.Id, hash, now.Unix())
+	})
+	if err != nil {
+		return errors.Join(errors.New("ResetPassword failed"), err)
+	}
+	if err := s.sessionsRepo.DeleteUserSessions(ctx, nil, []uint32{userId}); err != nil {
+		return errors.Join(errors.New("ResetPassword failed"), errors.New("password is reset, revoking sessions failed"), err)
+	}
+	if err := s.tokenRepo.RevokeUserTokens(ctx, strconv.Itoa(int(userId))); err != nil {
+		return errors.Join(errors.New("ResetPassword failed"), errors.New("password is reset, revoking sessions failed"), err)
+	}
+	return nil
+}
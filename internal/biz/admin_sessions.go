@@ -0,0 +1,243 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+)
+
+const DefaultRefreshTokenExpireHours = 24 * 30
+
+var ErrInvalidRefreshToken = PermissionDenied("invalid refresh token")
+var ErrReusedRefreshToken = PermissionDenied("refresh token reused, session revoked")
+var ErrUserDisabled = PermissionDenied("user is disabled")
+
+// ClientInfo describes where a session is used from.
+type ClientInfo struct {
+	Device string
+	IP     string
+}
+
+type Session struct {
+	Id         uint32
+	UserId     uint32
+	Device     string
+	IP         string
+	CreatedAt  int64
+	LastUsedAt int64
+	ExpiresAt  int64
+	// Current is the session of the request
+	Current bool
+}
+
+func ToBizSession(session *repo.Session) *Session {
+	return &Session{
+		Id:         session.Id,
+		UserId:     session.UserId,
+		Device:     session.Device,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+// newRefreshToken returns a random token and its hash to store.
+func newRefreshToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *AdminUsecase) refreshTokenTTL() time.Duration {
+	hours := s.conf.GetRefreshTokenExpireHours()
+	if hours <= 0 {
+		hours = DefaultRefreshTokenExpireHours
+	}
+	return time.Hour * time.Duration(hours)
+}
+
+func getSessionId(ctx context.Context) uint32 {
+	sid, ok := ctx.Value(data.CtxSessionId).(string)
+	if !ok {
+		return 0
+	}
+	id, err := strconv.ParseUint(sid, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(id)
+}
+
+// issueTokens rotates refresh token of session, creates the session if new,
+// and returns user with access token and refresh token.
+func (s *AdminUsecase) issueTokens(ctx context.Context, tx repo.TX, user *repo.User, session *repo.Session) (*User, error) {
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+	refreshToken, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session.PrevHash = session.Hash
+	session.Hash = hash
+	session.LastUsedAt = now.Unix()
+	session.ExpiresAt = now.Add(s.refreshTokenTTL()).Unix()
+	if session.Id == 0 {
+		session.UserId = user.Id
+		session.CreatedAt = now.Unix()
+		err = s.sessionsRepo.CreateSessions(ctx, tx, []*repo.Session{session})
+	} else {
+		// another refresh with the same token may have rotated it since it was read,
+		// only one of them wins and the other one is a reuse
+		var rotated bool
+		rotated, err = s.sessionsRepo.RotateSession(ctx, tx, session, session.PrevHash)
+		if err == nil && !rotated {
+			err = ErrReusedRefreshToken
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenRepo.CreateToken(ctx, repo.TokenClaims{
+		string(data.CtxUserId):    strconv.Itoa(int(user.Id)),
+		string(data.CtxUserName):  user.UserName,
+		string(data.CtxSessionId): strconv.Itoa(int(session.Id)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bizUser := ToBizUser(user)
+	bizUser.Token = token
+	bizUser.RefreshToken = refreshToken
+	return bizUser, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token.
+// a refresh token can be used only once, using a replaced one revokes the session.
+func (s *AdminUsecase) RefreshToken(ctx context.Context, refreshToken string, client *ClientInfo) (*User, error) {
+	if refreshToken == "" {
+		return nil, errors.Join(errors.New("RefreshToken failed"), ErrInvalidRefreshToken)
+	}
+	hash := hashRefreshToken(refreshToken)
+
+	sessions, err := s.sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{
+		Hashes: []string{hash},
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("RefreshToken failed"), err)
+	}
+	if len(sessions) != 1 {
+		return nil, errors.Join(errors.New("RefreshToken failed"), ErrInvalidRefreshToken)
+	}
+	session := sessions[0]
+	if session.Hash != hash {
+		// an old refresh token, may be stolen
+		return nil, s.revokeReusedSession(ctx, session.Id)
+	}
+	if session.ExpiresAt < time.Now().Unix() {
+		if err := s.sessionsRepo.DeleteSessions(ctx, nil, []uint32{session.Id}); err != nil {
+			return nil, errors.Join(errors.New("RefreshToken failed"), err)
+		}
+		return nil, errors.Join(errors.New("RefreshToken failed"), ErrInvalidRefreshToken)
+	}
+	if client != nil {
+		if client.Device != "" {
+			session.Device = client.Device
+		}
+		if client.IP != "" {
+			session.IP = client.IP
+		}
+	}
+
+	var bizUser *User
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		user, err := s.adminRepo.GetUsers(ctx, tx, session.UserId)
+		if err != nil {
+			return err
+		}
+		bizUser, err = s.issueTokens(ctx, tx, user, session)
+		return err
+	})
+	if errors.Is(err, ErrReusedRefreshToken) {
+		// a concurrent refresh rotated the token first
+		return nil, s.revokeReusedSession(ctx, session.Id)
+	}
+	if err != nil {
+		return nil, errors.Join(errors.New("RefreshToken failed"), err)
+	}
+	return bizUser, nil
+}
+
+// revokeReusedSession deletes the session of a reused refresh token and returns the error of RefreshToken.
+func (s *AdminUsecase) revokeReusedSession(ctx context.Context, id uint32) error {
+	s.log.Warnf("refresh token of session %d reused, revoke it", id)
+	if err := s.sessionsRepo.DeleteSessions(ctx, nil, []uint32{id}); err != nil {
+		return errors.Join(errors.New("RefreshToken failed"), err)
+	}
+	return errors.Join(errors.New("RefreshToken failed"), ErrReusedRefreshToken)
+}
+
+// ListSessions lists sessions of user. id 0 means the current user.
+// listing sessions of others requires user admin permission.
+func (s *AdminUsecase) ListSessions(ctx context.Context, id uint32) ([]*Session, error) {
+	if id == 0 {
+		_id, err := strconv.ParseUint(fmt.Sprint(ctx.Value(data.CtxUserId)), 10, 32)
+		if err != nil {
+			return nil, errors.Join(errors.New("ListSessions failed"), errors.New("invalid user id"))
+		}
+		id = uint32(_id)
+	}
+	if strconv.Itoa(int(id)) != ctx.Value(data.CtxUserId) {
+		usernameStr, err := getUsername(ctx)
+		if err != nil {
+			return nil, errors.Join(errors.New("ListSessions failed"), err)
+		}
+		user, err := s.adminRepo.GetUsers(ctx, nil, id)
+		if err != nil {
+			return nil, errors.Join(errors.New("ListSessions failed"), err)
+		}
+		if err := s.enforceAdminOfUser(ctx, usernameStr, user.UserName); err != nil {
+			return nil, errors.Join(errors.New("ListSessions failed"), err)
+		}
+	}
+
+	sessions, err := s.sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{
+		UserIds: []uint32{id},
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("ListSessions failed"), err)
+	}
+	current := getSessionId(ctx)
+	now := time.Now().Unix()
+	bizSessions := make([]*Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ExpiresAt < now {
+			continue
+		}
+		bizSession := ToBizSession(session)
+		bizSession.Current = session.Id == current
+		bizSessions = append(bizSessions, bizSession)
+	}
+	return bizSessions, nil
+}
@@ -0,0 +1,246 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+
+	"opspillar/internal/data/repo"
+)
+
+// externalPassword can never match a bcrypt hash, so users of sso or
+// directory can not login with a local password.
+func externalPassword(source string) string {
+	return "!" + source
+}
+
+// validateLoopbackRedirect only allows redirect to a local listener of cli.
+func validateLoopbackRedirect(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return errors.Join(errors.New("invalid redirect uri"), err)
+	}
+	if u.Scheme != "http" {
+		return errors.New("redirect uri must be http loopback")
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return errors.New("redirect uri must be http loopback")
+}
+
+// SSOAuthURL returns url of the identity provider to start authorization code flow.
+// state, nonce and PKCE code challenge are generated and kept by the client.
+func (s *AdminUsecase) SSOAuthURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	if s.sso == nil {
+		return "", repo.ErrSSONotConfigured
+	}
+	if state == "" || nonce == "" || codeChallenge == "" {
+		return "", errors.New("state, nonce or code challenge is empty")
+	}
+	if err := validateLoopbackRedirect(redirectURI); err != nil {
+		return "", invalidField("redirect_uri", err)
+	}
+	return s.sso.AuthCodeURL(ctx, redirectURI, state, nonce, codeChallenge)
+}
+
+// SSOLogin redeems authorization code, provisions the user on first login,
+// syncs casbin groups from groups claim and issues tokens like Login.
+func (s *AdminUsecase) SSOLogin(ctx context.Context, code, codeVerifier, redirectURI, nonce string, client *ClientInfo) (*User, error) {
+	if s.sso == nil {
+		return nil, repo.ErrSSONotConfigured
+	}
+	if code == "" || codeVerifier == "" || nonce == "" {
+		return nil, errors.New("code, code verifier or nonce is empty")
+	}
+	if err := validateLoopbackRedirect(redirectURI); err != nil {
+		return nil, invalidField("redirect_uri", err)
+	}
+	identity, err := s.sso.Exchange(ctx, code, codeVerifier, redirectURI, nonce)
+	if err != nil {
+		return nil, errors.Join(errors.New("SSOLogin failed"), err)
+	}
+
+	var user *repo.User
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		var e error
+		user, e = s.provisionSSOUser(ctx, tx, identity)
+		if e != nil {
+			return e
+		}
+		mapping, e := s.groupTeams(ctx, tx, "oidc", s.conf.GetOidc().GetGroupRoles())
+		if e != nil {
+			return e
+		}
+		return s.syncExternalGroups(ctx, tx, user, identity.Groups, mapping)
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("SSOLogin failed"), err)
+	}
+
+	session := &repo.Session{}
+	if client != nil {
+		session.Device = client.Device
+		session.IP = client.IP
+	}
+	return s.issueTokens(ctx, nil, user, session)
+}
+
+// provisionSSOUser creates user on first login, a local user of the same name is never taken over.
+func (s *AdminUsecase) provisionSSOUser(ctx context.Context, tx repo.TX, identity *repo.SSOIdentity) (*repo.User, error) {
+	users, err := s.adminRepo.ListUsers(ctx, tx, &repo.UsersFilter{
+		UserName: []string{identity.UserName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		user := users[0]
+		if user.Source != repo.UserSourceOIDC {
+			return nil, AlreadyExists("user name is taken by a local user")
+		}
+		if user.ExternalId != identity.Subject {
+			return nil, AlreadyExists("user name is taken by another sso user")
+		}
+		return user, nil
+	}
+
+	user := &repo.User{
+		UserName:   identity.UserName,
+		Email:      identity.Email,
+		Source:     repo.UserSourceOIDC,
+		ExternalId: identity.Subject,
+	}
+	if err := s.createExternalUser(ctx, tx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// createExternalUser creates user of sso or directory with the personal rule like CreateUsers.
+func (s *AdminUsecase) createExternalUser(ctx context.Context, tx repo.TX, user *repo.User) error {
+	user.Password = externalPassword(user.Source)
+	if err := s.adminRepo.CreateUsers(ctx, tx, []*repo.User{user}); err != nil {
+		return err
+	}
+	s.log.Infof("provisioned %s user %s", user.Source, user.UserName)
+	return s.authzRepo.CreateRule(ctx, tx, &repo.Rule{
+		Sub:      user.UserName,
+		Resource: repo.NewResource4Sv1("", "", "", user.UserName),
+		Action:   repo.ActWrite,
+	})
+}
+
+// groupTeam is a team of a group mapping with the role given to users of the group.
+type groupTeam struct {
+	team *repo.Team
+	role string
+}
+
+// groupTeams resolves a mapping of IdP or directory groups to `team` or `team:role` of teams
+// of the default organization, the role is member if omitted. leaders are set by updating the
+// team, so roles are member or maintainer. unknown teams and roles are skipped with a warning.
+func (s *AdminUsecase) groupTeams(ctx context.Context, tx repo.TX, source string, mapping map[string]string) (map[string]*groupTeam, error) {
+	if len(mapping) == 0 {
+		return nil, nil
+	}
+	roles := make(map[string]string, len(mapping))
+	names := make([]string, 0, len(mapping))
+	for group, value := range mapping {
+		name, role, ok := strings.Cut(value, ":")
+		if !ok {
+			role = repo.TeamRoleMember
+		}
+		if role != repo.TeamRoleMember && role != repo.TeamRoleMaintainer {
+			s.log.Warnf("role %s of %s group %s must be member or maintainer", role, source, group)
+			continue
+		}
+		roles[group] = role
+		names = append(names, name)
+	}
+	teams, err := s.teamsRepo.ListTeams(ctx, tx, &repo.TeamsFilter{Names: names, OrgIds: []uint32{repo.DefaultOrgId}})
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]*groupTeam{}
+	for group, role := range roles {
+		name, _, _ := strings.Cut(mapping[group], ":")
+		named := teamsNamed(teams, name)
+		if len(named) != 1 {
+			s.log.Warnf("team %s of %s group %s not found", name, source, group)
+			continue
+		}
+		res[group] = &groupTeam{team: named[0], role: role}
+	}
+	return res, nil
+}
+
+// syncExternalGroups makes user a member of the teams mapped from groups of IdP or directory,
+// with the highest role of its groups. only mapped teams are managed, memberships added by
+// hand to other teams are kept, and leaders keep their role.
+func (s *AdminUsecase) syncExternalGroups(ctx context.Context, tx repo.TX, user *repo.User, groups []string, mapping map[string]*groupTeam) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+	managed := map[uint32]*repo.Team{}
+	for _, gt := range mapping {
+		managed[gt.team.ID] = gt.team
+	}
+	want := map[uint32]string{}
+	for _, g := range groups {
+		gt, ok := mapping[g]
+		if !ok {
+			continue
+		}
+		if want[gt.team.ID] != repo.TeamRoleMaintainer {
+			want[gt.team.ID] = gt.role
+		}
+	}
+	teamIds := make([]uint32, 0, len(managed))
+	for id := range managed {
+		teamIds = append(teamIds, id)
+	}
+	current, err := s.members.memberRepo.ListTeamMembers(ctx, tx, &repo.TeamMembersFilter{
+		TeamIds: teamIds,
+		UserIds: []uint32{user.Id},
+	})
+	if err != nil {
+		return err
+	}
+
+	byTeam := map[uint32]*repo.TeamMember{}
+	for _, m := range current {
+		byTeam[m.TeamId] = m
+		if _, ok := want[m.TeamId]; ok || m.Role == repo.TeamRoleLeader {
+			continue
+		}
+		subject := TeamSubject(repo.DefaultOrgName, managed[m.TeamId].Name)
+		if err := s.members.removeGroup(ctx, tx, user.UserName, TeamRoleSubject(subject, m.Role)); err != nil {
+			return err
+		}
+		if err := s.members.memberRepo.DeleteTeamMembers(ctx, tx, []uint32{m.ID}); err != nil {
+			return err
+		}
+	}
+	for id, role := range want {
+		cur := byTeam[id]
+		if cur != nil && (cur.Role == role || cur.Role == repo.TeamRoleLeader) {
+			continue
+		}
+		team := managed[id]
+		if err := s.members.ensureTeamRoles(ctx, tx, repo.DefaultOrgName, team.Name); err != nil {
+			return err
+		}
+		if err := s.members.setMemberRole(ctx, tx, repo.DefaultOrgName, team.Name, cur,
+			&repo.TeamMember{TeamId: id, UserId: user.Id, Role: role}, user.UserName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
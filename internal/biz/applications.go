@@ -21,10 +21,13 @@ type ApplicationsUsecase struct {
 	ahgrepo   repo.AppHostgroupsRepo
 	prdrepo   repo.ProductsRepo
 	teamrepo  repo.TeamsRepo
+	orgrepo   repo.OrganizationsRepo
 	ftrepo    repo.FeaturesRepo
 	tagrepo   repo.TagsRepo
 	hgrepo    repo.HostgroupsRepo
 	hfrepo    repo.HostgroupFeaturesRepo
+	attrrepo  repo.AttributesRepo
+	tkrepo    repo.TagKeysRepo
 	authzrepo repo.AuthzRepo
 	adminrepo repo.AdminRepo
 	log       *log.Helper
@@ -38,10 +41,13 @@ func NewApplicationsUsecase(
 	ahgrepo repo.AppHostgroupsRepo,
 	prdrepo repo.ProductsRepo,
 	teamrepo repo.TeamsRepo,
+	orgrepo repo.OrganizationsRepo,
 	ftrepo repo.FeaturesRepo,
 	tagrepo repo.TagsRepo,
 	hgrepo repo.HostgroupsRepo,
 	hfrepo repo.HostgroupFeaturesRepo,
+	attrrepo repo.AttributesRepo,
+	tkrepo repo.TagKeysRepo,
 	authzrepo repo.AuthzRepo,
 	adminrepo repo.AdminRepo,
 	logger log.Logger,
@@ -54,10 +60,13 @@ func NewApplicationsUsecase(
 		ahgrepo:   ahgrepo,
 		prdrepo:   prdrepo,
 		teamrepo:  teamrepo,
+		orgrepo:   orgrepo,
 		ftrepo:    ftrepo,
 		tagrepo:   tagrepo,
 		hgrepo:    hgrepo,
 		hfrepo:    hfrepo,
+		attrrepo:  attrrepo,
+		tkrepo:    tkrepo,
 		authzrepo: authzrepo,
 		adminrepo: adminrepo,
 		log:       log.NewHelper(logger),
@@ -65,12 +74,28 @@ func NewApplicationsUsecase(
 	}
 }
 
-func (s *ApplicationsUsecase) validate(isNew bool, apps []*Application) error {
+func (s *ApplicationsUsecase) validate(ctx context.Context, isNew bool, apps []*Application) error {
 	for _, a := range apps {
 		if err := a.Validate(isNew); err != nil {
 			return err
 		}
 	}
+	attrs, err := kindAttributes(ctx, s.attrrepo, nil, repo.AttrKindApplication)
+	if err != nil {
+		return err
+	}
+	keys, err := registeredTagKeys(ctx, s.tkrepo, nil)
+	if err != nil {
+		return err
+	}
+	for _, a := range apps {
+		if err := ValidateAttributeValues(attrs, a.Attributes); err != nil {
+			return invalidField("attributes", fmt.Errorf("application %s: %w", a.Name, err))
+		}
+		if err := validateEntityTags(ctx, s.tagrepo, nil, keys, repo.AttrKindApplication, a.TagsId); err != nil {
+			return invalidField("tags_id", fmt.Errorf("application %s: %w", a.Name, err))
+		}
+	}
 	return nil
 }
 
@@ -243,7 +268,11 @@ func (s *ApplicationsUsecase) enforce(ctx context.Context, tx repo.TX, apps []*A
 		if err != nil {
 			return err
 		}
-		ires := repo.NewResource4Sv1("applications", team.Name, app.Name, owner.UserName)
+		org, err := orgName(ctx, s.orgrepo, team.OrgId)
+		if err != nil {
+			return err
+		}
+		ires := repo.NewOrgResource4Sv1(org, "applications", team.Name, app.Name, owner.UserName)
 		can, err := s.authzrepo.Enforce(ctx, tx, &repo.AuthenRequest{
 			Sub:      curUserName,
 			Resource: ires,
@@ -259,10 +288,31 @@ func (s *ApplicationsUsecase) enforce(ctx context.Context, tx repo.TX, apps []*A
 	return nil
 }
 
+// setOrg sets the organization of applications to the one of their team, the product must be
+// in the same organization.
+func (s *ApplicationsUsecase) setOrg(ctx context.Context, apps []*repo.Application) error {
+	for _, app := range apps {
+		team, err := s.teamrepo.GetTeams(ctx, app.TeamId)
+		if err != nil {
+			return err
+		}
+		product, err := s.prdrepo.GetProducts(ctx, app.ProductId)
+		if err != nil {
+			return err
+		}
+		if product.OrgId != team.OrgId {
+			return InvalidArgument("product %s and team %s of application %s are in different organizations",
+				product.Name, team.Name, app.Name)
+		}
+		app.OrgId = team.OrgId
+	}
+	return nil
+}
+
 // CreateApplications is
 func (s *ApplicationsUsecase) CreateApplications(ctx context.Context, apps []*Application) error {
-	if err := s.validate(true, apps); err != nil {
-		return err
+	if err := s.validate(ctx, true, apps); err != nil {
+		return invalidArgument(err)
 	}
 
 	curUserName, err := GetCurrentUser(ctx)
@@ -290,10 +340,14 @@ func (s *ApplicationsUsecase) CreateApplications(ctx context.Context, apps []*Ap
 			dbapp.UpdatedAt = time.Now().UnixMilli()
 			dbapp.CreatedBy = curUserName
 			dbapp.UpdatedBy = curUserName
+			if err := s.setOrg(ctx, []*repo.Application{dbapp}); err != nil {
+				return err
+			}
 			// create app and get id
 			if err := s.apprepo.CreateApplications(ctx, tx, []*repo.Application{dbapp}); err != nil {
 				return err
 			}
+			app.Id = dbapp.Id
 
 			// create app-tags
 			if err := s.createProps(ctx, tx, dbapp.Id, app.TagsId, appPropTag); err != nil {
@@ -309,6 +363,10 @@ func (s *ApplicationsUsecase) CreateApplications(ctx context.Context, apps []*Ap
 			if err := s.createProps(ctx, tx, dbapp.Id, app.HostgroupsId, appPropHostgroup); err != nil {
 				return err
 			}
+
+			if err := setAttributeValues(ctx, s.attrrepo, tx, repo.AttrKindApplication, dbapp.Id, app.Attributes); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -390,24 +448,32 @@ func (s *ApplicationsUsecase) HandleM2MProps(
 	if err != nil {
 		return err
 	}
+	// ids are related ids, relations are deleted by their row ids
 	var oldIds []uint32
+	rowIds := map[uint32]uint32{}
 
 	switch items := oldItems.(type) {
 	case []*repo.AppTag:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.TagID)
+			rowIds[item.TagID] = item.Id
 		}
 	case []*repo.AppFeature:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.FeatureID)
+			rowIds[item.FeatureID] = item.Id
 		}
 	case []*repo.AppHostgroup:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.HostgroupID)
+			rowIds[item.HostgroupID] = item.Id
 		}
 	}
 
-	toDelIds := DiffSliceUint32(oldIds, ids)
+	var toDelIds []uint32
+	for _, id := range DiffSliceUint32(oldIds, ids) {
+		toDelIds = append(toDelIds, rowIds[id])
+	}
 	toNewids := DiffSliceUint32(ids, oldIds)
 
 	if len(toDelIds) > 0 {
@@ -425,8 +491,8 @@ func (s *ApplicationsUsecase) HandleM2MProps(
 
 // UpdateApplications is
 func (s *ApplicationsUsecase) UpdateApplications(ctx context.Context, apps []*Application) error {
-	if err := s.validate(false, apps); err != nil {
-		return err
+	if err := s.validate(ctx, false, apps); err != nil {
+		return invalidArgument(err)
 	}
 	curUserName, err := GetCurrentUser(ctx)
 	if err != nil {
@@ -449,8 +515,11 @@ func (s *ApplicationsUsecase) UpdateApplications(ctx context.Context, apps []*Ap
 			return err
 		}
 
+		if err := s.setOrg(ctx, _apps); err != nil {
+			return err
+		}
 		// update
-		if err := s.apprepo.UpdateApplications(ctx, tx, _apps); err != nil {
+		if err := conflicting(s.apprepo.UpdateApplications(ctx, tx, _apps)); err != nil {
 			return err
 		}
 
@@ -469,6 +538,10 @@ func (s *ApplicationsUsecase) UpdateApplications(ctx context.Context, apps []*Ap
 			if err := s.HandleM2MProps(ctx, tx, a.Id, a.HostgroupsId, appPropHostgroup); err != nil {
 				return err
 			}
+
+			if err := setAttributeValues(ctx, s.attrrepo, tx, repo.AttrKindApplication, a.Id, a.Attributes); err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -476,10 +549,80 @@ func (s *ApplicationsUsecase) UpdateApplications(ctx context.Context, apps []*Ap
 
 }
 
+// UpdateApplicationsRelations adds relations to and removes them from applications of ids,
+// others keep theirs. Relations are read and edited in one transaction writing only the
+// relation rows, concurrent edits of other relations and fields are kept.
+func (s *ApplicationsUsecase) UpdateApplicationsRelations(ctx context.Context, ids []uint32,
+	add, remove *AppRelations) error {
+
+	if len(ids) == 0 {
+		return ErrEmptyIds
+	}
+	if len(ids) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if add.empty() && remove.empty() {
+		return ErrEmptyRelations
+	}
+	if add == nil {
+		add = &AppRelations{}
+	}
+	if remove == nil {
+		remove = &AppRelations{}
+	}
+	ids = DedupSliceUint32(ids)
+	keys, err := registeredTagKeys(ctx, s.tkrepo, nil)
+	if err != nil {
+		return err
+	}
+	return s.txm.RunInTX(func(tx repo.TX) error {
+		_apps, err := s.apprepo.ListApplications(ctx, tx, &repo.ApplicationsFilter{Ids: ids})
+		if err != nil {
+			return err
+		}
+		if len(_apps) != len(ids) {
+			return NotFound("some applications are not found")
+		}
+		apps, err := ToBizApplications(_apps)
+		if err != nil {
+			return err
+		}
+		if err := s.enforce(ctx, tx, apps); err != nil {
+			return err
+		}
+		for _, app := range apps {
+			if err := s.attachM2MProps(ctx, tx, app); err != nil {
+				return err
+			}
+			app.TagsId = EditSliceUint32(app.TagsId, add.TagsId, remove.TagsId)
+			app.FeaturesId = EditSliceUint32(app.FeaturesId, add.FeaturesId, remove.FeaturesId)
+			app.HostgroupsId = EditSliceUint32(app.HostgroupsId, add.HostgroupsId, remove.HostgroupsId)
+			if err := validateEntityTags(ctx, s.tagrepo, tx, keys, repo.AttrKindApplication, app.TagsId); err != nil {
+				return invalidField("tags_id", fmt.Errorf("application %s: %w", app.Name, err))
+			}
+		}
+		if err := s.validateProps(ctx, tx, apps); err != nil {
+			return err
+		}
+		for _, app := range apps {
+			if err := s.HandleM2MProps(ctx, tx, app.Id, app.TagsId, appPropTag); err != nil {
+				return err
+			}
+			if err := s.HandleM2MProps(ctx, tx, app.Id, app.FeaturesId, appPropFeature); err != nil {
+				return err
+			}
+			if err := s.HandleM2MProps(ctx, tx, app.Id, app.HostgroupsId, appPropHostgroup); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // DeleteApplications is
 func (s *ApplicationsUsecase) DeleteApplications(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 
 	return s.txm.RunInTX(func(tx repo.TX) error {
@@ -507,6 +650,12 @@ func (s *ApplicationsUsecase) DeleteApplications(ctx context.Context, ids []uint
 		if err := s.ahgrepo.DeleteAppHostgroupsByAppId(ctx, tx, ids); err != nil {
 			return err
 		}
+		if err := s.attrrepo.DeleteAttributeValues(ctx, tx, &repo.AttributeValuesFilter{
+			Kind:      repo.AttrKindApplication,
+			EntityIds: ids,
+		}); err != nil {
+			return err
+		}
 		// delete app
 		return s.apprepo.DeleteApplications(ctx, tx, ids)
 	})
@@ -515,27 +664,34 @@ func (s *ApplicationsUsecase) DeleteApplications(ctx context.Context, ids []uint
 // GetApplications is
 func (s *ApplicationsUsecase) GetApplications(ctx context.Context, id uint32) (*Application, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("InvalidId")
+		return nil, ErrInvalidId
 	}
 	_app, err := s.apprepo.GetApplications(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	readable, err := s.filterReadable(ctx, []*repo.Application{_app})
+	if err != nil {
+		return nil, err
+	}
+	if len(readable) == 0 {
+		return nil, ErrPermissionDenied
+	}
 	bapp, e := ToBizApplication(_app)
 	if e != nil {
 		return nil, e
 	}
 
-	if err := s.attachM2MProps(ctx, bapp); err != nil {
+	if err := s.attachM2MProps(ctx, nil, bapp); err != nil {
 		return nil, err
 	}
 
 	return bapp, nil
 }
 
-func (s *ApplicationsUsecase) attachM2MProps(ctx context.Context, app *Application) error {
+func (s *ApplicationsUsecase) attachM2MProps(ctx context.Context, tx repo.TX, app *Application) error {
 	// tags id
-	_tags, err := s.listProps(ctx, nil, []uint32{app.Id}, appPropTag)
+	_tags, err := s.listProps(ctx, tx, []uint32{app.Id}, appPropTag)
 	if err != nil {
 		return err
 	}
@@ -543,7 +699,7 @@ func (s *ApplicationsUsecase) attachM2MProps(ctx context.Context, app *Applicati
 		app.TagsId = append(app.TagsId, tag.TagID)
 	}
 	// features id
-	_fts, err := s.listProps(ctx, nil, []uint32{app.Id}, appPropFeature)
+	_fts, err := s.listProps(ctx, tx, []uint32{app.Id}, appPropFeature)
 	if err != nil {
 		return err
 	}
@@ -552,28 +708,44 @@ func (s *ApplicationsUsecase) attachM2MProps(ctx context.Context, app *Applicati
 	}
 
 	// hostgroups id
-	_hgs, err := s.listProps(ctx, nil, []uint32{app.Id}, appPropHostgroup)
+	_hgs, err := s.listProps(ctx, tx, []uint32{app.Id}, appPropHostgroup)
 	if err != nil {
 		return err
 	}
 	for _, hg := range _hgs.([]*repo.AppHostgroup) {
 		app.HostgroupsId = append(app.HostgroupsId, hg.HostgroupID)
 	}
+
+	values, err := listAttributeValues(ctx, s.attrrepo, tx, repo.AttrKindApplication, []uint32{app.Id})
+	if err != nil {
+		return err
+	}
+	app.Attributes = values[app.Id]
 	return nil
 }
 
 // ListApplications is
 func (s *ApplicationsUsecase) ListApplications(
 	ctx context.Context,
-	filter *ListApplicationsFilter) ([]*Application, error) {
+	filter *ListApplicationsFilter) ([]*Application, *PageInfo, error) {
 
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
 
-	dbFilter := ToDBApplicationsFilter(filter)
+	dbFilter := &repo.ApplicationsFilter{}
+	if filter != nil {
+		dbFilter = ToDBApplicationsFilter(filter)
+	}
+	if len(dbFilter.OrgIds) == 0 {
+		orgIds, err := selectedOrgIds(ctx, s.orgrepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		dbFilter.OrgIds = orgIds
+	}
 
 	processInitIds := func(filterIds []uint32, prop string) error {
 		if len(filterIds) == 0 {
@@ -585,7 +757,7 @@ func (s *ApplicationsUsecase) ListApplications(
 		switch prop {
 		case appPropTag:
 			items, err = s.atagrepo.ListAppTags(ctx, nil, &repo.AppTagsFilter{
-				Ids: filterIds})
+				TagIds: filterIds})
 			if err != nil {
 				return fmt.Errorf("ListApplications listAppTags error. %w", err)
 			}
@@ -594,7 +766,7 @@ func (s *ApplicationsUsecase) ListApplications(
 			}
 		case appPropFeature:
 			items, err = s.afrepo.ListAppFeatures(ctx, nil, &repo.AppFeaturesFilter{
-				Ids: filterIds})
+				FeatureIds: filterIds})
 			if err != nil {
 				return fmt.Errorf("ListApplications listAppFeatures error. %w", err)
 			}
@@ -603,7 +775,7 @@ func (s *ApplicationsUsecase) ListApplications(
 			}
 		case appPropHostgroup:
 			items, err = s.ahgrepo.ListAppHostgroups(ctx, nil, &repo.AppHostgroupsFilter{
-				Ids: filterIds})
+				HostgroupIds: filterIds})
 			if err != nil {
 				return fmt.Errorf("ListApplications listAppHostgroups error. %w", err)
 			}
@@ -630,35 +802,104 @@ func (s *ApplicationsUsecase) ListApplications(
 	}
 
 	if filter != nil {
+		resolver := &selectorResolver{teamrepo: s.teamrepo, prdrepo: s.prdrepo, hgrepo: s.hgrepo,
+			tagrepo: s.tagrepo, ftrepo: s.ftrepo}
+		selections, err := resolver.resolve(ctx, filter.Selector, dbFilter.OrgIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		dbFilter.Selections = selections
 
 		// tags id
 		if err := processInitIds(filter.TagsId, appPropTag); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// features id
 		if err := processInitIds(filter.FeaturesId, appPropFeature); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// hostgroups id
 		if err := processInitIds(filter.HostgroupsId, appPropHostgroup); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		if len(filter.Attributes) > 0 {
+			app_ids, err := filterAttributeIds(ctx, s.attrrepo, nil, repo.AttrKindApplication, filter.Attributes)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(app_ids) == 0 {
+				return nil, nil, fmt.Errorf("ListApplications no app with attributes")
+			}
+			if len(dbFilter.Ids) == 0 {
+				dbFilter.Ids = app_ids
+			} else {
+				dbFilter.Ids = IntersectSliceUint32(dbFilter.Ids, app_ids)
+				if len(dbFilter.Ids) == 0 {
+					return nil, nil, fmt.Errorf("ListApplications no app intersect with attributes")
+				}
+			}
 		}
 	}
 
 	_apps, err := s.apprepo.ListApplications(ctx, nil, dbFilter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// totals count applications readable by the caller only
+	page, err := listPage(_apps, dbFilter.PageSize, func(r *repo.Application) uint32 { return r.Id },
+		filter != nil && filter.WithTotal, func() ([]*repo.Application, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			rows, err := s.apprepo.ListApplications(ctx, nil, &all)
+			if err != nil {
+				return nil, err
+			}
+			return s.filterReadable(ctx, rows)
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	if page.NextPageToken != "" {
+		// sorted lists continue after the sort value of the last row
+		last := _apps[len(_apps)-1]
+		page.NextPageToken = sortedPageToken(dbFilter.Sort, last.Id, last.Name, last.ChangeInfo)
+	}
+	_apps, err = s.filterReadable(ctx, _apps)
+	if err != nil {
+		return nil, nil, err
 	}
 	bapps, err := ToBizApplications(_apps)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for _, app := range bapps {
-		if err := s.attachM2MProps(ctx, app); err != nil {
-			return nil, err
+		if err := s.attachM2MProps(ctx, nil, app); err != nil {
+			return nil, nil, err
 		}
 	}
-	return bapps, nil
+	return bapps, page, nil
+}
+
+// filterReadable keeps applications readable by the current user, an application is visible to
+// its team and owner.
+func (s *ApplicationsUsecase) filterReadable(ctx context.Context, apps []*repo.Application) ([]*repo.Application, error) {
+	names := newTeamResourceNames(s.teamrepo, s.adminrepo, s.orgrepo)
+	return filterReadable(ctx, s.authzrepo, nil, apps, func(app *repo.Application) ([]repo.IResource, error) {
+		team, err := names.team(ctx, app.TeamId)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := names.user(ctx, app.OwnerId)
+		if err != nil {
+			return nil, err
+		}
+		res, err := names.resource(ctx, "applications", team, app.Name, owner)
+		if err != nil {
+			return nil, err
+		}
+		return []repo.IResource{res}, nil
+	})
 }
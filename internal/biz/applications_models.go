@@ -2,7 +2,9 @@ package biz
 
 type Application struct {
 	ChangeInfo
-	Id           uint32
+	Id uint32
+	// OrgId is the organization of the team, set by usecases
+	OrgId        uint32
 	Name         string
 	Description  string
 	OwnerId      uint32
@@ -12,12 +14,26 @@ type Application struct {
 	FeaturesId   []uint32
 	TagsId       []uint32
 	HostgroupsId []uint32
+	// Attributes are values of custom attributes by name
+	Attributes map[string]string
+}
+
+// AppRelations are ids of related entities added to or removed from applications.
+type AppRelations struct {
+	TagsId       []uint32
+	FeaturesId   []uint32
+	HostgroupsId []uint32
+}
+
+func (r *AppRelations) empty() bool {
+	return r == nil || len(r.TagsId)+len(r.FeaturesId)+len(r.HostgroupsId) == 0
 }
 
 type ListApplicationsFilter struct {
 	Page         uint32
 	PageSize     uint32
 	Ids          []uint32
+	OrgIds       []uint32
 	Names        []string
 	IsStateful   string
 	ProductsId   []uint32
@@ -25,6 +41,30 @@ type ListApplicationsFilter struct {
 	FeaturesId   []uint32
 	TagsId       []uint32
 	HostgroupsId []uint32
+	// Attributes are name:value of custom attributes applications must have
+	Attributes []string
+	// NameMatch matches Names as prefixes or exact names, names contain them by default
+	NameMatch string
+	// SortBy is id, name, created_at or updated_at, ties are sorted by id
+	SortBy   string
+	SortDesc bool
+	// change times are unix seconds, after bounds are inclusive and before bounds exclusive
+	CreatedAfter      int64
+	CreatedBefore     int64
+	UpdatedAfter      int64
+	UpdatedBefore     int64
+	ExcludeTeamsId    []uint32
+	ExcludeProductsId []uint32
+	// NoTags and NoFeatures select applications without tags or features
+	NoTags     bool
+	NoFeatures bool
+	// Selector is a label selector of names, like team=infra,tag:sla=9999, see ParseSelector
+	Selector string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
 
 type MatchAppHostgroupsFilter struct {
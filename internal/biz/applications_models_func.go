@@ -7,45 +7,56 @@ import (
 
 func (m *Application) Validate(isNew bool) error {
 	if len(m.Name) == 0 {
-		return fmt.Errorf("InvalidNameValue")
+		return ErrInvalidNameValue
 	}
 	if e := ValidateName(m.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	if !isNew {
 		if m.Id == 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if len(m.Name) == 0 {
-		return fmt.Errorf("InvalidNameValue")
+		return ErrInvalidNameValue
 	}
 	if m.OwnerId == 0 {
 		return fmt.Errorf("InvalidOwnerIdValue")
 	}
 	if m.ProductId <= 0 {
-		return fmt.Errorf("InvalidProductId")
+		return ErrInvalidProductId
 	}
 	if m.TeamId <= 0 {
-		return fmt.Errorf("InvalidTeamId")
+		return ErrInvalidTeamId
 	}
 	return nil
 }
 
+// appSelectorFields are the keys label selectors of applications may use.
+var appSelectorFields = []string{
+	repo.SelectTeam, repo.SelectProduct, repo.SelectHostgroup, repo.SelectTag, repo.SelectFeature,
+}
+
 func (m *ListApplicationsFilter) Validate() error {
 	if m == nil {
 		return nil
 	}
 	if len(m.Ids) > MaxFilterValues ||
-		len(m.Names) > MaxFilterValues ||
+		len(m.OrgIds) > MaxFilterValues ||
 		len(m.ProductsId) > MaxFilterValues ||
 		len(m.TeamsId) > MaxFilterValues ||
 		len(m.FeaturesId) > MaxFilterValues ||
 		len(m.HostgroupsId) > MaxFilterValues ||
-		len(m.TagsId) > MaxFilterValues {
+		len(m.TagsId) > MaxFilterValues ||
+		len(m.Attributes) > MaxFilterValues ||
+		len(m.ExcludeTeamsId) > MaxFilterValues ||
+		len(m.ExcludeProductsId) > MaxFilterValues {
 
 		return ErrFilterValuesExceedMax
 	}
+	if m.NameMatch != repo.NameMatchExact && len(m.Names) > MaxFilterPatterns {
+		return ErrFilterValuesExceedMax
+	}
 
 	if m.PageSize == 0 || m.PageSize > MaxPageSize {
 		return ErrFilterInvalidPagesize
@@ -58,7 +69,10 @@ func (m *ListApplicationsFilter) Validate() error {
 		return fmt.Errorf("InvalidIsStateful")
 	}
 
-	return nil
+	if err := validateSelector(m.Selector, appSelectorFields); err != nil {
+		return invalidField("selector", err)
+	}
+	return validateListSort(m.SortBy, m.SortDesc, m.NameMatch, m.PageToken)
 }
 
 func DefaultApplicationFilter() *ListApplicationsFilter {
@@ -76,6 +90,7 @@ func ToDBApplication(app *Application) (*repo.Application, error) {
 	return &repo.Application{
 		ChangeInfo:  repo.ChangeInfo{},
 		Id:          app.Id,
+		OrgId:       app.OrgId,
 		Name:        app.Name,
 		Description: app.Description,
 		OwnerId:     app.OwnerId,
@@ -103,6 +118,7 @@ func ToBizApplication(t *repo.Application) (*Application, error) {
 	}
 	return &Application{
 		Id:          t.Id,
+		OrgId:       t.OrgId,
 		Name:        t.Name,
 		Description: t.Description,
 		OwnerId:     t.OwnerId,
@@ -136,11 +152,26 @@ func ToDBApplicationsFilter(filter *ListApplicationsFilter) *repo.ApplicationsFi
 	}
 	return &repo.ApplicationsFilter{
 		Ids:        filter.Ids,
+		OrgIds:     filter.OrgIds,
 		Names:      filter.Names,
 		ProductsId: filter.ProductsId,
 		TeamsId:    filter.TeamsId,
 		IsStateful: filter.IsStateful,
 		Page:       filter.Page,
 		PageSize:   filter.PageSize,
+		LastId:     pageTokenId(filter.PageToken),
+
+		ChangeFilter: repo.ChangeFilter{
+			CreatedAfter:  filter.CreatedAfter,
+			CreatedBefore: filter.CreatedBefore,
+			UpdatedAfter:  filter.UpdatedAfter,
+			UpdatedBefore: filter.UpdatedBefore,
+		},
+		NameMatch:         filter.NameMatch,
+		Sort:              toDBListSort(filter.SortBy, filter.SortDesc, filter.PageToken),
+		ExcludeTeamsId:    filter.ExcludeTeamsId,
+		ExcludeProductsId: filter.ExcludeProductsId,
+		NoTags:            filter.NoTags,
+		NoFeatures:        filter.NoFeatures,
 	}
 }
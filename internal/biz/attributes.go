@@ -0,0 +1,275 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type AttributesUsecase struct {
+	attrRepo  repo.AttributesRepo
+	authzRepo repo.AuthzRepo
+	log       *log.Helper
+	txm       repo.TxManager
+}
+
+func NewAttributesUsecase(
+	attrrepo repo.AttributesRepo,
+	authzrepo repo.AuthzRepo,
+	logger log.Logger,
+	txm repo.TxManager) *AttributesUsecase {
+	return &AttributesUsecase{
+		attrRepo:  attrrepo,
+		authzRepo: authzrepo,
+		log:       log.NewHelper(logger),
+		txm:       txm,
+	}
+}
+
+// kindAttributes lists the attributes defined for entities of kind.
+func kindAttributes(ctx context.Context, attrRepo repo.AttributesRepo, tx repo.TX, kind string) ([]*Attribute, error) {
+	attrs, err := attrRepo.ListAttributes(ctx, tx, &repo.AttributesFilter{Kinds: []string{kind}})
+	if err != nil {
+		return nil, err
+	}
+	return ToBizAttributes(attrs), nil
+}
+
+// setAttributeValues replaces the attribute values of entity, values are validated before.
+func setAttributeValues(ctx context.Context, attrRepo repo.AttributesRepo, tx repo.TX,
+	kind string, id uint32, values map[string]string) error {
+	vals := make([]*repo.AttributeValue, 0, len(values))
+	for name, value := range values {
+		vals = append(vals, &repo.AttributeValue{Name: name, Value: value})
+	}
+	slices.SortFunc(vals, func(a, b *repo.AttributeValue) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+	return attrRepo.SetAttributeValues(ctx, tx, kind, id, vals)
+}
+
+// listAttributeValues returns attribute values of entities by id.
+func listAttributeValues(ctx context.Context, attrRepo repo.AttributesRepo, tx repo.TX,
+	kind string, ids []uint32) (map[uint32]map[string]string, error) {
+	res := make(map[uint32]map[string]string)
+	if len(ids) == 0 {
+		return res, nil
+	}
+	vals, err := attrRepo.ListAttributeValues(ctx, tx, &repo.AttributeValuesFilter{Kind: kind, EntityIds: ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vals {
+		if res[v.EntityId] == nil {
+			res[v.EntityId] = make(map[string]string)
+		}
+		res[v.EntityId][v.Name] = v.Value
+	}
+	return res, nil
+}
+
+// filterAttributeIds returns ids of entities of kind matching all name:value filters.
+func filterAttributeIds(ctx context.Context, attrRepo repo.AttributesRepo, tx repo.TX,
+	kind string, kvs []string) ([]uint32, error) {
+	filters, err := parseAttributeKVs(kvs)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint32
+	first := true
+	for name, value := range filters {
+		vals, err := attrRepo.ListAttributeValues(ctx, tx, &repo.AttributeValuesFilter{
+			Kind:   kind,
+			Names:  []string{name},
+			Values: []string{value},
+		})
+		if err != nil {
+			return nil, err
+		}
+		matched := make([]uint32, 0, len(vals))
+		for _, v := range vals {
+			if first || slices.Contains(ids, v.EntityId) {
+				matched = append(matched, v.EntityId)
+			}
+		}
+		ids = matched
+		first = false
+	}
+	return ids, nil
+}
+
+func (s *AttributesUsecase) enforce(ctx context.Context, tx repo.TX) error {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	can, err := s.authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: repo.NewResource4Sv1("attributes", "", "", ""),
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return err
+	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *AttributesUsecase) validate(isNew bool, attrs []*Attribute) error {
+	if len(attrs) == 0 {
+		return fmt.Errorf("EmptyAttributes")
+	}
+	for _, a := range attrs {
+		if a == nil {
+			return fmt.Errorf("attribute is nil")
+		}
+		if err := a.Validate(isNew); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateAttributes defines custom attributes, admin only. new required attributes are checked
+// on the next change of existing entities.
+func (s *AttributesUsecase) CreateAttributes(ctx context.Context, attrs []*Attribute) error {
+	if err := s.validate(true, attrs); err != nil {
+		return errors.Join(errors.New("CreateAttributes failed"), invalidArgument(err))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx); err != nil {
+			return err
+		}
+		for _, a := range attrs {
+			dbattr := ToDBAttribute(a)
+			if err := s.attrRepo.CreateAttributes(ctx, tx, []*repo.Attribute{dbattr}); err != nil {
+				return err
+			}
+			a.Id = dbattr.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("CreateAttributes failed"), err)
+	}
+	return nil
+}
+
+// UpdateAttributes changes definitions of attributes, admin only. values refer to attributes
+// by kind and name, so they can not be changed.
+func (s *AttributesUsecase) UpdateAttributes(ctx context.Context, attrs []*Attribute) error {
+	if err := s.validate(false, attrs); err != nil {
+		return errors.Join(errors.New("UpdateAttributes failed"), invalidArgument(err))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx); err != nil {
+			return err
+		}
+		for _, a := range attrs {
+			old, err := s.attrRepo.GetAttributes(ctx, a.Id)
+			if err != nil {
+				return err
+			}
+			if old.Kind != a.Kind || old.Name != a.Name {
+				return InvalidArgument("kind and name of attribute %s can not be changed", old.Name).WithField("name", "can not be changed")
+			}
+			if err := conflicting(s.attrRepo.UpdateAttributes(ctx, tx, []*repo.Attribute{ToDBAttribute(a)})); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("UpdateAttributes failed"), err)
+	}
+	return nil
+}
+
+// DeleteAttributes deletes attributes with their values, admin only.
+func (s *AttributesUsecase) DeleteAttributes(ctx context.Context, ids []uint32) error {
+	if len(ids) == 0 {
+		return errors.Join(errors.New("DeleteAttributes failed"), ErrEmptyIds)
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx); err != nil {
+			return err
+		}
+		attrs, err := s.attrRepo.ListAttributes(ctx, tx, &repo.AttributesFilter{Ids: ids})
+		if err != nil {
+			return err
+		}
+		if err := s.attrRepo.DeleteAttributes(ctx, tx, ids); err != nil {
+			return err
+		}
+		for _, a := range attrs {
+			if err := s.attrRepo.DeleteAttributeValues(ctx, tx, &repo.AttributeValuesFilter{
+				Kind:  a.Kind,
+				Names: []string{a.Name},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("DeleteAttributes failed"), err)
+	}
+	return nil
+}
+
+// GetAttributes is
+func (s *AttributesUsecase) GetAttributes(ctx context.Context, id uint32) (*Attribute, error) {
+	if id <= 0 {
+		return nil, errors.Join(errors.New("GetAttributes failed"), ErrInvalidId)
+	}
+	if err := enforceRead(ctx, s.authzRepo, nil, repo.NewResource4Sv1("attributes", "", "", "")); err != nil {
+		return nil, errors.Join(errors.New("GetAttributes failed"), err)
+	}
+	attr, err := s.attrRepo.GetAttributes(ctx, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("GetAttributes failed"), err)
+	}
+	return ToBizAttribute(attr), nil
+}
+
+// ListAttributes is
+func (s *AttributesUsecase) ListAttributes(ctx context.Context, filter *ListAttributesFilter) ([]*Attribute, *PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, errors.Join(errors.New("ListAttributes failed"), invalidArgument(err))
+	}
+	if err := enforceRead(ctx, s.authzRepo, nil, repo.NewResource4Sv1("attributes", "", "", "")); err != nil {
+		return nil, nil, errors.Join(errors.New("ListAttributes failed"), err)
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListAttributesFilter{}
+	}
+	dbFilter := ToDBAttributesFilter(filter)
+	attrs, err := s.attrRepo.ListAttributes(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListAttributes failed"), err)
+	}
+	page, err := listPage(attrs, dbFilter.PageSize, func(r *repo.Attribute) uint32 { return r.ID },
+		filter.WithTotal, func() ([]*repo.Attribute, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.attrRepo.ListAttributes(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListAttributes failed"), err)
+	}
+	return ToBizAttributes(attrs), page, nil
+}
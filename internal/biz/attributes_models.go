@@ -0,0 +1,29 @@
+package biz
+
+// Attribute is an admin defined custom attribute of applications or hostgroups, values
+// of entities are checked against it.
+type Attribute struct {
+	Id       uint32
+	Kind     string
+	Name     string
+	Type     string
+	Required bool
+	// EnumValues are the allowed values of enum attributes
+	EnumValues []string
+	// Pattern is a regular expression the whole value must match
+	Pattern     string
+	Description string
+}
+
+type ListAttributesFilter struct {
+	Page     uint32
+	PageSize uint32
+	Ids      []uint32
+	Kinds    []string
+	Names    []string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
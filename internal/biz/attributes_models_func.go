@@ -0,0 +1,205 @@
+package biz
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"opspillar/internal/data/repo"
+)
+
+var AttributeKinds = []string{repo.AttrKindApplication, repo.AttrKindHostgroup}
+var AttributeTypes = []string{repo.AttrTypeString, repo.AttrTypeInt, repo.AttrTypeBool, repo.AttrTypeEnum}
+
+// MaxAttributeValueLength is the length of the value column.
+const MaxAttributeValueLength = 255
+
+const attrEnumSplit = ","
+
+func (a *Attribute) Validate(isNew bool) error {
+	if !isNew && a.Id == 0 {
+		return ErrInvalidId
+	}
+	if !slices.Contains(AttributeKinds, a.Kind) {
+		return fmt.Errorf("invalid kind %q, one of %s", a.Kind, strings.Join(AttributeKinds, ","))
+	}
+	if e := ValidateName(a.Name); e != nil {
+		return invalidField("name", e)
+	}
+	if !slices.Contains(AttributeTypes, a.Type) {
+		return fmt.Errorf("invalid type %q, one of %s", a.Type, strings.Join(AttributeTypes, ","))
+	}
+	if a.Type == repo.AttrTypeEnum {
+		if len(a.EnumValues) == 0 {
+			return fmt.Errorf("enum values of attribute %s are required", a.Name)
+		}
+		for _, v := range a.EnumValues {
+			if v == "" || strings.Contains(v, attrEnumSplit) || len(v) > MaxAttributeValueLength {
+				return fmt.Errorf("invalid enum value %q of attribute %s", v, a.Name)
+			}
+		}
+	} else if len(a.EnumValues) > 0 {
+		return fmt.Errorf("enum values are only for enum attributes")
+	}
+	if a.Pattern != "" {
+		if a.Type == repo.AttrTypeBool {
+			return fmt.Errorf("pattern is not for bool attributes")
+		}
+		if _, err := regexp.Compile(a.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern of attribute %s. %w", a.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks a value of the attribute.
+func (a *Attribute) ValidateValue(value string) error {
+	if value == "" {
+		return fmt.Errorf("empty value of attribute %s", a.Name)
+	}
+	if len(value) > MaxAttributeValueLength {
+		return fmt.Errorf("value of attribute %s too long", a.Name)
+	}
+	switch a.Type {
+	case repo.AttrTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("value %q of attribute %s is not an int", value, a.Name)
+		}
+	case repo.AttrTypeBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("value %q of attribute %s is not true or false", value, a.Name)
+		}
+	case repo.AttrTypeEnum:
+		if !slices.Contains(a.EnumValues, value) {
+			return fmt.Errorf("value %q of attribute %s not in %s", value, a.Name, strings.Join(a.EnumValues, ","))
+		}
+	}
+	if a.Pattern != "" {
+		re, err := regexp.Compile(`^(?:` + a.Pattern + `)$`)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("value %q of attribute %s not match %s", value, a.Name, a.Pattern)
+		}
+	}
+	return nil
+}
+
+// ValidateAttributeValues checks values of an entity against attributes of its kind, required
+// attributes must be set and unknown ones are rejected.
+func ValidateAttributeValues(attrs []*Attribute, values map[string]string) error {
+	byName := make(map[string]*Attribute, len(attrs))
+	for _, a := range attrs {
+		byName[a.Name] = a
+		if _, ok := values[a.Name]; !ok && a.Required {
+			return fmt.Errorf("attribute %s is required", a.Name)
+		}
+	}
+	for name, value := range values {
+		a, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown attribute %s", name)
+		}
+		if err := a.ValidateValue(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lf *ListAttributesFilter) Validate() error {
+	if lf == nil {
+		return nil
+	}
+	if len(lf.Ids) > MaxFilterValues || len(lf.Kinds) > MaxFilterValues || len(lf.Names) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
+		return ErrFilterInvalidPagesize
+	}
+	if lf.Page == 0 {
+		return ErrFilterInvalidPage
+	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefaultAttributesFilter() *ListAttributesFilter {
+	return &ListAttributesFilter{
+		Page:     1,
+		PageSize: DefaultPageSize,
+	}
+}
+
+// parseAttributeKVs splits filters of attribute values name:value, values may hold the separator.
+func parseAttributeKVs(kvs []string) (map[string]string, error) {
+	if len(kvs) > MaxFilterValues {
+		return nil, ErrFilterValuesExceedMax
+	}
+	res := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		name, value, ok := strings.Cut(kv, FilterKVSplit)
+		if !ok || name == "" || value == "" {
+			return nil, ErrFilterKVInvalid
+		}
+		res[name] = value
+	}
+	return res, nil
+}
+
+func ToDBAttribute(a *Attribute) *repo.Attribute {
+	return &repo.Attribute{
+		ID:          a.Id,
+		Kind:        a.Kind,
+		Name:        a.Name,
+		Type:        a.Type,
+		Required:    a.Required,
+		EnumValues:  strings.Join(a.EnumValues, attrEnumSplit),
+		Pattern:     a.Pattern,
+		Description: a.Description,
+	}
+}
+
+func ToBizAttribute(a *repo.Attribute) *Attribute {
+	var enums []string
+	if a.EnumValues != "" {
+		enums = strings.Split(a.EnumValues, attrEnumSplit)
+	}
+	return &Attribute{
+		Id:          a.ID,
+		Kind:        a.Kind,
+		Name:        a.Name,
+		Type:        a.Type,
+		Required:    a.Required,
+		EnumValues:  enums,
+		Pattern:     a.Pattern,
+		Description: a.Description,
+	}
+}
+
+func ToBizAttributes(attrs []*repo.Attribute) []*Attribute {
+	res := make([]*Attribute, len(attrs))
+	for i, a := range attrs {
+		res[i] = ToBizAttribute(a)
+	}
+	return res
+}
+
+func ToDBAttributesFilter(filter *ListAttributesFilter) *repo.AttributesFilter {
+	if filter == nil {
+		return nil
+	}
+	return &repo.AttributesFilter{
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
+		Ids:      filter.Ids,
+		Kinds:    filter.Kinds,
+		Names:    filter.Names,
+	}
+}
@@ -0,0 +1,491 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrAdminLockout is returned for changes removing the bootstrap admin access.
+var ErrAdminLockout = FailedPrecondition("refused to remove admin access")
+
+// AuthzUsecase manages casbin rules and groups, every change is audited.
+type AuthzUsecase struct {
+	authzRepo        repo.AuthzRepo
+	auditsRepo       repo.AuthzAuditsRepo
+	adminRepo        repo.AdminRepo
+	serviceAccounts  repo.ServiceAccountsRepo
+	teamsRepo        repo.TeamsRepo
+	orgRepo          repo.OrganizationsRepo
+	hostgroupsRepo   repo.HostgroupsRepo
+	applicationsRepo repo.ApplicationsRepo
+	txm              repo.TxManager
+	log              *log.Helper
+}
+
+func NewAuthzUsecase(
+	conf *conf.Authz,
+	authzRepo repo.AuthzRepo,
+	auditsRepo repo.AuthzAuditsRepo,
+	adminRepo repo.AdminRepo,
+	serviceAccounts repo.ServiceAccountsRepo,
+	teamsRepo repo.TeamsRepo,
+	orgRepo repo.OrganizationsRepo,
+	hostgroupsRepo repo.HostgroupsRepo,
+	applicationsRepo repo.ApplicationsRepo,
+	txm repo.TxManager,
+	logger log.Logger,
+) (*AuthzUsecase, error) {
+	uc := &AuthzUsecase{
+		authzRepo:        authzRepo,
+		auditsRepo:       auditsRepo,
+		adminRepo:        adminRepo,
+		serviceAccounts:  serviceAccounts,
+		teamsRepo:        teamsRepo,
+		orgRepo:          orgRepo,
+		hostgroupsRepo:   hostgroupsRepo,
+		applicationsRepo: applicationsRepo,
+		txm:              txm,
+		log:              log.NewHelper(logger),
+	}
+	kinds := conf.GetPublicKinds()
+	if len(kinds) == 0 {
+		kinds = DefaultPublicKinds
+	}
+	if err := uc.syncPublicKinds(context.Background(), kinds); err != nil {
+		return nil, err
+	}
+	if err := uc.syncTeamReadRules(context.Background()); err != nil {
+		return nil, err
+	}
+	return uc, nil
+}
+
+// syncTeamReadRules lets members read resources of teams whose roles were created without
+// the read rule.
+func (s *AuthzUsecase) syncTeamReadRules(ctx context.Context) error {
+	return s.txm.RunInTX(func(tx repo.TX) error {
+		teams, err := s.teamsRepo.ListTeams(ctx, tx, &repo.TeamsFilter{})
+		if err != nil {
+			return err
+		}
+		rules, err := s.authzRepo.ListRule(ctx, tx, &repo.RuleFilter{})
+		if err != nil {
+			return err
+		}
+		have := make(map[string]bool, len(rules))
+		for _, r := range rules {
+			if r.Action == repo.ActRead {
+				have[r.Sub+" "+r.Resource.ResourceStr()] = true
+			}
+		}
+		for _, t := range teams {
+			org, err := orgName(ctx, s.orgRepo, t.OrgId)
+			if err != nil {
+				return err
+			}
+			rule := &repo.Rule{
+				Sub:      TeamRoleSubject(TeamSubject(org, t.Name), repo.TeamRoleMember),
+				Resource: repo.NewOrgResource4Sv1(org, "", t.Name, "", ""),
+				Action:   repo.ActRead,
+			}
+			if have[rule.Sub+" "+rule.Resource.ResourceStr()] {
+				continue
+			}
+			if err := s.authzRepo.CreateRule(ctx, tx, rule); err != nil {
+				return err
+			}
+			s.log.Infof("members of team %s can read its resources", t.Name)
+		}
+		return nil
+	})
+}
+
+// publicKindRule lets everyone read resources of kind.
+func publicKindRule(kind string) *Rule {
+	return &Rule{Sub: repo.SubEveryone, ResType: kind, Action: repo.ActRead}
+}
+
+func isPublicKindRule(r *Rule) bool {
+	return r.Sub == repo.SubEveryone && r.Org == "" && r.ResType != "" && r.Team == "" && r.ResInst == "" &&
+		r.User == "" && r.Action == repo.ActRead
+}
+
+// syncPublicKinds makes the rules of everyone match public kinds of config, changes are audited
+// with operator "config".
+func (s *AuthzUsecase) syncPublicKinds(ctx context.Context, kinds []string) error {
+	want := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		if !slices.Contains(ResourceTypes, k) {
+			return fmt.Errorf("invalid public kind %q", k)
+		}
+		want[k] = true
+	}
+	return s.txm.RunInTX(func(tx repo.TX) error {
+		rules, err := s.authzRepo.ListRule(ctx, tx, &repo.RuleFilter{Sub: repo.SubEveryone})
+		if err != nil {
+			return err
+		}
+		have := map[string]bool{}
+		var revoke []*Rule
+		for _, r := range ToBizRules(rules) {
+			if isPublicKindRule(r) && want[r.ResType] {
+				have[r.ResType] = true
+				continue
+			}
+			revoke = append(revoke, r)
+		}
+		var grant []*Rule
+		for _, k := range kinds {
+			if !have[k] {
+				grant = append(grant, publicKindRule(k))
+				have[k] = true
+			}
+		}
+		if len(revoke) > 0 {
+			if err := s.changeRules(ctx, tx, "config", repo.AuthzOpRevoke, revoke, s.authzRepo.DeleteRule); err != nil {
+				return err
+			}
+		}
+		if len(grant) > 0 {
+			if err := s.changeRules(ctx, tx, "config", repo.AuthzOpGrant, grant, s.authzRepo.CreateRule); err != nil {
+				return err
+			}
+			s.log.Infof("public kinds granted %v", kinds)
+		}
+		return nil
+	})
+}
+
+// enforceAdmin requires permission on all resources, rules and groups are admin only.
+func (s *AuthzUsecase) enforceAdmin(ctx context.Context, tx repo.TX) (string, error) {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	can, err := s.authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: repo.NewResource4Sv1("", "", "", ""),
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !can {
+		return "", ErrPermissionDenied
+	}
+	return curUser, nil
+}
+
+// isAdminRule is the rule of admin team created at init.
+func isAdminRule(r *Rule) bool {
+	return r.Sub == AdminTeam && r.Org == "" && r.ResType == "" && r.Team == "" && r.ResInst == "" &&
+		r.User == "" && r.Action == repo.ActWrite
+}
+
+// checkMember requires the group user is a user or a service account.
+func (s *AuthzUsecase) checkMember(ctx context.Context, tx repo.TX, name string) error {
+	if saName, ok := strings.CutPrefix(name, ServiceAccountSubjectPrefix); ok {
+		sas, err := s.serviceAccounts.ListServiceAccounts(ctx, tx, &repo.ServiceAccountsFilter{Names: []string{saName}})
+		if err != nil {
+			return err
+		}
+		if len(sas) != 1 {
+			return NotFound("service account %s not found", saName)
+		}
+		return nil
+	}
+	users, err := s.adminRepo.ListUsers(ctx, tx, &repo.UsersFilter{UserName: []string{name}})
+	if err != nil {
+		return err
+	}
+	if len(users) != 1 {
+		return NotFound("user %s not found", name)
+	}
+	return nil
+}
+
+// checkRole requires the group role is a subject of rules or a role of a team, as team:leader,
+// team:maintainer or team:member. teams out of the default organization are named as org.team.
+// users join teams by the team members api, grouping them into a bare team grants nothing.
+func (s *AuthzUsecase) checkRole(ctx context.Context, tx repo.TX, role string) error {
+	rules, err := s.authzRepo.ListRule(ctx, tx, &repo.RuleFilter{Sub: role})
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		return nil
+	}
+	subject, _, ok := strings.Cut(role, ":")
+	found, err := s.hasTeam(ctx, tx, subject)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return InvalidArgument("role %s is neither a role of a team nor a subject of rules", role)
+	}
+	if !ok {
+		return InvalidArgument("role %s is a team, add its members by the team members api (opspillar-cli team add-member)", role).
+			WithField("role", "must be team:leader, team:maintainer or team:member")
+	}
+	return nil
+}
+
+// hasTeam reports whether the team of subject exists, teams out of the default organization
+// are named as org.team.
+func (s *AuthzUsecase) hasTeam(ctx context.Context, tx repo.TX, subject string) (bool, error) {
+	team := subject
+	filter := &repo.TeamsFilter{Names: []string{subject}, OrgIds: []uint32{repo.DefaultOrgId}}
+	if org, name, ok := strings.Cut(subject, "."); ok {
+		orgs, err := s.orgRepo.ListOrganizations(ctx, tx, &repo.OrganizationsFilter{Names: []string{org}})
+		if err != nil {
+			return false, err
+		}
+		if len(orgs) == 1 && orgs[0].ID != repo.DefaultOrgId {
+			team = name
+			filter = &repo.TeamsFilter{Names: []string{team}, OrgIds: []uint32{orgs[0].ID}}
+		}
+	}
+	teams, err := s.teamsRepo.ListTeams(ctx, tx, filter)
+	if err != nil {
+		return false, err
+	}
+	return len(teamsNamed(teams, team)) == 1, nil
+}
+
+// ListRules lists rules, of sub if not empty.
+func (s *AuthzUsecase) ListRules(ctx context.Context, filter *ListRulesFilter) ([]*Rule, error) {
+	if _, err := s.enforceAdmin(ctx, nil); err != nil {
+		return nil, errors.Join(errors.New("ListRules failed"), err)
+	}
+	dbFilter := &repo.RuleFilter{}
+	if filter != nil {
+		dbFilter.Sub = filter.Sub
+	}
+	rules, err := s.authzRepo.ListRule(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, errors.Join(errors.New("ListRules failed"), err)
+	}
+	return ToBizRules(rules), nil
+}
+
+// GrantRules creates rules, existing rules are kept.
+func (s *AuthzUsecase) GrantRules(ctx context.Context, rules []*Rule) error {
+	if len(rules) == 0 {
+		return errors.Join(errors.New("GrantRules failed"), InvalidArgument("EmptyRules").WithField("rules", "must not be empty"))
+	}
+	for _, r := range rules {
+		if r == nil {
+			return errors.Join(errors.New("GrantRules failed"), errors.New("rule is nil"))
+		}
+		if err := r.Validate(); err != nil {
+			return errors.Join(errors.New("GrantRules failed"), invalidArgument(err))
+		}
+	}
+	var operator string
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		var err error
+		if operator, err = s.enforceAdmin(ctx, tx); err != nil {
+			return err
+		}
+		return s.changeRules(ctx, tx, operator, repo.AuthzOpGrant, rules, s.authzRepo.CreateRule)
+	})
+	if err != nil {
+		return errors.Join(errors.New("GrantRules failed"), err)
+	}
+	s.log.Infof("%d rules granted by %s", len(rules), operator)
+	return nil
+}
+
+// RevokeRules deletes rules, the rule of admin team can not be revoked.
+func (s *AuthzUsecase) RevokeRules(ctx context.Context, rules []*Rule) error {
+	if len(rules) == 0 {
+		return errors.Join(errors.New("RevokeRules failed"), InvalidArgument("EmptyRules").WithField("rules", "must not be empty"))
+	}
+	for _, r := range rules {
+		if r == nil {
+			return errors.Join(errors.New("RevokeRules failed"), errors.New("rule is nil"))
+		}
+		if err := r.Validate(); err != nil {
+			return errors.Join(errors.New("RevokeRules failed"), invalidArgument(err))
+		}
+		if isAdminRule(r) {
+			return errors.Join(errors.New("RevokeRules failed"), ErrAdminLockout)
+		}
+	}
+	var operator string
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		var err error
+		if operator, err = s.enforceAdmin(ctx, tx); err != nil {
+			return err
+		}
+		return s.changeRules(ctx, tx, operator, repo.AuthzOpRevoke, rules, s.authzRepo.DeleteRule)
+	})
+	if err != nil {
+		return errors.Join(errors.New("RevokeRules failed"), err)
+	}
+	s.log.Infof("%d rules revoked by %s", len(rules), operator)
+	return nil
+}
+
+func (s *AuthzUsecase) changeRules(ctx context.Context, tx repo.TX, operator, op string, rules []*Rule,
+	change func(context.Context, repo.TX, *repo.Rule) error) error {
+	now := time.Now().Unix()
+	audits := make([]*repo.AuthzAudit, 0, len(rules))
+	for _, r := range rules {
+		dbRule := ToDBRule(r)
+		if err := change(ctx, tx, dbRule); err != nil {
+			return err
+		}
+		audits = append(audits, &repo.AuthzAudit{
+			Operator:  operator,
+			Operation: op,
+			Sub:       dbRule.Sub,
+			Resource:  dbRule.Resource.ResourceStr(),
+			Action:    dbRule.Action,
+			CreatedAt: now,
+		})
+	}
+	return s.auditsRepo.CreateAuthzAudits(ctx, tx, audits)
+}
+
+// ListGroups lists groups, of user or role if not empty.
+func (s *AuthzUsecase) ListGroups(ctx context.Context, filter *ListGroupsFilter) ([]*Group, error) {
+	if _, err := s.enforceAdmin(ctx, nil); err != nil {
+		return nil, errors.Join(errors.New("ListGroups failed"), err)
+	}
+	dbFilter := &repo.GroupFilter{}
+	if filter != nil {
+		if filter.User != "" && filter.Role != "" {
+			return nil, errors.Join(errors.New("ListGroups failed"), errors.New("filter by either user or role"))
+		}
+		dbFilter.User, dbFilter.Role = filter.User, filter.Role
+	}
+	groups, err := s.authzRepo.ListGroup(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, errors.Join(errors.New("ListGroups failed"), err)
+	}
+	return ToBizGroups(groups), nil
+}
+
+// AddGroups makes existing users or service accounts members of teams or roles with rules.
+func (s *AuthzUsecase) AddGroups(ctx context.Context, groups []*Group) error {
+	if len(groups) == 0 {
+		return errors.Join(errors.New("AddGroups failed"), InvalidArgument("EmptyGroups").WithField("groups", "must not be empty"))
+	}
+	for _, g := range groups {
+		if g == nil {
+			return errors.Join(errors.New("AddGroups failed"), errors.New("group is nil"))
+		}
+		if err := g.Validate(); err != nil {
+			return errors.Join(errors.New("AddGroups failed"), invalidArgument(err))
+		}
+	}
+	var operator string
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		var err error
+		if operator, err = s.enforceAdmin(ctx, tx); err != nil {
+			return err
+		}
+		for _, g := range groups {
+			if err := s.checkMember(ctx, tx, g.User); err != nil {
+				return err
+			}
+			if err := s.checkRole(ctx, tx, g.Role); err != nil {
+				return err
+			}
+		}
+		return s.changeGroups(ctx, tx, operator, repo.AuthzOpAddGroup, groups, s.authzRepo.CreateGroup)
+	})
+	if err != nil {
+		return errors.Join(errors.New("AddGroups failed"), err)
+	}
+	s.log.Infof("%d groups added by %s", len(groups), operator)
+	return nil
+}
+
+// RemoveGroups removes members from roles, admin can not be removed from admin team.
+func (s *AuthzUsecase) RemoveGroups(ctx context.Context, groups []*Group) error {
+	if len(groups) == 0 {
+		return errors.Join(errors.New("RemoveGroups failed"), InvalidArgument("EmptyGroups").WithField("groups", "must not be empty"))
+	}
+	for _, g := range groups {
+		if g == nil {
+			return errors.Join(errors.New("RemoveGroups failed"), errors.New("group is nil"))
+		}
+		if err := g.Validate(); err != nil {
+			return errors.Join(errors.New("RemoveGroups failed"), invalidArgument(err))
+		}
+		if g.User == AdminUser && g.Role == AdminTeam {
+			return errors.Join(errors.New("RemoveGroups failed"), ErrAdminLockout)
+		}
+	}
+	var operator string
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		var err error
+		if operator, err = s.enforceAdmin(ctx, tx); err != nil {
+			return err
+		}
+		return s.changeGroups(ctx, tx, operator, repo.AuthzOpRemoveGroup, groups, s.authzRepo.DeleteGroup)
+	})
+	if err != nil {
+		return errors.Join(errors.New("RemoveGroups failed"), err)
+	}
+	s.log.Infof("%d groups removed by %s", len(groups), operator)
+	return nil
+}
+
+func (s *AuthzUsecase) changeGroups(ctx context.Context, tx repo.TX, operator, op string, groups []*Group,
+	change func(context.Context, repo.TX, *repo.Group) error) error {
+	now := time.Now().Unix()
+	audits := make([]*repo.AuthzAudit, 0, len(groups))
+	for _, g := range groups {
+		if err := change(ctx, tx, &repo.Group{User: g.User, Role: g.Role}); err != nil {
+			return err
+		}
+		audits = append(audits, &repo.AuthzAudit{
+			Operator:  operator,
+			Operation: op,
+			Sub:       g.User,
+			Role:      g.Role,
+			CreatedAt: now,
+		})
+	}
+	return s.auditsRepo.CreateAuthzAudits(ctx, tx, audits)
+}
+
+// ListAuthzAudits lists changes of rules and groups, newest first.
+func (s *AuthzUsecase) ListAuthzAudits(ctx context.Context, filter *ListAuthzAuditsFilter) ([]*AuthzAudit, *PageInfo, error) {
+	if filter == nil {
+		filter = DefaultAuthzAuditsFilter()
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, nil, errors.Join(errors.New("ListAuthzAudits failed"), invalidArgument(err))
+	}
+	if _, err := s.enforceAdmin(ctx, nil); err != nil {
+		return nil, nil, errors.Join(errors.New("ListAuthzAudits failed"), err)
+	}
+	dbFilter := ToDBAuthzAuditsFilter(filter)
+	audits, err := s.auditsRepo.ListAuthzAudits(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListAuthzAudits failed"), err)
+	}
+	page, err := listPage(audits, dbFilter.PageSize, func(a *repo.AuthzAudit) uint32 { return a.ID },
+		filter.WithTotal, func() ([]*repo.AuthzAudit, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.auditsRepo.ListAuthzAudits(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListAuthzAudits failed"), err)
+	}
+	return ToBizAuthzAudits(audits), page, nil
+}
@@ -0,0 +1,163 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"opspillar/internal/data/repo"
+)
+
+// CanI evaluates a request as the usecases do and explains the answer. users may ask for
+// themselves, asking as another subject requires admin.
+func (s *AuthzUsecase) CanI(ctx context.Context, req *CanIRequest) (*CanIAnswer, error) {
+	if req == nil {
+		return nil, errors.Join(errors.New("CanI failed"), errors.New("request is nil"))
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errors.Join(errors.New("CanI failed"), invalidArgument(err))
+	}
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("CanI failed"), err)
+	}
+	sub := curUser
+	if req.As != "" && req.As != curUser {
+		if _, err := s.enforceAdmin(ctx, nil); err != nil {
+			return nil, errors.Join(errors.New("CanI failed"), err)
+		}
+		sub = req.As
+	}
+	res, err := s.canIResource(ctx, req, sub)
+	if err != nil {
+		return nil, errors.Join(errors.New("CanI failed"), err)
+	}
+	exp, err := s.authzRepo.Explain(ctx, nil, &repo.AuthenRequest{
+		Sub:      sub,
+		Resource: res,
+		Action:   req.Action,
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("CanI failed"), err)
+	}
+	answer := &CanIAnswer{
+		Allowed:  exp.Allowed,
+		Sub:      sub,
+		Resource: res.ResourceStr(),
+		Chain:    exp.Chain,
+		Roles:    exp.Roles,
+	}
+	if exp.Rule != nil {
+		answer.Rule = ToBizRules([]*repo.Rule{exp.Rule})[0]
+	}
+	return answer, nil
+}
+
+// orgKinds are kinds checked on resources of an organization.
+var orgKinds = []string{"applications", "hostgroups", "organizations", "product", "serviceaccounts", "team"}
+
+// canIResource builds the resource the usecases check for the request, an empty name asks
+// for the kind. names are looked up in the selected organization.
+func (s *AuthzUsecase) canIResource(ctx context.Context, req *CanIRequest, sub string) (repo.IResource, error) {
+	write := req.Action == repo.ActWrite
+	org, err := selectedOrg(ctx, s.orgRepo, nil)
+	if err != nil {
+		return nil, err
+	}
+	var orgIds []uint32
+	if org != nil {
+		orgIds = []uint32{org.ID}
+	}
+	switch req.Kind {
+	case "users":
+		// changes of users are checked on the caller
+		if write {
+			return repo.NewResource4Sv1("users", "", "", sub), nil
+		}
+		return repo.NewResource4Sv1("users", "", req.Name, req.Name), nil
+	case "tags":
+		// tags are written with permission on teams
+		if write {
+			return repo.NewResource4Sv1("team", "", "", ""), nil
+		}
+	}
+	if req.Name == "" {
+		if org != nil && slices.Contains(orgKinds, req.Kind) {
+			return repo.NewOrgResource4Sv1(org.Name, req.Kind, "", "", ""), nil
+		}
+		return repo.NewResource4Sv1(req.Kind, "", "", ""), nil
+	}
+
+	names := newTeamResourceNames(s.teamsRepo, s.adminRepo, s.orgRepo)
+	switch req.Kind {
+	case "hostgroups":
+		hgs, err := s.hostgroupsRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+			Names:     []string{req.Name},
+			NameMatch: repo.NameMatchExact,
+			OrgIds:    orgIds,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(hgs) != 1 {
+			return nil, NotFound("hostgroup %s not found", req.Name)
+		}
+		return names.leaderResource(ctx, "hostgroups", req.Name, hgs[0].TeamId)
+	case "applications":
+		apps, err := s.applicationsRepo.ListApplications(ctx, nil, &repo.ApplicationsFilter{
+			Names:     []string{req.Name},
+			NameMatch: repo.NameMatchExact,
+			OrgIds:    orgIds,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(apps) != 1 {
+			return nil, NotFound("application %s not found", req.Name)
+		}
+		team, err := names.team(ctx, apps[0].TeamId)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := names.user(ctx, apps[0].OwnerId)
+		if err != nil {
+			return nil, err
+		}
+		return names.resource(ctx, "applications", team, req.Name, owner)
+	case "serviceaccounts":
+		sas, err := s.serviceAccounts.ListServiceAccounts(ctx, nil, &repo.ServiceAccountsFilter{Names: []string{req.Name}})
+		if err != nil {
+			return nil, err
+		}
+		if len(sas) != 1 {
+			return nil, NotFound("service account %s not found", req.Name)
+		}
+		team, err := names.team(ctx, sas[0].TeamId)
+		if err != nil {
+			return nil, err
+		}
+		if write {
+			return names.resource(ctx, "serviceaccounts", team, "", "")
+		}
+		return names.resource(ctx, "serviceaccounts", team, req.Name, "")
+	case "team":
+		teams, err := s.teamsRepo.ListTeams(ctx, nil, &repo.TeamsFilter{
+			Names:  []string{req.Name},
+			OrgIds: orgIds,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if teams = teamsNamed(teams, req.Name); len(teams) != 1 {
+			return nil, NotFound("team %s not found", req.Name)
+		}
+		// writing a named team is managing its members
+		inst := ""
+		if write {
+			inst = "members"
+		}
+		return names.leaderResource(ctx, "team", inst, teams[0].ID)
+	}
+	// lookups are checked on the kind
+	return repo.NewResource4Sv1(req.Kind, "", "", ""), nil
+}
@@ -0,0 +1,76 @@
+package biz
+
+// Rule grants Action on resources matching the Resource4Sv1 pattern to Sub,
+// an empty section matches any value.
+type Rule struct {
+	Sub string
+	// Org is the organization of the resource, any organization if empty
+	Org     string
+	ResType string
+	Team    string
+	ResInst string
+	User    string
+	Action  string
+	// Resource is the casbin resource string, only returned
+	Resource string
+}
+
+// Group makes User a member of Role, so User has the rules of Role.
+type Group struct {
+	User string
+	Role string
+}
+
+type ListRulesFilter struct {
+	Sub string
+}
+
+type ListGroupsFilter struct {
+	User string
+	Role string
+}
+
+type AuthzAudit struct {
+	Id        uint32
+	Operator  string
+	Operation string
+	Sub       string
+	Resource  string
+	Action    string
+	Role      string
+	CreatedAt int64
+}
+
+type ListAuthzAuditsFilter struct {
+	Page      uint32
+	PageSize  uint32
+	Subs      []string
+	Operators []string
+	// Since is unix seconds, 0 means all
+	Since int64
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
+
+// CanIRequest asks whether As, the current user if empty, can do Action on the resource
+// Name of Kind. an empty Name asks for the kind.
+type CanIRequest struct {
+	Action string
+	Kind   string
+	Name   string
+	As     string
+}
+
+// CanIAnswer tells the casbin resource checked, the matched rule and the chain of groups
+// from Sub to the subject of the rule. Roles are all roles of Sub.
+type CanIAnswer struct {
+	Allowed  bool
+	Sub      string
+	Resource string
+	Rule     *Rule
+	Chain    []string
+	Roles    []string
+}
@@ -0,0 +1,192 @@
+package biz
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"opspillar/internal/data/repo"
+)
+
+// ResourceTypes are resource types of rules checked by usecases.
+var ResourceTypes = []string{
+	"applications", "attributes", "clusters", "datacenters", "envs", "features",
+	"hostgroups", "organizations", "product", "serviceaccounts", "tagkeys", "tags", "team", "users",
+}
+
+// Actions are actions of rules.
+var Actions = []string{repo.ActWrite, repo.ActRead}
+
+// AuthzNamePattern matches subjects and sections of resources, users from
+// directories may have upper case, dots or @ in their names.
+var AuthzNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.@-]*$`)
+
+func validateAuthzName(kind, name string) error {
+	if len(name) > MaxNameLength || !AuthzNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid %s %q", kind, name)
+	}
+	return nil
+}
+
+// validateSubject allows users, roles and service accounts.
+func validateSubject(kind, sub string) error {
+	return validateAuthzName(kind, strings.TrimPrefix(sub, ServiceAccountSubjectPrefix))
+}
+
+// validateRole allows roles and roles of teams, as team:member.
+func validateRole(kind, role string) error {
+	name := role
+	if team, teamRole, ok := strings.Cut(role, ":"); ok && slices.Contains(teamRoles, teamRole) {
+		name = team
+	}
+	if err := validateAuthzName(kind, name); err != nil {
+		return fmt.Errorf("invalid %s %q", kind, role)
+	}
+	return nil
+}
+
+func (r *Rule) Validate() error {
+	if err := validateSubject("subject", r.Sub); err != nil {
+		return err
+	}
+	if r.ResType != "" && !slices.Contains(ResourceTypes, r.ResType) {
+		return fmt.Errorf("invalid resource type %q, valid types are %s", r.ResType, strings.Join(ResourceTypes, ", "))
+	}
+	for kind, section := range map[string]string{"org": r.Org, "team": r.Team, "resource instance": r.ResInst, "user": r.User} {
+		if section == "" {
+			continue
+		}
+		if err := validateAuthzName(kind, section); err != nil {
+			return err
+		}
+	}
+	if !slices.Contains(Actions, r.Action) {
+		return fmt.Errorf("invalid action %q", r.Action)
+	}
+	return nil
+}
+
+func (g *Group) Validate() error {
+	if err := validateSubject("user", g.User); err != nil {
+		return err
+	}
+	if err := validateRole("role", g.Role); err != nil {
+		return err
+	}
+	if g.User == g.Role {
+		return errors.New("user and role are the same")
+	}
+	return nil
+}
+
+func (r *CanIRequest) Validate() error {
+	if !slices.Contains(Actions, r.Action) {
+		return fmt.Errorf("invalid action %q", r.Action)
+	}
+	if !slices.Contains(ResourceTypes, r.Kind) {
+		return fmt.Errorf("invalid kind %q, valid kinds are %s", r.Kind, strings.Join(ResourceTypes, ", "))
+	}
+	if r.Name != "" {
+		if err := validateAuthzName("name", r.Name); err != nil {
+			return err
+		}
+	}
+	if r.As != "" {
+		if err := validateSubject("subject", r.As); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lf *ListAuthzAuditsFilter) Validate() error {
+	if lf == nil {
+		return nil
+	}
+	if len(lf.Subs) > MaxFilterValues || len(lf.Operators) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
+		return ErrFilterInvalidPagesize
+	}
+	if lf.Page == 0 {
+		return ErrFilterInvalidPage
+	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefaultAuthzAuditsFilter() *ListAuthzAuditsFilter {
+	return &ListAuthzAuditsFilter{
+		Page:     1,
+		PageSize: DefaultPageSize,
+	}
+}
+
+func ToDBRule(r *Rule) *repo.Rule {
+	return &repo.Rule{
+		Sub:      r.Sub,
+		Resource: repo.NewOrgResource4Sv1(r.Org, r.ResType, r.Team, r.ResInst, r.User),
+		Action:   r.Action,
+	}
+}
+
+func ToBizRules(rules []*repo.Rule) []*Rule {
+	res := make([]*Rule, 0, len(rules))
+	for _, r := range rules {
+		rule := &Rule{Sub: r.Sub, Action: r.Action, Resource: r.Resource.ResourceStr()}
+		if ires, ok := r.Resource.(*repo.Resource4Sv1); ok {
+			rule.Org = ires.OrgName
+			rule.ResType = ires.ResType
+			rule.Team = ires.TeamName
+			rule.ResInst = ires.ResInst
+			rule.User = ires.UserName
+		}
+		res = append(res, rule)
+	}
+	return res
+}
+
+func ToBizGroups(groups []*repo.Group) []*Group {
+	res := make([]*Group, 0, len(groups))
+	for _, g := range groups {
+		// ListGroup leaves invalid grouping policies nil
+		if g == nil {
+			continue
+		}
+		res = append(res, &Group{User: g.User, Role: g.Role})
+	}
+	return res
+}
+
+func ToBizAuthzAudits(audits []*repo.AuthzAudit) []*AuthzAudit {
+	res := make([]*AuthzAudit, len(audits))
+	for i, a := range audits {
+		res[i] = &AuthzAudit{
+			Id:        a.ID,
+			Operator:  a.Operator,
+			Operation: a.Operation,
+			Sub:       a.Sub,
+			Resource:  a.Resource,
+			Action:    a.Action,
+			Role:      a.Role,
+			CreatedAt: a.CreatedAt,
+		}
+	}
+	return res
+}
+
+func ToDBAuthzAuditsFilter(filter *ListAuthzAuditsFilter) *repo.AuthzAuditsFilter {
+	return &repo.AuthzAuditsFilter{
+		Page:      filter.Page,
+		PageSize:  filter.PageSize,
+		LastId:    pageTokenId(filter.PageToken),
+		Subs:      filter.Subs,
+		Operators: filter.Operators,
+		Since:     filter.Since,
+	}
+}
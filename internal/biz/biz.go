@@ -16,24 +16,39 @@ var ProviderSet = wire.NewSet(
 	NewTagsUsecase,
 	NewFeaturesUsecase,
 	NewTeamsUsecase,
+	NewOrganizationsUsecase,
 	NewProductsUsecase,
 	NewEnvsUsecase,
 	NewClustersUsecase,
 	NewDatacentersUsecase,
 	NewHostgroupsUsecase,
 	NewApplicationsUsecase,
+	NewAttributesUsecase,
+	NewTagKeysUsecase,
 	NewAdminUsecase,
+	NewServiceAccountsUsecase,
+	NewBreakGlassUsecase,
+	NewAuthzUsecase,
 )
 
-const MaxFilterValues = 10
+// MaxFilterValues limits ids and exact values of a list filter, they are bound as one IN list
+const MaxFilterValues = 100
+
+// MaxFilterPatterns limits names matched as substrings or prefixes, each is a LIKE condition
+const MaxFilterPatterns = 10
 const DefaultPageSize = 50
 const MaxPageSize = 200
 const FilterKVSplit = ":"
 
-var ErrFilterValuesExceedMax = errors.New("filter values exceeded max number")
-var ErrFilterKVInvalid = errors.New("filter KV invalid format")
-var ErrFilterInvalidPagesize = errors.New("filter invalid page size")
-var ErrFilterInvalidPage = errors.New("filter invalid page")
+var ErrFilterValuesExceedMax = InvalidArgument("filter values exceeded max number")
+var ErrFilterKVInvalid = InvalidArgument("filter KV invalid format").WithField("kvs", "must be key:value")
+var ErrFilterInvalidPagesize = InvalidArgument("filter invalid page size").WithField("page_size", "must be 1 to 200")
+var ErrFilterInvalidPage = InvalidArgument("filter invalid page").WithField("page", "must be set")
+var ErrFilterInvalidPageToken = InvalidArgument("filter invalid page token").WithField("page_token", "must be a token of a reply")
+var ErrFilterPageTokenSort = InvalidArgument("filter page token of another sort").WithField("page_token", "must be a token of a list with the same sort_by and sort_desc")
+var ErrFilterInvalidSort = InvalidArgument("filter invalid sort").WithField("sort", "must be id, name, created_at or updated_at")
+var ErrFilterInvalidNameMatch = InvalidArgument("filter invalid name match").WithField("name_match", "must be prefix or exact")
+var ErrFilterInvalidSelector = InvalidArgument("filter invalid selector").WithField("selector", "must be a label selector")
 
 func filterKvValidate(kvstr string) error {
 	kv := strings.Split(kvstr, FilterKVSplit)
@@ -74,6 +89,11 @@ func DiffSliceUint32(s1 []uint32, s2 []uint32) []uint32 {
 	return result
 }
 
+// EditSliceUint32 returns s with add appended and remove taken out, without duplicates
+func EditSliceUint32(s []uint32, add []uint32, remove []uint32) []uint32 {
+	return DiffSliceUint32(DedupSliceUint32(append(append([]uint32{}, s...), add...)), remove)
+}
+
 // IntersectSliceUint32 返回两个 []uint32 切片的交集
 func IntersectSliceUint32(slice1, slice2 []uint32) []uint32 {
 	if len(slice1) == 0 || len(slice2) == 0 {
@@ -0,0 +1,171 @@
+package biz_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newLDAPAdminUsecase(t *testing.T) (*biz.AdminUsecase, *adminMocks, *MockDirectoryProvider) {
+	directory := new(MockDirectoryProvider)
+	uc, m := newAdminUsecaseWith(t, &repo.User{Id: 1, UserName: biz.AdminUser}, &conf.Admin{
+		AdminPassword: "admin@123", JwtExpireHours: 1,
+		Ldap: &conf.LDAP{
+			Url:        "ldap://127.0.0.1:389",
+			GroupTeams: map[string]string{"ops": "ops-team", "dba": "dba-team"},
+		},
+	}, nil, directory)
+	m.sessions.On("CreateSessions", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.token.On("CreateToken", mock.Anything, mock.Anything).Return("access", nil)
+	// dba-team does not exist
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.TeamsFilter) bool {
+		return len(f.Names) == 2
+	})).Return([]*repo.Team{{ID: 2, Name: "ops-team"}}, nil)
+	// roles of teams
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, mock.MatchedBy(func(g *repo.Group) bool {
+		return strings.Contains(g.User, ":")
+	})).Return(nil)
+	m.authz.On("CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return strings.Contains(r.Sub, ":")
+	})).Return(nil)
+	return uc, m, directory
+}
+
+func userNamed(name string) interface{} {
+	return mock.MatchedBy(func(f *repo.UsersFilter) bool {
+		return len(f.UserName) == 1 && f.UserName[0] == name
+	})
+}
+
+// memberRole matches a new member of team.
+func memberRole(teamId, userId uint32, role string) interface{} {
+	return mock.MatchedBy(func(members []*repo.TeamMember) bool {
+		return len(members) == 1 && members[0].TeamId == teamId && members[0].UserId == userId && members[0].Role == role
+	})
+}
+
+func TestAdminUsecase_Login_LDAP(t *testing.T) {
+	ctx := context.Background()
+	uc, m, directory := newLDAPAdminUsecase(t)
+
+	directory.On("Authenticate", ctx, "alice", "secret").Return(&repo.DirectoryUser{
+		DN: "uid=alice,ou=people,dc=example,dc=com", UserName: "alice", Email: "alice@example.com",
+		Groups: []string{"ops", "dba"},
+	}, nil)
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, userNamed("alice")).Return([]*repo.User{}, nil).Twice()
+	m.admin.On("CreateUsers", ctx, mock.Anything, mock.MatchedBy(func(users []*repo.User) bool {
+		u := users[0]
+		return u.UserName == "alice" && u.Source == repo.UserSourceLDAP &&
+			u.ExternalId == "uid=alice,ou=people,dc=example,dc=com" && !biz.CheckPassword(u.Password, "secret")
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).([]*repo.User)[0].Id = 5
+	}).Return(nil).Once()
+	m.authz.On("CreateRule", ctx, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == "alice"
+	})).Return(nil).Once()
+	m.members.On("ListTeamMembers", ctx, mock.Anything, memberOf(5)).Return([]*repo.TeamMember{}, nil)
+	m.members.On("CreateTeamMembers", ctx, mock.Anything, memberRole(2, 5, repo.TeamRoleMember)).Return(nil).Once()
+	m.authz.On("CreateGroup", ctx, mock.Anything, &repo.Group{User: "alice", Role: "ops-team:member"}).Return(nil).Once()
+
+	user, err := uc.Login(ctx, "alice", "secret", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), user.Id)
+	assert.Equal(t, "access", user.Token)
+	m.authz.AssertExpectations(t)
+	m.members.AssertExpectations(t)
+	// teams get no rules of their own
+	m.authz.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == "ops-team"
+	}))
+
+	// wrong password
+	directory.On("Authenticate", ctx, "alice", "wrong").Return(nil, repo.ErrInvalidCredentials)
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, userNamed("alice")).
+		Return([]*repo.User{{Id: 5, UserName: "alice", Source: repo.UserSourceLDAP}}, nil).Once()
+	_, err = uc.Login(ctx, "alice", "wrong", "", nil)
+	assert.Error(t, err)
+}
+
+func TestAdminUsecase_Login_LDAPLocalUser(t *testing.T) {
+	ctx := context.Background()
+	uc, m, directory := newLDAPAdminUsecase(t)
+	password, _ := biz.HashPassword("local@123")
+
+	// local users keep their password
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, userNamed("bob")).
+		Return([]*repo.User{{Id: 6, UserName: "bob", Password: password, Source: repo.UserSourceLocal}}, nil)
+	user, err := uc.Login(ctx, "bob", "local@123", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6), user.Id)
+	directory.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything, mock.Anything)
+
+	// disabled users can not login
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, userNamed("carol")).
+		Return([]*repo.User{{Id: 7, UserName: "carol", Password: password, Disabled: true}}, nil)
+	_, err = uc.Login(ctx, "carol", "local@123", "", nil)
+	assert.ErrorIs(t, err, biz.ErrUserDisabled)
+}
+
+func TestAdminUsecase_SyncDirectory(t *testing.T) {
+	ctx := context.Background()
+	uc, m, directory := newLDAPAdminUsecase(t)
+
+	directory.On("ListUsers", ctx).Return([]*repo.DirectoryUser{
+		{DN: "uid=alice,dc=example", UserName: "alice", Groups: []string{"ops"}},
+		{DN: "uid=carol,dc=example", UserName: "carol"},
+	}, nil)
+	m.admin.On("ListUsers", ctx, mock.Anything, &repo.UsersFilter{Sources: []string{repo.UserSourceLDAP}}).Return([]*repo.User{
+		{Id: 5, UserName: "alice", Source: repo.UserSourceLDAP},
+		{Id: 7, UserName: "carol", Source: repo.UserSourceLDAP, Disabled: true},
+		{Id: 8, UserName: "dave", Source: repo.UserSourceLDAP},
+	}, nil)
+	// carol is back in the directory
+	m.admin.On("ListUsers", ctx, mock.Anything, userNamed("carol")).
+		Return([]*repo.User{{Id: 7, UserName: "carol", Source: repo.UserSourceLDAP, Disabled: true}}, nil)
+	m.admin.On("DisableUsers", ctx, mock.Anything, []uint32{7}, false).Return(nil).Once()
+	m.members.On("ListTeamMembers", ctx, mock.Anything, memberOf(5)).Return([]*repo.TeamMember{}, nil)
+	m.members.On("ListTeamMembers", ctx, mock.Anything, memberOf(7)).Return([]*repo.TeamMember{
+		{ID: 10, TeamId: 2, UserId: 7, Role: repo.TeamRoleMember},
+	}, nil)
+	m.authz.On("ListGroup", ctx, mock.Anything, &repo.GroupFilter{User: "carol"}).
+		Return([]*repo.Group{{User: "carol", Role: "ops-team:member"}}, nil)
+	m.members.On("CreateTeamMembers", ctx, mock.Anything, memberRole(2, 5, repo.TeamRoleMember)).Return(nil).Once()
+	m.authz.On("CreateGroup", ctx, mock.Anything, &repo.Group{User: "alice", Role: "ops-team:member"}).Return(nil).Once()
+	m.authz.On("DeleteGroup", ctx, mock.Anything, &repo.Group{User: "carol", Role: "ops-team:member"}).Return(nil).Once()
+	m.members.On("DeleteTeamMembers", ctx, mock.Anything, []uint32{10}).Return(nil).Once()
+	// dave is removed from the directory
+	m.admin.On("DisableUsers", ctx, mock.Anything, []uint32{8}, true).Return(nil).Once()
+	m.sessions.On("DeleteUserSessions", ctx, mock.Anything, []uint32{8}).Return(nil).Once()
+	m.token.On("RevokeUserTokens", ctx, "8").Return(nil).Once()
+
+	assert.NoError(t, uc.SyncDirectory(ctx))
+	m.admin.AssertExpectations(t)
+	m.authz.AssertExpectations(t)
+	m.members.AssertExpectations(t)
+	m.sessions.AssertCalled(t, "DeleteUserSessions", ctx, mock.Anything, []uint32{8})
+	m.token.AssertCalled(t, "RevokeUserTokens", ctx, "8")
+	m.admin.AssertNotCalled(t, "CreateUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminUsecase_SyncDirectory_Empty(t *testing.T) {
+	ctx := context.Background()
+	uc, m, directory := newLDAPAdminUsecase(t)
+
+	// an empty directory does not disable everyone
+	directory.On("ListUsers", ctx).Return([]*repo.DirectoryUser{}, nil)
+	m.admin.On("ListUsers", ctx, mock.Anything, &repo.UsersFilter{Sources: []string{repo.UserSourceLDAP}}).
+		Return([]*repo.User{{Id: 5, UserName: "alice", Source: repo.UserSourceLDAP}}, nil)
+	assert.Error(t, uc.SyncDirectory(ctx))
+	m.admin.AssertNotCalled(t, "DisableUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// not configured
+	plain, _ := newAdminUsecase(t, &repo.User{Id: 1, UserName: biz.AdminUser})
+	assert.ErrorIs(t, plain.SyncDirectory(ctx), repo.ErrDirectoryNotConfigured)
+}
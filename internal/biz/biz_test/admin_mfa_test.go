@@ -0,0 +1,225 @@
+package biz_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// secret of the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func newMFAUsecase(t *testing.T, user *repo.User, required bool) (*biz.AdminUsecase, *adminMocks) {
+	uc, m := newLocalUserUsecase(t, user, &conf.Admin{
+		AdminPassword:   "admin@123",
+		JwtExpireHours:  1,
+		MaxFailedLogins: 3,
+		RequireAdminMfa: required,
+	})
+	m.sessions.On("CreateSessions", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.token.On("CreateToken", mock.Anything, mock.Anything).Return("access", nil)
+	return uc, m
+}
+
+func wrongCode(code string) string {
+	return string('0'+(code[0]-'0'+1)%10) + code[1:]
+}
+
+func TestTOTPCode(t *testing.T) {
+	for unix, want := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := biz.TOTPCode(rfcSecret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code, unix)
+	}
+	_, err := biz.TOTPCode("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+func TestAdminUsecase_LoginMFA(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password, MFASecret: rfcSecret, MFAEnabled: true}
+	uc, m := newMFAUsecase(t, user, false)
+
+	_, err := uc.Login(ctx, "alice", "alice@123", "", nil)
+	var mfaErr *biz.MFARequiredError
+	assert.True(t, errors.As(err, &mfaErr))
+
+	code, _ := biz.TOTPCode(rfcSecret, time.Now())
+	m.admin.On("AddFailedLogin", ctx, mock.Anything, uint32(2), int32(3), mock.Anything).Return(false, nil).Once()
+	_, err = uc.Login(ctx, "alice", "alice@123", wrongCode(code), nil)
+	assert.ErrorIs(t, err, biz.ErrMFACodeIncorrect)
+
+	m.admin.On("UseMFAStep", ctx, mock.Anything, uint32(2), mock.Anything).Return(true, nil).Once()
+	loggedIn, err := uc.Login(ctx, "alice", "alice@123", code, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "access", loggedIn.Token)
+
+	// a code is accepted once
+	m.admin.On("UseMFAStep", ctx, mock.Anything, uint32(2), mock.Anything).Return(false, nil).Once()
+	m.admin.On("AddFailedLogin", ctx, mock.Anything, uint32(2), int32(3), mock.Anything).Return(false, nil).Once()
+	_, err = uc.Login(ctx, "alice", "alice@123", code, nil)
+	assert.ErrorIs(t, err, biz.ErrMFACodeIncorrect)
+
+	// recovery codes are typed with or without separators
+	sum := sha256.Sum256([]byte("abcdefgh"))
+	m.admin.On("UseMFARecoveryCode", ctx, mock.Anything, uint32(2), hex.EncodeToString(sum[:]), mock.Anything).Return(nil).Once()
+	_, err = uc.Login(ctx, "alice", "alice@123", "ABCD-EFGH", nil)
+	assert.NoError(t, err)
+	m.admin.AssertNumberOfCalls(t, "AddFailedLogin", 2)
+}
+
+// countFailures keeps the failed logins of user between calls like the repo does.
+func countFailures(m *adminMocks, user *repo.User) {
+	m.admin.On("AddFailedLogin", mock.Anything, mock.Anything, user.Id, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			user.FailedLogins++
+			if user.FailedLogins >= args.Get(3).(int32) {
+				user.LockedUntil = args.Get(4).(int64)
+			}
+		}).Return(false, nil)
+	m.admin.On("ResetFailedLogins", mock.Anything, mock.Anything, user.Id).Run(func(args mock.Arguments) {
+		user.FailedLogins = 0
+	}).Return(nil)
+}
+
+func TestAdminUsecase_LoginMFALockout(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password, MFASecret: rfcSecret, MFAEnabled: true}
+	uc, m := newMFAUsecase(t, user, false)
+	countFailures(m, user)
+
+	// the right password does not clear the failures of wrong codes
+	code, _ := biz.TOTPCode(rfcSecret, time.Now())
+	for i := 0; i < 3; i++ {
+		_, err := uc.Login(ctx, "alice", "alice@123", wrongCode(code), nil)
+		assert.ErrorIs(t, err, biz.ErrMFACodeIncorrect)
+	}
+	_, err := uc.Login(ctx, "alice", "alice@123", code, nil)
+	assert.ErrorIs(t, err, biz.ErrUserLocked)
+	m.admin.AssertNotCalled(t, "ResetFailedLogins", mock.Anything, mock.Anything, uint32(2))
+
+	// a login passing all checks clears them
+	user.LockedUntil = 0
+	user.FailedLogins = 2
+	m.admin.On("UseMFAStep", ctx, mock.Anything, uint32(2), mock.Anything).Return(true, nil).Once()
+	_, err = uc.Login(ctx, "alice", "alice@123", code, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), user.FailedLogins)
+}
+
+func TestAdminUsecase_LoginMFAEnroll(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password}
+	uc, m := newMFAUsecase(t, user, true)
+	m.authz.On("ListGroup", ctx, mock.Anything, &repo.GroupFilter{User: "alice"}).
+		Return([]*repo.Group{{User: "alice", Role: "web-team:member"}, {User: "alice", Role: biz.AdminTeam}}, nil)
+
+	// users of admin-team without MFA are refused, the password alone gets no secret
+	_, err := uc.Login(ctx, "alice", "alice@123", "", nil)
+	assert.ErrorIs(t, err, biz.ErrMFAEnrollmentRequired)
+	_, err = uc.Login(ctx, "alice", "alice@123", "123456", nil)
+	assert.ErrorIs(t, err, biz.ErrMFAEnrollmentRequired)
+	m.admin.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// an admin enrolls alice and hands the enrollment over
+	adminCtx := context.WithValue(ctx, data.CtxUserName, biz.AdminUser)
+	adminCtx = context.WithValue(adminCtx, data.CtxUserId, "1")
+	m.admin.On("GetUsers", adminCtx, uint32(2)).Return(user, nil)
+	m.authz.On("Enforce", adminCtx, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Sub == biz.AdminUser
+	})).Return(true, nil)
+	var codes []*repo.MFARecoveryCode
+	m.admin.On("SetMFA", adminCtx, mock.Anything, uint32(2), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		user.MFASecret = args.String(3)
+		codes = args.Get(4).([]*repo.MFARecoveryCode)
+	}).Return(nil)
+	enrollment, err := uc.EnrollMFA(adminCtx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, user.MFASecret, enrollment.Secret)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/OpsPillar:alice?")
+	assert.Len(t, enrollment.RecoveryCodes, 10)
+	assert.Len(t, codes, 10)
+
+	// a code of the pending secret confirms it at login, recovery codes do not
+	_, err = uc.Login(ctx, "alice", "alice@123", "", nil)
+	var mfaErr *biz.MFARequiredError
+	assert.True(t, errors.As(err, &mfaErr))
+	m.admin.On("AddFailedLogin", ctx, mock.Anything, uint32(2), int32(3), mock.Anything).Return(false, nil).Once()
+	_, err = uc.Login(ctx, "alice", "alice@123", enrollment.RecoveryCodes[0], nil)
+	assert.ErrorIs(t, err, biz.ErrMFACodeIncorrect)
+	code, _ := biz.TOTPCode(user.MFASecret, time.Now())
+	m.admin.On("UseMFAStep", ctx, mock.Anything, uint32(2), mock.Anything).Return(true, nil)
+	m.admin.On("EnableMFA", ctx, mock.Anything, uint32(2), user.MFASecret).Return(nil)
+	_, err = uc.Login(ctx, "alice", "alice@123", code, nil)
+	assert.NoError(t, err)
+	m.admin.AssertCalled(t, "EnableMFA", ctx, mock.Anything, uint32(2), user.MFASecret)
+}
+
+func TestAdminUsecase_LoginMFANotRequired(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("bob@123")
+	user := &repo.User{Id: 3, UserName: "bob", Password: password}
+	uc, m := newMFAUsecase(t, user, true)
+	m.authz.On("ListGroup", ctx, mock.Anything, &repo.GroupFilter{User: "bob"}).
+		Return([]*repo.Group{{User: "bob", Role: "web-team:member"}}, nil)
+
+	_, err := uc.Login(ctx, "bob", "bob@123", "", nil)
+	assert.NoError(t, err)
+	m.admin.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminUsecase_EnrollConfirmDisableMFA(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
+	ctx = context.WithValue(ctx, data.CtxUserId, "2")
+	user := &repo.User{Id: 2, UserName: "alice"}
+	uc, m := newMFAUsecase(t, user, false)
+	m.admin.On("SetMFA", ctx, mock.Anything, uint32(2), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		user.MFASecret = args.String(3)
+	}).Return(nil)
+
+	enrollment, err := uc.EnrollMFA(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, user.MFASecret, enrollment.Secret)
+
+	code, _ := biz.TOTPCode(user.MFASecret, time.Now())
+	assert.ErrorIs(t, uc.ConfirmMFA(ctx, wrongCode(code)), biz.ErrMFACodeIncorrect)
+	m.admin.On("UseMFAStep", ctx, mock.Anything, uint32(2), mock.Anything).Return(true, nil)
+	m.admin.On("EnableMFA", ctx, mock.Anything, uint32(2), user.MFASecret).Return(nil)
+	assert.NoError(t, uc.ConfirmMFA(ctx, code))
+	user.MFAEnabled = true
+
+	_, err = uc.EnrollMFA(ctx, 0)
+	assert.Error(t, err)
+	assert.ErrorIs(t, uc.DisableMFA(ctx, 0, ""), biz.ErrMFACodeIncorrect)
+	assert.NoError(t, uc.DisableMFA(ctx, 2, code))
+	assert.Empty(t, user.MFASecret)
+
+	// others require user admin permission of them, not of the operator's own user
+	m.admin.On("GetUsers", ctx, uint32(3)).Return(&repo.User{Id: 3, UserName: "bob"}, nil)
+	m.authz.On("Enforce", ctx, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Sub == "alice" && r.Resource.ResourceStr() == repo.NewResource4Sv1("users", "", "bob", "bob").ResourceStr()
+	})).Return(false, nil)
+	assert.Error(t, uc.DisableMFA(ctx, 3, ""))
+	_, err = uc.EnrollMFA(ctx, 3)
+	assert.Error(t, err)
+	m.admin.AssertNotCalled(t, "SetMFA", ctx, mock.Anything, uint32(3), mock.Anything, mock.Anything)
+}
@@ -0,0 +1,196 @@
+package biz_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPasswordUsecase(t *testing.T, user *repo.User) (*biz.AdminUsecase, *adminMocks) {
+	return newLocalUserUsecase(t, user, &conf.Admin{
+		AdminPassword:      "admin@123",
+		JwtExpireHours:     1,
+		MaxFailedLogins:    3,
+		LockoutMinutes:     10,
+		PasswordExpireDays: 90,
+	})
+}
+
+// newLocalUserUsecase bootstraps with admin and finds user by name.
+func newLocalUserUsecase(t *testing.T, user *repo.User, c *conf.Admin) (*biz.AdminUsecase, *adminMocks) {
+	admin := user
+	if user.UserName != biz.AdminUser {
+		password, _ := biz.HashPassword("admin@123")
+		admin = &repo.User{Id: 1, UserName: biz.AdminUser, Password: password}
+	}
+	uc, m := newAdminUsecaseWith(t, admin, c, nil, nil)
+	if user != admin {
+		m.admin.On("ListUsers", mock.Anything, mock.Anything, &repo.UsersFilter{UserName: []string{user.UserName}}).
+			Return([]*repo.User{user}, nil)
+	}
+	return uc, m
+}
+
+func TestAdminUsecase_LoginLockout(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password, PasswordChangedAt: time.Now().Unix()}
+	uc, m := newPasswordUsecase(t, user)
+
+	m.admin.On("AddFailedLogin", ctx, mock.Anything, uint32(2), int32(3), mock.Anything).Return(false, nil).Once()
+	_, err := uc.Login(ctx, "alice", "wrong", "", nil)
+	assert.ErrorIs(t, err, biz.ErrPasswordIncorrect)
+
+	// the failure reaching max locks the user
+	m.admin.On("AddFailedLogin", ctx, mock.Anything, uint32(2), int32(3), mock.MatchedBy(func(until int64) bool {
+		return until > time.Now().Add(9*time.Minute).Unix()
+	})).Return(true, nil).Once()
+	_, err = uc.Login(ctx, "alice", "wrong", "", nil)
+	assert.ErrorIs(t, err, biz.ErrPasswordIncorrect)
+
+	// locked users are refused before checking the password
+	user.LockedUntil = time.Now().Add(time.Minute).Unix()
+	_, err = uc.Login(ctx, "alice", "alice@123", "", nil)
+	assert.ErrorIs(t, err, biz.ErrUserLocked)
+	m.admin.AssertNumberOfCalls(t, "AddFailedLogin", 2)
+}
+
+func TestAdminUsecase_LoginPasswordExpired(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password, FailedLogins: 1,
+		PasswordChangedAt: time.Now().Add(-91 * 24 * time.Hour).Unix()}
+	uc, m := newPasswordUsecase(t, user)
+
+	// failures are kept until a login passes all checks
+	_, err := uc.Login(ctx, "alice", "alice@123", "", nil)
+	assert.ErrorIs(t, err, biz.ErrPasswordExpired)
+	m.admin.AssertNotCalled(t, "ResetFailedLogins", ctx, mock.Anything, uint32(2))
+}
+
+func TestAdminUsecase_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password}
+	uc, m := newPasswordUsecase(t, user)
+	m.admin.On("SetPassword", ctx, mock.Anything, uint32(2), mock.MatchedBy(func(hash string) bool {
+		return biz.CheckPassword(hash, "alice@456")
+	}), mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.ChangePassword(ctx, "alice", "alice@123", "alice@456", ""))
+	// same password
+	assert.Error(t, uc.ChangePassword(ctx, "alice", "alice@123", "alice@123", ""))
+	// wrong old password counts as a failed login
+	m.admin.On("AddFailedLogin", ctx, mock.Anything, uint32(2), int32(3), mock.Anything).Return(false, nil).Once()
+	assert.ErrorIs(t, uc.ChangePassword(ctx, "alice", "wrong", "alice@456", ""), biz.ErrPasswordIncorrect)
+	m.admin.AssertNumberOfCalls(t, "SetPassword", 1)
+}
+
+func TestAdminUsecase_ChangePasswordRefused(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("admin@123")
+	uc, _ := newPasswordUsecase(t, &repo.User{Id: 1, UserName: biz.AdminUser, Password: password})
+	// admin password is set by config
+	assert.Error(t, uc.ChangePassword(ctx, biz.AdminUser, "admin@123", "admin@456", ""))
+
+	uc, _ = newPasswordUsecase(t, &repo.User{Id: 3, UserName: "carol", Source: repo.UserSourceLDAP})
+	assert.Error(t, uc.ChangePassword(ctx, "carol", "carol@123", "carol@456", ""))
+}
+
+func TestAdminUsecase_UpdateUsersKeepsPassword(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, biz.AdminUser)
+	password, _ := biz.HashPassword("alice@123")
+	changedAt := time.Now().Add(-24 * time.Hour).Unix()
+	user := &repo.User{Id: 2, UserName: "alice", Password: password, PasswordChangedAt: changedAt}
+	uc, m := newLocalUserUsecase(t, user, &conf.Admin{AdminPassword: "admin@123", StrictPasswordPolicy: true})
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.admin.On("GetUsers", ctx, uint32(2)).Return(user, nil)
+
+	// the stored hash written back, like by a masked update, passes the policy and keeps its time
+	err := uc.UpdateUsers(ctx, []*biz.User{{Id: 2, UserName: "alice", Password: password, Email: "alice@example.com"}})
+	assert.NoError(t, err)
+	m.admin.AssertCalled(t, "UpdateUsers", ctx, mock.Anything, mock.MatchedBy(func(users []*repo.User) bool {
+		return len(users) == 1 && users[0].Password == password && users[0].PasswordChangedAt == changedAt
+	}))
+
+	err = uc.UpdateUsers(ctx, []*biz.User{{Id: 2, UserName: "alice", Password: "alice@456"}})
+	assert.NoError(t, err)
+	m.admin.AssertCalled(t, "UpdateUsers", ctx, mock.Anything, mock.MatchedBy(func(users []*repo.User) bool {
+		return len(users) == 1 && biz.CheckPassword(users[0].Password, "alice@456") &&
+			users[0].PasswordChangedAt > changedAt
+	}))
+}
+
+func TestAdminUsecase_UsersRequireAdmin(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
+	user := &repo.User{Id: 2, UserName: "alice"}
+	uc, m := newLocalUserUsecase(t, user, &conf.Admin{AdminPassword: "admin@123"})
+	// users own the resources of their name, nothing else is granted to alice
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Sub == "alice" && strings.HasSuffix(r.Resource.ResourceStr(), "/alice")
+	})).Return(true, nil)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	err := uc.CreateUsers(ctx, []*biz.User{{UserName: "mallory", Password: "mallory@123"}})
+	assert.ErrorIs(t, err, biz.ErrPermissionDenied)
+	err = uc.UpdateUsers(ctx, []*biz.User{{Id: 2, UserName: "alice", Email: "alice@example.com"}})
+	assert.ErrorIs(t, err, biz.ErrPermissionDenied)
+	err = uc.DeleteUsers(ctx, nil, []uint32{3})
+	assert.ErrorIs(t, err, biz.ErrPermissionDenied)
+	m.admin.AssertNotCalled(t, "CreateUsers", mock.Anything, mock.Anything, mock.Anything)
+	m.admin.AssertNotCalled(t, "UpdateUsers", mock.Anything, mock.Anything, mock.MatchedBy(func(users []*repo.User) bool {
+		return users[0].Id == 2
+	}))
+	m.admin.AssertNotCalled(t, "DeleteUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminUsecase_PasswordResetToken(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, biz.AdminUser)
+	password, _ := biz.HashPassword("alice@123")
+	user := &repo.User{Id: 2, UserName: "alice", Password: password, LockedUntil: time.Now().Add(time.Hour).Unix()}
+	uc, m := newPasswordUsecase(t, user)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.admin.On("GetUsers", mock.Anything, uint32(2)).Return(user, nil)
+
+	var stored *repo.PasswordResetToken
+	m.admin.On("CreatePasswordResetToken", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(2).(*repo.PasswordResetToken)
+	}).Return(nil)
+	token, err := uc.CreatePasswordResetToken(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", token.UserName)
+	assert.NotEmpty(t, token.Token)
+	assert.NotEqual(t, token.Token, stored.Hash)
+	assert.Equal(t, biz.AdminUser, stored.CreatedBy)
+	assert.Greater(t, stored.ExpiresAt, time.Now().Add(23*time.Hour).Unix())
+
+	m.admin.On("UsePasswordResetToken", ctx, mock.Anything, stored.Hash, mock.Anything).Return(stored, nil)
+	m.admin.On("UsePasswordResetToken", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, repo.ErrInvalidResetToken)
+	m.admin.On("SetPassword", ctx, mock.Anything, uint32(2), mock.Anything, mock.Anything).Return(nil)
+	m.sessions.On("DeleteUserSessions", ctx, mock.Anything, []uint32{2}).Return(nil)
+	m.token.On("RevokeUserTokens", ctx, "2").Return(nil)
+
+	assert.NoError(t, uc.ResetPassword(ctx, token.Token, "alice@456"))
+	m.token.AssertCalled(t, "RevokeUserTokens", ctx, "2")
+	assert.ErrorIs(t, uc.ResetPassword(ctx, "unknown", "alice@456"), repo.ErrInvalidResetToken)
+
+	// issuing requires user admin permission of the user, not of the operator's own user
+	ctx = context.WithValue(context.Background(), data.CtxUserName, "bob")
+	uc, m = newPasswordUsecase(t, user)
+	m.admin.On("GetUsers", mock.Anything, uint32(2)).Return(user, nil)
+	m.authz.On("Enforce", ctx, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Sub == "bob" && r.Resource.ResourceStr() == repo.NewResource4Sv1("users", "", "alice", "alice").ResourceStr()
+	})).Return(false, nil)
+	_, err = uc.CreatePasswordResetToken(ctx, 2)
+	assert.Error(t, err)
+	m.admin.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,179 @@
+package biz_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type adminMocks struct {
+	admin    *MockAdminRepo
+	token    *MockTokenRepo
+	authz    *MockAuthzRepo
+	sessions *MockSessionsRepo
+	teams    *MockTeamsRepo
+	members  *MockTeamMembersRepo
+	apps     *MockApplicationsRepo
+}
+
+// newAdminUsecase mocks the bootstrap of admin user, team and rules.
+func newAdminUsecase(t *testing.T, user *repo.User) (*biz.AdminUsecase, *adminMocks) {
+	return newAdminUsecaseWith(t, user,
+		&conf.Admin{AdminPassword: "admin@123", JwtExpireHours: 1, RefreshTokenExpireHours: 1}, nil, nil)
+}
+
+func newAdminUsecaseWith(t *testing.T, user *repo.User, c *conf.Admin, sso repo.SSOProvider, directory repo.DirectoryProvider) (*biz.AdminUsecase, *adminMocks) {
+	m := &adminMocks{
+		admin:    new(MockAdminRepo),
+		token:    new(MockTokenRepo),
+		authz:    new(MockAuthzRepo),
+		sessions: new(MockSessionsRepo),
+		teams:    new(MockTeamsRepo),
+		members:  new(MockTeamMembersRepo),
+		apps:     new(MockApplicationsRepo),
+	}
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.UsersFilter) bool {
+		return len(f.UserName) == 1 && f.UserName[0] == user.UserName
+	})).Return([]*repo.User{user}, nil)
+	m.admin.On("UpdateUsers", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{Names: []string{biz.AdminTeam}}).Return([]*repo.Team{{ID: 1, Name: biz.AdminTeam}}, nil)
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, &repo.Group{User: biz.AdminUser, Role: biz.AdminTeam}).Return(nil)
+	m.authz.On("CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == biz.AdminTeam
+	})).Return(nil)
+
+	uc := biz.NewAdminUsecase(
+		c, m.admin, m.token, m.authz, m.sessions, sso, directory, m.teams, m.members, m.apps, new(MockTXManager), log.DefaultLogger,
+	)
+	return uc, m
+}
+
+func TestAdminUsecase_LoginAndRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	password, _ := biz.HashPassword("admin@123")
+	user := &repo.User{Id: 1, UserName: biz.AdminUser, Password: password}
+	uc, m := newAdminUsecase(t, user)
+
+	var session *repo.Session
+	m.sessions.On("CreateSessions", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		session = args.Get(2).([]*repo.Session)[0]
+		session.Id = 7
+	}).Return(nil)
+	m.token.On("CreateToken", ctx, mock.MatchedBy(func(claims repo.TokenClaims) bool {
+		return claims[string(data.CtxSessionId)] == "7" && claims[string(data.CtxUserId)] == "1"
+	})).Return("access", nil)
+
+	loggedIn, err := uc.Login(ctx, biz.AdminUser, "admin@123", "", &biz.ClientInfo{Device: "cli", IP: "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "access", loggedIn.Token)
+	assert.NotEmpty(t, loggedIn.RefreshToken)
+	assert.Equal(t, "cli", session.Device)
+	assert.Equal(t, "10.0.0.1", session.IP)
+	assert.Empty(t, session.PrevHash)
+	firstHash := session.Hash
+
+	// refresh rotates the refresh token
+	m.sessions.On("ListSessions", ctx, mock.Anything, mock.Anything).Return([]*repo.Session{session}, nil).Once()
+	m.admin.On("GetUsers", ctx, uint32(1)).Return(user, nil)
+	m.sessions.On("RotateSession", ctx, mock.Anything, session, firstHash).Return(true, nil)
+
+	refreshed, err := uc.RefreshToken(ctx, loggedIn.RefreshToken, &biz.ClientInfo{IP: "10.0.0.2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "access", refreshed.Token)
+	assert.NotEqual(t, loggedIn.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, firstHash, session.PrevHash)
+	assert.NotEqual(t, firstHash, session.Hash)
+	assert.Equal(t, "cli", session.Device)
+	assert.Equal(t, "10.0.0.2", session.IP)
+
+	// the replaced refresh token revokes the session
+	m.sessions.On("ListSessions", ctx, mock.Anything, mock.Anything).Return([]*repo.Session{session}, nil).Once()
+	m.sessions.On("DeleteSessions", ctx, mock.Anything, []uint32{7}).Return(nil).Once()
+	_, err = uc.RefreshToken(ctx, loggedIn.RefreshToken, nil)
+	assert.ErrorIs(t, err, biz.ErrReusedRefreshToken)
+	m.sessions.AssertCalled(t, "DeleteSessions", ctx, mock.Anything, []uint32{7})
+}
+
+func TestAdminUsecase_RefreshToken_Race(t *testing.T) {
+	ctx := context.Background()
+	user := &repo.User{Id: 1, UserName: biz.AdminUser}
+	uc, m := newAdminUsecase(t, user)
+
+	// both refreshes read the session before either rotated it
+	refreshToken := "token"
+	sum := sha256.Sum256([]byte(refreshToken))
+	session := &repo.Session{Id: 7, UserId: 1, Hash: hex.EncodeToString(sum[:]), ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	m.sessions.On("ListSessions", ctx, mock.Anything, mock.Anything).Return([]*repo.Session{session}, nil)
+	m.admin.On("GetUsers", ctx, uint32(1)).Return(user, nil)
+	// the other refresh updated the row first, the conditional update matches nothing
+	m.sessions.On("RotateSession", ctx, mock.Anything, session, mock.Anything).Return(false, nil)
+	m.sessions.On("DeleteSessions", ctx, mock.Anything, []uint32{7}).Return(nil)
+
+	_, err := uc.RefreshToken(ctx, refreshToken, nil)
+	assert.ErrorIs(t, err, biz.ErrReusedRefreshToken)
+	m.sessions.AssertCalled(t, "DeleteSessions", ctx, mock.Anything, []uint32{7})
+	m.token.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything)
+}
+
+func TestAdminUsecase_RefreshToken_Invalid(t *testing.T) {
+	ctx := context.Background()
+	uc, m := newAdminUsecase(t, &repo.User{Id: 1, UserName: biz.AdminUser})
+
+	_, err := uc.RefreshToken(ctx, "", nil)
+	assert.ErrorIs(t, err, biz.ErrInvalidRefreshToken)
+
+	m.sessions.On("ListSessions", ctx, mock.Anything, mock.Anything).Return([]*repo.Session{}, nil).Once()
+	_, err = uc.RefreshToken(ctx, "unknown", nil)
+	assert.ErrorIs(t, err, biz.ErrInvalidRefreshToken)
+
+	// expired session is deleted
+	expired := &repo.Session{Id: 3, UserId: 1, ExpiresAt: time.Now().Add(-time.Minute).Unix()}
+	m.sessions.On("ListSessions", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		expired.Hash = args.Get(2).(*repo.SessionsFilter).Hashes[0]
+	}).Return([]*repo.Session{expired}, nil).Once()
+	m.sessions.On("DeleteSessions", ctx, mock.Anything, []uint32{3}).Return(nil).Once()
+	_, err = uc.RefreshToken(ctx, "expired", nil)
+	assert.ErrorIs(t, err, biz.ErrInvalidRefreshToken)
+	m.sessions.AssertExpectations(t)
+}
+
+func TestAdminUsecase_ListSessions(t *testing.T) {
+	uc, m := newAdminUsecase(t, &repo.User{Id: 1, UserName: biz.AdminUser})
+	ctx := context.WithValue(context.Background(), data.CtxUserId, "2")
+	ctx = context.WithValue(ctx, data.CtxUserName, "user2")
+	ctx = context.WithValue(ctx, data.CtxSessionId, "5")
+
+	future := time.Now().Add(time.Hour).Unix()
+	m.sessions.On("ListSessions", ctx, mock.Anything, &repo.SessionsFilter{UserIds: []uint32{2}}).Return([]*repo.Session{
+		{Id: 4, UserId: 2, ExpiresAt: future},
+		{Id: 5, UserId: 2, ExpiresAt: future},
+		{Id: 6, UserId: 2, ExpiresAt: time.Now().Add(-time.Hour).Unix()},
+	}, nil)
+
+	sessions, err := uc.ListSessions(ctx, 0)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+
+	// others sessions require permission of them, users own resources of their name
+	m.admin.On("GetUsers", ctx, uint32(1)).Return(&repo.User{Id: 1, UserName: biz.AdminUser}, nil)
+	m.authz.On("Enforce", ctx, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Sub == "user2" && r.Resource.ResourceStr() == repo.NewResource4Sv1("users", "", biz.AdminUser, biz.AdminUser).ResourceStr()
+	})).Return(false, nil)
+	_, err = uc.ListSessions(ctx, 1)
+	assert.Error(t, err)
+	assert.Error(t, uc.RevokeSessions(ctx, 1))
+	m.token.AssertNotCalled(t, "RevokeUserTokens", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,178 @@
+package biz_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSSOAdminUsecase(t *testing.T) (*biz.AdminUsecase, *adminMocks, *MockSSOProvider) {
+	sso := new(MockSSOProvider)
+	uc, m := newAdminUsecaseWith(t, &repo.User{Id: 1, UserName: biz.AdminUser}, &conf.Admin{
+		AdminPassword: "admin@123", JwtExpireHours: 1,
+		Oidc: &conf.OIDC{
+			Issuer:     "http://idp",
+			GroupRoles: map[string]string{"ops": "ops-team", "ops-leads": "ops-team:maintainer", "dba": "dba-team"},
+		},
+	}, sso, nil)
+	m.sessions.On("CreateSessions", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.token.On("CreateToken", mock.Anything, mock.Anything).Return("access", nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.TeamsFilter) bool {
+		return len(f.Names) == 3
+	})).Return([]*repo.Team{{ID: 2, Name: "ops-team"}, {ID: 3, Name: "dba-team"}}, nil)
+	// roles of teams
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, mock.MatchedBy(func(g *repo.Group) bool {
+		return strings.Contains(g.User, ":")
+	})).Return(nil)
+	m.authz.On("CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return strings.Contains(r.Sub, ":")
+	})).Return(nil)
+	return uc, m, sso
+}
+
+// memberOf matches team members of user.
+func memberOf(userId uint32) interface{} {
+	return mock.MatchedBy(func(f *repo.TeamMembersFilter) bool {
+		return len(f.UserIds) == 1 && f.UserIds[0] == userId && len(f.TeamIds) > 0
+	})
+}
+
+func TestAdminUsecase_SSOAuthURL(t *testing.T) {
+	ctx := context.Background()
+	uc, _, sso := newSSOAdminUsecase(t)
+	sso.On("AuthCodeURL", ctx, "http://127.0.0.1:8080/callback", "s", "n", "c").Return("http://idp/authorize", nil)
+
+	url, err := uc.SSOAuthURL(ctx, "http://127.0.0.1:8080/callback", "s", "n", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://idp/authorize", url)
+
+	// only loopback redirect
+	_, err = uc.SSOAuthURL(ctx, "http://evil.example.com/callback", "s", "n", "c")
+	assert.Error(t, err)
+	_, err = uc.SSOAuthURL(ctx, "https://localhost/callback", "s", "n", "c")
+	assert.Error(t, err)
+	_, err = uc.SSOAuthURL(ctx, "http://127.0.0.1:8080/callback", "s", "n", "")
+	assert.Error(t, err)
+
+	// not configured
+	plain, _ := newAdminUsecase(t, &repo.User{Id: 1, UserName: biz.AdminUser})
+	_, err = plain.SSOAuthURL(ctx, "http://127.0.0.1:8080/callback", "s", "n", "c")
+	assert.ErrorIs(t, err, repo.ErrSSONotConfigured)
+}
+
+func TestAdminUsecase_SSOLogin_Provision(t *testing.T) {
+	ctx := context.Background()
+	uc, m, sso := newSSOAdminUsecase(t)
+	redirect := "http://localhost:8080/callback"
+	sso.On("Exchange", ctx, "code", "verifier", redirect, "nonce").Return(&repo.SSOIdentity{
+		Subject: "sub-1", UserName: "alice", Email: "alice@example.com", Groups: []string{"ops", "other"},
+	}, nil)
+
+	aliceFilter := mock.MatchedBy(func(f *repo.UsersFilter) bool {
+		return len(f.UserName) == 1 && f.UserName[0] == "alice"
+	})
+	m.admin.On("ListUsers", ctx, mock.Anything, aliceFilter).Return([]*repo.User{}, nil).Once()
+	m.admin.On("CreateUsers", ctx, mock.Anything, mock.MatchedBy(func(users []*repo.User) bool {
+		u := users[0]
+		return u.UserName == "alice" && u.Source == repo.UserSourceOIDC && u.ExternalId == "sub-1" &&
+			!biz.CheckPassword(u.Password, "")
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).([]*repo.User)[0].Id = 5
+	}).Return(nil).Once()
+	m.authz.On("CreateRule", ctx, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == "alice"
+	})).Return(nil).Once()
+	// dba-team was synced by sso before, admin-team granted by hand
+	m.members.On("ListTeamMembers", ctx, mock.Anything, memberOf(5)).Return([]*repo.TeamMember{
+		{ID: 10, TeamId: 3, UserId: 5, Role: repo.TeamRoleMember},
+	}, nil)
+	m.authz.On("ListGroup", ctx, mock.Anything, &repo.GroupFilter{User: "alice"}).Return([]*repo.Group{
+		{User: "alice", Role: "dba-team:member"}, {User: "alice", Role: biz.AdminTeam},
+	}, nil)
+	m.authz.On("DeleteGroup", ctx, mock.Anything, &repo.Group{User: "alice", Role: "dba-team:member"}).Return(nil).Once()
+	m.members.On("DeleteTeamMembers", ctx, mock.Anything, []uint32{10}).Return(nil).Once()
+	m.members.On("CreateTeamMembers", ctx, mock.Anything, memberRole(2, 5, repo.TeamRoleMember)).Return(nil).Once()
+	m.authz.On("CreateGroup", ctx, mock.Anything, &repo.Group{User: "alice", Role: "ops-team:member"}).Return(nil).Once()
+
+	user, err := uc.SSOLogin(ctx, "code", "verifier", redirect, "nonce", &biz.ClientInfo{Device: "cli"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), user.Id)
+	assert.Equal(t, "access", user.Token)
+	assert.NotEmpty(t, user.RefreshToken)
+	m.authz.AssertExpectations(t)
+	m.members.AssertExpectations(t)
+	m.authz.AssertNotCalled(t, "DeleteGroup", ctx, mock.Anything, &repo.Group{User: "alice", Role: biz.AdminTeam})
+	// users are grouped into team roles, never into bare team names
+	m.authz.AssertNotCalled(t, "CreateGroup", ctx, mock.Anything, &repo.Group{User: "alice", Role: "ops-team"})
+}
+
+func TestAdminUsecase_SSOLogin_TeamRoles(t *testing.T) {
+	ctx := context.Background()
+	uc, m, sso := newSSOAdminUsecase(t)
+	redirect := "http://localhost:8080/callback"
+	sso.On("Exchange", ctx, "code", "verifier", redirect, "nonce").Return(&repo.SSOIdentity{
+		Subject: "sub-1", UserName: "bob", Groups: []string{"ops", "ops-leads", "dba"},
+	}, nil)
+	m.admin.On("ListUsers", ctx, mock.Anything, userNamed("bob")).
+		Return([]*repo.User{{Id: 6, UserName: "bob", Source: repo.UserSourceOIDC, ExternalId: "sub-1"}}, nil)
+	// bob leads dba-team and was a member of ops-team
+	m.members.On("ListTeamMembers", ctx, mock.Anything, memberOf(6)).Return([]*repo.TeamMember{
+		{ID: 11, TeamId: 2, UserId: 6, Role: repo.TeamRoleMember},
+		{ID: 12, TeamId: 3, UserId: 6, Role: repo.TeamRoleLeader},
+	}, nil)
+	m.authz.On("ListGroup", ctx, mock.Anything, &repo.GroupFilter{User: "bob"}).Return([]*repo.Group{
+		{User: "bob", Role: "ops-team:member"}, {User: "bob", Role: "dba-team:leader"},
+	}, nil)
+	m.authz.On("DeleteGroup", ctx, mock.Anything, &repo.Group{User: "bob", Role: "ops-team:member"}).Return(nil).Once()
+	m.members.On("UpdateTeamMembers", ctx, mock.Anything, []*repo.TeamMember{
+		{ID: 11, TeamId: 2, UserId: 6, Role: repo.TeamRoleMaintainer},
+	}).Return(nil).Once()
+	m.authz.On("CreateGroup", ctx, mock.Anything, &repo.Group{User: "bob", Role: "ops-team:maintainer"}).Return(nil).Once()
+
+	// the highest role of groups of a team wins, leaders keep their role
+	_, err := uc.SSOLogin(ctx, "code", "verifier", redirect, "nonce", nil)
+	assert.NoError(t, err)
+	m.members.AssertExpectations(t)
+	m.authz.AssertNotCalled(t, "DeleteGroup", ctx, mock.Anything, &repo.Group{User: "bob", Role: "dba-team:leader"})
+	m.members.AssertNotCalled(t, "DeleteTeamMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminUsecase_SSOLogin_Existing(t *testing.T) {
+	ctx := context.Background()
+	uc, m, sso := newSSOAdminUsecase(t)
+	redirect := "http://localhost:8080/callback"
+	sso.On("Exchange", ctx, "code", "verifier", redirect, "nonce").Return(&repo.SSOIdentity{
+		Subject: "sub-1", UserName: "bob",
+	}, nil)
+	bobFilter := mock.MatchedBy(func(f *repo.UsersFilter) bool {
+		return len(f.UserName) == 1 && f.UserName[0] == "bob"
+	})
+
+	// local user is never taken over
+	m.admin.On("ListUsers", ctx, mock.Anything, bobFilter).
+		Return([]*repo.User{{Id: 6, UserName: "bob", Source: repo.UserSourceLocal}}, nil).Once()
+	_, err := uc.SSOLogin(ctx, "code", "verifier", redirect, "nonce", nil)
+	assert.Error(t, err)
+
+	// another subject
+	m.admin.On("ListUsers", ctx, mock.Anything, bobFilter).
+		Return([]*repo.User{{Id: 6, UserName: "bob", Source: repo.UserSourceOIDC, ExternalId: "sub-2"}}, nil).Once()
+	_, err = uc.SSOLogin(ctx, "code", "verifier", redirect, "nonce", nil)
+	assert.Error(t, err)
+
+	// returning user, no managed groups
+	m.admin.On("ListUsers", ctx, mock.Anything, bobFilter).
+		Return([]*repo.User{{Id: 6, UserName: "bob", Source: repo.UserSourceOIDC, ExternalId: "sub-1"}}, nil).Once()
+	m.members.On("ListTeamMembers", ctx, mock.Anything, memberOf(6)).Return([]*repo.TeamMember{}, nil)
+	user, err := uc.SSOLogin(ctx, "code", "verifier", redirect, "nonce", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(6), user.Id)
+	m.admin.AssertNotCalled(t, "CreateUsers", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -29,8 +29,10 @@ func TestCreateApp(t *testing.T) {
 	adminrepo := new(MockAdminRepo)
 	usecase := biz.NewApplicationsUsecase(
 		apprepo, atagrepo, afrepo, ahgrepo,
-		prdrepo, teamrepo, ftrepo, tagrepo,
-		hgrepo, hfrepo, authzrepo, adminrepo, nil, txm)
+		prdrepo, teamrepo, new(MockOrganizationsRepo), ftrepo, tagrepo,
+		hgrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), authzrepo, adminrepo, nil, txm)
+	teamrepo.On("GetTeams", mock.Anything, uint32(1)).Return(&repo.Team{ID: 1, Name: "test-team", OrgId: 1}, nil)
+	prdrepo.On("GetProducts", mock.Anything, uint32(1)).Return(&repo.Product{ID: 1, Name: "test-product", OrgId: 1}, nil)
 
 	// 测试字段验证
 	_app := biz.Application{
@@ -341,8 +343,8 @@ func TestUpdateApp(t *testing.T) {
 	adminrepo := new(MockAdminRepo)
 
 	usecase := biz.NewApplicationsUsecase(
-		apprepo, atagrepo, afrepo, ahgrepo, prdrepo, teamrepo, ftrepo, tagrepo,
-		hgrepo, hfrepo, authzrepo, adminrepo, nil, txm)
+		apprepo, atagrepo, afrepo, ahgrepo, prdrepo, teamrepo, new(MockOrganizationsRepo), ftrepo, tagrepo,
+		hgrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), authzrepo, adminrepo, nil, txm)
 
 	// bad field
 	_app := biz.Application{
@@ -388,6 +390,7 @@ func TestUpdateApp(t *testing.T) {
 	ctx = context.WithValue(ctx, data.CtxUserName, "demouser")
 	// validate enforce
 	teamrepo.On("GetTeams", ctx, mock.Anything).Return(&repo.Team{ID: 1, Name: "test-team"}, nil)
+	prdrepo.On("GetProducts", ctx, mock.Anything).Return(&repo.Product{ID: 1, Name: "test-product"}, nil)
 	adminrepo.On("GetUsers", ctx, mock.Anything, mock.Anything).Return(&repo.User{Id: 10, UserName: "demouser"}, nil)
 
 	efcall := authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(false, nil)
@@ -505,8 +508,8 @@ func TestAppHandleM2MProps(t *testing.T) {
 
 	usecase := biz.NewApplicationsUsecase(
 		apprepo, atagrepo, afrepo, ahgrepo,
-		prdrepo, teamrepo, ftrepo, tagrepo,
-		hgrepo, hfrepo, authzrepo, adminrepo, nil, txm)
+		prdrepo, teamrepo, new(MockOrganizationsRepo), ftrepo, tagrepo,
+		hgrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), authzrepo, adminrepo, nil, txm)
 
 	// app-tag
 	atagFilter := &repo.AppTagsFilter{
@@ -587,8 +590,8 @@ func TestDeleteApplications(t *testing.T) {
 	adminrepo := new(MockAdminRepo)
 
 	usecase := biz.NewApplicationsUsecase(
-		apprepo, atagrepo, afrepo, ahgrepo, prdrepo, teamrepo, ftrepo, tagrepo,
-		hgrepo, hfrepo, authzrepo, adminrepo, nil, txm)
+		apprepo, atagrepo, afrepo, ahgrepo, prdrepo, teamrepo, new(MockOrganizationsRepo), ftrepo, tagrepo,
+		hgrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), authzrepo, adminrepo, nil, txm)
 
 	ids := []uint32{1, 2}
 
@@ -643,7 +646,7 @@ func TestDeleteApplications(t *testing.T) {
 }
 
 func TestListApplications(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	txm := new(MockTXManager)
 	txm.On("RunInTX", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		fn := args.Get(0).(func(repo.TX) error)
@@ -665,8 +668,8 @@ func TestListApplications(t *testing.T) {
 
 	usecase := biz.NewApplicationsUsecase(
 		apprepo, atagrepo, afrepo, ahgrepo,
-		prdrepo, teamrepo, ftrepo, tagrepo,
-		hgrepo, hfrepo, authzrepo, adminrepo, nil, txm)
+		prdrepo, teamrepo, new(MockOrganizationsRepo), ftrepo, tagrepo,
+		hgrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), authzrepo, adminrepo, nil, txm)
 
 	// Empty filter
 	//filter := &biz.ListApplicationsFilter{}
@@ -754,7 +757,21 @@ func TestListApplications(t *testing.T) {
 		{Id: 1, AppID: 1, HostgroupID: 1},
 		{Id: 2, AppID: 1, HostgroupID: 2},
 	}, nil)
-	apps, err := usecase.ListApplications(ctx, nil)
+	teamrepo.On("GetTeams", ctx, uint32(1)).Return(&repo.Team{ID: 1, Name: "team1"}, nil)
+	teamrepo.On("GetTeams", ctx, uint32(2)).Return(&repo.Team{ID: 2, Name: "team2"}, nil)
+	adminrepo.On("GetUsers", ctx, uint32(10)).Return(&repo.User{Id: 10, UserName: "owner"}, nil)
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.MatchedBy(func(reqs []*repo.AuthenRequest) bool {
+		return len(reqs) == 2 && reqs[0].Action == repo.ActRead &&
+			reqs[0].Resource.ResourceStr() == "v1/default/applications/team1/app1/owner"
+	})).Return([]bool{false, true}, nil).Once()
+	// only readable applications are listed
+	apps, _, err := usecase.ListApplications(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(apps))
+	assert.Equal(t, "app2", apps[0].Name)
+
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{true, true}, nil)
+	apps, _, err = usecase.ListApplications(ctx, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(apps))
 	assert.Equal(t, biz_apps[0], apps[0])
@@ -771,7 +788,7 @@ func TestListApplications(t *testing.T) {
 	// app-tag filter nil
 	atagcall.Unset()
 	atagrepo.On("ListAppTags", ctx, mock.Anything, mock.Anything).Return([]*repo.AppTag{}, nil)
-	_, err = usecase.ListApplications(ctx, filter)
+	_, _, err = usecase.ListApplications(ctx, filter)
 	t.Logf("err: %v", err)
 	assert.Error(t, err)
 
@@ -783,7 +800,7 @@ func TestListApplications(t *testing.T) {
 	}, nil)
 	afcall.Unset()
 	afcall = afrepo.On("ListAppFeatures", ctx, mock.Anything, mock.Anything).Return([]*repo.AppFeature{}, nil)
-	_, err = usecase.ListApplications(ctx, filter)
+	_, _, err = usecase.ListApplications(ctx, filter)
 	t.Logf("err: %v", err)
 	assert.Error(t, err)
 
@@ -795,7 +812,7 @@ func TestListApplications(t *testing.T) {
 	}, nil)
 	ahcall.Unset()
 	ahgrepo.On("ListAppHostgroups", ctx, mock.Anything, mock.Anything).Return([]*repo.AppHostgroup{}, nil)
-	_, err = usecase.ListApplications(ctx, filter)
+	_, _, err = usecase.ListApplications(ctx, filter)
 	t.Logf("err: %v", err)
 	assert.Error(t, err)
 
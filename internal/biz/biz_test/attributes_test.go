@@ -0,0 +1,144 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAttribute_Validate(t *testing.T) {
+	valid := func() *biz.Attribute {
+		return &biz.Attribute{Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeString}
+	}
+	assert.NoError(t, valid().Validate(true))
+	assert.Error(t, valid().Validate(false))
+
+	a := valid()
+	a.Kind = "clusters"
+	assert.Error(t, a.Validate(true))
+	a = valid()
+	a.Name = "Tier"
+	assert.Error(t, a.Validate(true))
+	a = valid()
+	a.Type = "float"
+	assert.Error(t, a.Validate(true))
+	a = valid()
+	a.Pattern = "(["
+	assert.Error(t, a.Validate(true))
+	a = valid()
+	a.EnumValues = []string{"gold"}
+	assert.Error(t, a.Validate(true))
+
+	a = valid()
+	a.Type = repo.AttrTypeEnum
+	assert.Error(t, a.Validate(true))
+	a.EnumValues = []string{"gold,silver"}
+	assert.Error(t, a.Validate(true))
+	a.EnumValues = []string{"gold", "silver"}
+	assert.NoError(t, a.Validate(true))
+}
+
+func TestValidateAttributeValues(t *testing.T) {
+	attrs := []*biz.Attribute{
+		{Name: "tier", Type: repo.AttrTypeEnum, EnumValues: []string{"gold", "silver"}, Required: true},
+		{Name: "replicas", Type: repo.AttrTypeInt},
+		{Name: "public", Type: repo.AttrTypeBool},
+		{Name: "repo", Type: repo.AttrTypeString, Pattern: `https://\S+`},
+	}
+	assert.NoError(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "gold"}))
+	assert.NoError(t, biz.ValidateAttributeValues(attrs, map[string]string{
+		"tier": "silver", "replicas": "3", "public": "false", "repo": "https://git.example.com/app"}))
+
+	// required
+	assert.ErrorContains(t, biz.ValidateAttributeValues(attrs, nil), "required")
+	// unknown
+	assert.ErrorContains(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "gold", "x": "1"}), "unknown")
+	// types
+	assert.Error(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "bronze"}))
+	assert.Error(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "gold", "replicas": "three"}))
+	assert.Error(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "gold", "public": "yes"}))
+	assert.Error(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "gold", "replicas": ""}))
+	// the pattern matches whole values
+	assert.Error(t, biz.ValidateAttributeValues(attrs, map[string]string{"tier": "gold", "repo": "see https://x"}))
+}
+
+func TestAttributesUsecase_CreateAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	attrs := new(MockAttributesRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewAttributesUsecase(attrs, authz, log.DefaultLogger, new(MockTXManager))
+	tier := &biz.Attribute{Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeEnum,
+		EnumValues: []string{"gold", "silver"}}
+
+	// admin only
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	assert.Error(t, uc.CreateAttributes(ctx, []*biz.Attribute{tier}))
+	attrs.AssertNotCalled(t, "CreateAttributes", mock.Anything, mock.Anything, mock.Anything)
+
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Resource.ResourceStr() == repo.NewResource4Sv1("attributes", "", "", "").ResourceStr() &&
+			r.Action == repo.ActWrite
+	})).Return(true, nil)
+	attrs.On("CreateAttributes", mock.Anything, mock.Anything, []*repo.Attribute{{
+		Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeEnum, EnumValues: "gold,silver"}}).Return(nil)
+	assert.NoError(t, uc.CreateAttributes(ctx, []*biz.Attribute{tier}))
+}
+
+func TestAttributesUsecase_UpdateAndDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	attrs := new(MockAttributesRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewAttributesUsecase(attrs, authz, log.DefaultLogger, new(MockTXManager))
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	old := &repo.Attribute{ID: 1, Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeString}
+	attrs.On("GetAttributes", mock.Anything, uint32(1)).Return(old, nil)
+
+	// values refer to kind and name
+	assert.Error(t, uc.UpdateAttributes(ctx, []*biz.Attribute{
+		{Id: 1, Kind: repo.AttrKindHostgroup, Name: "tier", Type: repo.AttrTypeString}}))
+	attrs.On("UpdateAttributes", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	assert.NoError(t, uc.UpdateAttributes(ctx, []*biz.Attribute{
+		{Id: 1, Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeString, Required: true}}))
+
+	// values of deleted attributes are deleted
+	attrs.On("ListAttributes", mock.Anything, mock.Anything, &repo.AttributesFilter{Ids: []uint32{1}}).
+		Return([]*repo.Attribute{old}, nil)
+	attrs.On("DeleteAttributes", mock.Anything, mock.Anything, []uint32{1}).Return(nil)
+	attrs.On("DeleteAttributeValues", mock.Anything, mock.Anything, &repo.AttributeValuesFilter{
+		Kind: repo.AttrKindApplication, Names: []string{"tier"}}).Return(nil)
+	assert.NoError(t, uc.DeleteAttributes(ctx, []uint32{1}))
+	attrs.AssertExpectations(t)
+}
+
+func TestAppAttributes_Validate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	attrs := new(MockAttributesRepo)
+	attrs.On("ListAttributes", mock.Anything, mock.Anything, &repo.AttributesFilter{
+		Kinds: []string{repo.AttrKindApplication}}).Return([]*repo.Attribute{
+		{Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeEnum, EnumValues: "gold,silver", Required: true}}, nil)
+	apprepo := new(MockApplicationsRepo)
+	usecase := biz.NewApplicationsUsecase(
+		apprepo, new(MockAppTagsRepo), new(MockAppFeaturesRepo), new(MockAppHostgroupsRepo),
+		new(MockProductsRepo), new(MockTeamsRepo), new(MockOrganizationsRepo), new(MockFeaturesRepo), new(MockTagsRepo),
+		new(MockHostgroupsRepo), new(MockHostgroupFeaturesRepo), attrs, newNoTagKeysRepo(), new(MockAuthzRepo), new(MockAdminRepo), nil,
+		new(MockTXManager))
+	app := func(values map[string]string) []*biz.Application {
+		return []*biz.Application{{Name: "web", OwnerId: 1, ProductId: 1, TeamId: 1, Attributes: values}}
+	}
+
+	assert.ErrorContains(t, usecase.CreateApplications(ctx, app(nil)), "tier is required")
+	assert.ErrorContains(t, usecase.CreateApplications(ctx, app(map[string]string{"tier": "bronze"})), "not in")
+	apprepo.AssertNotCalled(t, "CreateApplications", mock.Anything, mock.Anything, mock.Anything)
+
+	// filters are name:value
+	_, _, err := usecase.ListApplications(ctx, &biz.ListApplicationsFilter{Page: 1, PageSize: 10,
+		Attributes: []string{"tier"}})
+	assert.ErrorIs(t, err, biz.ErrFilterKVInvalid)
+}
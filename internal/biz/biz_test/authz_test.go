@@ -0,0 +1,299 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type authzMocks struct {
+	authz  *MockAuthzRepo
+	audits *MockAuthzAuditsRepo
+	admin  *MockAdminRepo
+	sas    *MockServiceAccountsRepo
+	teams  *MockTeamsRepo
+	hgs    *MockHostgroupsRepo
+	apps   *MockApplicationsRepo
+}
+
+func newAuthzUsecase(t *testing.T) (*biz.AuthzUsecase, *authzMocks) {
+	m := &authzMocks{
+		authz:  new(MockAuthzRepo),
+		audits: new(MockAuthzAuditsRepo),
+		admin:  new(MockAdminRepo),
+		sas:    new(MockServiceAccountsRepo),
+		teams:  new(MockTeamsRepo),
+		hgs:    new(MockHostgroupsRepo),
+		apps:   new(MockApplicationsRepo),
+	}
+	publicRules := make([]*repo.Rule, 0, len(biz.DefaultPublicKinds))
+	for _, k := range biz.DefaultPublicKinds {
+		publicRules = append(publicRules, &repo.Rule{
+			Sub: repo.SubEveryone, Resource: repo.NewResource4Sv1(k, "", "", ""), Action: repo.ActRead})
+	}
+	m.authz.On("ListRule", mock.Anything, mock.Anything, &repo.RuleFilter{Sub: repo.SubEveryone}).
+		Return(publicRules, nil).Once()
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{}).Return([]*repo.Team{}, nil).Once()
+	m.authz.On("ListRule", mock.Anything, mock.Anything, &repo.RuleFilter{}).Return(publicRules, nil).Once()
+	uc, err := biz.NewAuthzUsecase(&conf.Authz{}, m.authz, m.audits, m.admin, m.sas, m.teams, new(MockOrganizationsRepo), m.hgs, m.apps,
+		new(MockTXManager), log.DefaultLogger)
+	assert.NoError(t, err)
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, userNamed("alice")).
+		Return([]*repo.User{{Id: 2, UserName: "alice"}}, nil)
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.User{}, nil)
+	return uc, m
+}
+
+func TestRule_Validate(t *testing.T) {
+	r := &biz.Rule{Sub: "dev-team", ResType: "applications", Team: "dev-team", Action: repo.ActWrite}
+	assert.NoError(t, r.Validate())
+
+	r.Sub = "sa:deployer"
+	assert.NoError(t, r.Validate())
+
+	// sections can not inject casbin patterns
+	r.Team = "{team}"
+	assert.Error(t, r.Validate())
+	r.Team = "dev/team"
+	assert.Error(t, r.Validate())
+	r.Team = ""
+	r.ResType = "unknown"
+	assert.Error(t, r.Validate())
+	r.ResType = ""
+	r.Action = repo.ActRead
+	assert.NoError(t, r.Validate())
+	r.Action = "delete"
+	assert.Error(t, r.Validate())
+}
+
+func TestAuthzUsecase_GrantRules(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	uc, m := newAuthzUsecase(t)
+	rule := &biz.Rule{Sub: "dev-team", ResType: "applications", Team: "dev-team", Action: repo.ActWrite}
+
+	// admin only
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	assert.Error(t, uc.GrantRules(ctx, []*biz.Rule{rule}))
+
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.authz.On("CreateRule", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	var audits []*repo.AuthzAudit
+	m.audits.On("CreateAuthzAudits", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { audits = append(audits, args.Get(2).([]*repo.AuthzAudit)...) }).Return(nil)
+	assert.NoError(t, uc.GrantRules(ctx, []*biz.Rule{rule}))
+	if assert.Len(t, audits, 1) {
+		assert.Equal(t, "admin", audits[0].Operator)
+		assert.Equal(t, repo.AuthzOpGrant, audits[0].Operation)
+		assert.Equal(t, "v1/{org}/applications/dev-team/{resource_id}/{user}", audits[0].Resource)
+	}
+}
+
+func TestAuthzUsecase_Lockout(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	uc, m := newAuthzUsecase(t)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	err := uc.RevokeRules(ctx, []*biz.Rule{{Sub: biz.AdminTeam, Action: repo.ActWrite}})
+	assert.ErrorIs(t, err, biz.ErrAdminLockout)
+	err = uc.RemoveGroups(ctx, []*biz.Group{{User: biz.AdminUser, Role: biz.AdminTeam}})
+	assert.ErrorIs(t, err, biz.ErrAdminLockout)
+	m.authz.AssertNotCalled(t, "DeleteRule", mock.Anything, mock.Anything, mock.Anything)
+	m.authz.AssertNotCalled(t, "DeleteGroup", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthzUsecase_AddGroups(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	uc, m := newAuthzUsecase(t)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{Names: []string{"dev-team"}, OrgIds: []uint32{repo.DefaultOrgId}}).
+		Return([]*repo.Team{{ID: 2, Name: "dev-team"}}, nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Team{}, nil)
+	m.authz.On("ListRule", mock.Anything, mock.Anything, &repo.RuleFilter{Sub: "deployers"}).
+		Return([]*repo.Rule{{Sub: "deployers", Resource: repo.NewResource4Sv1("hostgroups", "", "", ""), Action: repo.ActWrite}}, nil)
+	m.authz.On("ListRule", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Rule{}, nil)
+	m.sas.On("ListServiceAccounts", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*repo.ServiceAccount{{ID: 1, Name: "deployer"}}, nil)
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.audits.On("CreateAuthzAudits", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "dev-team:maintainer"}}))
+	assert.NoError(t, uc.AddGroups(ctx, []*biz.Group{{User: "sa:deployer", Role: "deployers"}}))
+
+	// unknown user
+	assert.Error(t, uc.AddGroups(ctx, []*biz.Group{{User: "bob", Role: "dev-team:member"}}))
+	// role is neither a role of a team nor has rules
+	assert.Error(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "nobody"}}))
+	assert.Error(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "nobody:member"}}))
+	assert.Error(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "dev-team:owner"}}))
+	m.authz.AssertNumberOfCalls(t, "CreateGroup", 2)
+}
+
+func TestAuthzUsecase_AddGroupsBareTeam(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	uc, m := newAuthzUsecase(t)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{Names: []string{"dev-team"}, OrgIds: []uint32{repo.DefaultOrgId}}).
+		Return([]*repo.Team{{ID: 2, Name: "dev-team"}}, nil)
+	m.authz.On("ListRule", mock.Anything, mock.Anything, &repo.RuleFilter{Sub: biz.AdminTeam}).
+		Return([]*repo.Rule{{Sub: biz.AdminTeam, Resource: repo.NewResource4Sv1("", "", "", ""), Action: repo.ActWrite}}, nil)
+	m.authz.On("ListRule", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Rule{}, nil)
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.audits.On("CreateAuthzAudits", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// a bare team grants nothing to its members, they join by the team members api
+	err := uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "dev-team"}})
+	var e *biz.Error
+	assert.ErrorAs(t, err, &e)
+	assert.Equal(t, biz.KindInvalidArgument, e.Kind)
+	assert.Contains(t, e.Message, "team add-member")
+	m.authz.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything)
+
+	// teams with rules of their own are subjects of rules
+	assert.NoError(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: biz.AdminTeam}}))
+}
+
+func TestNewAuthzUsecase_SyncPublicKinds(t *testing.T) {
+	authz := new(MockAuthzRepo)
+	audits := new(MockAuthzAuditsRepo)
+	stale := &repo.Rule{Sub: repo.SubEveryone, Resource: repo.NewResource4Sv1("hostgroups", "", "", ""), Action: repo.ActRead}
+	kept := &repo.Rule{Sub: repo.SubEveryone, Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead}
+	authz.On("ListRule", mock.Anything, mock.Anything, &repo.RuleFilter{Sub: repo.SubEveryone}).
+		Return([]*repo.Rule{stale, kept}, nil)
+	authz.On("DeleteRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Resource.ResourceStr() == "v1/{org}/hostgroups/{team}/{resource_id}/{user}"
+	})).Return(nil).Once()
+	authz.On("CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == repo.SubEveryone && r.Action == repo.ActRead &&
+			r.Resource.ResourceStr() == "v1/{org}/tags/{team}/{resource_id}/{user}"
+	})).Return(nil).Once()
+	audits.On("CreateAuthzAudits", mock.Anything, mock.Anything, mock.MatchedBy(func(a []*repo.AuthzAudit) bool {
+		return len(a) == 1 && a[0].Operator == "config"
+	})).Return(nil).Twice()
+
+	// members of teams created before the read action get the read rule
+	teams := new(MockTeamsRepo)
+	teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{}).
+		Return([]*repo.Team{{ID: 2, Name: "dev-team"}, {ID: 3, Name: "ops-team"}}, nil)
+	authz.On("ListRule", mock.Anything, mock.Anything, &repo.RuleFilter{}).Return([]*repo.Rule{
+		{Sub: "ops-team:member", Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "", "ops-team", "", ""), Action: repo.ActRead},
+	}, nil)
+	authz.On("CreateRule", mock.Anything, mock.Anything, &repo.Rule{
+		Sub: "dev-team:member", Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "", "dev-team", "", ""), Action: repo.ActRead,
+	}).Return(nil).Once()
+
+	_, err := biz.NewAuthzUsecase(&conf.Authz{PublicKinds: []string{"envs", "tags"}}, authz, audits,
+		new(MockAdminRepo), new(MockServiceAccountsRepo), teams, new(MockOrganizationsRepo), new(MockHostgroupsRepo), new(MockApplicationsRepo),
+		new(MockTXManager), log.DefaultLogger)
+	assert.NoError(t, err)
+	authz.AssertExpectations(t)
+	audits.AssertExpectations(t)
+
+	_, err = biz.NewAuthzUsecase(&conf.Authz{PublicKinds: []string{"unknown"}}, authz, audits,
+		new(MockAdminRepo), new(MockServiceAccountsRepo), new(MockTeamsRepo), new(MockOrganizationsRepo), new(MockHostgroupsRepo), new(MockApplicationsRepo),
+		new(MockTXManager), log.DefaultLogger)
+	assert.Error(t, err)
+}
+
+func TestAuthzUsecase_AddGroupsOverlappingTeams(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	uc, m := newAuthzUsecase(t)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	// names of teams are matched as substrings by the repo
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{Names: []string{"sre"}, OrgIds: []uint32{repo.DefaultOrgId}}).
+		Return([]*repo.Team{{ID: 2, Name: "sre"}, {ID: 3, Name: "sre-oncall"}}, nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{Names: []string{"oncall"}, OrgIds: []uint32{repo.DefaultOrgId}}).
+		Return([]*repo.Team{{ID: 3, Name: "sre-oncall"}}, nil)
+	m.authz.On("ListRule", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Rule{}, nil)
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.audits.On("CreateAuthzAudits", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "sre:member"}}))
+	// a part of a team name is not the team
+	assert.Error(t, uc.AddGroups(ctx, []*biz.Group{{User: "alice", Role: "oncall:member"}}))
+	m.authz.AssertNumberOfCalls(t, "CreateGroup", 1)
+}
+
+func TestAuthzUsecase_CanI(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
+	uc, m := newAuthzUsecase(t)
+	m.hgs.On("ListHostgroups", mock.Anything, mock.Anything, &repo.HostgroupsFilter{Names: []string{"web-01"}, NameMatch: repo.NameMatchExact}).
+		Return([]*repo.Hostgroup{{Id: 1, Name: "web-01", TeamId: 2}}, nil)
+	m.hgs.On("ListHostgroups", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Hostgroup{}, nil)
+	m.teams.On("GetTeams", mock.Anything, uint32(2)).Return(&repo.Team{ID: 2, Name: "web", LeaderId: 3}, nil)
+	m.admin.On("GetUsers", mock.Anything, uint32(3)).Return(&repo.User{Id: 3, UserName: "lead"}, nil)
+	m.authz.On("Explain", mock.Anything, mock.Anything, &repo.AuthenRequest{
+		Sub:      "alice",
+		Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "hostgroups", "web", "web-01", "lead"),
+		Action:   repo.ActWrite,
+	}).Return(&repo.Explanation{
+		Allowed: true,
+		Rule:    &repo.Rule{Sub: "web:maintainer", Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "", "web", "", ""), Action: repo.ActWrite},
+		Chain:   []string{"alice", "web:maintainer"},
+		Roles:   []string{"web:maintainer", "web:member"},
+	}, nil)
+
+	answer, err := uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActWrite, Kind: "hostgroups", Name: "web-01"})
+	assert.NoError(t, err)
+	assert.True(t, answer.Allowed)
+	assert.Equal(t, "alice", answer.Sub)
+	assert.Equal(t, "v1/default/hostgroups/web/web-01/lead", answer.Resource)
+	assert.Equal(t, "web:maintainer", answer.Rule.Sub)
+	assert.Equal(t, "web", answer.Rule.Team)
+	assert.Equal(t, []string{"alice", "web:maintainer"}, answer.Chain)
+
+	// unknown hostgroup
+	_, err = uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActWrite, Kind: "hostgroups", Name: "web-02"})
+	assert.Error(t, err)
+	// invalid kind
+	_, err = uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActWrite, Kind: "hosts"})
+	assert.Error(t, err)
+
+	// asking as another user requires admin
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	_, err = uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActRead, Kind: "envs", As: "bob"})
+	assert.Error(t, err)
+	m.authz.AssertNotCalled(t, "Explain", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Sub == "bob"
+	}))
+}
+
+func TestAuthzUsecase_CanIExactNames(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
+	uc, m := newAuthzUsecase(t)
+	web := &repo.Hostgroup{Id: 1, Name: "web", TeamId: 2}
+	// names matched as substrings find web-db too
+	m.hgs.On("ListHostgroups", mock.Anything, mock.Anything, &repo.HostgroupsFilter{Names: []string{"web"}, NameMatch: repo.NameMatchExact}).
+		Return([]*repo.Hostgroup{web}, nil)
+	m.hgs.On("ListHostgroups", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*repo.Hostgroup{web, {Id: 2, Name: "web-db", TeamId: 3}}, nil)
+	m.apps.On("ListApplications", mock.Anything, mock.Anything, &repo.ApplicationsFilter{Names: []string{"web"}, NameMatch: repo.NameMatchExact}).
+		Return([]*repo.Application{{Id: 1, Name: "web", TeamId: 2, OwnerId: 3}}, nil)
+	m.apps.On("ListApplications", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*repo.Application{{Id: 1, Name: "web", TeamId: 2, OwnerId: 3}, {Id: 2, Name: "web-db", TeamId: 3, OwnerId: 3}}, nil)
+	m.teams.On("ListTeams", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.TeamsFilter) bool {
+		return len(f.Names) == 1 && f.Names[0] == "sre"
+	})).Return([]*repo.Team{{ID: 2, Name: "sre-oncall", LeaderId: 3}, {ID: 3, Name: "sre", LeaderId: 3}}, nil)
+	m.teams.On("GetTeams", mock.Anything, uint32(2)).Return(&repo.Team{ID: 2, Name: "sre-oncall", LeaderId: 3}, nil)
+	m.teams.On("GetTeams", mock.Anything, uint32(3)).Return(&repo.Team{ID: 3, Name: "sre", LeaderId: 3}, nil)
+	m.admin.On("GetUsers", mock.Anything, uint32(3)).Return(&repo.User{Id: 3, UserName: "lead"}, nil)
+	m.authz.On("Explain", mock.Anything, mock.Anything, mock.Anything).Return(&repo.Explanation{Allowed: true}, nil)
+
+	answer, err := uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActWrite, Kind: "hostgroups", Name: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1/default/hostgroups/sre-oncall/web/lead", answer.Resource)
+	answer, err = uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActWrite, Kind: "applications", Name: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1/default/applications/sre-oncall/web/lead", answer.Resource)
+	// the team named, not the one containing its name
+	answer, err = uc.CanI(ctx, &biz.CanIRequest{Action: repo.ActWrite, Kind: "team", Name: "sre"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1/default/team/sre/members/lead", answer.Resource)
+}
@@ -34,6 +34,31 @@ func TestDiffSliceUint32(t *testing.T) {
 	}
 }
 
+func TestEditSliceUint32(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        []uint32
+		add      []uint32
+		remove   []uint32
+		expected []uint32
+	}{
+		{"Nothing", nil, nil, nil, []uint32{}},
+		{"Add", []uint32{1, 2}, []uint32{3}, nil, []uint32{1, 2, 3}},
+		{"AddExisting", []uint32{1, 2}, []uint32{2, 3, 3}, nil, []uint32{1, 2, 3}},
+		{"Remove", []uint32{1, 2}, nil, []uint32{1, 4}, []uint32{2}},
+		{"RemoveAdded", []uint32{1}, []uint32{2}, []uint32{2}, []uint32{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := biz.EditSliceUint32(tt.s, tt.add, tt.remove)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("EditSliceUint32(%v, %v, %v) = %v, want %v", tt.s, tt.add, tt.remove, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIntersectSliceUint32(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,141 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const listHostgroups = "/api.opspillar.v1.Hostgroups/ListHostgroups"
+
+func newBreakGlassUsecase(t *testing.T) (*biz.BreakGlassUsecase, *MockBreakGlassRepo, *MockAdminRepo, *MockAuthzRepo) {
+	creds := new(MockBreakGlassRepo)
+	admin := new(MockAdminRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewBreakGlassUsecase(creds, admin, authz, new(MockTXManager), log.DefaultLogger)
+	admin.On("ListUsers", mock.Anything, mock.Anything, userNamed("sre")).
+		Return([]*repo.User{{Id: 3, UserName: "sre"}}, nil)
+	admin.On("ListUsers", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.User{}, nil)
+	return uc, creds, admin, authz
+}
+
+func TestBreakGlass_Validate(t *testing.T) {
+	bg := &biz.BreakGlass{Name: "oncall", Principal: "sre", OneTime: true}
+	assert.NoError(t, bg.Validate())
+
+	// never expiring credential
+	bg.OneTime = false
+	assert.Error(t, bg.Validate())
+	bg.ExpireMinutes = 30
+	assert.NoError(t, bg.Validate())
+	bg.Principal = ""
+	assert.Error(t, bg.Validate())
+}
+
+func TestBreakGlassUsecase_Create(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	uc, creds, _, authz := newBreakGlassUsecase(t)
+
+	// admin only
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	_, err := uc.CreateBreakGlass(ctx, &biz.BreakGlass{Name: "oncall", Principal: "sre", OneTime: true})
+	assert.Error(t, err)
+
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	_, err = uc.CreateBreakGlass(ctx, &biz.BreakGlass{Name: "oncall", Principal: "nobody", OneTime: true})
+	assert.Error(t, err)
+
+	var stored *repo.BreakGlass
+	creds.On("CreateBreakGlass", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(2).([]*repo.BreakGlass)[0] }).Return(nil)
+	created, err := uc.CreateBreakGlass(ctx, &biz.BreakGlass{Name: "oncall", Principal: "sre", ExpireMinutes: 30, ExpireDays: 1})
+	assert.NoError(t, err)
+	assert.Contains(t, created.Secret, biz.BreakGlassPrefix)
+	assert.Equal(t, created.Secret[:len(stored.Prefix)], stored.Prefix)
+	assert.NotContains(t, stored.Hash, created.Secret)
+	assert.Equal(t, "user", stored.CreatedBy)
+	assert.Greater(t, stored.ExpiresAt, time.Now().Unix())
+}
+
+func TestBreakGlassUsecase_Authenticate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	uc, creds, _, authz := newBreakGlassUsecase(t)
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	var stored *repo.BreakGlass
+	creds.On("CreateBreakGlass", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(2).([]*repo.BreakGlass)[0] }).Return(nil)
+	created, err := uc.CreateBreakGlass(ctx, &biz.BreakGlass{Name: "oncall", Principal: "sre", OneTime: true})
+	assert.NoError(t, err)
+	stored.ID = 1
+
+	var audits []*repo.BreakGlassAudit
+	creds.On("CreateBreakGlassAudits", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { audits = append(audits, args.Get(2).([]*repo.BreakGlassAudit)...) }).Return(nil)
+	creds.On("ListBreakGlass", mock.Anything, mock.Anything, &repo.BreakGlassFilter{Hashes: []string{stored.Hash}}).
+		Return([]*repo.BreakGlass{stored}, nil)
+	creds.On("ListBreakGlass", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.BreakGlass{}, nil)
+	creds.On("ActivateBreakGlass", mock.Anything, mock.Anything, uint32(1), mock.Anything).
+		Run(func(args mock.Arguments) { stored.FirstUsedAt = args.Get(3).(int64) }).Return(true, nil).Once()
+
+	name, id, err := uc.AuthenticateBreakGlass(context.Background(), created.Secret, listHostgroups, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sre", name)
+	assert.Equal(t, uint32(3), id)
+
+	// one-time credential is used
+	_, _, err = uc.AuthenticateBreakGlass(context.Background(), created.Secret, listHostgroups, "10.0.0.2")
+	assert.ErrorIs(t, err, repo.ErrExpiredBreakGlass)
+
+	if assert.Len(t, audits, 2) {
+		assert.True(t, audits[0].Allowed)
+		assert.Equal(t, "10.0.0.1", audits[0].IP)
+		assert.Equal(t, listHostgroups, audits[0].Operation)
+		assert.False(t, audits[1].Allowed)
+		assert.NotEmpty(t, audits[1].Reason)
+	}
+
+	// unknown credentials are not audited
+	_, _, err = uc.AuthenticateBreakGlass(context.Background(), biz.BreakGlassPrefix+"unknown", listHostgroups, "10.0.0.3")
+	assert.ErrorIs(t, err, biz.ErrInvalidBreakGlass)
+	_, _, err = uc.AuthenticateBreakGlass(context.Background(), "not-a-credential", listHostgroups, "10.0.0.3")
+	assert.ErrorIs(t, err, biz.ErrInvalidBreakGlass)
+	assert.Len(t, audits, 2)
+}
+
+func TestBreakGlassUsecase_AuthenticateExpired(t *testing.T) {
+	uc, creds, _, _ := newBreakGlassUsecase(t)
+	now := time.Now().Unix()
+	secret := biz.BreakGlassPrefix + "secret"
+
+	cred := &repo.BreakGlass{ID: 1, Name: "oncall", Principal: "sre", ExpireMinutes: 10, FirstUsedAt: now - 60}
+	creds.On("ListBreakGlass", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.BreakGlass{cred}, nil)
+	creds.On("CreateBreakGlassAudits", mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	creds.On("CreateBreakGlassAudits", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// access is denied if the audit can not be written
+	_, _, err := uc.AuthenticateBreakGlass(context.Background(), secret, listHostgroups, "10.0.0.1")
+	assert.ErrorIs(t, err, assert.AnError)
+
+	_, _, err = uc.AuthenticateBreakGlass(context.Background(), secret, listHostgroups, "10.0.0.1")
+	assert.NoError(t, err)
+
+	// expired minutes after the first use
+	cred.FirstUsedAt = now - 11*60
+	_, _, err = uc.AuthenticateBreakGlass(context.Background(), secret, listHostgroups, "10.0.0.1")
+	assert.ErrorIs(t, err, repo.ErrExpiredBreakGlass)
+
+	// absolute expiry
+	cred.FirstUsedAt = now - 60
+	cred.ExpiresAt = now - 1
+	_, _, err = uc.AuthenticateBreakGlass(context.Background(), secret, listHostgroups, "10.0.0.1")
+	assert.ErrorIs(t, err, repo.ErrExpiredBreakGlass)
+}
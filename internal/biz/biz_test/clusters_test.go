@@ -209,11 +209,12 @@ func TestDeleteClusters(t *testing.T) {
 }
 
 func TestGetClusters(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	txm := new(MockTXManager)
 	clsrepo := new(MockClustersRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewClustersUsecase(
 		clsrepo,
 		authzrepo,
@@ -250,11 +251,12 @@ func TestGetClusters(t *testing.T) {
 }
 
 func TestListClusters(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	txm := new(MockTXManager)
 	clsrepo := new(MockClustersRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewClustersUsecase(
 		clsrepo,
 		authzrepo,
@@ -271,7 +273,7 @@ func TestListClusters(t *testing.T) {
 		{Page: 0, PageSize: 10},
 	}
 	for _, bc := range bad_filter {
-		_, err := usecase.ListClusters(ctx, &bc)
+		_, _, err := usecase.ListClusters(ctx, &bc)
 		t.Logf("error. %v", err)
 		assert.Error(t, err)
 	}
@@ -283,7 +285,7 @@ func TestListClusters(t *testing.T) {
 	}
 	call := clsrepo.On("ListClusters", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errors.New("repo error"))
-	_, err := usecase.ListClusters(ctx, &filter)
+	_, _, err := usecase.ListClusters(ctx, &filter)
 	assert.Error(t, err)
 	call.Unset()
 
@@ -310,7 +312,34 @@ func TestListClusters(t *testing.T) {
 		},
 	}
 	clsrepo.On("ListClusters", mock.Anything, mock.Anything, mock.Anything).Return(db_prds, nil)
-	prds, err := usecase.ListClusters(ctx, &filter)
+	prds, _, err := usecase.ListClusters(ctx, &filter)
 	assert.NoError(t, err)
 	assert.Equal(t, biz_prds, prds)
 }
+
+func TestCreateClustersReturnsIds(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	clsrepo := new(MockClustersRepo)
+	authzrepo := new(MockAuthzRepo)
+	usecase := biz.NewClustersUsecase(
+		clsrepo,
+		authzrepo,
+		new(MockHostgroupsRepo),
+		nil,
+		new(MockTXManager),
+	)
+
+	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	// the repo assigns ids on insert
+	clsrepo.On("CreateClusters", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		for i, c := range args.Get(2).([]*repo.Cluster) {
+			c.ID = uint32(20 + i)
+		}
+	}).Return(nil)
+
+	clusters := []*biz.Cluster{{Name: "name-1"}, {Name: "name-2"}}
+	err := usecase.CreateClusters(ctx, clusters)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(20), clusters[0].Id)
+	assert.Equal(t, uint32(21), clusters[1].Id)
+}
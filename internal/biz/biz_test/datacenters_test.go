@@ -200,11 +200,12 @@ func TestDeleteDatacenters(t *testing.T) {
 }
 
 func TestGetDatacenters(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	txm := new(MockTXManager)
 	dcrepo := new(MockDatacentersRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewDatacentersUsecase(
 		dcrepo,
 		authzrepo,
@@ -241,11 +242,12 @@ func TestGetDatacenters(t *testing.T) {
 }
 
 func TestListDatacenters(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	txm := new(MockTXManager)
 	dcrepo := new(MockDatacentersRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewDatacentersUsecase(
 		dcrepo,
 		authzrepo,
@@ -262,7 +264,7 @@ func TestListDatacenters(t *testing.T) {
 		{Page: 0, PageSize: 10},
 	}
 	for _, bc := range bad_filter {
-		_, err := usecase.ListDatacenters(ctx, &bc)
+		_, _, err := usecase.ListDatacenters(ctx, &bc)
 		t.Logf("error. %v", err)
 		assert.Error(t, err)
 	}
@@ -274,7 +276,7 @@ func TestListDatacenters(t *testing.T) {
 	}
 	call := dcrepo.On("ListDatacenters", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errors.New("repo error"))
-	_, err := usecase.ListDatacenters(ctx, &filter)
+	_, _, err := usecase.ListDatacenters(ctx, &filter)
 	assert.Error(t, err)
 	call.Unset()
 
@@ -301,7 +303,7 @@ func TestListDatacenters(t *testing.T) {
 		},
 	}
 	dcrepo.On("ListDatacenters", mock.Anything, mock.Anything, mock.Anything).Return(db_prds, nil)
-	prds, err := usecase.ListDatacenters(ctx, &filter)
+	prds, _, err := usecase.ListDatacenters(ctx, &filter)
 	assert.NoError(t, err)
 	assert.Equal(t, biz_prds, prds)
 }
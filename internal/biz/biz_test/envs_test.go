@@ -208,6 +208,7 @@ func TestGetEnvs(t *testing.T) {
 	envrepo := new(MockEnvsRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewEnvsUsecase(
 		envrepo,
 		authzrepo,
@@ -249,6 +250,7 @@ func TestListEnvs(t *testing.T) {
 	envrepo := new(MockEnvsRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewEnvsUsecase(
 		envrepo,
 		authzrepo,
@@ -265,7 +267,7 @@ func TestListEnvs(t *testing.T) {
 		{Page: 0, PageSize: 10},
 	}
 	for _, bc := range bad_filter {
-		_, err := usecase.ListEnvs(ctx, &bc)
+		_, _, err := usecase.ListEnvs(ctx, &bc)
 		t.Logf("error. %v", err)
 		assert.Error(t, err)
 	}
@@ -277,7 +279,7 @@ func TestListEnvs(t *testing.T) {
 	}
 	call := envrepo.On("ListEnvs", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errors.New("repo error"))
-	_, err := usecase.ListEnvs(ctx, &filter)
+	_, _, err := usecase.ListEnvs(ctx, &filter)
 	assert.Error(t, err)
 	call.Unset()
 
@@ -304,7 +306,7 @@ func TestListEnvs(t *testing.T) {
 		},
 	}
 	envrepo.On("ListEnvs", mock.Anything, mock.Anything, mock.Anything).Return(db_prds, nil)
-	prds, err := usecase.ListEnvs(ctx, &filter)
+	prds, _, err := usecase.ListEnvs(ctx, &filter)
 	assert.NoError(t, err)
 	assert.Equal(t, biz_prds, prds)
 }
@@ -0,0 +1,84 @@
+package biz_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAsError(t *testing.T) {
+	cases := []struct {
+		err    error
+		kind   biz.ErrorKind
+		fields map[string]string
+	}{
+		{biz.ErrEmptyIds, biz.KindInvalidArgument, map[string]string{"ids": "must not be empty"}},
+		{errors.Join(errors.New("DeleteTags failed"), biz.ErrPermissionDenied), biz.KindPermissionDenied, nil},
+		{fmt.Errorf("tag 9: %w", repo.ErrNotFound), biz.KindNotFound, nil},
+		{fmt.Errorf("UNIQUE constraint failed: %w", repo.ErrDuplicated), biz.KindAlreadyExists, nil},
+		{fmt.Errorf("FOREIGN KEY constraint failed: %w", repo.ErrReferenced), biz.KindFailedPrecondition, nil},
+		{biz.FailedPrecondition("some %s requires", "tag"), biz.KindFailedPrecondition, nil},
+	}
+	for _, c := range cases {
+		e, ok := biz.AsError(c.err)
+		assert.True(t, ok, c.err)
+		assert.Equal(t, c.kind, e.Kind, c.err)
+		assert.Equal(t, c.fields, e.Fields, c.err)
+		// the message is the one of the whole error, wrapping messages included
+		assert.Equal(t, c.err.Error(), e.Error())
+	}
+
+	_, ok := biz.AsError(errors.New("disk full"))
+	assert.False(t, ok)
+}
+
+func TestErrorWithField(t *testing.T) {
+	err := biz.InvalidArgument("bad %s", "cluster").WithField("name", "must be lower case")
+	withCode := err.WithField("code", "must not be empty")
+
+	assert.Equal(t, "bad cluster", withCode.Error())
+	assert.Equal(t, map[string]string{"name": "must be lower case"}, err.Fields)
+	assert.Equal(t, map[string]string{"name": "must be lower case", "code": "must not be empty"}, withCode.Fields)
+
+	cause := errors.New("cause")
+	assert.ErrorIs(t, biz.NotFound("tag 9: %w", cause), cause)
+}
+
+func TestUpdateClustersConflict(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	clsrepo := new(MockClustersRepo)
+	authzrepo := new(MockAuthzRepo)
+	usecase := biz.NewClustersUsecase(
+		clsrepo,
+		authzrepo,
+		new(MockHostgroupsRepo),
+		nil,
+		new(MockTXManager),
+	)
+
+	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	clsrepo.On("UpdateClusters", ctx, mock.Anything, mock.Anything).Return(
+		fmt.Errorf("UNIQUE constraint failed: %w", repo.ErrDuplicated))
+
+	// renaming to a taken name collides with another cluster, not with one created twice
+	err := usecase.UpdateClusters(ctx, []*biz.Cluster{{Id: 1, Name: "name-1"}})
+	e, ok := biz.AsError(err)
+	assert.True(t, ok)
+	assert.Equal(t, biz.KindConflict, e.Kind)
+	assert.ErrorIs(t, err, repo.ErrDuplicated)
+
+	// invalid entities are not sent to the repo
+	err = usecase.UpdateClusters(ctx, []*biz.Cluster{{Id: 1, Name: "name space"}})
+	e, ok = biz.AsError(err)
+	assert.True(t, ok)
+	assert.Equal(t, biz.KindInvalidArgument, e.Kind)
+	assert.Contains(t, e.Fields, "name")
+}
@@ -250,6 +250,7 @@ func TestGetFeatures(t *testing.T) {
 	hfrepo := new(MockHostgroupFeaturesRepo)
 	afrepo := new(MockAppFeaturesRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewFeaturesUsecase(
 		ftrepo,
 		authzrepo,
@@ -296,6 +297,7 @@ func TestListFeatures(t *testing.T) {
 	hfrepo := new(MockHostgroupFeaturesRepo)
 	afrepo := new(MockAppFeaturesRepo)
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	usecase := biz.NewFeaturesUsecase(
 		ftrepo,
 		authzrepo,
@@ -315,7 +317,7 @@ func TestListFeatures(t *testing.T) {
 		{Page: 0, PageSize: 10},
 	}
 	for _, bc := range bad_filter {
-		_, err := usecase.ListFeatures(ctx, &bc)
+		_, _, err := usecase.ListFeatures(ctx, &bc)
 		t.Logf("error. %v", err)
 		assert.Error(t, err)
 	}
@@ -327,7 +329,7 @@ func TestListFeatures(t *testing.T) {
 	}
 	call := ftrepo.On("ListFeatures", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errors.New("repo error"))
-	_, err := usecase.ListFeatures(ctx, &filter)
+	_, _, err := usecase.ListFeatures(ctx, &filter)
 	assert.Error(t, err)
 	call.Unset()
 
@@ -358,7 +360,7 @@ func TestListFeatures(t *testing.T) {
 		},
 	}
 	ftrepo.On("ListFeatures", mock.Anything, mock.Anything, mock.Anything).Return(db_prds, nil)
-	prds, err := usecase.ListFeatures(ctx, &filter)
+	prds, _, err := usecase.ListFeatures(ctx, &filter)
 	assert.NoError(t, err)
 	assert.Equal(t, biz_prds, prds)
 }
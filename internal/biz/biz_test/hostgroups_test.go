@@ -6,6 +6,8 @@ import (
 	"opspillar/internal/biz"
 	"opspillar/internal/data"
 	"opspillar/internal/data/repo"
+	"sort"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,8 +34,8 @@ func TestCreateHostgroup(t *testing.T) {
 	ahrepo := new(MockAppHostgroupsRepo)
 
 	usecase := biz.NewHostgroupsUsecase(
-		hgrepo, htrepo, hprepo, htagrepo, hfrepo, clsrepo,
-		dcrepo, envrepo, ftrepo, tagrepo, teamrepo,
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
 		prdrepo, ahrepo, authzrepo, adminrepo, nil, txm)
 
 	// bad field
@@ -85,9 +87,10 @@ func TestCreateHostgroup(t *testing.T) {
 	}
 	// enforce fail
 	teamrepo.On("GetTeams", ctx, mock.Anything, mock.Anything).Return(&repo.Team{
-		2, "team2", "team2code", 2, "desc"}, nil)
+		ID: 2, Name: "team2", Code: "team2code", LeaderId: 2, Description: "desc"}, nil)
+	prdrepo.On("GetProducts", ctx, mock.Anything).Return(&repo.Product{ID: 1, Name: "product1"}, nil)
 	adminrepo.On("GetUsers", ctx, mock.Anything, mock.Anything).Return(&repo.User{
-		2, "admin", "admin", "email", "phone"}, nil)
+		Id: 2, UserName: "admin", Password: "admin", Email: "email", Phone: "phone"}, nil)
 	hg := []*biz.Hostgroup{
 		&_hg,
 	}
@@ -463,8 +466,8 @@ func TestUpdateHostgroup(t *testing.T) {
 	ahrepo := new(MockAppHostgroupsRepo)
 
 	usecase := biz.NewHostgroupsUsecase(
-		hgrepo, htrepo, hprepo, htagrepo, hfrepo, clsrepo,
-		dcrepo, envrepo, ftrepo, tagrepo, teamrepo,
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
 		prdrepo, ahrepo, authzrepo, adminrepo, nil, txm)
 
 	bad_fields := []string{
@@ -522,9 +525,10 @@ func TestUpdateHostgroup(t *testing.T) {
 	}
 
 	teamrepo.On("GetTeams", ctx, mock.Anything, mock.Anything).Return(&repo.Team{
-		2, "team2", "team2code", 2, "desc"}, nil)
+		ID: 2, Name: "team2", Code: "team2code", LeaderId: 2, Description: "desc"}, nil)
+	prdrepo.On("GetProducts", ctx, mock.Anything).Return(&repo.Product{ID: 1, Name: "product1"}, nil)
 	adminrepo.On("GetUsers", ctx, mock.Anything, mock.Anything).Return(&repo.User{
-		2, "admin", "admin", "email", "phone"}, nil)
+		Id: 2, UserName: "admin", Password: "admin", Email: "email", Phone: "phone"}, nil)
 	// enforce fail
 	authcall := authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(false, errors.New("enforce fail"))
 	err := usecase.UpdateHostgroups(ctx, hg)
@@ -758,8 +762,8 @@ func TestHostgroupsHandleM2MProps(t *testing.T) {
 	ahrepo := new(MockAppHostgroupsRepo)
 
 	usecase := biz.NewHostgroupsUsecase(
-		hgrepo, htrepo, hprepo, htagrepo, hfrepo, clsrepo,
-		dcrepo, envrepo, ftrepo, tagrepo, teamrepo,
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
 		prdrepo, ahrepo, authzrepo, adminrepo, nil, txm)
 
 	// houstgroup-tag
@@ -845,6 +849,208 @@ func TestHostgroupsHandleM2MProps(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpdateHostgroupsRelations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	authzrepo := new(MockAuthzRepo)
+	adminrepo := new(MockAdminRepo)
+	hgrepo := new(MockHostgroupsRepo)
+	htrepo := new(MockHostgroupTeamsRepo)
+	htagrepo := new(MockHostgroupTagsRepo)
+	hprepo := new(MockHostgroupProductsRepo)
+	hfrepo := new(MockHostgroupFeaturesRepo)
+	clsrepo := new(MockClustersRepo)
+	dcrepo := new(MockDatacentersRepo)
+	envrepo := new(MockEnvsRepo)
+	ftrepo := new(MockFeaturesRepo)
+	tagrepo := new(MockTagsRepo)
+	teamrepo := new(MockTeamsRepo)
+	prdrepo := new(MockProductsRepo)
+
+	usecase := biz.NewHostgroupsUsecase(
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
+		prdrepo, new(MockAppHostgroupsRepo), authzrepo, adminrepo, nil, new(MockTXManager))
+
+	err := usecase.UpdateHostgroupsRelations(ctx, nil, &biz.HostgroupRelations{TagsId: []uint32{1}}, nil)
+	assert.Error(t, err)
+	err = usecase.UpdateHostgroupsRelations(ctx, []uint32{10}, nil, &biz.HostgroupRelations{})
+	assert.Error(t, err)
+
+	hgrepo.On("ListHostgroups", ctx, mock.Anything, &repo.HostgroupsFilter{Ids: []uint32{10}}).Return([]*repo.Hostgroup{
+		{Id: 10, Name: "web", ClusterId: 1, DatacenterId: 1, EnvId: 1, ProductId: 1, TeamId: 1}}, nil)
+	// relation rows have ids of their own, hostgroups keep the related ids
+	htagrepo.On("ListHostgroupTags", ctx, mock.Anything, &repo.HostgroupTagsFilter{HostgroupIds: []uint32{10}}).
+		Return([]*repo.HostgroupTag{{Id: 11, HostgroupID: 10, TagID: 1}, {Id: 12, HostgroupID: 10, TagID: 2}}, nil)
+	hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything, &repo.HostgroupFeaturesFilter{HostgroupIds: []uint32{10}}).
+		Return([]*repo.HostgroupFeature{{Id: 13, HostgroupID: 10, FeatureID: 5}}, nil)
+	hprepo.On("ListHostgroupProducts", ctx, mock.Anything, mock.Anything).Return([]*repo.HostgroupProduct{}, nil)
+	htrepo.On("ListHostgroupTeams", ctx, mock.Anything, mock.Anything).Return([]*repo.HostgroupTeam{}, nil)
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{true}, nil)
+	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(true, nil)
+	teamrepo.On("GetTeams", ctx, uint32(1)).Return(&repo.Team{ID: 1, Name: "team1", LeaderId: 2}, nil)
+	prdrepo.On("GetProducts", ctx, uint32(1)).Return(&repo.Product{ID: 1, Name: "product1"}, nil)
+	adminrepo.On("GetUsers", ctx, uint32(2)).Return(&repo.User{Id: 2, UserName: "leader"}, nil)
+	clsrepo.On("CountClusters", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	dcrepo.On("CountDatacenters", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	envrepo.On("CountEnvs", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	prdrepo.On("CountProducts", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	teamrepo.On("CountTeams", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	ftrepo.On("CountFeatures", ctx, mock.Anything, &repo.FeaturesFilter{Ids: []uint32{5}}).Return(int64(1), nil)
+	tagrepo.On("CountTags", ctx, mock.Anything, &repo.TagsFilter{Ids: []uint32{1, 3}}).Return(int64(2), nil)
+	// tag 2 is removed and tag 3 added, feature 5 is kept
+	htagrepo.On("DeleteHostgroupTags", ctx, mock.Anything, []uint32{12}).Return(nil).Once()
+	htagrepo.On("CreateHostgroupTags", ctx, mock.Anything, []*repo.HostgroupTag{{HostgroupID: 10, TagID: 3}}).
+		Return(nil).Once()
+
+	err = usecase.UpdateHostgroupsRelations(ctx, []uint32{10},
+		&biz.HostgroupRelations{TagsId: []uint32{3}}, &biz.HostgroupRelations{TagsId: []uint32{2}})
+	assert.NoError(t, err)
+	htagrepo.AssertExpectations(t)
+	hfrepo.AssertNotCalled(t, "CreateHostgroupFeatures", mock.Anything, mock.Anything, mock.Anything)
+	hfrepo.AssertNotCalled(t, "DeleteHostgroupFeatures", mock.Anything, mock.Anything, mock.Anything)
+	// only relation rows are written
+	hgrepo.AssertNotCalled(t, "UpdateHostgroups", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// lockingTXManager serializes transactions like the write lock of a database, waiting is
+// signaled if set before a transaction waits for the lock.
+type lockingTXManager struct {
+	mu      sync.Mutex
+	waiting chan struct{}
+}
+
+func (m *lockingTXManager) RunInTX(fn func(repo.TX) error) error {
+	if m.waiting != nil {
+		select {
+		case m.waiting <- struct{}{}:
+		default:
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(nil)
+}
+
+// memHostgroupTags keeps hostgroup tags in memory, onList runs after the first list read the rows.
+type memHostgroupTags struct {
+	MockHostgroupTagsRepo
+	mu     sync.Mutex
+	rows   map[uint32]*repo.HostgroupTag
+	lastId uint32
+	onList func()
+}
+
+func (m *memHostgroupTags) ListHostgroupTags(ctx context.Context, tx repo.TX,
+	filter *repo.HostgroupTagsFilter) ([]*repo.HostgroupTag, error) {
+	m.mu.Lock()
+	var rows []*repo.HostgroupTag
+	for _, r := range m.rows {
+		c := *r
+		rows = append(rows, &c)
+	}
+	onList := m.onList
+	m.onList = nil
+	m.mu.Unlock()
+	if onList != nil {
+		onList()
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Id < rows[j].Id })
+	return rows, nil
+}
+
+func (m *memHostgroupTags) CreateHostgroupTags(ctx context.Context, tx repo.TX, hts []*repo.HostgroupTag) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, ht := range hts {
+		m.lastId++
+		c := *ht
+		c.Id = m.lastId
+		m.rows[c.Id] = &c
+	}
+	return nil
+}
+
+func (m *memHostgroupTags) DeleteHostgroupTags(ctx context.Context, tx repo.TX, ids []uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		delete(m.rows, id)
+	}
+	return nil
+}
+
+func TestUpdateHostgroupsRelations_Interleaved(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	authzrepo := new(MockAuthzRepo)
+	adminrepo := new(MockAdminRepo)
+	hgrepo := new(MockHostgroupsRepo)
+	htrepo := new(MockHostgroupTeamsRepo)
+	hprepo := new(MockHostgroupProductsRepo)
+	hfrepo := new(MockHostgroupFeaturesRepo)
+	clsrepo := new(MockClustersRepo)
+	dcrepo := new(MockDatacentersRepo)
+	envrepo := new(MockEnvsRepo)
+	ftrepo := new(MockFeaturesRepo)
+	tagrepo := new(MockTagsRepo)
+	teamrepo := new(MockTeamsRepo)
+	prdrepo := new(MockProductsRepo)
+	htagrepo := &memHostgroupTags{rows: map[uint32]*repo.HostgroupTag{1: {Id: 1, HostgroupID: 10, TagID: 1}}, lastId: 1}
+	txm := &lockingTXManager{}
+
+	usecase := biz.NewHostgroupsUsecase(
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
+		prdrepo, new(MockAppHostgroupsRepo), authzrepo, adminrepo, nil, txm)
+
+	web := &repo.Hostgroup{Id: 10, Name: "web", ClusterId: 1, DatacenterId: 1, EnvId: 1, ProductId: 1, TeamId: 1}
+	hgrepo.On("ListHostgroups", ctx, mock.Anything, mock.Anything).Return([]*repo.Hostgroup{web}, nil)
+	hgrepo.On("GetHostgroups", ctx, uint32(10)).Return(web, nil)
+	hgrepo.On("UpdateHostgroups", ctx, mock.Anything, mock.Anything).Return(nil)
+	hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything, mock.Anything).Return([]*repo.HostgroupFeature{}, nil)
+	hprepo.On("ListHostgroupProducts", ctx, mock.Anything, mock.Anything).Return([]*repo.HostgroupProduct{}, nil)
+	htrepo.On("ListHostgroupTeams", ctx, mock.Anything, mock.Anything).Return([]*repo.HostgroupTeam{}, nil)
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{true}, nil)
+	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(true, nil)
+	teamrepo.On("GetTeams", ctx, uint32(1)).Return(&repo.Team{ID: 1, Name: "team1", LeaderId: 2}, nil)
+	prdrepo.On("GetProducts", ctx, uint32(1)).Return(&repo.Product{ID: 1, Name: "product1"}, nil)
+	adminrepo.On("GetUsers", ctx, uint32(2)).Return(&repo.User{Id: 2, UserName: "leader"}, nil)
+	clsrepo.On("CountClusters", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	dcrepo.On("CountDatacenters", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	envrepo.On("CountEnvs", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	prdrepo.On("CountProducts", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	teamrepo.On("CountTeams", ctx, mock.Anything, mock.Anything).Return(int64(1), nil)
+	ftrepo.On("CountFeatures", ctx, mock.Anything, mock.Anything).Return(int64(0), nil)
+	for n := 1; n <= 3; n++ {
+		count := n
+		tagrepo.On("CountTags", ctx, mock.Anything, mock.MatchedBy(func(f *repo.TagsFilter) bool {
+			return len(f.Ids) == count
+		})).Return(int64(count), nil)
+	}
+
+	// the edit adding tag 4 starts once the edit adding tag 3 read the tags, both are kept
+	done := make(chan error, 1)
+	htagrepo.onList = func() {
+		txm.waiting = make(chan struct{}, 1)
+		go func() {
+			done <- usecase.UpdateHostgroupsRelations(ctx, []uint32{10}, &biz.HostgroupRelations{TagsId: []uint32{4}}, nil)
+		}()
+		select {
+		case err := <-done:
+			done <- err
+		case <-txm.waiting:
+		}
+	}
+	err := usecase.UpdateHostgroupsRelations(ctx, []uint32{10}, &biz.HostgroupRelations{TagsId: []uint32{3}}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+
+	var tags []uint32
+	for _, row := range htagrepo.rows {
+		tags = append(tags, row.TagID)
+	}
+	assert.ElementsMatch(t, []uint32{1, 3, 4}, tags)
+}
+
 func TestDeleteHostgroups(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
 	authzrepo := new(MockAuthzRepo)
@@ -865,14 +1071,14 @@ func TestDeleteHostgroups(t *testing.T) {
 	ahrepo := new(MockAppHostgroupsRepo)
 
 	usecase := biz.NewHostgroupsUsecase(
-		hgrepo, htrepo, hprepo, htagrepo, hfrepo, clsrepo,
-		dcrepo, envrepo, ftrepo, tagrepo, teamrepo,
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
 		prdrepo, ahrepo, authzrepo, adminrepo, nil, txm)
 
 	teamrepo.On("GetTeams", ctx, mock.Anything, mock.Anything).Return(&repo.Team{
-		2, "team2", "team2code", 2, "desc"}, nil)
+		ID: 2, Name: "team2", Code: "team2code", LeaderId: 2, Description: "desc"}, nil)
 	adminrepo.On("GetUsers", ctx, mock.Anything, mock.Anything).Return(&repo.User{
-		2, "admin", "admin", "email", "phone"}, nil)
+		Id: 2, UserName: "admin", Password: "admin", Email: "email", Phone: "phone"}, nil)
 	hgrepo.On("ListHostgroups", ctx, mock.Anything, mock.Anything).Return([]*repo.Hostgroup{
 		&repo.Hostgroup{
 			Id:           1,
@@ -924,8 +1130,8 @@ func TestListHostgroup(t *testing.T) {
 	ahrepo := new(MockAppHostgroupsRepo)
 
 	usecase := biz.NewHostgroupsUsecase(
-		hgrepo, htrepo, hprepo, htagrepo, hfrepo, clsrepo,
-		dcrepo, envrepo, ftrepo, tagrepo, teamrepo,
+		hgrepo, htrepo, hprepo, htagrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), clsrepo,
+		dcrepo, envrepo, ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
 		prdrepo, ahrepo, authzrepo, adminrepo, nil, txm)
 
 	// fail
@@ -940,23 +1146,23 @@ func TestListHostgroup(t *testing.T) {
 
 	// hostgroup-tag filter empty
 	htagcall := htagrepo.On("ListHostgroupTags", ctx, mock.Anything, &repo.HostgroupTagsFilter{
-		Ids: []uint32{1},
+		TagIds: []uint32{1},
 	}).Return([]*repo.HostgroupTag{}, nil)
-	_, err := usecase.ListHostgroups(ctx, query)
+	_, _, err := usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	t.Logf("tag filter empty: %v", err)
 	assert.Error(t, err)
 
 	// hostgroup-feature filter empty
 	htagcall = htagrepo.On("ListHostgroupTags", ctx, mock.Anything, &repo.HostgroupTagsFilter{
-		Ids: []uint32{1},
+		TagIds: []uint32{1},
 	}).Return([]*repo.HostgroupTag{
 		{HostgroupID: 1, TagID: 1, Id: 1},
 	}, nil)
 	hfcall := hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything, &repo.HostgroupFeaturesFilter{
-		Ids: []uint32{1},
+		FeatureIds: []uint32{1},
 	}).Return([]*repo.HostgroupFeature{}, nil)
-	_, err = usecase.ListHostgroups(ctx, query)
+	_, _, err = usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	hfcall.Unset()
 	t.Logf("feature filter empty: %v", err)
@@ -964,21 +1170,21 @@ func TestListHostgroup(t *testing.T) {
 
 	// hostgroup-share-product filter empty
 	htagcall = htagrepo.On("ListHostgroupTags", ctx, mock.Anything, &repo.HostgroupTagsFilter{
-		Ids: []uint32{1},
+		TagIds: []uint32{1},
 	}).Return([]*repo.HostgroupTag{
 		{HostgroupID: 1, TagID: 1, Id: 1},
 	}, nil)
 	hfcall = hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything,
 		&repo.HostgroupFeaturesFilter{
-			Ids: []uint32{1},
+			FeatureIds: []uint32{1},
 		}).Return([]*repo.HostgroupFeature{
 		{HostgroupID: 1, FeatureID: 1, Id: 1},
 	}, nil)
 	hpcall := hprepo.On("ListHostgroupProducts", ctx, mock.Anything,
 		&repo.HostgroupProductsFilter{
-			Ids: []uint32{1},
+			ProductIds: []uint32{1},
 		}).Return([]*repo.HostgroupProduct{}, nil)
-	_, err = usecase.ListHostgroups(ctx, query)
+	_, _, err = usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	hfcall.Unset()
 	hpcall.Unset()
@@ -987,27 +1193,27 @@ func TestListHostgroup(t *testing.T) {
 
 	// hostgroup-share-team filter empty
 	htagcall = htagrepo.On("ListHostgroupTags", ctx, mock.Anything, &repo.HostgroupTagsFilter{
-		Ids: []uint32{1},
+		TagIds: []uint32{1},
 	}).Return([]*repo.HostgroupTag{
 		{HostgroupID: 1, TagID: 1, Id: 1},
 	}, nil)
 	hfcall = hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything,
 		&repo.HostgroupFeaturesFilter{
-			Ids: []uint32{1},
+			FeatureIds: []uint32{1},
 		}).Return([]*repo.HostgroupFeature{
 		{HostgroupID: 1, FeatureID: 1, Id: 1},
 	}, nil)
 	hpcall = hprepo.On("ListHostgroupProducts", ctx, mock.Anything,
 		&repo.HostgroupProductsFilter{
-			Ids: []uint32{1},
+			ProductIds: []uint32{1},
 		}).Return([]*repo.HostgroupProduct{
 		{HostgroupID: 1, ProductID: 1, Id: 1},
 	}, nil)
 	htcall := htrepo.On("ListHostgroupTeams", ctx, mock.Anything,
 		&repo.HostgroupTeamsFilter{
-			Ids: []uint32{1},
+			TeamIds: []uint32{1},
 		}).Return([]*repo.HostgroupTeam{}, nil)
-	_, err = usecase.ListHostgroups(ctx, query)
+	_, _, err = usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	hfcall.Unset()
 	hpcall.Unset()
@@ -1017,32 +1223,34 @@ func TestListHostgroup(t *testing.T) {
 
 	// hostgroup repo fail
 	htagcall = htagrepo.On("ListHostgroupTags", ctx, mock.Anything, &repo.HostgroupTagsFilter{
-		Ids: []uint32{1},
+		TagIds: []uint32{1},
 	}).Return([]*repo.HostgroupTag{
 		{HostgroupID: 1, TagID: 1, Id: 1},
 	}, nil)
 	hfcall = hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything,
 		&repo.HostgroupFeaturesFilter{
-			Ids: []uint32{1},
+			FeatureIds: []uint32{1},
 		}).Return([]*repo.HostgroupFeature{
 		{HostgroupID: 1, FeatureID: 1, Id: 1},
 	}, nil)
 	hpcall = hprepo.On("ListHostgroupProducts", ctx, mock.Anything,
 		&repo.HostgroupProductsFilter{
-			Ids: []uint32{1},
+			ProductIds: []uint32{1},
 		}).Return([]*repo.HostgroupProduct{
 		{HostgroupID: 1, ProductID: 1, Id: 1},
 	}, nil)
 	htcall = htrepo.On("ListHostgroupTeams", ctx, mock.Anything,
 		&repo.HostgroupTeamsFilter{
-			Ids: []uint32{1},
+			TeamIds: []uint32{1},
 		}).Return([]*repo.HostgroupTeam{
 		{HostgroupID: 1, TeamID: 1, Id: 1},
 	}, nil)
 	hgcall := hgrepo.On("ListHostgroups", ctx, mock.Anything, &repo.HostgroupsFilter{
-		Ids: []uint32{1},
+		Page:     1,
+		PageSize: 100,
+		Ids:      []uint32{1},
 	}).Return([]*repo.Hostgroup{}, errors.New("mock repo fail"))
-	_, err = usecase.ListHostgroups(ctx, query)
+	_, _, err = usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	hfcall.Unset()
 	hpcall.Unset()
@@ -1054,32 +1262,34 @@ func TestListHostgroup(t *testing.T) {
 	// repo find zero
 	htagcall = htagrepo.On("ListHostgroupTags", ctx, mock.Anything,
 		&repo.HostgroupTagsFilter{
-			Ids: []uint32{1},
+			TagIds: []uint32{1},
 		}).Return([]*repo.HostgroupTag{
 		{HostgroupID: 1, TagID: 1, Id: 1},
 	}, nil)
 	hfcall = hfrepo.On("ListHostgroupFeatures", ctx, mock.Anything,
 		&repo.HostgroupFeaturesFilter{
-			Ids: []uint32{1},
+			FeatureIds: []uint32{1},
 		}).Return([]*repo.HostgroupFeature{
 		{HostgroupID: 1, FeatureID: 1, Id: 1},
 	}, nil)
 	hpcall = hprepo.On("ListHostgroupProducts", ctx, mock.Anything,
 		&repo.HostgroupProductsFilter{
-			Ids: []uint32{1},
+			ProductIds: []uint32{1},
 		}).Return([]*repo.HostgroupProduct{
 		{HostgroupID: 1, ProductID: 1, Id: 1},
 	}, nil)
 	htcall = htrepo.On("ListHostgroupTeams", ctx, mock.Anything,
 		&repo.HostgroupTeamsFilter{
-			Ids: []uint32{1},
+			TeamIds: []uint32{1},
 		}).Return([]*repo.HostgroupTeam{
 		{HostgroupID: 1, TeamID: 1, Id: 1},
 	}, nil)
 	hgcall = hgrepo.On("ListHostgroups", ctx, mock.Anything, &repo.HostgroupsFilter{
-		Ids: []uint32{1},
+		Page:     1,
+		PageSize: 100,
+		Ids:      []uint32{1},
 	}).Return([]*repo.Hostgroup{}, nil)
-	r, err := usecase.ListHostgroups(ctx, query)
+	r, _, err := usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	hfcall.Unset()
 	hpcall.Unset()
@@ -1153,10 +1363,28 @@ func TestListHostgroup(t *testing.T) {
 			{HostgroupID: 1, TeamID: 2, Id: 2},
 		}, nil)
 	hgcall = hgrepo.On("ListHostgroups", ctx, mock.Anything, &repo.HostgroupsFilter{
-		Ids: []uint32{1},
+		Page:     1,
+		PageSize: 100,
+		Ids:      []uint32{1},
 	}).Return(want_hg_repo, nil)
 
-	r, err = usecase.ListHostgroups(ctx, query)
+	teamrepo.On("GetTeams", ctx, uint32(1)).Return(&repo.Team{ID: 1, Name: "team1", LeaderId: 10}, nil)
+	teamrepo.On("GetTeams", ctx, uint32(2)).Return(&repo.Team{ID: 2, Name: "team2", LeaderId: 20}, nil)
+	adminrepo.On("GetUsers", ctx, uint32(10)).Return(&repo.User{Id: 10, UserName: "lead1"}, nil)
+	adminrepo.On("GetUsers", ctx, uint32(20)).Return(&repo.User{Id: 20, UserName: "lead2"}, nil)
+	// visible to neither the owning team nor the share team
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{false, false}, nil).Once()
+	r, _, err = usecase.ListHostgroups(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(r))
+
+	// visible to the share team
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.MatchedBy(func(reqs []*repo.AuthenRequest) bool {
+		return len(reqs) == 2 &&
+			reqs[0].Resource.ResourceStr() == "v1/default/hostgroups/team1/test/lead1" &&
+			reqs[1].Resource.ResourceStr() == "v1/default/hostgroups/team2/test/lead2"
+	})).Return([]bool{false, true}, nil)
+	r, _, err = usecase.ListHostgroups(ctx, query)
 	htagcall.Unset()
 	hfcall.Unset()
 	hpcall.Unset()
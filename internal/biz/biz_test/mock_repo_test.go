@@ -57,6 +57,59 @@ func (m *MockAdminRepo) CountUsers(ctx context.Context, tx repo.TX, filter repo.
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockAdminRepo) DisableUsers(ctx context.Context, tx repo.TX, ids []uint32, disabled bool) error {
+	args := m.Called(ctx, tx, ids, disabled)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepo) SetPassword(ctx context.Context, tx repo.TX, id uint32, hash string, changedAt int64) error {
+	args := m.Called(ctx, tx, id, hash, changedAt)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepo) AddFailedLogin(ctx context.Context, tx repo.TX, id uint32, max int32, lockUntil int64) (bool, error) {
+	args := m.Called(ctx, tx, id, max, lockUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAdminRepo) ResetFailedLogins(ctx context.Context, tx repo.TX, id uint32) error {
+	args := m.Called(ctx, tx, id)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepo) CreatePasswordResetToken(ctx context.Context, tx repo.TX, token *repo.PasswordResetToken) error {
+	args := m.Called(ctx, tx, token)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepo) UsePasswordResetToken(ctx context.Context, tx repo.TX, hash string, now int64) (*repo.PasswordResetToken, error) {
+	args := m.Called(ctx, tx, hash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockAdminRepo) SetMFA(ctx context.Context, tx repo.TX, id uint32, secret string, codes []*repo.MFARecoveryCode) error {
+	args := m.Called(ctx, tx, id, secret, codes)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepo) EnableMFA(ctx context.Context, tx repo.TX, id uint32, secret string) error {
+	args := m.Called(ctx, tx, id, secret)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepo) UseMFAStep(ctx context.Context, tx repo.TX, id uint32, step int64) (bool, error) {
+	args := m.Called(ctx, tx, id, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAdminRepo) UseMFARecoveryCode(ctx context.Context, tx repo.TX, id uint32, hash string, now int64) error {
+	args := m.Called(ctx, tx, id, hash, now)
+	return args.Error(0)
+}
+
 func (m *MockAdminRepo) Logout(ctx context.Context, id uint32) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -116,6 +169,24 @@ func (m *MockAuthzRepo) Enforce(ctx context.Context, tx repo.TX, request *repo.A
 	return args.Get(0).(bool), args.Error(1)
 }
 
+// BatchEnforce
+func (m *MockAuthzRepo) BatchEnforce(ctx context.Context, tx repo.TX, requests []*repo.AuthenRequest) ([]bool, error) {
+	args := m.Called(ctx, tx, requests)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bool), args.Error(1)
+}
+
+// Explain
+func (m *MockAuthzRepo) Explain(ctx context.Context, tx repo.TX, request *repo.AuthenRequest) (*repo.Explanation, error) {
+	args := m.Called(ctx, tx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.Explanation), args.Error(1)
+}
+
 // ListGroup
 func (m *MockAuthzRepo) ListGroup(ctx context.Context, tx repo.TX, filter *repo.GroupFilter) ([]*repo.Group, error) {
 	args := m.Called(ctx, tx, filter)
@@ -601,6 +672,41 @@ func (m *MockEnvsRepo) CountRequire(ctx context.Context, tx repo.TX, need repo.R
 	return args.Get(0).(int64), args.Error(1)
 }
 
+type MockOrganizationsRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationsRepo) CreateOrganizations(ctx context.Context, tx repo.TX, o []*repo.Organization) error {
+	args := m.Called(ctx, tx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationsRepo) UpdateOrganizations(ctx context.Context, tx repo.TX, o []*repo.Organization) error {
+	args := m.Called(ctx, tx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationsRepo) DeleteOrganizations(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationsRepo) GetOrganizations(ctx context.Context, id uint32) (*repo.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationsRepo) ListOrganizations(ctx context.Context, tx repo.TX, filter *repo.OrganizationsFilter) ([]*repo.Organization, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.Organization), args.Error(1)
+}
+
 type MockDatacentersRepo struct {
 	mock.Mock
 }
@@ -882,3 +988,386 @@ func (m *MockApplicationsRepo) CountRequire(ctx context.Context, tx repo.TX, nee
 	args := m.Called(ctx, tx, need, ids)
 	return args.Get(0).(int64), args.Error(1)
 }
+
+// Mock TokenRepo
+type MockTokenRepo struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepo) CreateToken(ctx context.Context, claims repo.TokenClaims) (string, error) {
+	args := m.Called(ctx, claims)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenRepo) DeleteToken(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepo) ValidateToken(ctx context.Context, token string) (repo.TokenClaims, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(repo.TokenClaims), args.Error(1)
+}
+
+func (m *MockTokenRepo) RevokeUserTokens(ctx context.Context, userId string) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepo) PublicKeys(ctx context.Context) ([]*repo.JSONWebKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.JSONWebKey), args.Error(1)
+}
+
+// Mock SessionsRepo
+type MockSessionsRepo struct {
+	mock.Mock
+}
+
+func (m *MockSessionsRepo) CreateSessions(ctx context.Context, tx repo.TX, sessions []*repo.Session) error {
+	args := m.Called(ctx, tx, sessions)
+	return args.Error(0)
+}
+
+func (m *MockSessionsRepo) UpdateSessions(ctx context.Context, tx repo.TX, sessions []*repo.Session) error {
+	args := m.Called(ctx, tx, sessions)
+	return args.Error(0)
+}
+
+func (m *MockSessionsRepo) RotateSession(ctx context.Context, tx repo.TX, session *repo.Session, oldHash string) (bool, error) {
+	args := m.Called(ctx, tx, session, oldHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSessionsRepo) DeleteSessions(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockSessionsRepo) DeleteUserSessions(ctx context.Context, tx repo.TX, userIds []uint32) error {
+	args := m.Called(ctx, tx, userIds)
+	return args.Error(0)
+}
+
+func (m *MockSessionsRepo) ListSessions(ctx context.Context, tx repo.TX, filter *repo.SessionsFilter) ([]*repo.Session, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.Session), args.Error(1)
+}
+
+// Mock ServiceAccountsRepo
+type MockServiceAccountsRepo struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountsRepo) CreateServiceAccounts(ctx context.Context, tx repo.TX, accounts []*repo.ServiceAccount) error {
+	args := m.Called(ctx, tx, accounts)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountsRepo) DeleteServiceAccounts(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountsRepo) GetServiceAccounts(ctx context.Context, tx repo.TX, id uint32) (*repo.ServiceAccount, error) {
+	args := m.Called(ctx, tx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountsRepo) ListServiceAccounts(ctx context.Context, tx repo.TX, filter *repo.ServiceAccountsFilter) ([]*repo.ServiceAccount, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountsRepo) CountRequire(ctx context.Context, tx repo.TX, need repo.RequireType, ids []uint32) (int64, error) {
+	args := m.Called(ctx, tx, need, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Mock ApiKeysRepo
+type MockApiKeysRepo struct {
+	mock.Mock
+}
+
+func (m *MockApiKeysRepo) CreateApiKeys(ctx context.Context, tx repo.TX, keys []*repo.ApiKey) error {
+	args := m.Called(ctx, tx, keys)
+	return args.Error(0)
+}
+
+func (m *MockApiKeysRepo) DeleteApiKeys(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockApiKeysRepo) DeleteServiceAccountApiKeys(ctx context.Context, tx repo.TX, serviceAccountIds []uint32) error {
+	args := m.Called(ctx, tx, serviceAccountIds)
+	return args.Error(0)
+}
+
+func (m *MockApiKeysRepo) ListApiKeys(ctx context.Context, tx repo.TX, filter *repo.ApiKeysFilter) ([]*repo.ApiKey, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.ApiKey), args.Error(1)
+}
+
+func (m *MockApiKeysRepo) TouchApiKey(ctx context.Context, id uint32, lastUsedAt int64) error {
+	args := m.Called(ctx, id, lastUsedAt)
+	return args.Error(0)
+}
+
+// Mock BreakGlassRepo
+type MockBreakGlassRepo struct {
+	mock.Mock
+}
+
+func (m *MockBreakGlassRepo) CreateBreakGlass(ctx context.Context, tx repo.TX, creds []*repo.BreakGlass) error {
+	args := m.Called(ctx, tx, creds)
+	return args.Error(0)
+}
+
+func (m *MockBreakGlassRepo) DeleteBreakGlass(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockBreakGlassRepo) ListBreakGlass(ctx context.Context, tx repo.TX, filter *repo.BreakGlassFilter) ([]*repo.BreakGlass, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.BreakGlass), args.Error(1)
+}
+
+func (m *MockBreakGlassRepo) ActivateBreakGlass(ctx context.Context, tx repo.TX, id uint32, usedAt int64) (bool, error) {
+	args := m.Called(ctx, tx, id, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBreakGlassRepo) CreateBreakGlassAudits(ctx context.Context, tx repo.TX, audits []*repo.BreakGlassAudit) error {
+	args := m.Called(ctx, tx, audits)
+	return args.Error(0)
+}
+
+func (m *MockBreakGlassRepo) ListBreakGlassAudits(ctx context.Context, tx repo.TX, filter *repo.BreakGlassAuditsFilter) ([]*repo.BreakGlassAudit, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.BreakGlassAudit), args.Error(1)
+}
+
+// Mock AuthzAuditsRepo
+type MockAuthzAuditsRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuthzAuditsRepo) CreateAuthzAudits(ctx context.Context, tx repo.TX, audits []*repo.AuthzAudit) error {
+	args := m.Called(ctx, tx, audits)
+	return args.Error(0)
+}
+
+func (m *MockAuthzAuditsRepo) ListAuthzAudits(ctx context.Context, tx repo.TX, filter *repo.AuthzAuditsFilter) ([]*repo.AuthzAudit, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.AuthzAudit), args.Error(1)
+}
+
+// Mock TeamMembersRepo
+type MockTeamMembersRepo struct {
+	mock.Mock
+}
+
+func (m *MockTeamMembersRepo) CreateTeamMembers(ctx context.Context, tx repo.TX, members []*repo.TeamMember) error {
+	args := m.Called(ctx, tx, members)
+	return args.Error(0)
+}
+
+func (m *MockTeamMembersRepo) UpdateTeamMembers(ctx context.Context, tx repo.TX, members []*repo.TeamMember) error {
+	args := m.Called(ctx, tx, members)
+	return args.Error(0)
+}
+
+func (m *MockTeamMembersRepo) DeleteTeamMembers(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockTeamMembersRepo) DeleteTeamMembersByTeamId(ctx context.Context, tx repo.TX, teamIds []uint32) error {
+	args := m.Called(ctx, tx, teamIds)
+	return args.Error(0)
+}
+
+func (m *MockTeamMembersRepo) ListTeamMembers(ctx context.Context, tx repo.TX, filter *repo.TeamMembersFilter) ([]*repo.TeamMember, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.TeamMember), args.Error(1)
+}
+
+func (m *MockTeamMembersRepo) CountRequire(ctx context.Context, tx repo.TX, need repo.RequireType, ids []uint32) (int64, error) {
+	args := m.Called(ctx, tx, need, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Mock SSOProvider
+type MockSSOProvider struct {
+	mock.Mock
+}
+
+func (m *MockSSOProvider) AuthCodeURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	args := m.Called(ctx, redirectURI, state, nonce, codeChallenge)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSSOProvider) Exchange(ctx context.Context, code, codeVerifier, redirectURI, nonce string) (*repo.SSOIdentity, error) {
+	args := m.Called(ctx, code, codeVerifier, redirectURI, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.SSOIdentity), args.Error(1)
+}
+
+type MockDirectoryProvider struct {
+	mock.Mock
+}
+
+func (m *MockDirectoryProvider) Authenticate(ctx context.Context, username, password string) (*repo.DirectoryUser, error) {
+	args := m.Called(ctx, username, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.DirectoryUser), args.Error(1)
+}
+
+func (m *MockDirectoryProvider) ListUsers(ctx context.Context) ([]*repo.DirectoryUser, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.DirectoryUser), args.Error(1)
+}
+
+type MockAttributesRepo struct {
+	mock.Mock
+}
+
+// newNoAttributesRepo is an attributes repo without attributes defined.
+func newNoAttributesRepo() *MockAttributesRepo {
+	m := new(MockAttributesRepo)
+	m.On("ListAttributes", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Attribute{}, nil)
+	m.On("SetAttributeValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.On("ListAttributeValues", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.AttributeValue{}, nil)
+	m.On("DeleteAttributeValues", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return m
+}
+
+func (m *MockAttributesRepo) CreateAttributes(ctx context.Context, tx repo.TX, attrs []*repo.Attribute) error {
+	args := m.Called(ctx, tx, attrs)
+	return args.Error(0)
+}
+
+func (m *MockAttributesRepo) UpdateAttributes(ctx context.Context, tx repo.TX, attrs []*repo.Attribute) error {
+	args := m.Called(ctx, tx, attrs)
+	return args.Error(0)
+}
+
+func (m *MockAttributesRepo) DeleteAttributes(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockAttributesRepo) GetAttributes(ctx context.Context, id uint32) (*repo.Attribute, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.Attribute), args.Error(1)
+}
+
+func (m *MockAttributesRepo) ListAttributes(ctx context.Context, tx repo.TX, filter *repo.AttributesFilter) ([]*repo.Attribute, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.Attribute), args.Error(1)
+}
+
+func (m *MockAttributesRepo) SetAttributeValues(ctx context.Context, tx repo.TX, kind string, entityId uint32, values []*repo.AttributeValue) error {
+	args := m.Called(ctx, tx, kind, entityId, values)
+	return args.Error(0)
+}
+
+func (m *MockAttributesRepo) ListAttributeValues(ctx context.Context, tx repo.TX, filter *repo.AttributeValuesFilter) ([]*repo.AttributeValue, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.AttributeValue), args.Error(1)
+}
+
+func (m *MockAttributesRepo) DeleteAttributeValues(ctx context.Context, tx repo.TX, filter *repo.AttributeValuesFilter) error {
+	args := m.Called(ctx, tx, filter)
+	return args.Error(0)
+}
+
+type MockTagKeysRepo struct {
+	mock.Mock
+}
+
+// newNoTagKeysRepo is a tag keys repo without keys registered.
+func newNoTagKeysRepo() *MockTagKeysRepo {
+	m := new(MockTagKeysRepo)
+	m.On("ListTagKeys", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.TagKey{}, nil)
+	return m
+}
+
+func (m *MockTagKeysRepo) CreateTagKeys(ctx context.Context, tx repo.TX, keys []*repo.TagKey) error {
+	args := m.Called(ctx, tx, keys)
+	return args.Error(0)
+}
+
+func (m *MockTagKeysRepo) UpdateTagKeys(ctx context.Context, tx repo.TX, keys []*repo.TagKey) error {
+	args := m.Called(ctx, tx, keys)
+	return args.Error(0)
+}
+
+func (m *MockTagKeysRepo) DeleteTagKeys(ctx context.Context, tx repo.TX, ids []uint32) error {
+	args := m.Called(ctx, tx, ids)
+	return args.Error(0)
+}
+
+func (m *MockTagKeysRepo) GetTagKeys(ctx context.Context, id uint32) (*repo.TagKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repo.TagKey), args.Error(1)
+}
+
+func (m *MockTagKeysRepo) ListTagKeys(ctx context.Context, tx repo.TX, filter *repo.TagKeysFilter) ([]*repo.TagKey, error) {
+	args := m.Called(ctx, tx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repo.TagKey), args.Error(1)
+}
@@ -0,0 +1,174 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrganization_Validate(t *testing.T) {
+	assert.NoError(t, (&biz.Organization{Name: "acme"}).Validate(true))
+	assert.Error(t, (&biz.Organization{Name: "Acme"}).Validate(true))
+	assert.Error(t, (&biz.Organization{Name: "acme-"}).Validate(true))
+	assert.Error(t, (&biz.Organization{Name: "acme", Admins: []string{""}}).Validate(true))
+	assert.Error(t, (&biz.Organization{Name: "acme"}).Validate(false))
+}
+
+func TestOrganizationsUsecase_CreateOrganizations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	orgs := new(MockOrganizationsRepo)
+	authz := new(MockAuthzRepo)
+	admin := new(MockAdminRepo)
+	uc := biz.NewOrganizationsUsecase(orgs, authz, admin, new(MockTeamsRepo), new(MockProductsRepo),
+		log.DefaultLogger, new(MockTXManager))
+
+	// admin only
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	assert.Error(t, uc.CreateOrganizations(ctx, []*biz.Organization{{Name: "acme"}}))
+	orgs.AssertNotCalled(t, "CreateOrganizations", mock.Anything, mock.Anything, mock.Anything)
+
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Resource.ResourceStr() == repo.NewResource4Sv1("organizations", "", "", "").ResourceStr()
+	})).Return(true, nil)
+	orgs.On("CreateOrganizations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	authz.On("CreateRule", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	authz.On("ListGroup", mock.Anything, mock.Anything, &repo.GroupFilter{Role: biz.OrgAdminSubject("acme")}).
+		Return([]*repo.Group{}, nil)
+	admin.On("ListUsers", mock.Anything, mock.Anything, &repo.UsersFilter{UserName: []string{"alice"}}).
+		Return([]*repo.User{{Id: 2, UserName: "alice"}}, nil)
+	admin.On("ListUsers", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.User{}, nil)
+	authz.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.CreateOrganizations(ctx, []*biz.Organization{{Name: "acme", Admins: []string{"alice"}}}))
+	authz.AssertCalled(t, "CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == biz.OrgAdminSubject("acme") &&
+			r.Resource.ResourceStr() == repo.NewOrgResource4Sv1("acme", "", "", "", "").ResourceStr() &&
+			r.Action == repo.ActWrite
+	}))
+	authz.AssertCalled(t, "CreateGroup", mock.Anything, mock.Anything,
+		&repo.Group{User: "alice", Role: biz.OrgAdminSubject("acme")})
+
+	// unknown admins
+	assert.Error(t, uc.CreateOrganizations(ctx, []*biz.Organization{{Name: "acme", Admins: []string{"nobody"}}}))
+}
+
+func TestOrganizationsUsecase_UpdateOrganizations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
+	orgs := new(MockOrganizationsRepo)
+	authz := new(MockAuthzRepo)
+	admin := new(MockAdminRepo)
+	uc := biz.NewOrganizationsUsecase(orgs, authz, admin, new(MockTeamsRepo), new(MockProductsRepo),
+		log.DefaultLogger, new(MockTXManager))
+
+	orgs.On("GetOrganizations", mock.Anything, uint32(2)).Return(&repo.Organization{ID: 2, Name: "acme"}, nil)
+
+	// names are in casbin rules
+	err := uc.UpdateOrganizations(ctx, []*biz.Organization{{Id: 2, Name: "other"}})
+	assert.Error(t, err)
+
+	// admins of the organization may update it
+	authz.On("Enforce", mock.Anything, mock.Anything, &repo.AuthenRequest{
+		Sub:      "alice",
+		Resource: repo.NewOrgResource4Sv1("acme", "organizations", "", "", ""),
+		Action:   repo.ActWrite,
+	}).Return(true, nil)
+	orgs.On("UpdateOrganizations", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	authz.On("ListGroup", mock.Anything, mock.Anything, &repo.GroupFilter{Role: biz.OrgAdminSubject("acme")}).
+		Return([]*repo.Group{{User: "alice", Role: biz.OrgAdminSubject("acme")}}, nil)
+	admin.On("ListUsers", mock.Anything, mock.Anything, &repo.UsersFilter{UserName: []string{"bob"}}).
+		Return([]*repo.User{{Id: 3, UserName: "bob"}}, nil)
+	authz.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	authz.On("DeleteGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err = uc.UpdateOrganizations(ctx, []*biz.Organization{{Id: 2, Name: "acme", Admins: []string{"bob"}}})
+	assert.NoError(t, err)
+	authz.AssertCalled(t, "DeleteGroup", mock.Anything, mock.Anything,
+		&repo.Group{User: "alice", Role: biz.OrgAdminSubject("acme")})
+	authz.AssertCalled(t, "CreateGroup", mock.Anything, mock.Anything,
+		&repo.Group{User: "bob", Role: biz.OrgAdminSubject("acme")})
+}
+
+func TestOrganizationsUsecase_DeleteOrganizations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	orgs := new(MockOrganizationsRepo)
+	authz := new(MockAuthzRepo)
+	teams := new(MockTeamsRepo)
+	products := new(MockProductsRepo)
+	uc := biz.NewOrganizationsUsecase(orgs, authz, new(MockAdminRepo), teams, products,
+		log.DefaultLogger, new(MockTXManager))
+
+	// the default organization is kept
+	assert.Error(t, uc.DeleteOrganizations(ctx, []uint32{repo.DefaultOrgId}))
+	assert.Error(t, uc.DeleteOrganizations(ctx, nil))
+
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	// organizations with teams are kept
+	teamCall := teams.On("CountRequire", mock.Anything, mock.Anything, repo.RequireOrg, []uint32{2}).Return(int64(1), nil)
+	assert.Error(t, uc.DeleteOrganizations(ctx, []uint32{2}))
+	orgs.AssertNotCalled(t, "DeleteOrganizations", mock.Anything, mock.Anything, mock.Anything)
+	teamCall.Unset()
+
+	teams.On("CountRequire", mock.Anything, mock.Anything, repo.RequireOrg, []uint32{2}).Return(int64(0), nil)
+	products.On("CountRequire", mock.Anything, mock.Anything, repo.RequireOrg, []uint32{2}).Return(int64(0), nil)
+	orgs.On("ListOrganizations", mock.Anything, mock.Anything, &repo.OrganizationsFilter{Ids: []uint32{2}}).
+		Return([]*repo.Organization{{ID: 2, Name: "acme"}}, nil)
+	orgs.On("DeleteOrganizations", mock.Anything, mock.Anything, []uint32{2}).Return(nil)
+	authz.On("ListGroup", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*repo.Group{{User: "alice", Role: biz.OrgAdminSubject("acme")}}, nil)
+	authz.On("DeleteGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	authz.On("DeleteRule", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.DeleteOrganizations(ctx, []uint32{2}))
+	authz.AssertCalled(t, "DeleteGroup", mock.Anything, mock.Anything,
+		&repo.Group{User: "alice", Role: biz.OrgAdminSubject("acme")})
+	authz.AssertCalled(t, "DeleteRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == biz.OrgAdminSubject("acme")
+	}))
+}
+
+func TestApplicationsUsecase_OrgMismatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	apprepo := new(MockApplicationsRepo)
+	prdrepo := new(MockProductsRepo)
+	teamrepo := new(MockTeamsRepo)
+	orgrepo := new(MockOrganizationsRepo)
+	authzrepo := new(MockAuthzRepo)
+	adminrepo := new(MockAdminRepo)
+	ftrepo := new(MockFeaturesRepo)
+	tagrepo := new(MockTagsRepo)
+	hgrepo := new(MockHostgroupsRepo)
+	hfrepo := new(MockHostgroupFeaturesRepo)
+	usecase := biz.NewApplicationsUsecase(
+		apprepo, new(MockAppTagsRepo), new(MockAppFeaturesRepo), new(MockAppHostgroupsRepo),
+		prdrepo, teamrepo, orgrepo, ftrepo, tagrepo, hgrepo, hfrepo, newNoAttributesRepo(), newNoTagKeysRepo(), authzrepo, adminrepo, nil, new(MockTXManager))
+
+	teamrepo.On("GetTeams", mock.Anything, uint32(1)).Return(&repo.Team{ID: 1, Name: "web", OrgId: 2}, nil)
+	prdrepo.On("GetProducts", mock.Anything, uint32(1)).Return(&repo.Product{ID: 1, Name: "shop", OrgId: 1}, nil)
+	orgrepo.On("GetOrganizations", mock.Anything, uint32(2)).Return(&repo.Organization{ID: 2, Name: "acme"}, nil)
+	adminrepo.On("GetUsers", mock.Anything, mock.Anything, mock.Anything).Return(&repo.User{Id: 10, UserName: "owner"}, nil)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	prdrepo.On("CountProducts", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	teamrepo.On("CountTeams", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	ftrepo.On("CountFeatures", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	tagrepo.On("CountTags", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	hgrepo.On("CountHostgroups", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	adminrepo.On("CountUsers", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	hfrepo.On("ListHostgroupMatchFeatures", mock.Anything, mock.Anything, mock.Anything).Return([]uint32{1}, nil)
+	hgrepo.On("ListHostgroups", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Hostgroup{{Id: 1}}, nil)
+
+	// the product must be in the organization of the team
+	err := usecase.CreateApplications(ctx, []*biz.Application{{
+		Name: "web-app", OwnerId: 10, ProductId: 1, TeamId: 1,
+		FeaturesId: []uint32{1}, TagsId: []uint32{1}, HostgroupsId: []uint32{1},
+	}})
+	assert.ErrorContains(t, err, "different organizations")
+	apprepo.AssertNotCalled(t, "CreateApplications", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,138 @@
+package biz_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPageToken(t *testing.T) {
+	id, err := biz.DecodePageToken("")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), id)
+
+	id, err = biz.DecodePageToken(biz.EncodePageToken(42))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(42), id)
+
+	for _, token := range []string{"not a token", "e30", biz.EncodePageToken(0)} {
+		_, err = biz.DecodePageToken(token)
+		assert.Equal(t, biz.ErrFilterInvalidPageToken, err, token)
+	}
+}
+
+func TestListTagsPages(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "testuser")
+	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	tagsrepo := new(MockTagsRepo)
+	usecase := biz.NewTagsUsecase(tagsrepo, authzrepo, nil, new(MockAppTagsRepo),
+		new(MockHostgroupTagsRepo), newNoTagKeysRepo(), new(MockTXManager))
+
+	all := []*repo.Tag{
+		{ID: 1, Key: "env", Value: "prod"},
+		{ID: 2, Key: "env", Value: "dev"},
+		{ID: 3, Key: "env", Value: "qa"},
+	}
+	// first page
+	tagsrepo.On("ListTags", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.TagsFilter) bool {
+		return f.PageSize == 2 && f.LastId == 0
+	})).Return(all[:2], nil)
+	// page after the token
+	tagsrepo.On("ListTags", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.TagsFilter) bool {
+		return f.PageSize == 2 && f.LastId == 2
+	})).Return(all[2:], nil)
+	// totals
+	tagsrepo.On("ListTags", mock.Anything, mock.Anything, mock.MatchedBy(func(f *repo.TagsFilter) bool {
+		return f.PageSize == 0 && f.LastId == 0
+	})).Return(all, nil)
+
+	tags, page, err := usecase.ListTags(ctx, &biz.ListTagsFilter{Page: 1, PageSize: 2, WithTotal: true})
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+	assert.Equal(t, biz.EncodePageToken(2), page.NextPageToken)
+	assert.Equal(t, int64(3), *page.TotalCount)
+
+	tags, page, err = usecase.ListTags(ctx, &biz.ListTagsFilter{Page: 1, PageSize: 2, PageToken: page.NextPageToken})
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+	assert.Equal(t, uint32(3), tags[0].Id)
+	// last page
+	assert.Empty(t, page.NextPageToken)
+	assert.Nil(t, page.TotalCount)
+
+	_, _, err = usecase.ListTags(ctx, &biz.ListTagsFilter{Page: 1, PageSize: 2, PageToken: "bad"})
+	assert.Equal(t, biz.ErrFilterInvalidPageToken, err)
+}
+
+// tooManyIds exceeds the values of a list filter.
+func tooManyIds() []uint32 {
+	ids := make([]uint32, biz.MaxFilterValues+1)
+	for i := range ids {
+		ids[i] = uint32(i + 1)
+	}
+	return ids
+}
+
+// tooManyKvs exceeds the key:value pairs of a list filter.
+func tooManyKvs() []string {
+	kvs := make([]string, biz.MaxFilterValues+1)
+	for i := range kvs {
+		kvs[i] = fmt.Sprintf("key%d:value%d", i, i)
+	}
+	return kvs
+}
+
+func TestListHostgroupsFilterSort(t *testing.T) {
+	filter := biz.DefaultHostgroupFilter()
+	filter.SortBy = "size"
+	assert.Equal(t, biz.ErrFilterInvalidSort, filter.Validate())
+	filter.SortBy = repo.SortName
+	filter.NameMatch = "regexp"
+	assert.Equal(t, biz.ErrFilterInvalidNameMatch, filter.Validate())
+
+	// names are patterns unless matched exactly
+	filter.NameMatch = ""
+	filter.Names = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}
+	assert.Equal(t, biz.ErrFilterValuesExceedMax, filter.Validate())
+	filter.NameMatch = repo.NameMatchExact
+	assert.NoError(t, filter.Validate())
+
+	// tokens continue lists of the same sort and order
+	filter.PageToken = biz.EncodePageToken(5)
+	assert.Equal(t, biz.ErrFilterPageTokenSort, filter.Validate())
+	filter.PageToken = biz.EncodeSortedPageToken(5, repo.SortName, false, "web")
+	assert.NoError(t, filter.Validate())
+	filter.SortDesc = true
+	assert.Equal(t, biz.ErrFilterPageTokenSort, filter.Validate())
+	filter.PageToken = biz.EncodeSortedPageToken(5, repo.SortName, true, "web")
+	assert.NoError(t, filter.Validate())
+	dbFilter := biz.ToDBHostgroupsFilter(filter)
+	assert.Equal(t, uint32(5), dbFilter.LastId)
+	assert.Equal(t, repo.ListSort{By: repo.SortName, Desc: true, After: "web"}, dbFilter.Sort)
+
+	filter.SortBy = repo.SortUpdatedAt
+	assert.Equal(t, biz.ErrFilterPageTokenSort, filter.Validate())
+	filter.PageToken = biz.EncodeSortedPageToken(5, repo.SortUpdatedAt, true, int64(1700000000))
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, int64(1700000000), biz.ToDBHostgroupsFilter(filter).Sort.After)
+
+	// id is the default sort
+	filter.SortBy = "id"
+	filter.PageToken = biz.EncodePageToken(5)
+	assert.Equal(t, biz.ErrFilterPageTokenSort, filter.Validate())
+	filter.PageToken = biz.EncodeSortedPageToken(5, "", true, nil)
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, repo.ListSort{Desc: true}, biz.ToDBHostgroupsFilter(filter).Sort)
+	filter.SortDesc = false
+	assert.Equal(t, biz.ErrFilterPageTokenSort, filter.Validate())
+	filter.PageToken = biz.EncodePageToken(5)
+	assert.NoError(t, filter.Validate())
+}
@@ -22,6 +22,7 @@ func TestCreateProducts(t *testing.T) {
 	hgrepo := new(MockHostgroupsRepo)
 	usecase := biz.NewProductsUsecase(
 		prdrepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
 		hgrepo,
 		apprepo,
@@ -92,6 +93,7 @@ func TestUpdateProducts(t *testing.T) {
 	hgrepo := new(MockHostgroupsRepo)
 	usecase := biz.NewProductsUsecase(
 		prdrepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
 		hgrepo,
 		apprepo,
@@ -120,6 +122,8 @@ func TestUpdateProducts(t *testing.T) {
 		assert.Error(t, err)
 	}
 
+	prdrepo.On("GetProducts", ctx, mock.Anything).Return(&repo.Product{ID: 1, Name: "name"}, nil)
+
 	// enforce fail
 	prd := []*biz.Product{
 		{Id: 1, Name: "name", Code: "code"},
@@ -163,6 +167,7 @@ func TestDeleteProducts(t *testing.T) {
 	hgrepo := new(MockHostgroupsRepo)
 	usecase := biz.NewProductsUsecase(
 		prdrepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
 		hgrepo,
 		apprepo,
@@ -179,6 +184,9 @@ func TestDeleteProducts(t *testing.T) {
 	err = usecase.DeleteProducts(ctx, nil)
 	assert.Error(t, err)
 
+	prdrepo.On("ListProducts", ctx, mock.Anything, mock.Anything).Return(
+		[]*repo.Product{{ID: 1, Name: "p1"}, {ID: 2, Name: "p2"}}, nil)
+
 	// enforce fail
 	ids = []uint32{1, 2}
 	authzcall := authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(false, errors.New("PermissionDenied"))
@@ -283,6 +291,7 @@ func TestDeleteProducts(t *testing.T) {
 func TestGetProducts(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	txm := new(MockTXManager)
 	prdrepo := new(MockProductsRepo)
 	apprepo := new(MockApplicationsRepo)
@@ -290,6 +299,7 @@ func TestGetProducts(t *testing.T) {
 	hgrepo := new(MockHostgroupsRepo)
 	usecase := biz.NewProductsUsecase(
 		prdrepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
 		hgrepo,
 		apprepo,
@@ -333,6 +343,7 @@ func TestGetProducts(t *testing.T) {
 func TestListProducts(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	txm := new(MockTXManager)
 	prdrepo := new(MockProductsRepo)
 	apprepo := new(MockApplicationsRepo)
@@ -340,6 +351,7 @@ func TestListProducts(t *testing.T) {
 	hgrepo := new(MockHostgroupsRepo)
 	usecase := biz.NewProductsUsecase(
 		prdrepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
 		hgrepo,
 		apprepo,
@@ -357,7 +369,7 @@ func TestListProducts(t *testing.T) {
 		{Page: 0, PageSize: 10},
 	}
 	for _, bc := range bad_filter {
-		_, err := usecase.ListProducts(ctx, &bc)
+		_, _, err := usecase.ListProducts(ctx, &bc)
 		t.Logf("error. %v", err)
 		assert.Error(t, err)
 	}
@@ -369,7 +381,7 @@ func TestListProducts(t *testing.T) {
 	}
 	call := prdrepo.On("ListProducts", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errors.New("repo error"))
-	_, err := usecase.ListProducts(ctx, &filter)
+	_, _, err := usecase.ListProducts(ctx, &filter)
 	assert.Error(t, err)
 	call.Unset()
 
@@ -404,7 +416,7 @@ func TestListProducts(t *testing.T) {
 		},
 	}
 	prdrepo.On("ListProducts", mock.Anything, mock.Anything, mock.Anything).Return(db_prds, nil)
-	prds, err := usecase.ListProducts(ctx, &filter)
+	prds, _, err := usecase.ListProducts(ctx, &filter)
 	assert.NoError(t, err)
 	assert.Equal(t, biz_prds, prds)
 }
@@ -0,0 +1,113 @@
+package biz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseSelector(t *testing.T) {
+	reqs, err := biz.ParseSelector("team=infra, product in (meta, ads),tag:sla==9999,tag:env!=dev,!feature:gpu,feature:ssd,env notin (qa)")
+	assert.NoError(t, err)
+	assert.Equal(t, []biz.Requirement{
+		{Key: "team", Operator: biz.SelectorEquals, Values: []string{"infra"}},
+		{Key: "product", Operator: biz.SelectorIn, Values: []string{"meta", "ads"}},
+		{Key: "tag:sla", Operator: biz.SelectorEquals, Values: []string{"9999"}},
+		{Key: "tag:env", Operator: biz.SelectorNotEquals, Values: []string{"dev"}},
+		{Key: "feature:gpu", Operator: biz.SelectorNotExists},
+		{Key: "feature:ssd", Operator: biz.SelectorExists},
+		{Key: "env", Operator: biz.SelectorNotIn, Values: []string{"qa"}},
+	}, reqs)
+
+	reqs, err = biz.ParseSelector("")
+	assert.NoError(t, err)
+	assert.Empty(t, reqs)
+
+	for _, selector := range []string{
+		"team=infra,",
+		"team=",
+		"team in (a,(b))",
+		"team in (a,b",
+		"team in ()",
+		"tag:a:b=c",
+		"team=a b",
+		"=infra",
+		"a,b,c,d,e,f,g,h,i,j,k",
+	} {
+		_, err := biz.ParseSelector(selector)
+		assert.True(t, errors.Is(err, biz.ErrFilterInvalidSelector), selector)
+	}
+}
+
+func TestListHostgroupsFilterSelector(t *testing.T) {
+	filter := biz.DefaultHostgroupFilter()
+	for selector, valid := range map[string]bool{
+		"cluster=c1,dc!=x":             false,
+		"hostgroup=web":                false,
+		"tag=prod":                     false,
+		"team":                         false,
+		"!product":                     false,
+		"cluster=c1,tag:sla":           true,
+		"datacenter in (a,b),!tag:sla": true,
+	} {
+		filter.Selector = selector
+		err := filter.Validate()
+		assert.Equal(t, valid, err == nil, selector)
+	}
+
+	apps := biz.DefaultApplicationFilter()
+	apps.Selector = "hostgroup=web,team=infra"
+	assert.NoError(t, apps.Validate())
+	apps.Selector = "cluster=c1"
+	assert.True(t, errors.Is(apps.Validate(), biz.ErrFilterInvalidSelector))
+}
+
+func TestListHostgroupsSelector(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	hgrepo := new(MockHostgroupsRepo)
+	teamrepo := new(MockTeamsRepo)
+	tagrepo := new(MockTagsRepo)
+	ftrepo := new(MockFeaturesRepo)
+	usecase := biz.NewHostgroupsUsecase(
+		hgrepo, new(MockHostgroupTeamsRepo), new(MockHostgroupProductsRepo), new(MockHostgroupTagsRepo),
+		new(MockHostgroupFeaturesRepo), newNoAttributesRepo(), newNoTagKeysRepo(), new(MockClustersRepo),
+		new(MockDatacentersRepo), new(MockEnvsRepo), ftrepo, tagrepo, teamrepo, new(MockOrganizationsRepo),
+		new(MockProductsRepo), new(MockAppHostgroupsRepo), new(MockAuthzRepo), new(MockAdminRepo), nil,
+		new(MockTXManager))
+
+	// names are matched exactly, infra-ops only contains infra
+	teamrepo.On("ListTeams", ctx, mock.Anything, mock.MatchedBy(func(f *repo.TeamsFilter) bool {
+		return len(f.Codes) == 1
+	})).Return([]*repo.Team{{ID: 1, Code: "infra"}, {ID: 2, Code: "infra-ops"}}, nil)
+	teamrepo.On("ListTeams", ctx, mock.Anything, mock.MatchedBy(func(f *repo.TeamsFilter) bool {
+		return len(f.Names) == 1
+	})).Return([]*repo.Team{{ID: 3, Name: "infra", Code: "inf"}}, nil)
+	tagrepo.On("ListTags", ctx, mock.Anything, &repo.TagsFilter{Keys: []string{"sla"}}).Return([]*repo.Tag{
+		{ID: 4, Key: "sla", Value: "9999"},
+		{ID: 5, Key: "sla", Value: "99"},
+		{ID: 6, Key: "sla-tier", Value: "9999"},
+	}, nil)
+	ftrepo.On("ListFeatures", ctx, mock.Anything, &repo.FeaturesFilter{Names: []string{"gpu"}}).
+		Return([]*repo.Feature{{Id: 7, Name: "gpu", Value: "a100"}}, nil)
+	hgrepo.On("ListHostgroups", ctx, mock.Anything, mock.MatchedBy(func(f *repo.HostgroupsFilter) bool {
+		return assert.ObjectsAreEqual([]repo.Selection{
+			{Field: repo.SelectTeam, Ids: []uint32{1, 3}},
+			{Field: repo.SelectTag, Ids: []uint32{4}},
+			{Field: repo.SelectFeature, Ids: []uint32{7}, Exclude: true},
+		}, f.Selections)
+	})).Return([]*repo.Hostgroup{}, nil)
+
+	filter := biz.DefaultHostgroupFilter()
+	filter.Selector = "team=infra,tag:sla=9999,!feature:gpu"
+	hgs, _, err := usecase.ListHostgroups(ctx, filter)
+	assert.NoError(t, err)
+	assert.Empty(t, hgs)
+	hgrepo.AssertExpectations(t)
+}
@@ -0,0 +1,154 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScopeAllows(t *testing.T) {
+	list := "/api.opspillar.v1.Hostgroups/ListHostgroups"
+	update := "/api.opspillar.v1.Hostgroups/UpdateHostgroups"
+
+	assert.True(t, biz.ScopeAllows([]string{"*"}, update))
+	assert.True(t, biz.ScopeAllows([]string{"*:read"}, list))
+	assert.False(t, biz.ScopeAllows([]string{"*:read"}, update))
+	assert.True(t, biz.ScopeAllows([]string{"hostgroups"}, update))
+	assert.True(t, biz.ScopeAllows([]string{"hostgroups:read"}, list))
+	assert.False(t, biz.ScopeAllows([]string{"hostgroups:read"}, update))
+	assert.False(t, biz.ScopeAllows([]string{"applications"}, list))
+	assert.True(t, biz.ScopeAllows([]string{"applications", "hostgroups:write"}, update))
+	assert.False(t, biz.ScopeAllows([]string{"*"}, "invalid"))
+}
+
+func TestApiKey_Validate(t *testing.T) {
+	k := &biz.ApiKey{ServiceAccountId: 1, Name: "ci-key", Scopes: []string{"hostgroups:read", "*"}}
+	assert.NoError(t, k.Validate())
+
+	k.Scopes = nil
+	assert.Error(t, k.Validate())
+	k.Scopes = []string{"hostgroups:delete"}
+	assert.Error(t, k.Validate())
+	k.Scopes = []string{"Hostgroups"}
+	assert.Error(t, k.Validate())
+}
+
+func TestServiceAccountsUsecase_CreateApiKeyAndAuthenticate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	accounts := new(MockServiceAccountsRepo)
+	keys := new(MockApiKeysRepo)
+	teams := new(MockTeamsRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewServiceAccountsUsecase(accounts, keys, teams, new(MockOrganizationsRepo), authz, new(MockTXManager), log.DefaultLogger)
+
+	sa := &repo.ServiceAccount{ID: 1, Name: "ci", TeamId: 2}
+	accounts.On("GetServiceAccounts", mock.Anything, mock.Anything, uint32(1)).Return(sa, nil)
+	teams.On("GetTeams", mock.Anything, uint32(2)).Return(&repo.Team{ID: 2, Name: "ops"}, nil)
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	var stored *repo.ApiKey
+	keys.On("CreateApiKeys", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(2).([]*repo.ApiKey)[0] }).Return(nil)
+
+	created, err := uc.CreateApiKey(ctx, &biz.ApiKey{
+		ServiceAccountId: 1, Name: "ci-key", Scopes: []string{"hostgroups:read"}, ExpireDays: 1,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, created.Key, biz.ApiKeyPrefix)
+	assert.Equal(t, created.Key[:len(stored.Prefix)], stored.Prefix)
+	assert.NotContains(t, stored.Hash, created.Key)
+	assert.Greater(t, stored.ExpiresAt, time.Now().Unix())
+
+	keys.On("ListApiKeys", mock.Anything, mock.Anything, &repo.ApiKeysFilter{Hashes: []string{stored.Hash}}).
+		Return([]*repo.ApiKey{stored}, nil)
+	keys.On("ListApiKeys", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.ApiKey{}, nil)
+	keys.On("TouchApiKey", mock.Anything, stored.ID, mock.Anything).Return(nil).Once()
+
+	sub, err := uc.AuthenticateApiKey(ctx, created.Key, "/api.opspillar.v1.Hostgroups/ListHostgroups")
+	assert.NoError(t, err)
+	assert.Equal(t, "sa:ci", sub)
+
+	// out of scope
+	_, err = uc.AuthenticateApiKey(ctx, created.Key, "/api.opspillar.v1.Hostgroups/DeleteHostgroups")
+	assert.ErrorIs(t, err, repo.ErrApiKeyScope)
+
+	// unknown key
+	_, err = uc.AuthenticateApiKey(ctx, biz.ApiKeyPrefix+"unknown", "/api.opspillar.v1.Hostgroups/ListHostgroups")
+	assert.ErrorIs(t, err, biz.ErrInvalidApiKey)
+
+	// expired
+	stored.ExpiresAt = time.Now().Unix() - 1
+	_, err = uc.AuthenticateApiKey(ctx, created.Key, "/api.opspillar.v1.Hostgroups/ListHostgroups")
+	assert.ErrorIs(t, err, repo.ErrExpiredApiKey)
+}
+
+func TestServiceAccountsUsecase_CreateServiceAccounts(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	accounts := new(MockServiceAccountsRepo)
+	teams := new(MockTeamsRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewServiceAccountsUsecase(accounts, new(MockApiKeysRepo), teams, new(MockOrganizationsRepo), authz, new(MockTXManager), log.DefaultLogger)
+
+	teams.On("GetTeams", mock.Anything, uint32(2)).Return(&repo.Team{ID: 2, Name: "ops"}, nil)
+	accounts.On("CreateServiceAccounts", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	authz.On("CreateRule", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.Rule) bool {
+		return r.Sub == "sa:ci" && r.Resource.ResourceStr() == repo.NewOrgResource4Sv1(repo.DefaultOrgName, "", "ops", "", "").ResourceStr()
+	})).Return(nil)
+
+	// role binding requires admin
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Resource.ResourceStr() == repo.NewResource4Sv1("", "", "", "").ResourceStr()
+	})).Return(false, nil)
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	err := uc.CreateServiceAccounts(ctx, []*biz.ServiceAccount{{Name: "ci", TeamId: 2, Role: "admin"}})
+	assert.Error(t, err)
+
+	err = uc.CreateServiceAccounts(ctx, []*biz.ServiceAccount{{Name: "ci", TeamId: 2}})
+	assert.NoError(t, err)
+	authz.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything)
+
+	// invalid name
+	err = uc.CreateServiceAccounts(ctx, []*biz.ServiceAccount{{Name: "CI", TeamId: 2}})
+	assert.Error(t, err)
+}
+
+func TestServiceAccountsUsecase_ListVisible(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	accounts := new(MockServiceAccountsRepo)
+	keys := new(MockApiKeysRepo)
+	teams := new(MockTeamsRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewServiceAccountsUsecase(accounts, keys, teams, new(MockOrganizationsRepo), authz, new(MockTXManager), log.DefaultLogger)
+
+	teams.On("GetTeams", mock.Anything, uint32(2)).Return(&repo.Team{ID: 2, Name: "ops"}, nil)
+	teams.On("GetTeams", mock.Anything, uint32(3)).Return(&repo.Team{ID: 3, Name: "dev"}, nil)
+	sas := []*repo.ServiceAccount{{ID: 1, Name: "ci", TeamId: 2}, {ID: 2, Name: "deployer", TeamId: 3}}
+	accounts.On("ListServiceAccounts", mock.Anything, mock.Anything, mock.Anything).Return(sas, nil)
+	// the user is in team ops only
+	authz.On("BatchEnforce", mock.Anything, mock.Anything, mock.MatchedBy(func(reqs []*repo.AuthenRequest) bool {
+		return len(reqs) == 2 && reqs[0].Action == repo.ActRead &&
+			reqs[0].Resource.ResourceStr() == "v1/default/serviceaccounts/ops/ci/{user}"
+	})).Return([]bool{true, false}, nil)
+
+	res, _, err := uc.ListServiceAccounts(ctx, biz.DefaultServiceAccountsFilter())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, "ci", res[0].Name)
+
+	// api keys are visible with their service accounts
+	keys.On("ListApiKeys", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.ApiKey{
+		{ID: 1, ServiceAccountId: 1}, {ID: 2, ServiceAccountId: 2},
+	}, nil)
+	apiKeys, err := uc.ListApiKeys(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(apiKeys))
+	assert.Equal(t, uint32(1), apiKeys[0].Id)
+}
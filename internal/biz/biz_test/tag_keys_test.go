@@ -0,0 +1,151 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTagKey_Validate(t *testing.T) {
+	valid := func() *biz.TagKey {
+		return &biz.TagKey{Key: "cost-center", Pattern: "cc[0-9]+", Kinds: []string{repo.AttrKindApplication}}
+	}
+	assert.NoError(t, valid().Validate(true))
+	assert.Error(t, valid().Validate(false))
+
+	k := valid()
+	k.Key = "Cost"
+	assert.Error(t, k.Validate(true))
+	k = valid()
+	k.Pattern = "(["
+	assert.Error(t, k.Validate(true))
+	k = valid()
+	k.Kinds = []string{"clusters"}
+	assert.Error(t, k.Validate(true))
+	k = valid()
+	k.AllowedValues = []string{"a,b"}
+	assert.Error(t, k.Validate(true))
+}
+
+func TestValidateEntityTags(t *testing.T) {
+	keys := []*biz.TagKey{
+		{Key: "cost-center", Pattern: "cc[0-9]+", Required: true},
+		{Key: "runtime", AllowedValues: []string{"prod", "dev"}, Kinds: []string{repo.AttrKindHostgroup}},
+	}
+	cc := &biz.Tag{Key: "cost-center", Value: "cc12"}
+	assert.NoError(t, biz.ValidateEntityTags(keys, repo.AttrKindApplication, []*biz.Tag{cc, {Key: "free", Value: "x"}}))
+	assert.NoError(t, biz.ValidateEntityTags(keys, repo.AttrKindHostgroup, []*biz.Tag{cc, {Key: "runtime", Value: "prod"}}))
+
+	// required keys
+	assert.ErrorContains(t, biz.ValidateEntityTags(keys, repo.AttrKindApplication, nil), "cost-center is required")
+	// values
+	assert.Error(t, biz.ValidateEntityTags(keys, repo.AttrKindApplication, []*biz.Tag{{Key: "cost-center", Value: "x-cc12"}}))
+	assert.Error(t, biz.ValidateEntityTags(keys, repo.AttrKindHostgroup, []*biz.Tag{cc, {Key: "runtime", Value: "qa"}}))
+	// kinds
+	assert.ErrorContains(t, biz.ValidateEntityTags(keys, repo.AttrKindApplication,
+		[]*biz.Tag{cc, {Key: "runtime", Value: "prod"}}), "not allowed")
+}
+
+func TestTagKeysUsecase_CreateAndUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	keys := new(MockTagKeysRepo)
+	authz := new(MockAuthzRepo)
+	uc := biz.NewTagKeysUsecase(keys, authz, log.DefaultLogger, new(MockTXManager))
+	cc := &biz.TagKey{Key: "cost-center", Kinds: []string{repo.AttrKindApplication, repo.AttrKindHostgroup}, Required: true}
+
+	// admin only
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	assert.Error(t, uc.CreateTagKeys(ctx, []*biz.TagKey{cc}))
+	keys.AssertNotCalled(t, "CreateTagKeys", mock.Anything, mock.Anything, mock.Anything)
+
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Resource.ResourceStr() == repo.NewResource4Sv1("tagkeys", "", "", "").ResourceStr() &&
+			r.Action == repo.ActWrite
+	})).Return(true, nil)
+	keys.On("CreateTagKeys", mock.Anything, mock.Anything, []*repo.TagKey{{
+		Key: "cost-center", Kinds: "applications,hostgroups", Required: true}}).Return(nil)
+	assert.NoError(t, uc.CreateTagKeys(ctx, []*biz.TagKey{cc}))
+
+	// tags refer to keys
+	keys.On("GetTagKeys", mock.Anything, uint32(1)).Return(&repo.TagKey{ID: 1, Key: "cost-center"}, nil)
+	assert.Error(t, uc.UpdateTagKeys(ctx, []*biz.TagKey{{Id: 1, Key: "cost"}}))
+	keys.On("UpdateTagKeys", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	assert.NoError(t, uc.UpdateTagKeys(ctx, []*biz.TagKey{{Id: 1, Key: "cost-center", Pattern: "cc[0-9]+"}}))
+	keys.AssertExpectations(t)
+}
+
+func TestTagKeys_PublicByDefault(t *testing.T) {
+	// rules of everyone granted by the default config
+	authz := new(MockAuthzRepo)
+	var granted []*repo.Rule
+	authz.On("ListRule", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Rule{}, nil)
+	authz.On("CreateRule", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		granted = append(granted, args.Get(2).(*repo.Rule))
+	}).Return(nil)
+	audits := new(MockAuthzAuditsRepo)
+	audits.On("CreateAuthzAudits", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	teams := new(MockTeamsRepo)
+	teams.On("ListTeams", mock.Anything, mock.Anything, &repo.TeamsFilter{}).Return([]*repo.Team{}, nil)
+	_, err := biz.NewAuthzUsecase(&conf.Authz{}, authz, audits, new(MockAdminRepo), new(MockServiceAccountsRepo), teams,
+		new(MockOrganizationsRepo), new(MockHostgroupsRepo), new(MockApplicationsRepo), new(MockTXManager), log.DefaultLogger)
+	assert.NoError(t, err)
+
+	// a user without grants reads what everyone may read
+	users := new(MockAuthzRepo)
+	users.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		for _, g := range granted {
+			if g.Sub == repo.SubEveryone && g.Action == r.Action && g.Resource.ResourceStr() == r.Resource.ResourceStr() {
+				return true
+			}
+		}
+		return false
+	})).Return(true, nil)
+	users.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	keys := new(MockTagKeysRepo)
+	keys.On("ListTagKeys", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.TagKey{{ID: 1, Key: "cost-center", Required: true}}, nil)
+	uc := biz.NewTagKeysUsecase(keys, users, log.DefaultLogger, new(MockTXManager))
+
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
+	list, _, err := uc.ListTagKeys(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Error(t, uc.CreateTagKeys(ctx, []*biz.TagKey{{Key: "owner"}}))
+}
+
+func TestTagKeys_GovernTags(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
+	keys := new(MockTagKeysRepo)
+	keys.On("ListTagKeys", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.TagKey{
+		{Key: "cost-center", Pattern: "cc[0-9]+", Required: true}}, nil)
+	authz := new(MockAuthzRepo)
+	authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	tagsrepo := new(MockTagsRepo)
+
+	// tag values of registered keys
+	tags := biz.NewTagsUsecase(tagsrepo, authz, nil, new(MockAppTagsRepo), new(MockHostgroupTagsRepo), keys,
+		new(MockTXManager))
+	assert.ErrorContains(t, tags.CreateTags(ctx, []*biz.Tag{{Key: "cost-center", Value: "ops"}}), "not match")
+	tagsrepo.AssertNotCalled(t, "CreateTags", mock.Anything, mock.Anything, mock.Anything)
+
+	// required tags of applications
+	apprepo := new(MockApplicationsRepo)
+	apps := biz.NewApplicationsUsecase(
+		apprepo, new(MockAppTagsRepo), new(MockAppFeaturesRepo), new(MockAppHostgroupsRepo),
+		new(MockProductsRepo), new(MockTeamsRepo), new(MockOrganizationsRepo), new(MockFeaturesRepo), tagsrepo,
+		new(MockHostgroupsRepo), new(MockHostgroupFeaturesRepo), newNoAttributesRepo(), keys, authz,
+		new(MockAdminRepo), nil, new(MockTXManager))
+	tagsrepo.On("ListTags", mock.Anything, mock.Anything, &repo.TagsFilter{Ids: []uint32{2}}).
+		Return([]*repo.Tag{{ID: 2, Key: "runtime", Value: "prod"}}, nil)
+	err := apps.CreateApplications(ctx, []*biz.Application{
+		{Name: "web", OwnerId: 1, ProductId: 1, TeamId: 1, TagsId: []uint32{2}}})
+	assert.ErrorContains(t, err, "tag cost-center is required")
+	apprepo.AssertNotCalled(t, "CreateApplications", mock.Anything, mock.Anything, mock.Anything)
+}
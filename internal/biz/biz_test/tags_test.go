@@ -25,6 +25,7 @@ func TestCreateTags(t *testing.T) {
 		nil,
 		apptagrepo,
 		hgtagrepo,
+		newNoTagKeysRepo(),
 		txm,
 	)
 
@@ -105,6 +106,7 @@ func TestUpdateTags(t *testing.T) {
 		nil,
 		apptagrepo,
 		hgtagrepo,
+		newNoTagKeysRepo(),
 		txm,
 	)
 
@@ -205,6 +207,7 @@ func TestDeleteTags(t *testing.T) {
 		nil,
 		apptagrepo,
 		hgtagrepo,
+		newNoTagKeysRepo(),
 		txm,
 	)
 
@@ -287,6 +290,7 @@ func TestGetTags(t *testing.T) {
 
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "testuser")
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	tagsrepo := new(MockTagsRepo)
 	apptagrepo := new(MockAppTagsRepo)
 	hgtagrepo := new(MockHostgroupTagsRepo)
@@ -297,6 +301,7 @@ func TestGetTags(t *testing.T) {
 		nil,
 		apptagrepo,
 		hgtagrepo,
+		newNoTagKeysRepo(),
 		txm,
 	)
 	// id == 0
@@ -335,6 +340,7 @@ func TestGetTags(t *testing.T) {
 func TestListTags(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "testuser")
 	authzrepo := new(MockAuthzRepo)
+	authzrepo.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	tagsrepo := new(MockTagsRepo)
 	apptagrepo := new(MockAppTagsRepo)
 	hgtagrepo := new(MockHostgroupTagsRepo)
@@ -345,6 +351,7 @@ func TestListTags(t *testing.T) {
 		nil,
 		apptagrepo,
 		hgtagrepo,
+		newNoTagKeysRepo(),
 		txm,
 	)
 
@@ -352,39 +359,39 @@ func TestListTags(t *testing.T) {
 	filter := biz.ListTagsFilter{
 		Keys: []string{"key1", "key2", "key3", "key4", "key5", "key6", "key7", "key8", "key9", "key10", "key11", "key12", "key13", "key14", "key15", "key16", "key17", "key18", "key19", "key20", "key21", "key22", "key23", "key24", "key25", "key26", "key27", "key28", "key29"},
 	}
-	_, err := usecase.ListTags(ctx, &filter)
+	_, _, err := usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz.ErrFilterValuesExceedMax, err)
 	// filter ids exceeds
 	filter = biz.ListTagsFilter{
-		Ids: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29},
+		Ids: tooManyIds(),
 	}
-	_, err = usecase.ListTags(ctx, &filter)
+	_, _, err = usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz.ErrFilterValuesExceedMax, err)
 	// filter kvs exceeds
 	filter = biz.ListTagsFilter{
-		Kvs: []string{"key1=value1", "key2=value2", "key3=value3", "key4=value4", "key5=value5", "key6=value6", "key7=value7", "key8=value8", "key9=value9", "key10=value1", "key11=value1", "key12=value1", "key13=value1", "key14=value1", "key15=value1", "key16=value1", "key17=value1", "key18=value1", "key19=value1", "key20=value1", "key21=value1", "key22=value1", "key23=value1"},
+		Kvs: tooManyKvs(),
 	}
-	_, err = usecase.ListTags(ctx, &filter)
+	_, _, err = usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz.ErrFilterValuesExceedMax, err)
 	// filter malform kvs
 	filter = biz.ListTagsFilter{
 		Kvs: []string{"key1=value1", "key2=value2", "key3=value3", "key4=value4", "key5=value5", "key6=value6", "key7=value7", "key8=value8", "key9=value9", "key10=value1"},
 	}
-	_, err = usecase.ListTags(ctx, &filter)
+	_, _, err = usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz.ErrFilterKVInvalid, err)
 	// filter pagesize ==0
 	filter = biz.ListTagsFilter{
 		Page:     1,
 		PageSize: 0,
 	}
-	_, err = usecase.ListTags(ctx, &filter)
+	_, _, err = usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz.ErrFilterInvalidPagesize, err)
 	// filter pagesize exceeds max
 	filter = biz.ListTagsFilter{
 		Page:     1,
 		PageSize: 201,
 	}
-	_, err = usecase.ListTags(ctx, &filter)
+	_, _, err = usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz.ErrFilterInvalidPagesize, err)
 	// repo return error
 	filter = biz.ListTagsFilter{
@@ -393,7 +400,7 @@ func TestListTags(t *testing.T) {
 	}
 	call := tagsrepo.On("ListTags", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errors.New("repo error")).Once()
-	_, err = usecase.ListTags(ctx, &filter)
+	_, _, err = usecase.ListTags(ctx, &filter)
 	assert.Equal(t, errors.New("repo error"), err)
 	call.Unset()
 	// success
@@ -422,7 +429,45 @@ func TestListTags(t *testing.T) {
 		},
 	}
 	tagsrepo.On("ListTags", mock.Anything, mock.Anything, mock.Anything).Return(db_tags, nil)
-	tags, _ := usecase.ListTags(context.Background(), &filter)
+	tags, _, _ := usecase.ListTags(ctx, &filter)
 	assert.Equal(t, biz_tags, tags)
 
+	// tags are not public
+	denied := new(MockAuthzRepo)
+	denied.On("Enforce", mock.Anything, mock.Anything, mock.MatchedBy(func(r *repo.AuthenRequest) bool {
+		return r.Action == repo.ActRead && r.Resource.ResourceStr() == "v1/{org}/tags/{team}/{resource_id}/{user}"
+	})).Return(false, nil)
+	usecase = biz.NewTagsUsecase(tagsrepo, denied, nil, apptagrepo, hgtagrepo, newNoTagKeysRepo(), txm)
+	_, _, err = usecase.ListTags(ctx, &filter)
+	assert.Error(t, err)
+
+}
+
+func TestCreateTagsReturnsIds(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "testuser")
+	authzrepo := new(MockAuthzRepo)
+	tagsrepo := new(MockTagsRepo)
+	usecase := biz.NewTagsUsecase(
+		tagsrepo,
+		authzrepo,
+		nil,
+		new(MockAppTagsRepo),
+		new(MockHostgroupTagsRepo),
+		newNoTagKeysRepo(),
+		new(MockTXManager),
+	)
+
+	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(true, nil)
+	// the repo assigns ids on insert
+	tagsrepo.On("CreateTags", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		for i, tag := range args.Get(1).([]*repo.Tag) {
+			tag.ID = uint32(10 + i)
+		}
+	}).Return(nil)
+
+	tags := []*biz.Tag{{Key: "zone", Value: "z1"}, {Key: "zone", Value: "z2"}}
+	err := usecase.CreateTags(ctx, tags)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), tags[0].Id)
+	assert.Equal(t, uint32(11), tags[1].Id)
 }
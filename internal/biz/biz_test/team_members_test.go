@@ -0,0 +1,168 @@
+package biz_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type teamMocks struct {
+	teams   *MockTeamsRepo
+	authz   *MockAuthzRepo
+	members *MockTeamMembersRepo
+	admin   *MockAdminRepo
+}
+
+func newTeamMembersUsecase(t *testing.T) (*biz.TeamsUsecase, *teamMocks) {
+	m := &teamMocks{
+		teams:   new(MockTeamsRepo),
+		authz:   new(MockAuthzRepo),
+		members: new(MockTeamMembersRepo),
+		admin:   new(MockAdminRepo),
+	}
+	uc := biz.NewTeamsUsecase(m.teams, new(MockOrganizationsRepo), m.authz, m.members, m.admin, new(MockHostgroupsRepo), new(MockHostgroupTeamsRepo),
+		new(MockApplicationsRepo), new(MockServiceAccountsRepo), log.DefaultLogger, new(MockTXManager))
+	m.teams.On("GetTeams", mock.Anything, uint32(1)).Return(&repo.Team{ID: 1, Name: "dev-team", LeaderId: 1}, nil)
+	for id, name := range map[uint32]string{1: "lead", 2: "alice", 3: "bob"} {
+		m.admin.On("GetUsers", mock.Anything, id).Return(&repo.User{Id: id, UserName: name}, nil)
+	}
+	m.members.On("ListTeamMembers", mock.Anything, mock.Anything, &repo.TeamMembersFilter{TeamIds: []uint32{1}}).
+		Return([]*repo.TeamMember{
+			{ID: 1, TeamId: 1, UserId: 1, Role: repo.TeamRoleLeader},
+			{ID: 2, TeamId: 1, UserId: 2, Role: repo.TeamRoleMember},
+		}, nil)
+	m.authz.On("CreateGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.authz.On("CreateRule", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return uc, m
+}
+
+func TestTeamMember_Validate(t *testing.T) {
+	assert.NoError(t, (&biz.TeamMember{UserId: 2, Role: repo.TeamRoleMaintainer}).Validate())
+	assert.Error(t, (&biz.TeamMember{UserId: 2, Role: repo.TeamRoleLeader}).Validate())
+	assert.Error(t, (&biz.TeamMember{UserId: 2, Role: "owner"}).Validate())
+	assert.Error(t, (&biz.TeamMember{Role: repo.TeamRoleMember}).Validate())
+}
+
+func TestTeamsUsecase_AddTeamMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "lead")
+	uc, m := newTeamMembersUsecase(t)
+
+	// leader, maintainers and admins manage members
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	err := uc.AddTeamMembers(ctx, 1, []*biz.TeamMember{{UserId: 3, Role: repo.TeamRoleMember}})
+	assert.ErrorIs(t, err, biz.ErrPermissionDenied)
+	assert.ErrorContains(t, err, "AddTeamMembers failed")
+	m.authz.On("Enforce", mock.Anything, mock.Anything, &repo.AuthenRequest{
+		Sub:      "lead",
+		Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "team", "dev-team", "members", "lead"),
+		Action:   repo.ActWrite,
+	}).Return(true, nil)
+
+	m.members.On("CreateTeamMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.members.On("UpdateTeamMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.authz.On("ListGroup", mock.Anything, mock.Anything, &repo.GroupFilter{User: "alice"}).
+		Return([]*repo.Group{{User: "alice", Role: "dev-team:member"}}, nil)
+	m.authz.On("DeleteGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err = uc.AddTeamMembers(ctx, 1, []*biz.TeamMember{
+		{UserId: 3, Role: repo.TeamRoleMember},
+		{UserId: 2, Role: repo.TeamRoleMaintainer},
+	})
+	assert.NoError(t, err)
+	m.members.AssertCalled(t, "CreateTeamMembers", mock.Anything, mock.Anything, mock.MatchedBy(func(ms []*repo.TeamMember) bool {
+		return len(ms) == 1 && ms[0].UserId == 3 && ms[0].Role == repo.TeamRoleMember
+	}))
+	// promoted member moves to the maintainer role
+	m.authz.AssertCalled(t, "DeleteGroup", mock.Anything, mock.Anything, &repo.Group{User: "alice", Role: "dev-team:member"})
+	m.authz.AssertCalled(t, "CreateGroup", mock.Anything, mock.Anything, &repo.Group{User: "alice", Role: "dev-team:maintainer"})
+	m.authz.AssertCalled(t, "CreateGroup", mock.Anything, mock.Anything, &repo.Group{User: "bob", Role: "dev-team:member"})
+	m.authz.AssertCalled(t, "CreateRule", mock.Anything, mock.Anything, &repo.Rule{
+		Sub:      "dev-team:maintainer",
+		Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "", "dev-team", "", ""),
+		Action:   repo.ActWrite,
+	})
+	m.authz.AssertCalled(t, "CreateRule", mock.Anything, mock.Anything, &repo.Rule{
+		Sub:      "dev-team:member",
+		Resource: repo.NewOrgResource4Sv1(repo.DefaultOrgName, "", "dev-team", "", ""),
+		Action:   repo.ActRead,
+	})
+
+	// the leader is changed by updating the team
+	err = uc.AddTeamMembers(ctx, 1, []*biz.TeamMember{{UserId: 1, Role: repo.TeamRoleMember}})
+	assert.Error(t, err)
+}
+
+func TestTeamsUsecase_RemoveTeamMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "lead")
+	uc, m := newTeamMembersUsecase(t)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.members.On("ListTeamMembers", mock.Anything, mock.Anything, &repo.TeamMembersFilter{TeamIds: []uint32{1}, UserIds: []uint32{1}}).
+		Return([]*repo.TeamMember{{ID: 1, TeamId: 1, UserId: 1, Role: repo.TeamRoleLeader}}, nil)
+	m.members.On("ListTeamMembers", mock.Anything, mock.Anything, &repo.TeamMembersFilter{TeamIds: []uint32{1}, UserIds: []uint32{2}}).
+		Return([]*repo.TeamMember{{ID: 2, TeamId: 1, UserId: 2, Role: repo.TeamRoleMember}}, nil)
+	m.members.On("ListTeamMembers", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.TeamMember{}, nil)
+	m.authz.On("ListGroup", mock.Anything, mock.Anything, &repo.GroupFilter{User: "alice"}).
+		Return([]*repo.Group{{User: "alice", Role: "dev-team:member"}}, nil)
+	m.authz.On("DeleteGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.members.On("DeleteTeamMembers", mock.Anything, mock.Anything, []uint32{2}).Return(nil)
+
+	assert.Error(t, uc.RemoveTeamMembers(ctx, 1, []uint32{1}))
+	// not a member
+	assert.ErrorContains(t, uc.RemoveTeamMembers(ctx, 1, []uint32{3}), "RemoveTeamMembers failed")
+	assert.NoError(t, uc.RemoveTeamMembers(ctx, 1, []uint32{2}))
+	m.authz.AssertCalled(t, "DeleteGroup", mock.Anything, mock.Anything, &repo.Group{User: "alice", Role: "dev-team:member"})
+	m.members.AssertNumberOfCalls(t, "DeleteTeamMembers", 1)
+}
+
+func TestTeamsUsecase_UpdateTeamsLeader(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "admin")
+	uc, m := newTeamMembersUsecase(t)
+	m.authz.On("Enforce", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	m.teams.On("UpdateTeams", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.members.On("UpdateTeamMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.authz.On("ListGroup", mock.Anything, mock.Anything, mock.Anything).Return([]*repo.Group{
+		{User: "lead", Role: "dev-team:leader"},
+		{User: "alice", Role: "dev-team:member"},
+	}, nil)
+	m.authz.On("DeleteGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// alice becomes the leader, the former leader stays as maintainer
+	err := uc.UpdateTeams(ctx, []*biz.Team{{Id: 1, Name: "dev-team", Code: "dev", LeaderId: 2}})
+	assert.NoError(t, err)
+	m.members.AssertCalled(t, "UpdateTeamMembers", mock.Anything, mock.Anything, []*repo.TeamMember{
+		{ID: 1, TeamId: 1, UserId: 1, Role: repo.TeamRoleMaintainer},
+	})
+	m.members.AssertCalled(t, "UpdateTeamMembers", mock.Anything, mock.Anything, []*repo.TeamMember{
+		{ID: 2, TeamId: 1, UserId: 2, Role: repo.TeamRoleLeader},
+	})
+	m.authz.AssertCalled(t, "DeleteGroup", mock.Anything, mock.Anything, &repo.Group{User: "lead", Role: "dev-team:leader"})
+	m.authz.AssertCalled(t, "CreateGroup", mock.Anything, mock.Anything, &repo.Group{User: "lead", Role: "dev-team:maintainer"})
+	m.authz.AssertCalled(t, "CreateGroup", mock.Anything, mock.Anything, &repo.Group{User: "alice", Role: "dev-team:leader"})
+}
+
+func TestTeamsUsecase_ListTeamMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "bob")
+	uc, m := newTeamMembersUsecase(t)
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, &repo.UsersFilter{Ids: []uint32{1}}).
+		Return([]*repo.User{{Id: 1, UserName: "lead"}}, nil)
+	m.admin.On("ListUsers", mock.Anything, mock.Anything, &repo.UsersFilter{Ids: []uint32{1, 2}}).
+		Return([]*repo.User{{Id: 1, UserName: "lead"}, {Id: 2, UserName: "alice"}}, nil)
+
+	// members of other teams can not read the team
+	m.authz.On("BatchEnforce", mock.Anything, mock.Anything, mock.Anything).Return([]bool{false}, nil).Once()
+	_, err := uc.ListTeamMembers(ctx, 1)
+	assert.Error(t, err)
+
+	m.authz.On("BatchEnforce", mock.Anything, mock.Anything, mock.Anything).Return([]bool{true}, nil)
+	members, err := uc.ListTeamMembers(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(members))
+	assert.Equal(t, "lead", members[0].UserName)
+}
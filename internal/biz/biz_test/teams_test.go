@@ -17,16 +17,23 @@ func TestCreateTeams(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
 	teamRepo := new(MockTeamsRepo)
 	authzrepo := new(MockAuthzRepo)
+	memberrepo := new(MockTeamMembersRepo)
+	adminrepo := new(MockAdminRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	hgteamrepo := new(MockHostgroupTeamsRepo)
 	apprepo := new(MockApplicationsRepo)
+	sarepo := new(MockServiceAccountsRepo)
 	txm := new(MockTXManager)
 	usecase := biz.NewTeamsUsecase(
 		teamRepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
+		memberrepo,
+		adminrepo,
 		hgrepo,
 		hgteamrepo,
 		apprepo,
+		sarepo,
 		nil,
 		txm,
 	)
@@ -82,6 +89,12 @@ func TestCreateTeams(t *testing.T) {
 	authcall.Unset()
 
 	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(true, nil)
+	// the leader becomes a member with casbin roles of the team
+	memberrepo.On("ListTeamMembers", ctx, mock.Anything, mock.Anything).Return([]*repo.TeamMember{}, nil)
+	memberrepo.On("CreateTeamMembers", ctx, mock.Anything, mock.Anything).Return(nil)
+	adminrepo.On("GetUsers", ctx, uint32(1)).Return(&repo.User{Id: 1, UserName: "leader"}, nil)
+	authzrepo.On("CreateGroup", ctx, mock.Anything, mock.Anything).Return(nil)
+	authzrepo.On("CreateRule", ctx, mock.Anything, mock.Anything).Return(nil)
 
 	// Test case: Creation fails
 	teams = []*biz.Team{{Name: "valid", Code: "validcode", LeaderId: 1}}
@@ -113,16 +126,23 @@ func TestUpdateTeams(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
 	teamRepo := new(MockTeamsRepo)
 	authzrepo := new(MockAuthzRepo)
+	memberrepo := new(MockTeamMembersRepo)
+	adminrepo := new(MockAdminRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	hgteamrepo := new(MockHostgroupTeamsRepo)
 	apprepo := new(MockApplicationsRepo)
+	sarepo := new(MockServiceAccountsRepo)
 	txm := new(MockTXManager)
 	usecase := biz.NewTeamsUsecase(
 		teamRepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
+		memberrepo,
+		adminrepo,
 		hgrepo,
 		hgteamrepo,
 		apprepo,
+		sarepo,
 		nil,
 		txm,
 	)
@@ -177,6 +197,8 @@ func TestUpdateTeams(t *testing.T) {
 	err = usecase.UpdateTeams(ctx, teams)
 	assert.Error(t, err)
 
+	teamRepo.On("GetTeams", ctx, uint32(1)).Return(&repo.Team{ID: 1, Name: "name", Code: "validcode", LeaderId: 1}, nil)
+
 	// enforce fail
 	teams = []*biz.Team{{Id: 1, Name: "valid", Code: "validcode", LeaderId: 1}}
 	authcall := authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(false, errors.New("enforce failed"))
@@ -184,6 +206,15 @@ func TestUpdateTeams(t *testing.T) {
 	assert.Error(t, err)
 	authcall.Unset()
 	authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(true, nil)
+	// the leader becomes a member with casbin roles of the team
+	memberrepo.On("ListTeamMembers", ctx, mock.Anything, mock.Anything).Return([]*repo.TeamMember{}, nil)
+	memberrepo.On("CreateTeamMembers", ctx, mock.Anything, mock.Anything).Return(nil)
+	adminrepo.On("GetUsers", ctx, uint32(1)).Return(&repo.User{Id: 1, UserName: "leader"}, nil)
+	authzrepo.On("CreateGroup", ctx, mock.Anything, mock.Anything).Return(nil)
+	authzrepo.On("CreateRule", ctx, mock.Anything, mock.Anything).Return(nil)
+	// renamed teams move their casbin roles
+	authzrepo.On("ListGroup", ctx, mock.Anything, mock.Anything).Return([]*repo.Group{}, nil)
+	authzrepo.On("DeleteRule", ctx, mock.Anything, mock.Anything).Return(nil)
 
 	// Test case: Creation fails
 	teams = []*biz.Team{{Id: 1, Name: "valid", Code: "validcode", LeaderId: 1}}
@@ -215,16 +246,23 @@ func TestDeleteTeams(t *testing.T) {
 	ctx := context.WithValue(context.Background(), data.CtxUserName, "user")
 	teamRepo := new(MockTeamsRepo)
 	authzrepo := new(MockAuthzRepo)
+	memberrepo := new(MockTeamMembersRepo)
+	adminrepo := new(MockAdminRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	hgteamrepo := new(MockHostgroupTeamsRepo)
 	apprepo := new(MockApplicationsRepo)
+	sarepo := new(MockServiceAccountsRepo)
 	txm := new(MockTXManager)
 	usecase := biz.NewTeamsUsecase(
 		teamRepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
+		memberrepo,
+		adminrepo,
 		hgrepo,
 		hgteamrepo,
 		apprepo,
+		sarepo,
 		nil,
 		txm,
 	)
@@ -237,6 +275,10 @@ func TestDeleteTeams(t *testing.T) {
 	err = usecase.DeleteTeams(ctx, nil)
 	assert.Error(t, err)
 
+	teams = []uint32{1, 2}
+	teamRepo.On("ListTeams", ctx, mock.Anything, &repo.TeamsFilter{Ids: teams}).
+		Return([]*repo.Team{{ID: 1, Name: "team1"}, {ID: 2, Name: "team2"}}, nil)
+
 	// enforce fail
 	authcall := authzrepo.On("Enforce", ctx, mock.Anything, mock.Anything).Return(false, errors.New("enforce failed"))
 	err = usecase.DeleteTeams(ctx, teams)
@@ -297,6 +339,24 @@ func TestDeleteTeams(t *testing.T) {
 	htrepoCall.Unset()
 	teamrepoCall.Unset()
 
+	// Test case: failed on service account need check fail
+	teams = []uint32{1, 2}
+	hgrepoCall = hgrepo.On("CountRequire",
+		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(0), nil)
+	apprepoCall = apprepo.On("CountRequire",
+		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(0), nil)
+	htrepoCall = hgteamrepo.On("CountRequire",
+		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(0), nil)
+	sarepoCall := sarepo.On("CountRequire",
+		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(1), nil)
+	err = usecase.DeleteTeams(ctx, teams)
+	assert.Error(t, err)
+	t.Logf("error. %v", err)
+	hgrepoCall.Unset()
+	apprepoCall.Unset()
+	htrepoCall.Unset()
+	sarepoCall.Unset()
+
 	// Test case: failed on delete
 	teams = []uint32{1, 2}
 	hgrepoCall = hgrepo.On("CountRequire",
@@ -305,6 +365,8 @@ func TestDeleteTeams(t *testing.T) {
 		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(0), nil)
 	htrepoCall = hgteamrepo.On("CountRequire",
 		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(0), nil)
+	sarepoCall = sarepo.On("CountRequire",
+		ctx, mock.Anything, repo.RequireTeam, teams).Return(int64(0), nil)
 	teamrepoCall = teamRepo.On("DeleteTeams",
 		ctx, mock.Anything, teams).Return(errors.New("delete mock fail"))
 	err = usecase.DeleteTeams(ctx, teams)
@@ -313,23 +375,31 @@ func TestDeleteTeams(t *testing.T) {
 	hgrepoCall.Unset()
 	apprepoCall.Unset()
 	htrepoCall.Unset()
+	sarepoCall.Unset()
 	teamrepoCall.Unset()
 }
 
 func TestGetTeams(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	teamRepo := new(MockTeamsRepo)
 	authzrepo := new(MockAuthzRepo)
+	memberrepo := new(MockTeamMembersRepo)
+	adminrepo := new(MockAdminRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	hgteamrepo := new(MockHostgroupTeamsRepo)
 	apprepo := new(MockApplicationsRepo)
+	sarepo := new(MockServiceAccountsRepo)
 	txm := new(MockTXManager)
 	usecase := biz.NewTeamsUsecase(
 		teamRepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
+		memberrepo,
+		adminrepo,
 		hgrepo,
 		hgteamrepo,
 		apprepo,
+		sarepo,
 		nil,
 		txm,
 	)
@@ -364,6 +434,17 @@ func TestGetTeams(t *testing.T) {
 		Description: "team1 description",
 	}
 	call = teamRepo.On("GetTeams", ctx, team_id).Return(&db_team, nil)
+	adminrepo.On("ListUsers", ctx, mock.Anything, &repo.UsersFilter{Ids: []uint32{1}}).
+		Return([]*repo.User{{Id: 1, UserName: "lead1"}}, nil)
+	// not a member of the team
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{false}, nil).Once()
+	_, err = usecase.GetTeams(ctx, team_id)
+	assert.Error(t, err)
+
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.MatchedBy(func(reqs []*repo.AuthenRequest) bool {
+		return len(reqs) == 1 && reqs[0].Action == repo.ActRead &&
+			reqs[0].Resource.ResourceStr() == "v1/default/team/team1/{resource_id}/lead1"
+	})).Return([]bool{true}, nil)
 	team, err := usecase.GetTeams(ctx, team_id)
 	t.Logf("team. %+v", team)
 	assert.NoError(t, err)
@@ -372,19 +453,26 @@ func TestGetTeams(t *testing.T) {
 }
 
 func TestListTeams(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), data.CtxUserName, "alice")
 	teamRepo := new(MockTeamsRepo)
 	authzrepo := new(MockAuthzRepo)
+	memberrepo := new(MockTeamMembersRepo)
+	adminrepo := new(MockAdminRepo)
 	hgrepo := new(MockHostgroupsRepo)
 	hgteamrepo := new(MockHostgroupTeamsRepo)
 	apprepo := new(MockApplicationsRepo)
+	sarepo := new(MockServiceAccountsRepo)
 	txm := new(MockTXManager)
 	usecase := biz.NewTeamsUsecase(
 		teamRepo,
+		new(MockOrganizationsRepo),
 		authzrepo,
+		memberrepo,
+		adminrepo,
 		hgrepo,
 		hgteamrepo,
 		apprepo,
+		sarepo,
 		nil,
 		txm,
 	)
@@ -393,43 +481,43 @@ func TestListTeams(t *testing.T) {
 	filter := biz.ListTeamsFilter{
 		Codes: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z", "a", "b", "c", "d", "e", "f", "g", "h", "i", "j"},
 	}
-	_, e := usecase.ListTeams(context.Background(), &filter)
+	_, _, e := usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, biz.ErrFilterValuesExceedMax)
 	// filter error. ids exceeds
 	filter = biz.ListTeamsFilter{
-		Ids: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34},
+		Ids: tooManyIds(),
 	}
-	_, e = usecase.ListTeams(context.Background(), &filter)
+	_, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, biz.ErrFilterValuesExceedMax)
 	// filter error. name exceeds
 	filter = biz.ListTeamsFilter{
 		Names: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "30", "31", "32", "33", "34"},
 	}
-	_, e = usecase.ListTeams(context.Background(), &filter)
+	_, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, biz.ErrFilterValuesExceedMax)
 	// filter error. leaders exceeds
 	filter = biz.ListTeamsFilter{
-		LeadersId: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34},
+		LeadersId: tooManyIds(),
 	}
-	_, e = usecase.ListTeams(context.Background(), &filter)
+	_, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, biz.ErrFilterValuesExceedMax)
 	// filter error. pagesize 0
 	filter = biz.ListTeamsFilter{
 		PageSize: 0,
 	}
-	_, e = usecase.ListTeams(context.Background(), &filter)
+	_, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, biz.ErrFilterInvalidPagesize)
 	// filter error. pagesize exceeds
 	filter = biz.ListTeamsFilter{
 		PageSize: 201,
 	}
-	_, e = usecase.ListTeams(context.Background(), &filter)
+	_, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, biz.ErrFilterInvalidPagesize)
 	// repo error
 	filter = *biz.DefaultTeamsFilter()
 	call := teamRepo.On("ListTeams",
 		ctx, mock.Anything, mock.Anything).Return(nil, errors.New("repo error"))
-	_, e = usecase.ListTeams(context.Background(), &filter)
+	_, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Equal(t, e, errors.New("repo error"))
 	call.Unset()
 
@@ -465,8 +553,15 @@ func TestListTeams(t *testing.T) {
 		},
 	}
 	teamRepo.On("ListTeams", mock.Anything, mock.Anything, mock.Anything).Return(db_teams, nil)
+	adminrepo.On("ListUsers", ctx, mock.Anything, &repo.UsersFilter{Ids: []uint32{1, 2}}).
+		Return([]*repo.User{{Id: 1, UserName: "lead1"}, {Id: 2, UserName: "lead2"}}, nil)
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{false, true}, nil).Once()
+	ts, _, e := usecase.ListTeams(ctx, &filter)
+	assert.Nil(t, e)
+	assert.Equal(t, biz_teams[1:], ts)
 
-	ts, e := usecase.ListTeams(ctx, &filter)
+	authzrepo.On("BatchEnforce", ctx, mock.Anything, mock.Anything).Return([]bool{true, true}, nil)
+	ts, _, e = usecase.ListTeams(ctx, &filter)
 	assert.Nil(t, e)
 	assert.Equal(t, biz_teams, ts)
 }
@@ -0,0 +1,265 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// BreakGlassPrefix marks break-glass secrets.
+const BreakGlassPrefix = "opbg_"
+
+var ErrInvalidBreakGlass = PermissionDenied("invalid break-glass credential")
+
+type BreakGlassUsecase struct {
+	breakGlassRepo repo.BreakGlassRepo
+	adminRepo      repo.AdminRepo
+	authzRepo      repo.AuthzRepo
+	txm            repo.TxManager
+	log            *log.Helper
+}
+
+func NewBreakGlassUsecase(
+	breakGlassRepo repo.BreakGlassRepo,
+	adminRepo repo.AdminRepo,
+	authzRepo repo.AuthzRepo,
+	txm repo.TxManager,
+	logger log.Logger,
+) *BreakGlassUsecase {
+	return &BreakGlassUsecase{
+		breakGlassRepo: breakGlassRepo,
+		adminRepo:      adminRepo,
+		authzRepo:      authzRepo,
+		txm:            txm,
+		log:            log.NewHelper(logger),
+	}
+}
+
+// newBreakGlassSecret returns a random secret, its hash to store and a prefix to display.
+func newBreakGlassSecret() (string, string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	secret := BreakGlassPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return secret, hashRefreshToken(secret), secret[:len(BreakGlassPrefix)+8], nil
+}
+
+// enforceAdmin requires permission on all resources, break-glass credentials are admin only.
+func (s *BreakGlassUsecase) enforceAdmin(ctx context.Context, tx repo.TX) (string, error) {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	can, err := s.authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: repo.NewResource4Sv1("", "", "", ""),
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !can {
+		return "", ErrPermissionDenied
+	}
+	return curUser, nil
+}
+
+func (s *BreakGlassUsecase) getPrincipal(ctx context.Context, tx repo.TX, name string) (*repo.User, error) {
+	users, err := s.adminRepo.ListUsers(ctx, tx, &repo.UsersFilter{UserName: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, NotFound("principal user not found")
+	}
+	return users[0], nil
+}
+
+// CreateBreakGlass creates a credential acting as an existing user. the plain secret is only returned here.
+func (s *BreakGlassUsecase) CreateBreakGlass(ctx context.Context, bg *BreakGlass) (*BreakGlass, error) {
+	if bg == nil {
+		return nil, errors.Join(errors.New("CreateBreakGlass failed"), errors.New("credential is nil"))
+	}
+	if err := bg.Validate(); err != nil {
+		return nil, errors.Join(errors.New("CreateBreakGlass failed"), invalidArgument(err))
+	}
+	secret, hash, prefix, err := newBreakGlassSecret()
+	if err != nil {
+		return nil, errors.Join(errors.New("CreateBreakGlass failed"), err)
+	}
+	now := time.Now()
+	dbCred := &repo.BreakGlass{
+		Name:          bg.Name,
+		Principal:     bg.Principal,
+		Prefix:        prefix,
+		Hash:          hash,
+		OneTime:       bg.OneTime,
+		ExpireMinutes: bg.ExpireMinutes,
+		Description:   bg.Description,
+		CreatedAt:     now.Unix(),
+	}
+	if bg.ExpireDays > 0 {
+		dbCred.ExpiresAt = now.Add(time.Duration(bg.ExpireDays) * 24 * time.Hour).Unix()
+	}
+
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		curUser, err := s.enforceAdmin(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if _, err := s.getPrincipal(ctx, tx, bg.Principal); err != nil {
+			return err
+		}
+		dbCred.CreatedBy = curUser
+		return s.breakGlassRepo.CreateBreakGlass(ctx, tx, []*repo.BreakGlass{dbCred})
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("CreateBreakGlass failed"), err)
+	}
+	s.log.Infof("break-glass credential %s for %s created by %s", dbCred.Name, dbCred.Principal, dbCred.CreatedBy)
+	created := ToBizBreakGlass(dbCred)
+	created.Secret = secret
+	return created, nil
+}
+
+// ListBreakGlass lists credentials, without the secrets.
+func (s *BreakGlassUsecase) ListBreakGlass(ctx context.Context) ([]*BreakGlass, error) {
+	if _, err := s.enforceAdmin(ctx, nil); err != nil {
+		return nil, errors.Join(errors.New("ListBreakGlass failed"), err)
+	}
+	creds, err := s.breakGlassRepo.ListBreakGlass(ctx, nil, nil)
+	if err != nil {
+		return nil, errors.Join(errors.New("ListBreakGlass failed"), err)
+	}
+	return ToBizBreakGlasses(creds), nil
+}
+
+// DeleteBreakGlass revokes credentials, their audits are kept.
+func (s *BreakGlassUsecase) DeleteBreakGlass(ctx context.Context, ids []uint32) error {
+	if len(ids) == 0 {
+		return errors.Join(errors.New("DeleteBreakGlass failed"), ErrEmptyIds)
+	}
+	ids = DedupSliceUint32(ids)
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if _, err := s.enforceAdmin(ctx, tx); err != nil {
+			return err
+		}
+		return s.breakGlassRepo.DeleteBreakGlass(ctx, tx, ids)
+	})
+	if err != nil {
+		return errors.Join(errors.New("DeleteBreakGlass failed"), err)
+	}
+	return nil
+}
+
+// ListBreakGlassAudits lists uses of credentials, newest first.
+func (s *BreakGlassUsecase) ListBreakGlassAudits(ctx context.Context, filter *ListBreakGlassAuditsFilter) ([]*BreakGlassAudit, *PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, errors.Join(errors.New("ListBreakGlassAudits failed"), invalidArgument(err))
+	}
+	if _, err := s.enforceAdmin(ctx, nil); err != nil {
+		return nil, nil, errors.Join(errors.New("ListBreakGlassAudits failed"), err)
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListBreakGlassAuditsFilter{}
+	}
+	dbFilter := ToDBBreakGlassAuditsFilter(filter)
+	audits, err := s.breakGlassRepo.ListBreakGlassAudits(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListBreakGlassAudits failed"), err)
+	}
+	page, err := listPage(audits, dbFilter.PageSize, func(a *repo.BreakGlassAudit) uint32 { return a.ID },
+		filter.WithTotal, func() ([]*repo.BreakGlassAudit, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.breakGlassRepo.ListBreakGlassAudits(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListBreakGlassAudits failed"), err)
+	}
+	return ToBizBreakGlassAudits(audits), page, nil
+}
+
+// checkBreakGlass returns why the credential can not be used now, the first use is recorded.
+func (s *BreakGlassUsecase) checkBreakGlass(ctx context.Context, cred *repo.BreakGlass, now int64) (*repo.User, error) {
+	if cred.ExpiresAt > 0 && now >= cred.ExpiresAt {
+		return nil, repo.ErrExpiredBreakGlass
+	}
+	if cred.FirstUsedAt > 0 {
+		if cred.OneTime {
+			return nil, errors.Join(repo.ErrExpiredBreakGlass, PermissionDenied("one-time credential used"))
+		}
+		if cred.ExpireMinutes > 0 && now >= cred.FirstUsedAt+int64(cred.ExpireMinutes)*60 {
+			return nil, repo.ErrExpiredBreakGlass
+		}
+	} else {
+		activated, err := s.breakGlassRepo.ActivateBreakGlass(ctx, nil, cred.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		// used concurrently
+		if !activated && cred.OneTime {
+			return nil, errors.Join(repo.ErrExpiredBreakGlass, PermissionDenied("one-time credential used"))
+		}
+	}
+	principal, err := s.getPrincipal(ctx, nil, cred.Principal)
+	if err != nil {
+		return nil, err
+	}
+	if principal.Disabled {
+		return nil, ErrUserDisabled
+	}
+	return principal, nil
+}
+
+// AuthenticateBreakGlass verifies secret and returns the principal user name and id.
+// every use of a known credential is audited, access is denied if audit can not be written.
+func (s *BreakGlassUsecase) AuthenticateBreakGlass(ctx context.Context, secret, operation, ip string) (string, uint32, error) {
+	if len(secret) <= len(BreakGlassPrefix) || secret[:len(BreakGlassPrefix)] != BreakGlassPrefix {
+		return "", 0, ErrInvalidBreakGlass
+	}
+	creds, err := s.breakGlassRepo.ListBreakGlass(ctx, nil, &repo.BreakGlassFilter{
+		Hashes: []string{hashRefreshToken(secret)},
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(creds) != 1 {
+		s.log.Warnf("unknown break-glass credential used from %s on %s", ip, operation)
+		return "", 0, ErrInvalidBreakGlass
+	}
+	cred := creds[0]
+	now := time.Now().Unix()
+	principal, authErr := s.checkBreakGlass(ctx, cred, now)
+
+	audit := &repo.BreakGlassAudit{
+		CredentialId: cred.ID,
+		Name:         cred.Name,
+		Principal:    cred.Principal,
+		Operation:    operation,
+		IP:           ip,
+		Allowed:      authErr == nil,
+		CreatedAt:    now,
+	}
+	if authErr != nil {
+		audit.Reason = authErr.Error()
+	}
+	if err := s.breakGlassRepo.CreateBreakGlassAudits(ctx, nil, []*repo.BreakGlassAudit{audit}); err != nil {
+		s.log.Errorf("audit break-glass credential %s failed: %v", cred.Name, err)
+		return "", 0, errors.Join(errors.New("audit break-glass failed"), err)
+	}
+	if authErr != nil {
+		s.log.Warnf("break-glass credential %s denied from %s on %s: %v", cred.Name, ip, operation, authErr)
+		return "", 0, authErr
+	}
+	s.log.Warnf("break-glass credential %s used as %s from %s on %s", cred.Name, cred.Principal, ip, operation)
+	return principal.UserName, principal.Id, nil
+}
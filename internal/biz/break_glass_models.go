@@ -0,0 +1,45 @@
+package biz
+
+type BreakGlass struct {
+	Id        uint32
+	Name      string
+	Principal string
+	// Secret is the plain credential, only returned on creation
+	Secret  string
+	Prefix  string
+	OneTime bool
+	// ExpireMinutes expires credential minutes after its first use, 0 disables
+	ExpireMinutes uint32
+	// ExpireDays is used on creation, 0 never expires
+	ExpireDays  uint32
+	Description string
+	ExpiresAt   int64
+	FirstUsedAt int64
+	CreatedBy   string
+	CreatedAt   int64
+}
+
+type BreakGlassAudit struct {
+	Id           uint32
+	CredentialId uint32
+	Name         string
+	Principal    string
+	Operation    string
+	IP           string
+	Allowed      bool
+	Reason       string
+	CreatedAt    int64
+}
+
+type ListBreakGlassAuditsFilter struct {
+	Page          uint32
+	PageSize      uint32
+	CredentialIds []uint32
+	// Since is unix seconds, 0 means all
+	Since int64
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
@@ -0,0 +1,99 @@
+package biz
+
+import (
+	"errors"
+
+	"opspillar/internal/data/repo"
+)
+
+func (bg *BreakGlass) Validate() error {
+	if e := ValidateName(bg.Name); e != nil {
+		return invalidField("name", e)
+	}
+	if e := ValidateName(bg.Principal); e != nil {
+		return invalidField("principal", errors.Join(errors.New("invalid principal"), e))
+	}
+	// break-glass access is always time-boxed
+	if !bg.OneTime && bg.ExpireMinutes == 0 && bg.ExpireDays == 0 {
+		return errors.New("credential must be one-time or expire")
+	}
+	return nil
+}
+
+func (lf *ListBreakGlassAuditsFilter) Validate() error {
+	if lf == nil {
+		return nil
+	}
+	if len(lf.CredentialIds) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
+		return ErrFilterInvalidPagesize
+	}
+	if lf.Page == 0 {
+		return ErrFilterInvalidPage
+	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefaultBreakGlassAuditsFilter() *ListBreakGlassAuditsFilter {
+	return &ListBreakGlassAuditsFilter{
+		Page:     1,
+		PageSize: DefaultPageSize,
+	}
+}
+
+func ToBizBreakGlass(bg *repo.BreakGlass) *BreakGlass {
+	return &BreakGlass{
+		Id:            bg.ID,
+		Name:          bg.Name,
+		Principal:     bg.Principal,
+		Prefix:        bg.Prefix,
+		OneTime:       bg.OneTime,
+		ExpireMinutes: bg.ExpireMinutes,
+		Description:   bg.Description,
+		ExpiresAt:     bg.ExpiresAt,
+		FirstUsedAt:   bg.FirstUsedAt,
+		CreatedBy:     bg.CreatedBy,
+		CreatedAt:     bg.CreatedAt,
+	}
+}
+
+func ToBizBreakGlasses(creds []*repo.BreakGlass) []*BreakGlass {
+	res := make([]*BreakGlass, len(creds))
+	for i, bg := range creds {
+		res[i] = ToBizBreakGlass(bg)
+	}
+	return res
+}
+
+func ToBizBreakGlassAudits(audits []*repo.BreakGlassAudit) []*BreakGlassAudit {
+	res := make([]*BreakGlassAudit, len(audits))
+	for i, a := range audits {
+		res[i] = &BreakGlassAudit{
+			Id:           a.ID,
+			CredentialId: a.CredentialId,
+			Name:         a.Name,
+			Principal:    a.Principal,
+			Operation:    a.Operation,
+			IP:           a.IP,
+			Allowed:      a.Allowed,
+			Reason:       a.Reason,
+			CreatedAt:    a.CreatedAt,
+		}
+	}
+	return res
+}
+
+func ToDBBreakGlassAuditsFilter(filter *ListBreakGlassAuditsFilter) *repo.BreakGlassAuditsFilter {
+	return &repo.BreakGlassAuditsFilter{
+		Page:          filter.Page,
+		PageSize:      filter.PageSize,
+		LastId:        pageTokenId(filter.PageToken),
+		CredentialIds: filter.CredentialIds,
+		Since:         filter.Since,
+	}
+}
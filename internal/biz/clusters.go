@@ -56,7 +56,7 @@ func (s *ClustersUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 }
@@ -64,7 +64,7 @@ func (s *ClustersUsecase) enforce(ctx context.Context, tx repo.TX) error {
 // CreateClusters is
 func (s *ClustersUsecase) CreateClusters(ctx context.Context, cs []*Cluster) error {
 	if err := s.validate(true, cs); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_cs, err := ToDBClusters(cs)
 	if err != nil {
@@ -74,7 +74,13 @@ func (s *ClustersUsecase) CreateClusters(ctx context.Context, cs []*Cluster) err
 		if err := s.enforce(ctx, tx); err != nil {
 			return err
 		}
-		return s.csrepo.CreateClusters(ctx, tx, _cs)
+		if err := s.csrepo.CreateClusters(ctx, tx, _cs); err != nil {
+			return err
+		}
+		for i, c := range _cs {
+			cs[i].Id = c.ID
+		}
+		return nil
 	})
 	if err != nil {
 		return err
@@ -85,7 +91,7 @@ func (s *ClustersUsecase) CreateClusters(ctx context.Context, cs []*Cluster) err
 // UpdateClusters is
 func (s *ClustersUsecase) UpdateClusters(ctx context.Context, cs []*Cluster) error {
 	if err := s.validate(false, cs); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_cs, err := ToDBClusters(cs)
 	if err != nil {
@@ -95,7 +101,7 @@ func (s *ClustersUsecase) UpdateClusters(ctx context.Context, cs []*Cluster) err
 		if err := s.enforce(ctx, tx); err != nil {
 			return err
 		}
-		return s.csrepo.UpdateClusters(ctx, tx, _cs)
+		return conflicting(s.csrepo.UpdateClusters(ctx, tx, _cs))
 	})
 	if err != nil {
 		return err
@@ -107,7 +113,7 @@ func (s *ClustersUsecase) UpdateClusters(ctx context.Context, cs []*Cluster) err
 // DeleteClusters is
 func (s *ClustersUsecase) DeleteClusters(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 
 	return s.txm.RunInTX(func(tx repo.TX) error {
@@ -132,7 +138,10 @@ func (s *ClustersUsecase) DeleteClusters(ctx context.Context, ids []uint32) erro
 // GetClusters is
 func (s *ClustersUsecase) GetClusters(ctx context.Context, id uint32) (*Cluster, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("InvalidId")
+		return nil, ErrInvalidId
+	}
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("clusters", "", "", "")); err != nil {
+		return nil, err
 	}
 	_cs, err := s.csrepo.GetClusters(ctx, id)
 	if err != nil {
@@ -143,16 +152,34 @@ func (s *ClustersUsecase) GetClusters(ctx context.Context, id uint32) (*Cluster,
 
 // ListClusters is
 func (s *ClustersUsecase) ListClusters(ctx context.Context,
-	filter *ListClustersFilter) ([]*Cluster, error) {
+	filter *ListClustersFilter) ([]*Cluster, *PageInfo, error) {
 
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
-	_cs, err := s.csrepo.ListClusters(ctx, nil, ToDBClustersFilter(filter))
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("clusters", "", "", "")); err != nil {
+		return nil, nil, err
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListClustersFilter{}
+	}
+	dbFilter := ToDBClustersFilter(filter)
+	_cs, err := s.csrepo.ListClusters(ctx, nil, dbFilter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	page, err := listPage(_cs, dbFilter.PageSize, func(r *repo.Cluster) uint32 { return r.ID },
+		filter.WithTotal, func() ([]*repo.Cluster, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.csrepo.ListClusters(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, err
 	}
-	return ToBizClusters(_cs)
+	res, err := ToBizClusters(_cs)
+	return res, page, err
 }
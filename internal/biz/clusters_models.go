@@ -11,4 +11,9 @@ type ListClustersFilter struct {
 	PageSize uint32
 	Names    []string
 	Ids      []uint32
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
@@ -54,7 +54,7 @@ func (s *DatacentersUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 }
@@ -63,7 +63,7 @@ func (s *DatacentersUsecase) enforce(ctx context.Context, tx repo.TX) error {
 func (s *DatacentersUsecase) CreateDatacenters(ctx context.Context, dcs []*Datacenter) error {
 
 	if err := s.validate(true, dcs); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 
 	_dcs, err := ToDBDatacenters(dcs)
@@ -77,6 +77,9 @@ func (s *DatacentersUsecase) CreateDatacenters(ctx context.Context, dcs []*Datac
 		if err := s.dcrepo.CreateDatacenters(ctx, tx, _dcs); err != nil {
 			return err
 		}
+		for i, dc := range _dcs {
+			dcs[i].Id = dc.ID
+		}
 		return nil
 
 	})
@@ -88,7 +91,7 @@ func (s *DatacentersUsecase) CreateDatacenters(ctx context.Context, dcs []*Datac
 // UpdateDatacenters is
 func (s *DatacentersUsecase) UpdateDatacenters(ctx context.Context, dcs []*Datacenter) error {
 	if err := s.validate(false, dcs); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_dcs, err := ToDBDatacenters(dcs)
 	if err != nil {
@@ -99,7 +102,7 @@ func (s *DatacentersUsecase) UpdateDatacenters(ctx context.Context, dcs []*Datac
 		if err := s.enforce(ctx, tx); err != nil {
 			return err
 		}
-		if err := s.dcrepo.UpdateDatacenters(ctx, tx, _dcs); err != nil {
+		if err := conflicting(s.dcrepo.UpdateDatacenters(ctx, tx, _dcs)); err != nil {
 			return err
 		}
 		return nil
@@ -110,7 +113,7 @@ func (s *DatacentersUsecase) UpdateDatacenters(ctx context.Context, dcs []*Datac
 // DeleteDatacenters is
 func (s *DatacentersUsecase) DeleteDatacenters(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 	return s.txm.RunInTX(func(tx repo.TX) error {
 		if err := s.enforce(ctx, tx); err != nil {
@@ -133,7 +136,10 @@ func (s *DatacentersUsecase) DeleteDatacenters(ctx context.Context, ids []uint32
 // GetDatacenters is
 func (s *DatacentersUsecase) GetDatacenters(ctx context.Context, id uint32) (*Datacenter, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("InvalidId")
+		return nil, ErrInvalidId
+	}
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("datacenters", "", "", "")); err != nil {
+		return nil, err
 	}
 	_dsc, err := s.dcrepo.GetDatacenters(ctx, id)
 	if err != nil {
@@ -144,16 +150,34 @@ func (s *DatacentersUsecase) GetDatacenters(ctx context.Context, id uint32) (*Da
 
 // ListDatacenters is
 func (s *DatacentersUsecase) ListDatacenters(ctx context.Context,
-	filter *ListDatacentersFilter) ([]*Datacenter, error) {
+	filter *ListDatacentersFilter) ([]*Datacenter, *PageInfo, error) {
 
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
-	_dcs, err := s.dcrepo.ListDatacenters(ctx, nil, ToDBDatacentersFilter(filter))
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("datacenters", "", "", "")); err != nil {
+		return nil, nil, err
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListDatacentersFilter{}
+	}
+	dbFilter := ToDBDatacentersFilter(filter)
+	_dcs, err := s.dcrepo.ListDatacenters(ctx, nil, dbFilter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	page, err := listPage(_dcs, dbFilter.PageSize, func(r *repo.Datacenter) uint32 { return r.ID },
+		filter.WithTotal, func() ([]*repo.Datacenter, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.dcrepo.ListDatacenters(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, err
 	}
-	return ToBizDatacenters(_dcs)
+	res, err := ToBizDatacenters(_dcs)
+	return res, page, err
 }
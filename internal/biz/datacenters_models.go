@@ -11,4 +11,9 @@ type ListDatacentersFilter struct {
 	PageSize uint32
 	Ids      []uint32
 	Names    []string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
@@ -2,20 +2,19 @@ package biz
 
 import (
 	"opspillar/internal/data/repo"
-	"fmt"
 )
 
 func (f *Datacenter) Validate(isNew bool) error {
 	if len(f.Name) == 0 {
-		return fmt.Errorf("InvalidNameValue")
+		return ErrInvalidNameValue
 	}
 	if !isNew {
 		if f.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if e := ValidateName(f.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	return nil
 }
@@ -24,7 +23,7 @@ func (lf *ListDatacentersFilter) Validate() error {
 	if lf == nil {
 		return nil
 	}
-	if len(lf.Ids) > MaxFilterValues || len(lf.Names) > MaxFilterValues {
+	if len(lf.Ids) > MaxFilterValues || len(lf.Names) > MaxFilterPatterns {
 		return ErrFilterValuesExceedMax
 	}
 	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
@@ -33,6 +32,9 @@ func (lf *ListDatacentersFilter) Validate() error {
 	if lf.Page == 0 {
 		return ErrFilterInvalidPage
 	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -89,5 +91,6 @@ func ToDBDatacentersFilter(filter *ListDatacentersFilter) *repo.DatacentersFilte
 		Names:    filter.Names,
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
 	}
 }
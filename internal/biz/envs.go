@@ -4,7 +4,6 @@ import (
 	"opspillar/internal/data"
 	"opspillar/internal/data/repo"
 	"context"
-	"fmt"
 
 	"github.com/go-kratos/kratos/v2/log"
 )
@@ -55,7 +54,7 @@ func (s *EnvsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 
@@ -64,7 +63,7 @@ func (s *EnvsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 // CreateEnvs is
 func (s *EnvsUsecase) CreateEnvs(ctx context.Context, envs []*Env) error {
 	if err := s.validate(true, envs); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_envs, err := ToDBEnvs(envs)
 	if err != nil {
@@ -77,6 +76,9 @@ func (s *EnvsUsecase) CreateEnvs(ctx context.Context, envs []*Env) error {
 		if err := s.envrepo.CreateEnvs(ctx, tx, _envs); err != nil {
 			return err
 		}
+		for i, env := range _envs {
+			envs[i].Id = env.ID
+		}
 		return nil
 	})
 	return err
@@ -85,7 +87,7 @@ func (s *EnvsUsecase) CreateEnvs(ctx context.Context, envs []*Env) error {
 // UpdateEnvs is
 func (s *EnvsUsecase) UpdateEnvs(ctx context.Context, envs []*Env) error {
 	if err := s.validate(false, envs); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_envs, e := ToDBEnvs(envs)
 	if e != nil {
@@ -97,7 +99,7 @@ func (s *EnvsUsecase) UpdateEnvs(ctx context.Context, envs []*Env) error {
 		if err := s.enforce(ctx, tx); err != nil {
 			return err
 		}
-		if err := s.envrepo.UpdateEnvs(ctx, tx, _envs); err != nil {
+		if err := conflicting(s.envrepo.UpdateEnvs(ctx, tx, _envs)); err != nil {
 			return err
 		}
 		return nil
@@ -108,7 +110,7 @@ func (s *EnvsUsecase) UpdateEnvs(ctx context.Context, envs []*Env) error {
 // DeleteEnvs is
 func (s *EnvsUsecase) DeleteEnvs(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 	return s.txm.RunInTX(func(tx repo.TX) error {
 		if err := s.enforce(ctx, tx); err != nil {
@@ -120,7 +122,7 @@ func (s *EnvsUsecase) DeleteEnvs(ctx context.Context, ids []uint32) error {
 				return err
 			}
 			if c > 0 {
-				return fmt.Errorf("some %s requires", r.name)
+				return FailedPrecondition("some %s requires", r.name)
 			}
 		}
 		return s.envrepo.DeleteEnvs(ctx, tx, ids)
@@ -130,7 +132,10 @@ func (s *EnvsUsecase) DeleteEnvs(ctx context.Context, ids []uint32) error {
 // GetEnvs is
 func (s *EnvsUsecase) GetEnvs(ctx context.Context, id uint32) (*Env, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("InvalidId")
+		return nil, ErrInvalidId
+	}
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("envs", "", "", "")); err != nil {
+		return nil, err
 	}
 	_envs, err := s.envrepo.GetEnvs(ctx, id)
 	if err != nil {
@@ -140,17 +145,35 @@ func (s *EnvsUsecase) GetEnvs(ctx context.Context, id uint32) (*Env, error) {
 }
 
 // ListEnvs is
-func (s *EnvsUsecase) ListEnvs(ctx context.Context, filter *ListEnvsFilter) ([]*Env, error) {
+func (s *EnvsUsecase) ListEnvs(ctx context.Context, filter *ListEnvsFilter) ([]*Env, *PageInfo, error) {
 
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
 
-	_envs, err := s.envrepo.ListEnvs(ctx, nil, ToDBEnvsFilter(filter))
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("envs", "", "", "")); err != nil {
+		return nil, nil, err
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListEnvsFilter{}
+	}
+	dbFilter := ToDBEnvsFilter(filter)
+	_envs, err := s.envrepo.ListEnvs(ctx, nil, dbFilter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	page, err := listPage(_envs, dbFilter.PageSize, func(r *repo.Env) uint32 { return r.ID },
+		filter.WithTotal, func() ([]*repo.Env, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.envrepo.ListEnvs(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, err
 	}
-	return ToBizEnvs(_envs)
+	res, err := ToBizEnvs(_envs)
+	return res, page, err
 }
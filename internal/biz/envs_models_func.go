@@ -2,20 +2,19 @@ package biz
 
 import (
 	"opspillar/internal/data/repo"
-	"fmt"
 )
 
 func (f *Env) Validate(isNew bool) error {
 	if len(f.Name) == 0 {
-		return fmt.Errorf("InvalidNameValue")
+		return ErrInvalidNameValue
 	}
 	if !isNew {
 		if f.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if e := ValidateName(f.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	return nil
 }
@@ -24,7 +23,7 @@ func (lf *ListEnvsFilter) Validate() error {
 	if lf == nil {
 		return nil
 	}
-	if len(lf.Names) > MaxFilterValues || len(lf.Ids) > MaxFilterValues {
+	if len(lf.Names) > MaxFilterPatterns || len(lf.Ids) > MaxFilterValues {
 		return ErrFilterValuesExceedMax
 	}
 	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
@@ -33,6 +32,9 @@ func (lf *ListEnvsFilter) Validate() error {
 	if lf.Page == 0 {
 		return ErrFilterInvalidPage
 	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -95,5 +97,6 @@ func ToDBEnvsFilter(filter *ListEnvsFilter) *repo.EnvsFilter {
 		Names:    filter.Names,
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
 	}
 }
@@ -0,0 +1,144 @@
+package biz
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"opspillar/internal/data/repo"
+)
+
+// ErrorKind classifies domain errors, the service layer maps each kind to gRPC and HTTP codes.
+type ErrorKind string
+
+const (
+	KindNotFound           ErrorKind = "NOT_FOUND"
+	KindAlreadyExists      ErrorKind = "ALREADY_EXISTS"
+	KindPermissionDenied   ErrorKind = "PERMISSION_DENIED"
+	KindInvalidArgument    ErrorKind = "INVALID_ARGUMENT"
+	KindFailedPrecondition ErrorKind = "FAILED_PRECONDITION"
+	KindConflict           ErrorKind = "CONFLICT"
+)
+
+// Error is a domain error of a kind. Fields maps the request fields at fault to what is wrong
+// with them, Err is the cause if any.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+	Fields  map[string]string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithField returns a copy of e with field at fault for reason.
+func (e *Error) WithField(field, reason string) *Error {
+	c := *e
+	c.Fields = maps.Clone(e.Fields)
+	if c.Fields == nil {
+		c.Fields = make(map[string]string)
+	}
+	c.Fields[field] = reason
+	return &c
+}
+
+func newError(kind ErrorKind, format string, a ...any) *Error {
+	err := fmt.Errorf(format, a...)
+	return &Error{Kind: kind, Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+// NotFound is an error of missing entities, formatted like fmt.Errorf.
+func NotFound(format string, a ...any) *Error {
+	return newError(KindNotFound, format, a...)
+}
+
+// AlreadyExists is an error of entities created twice, formatted like fmt.Errorf.
+func AlreadyExists(format string, a ...any) *Error {
+	return newError(KindAlreadyExists, format, a...)
+}
+
+// PermissionDenied is an error of actions the user may not do, formatted like fmt.Errorf.
+func PermissionDenied(format string, a ...any) *Error {
+	return newError(KindPermissionDenied, format, a...)
+}
+
+// InvalidArgument is an error of malformed requests, formatted like fmt.Errorf.
+func InvalidArgument(format string, a ...any) *Error {
+	return newError(KindInvalidArgument, format, a...)
+}
+
+// FailedPrecondition is an error of requests refused in the current state, formatted like
+// fmt.Errorf. They may succeed once the state is changed, e.g. references are removed.
+func FailedPrecondition(format string, a ...any) *Error {
+	return newError(KindFailedPrecondition, format, a...)
+}
+
+// Conflict is an error of updates colliding with other entities, formatted like fmt.Errorf.
+func Conflict(format string, a ...any) *Error {
+	return newError(KindConflict, format, a...)
+}
+
+// ErrPermissionDenied is returned when authorization refuses an action.
+var ErrPermissionDenied = PermissionDenied("PermissionDenied")
+
+// Errors of request fields checked by many usecases, their messages are kept for clients
+// matching them.
+var (
+	ErrInvalidId        = InvalidArgument("InvalidId").WithField("id", "must be set")
+	ErrEmptyId          = InvalidArgument("EmptyId").WithField("id", "must be set")
+	ErrEmptyIds         = InvalidArgument("EmptyIds").WithField("ids", "must not be empty")
+	ErrInvalidNameValue = InvalidArgument("InvalidNameValue").WithField("name", "must not be empty")
+	ErrInvalidProductId = InvalidArgument("InvalidProductId").WithField("product_id", "must be set")
+	ErrInvalidTeamId    = InvalidArgument("InvalidTeamId").WithField("team_id", "must be set")
+	ErrEmptyRelations   = InvalidArgument("EmptyRelations").WithField("add", "add or remove must be set")
+)
+
+// invalidField is an InvalidArgument error of field for the reason of err.
+func invalidField(field string, err error) *Error {
+	return (&Error{Kind: KindInvalidArgument, Err: err}).WithField(field, err.Error())
+}
+
+// invalidArgument classifies the errors of validation not of a kind as InvalidArgument.
+func invalidArgument(err error) error {
+	var e *Error
+	if err == nil || errors.As(err, &e) {
+		return err
+	}
+	return &Error{Kind: KindInvalidArgument, Err: err}
+}
+
+// conflicting classifies updates duplicating unique keys of other entities as Conflict.
+func conflicting(err error) error {
+	var e *Error
+	if err == nil || errors.As(err, &e) || !errors.Is(err, repo.ErrDuplicated) {
+		return err
+	}
+	return &Error{Kind: KindConflict, Err: err}
+}
+
+// AsError returns err as a domain error with the message of err. Errors of missing, duplicated
+// and referenced rows are NotFound, AlreadyExists and FailedPrecondition. ok is false for errors
+// of no kind.
+func AsError(err error) (e *Error, ok bool) {
+	if errors.As(err, &e) {
+		return &Error{Kind: e.Kind, Message: err.Error(), Fields: e.Fields, Err: err}, true
+	}
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return &Error{Kind: KindNotFound, Err: err}, true
+	case errors.Is(err, repo.ErrDuplicated):
+		return &Error{Kind: KindAlreadyExists, Err: err}, true
+	case errors.Is(err, repo.ErrReferenced):
+		return &Error{Kind: KindFailedPrecondition, Err: err}, true
+	}
+	return nil, false
+}
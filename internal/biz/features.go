@@ -4,7 +4,6 @@ import (
 	"opspillar/internal/data"
 	"opspillar/internal/data/repo"
 	"context"
-	"fmt"
 
 	"github.com/go-kratos/kratos/v2/log"
 )
@@ -57,7 +56,7 @@ func (s *FeaturesUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 }
@@ -65,7 +64,7 @@ func (s *FeaturesUsecase) enforce(ctx context.Context, tx repo.TX) error {
 // CreateFeatures is
 func (s *FeaturesUsecase) CreateFeatures(ctx context.Context, features []*Feature) error {
 	if err := s.validate(true, features); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_f, e := ToDBFeatures(features)
 	if e != nil {
@@ -78,6 +77,9 @@ func (s *FeaturesUsecase) CreateFeatures(ctx context.Context, features []*Featur
 		if err := s.ftrepo.CreateFeatures(ctx, tx, _f); err != nil {
 			return err
 		}
+		for i, f := range _f {
+			features[i].Id = f.Id
+		}
 		return nil
 	})
 	return err
@@ -87,7 +89,7 @@ func (s *FeaturesUsecase) CreateFeatures(ctx context.Context, features []*Featur
 // UpdateFeatures is
 func (s *FeaturesUsecase) UpdateFeatures(ctx context.Context, features []*Feature) error {
 	if err := s.validate(false, features); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_f, e := ToDBFeatures(features)
 	if e != nil {
@@ -97,7 +99,7 @@ func (s *FeaturesUsecase) UpdateFeatures(ctx context.Context, features []*Featur
 		if err := s.enforce(ctx, tx); err != nil {
 			return err
 		}
-		if err := s.ftrepo.UpdateFeatures(ctx, tx, _f); err != nil {
+		if err := conflicting(s.ftrepo.UpdateFeatures(ctx, tx, _f)); err != nil {
 			return err
 		}
 		return nil
@@ -108,7 +110,7 @@ func (s *FeaturesUsecase) UpdateFeatures(ctx context.Context, features []*Featur
 // DeleteFeatures is
 func (s *FeaturesUsecase) DeleteFeatures(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 	return s.txm.RunInTX(func(tx repo.TX) error {
 		if err := s.enforce(ctx, tx); err != nil {
@@ -120,7 +122,7 @@ func (s *FeaturesUsecase) DeleteFeatures(ctx context.Context, ids []uint32) erro
 				return err
 			}
 			if c > 0 {
-				return fmt.Errorf("some %s requires", r.name)
+				return FailedPrecondition("some %s requires", r.name)
 			}
 		}
 		return s.ftrepo.DeleteFeatures(ctx, tx, ids)
@@ -130,7 +132,10 @@ func (s *FeaturesUsecase) DeleteFeatures(ctx context.Context, ids []uint32) erro
 // GetFeatures is
 func (s *FeaturesUsecase) GetFeatures(ctx context.Context, id uint32) (*Feature, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("EmptyId")
+		return nil, ErrEmptyId
+	}
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("features", "", "", "")); err != nil {
+		return nil, err
 	}
 	_f, e := s.ftrepo.GetFeatures(ctx, id)
 	if e != nil {
@@ -141,16 +146,34 @@ func (s *FeaturesUsecase) GetFeatures(ctx context.Context, id uint32) (*Feature,
 
 // ListFeatures is
 func (s *FeaturesUsecase) ListFeatures(ctx context.Context,
-	filter *ListFeaturesFilter) ([]*Feature, error) {
+	filter *ListFeaturesFilter) ([]*Feature, *PageInfo, error) {
 
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
-	_f, e := s.ftrepo.ListFeatures(ctx, nil, ToDBFeaturesFilter(filter))
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("features", "", "", "")); err != nil {
+		return nil, nil, err
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListFeaturesFilter{}
+	}
+	dbFilter := ToDBFeaturesFilter(filter)
+	_f, e := s.ftrepo.ListFeatures(ctx, nil, dbFilter)
 	if e != nil {
-		return nil, e
+		return nil, nil, e
+	}
+	page, e := listPage(_f, dbFilter.PageSize, func(r *repo.Feature) uint32 { return r.Id },
+		filter.WithTotal, func() ([]*repo.Feature, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.ftrepo.ListFeatures(ctx, nil, &all)
+		})
+	if e != nil {
+		return nil, nil, e
 	}
-	return ToBizFeatures(_f)
+	res, e := ToBizFeatures(_f)
+	return res, page, e
 }
@@ -13,4 +13,9 @@ type ListFeaturesFilter struct {
 	Ids      []uint32
 	Names    []string
 	Kvs      []string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
@@ -2,23 +2,22 @@ package biz
 
 import (
 	"opspillar/internal/data/repo"
-	"fmt"
 )
 
 func (f *Feature) Validate(isNew bool) error {
 	if len(f.Name) == 0 || len(f.Value) == 0 {
-		return fmt.Errorf("InvalidNameValue")
+		return ErrInvalidNameValue
 	}
 	if !isNew {
 		if f.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if e := ValidateName(f.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	if e := ValidateCode(f.Value); e != nil {
-		return e
+		return invalidField("value", e)
 	}
 	return nil
 }
@@ -28,7 +27,7 @@ func (lf *ListFeaturesFilter) Validate() error {
 		return nil
 	}
 	if len(lf.Ids) > MaxFilterValues ||
-		len(lf.Names) > MaxFilterValues ||
+		len(lf.Names) > MaxFilterPatterns ||
 		len(lf.Kvs) > MaxFilterValues {
 
 		return ErrFilterValuesExceedMax
@@ -48,6 +47,9 @@ func (lf *ListFeaturesFilter) Validate() error {
 		return ErrFilterInvalidPage
 	}
 
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -103,5 +105,6 @@ func ToDBFeaturesFilter(filter *ListFeaturesFilter) *repo.FeaturesFilter {
 		Names:    filter.Names,
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
 	}
 }
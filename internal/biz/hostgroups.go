@@ -17,11 +17,14 @@ type HostgroupsUsecase struct {
 	hprepo    repo.HostgroupProductsRepo
 	htagrepo  repo.HostgroupTagsRepo
 	hfrepo    repo.HostgroupFeaturesRepo
+	attrrepo  repo.AttributesRepo
+	tkrepo    repo.TagKeysRepo
 
 	clsrepo  repo.ClustersRepo
 	dcrepo   repo.DatacentersRepo
 	prdrepo  repo.ProductsRepo
 	teamrepo repo.TeamsRepo
+	orgrepo  repo.OrganizationsRepo
 	ftrepo   repo.FeaturesRepo
 	tagrepo  repo.TagsRepo
 	envrepo  repo.EnvsRepo
@@ -39,12 +42,15 @@ func NewHostgroupsUsecase(repo repo.HostgroupsRepo,
 	hprepo repo.HostgroupProductsRepo,
 	htagrepo repo.HostgroupTagsRepo,
 	hfrepo repo.HostgroupFeaturesRepo,
+	attrrepo repo.AttributesRepo,
+	tkrepo repo.TagKeysRepo,
 	clsrepo repo.ClustersRepo,
 	dcrepo repo.DatacentersRepo,
 	envrepo repo.EnvsRepo,
 	ftrepo repo.FeaturesRepo,
 	tagrepo repo.TagsRepo,
 	teamrepo repo.TeamsRepo,
+	orgrepo repo.OrganizationsRepo,
 	prdrepo repo.ProductsRepo,
 	apphgrepo repo.AppHostgroupsRepo,
 	authzrepo repo.AuthzRepo,
@@ -58,10 +64,13 @@ func NewHostgroupsUsecase(repo repo.HostgroupsRepo,
 		hprepo:    hprepo,
 		htagrepo:  htagrepo,
 		hfrepo:    hfrepo,
+		attrrepo:  attrrepo,
+		tkrepo:    tkrepo,
 		clsrepo:   clsrepo,
 		dcrepo:    dcrepo,
 		prdrepo:   prdrepo,
 		teamrepo:  teamrepo,
+		orgrepo:   orgrepo,
 		ftrepo:    ftrepo,
 		tagrepo:   tagrepo,
 		envrepo:   envrepo,
@@ -87,8 +96,12 @@ func (s *HostgroupsUsecase) enforce(ctx context.Context, tx repo.TX, hgs []*Host
 		if err != nil {
 			return err
 		}
+		org, err := orgName(ctx, s.orgrepo, team.OrgId)
+		if err != nil {
+			return err
+		}
 		// hostgroup is team leader's resource
-		ires := repo.NewResource4Sv1("hostgroups", team.Name, hg.Name, leader.UserName)
+		ires := repo.NewOrgResource4Sv1(org, "hostgroups", team.Name, hg.Name, leader.UserName)
 		can, err := s.authzrepo.Enforce(ctx, tx, &repo.AuthenRequest{
 			Sub:      curUser,
 			Resource: ires,
@@ -98,18 +111,55 @@ func (s *HostgroupsUsecase) enforce(ctx context.Context, tx repo.TX, hgs []*Host
 			return err
 		}
 		if !can {
-			return fmt.Errorf("PermissionDenied")
+			return ErrPermissionDenied
 		}
 	}
 	return nil
 }
 
-func (s *HostgroupsUsecase) validate(isNew bool, hgs []*Hostgroup) error {
+// setOrg sets the organization of hostgroups to the one of their team, the product must be
+// in the same organization.
+func (s *HostgroupsUsecase) setOrg(ctx context.Context, hgs []*repo.Hostgroup) error {
+	for _, hg := range hgs {
+		team, err := s.teamrepo.GetTeams(ctx, hg.TeamId)
+		if err != nil {
+			return err
+		}
+		product, err := s.prdrepo.GetProducts(ctx, hg.ProductId)
+		if err != nil {
+			return err
+		}
+		if product.OrgId != team.OrgId {
+			return InvalidArgument("product %s and team %s of hostgroup %s are in different organizations",
+				product.Name, team.Name, hg.Name)
+		}
+		hg.OrgId = team.OrgId
+	}
+	return nil
+}
+
+func (s *HostgroupsUsecase) validate(ctx context.Context, isNew bool, hgs []*Hostgroup) error {
 	for _, hg := range hgs {
 		if err := hg.Validate(isNew); err != nil {
 			return err
 		}
 	}
+	attrs, err := kindAttributes(ctx, s.attrrepo, nil, repo.AttrKindHostgroup)
+	if err != nil {
+		return err
+	}
+	keys, err := registeredTagKeys(ctx, s.tkrepo, nil)
+	if err != nil {
+		return err
+	}
+	for _, hg := range hgs {
+		if err := ValidateAttributeValues(attrs, hg.Attributes); err != nil {
+			return invalidField("attributes", fmt.Errorf("hostgroup %s: %w", hg.Name, err))
+		}
+		if err := validateEntityTags(ctx, s.tagrepo, nil, keys, repo.AttrKindHostgroup, hg.TagsId); err != nil {
+			return invalidField("tags_id", fmt.Errorf("hostgroup %s: %w", hg.Name, err))
+		}
+	}
 	return nil
 }
 
@@ -287,8 +337,8 @@ func (s *HostgroupsUsecase) createM2MProps(
 
 // CreateHostgroups is
 func (s *HostgroupsUsecase) CreateHostgroups(ctx context.Context, hgs []*Hostgroup) error {
-	if err := s.validate(true, hgs); err != nil {
-		return err
+	if err := s.validate(ctx, true, hgs); err != nil {
+		return invalidArgument(err)
 	}
 
 	curUserName, err := GetCurrentUser(ctx)
@@ -313,10 +363,14 @@ func (s *HostgroupsUsecase) CreateHostgroups(ctx context.Context, hgs []*Hostgro
 			dbhg.CreatedBy = curUserName
 			dbhg.UpdatedAt = time.Now().Unix()
 			dbhg.UpdatedBy = curUserName
+			if err := s.setOrg(ctx, []*repo.Hostgroup{dbhg}); err != nil {
+				return err
+			}
 
 			if err := s.hgrepo.CreateHostgroups(ctx, tx, []*repo.Hostgroup{dbhg}); err != nil {
 				return err
 			}
+			hg.Id = dbhg.Id
 			if err := s.createM2MProps(ctx, tx, dbhg.Id, hg.TagsId, hgPropTag); err != nil {
 				return err
 			}
@@ -329,6 +383,9 @@ func (s *HostgroupsUsecase) CreateHostgroups(ctx context.Context, hgs []*Hostgro
 			if err := s.createM2MProps(ctx, tx, dbhg.Id, hg.ShareTeamsId, hgPropShareTeam); err != nil {
 				return err
 			}
+			if err := setAttributeValues(ctx, s.attrrepo, tx, repo.AttrKindHostgroup, dbhg.Id, hg.Attributes); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -399,28 +456,37 @@ func (s *HostgroupsUsecase) HandleM2MProps(
 	if err != nil {
 		return err
 	}
+	// ids are related ids, relations are deleted by their row ids
 	var oldIds []uint32
+	rowIds := map[uint32]uint32{}
 
 	switch items := oldItems.(type) {
 	case []*repo.HostgroupTag:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.TagID)
+			rowIds[item.TagID] = item.Id
 		}
 	case []*repo.HostgroupFeature:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.FeatureID)
+			rowIds[item.FeatureID] = item.Id
 		}
 	case []*repo.HostgroupProduct:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.ProductID)
+			rowIds[item.ProductID] = item.Id
 		}
 	case []*repo.HostgroupTeam:
 		for _, item := range items {
-			oldIds = append(oldIds, item.Id)
+			oldIds = append(oldIds, item.TeamID)
+			rowIds[item.TeamID] = item.Id
 		}
 	}
 
-	toDelIds := DiffSliceUint32(oldIds, ids)
+	var toDelIds []uint32
+	for _, id := range DiffSliceUint32(oldIds, ids) {
+		toDelIds = append(toDelIds, rowIds[id])
+	}
 	toNewids := DiffSliceUint32(ids, oldIds)
 
 	if len(toDelIds) > 0 {
@@ -438,8 +504,8 @@ func (s *HostgroupsUsecase) HandleM2MProps(
 
 // UpdateHostgroups is
 func (s *HostgroupsUsecase) UpdateHostgroups(ctx context.Context, hgs []*Hostgroup) error {
-	if err := s.validate(false, hgs); err != nil {
-		return err
+	if err := s.validate(ctx, false, hgs); err != nil {
+		return invalidArgument(err)
 	}
 	curUserName, err := GetCurrentUser(ctx)
 	if err != nil {
@@ -461,7 +527,10 @@ func (s *HostgroupsUsecase) UpdateHostgroups(ctx context.Context, hgs []*Hostgro
 		if err := s.validateProps(ctx, tx, hgs); err != nil {
 			return err
 		}
-		if err := s.hgrepo.UpdateHostgroups(ctx, tx, _hgs); err != nil {
+		if err := s.setOrg(ctx, _hgs); err != nil {
+			return err
+		}
+		if err := conflicting(s.hgrepo.UpdateHostgroups(ctx, tx, _hgs)); err != nil {
 			return err
 		}
 
@@ -482,6 +551,83 @@ func (s *HostgroupsUsecase) UpdateHostgroups(ctx context.Context, hgs []*Hostgro
 				hg.Id, hg.ShareTeamsId, hgPropShareTeam); err != nil {
 				return err
 			}
+			if err := setAttributeValues(ctx, s.attrrepo, tx, repo.AttrKindHostgroup, hg.Id, hg.Attributes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateHostgroupsRelations adds relations to and removes them from hostgroups of ids, others
+// keep theirs. Relations are read and edited in one transaction writing only the relation rows,
+// concurrent edits of other relations and fields are kept.
+func (s *HostgroupsUsecase) UpdateHostgroupsRelations(ctx context.Context, ids []uint32,
+	add, remove *HostgroupRelations) error {
+
+	if len(ids) == 0 {
+		return ErrEmptyIds
+	}
+	if len(ids) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if add.empty() && remove.empty() {
+		return ErrEmptyRelations
+	}
+	if add == nil {
+		add = &HostgroupRelations{}
+	}
+	if remove == nil {
+		remove = &HostgroupRelations{}
+	}
+	ids = DedupSliceUint32(ids)
+	keys, err := registeredTagKeys(ctx, s.tkrepo, nil)
+	if err != nil {
+		return err
+	}
+	return s.txm.RunInTX(func(tx repo.TX) error {
+		repohgs, err := s.hgrepo.ListHostgroups(ctx, tx, &repo.HostgroupsFilter{Ids: ids})
+		if err != nil {
+			return err
+		}
+		if len(repohgs) != len(ids) {
+			return NotFound("some hostgroups are not found")
+		}
+		hgs, err := ToBizHostgroups(repohgs)
+		if err != nil {
+			return err
+		}
+		if err := s.enforce(ctx, tx, hgs); err != nil {
+			return err
+		}
+		for _, hg := range hgs {
+			if err := s.attachM2MProps(ctx, tx, hg); err != nil {
+				return err
+			}
+			hg.TagsId = EditSliceUint32(hg.TagsId, add.TagsId, remove.TagsId)
+			hg.FeaturesId = EditSliceUint32(hg.FeaturesId, add.FeaturesId, remove.FeaturesId)
+			hg.ShareProductsId = EditSliceUint32(hg.ShareProductsId, add.ShareProductsId, remove.ShareProductsId)
+			hg.ShareTeamsId = EditSliceUint32(hg.ShareTeamsId, add.ShareTeamsId, remove.ShareTeamsId)
+			if err := validateEntityTags(ctx, s.tagrepo, tx, keys, repo.AttrKindHostgroup, hg.TagsId); err != nil {
+				return invalidField("tags_id", fmt.Errorf("hostgroup %s: %w", hg.Name, err))
+			}
+		}
+		if err := s.validateProps(ctx, tx, hgs); err != nil {
+			return err
+		}
+		for _, hg := range hgs {
+			if err := s.HandleM2MProps(ctx, tx, hg.Id, hg.TagsId, hgPropTag); err != nil {
+				return err
+			}
+			if err := s.HandleM2MProps(ctx, tx, hg.Id, hg.FeaturesId, hgPropFeature); err != nil {
+				return err
+			}
+			if err := s.HandleM2MProps(ctx, tx, hg.Id, hg.ShareProductsId, hgPropShareProduct); err != nil {
+				return err
+			}
+			if err := s.HandleM2MProps(ctx, tx, hg.Id, hg.ShareTeamsId, hgPropShareTeam); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -490,7 +636,7 @@ func (s *HostgroupsUsecase) UpdateHostgroups(ctx context.Context, hgs []*Hostgro
 // DeleteHostgroups is
 func (s *HostgroupsUsecase) DeleteHostgroups(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 	return s.txm.RunInTX(func(tx repo.TX) error {
 		repohgs, err := s.hgrepo.ListHostgroups(ctx, tx, &repo.HostgroupsFilter{
@@ -512,7 +658,7 @@ func (s *HostgroupsUsecase) DeleteHostgroups(ctx context.Context, ids []uint32)
 				return err
 			}
 			if c > 0 {
-				return fmt.Errorf("some %s requires", r.name)
+				return FailedPrecondition("some %s requires", r.name)
 			}
 		}
 		for _, id := range ids {
@@ -529,6 +675,12 @@ func (s *HostgroupsUsecase) DeleteHostgroups(ctx context.Context, ids []uint32)
 				return err
 			}
 		}
+		if err := s.attrrepo.DeleteAttributeValues(ctx, tx, &repo.AttributeValuesFilter{
+			Kind:      repo.AttrKindHostgroup,
+			EntityIds: ids,
+		}); err != nil {
+			return err
+		}
 		return s.hgrepo.DeleteHostgroups(ctx, tx, ids)
 	})
 }
@@ -536,71 +688,94 @@ func (s *HostgroupsUsecase) DeleteHostgroups(ctx context.Context, ids []uint32)
 // GetHostgroups is
 func (s *HostgroupsUsecase) GetHostgroups(ctx context.Context, id uint32) (*Hostgroup, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("InvalidId")
+		return nil, ErrInvalidId
 	}
 	hg, err := s.hgrepo.GetHostgroups(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	readable, err := s.filterReadable(ctx, []*repo.Hostgroup{hg})
+	if err != nil {
+		return nil, err
+	}
+	if len(readable) == 0 {
+		return nil, ErrPermissionDenied
+	}
 	bizhg, e := ToBizHostgroup(hg)
 	if e != nil {
 		return nil, e
 	}
-	if err := s.attachM2MProps(ctx, bizhg); err != nil {
+	if err := s.attachM2MProps(ctx, nil, bizhg); err != nil {
 		return nil, err
 	}
 	return bizhg, nil
 }
 
-func (s *HostgroupsUsecase) attachM2MProps(ctx context.Context, hg *Hostgroup) error {
+func (s *HostgroupsUsecase) attachM2MProps(ctx context.Context, tx repo.TX, hg *Hostgroup) error {
 	// tags id
-	_tags, err := s.listM2MProps(ctx, nil, []uint32{hg.Id}, hgPropTag)
+	_tags, err := s.listM2MProps(ctx, tx, []uint32{hg.Id}, hgPropTag)
 	if err != nil {
 		return err
 	}
 	for _, tag := range _tags.([]*repo.HostgroupTag) {
-		hg.TagsId = append(hg.TagsId, tag.Id)
+		hg.TagsId = append(hg.TagsId, tag.TagID)
 	}
 	// features id
-	_fts, err := s.listM2MProps(ctx, nil, []uint32{hg.Id}, hgPropFeature)
+	_fts, err := s.listM2MProps(ctx, tx, []uint32{hg.Id}, hgPropFeature)
 	if err != nil {
 		return err
 	}
 	for _, ft := range _fts.([]*repo.HostgroupFeature) {
-		hg.FeaturesId = append(hg.FeaturesId, ft.Id)
+		hg.FeaturesId = append(hg.FeaturesId, ft.FeatureID)
 	}
 
 	// share products id
-	_prds, err := s.listM2MProps(ctx, nil, []uint32{hg.Id}, hgPropShareProduct)
+	_prds, err := s.listM2MProps(ctx, tx, []uint32{hg.Id}, hgPropShareProduct)
 	if err != nil {
 		return err
 	}
 	for _, prd := range _prds.([]*repo.HostgroupProduct) {
-		hg.ShareProductsId = append(hg.ShareProductsId, prd.Id)
+		hg.ShareProductsId = append(hg.ShareProductsId, prd.ProductID)
 	}
 	// share teams id
-	_teams, err := s.listM2MProps(ctx, nil, []uint32{hg.Id}, hgPropShareTeam)
+	_teams, err := s.listM2MProps(ctx, tx, []uint32{hg.Id}, hgPropShareTeam)
 	if err != nil {
 		return err
 	}
 	for _, team := range _teams.([]*repo.HostgroupTeam) {
-		hg.ShareTeamsId = append(hg.ShareTeamsId, team.Id)
+		hg.ShareTeamsId = append(hg.ShareTeamsId, team.TeamID)
+	}
+
+	values, err := listAttributeValues(ctx, s.attrrepo, tx, repo.AttrKindHostgroup, []uint32{hg.Id})
+	if err != nil {
+		return err
 	}
+	hg.Attributes = values[hg.Id]
 
 	return nil
 }
 
 // ListHostgroups is
 func (s *HostgroupsUsecase) ListHostgroups(
-	ctx context.Context, filter *ListHostgroupsFilter) ([]*Hostgroup, error) {
+	ctx context.Context, filter *ListHostgroupsFilter) ([]*Hostgroup, *PageInfo, error) {
 
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
 
-	dbFilter := ToDBHostgroupsFilter(filter)
+	dbFilter := &repo.HostgroupsFilter{}
+	if filter != nil {
+		dbFilter = ToDBHostgroupsFilter(filter)
+	}
+	if len(dbFilter.OrgIds) == 0 {
+		orgIds, err := selectedOrgIds(ctx, s.orgrepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		dbFilter.OrgIds = orgIds
+	}
 
 	processInitIds := func(filterIds []uint32, prop string) error {
 		if len(filterIds) == 0 {
@@ -611,7 +786,7 @@ func (s *HostgroupsUsecase) ListHostgroups(
 		switch prop {
 		case hgPropTag:
 			items, err := s.htagrepo.ListHostgroupTags(ctx, nil, &repo.HostgroupTagsFilter{
-				Ids: filterIds})
+				TagIds: filterIds})
 			if err != nil {
 				return err
 			}
@@ -620,7 +795,7 @@ func (s *HostgroupsUsecase) ListHostgroups(
 			}
 		case hgPropFeature:
 			items, err := s.hfrepo.ListHostgroupFeatures(ctx, nil, &repo.HostgroupFeaturesFilter{
-				Ids: filterIds})
+				FeatureIds: filterIds})
 			if err != nil {
 				return err
 			}
@@ -629,7 +804,7 @@ func (s *HostgroupsUsecase) ListHostgroups(
 			}
 		case hgPropShareProduct:
 			items, err := s.hprepo.ListHostgroupProducts(ctx, nil, &repo.HostgroupProductsFilter{
-				Ids: filterIds})
+				ProductIds: filterIds})
 			if err != nil {
 				return err
 			}
@@ -638,7 +813,7 @@ func (s *HostgroupsUsecase) ListHostgroups(
 			}
 		case hgPropShareTeam:
 			items, err := s.hteamrepo.ListHostgroupTeams(ctx, nil, &repo.HostgroupTeamsFilter{
-				Ids: filterIds})
+				TeamIds: filterIds})
 			if err != nil {
 				return err
 			}
@@ -662,36 +837,118 @@ func (s *HostgroupsUsecase) ListHostgroups(
 	}
 
 	if filter != nil {
+		resolver := &selectorResolver{teamrepo: s.teamrepo, prdrepo: s.prdrepo, clsrepo: s.clsrepo,
+			dcrepo: s.dcrepo, envrepo: s.envrepo, tagrepo: s.tagrepo, ftrepo: s.ftrepo}
+		selections, err := resolver.resolve(ctx, filter.Selector, dbFilter.OrgIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		dbFilter.Selections = selections
+
 		// tags id
 		if err := processInitIds(filter.TagsId, hgPropTag); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		// features id
 		if err := processInitIds(filter.FeaturesId, hgPropFeature); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		// share products id
 		if err := processInitIds(filter.ShareProductsId, hgPropShareProduct); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		// share teams id
 		if err := processInitIds(filter.ShareTeamsId, hgPropShareTeam); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		if len(filter.Attributes) > 0 {
+			hg_ids, err := filterAttributeIds(ctx, s.attrrepo, nil, repo.AttrKindHostgroup, filter.Attributes)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(hg_ids) == 0 {
+				return nil, nil, fmt.Errorf("ListHostgroups no hostgroup with attributes")
+			}
+			if len(dbFilter.Ids) == 0 {
+				dbFilter.Ids = hg_ids
+			} else {
+				dbFilter.Ids = IntersectSliceUint32(dbFilter.Ids, hg_ids)
+				if len(dbFilter.Ids) == 0 {
+					return nil, nil, fmt.Errorf("ListHostgroups no hostgroup intersect with attributes")
+				}
+			}
 		}
 	}
 	hg, err := s.hgrepo.ListHostgroups(ctx, nil, dbFilter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// totals count hostgroups readable by the caller only
+	page, err := listPage(hg, dbFilter.PageSize, func(r *repo.Hostgroup) uint32 { return r.Id },
+		filter != nil && filter.WithTotal, func() ([]*repo.Hostgroup, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			rows, err := s.hgrepo.ListHostgroups(ctx, nil, &all)
+			if err != nil {
+				return nil, err
+			}
+			return s.filterReadable(ctx, rows)
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	if page.NextPageToken != "" {
+		// sorted lists continue after the sort value of the last row
+		last := hg[len(hg)-1]
+		page.NextPageToken = sortedPageToken(dbFilter.Sort, last.Id, last.Name, last.ChangeInfo)
+	}
+	hg, err = s.filterReadable(ctx, hg)
+	if err != nil {
+		return nil, nil, err
 	}
 	bizhg, err := ToBizHostgroups(hg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for _, hg := range bizhg {
-		if err := s.attachM2MProps(ctx, hg); err != nil {
-			return nil, err
+		if err := s.attachM2MProps(ctx, nil, hg); err != nil {
+			return nil, nil, err
 		}
 	}
 
-	return bizhg, nil
+	return bizhg, page, nil
+}
+
+// filterReadable keeps hostgroups readable by the current user, a hostgroup is visible to
+// its team and the share teams.
+func (s *HostgroupsUsecase) filterReadable(ctx context.Context, hgs []*repo.Hostgroup) ([]*repo.Hostgroup, error) {
+	if len(hgs) == 0 {
+		return hgs, nil
+	}
+	ids := make([]uint32, len(hgs))
+	for i, hg := range hgs {
+		ids[i] = hg.Id
+	}
+	shares, err := s.hteamrepo.ListHostgroupTeams(ctx, nil, &repo.HostgroupTeamsFilter{HostgroupIds: ids})
+	if err != nil {
+		return nil, err
+	}
+	shareTeams := make(map[uint32][]uint32, len(hgs))
+	for _, st := range shares {
+		shareTeams[st.HostgroupID] = append(shareTeams[st.HostgroupID], st.TeamID)
+	}
+	names := newTeamResourceNames(s.teamrepo, s.adminrepo, s.orgrepo)
+	return filterReadable(ctx, s.authzrepo, nil, hgs, func(hg *repo.Hostgroup) ([]repo.IResource, error) {
+		teamIds := DedupSliceUint32(append([]uint32{hg.TeamId}, shareTeams[hg.Id]...))
+		ress := make([]repo.IResource, 0, len(teamIds))
+		for _, teamId := range teamIds {
+			res, err := names.leaderResource(ctx, "hostgroups", hg.Name, teamId)
+			if err != nil {
+				return nil, err
+			}
+			ress = append(ress, res)
+		}
+		return ress, nil
+	})
 }
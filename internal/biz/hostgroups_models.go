@@ -2,7 +2,9 @@ package biz
 
 type Hostgroup struct {
 	ChangeInfo
-	Id              uint32
+	Id uint32
+	// OrgId is the organization of the team, set by usecases
+	OrgId           uint32
 	Name            string
 	Description     string
 	ClusterId       uint32
@@ -14,12 +16,27 @@ type Hostgroup struct {
 	TagsId          []uint32
 	ShareProductsId []uint32
 	ShareTeamsId    []uint32
+	// Attributes are values of custom attributes by name
+	Attributes map[string]string
+}
+
+// HostgroupRelations are ids of related entities added to or removed from hostgroups.
+type HostgroupRelations struct {
+	TagsId          []uint32
+	FeaturesId      []uint32
+	ShareProductsId []uint32
+	ShareTeamsId    []uint32
+}
+
+func (r *HostgroupRelations) empty() bool {
+	return r == nil || len(r.TagsId)+len(r.FeaturesId)+len(r.ShareProductsId)+len(r.ShareTeamsId) == 0
 }
 
 type ListHostgroupsFilter struct {
 	Page            uint32
 	PageSize        uint32
 	Ids             []uint32
+	OrgIds          []uint32
 	Names           []string
 	ClustersId      []uint32
 	DatacentersId   []uint32
@@ -30,4 +47,28 @@ type ListHostgroupsFilter struct {
 	TagsId          []uint32
 	ShareProductsId []uint32
 	ShareTeamsId    []uint32
+	// Attributes are name:value of custom attributes hostgroups must have
+	Attributes []string
+	// NameMatch matches Names as prefixes or exact names, names contain them by default
+	NameMatch string
+	// SortBy is id, name, created_at or updated_at, ties are sorted by id
+	SortBy   string
+	SortDesc bool
+	// change times are unix seconds, after bounds are inclusive and before bounds exclusive
+	CreatedAfter      int64
+	CreatedBefore     int64
+	UpdatedAfter      int64
+	UpdatedBefore     int64
+	ExcludeTeamsId    []uint32
+	ExcludeProductsId []uint32
+	// NoTags and NoFeatures select hostgroups without tags or features
+	NoTags     bool
+	NoFeatures bool
+	// Selector is a label selector of names, like team=infra,tag:sla=9999, see ParseSelector
+	Selector string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
@@ -7,15 +7,15 @@ import (
 
 func (f *Hostgroup) Validate(isNew bool) error {
 	if len(f.Name) == 0 {
-		return fmt.Errorf("InvalidNameValue")
+		return ErrInvalidNameValue
 	}
 	if !isNew {
 		if f.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if e := ValidateName(f.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	if f.ClusterId <= 0 {
 		return fmt.Errorf("InvalidClusterId")
@@ -27,21 +27,27 @@ func (f *Hostgroup) Validate(isNew bool) error {
 		return fmt.Errorf("InvalidEnvId")
 	}
 	if f.ProductId <= 0 {
-		return fmt.Errorf("InvalidProductId")
+		return ErrInvalidProductId
 	}
 	if f.TeamId <= 0 {
-		return fmt.Errorf("InvalidTeamId")
+		return ErrInvalidTeamId
 	}
 
 	return nil
 }
 
+// hostgroupSelectorFields are the keys label selectors of hostgroups may use.
+var hostgroupSelectorFields = []string{
+	repo.SelectTeam, repo.SelectProduct, repo.SelectCluster, repo.SelectDatacenter, repo.SelectEnv,
+	repo.SelectTag, repo.SelectFeature,
+}
+
 func (lf *ListHostgroupsFilter) Validate() error {
 	if lf == nil {
 		return nil
 	}
 
-	if len(lf.Names) > MaxFilterValues ||
+	if len(lf.OrgIds) > MaxFilterValues ||
 		len(lf.ClustersId) > MaxFilterValues ||
 		len(lf.DatacentersId) > MaxFilterValues ||
 		len(lf.EnvsId) > MaxFilterValues ||
@@ -50,10 +56,16 @@ func (lf *ListHostgroupsFilter) Validate() error {
 		len(lf.FeaturesId) > MaxFilterValues ||
 		len(lf.TagsId) > MaxFilterValues ||
 		len(lf.ShareProductsId) > MaxFilterValues ||
-		len(lf.ShareTeamsId) > MaxFilterValues {
+		len(lf.ShareTeamsId) > MaxFilterValues ||
+		len(lf.Attributes) > MaxFilterValues ||
+		len(lf.ExcludeTeamsId) > MaxFilterValues ||
+		len(lf.ExcludeProductsId) > MaxFilterValues {
 
 		return ErrFilterValuesExceedMax
 	}
+	if lf.NameMatch != repo.NameMatchExact && len(lf.Names) > MaxFilterPatterns {
+		return ErrFilterValuesExceedMax
+	}
 
 	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
 		return ErrFilterInvalidPagesize
@@ -61,7 +73,10 @@ func (lf *ListHostgroupsFilter) Validate() error {
 	if lf.Page == 0 {
 		return ErrFilterInvalidPage
 	}
-	return nil
+	if err := validateSelector(lf.Selector, hostgroupSelectorFields); err != nil {
+		return invalidField("selector", err)
+	}
+	return validateListSort(lf.SortBy, lf.SortDesc, lf.NameMatch, lf.PageToken)
 }
 
 func DefaultHostgroupFilter() *ListHostgroupsFilter {
@@ -74,6 +89,7 @@ func DefaultHostgroupFilter() *ListHostgroupsFilter {
 func ToDBHostgroup(t *Hostgroup) (*repo.Hostgroup, error) {
 	return &repo.Hostgroup{
 		Id:           t.Id,
+		OrgId:        t.OrgId,
 		Name:         t.Name,
 		Description:  t.Description,
 		ClusterId:    t.ClusterId,
@@ -99,6 +115,7 @@ func ToDBHostgroups(ts []*Hostgroup) ([]*repo.Hostgroup, error) {
 func ToBizHostgroup(t *repo.Hostgroup) (*Hostgroup, error) {
 	return &Hostgroup{
 		Id:           t.Id,
+		OrgId:        t.OrgId,
 		Description:  t.Description,
 		Name:         t.Name,
 		ClusterId:    t.ClusterId,
@@ -131,12 +148,29 @@ func ToBizHostgroups(ps []*repo.Hostgroup) ([]*Hostgroup, error) {
 
 func ToDBHostgroupsFilter(filter *ListHostgroupsFilter) *repo.HostgroupsFilter {
 	return &repo.HostgroupsFilter{
+		Page:          filter.Page,
+		PageSize:      filter.PageSize,
+		LastId:        pageTokenId(filter.PageToken),
 		Ids:           filter.Ids,
+		OrgIds:        filter.OrgIds,
 		Names:         filter.Names,
 		ClustersId:    filter.ClustersId,
 		DatacentersId: filter.DatacentersId,
 		EnvsId:        filter.EnvsId,
 		ProductsId:    filter.ProductsId,
 		TeamsId:       filter.TeamsId,
+
+		ChangeFilter: repo.ChangeFilter{
+			CreatedAfter:  filter.CreatedAfter,
+			CreatedBefore: filter.CreatedBefore,
+			UpdatedAfter:  filter.UpdatedAfter,
+			UpdatedBefore: filter.UpdatedBefore,
+		},
+		NameMatch:         filter.NameMatch,
+		Sort:              toDBListSort(filter.SortBy, filter.SortDesc, filter.PageToken),
+		ExcludeTeamsId:    filter.ExcludeTeamsId,
+		ExcludeProductsId: filter.ExcludeProductsId,
+		NoTags:            filter.NoTags,
+		NoFeatures:        filter.NoFeatures,
 	}
 }
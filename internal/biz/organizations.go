@@ -0,0 +1,353 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type OrganizationsUsecase struct {
+	orgRepo   repo.OrganizationsRepo
+	authzRepo repo.AuthzRepo
+	adminRepo repo.AdminRepo
+	log       *log.Helper
+	txm       repo.TxManager
+	required  []requiredBy
+}
+
+func NewOrganizationsUsecase(
+	orgrepo repo.OrganizationsRepo,
+	authzrepo repo.AuthzRepo,
+	adminrepo repo.AdminRepo,
+	teamrepo repo.TeamsRepo,
+	prdrepo repo.ProductsRepo,
+	logger log.Logger,
+	txm repo.TxManager) *OrganizationsUsecase {
+	return &OrganizationsUsecase{
+		orgRepo:   orgrepo,
+		authzRepo: authzrepo,
+		adminRepo: adminrepo,
+		log:       log.NewHelper(logger),
+		txm:       txm,
+		required: []requiredBy{
+			{inst: teamrepo, name: "team"},
+			{inst: prdrepo, name: "product"},
+		},
+	}
+}
+
+// orgName is the org section of casbin resources of id, the default organization is
+// not looked up.
+func orgName(ctx context.Context, orgRepo repo.OrganizationsRepo, id uint32) (string, error) {
+	if id == 0 || id == repo.DefaultOrgId {
+		return repo.DefaultOrgName, nil
+	}
+	org, err := orgRepo.GetOrganizations(ctx, id)
+	if err != nil {
+		return "", errors.Join(NotFound("organization %d not found", id), err)
+	}
+	return org.Name, nil
+}
+
+// selectedOrg is the organization selected by the request, nil if none.
+func selectedOrg(ctx context.Context, orgRepo repo.OrganizationsRepo, tx repo.TX) (*repo.Organization, error) {
+	name, _ := ctx.Value(data.CtxOrgName).(string)
+	if name == "" {
+		return nil, nil
+	}
+	orgs, err := orgRepo.ListOrganizations(ctx, tx, &repo.OrganizationsFilter{Names: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(orgs) != 1 {
+		return nil, NotFound("organization %s not found", name)
+	}
+	return orgs[0], nil
+}
+
+// selectedOrgIds filters lists by the selected organization, all organizations if none.
+func selectedOrgIds(ctx context.Context, orgRepo repo.OrganizationsRepo) ([]uint32, error) {
+	org, err := selectedOrg(ctx, orgRepo, nil)
+	if err != nil || org == nil {
+		return nil, err
+	}
+	return []uint32{org.ID}, nil
+}
+
+// newOrgId is the organization of new teams and products, the selected one or default
+// if not set.
+func newOrgId(ctx context.Context, orgRepo repo.OrganizationsRepo, tx repo.TX, id uint32) (uint32, error) {
+	if id > 0 {
+		if _, err := orgName(ctx, orgRepo, id); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+	org, err := selectedOrg(ctx, orgRepo, tx)
+	if err != nil {
+		return 0, err
+	}
+	if org == nil {
+		return repo.DefaultOrgId, nil
+	}
+	return org.ID, nil
+}
+
+func (s *OrganizationsUsecase) enforce(ctx context.Context, tx repo.TX, res repo.IResource) error {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	can, err := s.authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: res,
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return err
+	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *OrganizationsUsecase) validate(isNew bool, orgs []*Organization) error {
+	if len(orgs) == 0 {
+		return fmt.Errorf("EmptyOrganizations")
+	}
+	for _, o := range orgs {
+		if o == nil {
+			return fmt.Errorf("organization is nil")
+		}
+		if err := o.Validate(isNew); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listAdmins lists users of the admin role of org.
+func (s *OrganizationsUsecase) listAdmins(ctx context.Context, tx repo.TX, name string) ([]string, error) {
+	groups, err := s.authzRepo.ListGroup(ctx, tx, &repo.GroupFilter{Role: OrgAdminSubject(name)})
+	if err != nil {
+		return nil, err
+	}
+	admins := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g != nil {
+			admins = append(admins, g.User)
+		}
+	}
+	return admins, nil
+}
+
+// setAdmins makes admins the users of the admin role of org.
+func (s *OrganizationsUsecase) setAdmins(ctx context.Context, tx repo.TX, name string, admins []string) error {
+	current, err := s.listAdmins(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+	for _, u := range current {
+		if slices.Contains(admins, u) {
+			continue
+		}
+		if err := s.authzRepo.DeleteGroup(ctx, tx, &repo.Group{User: u, Role: OrgAdminSubject(name)}); err != nil {
+			return err
+		}
+	}
+	for _, u := range admins {
+		if slices.Contains(current, u) {
+			continue
+		}
+		users, err := s.adminRepo.ListUsers(ctx, tx, &repo.UsersFilter{UserName: []string{u}})
+		if err != nil {
+			return err
+		}
+		if len(users) != 1 {
+			return NotFound("user %s not found", u)
+		}
+		if err := s.authzRepo.CreateGroup(ctx, tx, &repo.Group{User: u, Role: OrgAdminSubject(name)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orgAdminRule(name string) *repo.Rule {
+	return &repo.Rule{
+		Sub:      OrgAdminSubject(name),
+		Resource: repo.NewOrgResource4Sv1(name, "", "", "", ""),
+		Action:   repo.ActWrite,
+	}
+}
+
+// CreateOrganizations creates organizations with the rule of their admins, admin only.
+func (s *OrganizationsUsecase) CreateOrganizations(ctx context.Context, orgs []*Organization) error {
+	if err := s.validate(true, orgs); err != nil {
+		return errors.Join(errors.New("CreateOrganizations failed"), invalidArgument(err))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx, repo.NewResource4Sv1("organizations", "", "", "")); err != nil {
+			return err
+		}
+		for _, o := range orgs {
+			dborg := ToDBOrganization(o)
+			if err := s.orgRepo.CreateOrganizations(ctx, tx, []*repo.Organization{dborg}); err != nil {
+				return err
+			}
+			o.Id = dborg.ID
+			if err := s.authzRepo.CreateRule(ctx, tx, orgAdminRule(o.Name)); err != nil {
+				return err
+			}
+			if err := s.setAdmins(ctx, tx, o.Name, o.Admins); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("CreateOrganizations failed"), err)
+	}
+	return nil
+}
+
+// UpdateOrganizations changes descriptions and admins, admins of an organization may update it.
+// names are in casbin rules and can not be changed.
+func (s *OrganizationsUsecase) UpdateOrganizations(ctx context.Context, orgs []*Organization) error {
+	if err := s.validate(false, orgs); err != nil {
+		return errors.Join(errors.New("UpdateOrganizations failed"), invalidArgument(err))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		for _, o := range orgs {
+			old, err := s.orgRepo.GetOrganizations(ctx, o.Id)
+			if err != nil {
+				return err
+			}
+			if old.Name != o.Name {
+				return InvalidArgument("name of organization %s can not be changed", old.Name).WithField("name", "can not be changed")
+			}
+			if err := s.enforce(ctx, tx, repo.NewOrgResource4Sv1(old.Name, "organizations", "", "", "")); err != nil {
+				return err
+			}
+			if err := conflicting(s.orgRepo.UpdateOrganizations(ctx, tx, []*repo.Organization{ToDBOrganization(o)})); err != nil {
+				return err
+			}
+			if err := s.setAdmins(ctx, tx, o.Name, o.Admins); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("UpdateOrganizations failed"), err)
+	}
+	return nil
+}
+
+// DeleteOrganizations deletes organizations without teams and products, admin only.
+func (s *OrganizationsUsecase) DeleteOrganizations(ctx context.Context, ids []uint32) error {
+	if len(ids) == 0 {
+		return errors.Join(errors.New("DeleteOrganizations failed"), ErrEmptyIds)
+	}
+	if slices.Contains(ids, repo.DefaultOrgId) {
+		return errors.Join(errors.New("DeleteOrganizations failed"),
+			FailedPrecondition("organization %s can not be deleted", repo.DefaultOrgName))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx, repo.NewResource4Sv1("organizations", "", "", "")); err != nil {
+			return err
+		}
+		for _, r := range s.required {
+			c, err := r.inst.CountRequire(ctx, tx, repo.RequireOrg, ids)
+			if err != nil {
+				return err
+			}
+			if c > 0 {
+				return FailedPrecondition("some %s requires", r.name)
+			}
+		}
+		orgs, err := s.orgRepo.ListOrganizations(ctx, tx, &repo.OrganizationsFilter{Ids: ids})
+		if err != nil {
+			return err
+		}
+		if err := s.orgRepo.DeleteOrganizations(ctx, tx, ids); err != nil {
+			return err
+		}
+		for _, o := range orgs {
+			if err := s.setAdmins(ctx, tx, o.Name, nil); err != nil {
+				return err
+			}
+			if err := s.authzRepo.DeleteRule(ctx, tx, orgAdminRule(o.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("DeleteOrganizations failed"), err)
+	}
+	return nil
+}
+
+// GetOrganizations is
+func (s *OrganizationsUsecase) GetOrganizations(ctx context.Context, id uint32) (*Organization, error) {
+	if id <= 0 {
+		return nil, errors.Join(errors.New("GetOrganizations failed"), ErrInvalidId)
+	}
+	if err := enforceRead(ctx, s.authzRepo, nil, repo.NewResource4Sv1("organizations", "", "", "")); err != nil {
+		return nil, errors.Join(errors.New("GetOrganizations failed"), err)
+	}
+	org, err := s.orgRepo.GetOrganizations(ctx, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("GetOrganizations failed"), err)
+	}
+	admins, err := s.listAdmins(ctx, nil, org.Name)
+	if err != nil {
+		return nil, errors.Join(errors.New("GetOrganizations failed"), err)
+	}
+	return ToBizOrganization(org, admins), nil
+}
+
+// ListOrganizations is
+func (s *OrganizationsUsecase) ListOrganizations(ctx context.Context, filter *ListOrganizationsFilter) ([]*Organization, *PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, errors.Join(errors.New("ListOrganizations failed"), invalidArgument(err))
+	}
+	if err := enforceRead(ctx, s.authzRepo, nil, repo.NewResource4Sv1("organizations", "", "", "")); err != nil {
+		return nil, nil, errors.Join(errors.New("ListOrganizations failed"), err)
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListOrganizationsFilter{}
+	}
+	dbFilter := ToDBOrganizationsFilter(filter)
+	orgs, err := s.orgRepo.ListOrganizations(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListOrganizations failed"), err)
+	}
+	page, err := listPage(orgs, dbFilter.PageSize, func(o *repo.Organization) uint32 { return o.ID },
+		filter.WithTotal, func() ([]*repo.Organization, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.orgRepo.ListOrganizations(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListOrganizations failed"), err)
+	}
+	res := make([]*Organization, len(orgs))
+	for i, o := range orgs {
+		admins, err := s.listAdmins(ctx, nil, o.Name)
+		if err != nil {
+			return nil, nil, errors.Join(errors.New("ListOrganizations failed"), err)
+		}
+		res[i] = ToBizOrganization(o, admins)
+	}
+	return res, page, nil
+}
@@ -0,0 +1,25 @@
+package biz
+
+// MaxOrganizationAdmins limits admins of an organization
+const MaxOrganizationAdmins = 10
+
+// Organization is a namespace of teams and products, Admins are users who can write all
+// resources of it.
+type Organization struct {
+	Id          uint32
+	Name        string
+	Description string
+	Admins      []string
+}
+
+type ListOrganizationsFilter struct {
+	Page     uint32
+	PageSize uint32
+	Names    []string
+	Ids      []uint32
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
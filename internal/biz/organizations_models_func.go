@@ -0,0 +1,100 @@
+package biz
+
+import (
+	"fmt"
+	"opspillar/internal/data/repo"
+)
+
+func (o *Organization) Validate(isNew bool) error {
+	if len(o.Name) == 0 {
+		return fmt.Errorf("InvalidName")
+	}
+	if !isNew {
+		if o.Id <= 0 {
+			return ErrInvalidId
+		}
+	}
+	if e := ValidateName(o.Name); e != nil {
+		return invalidField("name", e)
+	}
+	if len(o.Admins) > MaxOrganizationAdmins {
+		return InvalidArgument("too many admins, at most %d", MaxOrganizationAdmins).
+			WithField("admins", fmt.Sprintf("at most %d", MaxOrganizationAdmins))
+	}
+	for _, a := range o.Admins {
+		if e := validateAuthzName("admin", a); e != nil {
+			return invalidField("admins", e)
+		}
+	}
+	return nil
+}
+
+func (lf *ListOrganizationsFilter) Validate() error {
+	if lf == nil {
+		return nil
+	}
+	if len(lf.Names) > MaxFilterValues || len(lf.Ids) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
+		return ErrFilterInvalidPagesize
+	}
+	if lf.Page == 0 {
+		return ErrFilterInvalidPage
+	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefaultOrganizationsFilter() *ListOrganizationsFilter {
+	return &ListOrganizationsFilter{
+		Page:     1,
+		PageSize: DefaultPageSize,
+	}
+}
+
+// OrgAdminSubject is the casbin role of admins of organization.
+func OrgAdminSubject(orgName string) string {
+	return orgName + ":admin"
+}
+
+// TeamSubject is the casbin subject of team. names of teams are unique in an organization,
+// teams out of the default organization are prefixed with its name.
+func TeamSubject(orgName, teamName string) string {
+	if orgName == "" || orgName == repo.DefaultOrgName {
+		return teamName
+	}
+	return orgName + "." + teamName
+}
+
+func ToDBOrganization(o *Organization) *repo.Organization {
+	return &repo.Organization{
+		ID:          o.Id,
+		Name:        o.Name,
+		Description: o.Description,
+	}
+}
+
+func ToBizOrganization(o *repo.Organization, admins []string) *Organization {
+	return &Organization{
+		Id:          o.ID,
+		Name:        o.Name,
+		Description: o.Description,
+		Admins:      admins,
+	}
+}
+
+func ToDBOrganizationsFilter(filter *ListOrganizationsFilter) *repo.OrganizationsFilter {
+	if filter == nil {
+		return nil
+	}
+	return &repo.OrganizationsFilter{
+		Ids:      filter.Ids,
+		Names:    filter.Names,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
+	}
+}
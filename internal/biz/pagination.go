@@ -0,0 +1,169 @@
+package biz
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+
+	"opspillar/internal/data/repo"
+)
+
+// PageInfo tells how to continue a list. NextPageToken is empty on the last page, which may be
+// empty when the previous page ended the list. TotalCount is set when asked by the filter.
+type PageInfo struct {
+	NextPageToken string
+	TotalCount    *int64
+}
+
+// pageToken is the position of a page, clients pass it back unchanged.
+type pageToken struct {
+	// LastId is the id of the last row of the previous page
+	LastId uint32 `json:"l"`
+	// Sort is the column of a list not sorted by id, After the value of the last row in it
+	Sort  string      `json:"s,omitempty"`
+	After interface{} `json:"v,omitempty"`
+	// Desc is set in tokens of lists sorted in descending order
+	Desc bool `json:"d,omitempty"`
+}
+
+// EncodePageToken returns the token of the page after the row of lastId.
+func EncodePageToken(lastId uint32) string {
+	b, _ := json.Marshal(&pageToken{LastId: lastId})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// EncodeSortedPageToken returns the token of the page after the row of lastId in a list sorted
+// by sortBy in the order of desc, after is the value of the row in that column.
+func EncodeSortedPageToken(lastId uint32, sortBy string, desc bool, after interface{}) string {
+	b, _ := json.Marshal(&pageToken{LastId: lastId, Sort: sortBy, Desc: desc, After: after})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodePageToken returns the id of the last row before the page of token, 0 if token is empty.
+func DecodePageToken(token string) (uint32, error) {
+	t, err := decodePageToken(token)
+	if err != nil {
+		return 0, err
+	}
+	return t.LastId, nil
+}
+
+func decodePageToken(token string) (*pageToken, error) {
+	t := &pageToken{}
+	if token == "" {
+		return t, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrFilterInvalidPageToken
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(t); err != nil || t.LastId == 0 {
+		return nil, ErrFilterInvalidPageToken
+	}
+	return t, nil
+}
+
+// decodeSortedPageToken returns the last id and sort value of token of a list sorted by sortBy
+// in the order of desc, tokens of lists sorted otherwise are rejected.
+func decodeSortedPageToken(token string, sortBy string, desc bool) (uint32, interface{}, error) {
+	t, err := decodePageToken(token)
+	if err != nil {
+		return 0, nil, err
+	}
+	if token == "" {
+		return 0, nil, nil
+	}
+	if t.Sort != sortBy || t.Desc != desc {
+		return 0, nil, ErrFilterPageTokenSort
+	}
+	switch sortBy {
+	case "":
+		return t.LastId, nil, nil
+	case repo.SortName:
+		if name, ok := t.After.(string); ok {
+			return t.LastId, name, nil
+		}
+	case repo.SortCreatedAt, repo.SortUpdatedAt:
+		if n, ok := t.After.(json.Number); ok {
+			if at, err := n.Int64(); err == nil {
+				return t.LastId, at, nil
+			}
+		}
+	}
+	return 0, nil, ErrFilterInvalidPageToken
+}
+
+// pageTokenId is the last id of a token checked by the filter validation.
+func pageTokenId(token string) uint32 {
+	id, _ := DecodePageToken(token)
+	return id
+}
+
+// listPage returns the page info of rows listed for a page of pageSize. rows are fetched before
+// filtering for the caller, so the list continues after rows the caller can not read. totals
+// count rows of all pages listed by unpaged when asked.
+func listPage[T any](rows []T, pageSize uint32, id func(T) uint32,
+	withTotal bool, unpaged func() ([]T, error)) (*PageInfo, error) {
+	page := &PageInfo{}
+	if pageSize > 0 && len(rows) >= int(pageSize) {
+		page.NextPageToken = EncodePageToken(id(rows[len(rows)-1]))
+	}
+	if withTotal {
+		all, err := unpaged()
+		if err != nil {
+			return nil, err
+		}
+		total := int64(len(all))
+		page.TotalCount = &total
+	}
+	return page, nil
+}
+
+// toDBListSort returns the sort of a list by sortBy, continuing after the row of token.
+// sortBy and token are checked by validateListSort.
+func toDBListSort(sortBy string, desc bool, token string) repo.ListSort {
+	by := listSortColumn(sortBy)
+	_, after, _ := decodeSortedPageToken(token, by, desc)
+	return repo.ListSort{By: by, Desc: desc, After: after}
+}
+
+// listSortColumn returns the column of sortBy, empty for lists sorted by id.
+func listSortColumn(sortBy string) string {
+	if sortBy == "id" {
+		return ""
+	}
+	return sortBy
+}
+
+// validateListSort checks sortBy and nameMatch of a list filter and that token continues a
+// list of the same sort and order.
+func validateListSort(sortBy string, desc bool, nameMatch string, token string) error {
+	switch sortBy {
+	case "", "id", repo.SortName, repo.SortCreatedAt, repo.SortUpdatedAt:
+	default:
+		return ErrFilterInvalidSort
+	}
+	switch nameMatch {
+	case "", repo.NameMatchPrefix, repo.NameMatchExact:
+	default:
+		return ErrFilterInvalidNameMatch
+	}
+	_, _, err := decodeSortedPageToken(token, listSortColumn(sortBy), desc)
+	return err
+}
+
+// sortedPageToken returns the token of the page after a row of id, name and change in the
+// order of sort.
+func sortedPageToken(sort repo.ListSort, id uint32, name string, change repo.ChangeInfo) string {
+	switch sort.By {
+	case repo.SortName:
+		return EncodeSortedPageToken(id, sort.By, sort.Desc, name)
+	case repo.SortCreatedAt:
+		return EncodeSortedPageToken(id, sort.By, sort.Desc, change.CreatedAt)
+	case repo.SortUpdatedAt:
+		return EncodeSortedPageToken(id, sort.By, sort.Desc, change.UpdatedAt)
+	}
+	return EncodeSortedPageToken(id, "", sort.Desc, nil)
+}
@@ -4,7 +4,6 @@ import (
 	"opspillar/internal/data"
 	"opspillar/internal/data/repo"
 	"context"
-	"fmt"
 
 	"github.com/go-kratos/kratos/v2/log"
 )
@@ -12,6 +11,7 @@ import (
 type ProductsUsecase struct {
 	txm       repo.TxManager
 	prdrepo   repo.ProductsRepo
+	orgRepo   repo.OrganizationsRepo
 	authzrepo repo.AuthzRepo
 	log       *log.Helper
 	required  []requiredBy
@@ -19,6 +19,7 @@ type ProductsUsecase struct {
 
 func NewProductsUsecase(
 	repo repo.ProductsRepo,
+	orgrepo repo.OrganizationsRepo,
 	authzrepo repo.AuthzRepo,
 	hostgrouprepo repo.HostgroupsRepo,
 	apprepo repo.ApplicationsRepo,
@@ -27,6 +28,7 @@ func NewProductsUsecase(
 	txm repo.TxManager) *ProductsUsecase {
 	return &ProductsUsecase{
 		prdrepo:   repo,
+		orgRepo:   orgrepo,
 		authzrepo: authzrepo,
 		log:       log.NewHelper(logger),
 		txm:       txm,
@@ -47,9 +49,10 @@ func (s *ProductsUsecase) validate(isNew bool, ps []*Product) error {
 	return nil
 }
 
-func (s *ProductsUsecase) enforce(ctx context.Context, tx repo.TX) error {
+// enforce requires permission on products of org, admins of the organization have it.
+func (s *ProductsUsecase) enforce(ctx context.Context, tx repo.TX, org string) error {
 	curUser := ctx.Value(data.CtxUserName).(string)
-	ires := repo.NewResource4Sv1("product", "", "", "")
+	ires := repo.NewOrgResource4Sv1(org, "product", "", "", "")
 	can, err := s.authzrepo.Enforce(ctx, tx, &repo.AuthenRequest{
 		Sub:      curUser,
 		Resource: ires,
@@ -59,7 +62,7 @@ func (s *ProductsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 }
@@ -67,7 +70,7 @@ func (s *ProductsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 // CreateProducts is
 func (s *ProductsUsecase) CreateProducts(ctx context.Context, ps []*Product) error {
 	if err := s.validate(true, ps); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_ps, e := ToDBProducts(ps)
 	if e != nil {
@@ -75,12 +78,26 @@ func (s *ProductsUsecase) CreateProducts(ctx context.Context, ps []*Product) err
 	}
 	//return s.prdrepo.CreateProducts(ctx, _ps)
 	err := s.txm.RunInTX(func(tx repo.TX) error {
-		if err := s.enforce(ctx, tx); err != nil {
-			return err
+		for _, p := range _ps {
+			orgId, err := newOrgId(ctx, s.orgRepo, tx, p.OrgId)
+			if err != nil {
+				return err
+			}
+			p.OrgId = orgId
+			org, err := orgName(ctx, s.orgRepo, orgId)
+			if err != nil {
+				return err
+			}
+			if err := s.enforce(ctx, tx, org); err != nil {
+				return err
+			}
 		}
 		if e := s.prdrepo.CreateProducts(ctx, tx, _ps); e != nil {
 			return e
 		}
+		for i, p := range _ps {
+			ps[i].Id = p.ID
+		}
 		return nil
 	})
 	return err
@@ -89,7 +106,7 @@ func (s *ProductsUsecase) CreateProducts(ctx context.Context, ps []*Product) err
 // UpdateProducts is
 func (s *ProductsUsecase) UpdateProducts(ctx context.Context, ps []*Product) error {
 	if err := s.validate(false, ps); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	dps, e := ToDBProducts(ps)
 	if e != nil {
@@ -97,10 +114,24 @@ func (s *ProductsUsecase) UpdateProducts(ctx context.Context, ps []*Product) err
 	}
 	//return s.prdrepo.UpdateProducts(ctx, dps)
 	err := s.txm.RunInTX(func(tx repo.TX) error {
-		if err := s.enforce(ctx, tx); err != nil {
-			return err
+		for _, p := range dps {
+			old, err := s.prdrepo.GetProducts(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+			if p.OrgId != 0 && p.OrgId != old.OrgId {
+				return InvalidArgument("organization of product %s can not be changed", old.Name).WithField("org_id", "can not be changed")
+			}
+			p.OrgId = old.OrgId
+			org, err := orgName(ctx, s.orgRepo, old.OrgId)
+			if err != nil {
+				return err
+			}
+			if err := s.enforce(ctx, tx, org); err != nil {
+				return err
+			}
 		}
-		if e := s.prdrepo.UpdateProducts(ctx, tx, dps); e != nil {
+		if e := conflicting(s.prdrepo.UpdateProducts(ctx, tx, dps)); e != nil {
 			return e
 		}
 		return nil
@@ -111,19 +142,29 @@ func (s *ProductsUsecase) UpdateProducts(ctx context.Context, ps []*Product) err
 // DeleteProducts is
 func (s *ProductsUsecase) DeleteProducts(ctx context.Context, ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 	return s.txm.RunInTX(func(tx repo.TX) error {
-		if err := s.enforce(ctx, tx); err != nil {
+		ps, err := s.prdrepo.ListProducts(ctx, tx, &repo.ProductsFilter{Ids: ids})
+		if err != nil {
 			return err
 		}
+		for _, p := range ps {
+			org, err := orgName(ctx, s.orgRepo, p.OrgId)
+			if err != nil {
+				return err
+			}
+			if err := s.enforce(ctx, tx, org); err != nil {
+				return err
+			}
+		}
 		for _, r := range s.required {
 			c, err := r.inst.CountRequire(ctx, tx, repo.RequireProduct, ids)
 			if err != nil {
 				return err
 			}
 			if c > 0 {
-				return fmt.Errorf("some %s requires", r.name)
+				return FailedPrecondition("some %s requires", r.name)
 			}
 		}
 		if e := s.prdrepo.DeleteProducts(ctx, tx, ids); e != nil {
@@ -136,7 +177,10 @@ func (s *ProductsUsecase) DeleteProducts(ctx context.Context, ids []uint32) erro
 // GetProducts is
 func (s *ProductsUsecase) GetProducts(ctx context.Context, id uint32) (*Product, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("EmptyId")
+		return nil, ErrEmptyId
+	}
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("product", "", "", "")); err != nil {
+		return nil, err
 	}
 	ps, e := s.prdrepo.GetProducts(ctx, id)
 	if e != nil {
@@ -146,15 +190,39 @@ func (s *ProductsUsecase) GetProducts(ctx context.Context, id uint32) (*Product,
 }
 
 // ListProducts is
-func (s *ProductsUsecase) ListProducts(ctx context.Context, filter *ListProductsFilter) ([]*Product, error) {
+func (s *ProductsUsecase) ListProducts(ctx context.Context, filter *ListProductsFilter) ([]*Product, *PageInfo, error) {
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
-	dbps, e := s.prdrepo.ListProducts(ctx, nil, ToDBProductFilter(filter))
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("product", "", "", "")); err != nil {
+		return nil, nil, err
+	}
+	dbFilter := &repo.ProductsFilter{}
+	if filter != nil {
+		dbFilter = ToDBProductFilter(filter)
+	}
+	if len(dbFilter.OrgIds) == 0 {
+		orgIds, e := selectedOrgIds(ctx, s.orgRepo)
+		if e != nil {
+			return nil, nil, e
+		}
+		dbFilter.OrgIds = orgIds
+	}
+	dbps, e := s.prdrepo.ListProducts(ctx, nil, dbFilter)
 	if e != nil {
-		return nil, e
+		return nil, nil, e
+	}
+	page, e := listPage(dbps, dbFilter.PageSize, func(p *repo.Product) uint32 { return p.ID },
+		filter != nil && filter.WithTotal, func() ([]*repo.Product, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.prdrepo.ListProducts(ctx, nil, &all)
+		})
+	if e != nil {
+		return nil, nil, e
 	}
-	return ToBizProducts(dbps)
+	res, e := ToBizProducts(dbps)
+	return res, page, e
 }
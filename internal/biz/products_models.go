@@ -1,7 +1,9 @@
 package biz
 
 type Product struct {
-	Id          uint32
+	Id uint32
+	// OrgId is the organization of product, the selected one if not set on creation
+	OrgId       uint32
 	Name        string
 	Code        string
 	Description string
@@ -13,4 +15,10 @@ type ListProductsFilter struct {
 	Names    []string
 	Codes    []string
 	Ids      []uint32
+	OrgIds   []uint32
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
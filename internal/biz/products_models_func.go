@@ -11,14 +11,14 @@ func (f *Product) Validate(isNew bool) error {
 	}
 	if !isNew {
 		if f.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if e := ValidateName(f.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	if e := ValidateCode(f.Code); e != nil {
-		return e
+		return invalidField("code", e)
 	}
 	return nil
 }
@@ -27,9 +27,10 @@ func (lf *ListProductsFilter) Validate() error {
 	if lf == nil {
 		return nil
 	}
-	if len(lf.Codes) > MaxFilterValues ||
+	if len(lf.Codes) > MaxFilterPatterns ||
 		len(lf.Ids) > MaxFilterValues ||
-		len(lf.Names) > MaxFilterValues {
+		len(lf.Names) > MaxFilterPatterns ||
+		len(lf.OrgIds) > MaxFilterValues {
 
 		return ErrFilterValuesExceedMax
 	}
@@ -40,6 +41,9 @@ func (lf *ListProductsFilter) Validate() error {
 		return ErrFilterInvalidPage
 	}
 
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -53,6 +57,7 @@ func DefaultProductsFilter() *ListProductsFilter {
 func ToDBProduct(t *Product) (*repo.Product, error) {
 	return &repo.Product{
 		ID:          t.Id,
+		OrgId:       t.OrgId,
 		Name:        t.Name,
 		Code:        t.Code,
 		Description: t.Description,
@@ -74,6 +79,7 @@ func ToDBProducts(ts []*Product) ([]*repo.Product, error) {
 func ToBizProduct(t *repo.Product) (*Product, error) {
 	return &Product{
 		Id:          t.ID,
+		OrgId:       t.OrgId,
 		Code:        t.Code,
 		Description: t.Description,
 		Name:        t.Name,
@@ -85,6 +91,7 @@ func ToBizProducts(ps []*repo.Product) ([]*Product, error) {
 	for i, t := range ps {
 		_ps[i] = &Product{
 			Id:          t.ID,
+			OrgId:       t.OrgId,
 			Code:        t.Code,
 			Description: t.Description,
 			Name:        t.Name,
@@ -98,7 +105,9 @@ func ToDBProductFilter(filter *ListProductsFilter) *repo.ProductsFilter {
 		Codes:    filter.Codes,
 		Ids:      filter.Ids,
 		Names:    filter.Names,
+		OrgIds:   filter.OrgIds,
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
 	}
 }
@@ -0,0 +1,324 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"opspillar/internal/data/repo"
+)
+
+// Operators of selector requirements.
+const (
+	SelectorEquals    = "="
+	SelectorNotEquals = "!="
+	SelectorIn        = "in"
+	SelectorNotIn     = "notin"
+	SelectorExists    = "exists"
+	SelectorNotExists = "!"
+)
+
+// MaxSelectorLength limits the length of a label selector.
+const MaxSelectorLength = 1024
+
+// selectorKeyPrefixes are the keys of related labels, their names follow the colon.
+var selectorKeyPrefixes = []string{repo.SelectTag + ":", repo.SelectFeature + ":"}
+
+var (
+	selectorKeyRegexp   = regexp.MustCompile(`^[A-Za-z0-9_.-]+(:[A-Za-z0-9_./-]+)?$`)
+	selectorValueRegexp = regexp.MustCompile(`^[^\s,()=!]+$`)
+	selectorSetRegexp   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Requirement is a term of a label selector, Key is a field like team or a related label like
+// tag:sla. Values are empty for exists and not exists.
+type Requirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// ParseSelector parses a label selector of comma separated requirements, all of them must
+// match:
+//
+//	team=infra,product in (meta,ads),tag:sla=9999,!feature:gpu
+//
+// key=value and key!=value compare one value, key in (v1,v2) and key notin (v1,v2) sets of
+// them. A tag:key or feature:name alone requires the label with any value, !tag:key requires
+// its absence.
+func ParseSelector(selector string) ([]Requirement, error) {
+	if len(selector) > MaxSelectorLength {
+		return nil, fmt.Errorf("%w: longer than %d", ErrFilterInvalidSelector, MaxSelectorLength)
+	}
+	terms, err := splitSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) > MaxFilterPatterns {
+		return nil, fmt.Errorf("%w: more than %d requirements", ErrFilterInvalidSelector, MaxFilterPatterns)
+	}
+	reqs := make([]Requirement, 0, len(terms))
+	for _, term := range terms {
+		req, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, *req)
+	}
+	return reqs, nil
+}
+
+// splitSelector splits selector at commas outside of parentheses.
+func splitSelector(selector string) ([]string, error) {
+	var terms []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, strings.TrimSpace(selector[start:i]))
+				start = i + 1
+			}
+		}
+		if depth < 0 || depth > 1 {
+			return nil, fmt.Errorf("%w: unbalanced parentheses", ErrFilterInvalidSelector)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses", ErrFilterInvalidSelector)
+	}
+	if last := strings.TrimSpace(selector[start:]); last != "" || len(terms) > 0 {
+		terms = append(terms, last)
+	}
+	return terms, nil
+}
+
+func parseRequirement(term string) (*Requirement, error) {
+	req := &Requirement{}
+	switch {
+	case term == "":
+		return nil, fmt.Errorf("%w: empty requirement", ErrFilterInvalidSelector)
+	case selectorSetRegexp.MatchString(term):
+		m := selectorSetRegexp.FindStringSubmatch(term)
+		req.Key, req.Operator = m[1], m[2]
+		for _, v := range strings.Split(m[3], ",") {
+			req.Values = append(req.Values, strings.TrimSpace(v))
+		}
+	case strings.HasPrefix(term, SelectorNotExists):
+		req.Key, req.Operator = strings.TrimSpace(term[1:]), SelectorNotExists
+	case strings.Contains(term, SelectorNotEquals):
+		kv := strings.SplitN(term, SelectorNotEquals, 2)
+		req.Key, req.Operator = strings.TrimSpace(kv[0]), SelectorNotEquals
+		req.Values = []string{strings.TrimSpace(kv[1])}
+	case strings.Contains(term, SelectorEquals):
+		kv := strings.SplitN(term, SelectorEquals, 2)
+		// == is the same as =
+		req.Key, req.Operator = strings.TrimSpace(kv[0]), SelectorEquals
+		req.Values = []string{strings.TrimSpace(strings.TrimPrefix(kv[1], SelectorEquals))}
+	default:
+		req.Key, req.Operator = term, SelectorExists
+	}
+
+	if !selectorKeyRegexp.MatchString(req.Key) {
+		return nil, fmt.Errorf("%w: invalid key %q", ErrFilterInvalidSelector, req.Key)
+	}
+	if len(req.Values) > MaxFilterPatterns {
+		return nil, fmt.Errorf("%w: more than %d values of %s", ErrFilterInvalidSelector, MaxFilterPatterns, req.Key)
+	}
+	for _, v := range req.Values {
+		if !selectorValueRegexp.MatchString(v) {
+			return nil, fmt.Errorf("%w: invalid value %q of %s", ErrFilterInvalidSelector, v, req.Key)
+		}
+	}
+	return req, nil
+}
+
+// field returns the selection field of the key and the label name of related labels.
+func (r *Requirement) field() (string, string) {
+	for _, prefix := range selectorKeyPrefixes {
+		if strings.HasPrefix(r.Key, prefix) {
+			return strings.TrimSuffix(prefix, ":"), strings.TrimPrefix(r.Key, prefix)
+		}
+	}
+	return r.Key, ""
+}
+
+// validateSelector checks selector parses and only uses the selection fields of a list.
+func validateSelector(selector string, fields []string) error {
+	if selector == "" {
+		return nil
+	}
+	reqs, err := ParseSelector(selector)
+	if err != nil {
+		return err
+	}
+	for _, req := range reqs {
+		field, label := req.field()
+		if !containsString(fields, field) {
+			return fmt.Errorf("%w: unknown key %s", ErrFilterInvalidSelector, req.Key)
+		}
+		related := field == repo.SelectTag || field == repo.SelectFeature
+		if related && label == "" {
+			return fmt.Errorf("%w: %s needs a name, like %s:name", ErrFilterInvalidSelector, field, field)
+		}
+		if !related && (req.Operator == SelectorExists || req.Operator == SelectorNotExists) {
+			return fmt.Errorf("%w: %s needs a value", ErrFilterInvalidSelector, req.Key)
+		}
+	}
+	return nil
+}
+
+func containsString(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorResolver resolves the names of selector requirements to ids, repos of fields a list
+// can not select may be nil.
+type selectorResolver struct {
+	teamrepo repo.TeamsRepo
+	prdrepo  repo.ProductsRepo
+	clsrepo  repo.ClustersRepo
+	dcrepo   repo.DatacentersRepo
+	envrepo  repo.EnvsRepo
+	hgrepo   repo.HostgroupsRepo
+	tagrepo  repo.TagsRepo
+	ftrepo   repo.FeaturesRepo
+}
+
+// resolve returns the selections of a validated selector, teams, products and hostgroups are
+// looked up in orgIds. Names matching nothing select nothing.
+func (r *selectorResolver) resolve(ctx context.Context, selector string, orgIds []uint32) ([]repo.Selection, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	reqs, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	selections := make([]repo.Selection, 0, len(reqs))
+	for _, req := range reqs {
+		field, label := req.field()
+		ids, err := r.lookup(ctx, field, label, req.Values, orgIds)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, repo.Selection{
+			Field: field,
+			Ids:   ids,
+			Exclude: req.Operator == SelectorNotEquals || req.Operator == SelectorNotIn ||
+				req.Operator == SelectorNotExists,
+		})
+	}
+	return selections, nil
+}
+
+// lookup returns the ids of field named by values, or of label with one of values. A label
+// without values is looked up with any value.
+func (r *selectorResolver) lookup(ctx context.Context, field, label string, values []string,
+	orgIds []uint32) ([]uint32, error) {
+
+	var ids []uint32
+	switch field {
+	case repo.SelectTeam:
+		// teams are named by code or name
+		byCode, err := r.teamrepo.ListTeams(ctx, nil, &repo.TeamsFilter{OrgIds: orgIds, Codes: values})
+		if err != nil {
+			return nil, err
+		}
+		byName, err := r.teamrepo.ListTeams(ctx, nil, &repo.TeamsFilter{OrgIds: orgIds, Names: values})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range append(byCode, byName...) {
+			if containsString(values, t.Code) || containsString(values, t.Name) {
+				ids = append(ids, t.ID)
+			}
+		}
+	case repo.SelectProduct:
+		byCode, err := r.prdrepo.ListProducts(ctx, nil, &repo.ProductsFilter{OrgIds: orgIds, Codes: values})
+		if err != nil {
+			return nil, err
+		}
+		byName, err := r.prdrepo.ListProducts(ctx, nil, &repo.ProductsFilter{OrgIds: orgIds, Names: values})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range append(byCode, byName...) {
+			if containsString(values, p.Code) || containsString(values, p.Name) {
+				ids = append(ids, p.ID)
+			}
+		}
+	case repo.SelectCluster:
+		clusters, err := r.clsrepo.ListClusters(ctx, nil, &repo.ClustersFilter{Names: values})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range clusters {
+			if containsString(values, c.Name) {
+				ids = append(ids, c.ID)
+			}
+		}
+	case repo.SelectDatacenter:
+		dcs, err := r.dcrepo.ListDatacenters(ctx, nil, &repo.DatacentersFilter{Names: values})
+		if err != nil {
+			return nil, err
+		}
+		for _, dc := range dcs {
+			if containsString(values, dc.Name) {
+				ids = append(ids, dc.ID)
+			}
+		}
+	case repo.SelectEnv:
+		envs, err := r.envrepo.ListEnvs(ctx, nil, &repo.EnvsFilter{Names: values})
+		if err != nil {
+			return nil, err
+		}
+		for _, env := range envs {
+			if containsString(values, env.Name) {
+				ids = append(ids, env.ID)
+			}
+		}
+	case repo.SelectHostgroup:
+		hgs, err := r.hgrepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+			OrgIds: orgIds, Names: values, NameMatch: repo.NameMatchExact})
+		if err != nil {
+			return nil, err
+		}
+		for _, hg := range hgs {
+			ids = append(ids, hg.Id)
+		}
+	case repo.SelectTag:
+		tags, err := r.tagrepo.ListTags(ctx, nil, &repo.TagsFilter{Keys: []string{label}})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			if t.Key == label && (len(values) == 0 || containsString(values, t.Value)) {
+				ids = append(ids, t.ID)
+			}
+		}
+	case repo.SelectFeature:
+		features, err := r.ftrepo.ListFeatures(ctx, nil, &repo.FeaturesFilter{Names: []string{label}})
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range features {
+			if f.Name == label && (len(values) == 0 || containsString(values, f.Value)) {
+				ids = append(ids, f.Id)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown key %s", ErrFilterInvalidSelector, field)
+	}
+	return DedupSliceUint32(ids), nil
+}
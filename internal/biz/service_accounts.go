@@ -0,0 +1,414 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ApiKeyPrefix marks api keys, so they can be told apart from JWT in Bearer scheme.
+const ApiKeyPrefix = "opk_"
+
+// ServiceAccountSubjectPrefix is prepended to service account name as casbin subject,
+// so service accounts never collide with users.
+const ServiceAccountSubjectPrefix = "sa:"
+
+// apiKeyTouchInterval throttles writes of last used time.
+const apiKeyTouchInterval = time.Minute
+
+var ErrInvalidApiKey = PermissionDenied("invalid api key")
+
+type ServiceAccountsUsecase struct {
+	accountsRepo repo.ServiceAccountsRepo
+	apiKeysRepo  repo.ApiKeysRepo
+	teamsRepo    repo.TeamsRepo
+	orgRepo      repo.OrganizationsRepo
+	authzRepo    repo.AuthzRepo
+	txm          repo.TxManager
+	log          *log.Helper
+}
+
+func NewServiceAccountsUsecase(
+	accountsRepo repo.ServiceAccountsRepo,
+	apiKeysRepo repo.ApiKeysRepo,
+	teamsRepo repo.TeamsRepo,
+	orgRepo repo.OrganizationsRepo,
+	authzRepo repo.AuthzRepo,
+	txm repo.TxManager,
+	logger log.Logger,
+) *ServiceAccountsUsecase {
+	return &ServiceAccountsUsecase{
+		accountsRepo: accountsRepo,
+		apiKeysRepo:  apiKeysRepo,
+		teamsRepo:    teamsRepo,
+		orgRepo:      orgRepo,
+		authzRepo:    authzRepo,
+		txm:          txm,
+		log:          log.NewHelper(logger),
+	}
+}
+
+// newApiKey returns a random api key, its hash to store and a prefix to display.
+func newApiKey() (string, string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	key := ApiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, hashRefreshToken(key), key[:len(ApiKeyPrefix)+8], nil
+}
+
+func (s *ServiceAccountsUsecase) enforce(ctx context.Context, tx repo.TX, ires repo.IResource) error {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	can, err := s.authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: ires,
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return err
+	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// enforceTeam requires write permission on service accounts of team, the organization of
+// team is returned with it.
+func (s *ServiceAccountsUsecase) enforceTeam(ctx context.Context, tx repo.TX, teamId uint32) (*repo.Team, string, error) {
+	team, err := s.teamsRepo.GetTeams(ctx, teamId)
+	if err != nil {
+		return nil, "", errors.Join(NotFound("team not found"), err)
+	}
+	org, err := orgName(ctx, s.orgRepo, team.OrgId)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.enforce(ctx, tx, repo.NewOrgResource4Sv1(org, "serviceaccounts", team.Name, "", "")); err != nil {
+		return nil, "", err
+	}
+	return team, org, nil
+}
+
+func teamRule(sub, org, teamName string) *repo.Rule {
+	return &repo.Rule{
+		Sub:      sub,
+		Resource: repo.NewOrgResource4Sv1(org, "", teamName, "", ""),
+		Action:   repo.ActWrite,
+	}
+}
+
+// CreateServiceAccounts creates service accounts with write permission on their team,
+// binding a casbin role requires admin permission.
+func (s *ServiceAccountsUsecase) CreateServiceAccounts(ctx context.Context, accounts []*ServiceAccount) error {
+	if len(accounts) == 0 {
+		return errors.Join(errors.New("CreateServiceAccounts failed"), errors.New("no service account to create"))
+	}
+	for _, sa := range accounts {
+		if err := sa.Validate(); err != nil {
+			return errors.Join(errors.New("CreateServiceAccounts failed"), invalidArgument(err))
+		}
+	}
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return errors.Join(errors.New("CreateServiceAccounts failed"), err)
+	}
+
+	now := time.Now().Unix()
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		dbAccounts := make([]*repo.ServiceAccount, len(accounts))
+		teams := make([]*repo.Team, len(accounts))
+		orgs := make([]string, len(accounts))
+		for i, sa := range accounts {
+			team, org, err := s.enforceTeam(ctx, tx, sa.TeamId)
+			if err != nil {
+				return err
+			}
+			if sa.Role != "" {
+				if err := s.enforce(ctx, tx, repo.NewResource4Sv1("", "", "", "")); err != nil {
+					return errors.Join(errors.New("bind role requires admin"), err)
+				}
+			}
+			teams[i], orgs[i] = team, org
+			dbAccounts[i] = ToDBServiceAccount(sa)
+			dbAccounts[i].ID = 0
+			dbAccounts[i].CreatedBy = curUser
+			dbAccounts[i].CreatedAt = now
+		}
+		if err := s.accountsRepo.CreateServiceAccounts(ctx, tx, dbAccounts); err != nil {
+			return err
+		}
+		for i, sa := range dbAccounts {
+			sub := ServiceAccountSubject(sa.Name)
+			if err := s.authzRepo.CreateRule(ctx, tx, teamRule(sub, orgs[i], teams[i].Name)); err != nil {
+				return err
+			}
+			if sa.Role != "" {
+				if err := s.authzRepo.CreateGroup(ctx, tx, &repo.Group{User: sub, Role: sa.Role}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("CreateServiceAccounts failed"), err)
+	}
+	return nil
+}
+
+// DeleteServiceAccounts deletes service accounts with their api keys and permissions.
+func (s *ServiceAccountsUsecase) DeleteServiceAccounts(ctx context.Context, ids []uint32) error {
+	if len(ids) == 0 {
+		return errors.Join(errors.New("DeleteServiceAccounts failed"), ErrEmptyIds)
+	}
+	ids = DedupSliceUint32(ids)
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		for _, id := range ids {
+			sa, err := s.accountsRepo.GetServiceAccounts(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			team, org, err := s.enforceTeam(ctx, tx, sa.TeamId)
+			if err != nil {
+				return err
+			}
+			sub := ServiceAccountSubject(sa.Name)
+			if err := s.authzRepo.DeleteRule(ctx, tx, teamRule(sub, org, team.Name)); err != nil {
+				return err
+			}
+			if sa.Role != "" {
+				if err := s.authzRepo.DeleteGroup(ctx, tx, &repo.Group{User: sub, Role: sa.Role}); err != nil {
+					return err
+				}
+			}
+		}
+		if err := s.apiKeysRepo.DeleteServiceAccountApiKeys(ctx, tx, ids); err != nil {
+			return err
+		}
+		return s.accountsRepo.DeleteServiceAccounts(ctx, tx, ids)
+	})
+	if err != nil {
+		return errors.Join(errors.New("DeleteServiceAccounts failed"), err)
+	}
+	return nil
+}
+
+// ListServiceAccounts is
+func (s *ServiceAccountsUsecase) ListServiceAccounts(ctx context.Context,
+	filter *ListServiceAccountsFilter) ([]*ServiceAccount, *PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, errors.Join(errors.New("ListServiceAccounts failed"), invalidArgument(err))
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListServiceAccountsFilter{}
+	}
+	dbFilter := ToDBServiceAccountsFilter(filter)
+	accounts, err := s.accountsRepo.ListServiceAccounts(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListServiceAccounts failed"), err)
+	}
+	// totals count accounts readable by the caller only
+	page, err := listPage(accounts, dbFilter.PageSize, func(sa *repo.ServiceAccount) uint32 { return sa.ID },
+		filter.WithTotal, func() ([]*repo.ServiceAccount, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			rows, err := s.accountsRepo.ListServiceAccounts(ctx, nil, &all)
+			if err != nil {
+				return nil, err
+			}
+			return s.filterReadable(ctx, rows)
+		})
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListServiceAccounts failed"), err)
+	}
+	accounts, err = s.filterReadable(ctx, accounts)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListServiceAccounts failed"), err)
+	}
+	return ToBizServiceAccounts(accounts), page, nil
+}
+
+// CreateApiKey creates an api key of service account. the plain key is only returned here.
+func (s *ServiceAccountsUsecase) CreateApiKey(ctx context.Context, apiKey *ApiKey) (*ApiKey, error) {
+	if apiKey == nil {
+		return nil, errors.Join(errors.New("CreateApiKey failed"), errors.New("api key is nil"))
+	}
+	if err := apiKey.Validate(); err != nil {
+		return nil, errors.Join(errors.New("CreateApiKey failed"), invalidArgument(err))
+	}
+	key, hash, prefix, err := newApiKey()
+	if err != nil {
+		return nil, errors.Join(errors.New("CreateApiKey failed"), err)
+	}
+	now := time.Now()
+	dbKey := &repo.ApiKey{
+		ServiceAccountId: apiKey.ServiceAccountId,
+		Name:             apiKey.Name,
+		Prefix:           prefix,
+		Hash:             hash,
+		Scopes:           strings.Join(apiKey.Scopes, ","),
+		Description:      apiKey.Description,
+		CreatedAt:        now.Unix(),
+	}
+	if apiKey.ExpireDays > 0 {
+		dbKey.ExpiresAt = now.Add(time.Duration(apiKey.ExpireDays) * 24 * time.Hour).Unix()
+	}
+
+	err = s.txm.RunInTX(func(tx repo.TX) error {
+		sa, err := s.accountsRepo.GetServiceAccounts(ctx, tx, apiKey.ServiceAccountId)
+		if err != nil {
+			return err
+		}
+		if _, _, err := s.enforceTeam(ctx, tx, sa.TeamId); err != nil {
+			return err
+		}
+		return s.apiKeysRepo.CreateApiKeys(ctx, tx, []*repo.ApiKey{dbKey})
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("CreateApiKey failed"), err)
+	}
+	created := ToBizApiKey(dbKey)
+	created.Key = key
+	return created, nil
+}
+
+// ListApiKeys lists api keys of service accounts, without the keys.
+func (s *ServiceAccountsUsecase) ListApiKeys(ctx context.Context, serviceAccountIds []uint32) ([]*ApiKey, error) {
+	if len(serviceAccountIds) > MaxFilterValues {
+		return nil, errors.Join(errors.New("ListApiKeys failed"), ErrFilterValuesExceedMax)
+	}
+	keys, err := s.apiKeysRepo.ListApiKeys(ctx, nil, &repo.ApiKeysFilter{
+		ServiceAccountIds: serviceAccountIds,
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("ListApiKeys failed"), err)
+	}
+	if len(keys) == 0 {
+		return ToBizApiKeys(keys), nil
+	}
+	// keys are visible with their service accounts
+	saIds := make([]uint32, 0, len(keys))
+	for _, k := range keys {
+		saIds = append(saIds, k.ServiceAccountId)
+	}
+	accounts, err := s.accountsRepo.ListServiceAccounts(ctx, nil, &repo.ServiceAccountsFilter{Ids: DedupSliceUint32(saIds)})
+	if err != nil {
+		return nil, errors.Join(errors.New("ListApiKeys failed"), err)
+	}
+	accounts, err = s.filterReadable(ctx, accounts)
+	if err != nil {
+		return nil, errors.Join(errors.New("ListApiKeys failed"), err)
+	}
+	readable := make(map[uint32]bool, len(accounts))
+	for _, sa := range accounts {
+		readable[sa.ID] = true
+	}
+	visible := make([]*repo.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		if readable[k.ServiceAccountId] {
+			visible = append(visible, k)
+		}
+	}
+	return ToBizApiKeys(visible), nil
+}
+
+// filterReadable keeps service accounts readable by the current user, they are visible to their team.
+func (s *ServiceAccountsUsecase) filterReadable(ctx context.Context,
+	accounts []*repo.ServiceAccount) ([]*repo.ServiceAccount, error) {
+	names := newTeamResourceNames(s.teamsRepo, nil, s.orgRepo)
+	return filterReadable(ctx, s.authzRepo, nil, accounts, func(sa *repo.ServiceAccount) ([]repo.IResource, error) {
+		team, err := names.team(ctx, sa.TeamId)
+		if err != nil {
+			return nil, err
+		}
+		res, err := names.resource(ctx, "serviceaccounts", team, sa.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		return []repo.IResource{res}, nil
+	})
+}
+
+// RevokeApiKeys deletes api keys.
+func (s *ServiceAccountsUsecase) RevokeApiKeys(ctx context.Context, ids []uint32) error {
+	if len(ids) == 0 {
+		return errors.Join(errors.New("RevokeApiKeys failed"), ErrEmptyIds)
+	}
+	ids = DedupSliceUint32(ids)
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		keys, err := s.apiKeysRepo.ListApiKeys(ctx, tx, &repo.ApiKeysFilter{Ids: ids})
+		if err != nil {
+			return err
+		}
+		if len(keys) != len(ids) {
+			return errors.New("some api keys not found")
+		}
+		enforced := map[uint32]bool{}
+		for _, k := range keys {
+			if enforced[k.ServiceAccountId] {
+				continue
+			}
+			sa, err := s.accountsRepo.GetServiceAccounts(ctx, tx, k.ServiceAccountId)
+			if err != nil {
+				return err
+			}
+			if _, _, err := s.enforceTeam(ctx, tx, sa.TeamId); err != nil {
+				return err
+			}
+			enforced[k.ServiceAccountId] = true
+		}
+		return s.apiKeysRepo.DeleteApiKeys(ctx, tx, ids)
+	})
+	if err != nil {
+		return errors.Join(errors.New("RevokeApiKeys failed"), err)
+	}
+	return nil
+}
+
+// AuthenticateApiKey checks api key against expiry and scopes of the operation,
+// and returns the casbin subject of its service account.
+func (s *ServiceAccountsUsecase) AuthenticateApiKey(ctx context.Context, key, operation string) (string, error) {
+	if !strings.HasPrefix(key, ApiKeyPrefix) {
+		return "", ErrInvalidApiKey
+	}
+	keys, err := s.apiKeysRepo.ListApiKeys(ctx, nil, &repo.ApiKeysFilter{
+		Hashes: []string{hashRefreshToken(key)},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(keys) != 1 {
+		return "", ErrInvalidApiKey
+	}
+	k := keys[0]
+	now := time.Now()
+	if k.ExpiresAt > 0 && now.Unix() >= k.ExpiresAt {
+		return "", repo.ErrExpiredApiKey
+	}
+	if !ScopeAllows(ToBizApiKey(k).Scopes, operation) {
+		return "", repo.ErrApiKeyScope
+	}
+	sa, err := s.accountsRepo.GetServiceAccounts(ctx, nil, k.ServiceAccountId)
+	if err != nil {
+		return "", errors.Join(ErrInvalidApiKey, err)
+	}
+	if now.Sub(time.Unix(k.LastUsedAt, 0)) >= apiKeyTouchInterval {
+		if err := s.apiKeysRepo.TouchApiKey(ctx, k.ID, now.Unix()); err != nil {
+			s.log.Warnf("touch api key %d failed: %v", k.ID, err)
+		}
+	}
+	return ServiceAccountSubject(sa.Name), nil
+}
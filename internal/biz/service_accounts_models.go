@@ -0,0 +1,40 @@
+package biz
+
+type ServiceAccount struct {
+	Id          uint32
+	Name        string
+	TeamId      uint32
+	Role        string
+	Description string
+	CreatedBy   string
+	CreatedAt   int64
+}
+
+type ListServiceAccountsFilter struct {
+	Page     uint32
+	PageSize uint32
+	Ids      []uint32
+	Names    []string
+	TeamIds  []uint32
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
+
+type ApiKey struct {
+	Id               uint32
+	ServiceAccountId uint32
+	Name             string
+	// Key is the plain api key, only returned on creation
+	Key         string
+	Prefix      string
+	Scopes      []string
+	Description string
+	// ExpireDays is used on creation, 0 never expires
+	ExpireDays uint32
+	ExpiresAt  int64
+	LastUsedAt int64
+	CreatedAt  int64
+}
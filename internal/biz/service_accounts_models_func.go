@@ -0,0 +1,181 @@
+package biz
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"opspillar/internal/data/repo"
+)
+
+// ScopePattern is `<resource>[:read|write]`, resource is the lowercased api service name or `*`.
+var ScopePattern = regexp.MustCompile(`^(\*|[a-z]+)(:(read|write))?$`)
+
+const MaxScopes = 20
+
+func (sa *ServiceAccount) Validate() error {
+	if sa.TeamId == 0 {
+		return errors.New("invalid team id")
+	}
+	if e := ValidateName(sa.Name); e != nil {
+		return invalidField("name", e)
+	}
+	if sa.Role != "" {
+		if e := ValidateName(sa.Role); e != nil {
+			return invalidField("role", errors.Join(errors.New("invalid role"), e))
+		}
+	}
+	return nil
+}
+
+func (k *ApiKey) Validate() error {
+	if k.ServiceAccountId == 0 {
+		return errors.New("invalid service account id")
+	}
+	if e := ValidateName(k.Name); e != nil {
+		return invalidField("name", e)
+	}
+	if len(k.Scopes) == 0 {
+		return errors.New("empty scopes")
+	}
+	if len(k.Scopes) > MaxScopes {
+		return errors.New("too many scopes")
+	}
+	for _, scope := range k.Scopes {
+		if !ScopePattern.MatchString(scope) {
+			return errors.New("invalid scope " + scope)
+		}
+	}
+	return nil
+}
+
+func (lf *ListServiceAccountsFilter) Validate() error {
+	if lf == nil {
+		return nil
+	}
+	if len(lf.Ids) > MaxFilterValues ||
+		len(lf.Names) > MaxFilterValues ||
+		len(lf.TeamIds) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
+		return ErrFilterInvalidPagesize
+	}
+	if lf.Page == 0 {
+		return ErrFilterInvalidPage
+	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefaultServiceAccountsFilter() *ListServiceAccountsFilter {
+	return &ListServiceAccountsFilter{
+		Page:     1,
+		PageSize: DefaultPageSize,
+	}
+}
+
+// ScopeAllows checks if scopes allow the api operation, eg: /api.opspillar.v1.Hostgroups/ListHostgroups
+func ScopeAllows(scopes []string, operation string) bool {
+	// operation: /<package>.<Service>/<Method>
+	parts := strings.Split(strings.TrimPrefix(operation, "/"), "/")
+	if len(parts) != 2 {
+		return false
+	}
+	service := strings.ToLower(parts[0][strings.LastIndex(parts[0], ".")+1:])
+	method := parts[1]
+	read := strings.HasPrefix(method, "Get") ||
+		strings.HasPrefix(method, "List") ||
+		strings.HasPrefix(method, "Match") ||
+		strings.HasPrefix(method, "Count")
+
+	for _, scope := range scopes {
+		res, act, _ := strings.Cut(scope, ":")
+		if res != "*" && res != service {
+			continue
+		}
+		if act == "read" && !read {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func ServiceAccountSubject(name string) string {
+	return ServiceAccountSubjectPrefix + name
+}
+
+func ToDBServiceAccount(sa *ServiceAccount) *repo.ServiceAccount {
+	return &repo.ServiceAccount{
+		ID:          sa.Id,
+		Name:        sa.Name,
+		TeamId:      sa.TeamId,
+		Role:        sa.Role,
+		Description: sa.Description,
+		CreatedBy:   sa.CreatedBy,
+		CreatedAt:   sa.CreatedAt,
+	}
+}
+
+func ToBizServiceAccount(sa *repo.ServiceAccount) *ServiceAccount {
+	return &ServiceAccount{
+		Id:          sa.ID,
+		Name:        sa.Name,
+		TeamId:      sa.TeamId,
+		Role:        sa.Role,
+		Description: sa.Description,
+		CreatedBy:   sa.CreatedBy,
+		CreatedAt:   sa.CreatedAt,
+	}
+}
+
+func ToBizServiceAccounts(accounts []*repo.ServiceAccount) []*ServiceAccount {
+	var biz_accounts = make([]*ServiceAccount, len(accounts))
+	for i, sa := range accounts {
+		biz_accounts[i] = ToBizServiceAccount(sa)
+	}
+	return biz_accounts
+}
+
+func ToDBServiceAccountsFilter(filter *ListServiceAccountsFilter) *repo.ServiceAccountsFilter {
+	if filter == nil {
+		return nil
+	}
+	return &repo.ServiceAccountsFilter{
+		Ids:      filter.Ids,
+		Names:    filter.Names,
+		TeamIds:  filter.TeamIds,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
+	}
+}
+
+func ToBizApiKey(k *repo.ApiKey) *ApiKey {
+	var scopes []string
+	if k.Scopes != "" {
+		scopes = strings.Split(k.Scopes, ",")
+	}
+	return &ApiKey{
+		Id:               k.ID,
+		ServiceAccountId: k.ServiceAccountId,
+		Name:             k.Name,
+		Prefix:           k.Prefix,
+		Scopes:           scopes,
+		Description:      k.Description,
+		ExpiresAt:        k.ExpiresAt,
+		LastUsedAt:       k.LastUsedAt,
+		CreatedAt:        k.CreatedAt,
+	}
+}
+
+func ToBizApiKeys(keys []*repo.ApiKey) []*ApiKey {
+	var biz_keys = make([]*ApiKey, len(keys))
+	for i, k := range keys {
+		biz_keys[i] = ToBizApiKey(k)
+	}
+	return biz_keys
+}
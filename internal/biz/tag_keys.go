@@ -0,0 +1,231 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type TagKeysUsecase struct {
+	tagKeyRepo repo.TagKeysRepo
+	authzRepo  repo.AuthzRepo
+	log        *log.Helper
+	txm        repo.TxManager
+}
+
+func NewTagKeysUsecase(
+	tagkeyrepo repo.TagKeysRepo,
+	authzrepo repo.AuthzRepo,
+	logger log.Logger,
+	txm repo.TxManager) *TagKeysUsecase {
+	return &TagKeysUsecase{
+		tagKeyRepo: tagkeyrepo,
+		authzRepo:  authzrepo,
+		log:        log.NewHelper(logger),
+		txm:        txm,
+	}
+}
+
+// validateEntityTags checks tags by id of an entity of kind against registered tag keys.
+func validateEntityTags(ctx context.Context, tagRepo repo.TagsRepo, tx repo.TX,
+	keys []*TagKey, kind string, tagIds []uint32) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	var tags []*Tag
+	if len(tagIds) > 0 {
+		_tags, err := tagRepo.ListTags(ctx, tx, &repo.TagsFilter{Ids: tagIds})
+		if err != nil {
+			return err
+		}
+		if tags, err = ToBizTags(_tags); err != nil {
+			return err
+		}
+	}
+	return ValidateEntityTags(keys, kind, tags)
+}
+
+// registeredTagKeys lists all registered tag keys.
+func registeredTagKeys(ctx context.Context, tagKeyRepo repo.TagKeysRepo, tx repo.TX) ([]*TagKey, error) {
+	keys, err := tagKeyRepo.ListTagKeys(ctx, tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ToBizTagKeys(keys), nil
+}
+
+// validateTagValues checks values of tags of registered keys.
+func validateTagValues(ctx context.Context, tagKeyRepo repo.TagKeysRepo, tx repo.TX, tags []*Tag) error {
+	keys := make([]string, 0, len(tags))
+	for _, t := range tags {
+		keys = append(keys, t.Key)
+	}
+	_keys, err := tagKeyRepo.ListTagKeys(ctx, tx, &repo.TagKeysFilter{Keys: keys})
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*TagKey, len(_keys))
+	for _, k := range ToBizTagKeys(_keys) {
+		byKey[k.Key] = k
+	}
+	for _, t := range tags {
+		if k, ok := byKey[t.Key]; ok {
+			if err := k.ValidateValue(t.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *TagKeysUsecase) enforce(ctx context.Context, tx repo.TX) error {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	can, err := s.authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: repo.NewResource4Sv1("tagkeys", "", "", ""),
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return err
+	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *TagKeysUsecase) validate(isNew bool, keys []*TagKey) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("EmptyTagKeys")
+	}
+	for _, k := range keys {
+		if k == nil {
+			return fmt.Errorf("tag key is nil")
+		}
+		if err := k.Validate(isNew); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTagKeys registers tag keys, admin only. existing entities are checked on their next change.
+func (s *TagKeysUsecase) CreateTagKeys(ctx context.Context, keys []*TagKey) error {
+	if err := s.validate(true, keys); err != nil {
+		return errors.Join(errors.New("CreateTagKeys failed"), invalidArgument(err))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			dbkey := ToDBTagKey(k)
+			if err := s.tagKeyRepo.CreateTagKeys(ctx, tx, []*repo.TagKey{dbkey}); err != nil {
+				return err
+			}
+			k.Id = dbkey.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("CreateTagKeys failed"), err)
+	}
+	return nil
+}
+
+// UpdateTagKeys changes tag keys, admin only. tags refer to keys by name, so it can not be changed.
+func (s *TagKeysUsecase) UpdateTagKeys(ctx context.Context, keys []*TagKey) error {
+	if err := s.validate(false, keys); err != nil {
+		return errors.Join(errors.New("UpdateTagKeys failed"), invalidArgument(err))
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			old, err := s.tagKeyRepo.GetTagKeys(ctx, k.Id)
+			if err != nil {
+				return err
+			}
+			if old.Key != k.Key {
+				return InvalidArgument("tag key %s can not be changed", old.Key).WithField("key", "can not be changed")
+			}
+			if err := conflicting(s.tagKeyRepo.UpdateTagKeys(ctx, tx, []*repo.TagKey{ToDBTagKey(k)})); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Join(errors.New("UpdateTagKeys failed"), err)
+	}
+	return nil
+}
+
+// DeleteTagKeys unregisters tag keys, admin only. tags of the keys are kept ungoverned.
+func (s *TagKeysUsecase) DeleteTagKeys(ctx context.Context, ids []uint32) error {
+	if len(ids) == 0 {
+		return errors.Join(errors.New("DeleteTagKeys failed"), ErrEmptyIds)
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		if err := s.enforce(ctx, tx); err != nil {
+			return err
+		}
+		return s.tagKeyRepo.DeleteTagKeys(ctx, tx, ids)
+	})
+	if err != nil {
+		return errors.Join(errors.New("DeleteTagKeys failed"), err)
+	}
+	return nil
+}
+
+// GetTagKeys is
+func (s *TagKeysUsecase) GetTagKeys(ctx context.Context, id uint32) (*TagKey, error) {
+	if id <= 0 {
+		return nil, errors.Join(errors.New("GetTagKeys failed"), ErrInvalidId)
+	}
+	if err := enforceRead(ctx, s.authzRepo, nil, repo.NewResource4Sv1("tagkeys", "", "", "")); err != nil {
+		return nil, errors.Join(errors.New("GetTagKeys failed"), err)
+	}
+	key, err := s.tagKeyRepo.GetTagKeys(ctx, id)
+	if err != nil {
+		return nil, errors.Join(errors.New("GetTagKeys failed"), err)
+	}
+	return ToBizTagKey(key), nil
+}
+
+// ListTagKeys is
+func (s *TagKeysUsecase) ListTagKeys(ctx context.Context, filter *ListTagKeysFilter) ([]*TagKey, *PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, errors.Join(errors.New("ListTagKeys failed"), invalidArgument(err))
+	}
+	if err := enforceRead(ctx, s.authzRepo, nil, repo.NewResource4Sv1("tagkeys", "", "", "")); err != nil {
+		return nil, nil, errors.Join(errors.New("ListTagKeys failed"), err)
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListTagKeysFilter{}
+	}
+	dbFilter := ToDBTagKeysFilter(filter)
+	keys, err := s.tagKeyRepo.ListTagKeys(ctx, nil, dbFilter)
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListTagKeys failed"), err)
+	}
+	page, err := listPage(keys, dbFilter.PageSize, func(r *repo.TagKey) uint32 { return r.ID },
+		filter.WithTotal, func() ([]*repo.TagKey, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.tagKeyRepo.ListTagKeys(ctx, nil, &all)
+		})
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("ListTagKeys failed"), err)
+	}
+	return ToBizTagKeys(keys), page, nil
+}
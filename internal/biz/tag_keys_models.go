@@ -0,0 +1,29 @@
+package biz
+
+// TagKey governs tags of a key, their values and the entity kinds using them. tags of
+// unregistered keys are not governed.
+type TagKey struct {
+	Id          uint32
+	Key         string
+	Description string
+	// AllowedValues are the values tags of the key may have, any if empty
+	AllowedValues []string
+	// Pattern is a regular expression the whole value must match
+	Pattern string
+	// Kinds are entity kinds tags of the key may be used on, all if empty
+	Kinds []string
+	// Required tags of the key must be on entities of kinds
+	Required bool
+}
+
+type ListTagKeysFilter struct {
+	Page     uint32
+	PageSize uint32
+	Ids      []uint32
+	Keys     []string
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
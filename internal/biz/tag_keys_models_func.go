@@ -0,0 +1,169 @@
+package biz
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"opspillar/internal/data/repo"
+)
+
+const tagKeyListSplit = ","
+
+func (k *TagKey) Validate(isNew bool) error {
+	if !isNew && k.Id == 0 {
+		return ErrInvalidId
+	}
+	if e := ValidateName(k.Key); e != nil {
+		return invalidField("key", e)
+	}
+	for _, v := range k.AllowedValues {
+		if strings.Contains(v, tagKeyListSplit) {
+			return fmt.Errorf("invalid allowed value %q of tag key %s", v, k.Key)
+		}
+		if e := ValidateCode(v); e != nil {
+			return invalidField("allowed_values", e)
+		}
+	}
+	if k.Pattern != "" {
+		if _, err := regexp.Compile(k.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern of tag key %s. %w", k.Key, err)
+		}
+	}
+	for _, kind := range k.Kinds {
+		if !slices.Contains(AttributeKinds, kind) {
+			return fmt.Errorf("invalid kind %q, one of %s", kind, strings.Join(AttributeKinds, ","))
+		}
+	}
+	return nil
+}
+
+// AppliesTo reports whether tags of the key may be used on entities of kind.
+func (k *TagKey) AppliesTo(kind string) bool {
+	return len(k.Kinds) == 0 || slices.Contains(k.Kinds, kind)
+}
+
+// ValidateValue checks a value of tags of the key.
+func (k *TagKey) ValidateValue(value string) error {
+	if len(k.AllowedValues) > 0 && !slices.Contains(k.AllowedValues, value) {
+		return fmt.Errorf("value %q of tag %s not in %s", value, k.Key, strings.Join(k.AllowedValues, ","))
+	}
+	if k.Pattern != "" {
+		re, err := regexp.Compile(`^(?:` + k.Pattern + `)$`)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("value %q of tag %s not match %s", value, k.Key, k.Pattern)
+		}
+	}
+	return nil
+}
+
+// ValidateEntityTags checks tags of an entity of kind against registered keys, tags must
+// be allowed on the kind and required keys applying to the kind must be present.
+func ValidateEntityTags(keys []*TagKey, kind string, tags []*Tag) error {
+	byKey := make(map[string]*TagKey, len(keys))
+	for _, k := range keys {
+		byKey[k.Key] = k
+	}
+	present := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		present[t.Key] = true
+		k, ok := byKey[t.Key]
+		if !ok {
+			continue
+		}
+		if !k.AppliesTo(kind) {
+			return fmt.Errorf("tag %s is not allowed on %s", t.Key, kind)
+		}
+		if err := k.ValidateValue(t.Value); err != nil {
+			return err
+		}
+	}
+	for _, k := range keys {
+		if k.Required && k.AppliesTo(kind) && !present[k.Key] {
+			return fmt.Errorf("tag %s is required", k.Key)
+		}
+	}
+	return nil
+}
+
+func (lf *ListTagKeysFilter) Validate() error {
+	if lf == nil {
+		return nil
+	}
+	if len(lf.Ids) > MaxFilterValues || len(lf.Keys) > MaxFilterValues {
+		return ErrFilterValuesExceedMax
+	}
+	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
+		return ErrFilterInvalidPagesize
+	}
+	if lf.Page == 0 {
+		return ErrFilterInvalidPage
+	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefaultTagKeysFilter() *ListTagKeysFilter {
+	return &ListTagKeysFilter{
+		Page:     1,
+		PageSize: DefaultPageSize,
+	}
+}
+
+func splitTagKeyList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, tagKeyListSplit)
+}
+
+func ToDBTagKey(k *TagKey) *repo.TagKey {
+	return &repo.TagKey{
+		ID:            k.Id,
+		Key:           k.Key,
+		Description:   k.Description,
+		AllowedValues: strings.Join(k.AllowedValues, tagKeyListSplit),
+		Pattern:       k.Pattern,
+		Kinds:         strings.Join(k.Kinds, tagKeyListSplit),
+		Required:      k.Required,
+	}
+}
+
+func ToBizTagKey(k *repo.TagKey) *TagKey {
+	return &TagKey{
+		Id:            k.ID,
+		Key:           k.Key,
+		Description:   k.Description,
+		AllowedValues: splitTagKeyList(k.AllowedValues),
+		Pattern:       k.Pattern,
+		Kinds:         splitTagKeyList(k.Kinds),
+		Required:      k.Required,
+	}
+}
+
+func ToBizTagKeys(keys []*repo.TagKey) []*TagKey {
+	res := make([]*TagKey, len(keys))
+	for i, k := range keys {
+		res[i] = ToBizTagKey(k)
+	}
+	return res
+}
+
+func ToDBTagKeysFilter(filter *ListTagKeysFilter) *repo.TagKeysFilter {
+	if filter == nil {
+		return nil
+	}
+	return &repo.TagKeysFilter{
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
+		Ids:      filter.Ids,
+		Keys:     filter.Keys,
+	}
+}
@@ -4,13 +4,13 @@ import (
 	"opspillar/internal/data"
 	"opspillar/internal/data/repo"
 	"context"
-	"fmt"
 
 	"github.com/go-kratos/kratos/v2/log"
 )
 
 type TagsUsecase struct {
 	tagsrepo  repo.TagsRepo
+	tkrepo    repo.TagKeysRepo
 	authzrepo repo.AuthzRepo
 	txm       repo.TxManager
 	log       *log.Helper
@@ -22,10 +22,12 @@ func NewTagsUsecase(repo repo.TagsRepo,
 	logger log.Logger,
 	apptagrepo repo.AppTagsRepo,
 	hgtagrepo repo.HostgroupTagsRepo,
+	tkrepo repo.TagKeysRepo,
 	txm repo.TxManager) *TagsUsecase {
 
 	return &TagsUsecase{
 		tagsrepo:  repo,
+		tkrepo:    tkrepo,
 		authzrepo: authzrepo,
 		log:       log.NewHelper(logger),
 		txm:       txm,
@@ -57,7 +59,7 @@ func (s *TagsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 }
@@ -66,7 +68,7 @@ func (s *TagsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 func (s *TagsUsecase) CreateTags(ctx context.Context, tags []*Tag) error {
 	// validate tags
 	if err := s.validate(true, tags); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 
 	_tags, e := ToDBTags(tags)
@@ -79,9 +81,16 @@ func (s *TagsUsecase) CreateTags(ctx context.Context, tags []*Tag) error {
 			if err := s.enforce(ctx, tx); err != nil {
 				return err
 			}
+			// values of registered keys are governed
+			if err := validateTagValues(ctx, s.tkrepo, tx, tags); err != nil {
+				return invalidField("value", err)
+			}
 			if e := s.tagsrepo.CreateTags(ctx, tx, _tags); e != nil {
 				return e
 			}
+			for i, t := range _tags {
+				tags[i].Id = t.ID
+			}
 			return nil
 		})
 	return err
@@ -91,7 +100,7 @@ func (s *TagsUsecase) CreateTags(ctx context.Context, tags []*Tag) error {
 func (s *TagsUsecase) UpdateTags(ctx context.Context, tags []*Tag) error {
 
 	if err := s.validate(false, tags); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_tags, e := ToDBTags(tags)
 	if e != nil {
@@ -103,7 +112,11 @@ func (s *TagsUsecase) UpdateTags(ctx context.Context, tags []*Tag) error {
 			if err := s.enforce(ctx, tx); err != nil {
 				return err
 			}
-			if e := s.tagsrepo.UpdateTags(ctx, tx, _tags); e != nil {
+			// values of registered keys are governed
+			if err := validateTagValues(ctx, s.tkrepo, tx, tags); err != nil {
+				return invalidField("value", err)
+			}
+			if e := conflicting(s.tagsrepo.UpdateTags(ctx, tx, _tags)); e != nil {
 				return e
 			}
 			return nil
@@ -115,7 +128,7 @@ func (s *TagsUsecase) UpdateTags(ctx context.Context, tags []*Tag) error {
 func (s *TagsUsecase) DeleteTags(ctx context.Context, ids []uint32) error {
 
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 	return s.txm.RunInTX(func(tx repo.TX) error {
 		if err := s.enforce(ctx, tx); err != nil {
@@ -127,7 +140,7 @@ func (s *TagsUsecase) DeleteTags(ctx context.Context, ids []uint32) error {
 				return err
 			}
 			if c > 0 {
-				return fmt.Errorf("some %s requires", r.name)
+				return FailedPrecondition("some %s requires", r.name)
 			}
 		}
 		if e := s.tagsrepo.DeleteTags(ctx, tx, ids); e != nil {
@@ -140,7 +153,10 @@ func (s *TagsUsecase) DeleteTags(ctx context.Context, ids []uint32) error {
 // GetTags is
 func (s *TagsUsecase) GetTags(ctx context.Context, id uint32) (*Tag, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("EmptyId")
+		return nil, ErrEmptyId
+	}
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("tags", "", "", "")); err != nil {
+		return nil, err
 	}
 	t, e := s.tagsrepo.GetTags(ctx, id)
 	if e != nil {
@@ -151,15 +167,33 @@ func (s *TagsUsecase) GetTags(ctx context.Context, id uint32) (*Tag, error) {
 
 // ListTags is
 func (s *TagsUsecase) ListTags(ctx context.Context,
-	filter *ListTagsFilter) ([]*Tag, error) {
+	filter *ListTagsFilter) ([]*Tag, *PageInfo, error) {
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
 		}
 	}
-	_ts, e := s.tagsrepo.ListTags(ctx, nil, ToDBTagsFilter(filter))
+	if err := enforceRead(ctx, s.authzrepo, nil, repo.NewResource4Sv1("tags", "", "", "")); err != nil {
+		return nil, nil, err
+	}
+	if filter == nil {
+		// lists all
+		filter = &ListTagsFilter{}
+	}
+	dbFilter := ToDBTagsFilter(filter)
+	_ts, e := s.tagsrepo.ListTags(ctx, nil, dbFilter)
 	if e != nil {
-		return nil, e
+		return nil, nil, e
+	}
+	page, e := listPage(_ts, dbFilter.PageSize, func(r *repo.Tag) uint32 { return r.ID },
+		filter.WithTotal, func() ([]*repo.Tag, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			return s.tagsrepo.ListTags(ctx, nil, &all)
+		})
+	if e != nil {
+		return nil, nil, e
 	}
-	return ToBizTags(_ts)
+	res, e := ToBizTags(_ts)
+	return res, page, e
 }
@@ -13,4 +13,9 @@ type ListTagsFilter struct {
 	Keys     []string
 	Kvs      []string
 	Ids      []uint32
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
 }
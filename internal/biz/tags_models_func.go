@@ -11,14 +11,14 @@ func (t *Tag) Validate(isNew bool) error {
 	}
 	if !isNew {
 		if t.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 	if e := ValidateName(t.Key); e != nil {
-		return e
+		return invalidField("key", e)
 	}
 	if e := ValidateCode(t.Value); e != nil {
-		return e
+		return invalidField("value", e)
 	}
 	return nil
 }
@@ -27,7 +27,7 @@ func (lf *ListTagsFilter) Validate() error {
 	if lf == nil {
 		return nil
 	}
-	if len(lf.Ids) > MaxFilterValues || len(lf.Keys) > MaxFilterValues || len(lf.Kvs) > MaxFilterValues {
+	if len(lf.Ids) > MaxFilterValues || len(lf.Keys) > MaxFilterPatterns || len(lf.Kvs) > MaxFilterValues {
 		return ErrFilterValuesExceedMax
 	}
 	for _, kv := range lf.Kvs {
@@ -41,6 +41,9 @@ func (lf *ListTagsFilter) Validate() error {
 	if lf.Page == 0 {
 		return ErrFilterInvalidPage
 	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -102,5 +105,6 @@ func ToDBTagsFilter(filter *ListTagsFilter) *repo.TagsFilter {
 		Kvs:      filter.Kvs,
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		LastId:   pageTokenId(filter.PageToken),
 	}
 }
@@ -0,0 +1,356 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"opspillar/internal/data/repo"
+)
+
+var teamRoles = []string{repo.TeamRoleLeader, repo.TeamRoleMaintainer, repo.TeamRoleMember}
+
+// teamMembership writes team_members rows with the casbin groups of their roles, shared by
+// the member API and the group sync of sso and directory users.
+type teamMembership struct {
+	authzrepo  repo.AuthzRepo
+	memberRepo repo.TeamMembersRepo
+}
+
+// ensureTeamRoles creates casbin roles of team, leader inherits maintainer and maintainer
+// inherits member. maintainers can write all resources of the team, members can read them.
+func (s *teamMembership) ensureTeamRoles(ctx context.Context, tx repo.TX, org, teamName string) error {
+	subject := TeamSubject(org, teamName)
+	leader := TeamRoleSubject(subject, repo.TeamRoleLeader)
+	maintainer := TeamRoleSubject(subject, repo.TeamRoleMaintainer)
+	member := TeamRoleSubject(subject, repo.TeamRoleMember)
+	if err := s.authzrepo.CreateGroup(ctx, tx, &repo.Group{User: leader, Role: maintainer}); err != nil {
+		return err
+	}
+	if err := s.authzrepo.CreateGroup(ctx, tx, &repo.Group{User: maintainer, Role: member}); err != nil {
+		return err
+	}
+	if err := s.authzrepo.CreateRule(ctx, tx, &repo.Rule{
+		Sub:      maintainer,
+		Resource: repo.NewOrgResource4Sv1(org, "", teamName, "", ""),
+		Action:   repo.ActWrite,
+	}); err != nil {
+		return err
+	}
+	return s.authzrepo.CreateRule(ctx, tx, &repo.Rule{
+		Sub:      member,
+		Resource: repo.NewOrgResource4Sv1(org, "", teamName, "", ""),
+		Action:   repo.ActRead,
+	})
+}
+
+// dropTeamRoles removes casbin roles of team with the groups of its members.
+func (s *TeamsUsecase) dropTeamRoles(ctx context.Context, tx repo.TX, org, teamName string) error {
+	subject := TeamSubject(org, teamName)
+	for _, role := range teamRoles {
+		groups, err := s.authzrepo.ListGroup(ctx, tx, &repo.GroupFilter{Role: TeamRoleSubject(subject, role)})
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			if g == nil {
+				continue
+			}
+			if err := s.authzrepo.DeleteGroup(ctx, tx, g); err != nil {
+				return err
+			}
+		}
+	}
+	if err := s.authzrepo.DeleteRule(ctx, tx, &repo.Rule{
+		Sub:      TeamRoleSubject(subject, repo.TeamRoleMaintainer),
+		Resource: repo.NewOrgResource4Sv1(org, "", teamName, "", ""),
+		Action:   repo.ActWrite,
+	}); err != nil {
+		return err
+	}
+	return s.authzrepo.DeleteRule(ctx, tx, &repo.Rule{
+		Sub:      TeamRoleSubject(subject, repo.TeamRoleMember),
+		Resource: repo.NewOrgResource4Sv1(org, "", teamName, "", ""),
+		Action:   repo.ActRead,
+	})
+}
+
+// removeGroup removes user from role, members added before roles were synced have no group.
+func (s *teamMembership) removeGroup(ctx context.Context, tx repo.TX, user, role string) error {
+	groups, err := s.authzrepo.ListGroup(ctx, tx, &repo.GroupFilter{User: user})
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if g != nil && g.Role == role {
+			return s.authzrepo.DeleteGroup(ctx, tx, g)
+		}
+	}
+	return nil
+}
+
+// setMemberRole makes user a member of team with role, existing is the current membership or nil.
+func (s *teamMembership) setMemberRole(ctx context.Context, tx repo.TX, org, teamName string, existing *repo.TeamMember,
+	member *repo.TeamMember, userName string) error {
+	subject := TeamSubject(org, teamName)
+	if existing == nil {
+		member.CreatedAt = time.Now().Unix()
+		if err := s.memberRepo.CreateTeamMembers(ctx, tx, []*repo.TeamMember{member}); err != nil {
+			return err
+		}
+	} else if existing.Role != member.Role {
+		if err := s.removeGroup(ctx, tx, userName, TeamRoleSubject(subject, existing.Role)); err != nil {
+			return err
+		}
+		existing.Role = member.Role
+		if err := s.memberRepo.UpdateTeamMembers(ctx, tx, []*repo.TeamMember{existing}); err != nil {
+			return err
+		}
+	}
+	return s.authzrepo.CreateGroup(ctx, tx, &repo.Group{User: userName, Role: TeamRoleSubject(subject, member.Role)})
+}
+
+// syncLeader makes the team leader a member with role leader, a replaced leader stays as maintainer.
+func (s *TeamsUsecase) syncLeader(ctx context.Context, tx repo.TX, org string, team *repo.Team) error {
+	members, err := s.memberRepo.ListTeamMembers(ctx, tx, &repo.TeamMembersFilter{TeamIds: []uint32{team.ID}})
+	if err != nil {
+		return err
+	}
+	var current *repo.TeamMember
+	for _, m := range members {
+		if m.UserId == team.LeaderId {
+			current = m
+			continue
+		}
+		if m.Role != repo.TeamRoleLeader {
+			continue
+		}
+		old, err := s.adminRepo.GetUsers(ctx, tx, m.UserId)
+		if err != nil {
+			return err
+		}
+		if err := s.setMemberRole(ctx, tx, org, team.Name, m,
+			&repo.TeamMember{TeamId: team.ID, UserId: m.UserId, Role: repo.TeamRoleMaintainer}, old.UserName); err != nil {
+			return err
+		}
+	}
+	leader, err := s.adminRepo.GetUsers(ctx, tx, team.LeaderId)
+	if err != nil {
+		return errors.Join(errors.New("leader not found"), err)
+	}
+	if err := s.ensureTeamRoles(ctx, tx, org, team.Name); err != nil {
+		return err
+	}
+	return s.setMemberRole(ctx, tx, org, team.Name, current,
+		&repo.TeamMember{TeamId: team.ID, UserId: team.LeaderId, Role: repo.TeamRoleLeader}, leader.UserName)
+}
+
+// renameTeamRoles moves groups of members to roles of the new team name.
+func (s *TeamsUsecase) renameTeamRoles(ctx context.Context, tx repo.TX, teamId uint32, org, oldName, newName string) error {
+	if err := s.dropTeamRoles(ctx, tx, org, oldName); err != nil {
+		return err
+	}
+	if err := s.ensureTeamRoles(ctx, tx, org, newName); err != nil {
+		return err
+	}
+	members, err := s.memberRepo.ListTeamMembers(ctx, tx, &repo.TeamMembersFilter{TeamIds: []uint32{teamId}})
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		user, err := s.adminRepo.GetUsers(ctx, tx, m.UserId)
+		if err != nil {
+			return err
+		}
+		if err := s.authzrepo.CreateGroup(ctx, tx, &repo.Group{User: user.UserName, Role: TeamRoleSubject(TeamSubject(org, newName), m.Role)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforceMembers requires permission on the team's resources, granted to admins, the leader and maintainers.
+func (s *TeamsUsecase) enforceMembers(ctx context.Context, tx repo.TX, org string, team *repo.Team) error {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	leader, err := s.adminRepo.GetUsers(ctx, tx, team.LeaderId)
+	if err != nil {
+		return err
+	}
+	can, err := s.authzrepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: repo.NewOrgResource4Sv1(org, "team", team.Name, "members", leader.UserName),
+		Action:   repo.ActWrite,
+	})
+	if err != nil {
+		return err
+	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// ListTeamMembers lists members of team, the leader first.
+func (s *TeamsUsecase) ListTeamMembers(ctx context.Context, teamId uint32) ([]*TeamMember, error) {
+	if teamId == 0 {
+		return nil, ErrEmptyId
+	}
+	team, err := s.teamRepo.GetTeams(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	readable, err := s.filterReadable(ctx, []*repo.Team{team})
+	if err != nil {
+		return nil, err
+	}
+	if len(readable) == 0 {
+		return nil, ErrPermissionDenied
+	}
+	members, err := s.memberRepo.ListTeamMembers(ctx, nil, &repo.TeamMembersFilter{TeamIds: []uint32{teamId}})
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return []*TeamMember{}, nil
+	}
+	ids := make([]uint32, len(members))
+	for i, m := range members {
+		ids[i] = m.UserId
+	}
+	users, err := s.adminRepo.ListUsers(ctx, nil, &repo.UsersFilter{Ids: ids})
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint32]string, len(users))
+	for _, u := range users {
+		names[u.Id] = u.UserName
+	}
+	res := make([]*TeamMember, 0, len(members))
+	for _, m := range members {
+		if m.Role == repo.TeamRoleLeader {
+			res = append([]*TeamMember{ToBizTeamMember(m, names[m.UserId])}, res...)
+			continue
+		}
+		res = append(res, ToBizTeamMember(m, names[m.UserId]))
+	}
+	return res, nil
+}
+
+// AddTeamMembers adds users to team as members or maintainers, roles of existing members are changed.
+func (s *TeamsUsecase) AddTeamMembers(ctx context.Context, teamId uint32, members []*TeamMember) error {
+	if teamId == 0 {
+		return errors.Join(errors.New("AddTeamMembers failed"), ErrEmptyId)
+	}
+	if len(members) == 0 {
+		return errors.Join(errors.New("AddTeamMembers failed"), fmt.Errorf("EmptyMembers"))
+	}
+	for _, m := range members {
+		if m == nil {
+			return errors.Join(errors.New("AddTeamMembers failed"), fmt.Errorf("member is nil"))
+		}
+		if err := m.Validate(); err != nil {
+			return errors.Join(errors.New("AddTeamMembers failed"), invalidArgument(err))
+		}
+	}
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		team, err := s.teamRepo.GetTeams(ctx, teamId)
+		if err != nil {
+			return err
+		}
+		org, err := orgName(ctx, s.orgRepo, team.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := s.enforceMembers(ctx, tx, org, team); err != nil {
+			return err
+		}
+		existing, err := s.memberRepo.ListTeamMembers(ctx, tx, &repo.TeamMembersFilter{TeamIds: []uint32{teamId}})
+		if err != nil {
+			return err
+		}
+		byUser := make(map[uint32]*repo.TeamMember, len(existing))
+		for _, m := range existing {
+			byUser[m.UserId] = m
+		}
+		if err := s.ensureTeamRoles(ctx, tx, org, team.Name); err != nil {
+			return err
+		}
+		for _, m := range members {
+			if cur := byUser[m.UserId]; cur != nil && cur.Role == repo.TeamRoleLeader {
+				return InvalidArgument("leader is changed by updating the team")
+			}
+			user, err := s.adminRepo.GetUsers(ctx, tx, m.UserId)
+			if err != nil {
+				return errors.Join(NotFound("user %d not found", m.UserId), err)
+			}
+			if err := s.setMemberRole(ctx, tx, org, team.Name, byUser[m.UserId],
+				&repo.TeamMember{TeamId: teamId, UserId: m.UserId, Role: m.Role}, user.UserName); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Errorf("AddTeamMembers of team %d failed: %v", teamId, err)
+		return errors.Join(errors.New("AddTeamMembers failed"), err)
+	}
+	return nil
+}
+
+// RemoveTeamMembers removes users from team, the leader can not be removed.
+func (s *TeamsUsecase) RemoveTeamMembers(ctx context.Context, teamId uint32, userIds []uint32) error {
+	if teamId == 0 {
+		return errors.Join(errors.New("RemoveTeamMembers failed"), ErrEmptyId)
+	}
+	if len(userIds) == 0 {
+		return errors.Join(errors.New("RemoveTeamMembers failed"), ErrEmptyIds)
+	}
+	userIds = DedupSliceUint32(userIds)
+	err := s.txm.RunInTX(func(tx repo.TX) error {
+		team, err := s.teamRepo.GetTeams(ctx, teamId)
+		if err != nil {
+			return err
+		}
+		org, err := orgName(ctx, s.orgRepo, team.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := s.enforceMembers(ctx, tx, org, team); err != nil {
+			return err
+		}
+		members, err := s.memberRepo.ListTeamMembers(ctx, tx, &repo.TeamMembersFilter{
+			TeamIds: []uint32{teamId},
+			UserIds: userIds,
+		})
+		if err != nil {
+			return err
+		}
+		if len(members) != len(userIds) {
+			return FailedPrecondition("some users are not members of team %s", team.Name)
+		}
+		ids := make([]uint32, 0, len(members))
+		for _, m := range members {
+			if m.Role == repo.TeamRoleLeader {
+				return FailedPrecondition("leader can not be removed from team")
+			}
+			user, err := s.adminRepo.GetUsers(ctx, tx, m.UserId)
+			if err != nil {
+				return err
+			}
+			if err := s.removeGroup(ctx, tx, user.UserName, TeamRoleSubject(TeamSubject(org, team.Name), m.Role)); err != nil {
+				return err
+			}
+			ids = append(ids, m.ID)
+		}
+		return s.memberRepo.DeleteTeamMembers(ctx, tx, ids)
+	})
+	if err != nil {
+		s.log.Errorf("RemoveTeamMembers of team %d failed: %v", teamId, err)
+		return errors.Join(errors.New("RemoveTeamMembers failed"), err)
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package biz
 
 import (
 	"context"
-	"fmt"
 
 	"opspillar/internal/data"
 	"opspillar/internal/data/repo"
@@ -11,8 +10,10 @@ import (
 )
 
 type TeamsUsecase struct {
+	teamMembership
 	teamRepo  repo.TeamsRepo
-	authzrepo repo.AuthzRepo
+	orgRepo   repo.OrganizationsRepo
+	adminRepo repo.AdminRepo
 	txm       repo.TxManager
 	log       *log.Helper
 	required  []requiredBy
@@ -20,22 +21,29 @@ type TeamsUsecase struct {
 
 func NewTeamsUsecase(
 	teamrepo repo.TeamsRepo,
+	orgrepo repo.OrganizationsRepo,
 	authzrepo repo.AuthzRepo,
+	tmrepo repo.TeamMembersRepo,
+	adminrepo repo.AdminRepo,
 	hgrepo repo.HostgroupsRepo,
 	htrepo repo.HostgroupTeamsRepo,
 	apprepo repo.ApplicationsRepo,
+	sarepo repo.ServiceAccountsRepo,
 	logger log.Logger,
 	txm repo.TxManager) *TeamsUsecase {
 
 	return &TeamsUsecase{
-		teamRepo:  teamrepo,
-		authzrepo: authzrepo,
-		log:       log.NewHelper(logger),
-		txm:       txm,
+		teamMembership: teamMembership{authzrepo: authzrepo, memberRepo: tmrepo},
+		teamRepo:       teamrepo,
+		orgRepo:        orgrepo,
+		adminRepo:      adminrepo,
+		log:            log.NewHelper(logger),
+		txm:            txm,
 		required: []requiredBy{
 			{inst: hgrepo, name: "hostgroup"},
 			{inst: apprepo, name: "app"},
 			{inst: htrepo, name: "hostgroup_team"},
+			{inst: sarepo, name: "service_account"},
 		},
 	}
 }
@@ -49,9 +57,10 @@ func (s *TeamsUsecase) validate(isNew bool, teams []*Team) error {
 	return nil
 }
 
-func (s *TeamsUsecase) enforce(ctx context.Context, tx repo.TX) error {
+// enforce requires permission on teams of org, admins of the organization have it.
+func (s *TeamsUsecase) enforce(ctx context.Context, tx repo.TX, org string) error {
 	curUser := ctx.Value(data.CtxUserName).(string)
-	ires := repo.NewResource4Sv1("team", "", "", "")
+	ires := repo.NewOrgResource4Sv1(org, "team", "", "", "")
 	can, err := s.authzrepo.Enforce(ctx, tx, &repo.AuthenRequest{
 		Sub:      curUser,
 		Resource: ires,
@@ -61,7 +70,7 @@ func (s *TeamsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 		return err
 	}
 	if !can {
-		return fmt.Errorf("PermissionDenied")
+		return ErrPermissionDenied
 	}
 	return nil
 }
@@ -69,7 +78,7 @@ func (s *TeamsUsecase) enforce(ctx context.Context, tx repo.TX) error {
 // CreateTeams is
 func (s *TeamsUsecase) CreateTeams(ctx context.Context, teams []*Team) error {
 	if err := s.validate(true, teams); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 
 	_teams, e := ToDBTeams(teams)
@@ -79,12 +88,30 @@ func (s *TeamsUsecase) CreateTeams(ctx context.Context, teams []*Team) error {
 
 	err := s.txm.RunInTX(
 		func(tx repo.TX) error {
-			if err := s.enforce(ctx, tx); err != nil {
-				return err
+			orgs := make([]string, len(_teams))
+			for i, t := range _teams {
+				orgId, err := newOrgId(ctx, s.orgRepo, tx, t.OrgId)
+				if err != nil {
+					return err
+				}
+				t.OrgId = orgId
+				if orgs[i], err = orgName(ctx, s.orgRepo, orgId); err != nil {
+					return err
+				}
+				if err := s.enforce(ctx, tx, orgs[i]); err != nil {
+					return err
+				}
 			}
 			if e := s.teamRepo.CreateTeams(ctx, tx, _teams); e != nil {
 				return e
 			}
+			// the leader is the first member
+			for i, t := range _teams {
+				teams[i].Id = t.ID
+				if e := s.syncLeader(ctx, tx, orgs[i], t); e != nil {
+					return e
+				}
+			}
 			return nil
 		})
 	return err
@@ -93,7 +120,7 @@ func (s *TeamsUsecase) CreateTeams(ctx context.Context, teams []*Team) error {
 // UpdateTeams is
 func (s *TeamsUsecase) UpdateTeams(ctx context.Context, teams []*Team) error {
 	if err := s.validate(false, teams); err != nil {
-		return err
+		return invalidArgument(err)
 	}
 	_teams, e := ToDBTeams(teams)
 	if e != nil {
@@ -102,12 +129,39 @@ func (s *TeamsUsecase) UpdateTeams(ctx context.Context, teams []*Team) error {
 	//return s.teamRepo.UpdateTeams(ctx, _teams)
 	err := s.txm.RunInTX(
 		func(tx repo.TX) error {
-			if err := s.enforce(ctx, tx); err != nil {
-				return err
+			olds := make([]*repo.Team, len(_teams))
+			orgs := make([]string, len(_teams))
+			for i, t := range _teams {
+				old, e := s.teamRepo.GetTeams(ctx, t.ID)
+				if e != nil {
+					return e
+				}
+				olds[i] = old
+				// roles and rules of the team are named by its organization
+				if t.OrgId != 0 && t.OrgId != old.OrgId {
+					return InvalidArgument("organization of team %s can not be changed", old.Name).WithField("org_id", "can not be changed")
+				}
+				t.OrgId = old.OrgId
+				if orgs[i], e = orgName(ctx, s.orgRepo, old.OrgId); e != nil {
+					return e
+				}
+				if err := s.enforce(ctx, tx, orgs[i]); err != nil {
+					return err
+				}
 			}
-			if e := s.teamRepo.UpdateTeams(ctx, tx, _teams); e != nil {
+			if e := conflicting(s.teamRepo.UpdateTeams(ctx, tx, _teams)); e != nil {
 				return e
 			}
+			for i, t := range _teams {
+				if olds[i].Name != t.Name {
+					if e := s.renameTeamRoles(ctx, tx, t.ID, orgs[i], olds[i].Name, t.Name); e != nil {
+						return e
+					}
+				}
+				if e := s.syncLeader(ctx, tx, orgs[i], t); e != nil {
+					return e
+				}
+			}
 			return nil
 		})
 	return err
@@ -117,14 +171,23 @@ func (s *TeamsUsecase) UpdateTeams(ctx context.Context, teams []*Team) error {
 func (s *TeamsUsecase) DeleteTeams(ctx context.Context,
 	ids []uint32) error {
 	if len(ids) == 0 {
-		return fmt.Errorf("EmptyIds")
+		return ErrEmptyIds
 	}
 
 	return s.txm.RunInTX(
 		func(tx repo.TX) error {
-
-			if err := s.enforce(ctx, tx); err != nil {
-				return err
+			teams, e := s.teamRepo.ListTeams(ctx, tx, &repo.TeamsFilter{Ids: ids})
+			if e != nil {
+				return e
+			}
+			orgs := make([]string, len(teams))
+			for i, t := range teams {
+				if orgs[i], e = orgName(ctx, s.orgRepo, t.OrgId); e != nil {
+					return e
+				}
+				if err := s.enforce(ctx, tx, orgs[i]); err != nil {
+					return err
+				}
 			}
 
 			for _, r := range s.required {
@@ -133,12 +196,20 @@ func (s *TeamsUsecase) DeleteTeams(ctx context.Context,
 					return err
 				}
 				if c > 0 {
-					return fmt.Errorf("some %s requires", r.name)
+					return FailedPrecondition("some %s requires", r.name)
 				}
 			}
 			if e := s.teamRepo.DeleteTeams(ctx, tx, ids); e != nil {
 				return e
 			}
+			if e := s.memberRepo.DeleteTeamMembersByTeamId(ctx, tx, ids); e != nil {
+				return e
+			}
+			for i, t := range teams {
+				if e := s.dropTeamRoles(ctx, tx, orgs[i], t.Name); e != nil {
+					return e
+				}
+			}
 			return nil
 		})
 }
@@ -146,27 +217,89 @@ func (s *TeamsUsecase) DeleteTeams(ctx context.Context,
 // GetTeams is
 func (s *TeamsUsecase) GetTeams(ctx context.Context, id uint32) (*Team, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("EmptyId")
+		return nil, ErrEmptyId
 	}
 	dbt, e := s.teamRepo.GetTeams(ctx, id)
 	if e != nil {
 		return nil, e
 	}
+	readable, e := s.filterReadable(ctx, []*repo.Team{dbt})
+	if e != nil {
+		return nil, e
+	}
+	if len(readable) == 0 {
+		return nil, ErrPermissionDenied
+	}
 	return ToBizTeam(dbt)
 }
 
 // ListTeams is
 func (s *TeamsUsecase) ListTeams(ctx context.Context,
-	filter *ListTeamsFilter) ([]*Team, error) {
+	filter *ListTeamsFilter) ([]*Team, *PageInfo, error) {
 	if filter != nil {
 		if err := filter.Validate(); err != nil {
-			return nil, err
+			return nil, nil, invalidArgument(err)
+		}
+	}
+	dbFilter := ToDBTeamsFilter(filter)
+	if len(dbFilter.OrgIds) == 0 {
+		orgIds, e := selectedOrgIds(ctx, s.orgRepo)
+		if e != nil {
+			return nil, nil, e
 		}
+		dbFilter.OrgIds = orgIds
 	}
-	teams, e := s.teamRepo.ListTeams(ctx, nil, ToDBTeamsFilter(filter))
+	teams, e := s.teamRepo.ListTeams(ctx, nil, dbFilter)
 
 	if e != nil {
-		return nil, e
+		return nil, nil, e
 	}
-	return ToBizTeams(teams)
+	// totals count teams readable by the caller only
+	page, e := listPage(teams, dbFilter.PageSize, func(t *repo.Team) uint32 { return t.ID },
+		filter != nil && filter.WithTotal, func() ([]*repo.Team, error) {
+			all := *dbFilter
+			all.Page, all.PageSize, all.LastId = 0, 0, 0
+			rows, err := s.teamRepo.ListTeams(ctx, nil, &all)
+			if err != nil {
+				return nil, err
+			}
+			return s.filterReadable(ctx, rows)
+		})
+	if e != nil {
+		return nil, nil, e
+	}
+	teams, e = s.filterReadable(ctx, teams)
+	if e != nil {
+		return nil, nil, e
+	}
+	res, e := ToBizTeams(teams)
+	return res, page, e
+}
+
+// filterReadable keeps teams readable by the current user, teams are public by default,
+// otherwise visible to their members and leader.
+func (s *TeamsUsecase) filterReadable(ctx context.Context, teams []*repo.Team) ([]*repo.Team, error) {
+	leaderIds := make([]uint32, 0, len(teams))
+	for _, t := range teams {
+		if t.LeaderId > 0 {
+			leaderIds = append(leaderIds, t.LeaderId)
+		}
+	}
+	leaders := make(map[uint32]string, len(leaderIds))
+	if len(leaderIds) > 0 {
+		users, err := s.adminRepo.ListUsers(ctx, nil, &repo.UsersFilter{Ids: DedupSliceUint32(leaderIds)})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			leaders[u.Id] = u.UserName
+		}
+	}
+	return filterReadable(ctx, s.authzrepo, nil, teams, func(t *repo.Team) ([]repo.IResource, error) {
+		org, err := orgName(ctx, s.orgRepo, t.OrgId)
+		if err != nil {
+			return nil, err
+		}
+		return []repo.IResource{repo.NewOrgResource4Sv1(org, "team", t.Name, "", leaders[t.LeaderId])}, nil
+	})
 }
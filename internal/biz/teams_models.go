@@ -6,6 +6,7 @@ type Team struct {
 	Code        string
 	Description string
 	LeaderId    uint32
+	OrgId       uint32
 }
 
 type ListTeamsFilter struct {
@@ -15,4 +16,21 @@ type ListTeamsFilter struct {
 	Codes     []string
 	LeadersId []uint32
 	Ids       []uint32
+	OrgIds    []uint32
+
+	// PageToken continues the list after the page of the token, Page is ignored then
+	PageToken string
+	// WithTotal counts rows of all pages
+	WithTotal bool
+}
+
+// TeamMember is a user in a team with role member, maintainer or leader.
+// maintainers and the leader can modify the team's resources.
+type TeamMember struct {
+	Id        uint32
+	TeamId    uint32
+	UserId    uint32
+	UserName  string
+	Role      string
+	CreatedAt int64
 }
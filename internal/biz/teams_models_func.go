@@ -11,15 +11,15 @@ func (f *Team) Validate(isNew bool) error {
 	}
 	if !isNew {
 		if f.Id <= 0 {
-			return fmt.Errorf("InvalidId")
+			return ErrInvalidId
 		}
 	}
 
 	if e := ValidateName(f.Name); e != nil {
-		return e
+		return invalidField("name", e)
 	}
 	if e := ValidateCode(f.Code); e != nil {
-		return e
+		return invalidField("code", e)
 	}
 
 	return nil
@@ -29,10 +29,11 @@ func (lf *ListTeamsFilter) Validate() error {
 	if lf == nil {
 		return nil
 	}
-	if len(lf.Codes) > MaxFilterValues ||
+	if len(lf.Codes) > MaxFilterPatterns ||
 		len(lf.Ids) > MaxFilterValues ||
 		len(lf.LeadersId) > MaxFilterValues ||
-		len(lf.Names) > MaxFilterValues {
+		len(lf.OrgIds) > MaxFilterValues ||
+		len(lf.Names) > MaxFilterPatterns {
 		return ErrFilterValuesExceedMax
 	}
 	if lf.PageSize == 0 || lf.PageSize > MaxPageSize {
@@ -41,6 +42,9 @@ func (lf *ListTeamsFilter) Validate() error {
 	if lf.Page == 0 {
 		return ErrFilterInvalidPage
 	}
+	if _, err := DecodePageToken(lf.PageToken); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -58,6 +62,7 @@ func ToDBTeam(t *Team) (*repo.Team, error) {
 		Code:        t.Code,
 		LeaderId:    t.LeaderId,
 		Description: t.Description,
+		OrgId:       t.OrgId,
 	}, nil
 }
 
@@ -80,6 +85,7 @@ func ToBizTeam(t *repo.Team) (*Team, error) {
 		Description: t.Description,
 		LeaderId:    t.LeaderId,
 		Name:        t.Name,
+		OrgId:       t.OrgId,
 	}, nil
 }
 
@@ -97,7 +103,51 @@ func ToDBTeamsFilter(filter *ListTeamsFilter) *repo.TeamsFilter {
 		Ids:       filter.Ids,
 		LeadersId: filter.LeadersId,
 		Names:     filter.Names,
+		OrgIds:    filter.OrgIds,
 		Page:      filter.Page,
 		PageSize:  filter.PageSize,
+		LastId:    pageTokenId(filter.PageToken),
+	}
+}
+
+// teamsNamed keeps the teams named name exactly, names of TeamsFilter match substrings.
+func teamsNamed(teams []*repo.Team, name string) []*repo.Team {
+	named := make([]*repo.Team, 0, 1)
+	for _, t := range teams {
+		if t.Name == name {
+			named = append(named, t)
+		}
+	}
+	return named
+}
+
+// Validate checks members added through the team members api, the leader is set by updating the team.
+func (m *TeamMember) Validate() error {
+	if m.UserId == 0 {
+		return fmt.Errorf("InvalidUserId")
+	}
+	switch m.Role {
+	case repo.TeamRoleMember, repo.TeamRoleMaintainer:
+		return nil
+	case repo.TeamRoleLeader:
+		return InvalidArgument("leader is changed by updating the team")
+	default:
+		return fmt.Errorf("invalid team role %q", m.Role)
+	}
+}
+
+// TeamRoleSubject is the casbin role of members of team with role.
+func TeamRoleSubject(teamName, role string) string {
+	return teamName + ":" + role
+}
+
+func ToBizTeamMember(m *repo.TeamMember, userName string) *TeamMember {
+	return &TeamMember{
+		Id:        m.ID,
+		TeamId:    m.TeamId,
+		UserId:    m.UserId,
+		UserName:  userName,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt,
 	}
 }
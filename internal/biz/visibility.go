@@ -0,0 +1,156 @@
+package biz
+
+import (
+	"context"
+
+	"opspillar/internal/data/repo"
+)
+
+// DefaultPublicKinds are resource kinds every user can read when authz.public_kinds is empty,
+// they are the lookups describing hostgroups and applications.
+var DefaultPublicKinds = []string{"attributes", "clusters", "datacenters", "envs", "features", "organizations", "product", "tagkeys", "tags", "team"}
+
+// enforceRead requires read permission of the current user on res.
+func enforceRead(ctx context.Context, authzRepo repo.AuthzRepo, tx repo.TX, res repo.IResource) error {
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	can, err := authzRepo.Enforce(ctx, tx, &repo.AuthenRequest{
+		Sub:      curUser,
+		Resource: res,
+		Action:   repo.ActRead,
+	})
+	if err != nil {
+		return err
+	}
+	if !can {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// filterReadable keeps items the current user can read any resource of, policies are loaded once
+// for all items. a filtered page may be shorter than its page size.
+func filterReadable[T any](ctx context.Context, authzRepo repo.AuthzRepo, tx repo.TX, items []T,
+	resources func(T) ([]repo.IResource, error)) ([]T, error) {
+	if len(items) == 0 {
+		return items, nil
+	}
+	curUser, err := GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*repo.AuthenRequest, 0, len(items))
+	owners := make([]int, 0, len(items))
+	for i, item := range items {
+		ress, err := resources(item)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range ress {
+			requests = append(requests, &repo.AuthenRequest{
+				Sub:      curUser,
+				Resource: res,
+				Action:   repo.ActRead,
+			})
+			owners = append(owners, i)
+		}
+	}
+	can, err := authzRepo.BatchEnforce(ctx, tx, requests)
+	if err != nil {
+		return nil, err
+	}
+	readable := make([]bool, len(items))
+	for i, ok := range can {
+		if ok {
+			readable[owners[i]] = true
+		}
+	}
+	res := make([]T, 0, len(items))
+	for i, item := range items {
+		if readable[i] {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+// teamResourceNames caches names of teams, organizations and users to build resources of a list.
+type teamResourceNames struct {
+	teamsRepo repo.TeamsRepo
+	adminRepo repo.AdminRepo
+	orgRepo   repo.OrganizationsRepo
+	teams     map[uint32]*repo.Team
+	orgs      map[uint32]string
+	users     map[uint32]string
+}
+
+func newTeamResourceNames(teamsRepo repo.TeamsRepo, adminRepo repo.AdminRepo, orgRepo repo.OrganizationsRepo) *teamResourceNames {
+	return &teamResourceNames{
+		teamsRepo: teamsRepo,
+		adminRepo: adminRepo,
+		orgRepo:   orgRepo,
+		teams:     map[uint32]*repo.Team{},
+		orgs:      map[uint32]string{},
+		users:     map[uint32]string{},
+	}
+}
+
+func (n *teamResourceNames) team(ctx context.Context, id uint32) (*repo.Team, error) {
+	if t, ok := n.teams[id]; ok {
+		return t, nil
+	}
+	t, err := n.teamsRepo.GetTeams(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	n.teams[id] = t
+	return t, nil
+}
+
+func (n *teamResourceNames) org(ctx context.Context, id uint32) (string, error) {
+	if o, ok := n.orgs[id]; ok {
+		return o, nil
+	}
+	o, err := orgName(ctx, n.orgRepo, id)
+	if err != nil {
+		return "", err
+	}
+	n.orgs[id] = o
+	return o, nil
+}
+
+// resource is the resource of inst of team in the organization of team.
+func (n *teamResourceNames) resource(ctx context.Context, resType string, team *repo.Team, inst, user string) (repo.IResource, error) {
+	org, err := n.org(ctx, team.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	return repo.NewOrgResource4Sv1(org, resType, team.Name, inst, user), nil
+}
+
+func (n *teamResourceNames) user(ctx context.Context, id uint32) (string, error) {
+	if u, ok := n.users[id]; ok {
+		return u, nil
+	}
+	u, err := n.adminRepo.GetUsers(ctx, nil, id)
+	if err != nil {
+		return "", err
+	}
+	n.users[id] = u.UserName
+	return u.UserName, nil
+}
+
+// leaderResource is the resource of inst owned by the leader of team, as hostgroups are.
+func (n *teamResourceNames) leaderResource(ctx context.Context, resType, inst string, teamId uint32) (repo.IResource, error) {
+	team, err := n.team(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	leader, err := n.user(ctx, team.LeaderId)
+	if err != nil {
+		return nil, err
+	}
+	return n.resource(ctx, resType, team, inst, leader)
+}
@@ -7,4 +7,6 @@ const (
 	CtxUserTokenKey ContextKey = "user-token"
 	CtxUserName     ContextKey = "username"
 	CtxUserId       ContextKey = "userid"
+	CtxSessionId    ContextKey = "sid"
+	CtxOrgName      ContextKey = "org"
 )
@@ -14,6 +14,7 @@ var ProviderSet = wire.NewSet(
 	sqldb.NewTagsRepoGorm,
 	sqldb.NewTeamsRepoGorm,
 	sqldb.NewProductsRepoGorm,
+	sqldb.NewOrganizationsRepoGorm,
 	sqldb.NewEnvsRepoGorm,
 	sqldb.NewClustersRepoGorm,
 	sqldb.NewDatacentersRepoGorm,
@@ -26,7 +27,19 @@ var ProviderSet = wire.NewSet(
 	sqldb.NewHostgroupProductsRepoGorm,
 	sqldb.NewHostgroupTagsRepoGorm,
 	sqldb.NewHostgroupFeaturesRepoGorm,
+	sqldb.NewAttributesRepoGorm,
+	sqldb.NewTagKeysRepoGorm,
 	sqldb.NewAdminRepoGorm,
 	sqldb.NewAuthzRepoGorm,
+	sqldb.NewSessionsRepoGorm,
+	sqldb.NewServiceAccountsRepoGorm,
+	sqldb.NewApiKeysRepoGorm,
+	sqldb.NewBreakGlassRepoGorm,
+	sqldb.NewSigningKeysRepoGorm,
+	sqldb.NewAuthzAuditsRepoGorm,
+	sqldb.NewTeamMembersRepoGorm,
+	NewTokenRevocationRepo,
 	NewJwtMemRepo,
+	NewOIDCProvider,
+	NewLDAPProvider,
 )
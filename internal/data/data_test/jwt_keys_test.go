@@ -0,0 +1,153 @@
+package data_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/redisdb"
+	"opspillar/internal/data/repo"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// memSigningKeys is a SigningKeysRepo shared by token repos as a database.
+type memSigningKeys struct {
+	mu   sync.Mutex
+	keys []*repo.SigningKey
+}
+
+func (m *memSigningKeys) CreateSigningKeys(ctx context.Context, tx repo.TX, keys []*repo.SigningKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range keys {
+		k.ID = uint32(len(m.keys) + 1)
+		m.keys = append(m.keys, k)
+	}
+	return nil
+}
+
+func (m *memSigningKeys) ListSigningKeys(ctx context.Context, tx repo.TX, now int64) ([]*repo.SigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []*repo.SigningKey
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if k := m.keys[i]; k.ExpiresAt == 0 || k.ExpiresAt > now {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memSigningKeys) ExpireSigningKeys(ctx context.Context, tx repo.TX, ids []uint32, expiresAt int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if k := m.keys[id-1]; k.ExpiresAt == 0 {
+			k.ExpiresAt = expiresAt
+		}
+	}
+	return nil
+}
+
+func (m *memSigningKeys) DeleteExpiredSigningKeys(ctx context.Context, tx repo.TX, now int64) error {
+	return nil
+}
+
+func initKeyTokenRepo(t *testing.T, alg string, keys repo.SigningKeysRepo) repo.TokenRepo {
+	mr := miniredis.RunT(t)
+	rdata := &redisdb.DataRedis{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	revocations, err := redisdb.NewTokenRevocationRepoRedis(rdata, logger)
+	assert.NoError(t, err)
+	r, err := data.NewJwtMemRepo(&conf.Admin{JwtAlgorithm: alg, JwtExpireHours: 1}, revocations, keys)
+	assert.NoError(t, err)
+	return r
+}
+
+func tokenKid(t *testing.T, token string) string {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	assert.NoError(t, err)
+	kid, _ := parsed.Header[data.HeaderKeyId].(string)
+	return kid
+}
+
+func TestJwtMemRepo_SigningKeys(t *testing.T) {
+	for _, tc := range []struct {
+		alg string
+		kty string
+	}{
+		{repo.SigningAlgRS256, "RSA"},
+		{repo.SigningAlgEdDSA, "OKP"},
+	} {
+		t.Run(tc.alg, func(t *testing.T) {
+			ctx := context.Background()
+			keys := &memSigningKeys{}
+			r := initKeyTokenRepo(t, tc.alg, keys)
+
+			// a key is created on first start
+			jwks, err := r.PublicKeys(ctx)
+			assert.NoError(t, err)
+			if assert.Len(t, jwks, 1) {
+				assert.Equal(t, tc.kty, jwks[0].Kty)
+				assert.Equal(t, tc.alg, jwks[0].Alg)
+			}
+
+			token := newToken(t, r, "1")
+			assert.Equal(t, jwks[0].Kid, tokenKid(t, token))
+			claims, err := r.ValidateToken(ctx, token)
+			assert.NoError(t, err)
+			assert.Equal(t, "1", claims[string(data.CtxUserId)])
+
+			// rotated by another process, the old key still verifies
+			_, err = data.RotateSigningKeys(ctx, keys, tc.alg, data.SigningKeyOverlap(&conf.Admin{JwtExpireHours: 1}))
+			assert.NoError(t, err)
+			other := initKeyTokenRepo(t, tc.alg, keys)
+			rotated := newToken(t, other, "1")
+			assert.NotEqual(t, tokenKid(t, token), tokenKid(t, rotated))
+			_, err = other.ValidateToken(ctx, token)
+			assert.NoError(t, err)
+			_, err = r.ValidateToken(ctx, rotated)
+			assert.NoError(t, err)
+			jwks, err = other.PublicKeys(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, jwks, 2)
+
+			// a token signed by another key with the same kid
+			parts := strings.Split(rotated, ".")
+			_, err = r.ValidateToken(ctx, parts[0]+"."+parts[1]+"."+strings.Split(token, ".")[2])
+			assert.ErrorIs(t, err, repo.ErrInvalidToken)
+		})
+	}
+}
+
+func TestJwtMemRepo_AlgorithmConfusion(t *testing.T) {
+	ctx := context.Background()
+	keys := &memSigningKeys{}
+	r := initKeyTokenRepo(t, repo.SigningAlgRS256, keys)
+	kid := tokenKid(t, newToken(t, r, "1"))
+
+	// public key used as HMAC secret
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{string(data.CtxUserId): "1"})
+	forged.Header[data.HeaderKeyId] = kid
+	signed, err := forged.SignedString([]byte(keys.keys[0].PublicKey))
+	assert.NoError(t, err)
+	_, err = r.ValidateToken(ctx, signed)
+	assert.ErrorIs(t, err, repo.ErrInvalidToken)
+
+	// tokens of shared secret are not accepted
+	hs := initTokenRepo(t)
+	_, err = r.ValidateToken(ctx, newToken(t, hs, "1"))
+	assert.ErrorIs(t, err, repo.ErrInvalidToken)
+	jwks, err := hs.PublicKeys(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, jwks)
+
+	_, err = data.NewJwtMemRepo(&conf.Admin{JwtAlgorithm: "none"}, nil, keys)
+	assert.Error(t, err)
+}
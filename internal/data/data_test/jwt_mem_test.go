@@ -0,0 +1,90 @@
+package data_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/redisdb"
+	"opspillar/internal/data/repo"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var logger = log.With(log.NewStdLogger(os.Stdout))
+
+func initTokenRepo(t *testing.T) repo.TokenRepo {
+	mr := miniredis.RunT(t)
+	rdata := &redisdb.DataRedis{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	revocations, err := redisdb.NewTokenRevocationRepoRedis(rdata, logger)
+	assert.NoError(t, err)
+	r, err := data.NewJwtMemRepo(&conf.Admin{JwtSecret: "secret", JwtExpireHours: 1}, revocations, nil)
+	assert.NoError(t, err)
+	return r
+}
+
+func newToken(t *testing.T, r repo.TokenRepo, userId string) string {
+	token, err := r.CreateToken(context.Background(), repo.TokenClaims{
+		string(data.CtxUserId):   userId,
+		string(data.CtxUserName): "user" + userId,
+	})
+	assert.NoError(t, err)
+	return token
+}
+
+func TestJwtMemRepo_ValidateToken(t *testing.T) {
+	r := initTokenRepo(t)
+	token := newToken(t, r, "1")
+
+	claims, err := r.ValidateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", claims[string(data.CtxUserId)])
+	assert.NotEmpty(t, claims[data.ClaimTokenId])
+
+	_, err = r.ValidateToken(context.Background(), token+"x")
+	assert.ErrorIs(t, err, repo.ErrInvalidToken)
+}
+
+func TestJwtMemRepo_DeleteToken(t *testing.T) {
+	r := initTokenRepo(t)
+	ctx := context.Background()
+	token := newToken(t, r, "1")
+	other := newToken(t, r, "1")
+
+	assert.NoError(t, r.DeleteToken(ctx, token))
+
+	_, err := r.ValidateToken(ctx, token)
+	assert.ErrorIs(t, err, repo.ErrRevokedToken)
+	// other sessions of the user are still valid
+	_, err = r.ValidateToken(ctx, other)
+	assert.NoError(t, err)
+}
+
+func TestJwtMemRepo_RevokeUserTokens(t *testing.T) {
+	r := initTokenRepo(t)
+	ctx := context.Background()
+	token1 := newToken(t, r, "1")
+	token2 := newToken(t, r, "1")
+	otherUser := newToken(t, r, "2")
+
+	assert.NoError(t, r.RevokeUserTokens(ctx, "1"))
+
+	_, err := r.ValidateToken(ctx, token1)
+	assert.ErrorIs(t, err, repo.ErrRevokedToken)
+	_, err = r.ValidateToken(ctx, token2)
+	assert.ErrorIs(t, err, repo.ErrRevokedToken)
+	_, err = r.ValidateToken(ctx, otherUser)
+	assert.NoError(t, err)
+
+	// login again after revocation
+	time.Sleep(2 * time.Millisecond)
+	token3 := newToken(t, r, "1")
+	_, err = r.ValidateToken(ctx, token3)
+	assert.NoError(t, err)
+}
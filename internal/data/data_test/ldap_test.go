@@ -0,0 +1,222 @@
+package data_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type ldapEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// mockLDAP is a minimal in-process LDAP server supporting simple bind and
+// search with and, or, not, equality and presence filters.
+type mockLDAP struct {
+	listener net.Listener
+	entries  []*ldapEntry
+}
+
+func newMockLDAP(t *testing.T, entries []*ldapEntry) *mockLDAP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := &mockLDAP{listener: listener, entries: entries}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *mockLDAP) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func ldapResponse(id int64, tag ber.Tag, children ...*ber.Packet) *ber.Packet {
+	msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	for _, child := range children {
+		op.AppendChild(child)
+	}
+	msg.AppendChild(op)
+	return msg
+}
+
+func ldapResult(id int64, tag ber.Tag, code int64) *ber.Packet {
+	return ldapResponse(id, tag,
+		ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+}
+
+func (s *mockLDAP) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, _ := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			if dn == "" && password == "" {
+				code = ldap.LDAPResultSuccess
+			}
+			for _, entry := range s.entries {
+				if strings.EqualFold(entry.dn, dn) && entry.password != "" && entry.password == password {
+					code = ldap.LDAPResultSuccess
+				}
+			}
+			conn.Write(ldapResult(id, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationUnbindRequest:
+			return
+		case ldap.ApplicationSearchRequest:
+			base, _ := op.Children[0].Value.(string)
+			for _, entry := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(entry.dn), strings.ToLower(base)) || !matchFilter(entry, op.Children[6]) {
+					continue
+				}
+				attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				for _, name := range op.Children[7].Children {
+					attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+					attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name.Value.(string), ""))
+					values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+					for _, value := range entry.attrs[name.Value.(string)] {
+						values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+					}
+					attr.AppendChild(values)
+					attrs.AppendChild(attr)
+				}
+				conn.Write(ldapResponse(id, ldap.ApplicationSearchResultEntry,
+					ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.dn, ""), attrs).Bytes())
+			}
+			conn.Write(ldapResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		default:
+			conn.Write(ldapResult(id, op.Tag+1, ldap.LDAPResultUnwillingToPerform).Bytes())
+		}
+	}
+}
+
+func matchFilter(entry *ldapEntry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(entry, child) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchFilter(entry, child) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchFilter(entry, filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		for _, value := range entry.attrs[filter.Children[0].Value.(string)] {
+			if strings.EqualFold(value, filter.Children[1].Value.(string)) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(entry.attrs[filter.Data.String()]) > 0
+	}
+	return false
+}
+
+func newTestDirectory(t *testing.T) *conf.LDAP {
+	server := newMockLDAP(t, []*ldapEntry{
+		{dn: "cn=svc,dc=example,dc=com", password: "svc"},
+		{dn: "uid=alice,ou=people,dc=example,dc=com", password: "alice@123", attrs: map[string][]string{
+			"uid": {"alice"}, "mail": {"alice@example.com"},
+		}},
+		{dn: "uid=bob,ou=people,dc=example,dc=com", password: "bob@123", attrs: map[string][]string{
+			"uid": {"bob"},
+		}},
+		{dn: "cn=ops,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+			"cn": {"ops"}, "member": {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+		}},
+		{dn: "cn=dba,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+			"cn": {"dba"}, "member": {"UID=alice,ou=people,dc=example,dc=com"},
+		}},
+	})
+	return &conf.LDAP{
+		Url:          server.URL(),
+		BindDn:       "cn=svc,dc=example,dc=com",
+		BindPassword: "svc",
+		UserBaseDn:   "ou=people,dc=example,dc=com",
+		GroupBaseDn:  "ou=groups,dc=example,dc=com",
+	}
+}
+
+func TestLDAPProvider_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	provider := data.NewLDAPProvider(&conf.Admin{Ldap: newTestDirectory(t)})
+
+	user, err := provider.Authenticate(ctx, "alice", "alice@123")
+	assert.NoError(t, err)
+	assert.Equal(t, &repo.DirectoryUser{
+		DN:       "uid=alice,ou=people,dc=example,dc=com",
+		UserName: "alice",
+		Email:    "alice@example.com",
+		Groups:   []string{"ops", "dba"},
+	}, user)
+
+	_, err = provider.Authenticate(ctx, "alice", "wrong")
+	assert.ErrorIs(t, err, repo.ErrInvalidCredentials)
+	_, err = provider.Authenticate(ctx, "carol", "alice@123")
+	assert.ErrorIs(t, err, repo.ErrInvalidCredentials)
+	// an empty password must not be an unauthenticated bind
+	_, err = provider.Authenticate(ctx, "alice", "")
+	assert.ErrorIs(t, err, repo.ErrInvalidCredentials)
+	// filter injection
+	_, err = provider.Authenticate(ctx, "*", "alice@123")
+	assert.ErrorIs(t, err, repo.ErrInvalidCredentials)
+}
+
+func TestLDAPProvider_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	c := newTestDirectory(t)
+	provider := data.NewLDAPProvider(&conf.Admin{Ldap: c})
+
+	users, err := provider.ListUsers(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "alice", users[0].UserName)
+	// member dn is compared case insensitive
+	assert.Equal(t, []string{"ops", "dba"}, users[0].Groups)
+	assert.Equal(t, "bob", users[1].UserName)
+	assert.Equal(t, []string{"ops"}, users[1].Groups)
+
+	c.BindPassword = "wrong"
+	_, err = provider.ListUsers(ctx)
+	assert.Error(t, err)
+
+	assert.Nil(t, data.NewLDAPProvider(&conf.Admin{}))
+}
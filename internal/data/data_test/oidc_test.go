@@ -0,0 +1,193 @@
+package data_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockIdP is a minimal OIDC provider supporting authorization code flow with PKCE.
+type mockIdP struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+
+	mu    sync.Mutex
+	codes map[string]url.Values
+}
+
+func newMockIdP(t *testing.T, claims jwt.MapClaims) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	idp := &mockIdP{key: key, claims: claims, codes: map[string]url.Values{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                idp.URL,
+			"authorization_endpoint":                idp.URL + "/authorize",
+			"token_endpoint":                        idp.URL + "/token",
+			"jwks_uri":                              idp.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA", "alg": "RS256", "use": "sig", "kid": "test",
+				"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	// authorize approves at once and redirects back with code
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		code := "code-" + q.Get("state")
+		idp.mu.Lock()
+		idp.codes[code] = q
+		idp.mu.Unlock()
+		http.Redirect(w, r, q.Get("redirect_uri")+"?code="+code+"&state="+q.Get("state"), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		idp.mu.Lock()
+		q, ok := idp.codes[r.Form.Get("code")]
+		delete(idp.codes, r.Form.Get("code"))
+		idp.mu.Unlock()
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if !ok || q.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(sum[:]) ||
+			q.Get("redirect_uri") != r.Form.Get("redirect_uri") {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := jwt.MapClaims{
+			"iss":   idp.URL,
+			"aud":   q.Get("client_id"),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"iat":   time.Now().Unix(),
+			"nonce": q.Get("nonce"),
+		}
+		for k, v := range idp.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, _ := token.SignedString(key)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+// authorize follows the auth url like a browser and returns the code.
+func (idp *mockIdP) authorize(t *testing.T, authURL string) string {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	assert.NoError(t, err)
+	return loc.Query().Get("code")
+}
+
+func pkce(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestOIDCProvider(t *testing.T) {
+	idp := newMockIdP(t, jwt.MapClaims{
+		"sub":                "user-1",
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"groups":             []string{"ops", "dev"},
+	})
+	ctx := context.Background()
+	redirect := "http://127.0.0.1:9999/callback"
+
+	assert.Nil(t, data.NewOIDCProvider(&conf.Admin{}))
+	p := data.NewOIDCProvider(&conf.Admin{Oidc: &conf.OIDC{Issuer: idp.URL, ClientId: "opspillar"}})
+	assert.NotNil(t, p)
+
+	authURL, err := p.AuthCodeURL(ctx, redirect, "state1", "nonce1", pkce("verifier1"))
+	assert.NoError(t, err)
+	assert.Contains(t, authURL, "code_challenge_method=S256")
+
+	identity, err := p.Exchange(ctx, idp.authorize(t, authURL), "verifier1", redirect, "nonce1")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", identity.Subject)
+	assert.Equal(t, "alice", identity.UserName)
+	assert.Equal(t, "alice@example.com", identity.Email)
+	assert.Equal(t, []string{"ops", "dev"}, identity.Groups)
+
+	// wrong code verifier
+	authURL, _ = p.AuthCodeURL(ctx, redirect, "state2", "nonce2", pkce("verifier2"))
+	_, err = p.Exchange(ctx, idp.authorize(t, authURL), "wrong", redirect, "nonce2")
+	assert.Error(t, err)
+
+	// wrong nonce
+	authURL, _ = p.AuthCodeURL(ctx, redirect, "state3", "nonce3", pkce("verifier3"))
+	_, err = p.Exchange(ctx, idp.authorize(t, authURL), "verifier3", redirect, "other")
+	assert.Error(t, err)
+
+	// code is used once
+	authURL, _ = p.AuthCodeURL(ctx, redirect, "state4", "nonce4", pkce("verifier4"))
+	code := idp.authorize(t, authURL)
+	_, err = p.Exchange(ctx, code, "verifier4", redirect, "nonce4")
+	assert.NoError(t, err)
+	_, err = p.Exchange(ctx, code, "verifier4", redirect, "nonce4")
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_Claims(t *testing.T) {
+	idp := newMockIdP(t, jwt.MapClaims{
+		"sub":   "user-2",
+		"email": "bob@example.com",
+		"roles": "ops",
+	})
+	ctx := context.Background()
+	redirect := "http://localhost:9999/callback"
+
+	// missing user name claim
+	p := data.NewOIDCProvider(&conf.Admin{Oidc: &conf.OIDC{Issuer: idp.URL, ClientId: "opspillar"}})
+	authURL, _ := p.AuthCodeURL(ctx, redirect, "s1", "n1", pkce("v1"))
+	_, err := p.Exchange(ctx, idp.authorize(t, authURL), "v1", redirect, "n1")
+	assert.Error(t, err)
+
+	// custom claims
+	p = data.NewOIDCProvider(&conf.Admin{Oidc: &conf.OIDC{
+		Issuer: idp.URL, ClientId: "opspillar", UsernameClaim: "email", GroupsClaim: "roles",
+	}})
+	authURL, _ = p.AuthCodeURL(ctx, redirect, "s2", "n2", pkce("v2"))
+	identity, err := p.Exchange(ctx, idp.authorize(t, authURL), "v2", redirect, "n2")
+	assert.NoError(t, err)
+	assert.Equal(t, "bob@example.com", identity.UserName)
+	assert.Equal(t, []string{"ops"}, identity.Groups)
+}
@@ -0,0 +1,272 @@
+package data
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// HeaderKeyId is the token header of signing key id.
+const HeaderKeyId = "kid"
+
+const (
+	// keyReloadInterval is how soon a key rotated by another process is used.
+	keyReloadInterval = time.Minute
+	// keyMissReloadInterval limits reloads of tokens with unknown kid.
+	keyMissReloadInterval = 5 * time.Second
+	rsaKeyBits            = 2048
+)
+
+// SigningAlgorithm returns jwt_algorithm of conf, default HS256.
+func SigningAlgorithm(c *conf.Admin) (string, error) {
+	switch alg := c.GetJwtAlgorithm(); alg {
+	case "":
+		return repo.SigningAlgHS256, nil
+	case repo.SigningAlgHS256, repo.SigningAlgRS256, repo.SigningAlgEdDSA:
+		return alg, nil
+	default:
+		return "", fmt.Errorf("unsupported jwt algorithm %q", alg)
+	}
+}
+
+// SigningKeyOverlap is how long a rotated key verifies tokens, until the last token
+// it signed expires. other processes may sign with it until they reload keys.
+func SigningKeyOverlap(c *conf.Admin) time.Duration {
+	return time.Duration(c.GetJwtExpireHours())*time.Hour + keyReloadInterval
+}
+
+// RotateSigningKeys creates a key signing new tokens, older keys verify tokens for overlap
+// and keys expired before are deleted.
+func RotateSigningKeys(ctx context.Context, keys repo.SigningKeysRepo, alg string, overlap time.Duration) (*repo.SigningKey, error) {
+	key, err := generateSigningKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	old, err := keys.ListSigningKeys(ctx, nil, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+	if err := keys.CreateSigningKeys(ctx, nil, []*repo.SigningKey{key}); err != nil {
+		return nil, err
+	}
+	ids := make([]uint32, 0, len(old))
+	for _, k := range old {
+		ids = append(ids, k.ID)
+	}
+	if err := keys.ExpireSigningKeys(ctx, nil, ids, now.Add(overlap).Unix()); err != nil {
+		return nil, err
+	}
+	if err := keys.DeleteExpiredSigningKeys(ctx, nil, now.Unix()); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func generateSigningKey(alg string) (*repo.SigningKey, error) {
+	var private crypto.Signer
+	switch alg {
+	case repo.SigningAlgRS256:
+		k, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		private = k
+	case repo.SigningAlgEdDSA:
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		private = k
+	default:
+		return nil, fmt.Errorf("unsupported signing key algorithm %q", alg)
+	}
+	privateDer, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	publicDer, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	kid, err := newTokenId()
+	if err != nil {
+		return nil, err
+	}
+	return &repo.SigningKey{
+		Kid:        kid,
+		Algorithm:  alg,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer})),
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer})),
+		CreatedAt:  time.Now().Unix(),
+	}, nil
+}
+
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+	jwk     *repo.JSONWebKey
+}
+
+func parseSigningKey(k *repo.SigningKey) (*signingKey, error) {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("invalid private key of %s", k.Kid)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key := &signingKey{kid: k.Kid}
+	jwk := &repo.JSONWebKey{Kid: k.Kid, Use: "sig", Alg: k.Algorithm}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.Algorithm != repo.SigningAlgRS256 {
+			return nil, fmt.Errorf("algorithm %s of %s is not RSA", k.Algorithm, k.Kid)
+		}
+		key.method, key.private, key.public = jwt.SigningMethodRS256, private, &private.PublicKey
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(private.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(private.E)).Bytes())
+	case ed25519.PrivateKey:
+		if k.Algorithm != repo.SigningAlgEdDSA {
+			return nil, fmt.Errorf("algorithm %s of %s is not EdDSA", k.Algorithm, k.Kid)
+		}
+		public := private.Public().(ed25519.PublicKey)
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, private, public
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return nil, fmt.Errorf("unsupported private key of %s", k.Kid)
+	}
+	key.jwk = jwk
+	return key, nil
+}
+
+// signingKeyCache keeps keys in memory, reloaded so keys rotated by another process are used.
+type signingKeyCache struct {
+	repo repo.SigningKeysRepo
+
+	mu       sync.RWMutex
+	keys     []*signingKey
+	loadedAt time.Time
+	missedAt time.Time
+}
+
+func (c *signingKeyCache) reload(ctx context.Context) error {
+	dbKeys, err := c.repo.ListSigningKeys(ctx, nil, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	keys := make([]*signingKey, 0, len(dbKeys))
+	for _, k := range dbKeys {
+		key, err := parseSigningKey(k)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	c.mu.Lock()
+	c.keys = keys
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *signingKeyCache) loaded(within time.Duration) ([]*signingKey, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.keys, !c.loadedAt.IsZero() && time.Since(c.loadedAt) < within
+}
+
+func (c *signingKeyCache) current(ctx context.Context) ([]*signingKey, error) {
+	keys, fresh := c.loaded(keyReloadInterval)
+	if fresh {
+		return keys, nil
+	}
+	if err := c.reload(ctx); err != nil {
+		// keep using keys loaded before if database is unavailable
+		if keys != nil {
+			return keys, nil
+		}
+		return nil, err
+	}
+	keys, _ = c.loaded(keyReloadInterval)
+	return keys, nil
+}
+
+// signing returns the newest key.
+func (c *signingKeyCache) signing(ctx context.Context) (*signingKey, error) {
+	keys, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, repo.ErrNoSigningKey
+	}
+	return keys[0], nil
+}
+
+// verifying returns key of kid, nil if not found.
+func (c *signingKeyCache) verifying(ctx context.Context, kid string) *signingKey {
+	if kid == "" {
+		return nil
+	}
+	find := func(keys []*signingKey) *signingKey {
+		for _, k := range keys {
+			if k.kid == kid {
+				return k
+			}
+		}
+		return nil
+	}
+	keys, err := c.current(ctx)
+	if err != nil {
+		return nil
+	}
+	if key := find(keys); key != nil {
+		return key
+	}
+	// the key may be rotated by another process just now
+	c.mu.Lock()
+	if time.Since(c.missedAt) < keyMissReloadInterval {
+		c.mu.Unlock()
+		return nil
+	}
+	c.missedAt = time.Now()
+	c.mu.Unlock()
+	if err := c.reload(ctx); err != nil {
+		return nil
+	}
+	keys, _ = c.loaded(keyReloadInterval)
+	return find(keys)
+}
+
+func (c *signingKeyCache) publicKeys(ctx context.Context) ([]*repo.JSONWebKey, error) {
+	keys, err := c.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jwks := make([]*repo.JSONWebKey, 0, len(keys))
+	for _, k := range keys {
+		jwks = append(jwks, k.jwk)
+	}
+	return jwks, nil
+}
@@ -1,51 +1,161 @@
 package data
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
 	"opspillar/internal/conf"
 	"opspillar/internal/data/repo"
-	"context"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
+const (
+	ClaimTokenId   = "jti"
+	ClaimIssuedAt  = "iat"
+	ClaimExpiresAt = "exp"
+)
+
 type JwtMemRepo struct {
-	conf *conf.Admin
+	conf        *conf.Admin
+	revocations repo.TokenRevocationRepo
+	keys        *signingKeyCache
+}
+
+// NewJwtMemRepo signs tokens with jwt_secret, or with rotated keys if jwt_algorithm is asymmetric.
+// a key is created on first start, see RotateSigningKeys.
+func NewJwtMemRepo(conf *conf.Admin, revocations repo.TokenRevocationRepo, keys repo.SigningKeysRepo) (repo.TokenRepo, error) {
+	alg, err := SigningAlgorithm(conf)
+	if err != nil {
+		return nil, err
+	}
+	r := &JwtMemRepo{
+		conf:        conf,
+		revocations: revocations,
+	}
+	if alg == repo.SigningAlgHS256 {
+		return r, nil
+	}
+	r.keys = &signingKeyCache{repo: keys}
+	ctx := context.Background()
+	if key, err := r.keys.signing(ctx); err != nil && !errors.Is(err, repo.ErrNoSigningKey) {
+		return nil, err
+	} else if key == nil || key.method.Alg() != alg {
+		if _, err := RotateSigningKeys(ctx, keys, alg, SigningKeyOverlap(conf)); err != nil {
+			return nil, err
+		}
+		if err := r.keys.reload(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
 }
 
-func NewJwtMemRepo(conf *conf.Admin) repo.TokenRepo {
-	return &JwtMemRepo{
-		conf: conf,
+func newTokenId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+	return hex.EncodeToString(b), nil
 }
 
 func (r *JwtMemRepo) CreateToken(ctx context.Context, claims repo.TokenClaims) (string, error) {
+	jti, err := newTokenId()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
 	jwtClaims := jwt.MapClaims{
-		"exp": time.Now().Add(time.Hour * time.Duration(r.conf.JwtExpireHours)).Unix(),
+		ClaimExpiresAt: now.Add(time.Hour * time.Duration(r.conf.JwtExpireHours)).Unix(),
+		// milliseconds precision, to compare with the time user tokens revoked
+		ClaimIssuedAt: float64(now.UnixMilli()) / 1000,
+		ClaimTokenId:  jti,
 	}
 	for k, v := range claims {
 		jwtClaims[k] = v
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
+	if r.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
+		return token.SignedString([]byte(r.conf.JwtSecret))
+	}
 
-	tokenString, err := token.SignedString([]byte(r.conf.JwtSecret))
+	key, err := r.keys.signing(ctx)
 	if err != nil {
 		return "", err
 	}
-
-	return tokenString, nil
+	token := jwt.NewWithClaims(key.method, jwtClaims)
+	token.Header[HeaderKeyId] = key.kid
+	return token.SignedString(key.private)
 }
 
+// DeleteToken revokes token until it expires.
 func (r *JwtMemRepo) DeleteToken(ctx context.Context, token string) error {
-	return nil
+	claims, err := r.parse(ctx, token)
+	if err != nil {
+		return err
+	}
+	expiresAt, _ := claims[ClaimExpiresAt].(float64)
+	return r.revocations.RevokeToken(ctx, tokenId(token, claims), int64(expiresAt))
+}
+
+// RevokeUserTokens revokes every token issued to the user before now. Only the cutoff is stored,
+// tokens with an iat at or before it are rejected by ValidateToken, later logins are not affected.
+func (r *JwtMemRepo) RevokeUserTokens(ctx context.Context, userId string) error {
+	if userId == "" {
+		return errors.New("empty user id")
+	}
+	return r.revocations.RevokeUser(ctx, userId, time.Now().UnixMilli())
 }
 
 func (r *JwtMemRepo) ValidateToken(ctx context.Context, token string) (repo.TokenClaims, error) {
+	claims, err := r.parse(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.checkRevoked(ctx, token, claims); err != nil {
+		return nil, err
+	}
+
+	tokenClaims := make(repo.TokenClaims)
+	for key, value := range claims {
+		if v, ok := value.(string); ok {
+			tokenClaims[key] = v
+		}
+	}
+
+	return tokenClaims, nil
+
+}
+
+// PublicKeys returns keys not expired yet, newest first.
+func (r *JwtMemRepo) PublicKeys(ctx context.Context) ([]*repo.JSONWebKey, error) {
+	if r.keys == nil {
+		return []*repo.JSONWebKey{}, nil
+	}
+	return r.keys.publicKeys(ctx)
+}
+
+// parse verifies the signature with the key of token kid, the signing method
+// must be the method of the key so a public key can not be used as HMAC secret.
+func (r *JwtMemRepo) parse(ctx context.Context, token string) (jwt.MapClaims, error) {
 	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if r.keys == nil {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, repo.ErrInvalidToken
+			}
+			return []byte(r.conf.JwtSecret), nil
+		}
+		kid, _ := token.Header[HeaderKeyId].(string)
+		key := r.keys.verifying(ctx, kid)
+		if key == nil || token.Method.Alg() != key.method.Alg() {
 			return nil, repo.ErrInvalidToken
 		}
-		return []byte(r.conf.JwtSecret), nil
+		return key.public, nil
 	})
 
 	if err != nil {
@@ -60,14 +170,41 @@ func (r *JwtMemRepo) ValidateToken(ctx context.Context, token string) (repo.Toke
 	if !ok {
 		return nil, repo.ErrInvalidToken
 	}
+	return claims, nil
+}
 
-	tokenClaims := make(repo.TokenClaims)
-	for key, value := range claims {
-		if v, ok := value.(string); ok {
-			tokenClaims[key] = v
-		}
+func (r *JwtMemRepo) checkRevoked(ctx context.Context, token string, claims jwt.MapClaims) error {
+	revoked, err := r.revocations.IsTokenRevoked(ctx, tokenId(token, claims))
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return repo.ErrRevokedToken
 	}
 
-	return tokenClaims, nil
+	userId, _ := claims[string(CtxUserId)].(string)
+	if userId == "" {
+		return nil
+	}
+	revokedAt, err := r.revocations.UserRevokedAt(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if revokedAt == 0 {
+		return nil
+	}
+	issuedAt, _ := claims[ClaimIssuedAt].(float64)
+	if int64(math.Round(issuedAt*1000)) <= revokedAt {
+		return repo.ErrRevokedToken
+	}
+	return nil
+}
 
+// tokenId is claim jti, or hash of tokens issued without jti.
+func tokenId(token string, claims jwt.MapClaims) string {
+	if jti, ok := claims[ClaimTokenId].(string); ok && jti != "" {
+		return jti
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,200 @@
+package data
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	DefaultLDAPUserFilter        = "(uid=%s)"
+	DefaultLDAPUsernameAttribute = "uid"
+	DefaultLDAPEmailAttribute    = "mail"
+	DefaultLDAPGroupFilter       = "(member=%s)"
+	DefaultLDAPGroupAttribute    = "cn"
+	DefaultLDAPMemberAttribute   = "member"
+
+	ldapTimeout  = 10 * time.Second
+	ldapPageSize = 500
+)
+
+// LDAPProvider authenticates users by binding to LDAP or Active Directory.
+// a new connection is used for every call, a bind changes the identity of it.
+type LDAPProvider struct {
+	conf *conf.LDAP
+}
+
+// NewLDAPProvider returns nil if ldap is not configured.
+func NewLDAPProvider(c *conf.Admin) repo.DirectoryProvider {
+	if c.GetLdap().GetUrl() == "" {
+		return nil
+	}
+	return &LDAPProvider{conf: c.GetLdap()}
+}
+
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+func (p *LDAPProvider) connect() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: p.conf.GetInsecureSkipVerify()}
+	conn, err := ldap.DialURL(p.conf.GetUrl(),
+		ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}),
+		ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, errors.Join(errors.New("connect ldap failed"), err)
+	}
+	conn.SetTimeout(ldapTimeout)
+	if p.conf.GetStartTls() {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Join(errors.New("ldap start tls failed"), err)
+		}
+	}
+	if err := p.bindService(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// bindService binds as the service account, anonymous if bind dn is empty.
+func (p *LDAPProvider) bindService(conn *ldap.Conn) error {
+	var err error
+	if p.conf.GetBindDn() == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(p.conf.GetBindDn(), p.conf.GetBindPassword())
+	}
+	if err != nil {
+		return errors.Join(errors.New("ldap service bind failed"), err)
+	}
+	return nil
+}
+
+func (p *LDAPProvider) userAttributes() []string {
+	return []string{
+		orDefault(p.conf.GetUsernameAttribute(), DefaultLDAPUsernameAttribute),
+		orDefault(p.conf.GetEmailAttribute(), DefaultLDAPEmailAttribute),
+	}
+}
+
+func (p *LDAPProvider) toUser(entry *ldap.Entry) *repo.DirectoryUser {
+	attrs := p.userAttributes()
+	return &repo.DirectoryUser{
+		DN:       entry.DN,
+		UserName: entry.GetAttributeValue(attrs[0]),
+		Email:    entry.GetAttributeValue(attrs[1]),
+	}
+}
+
+func (p *LDAPProvider) Authenticate(ctx context.Context, username, password string) (*repo.DirectoryUser, error) {
+	// an empty password is an unauthenticated bind, which always succeeds
+	if username == "" || password == "" {
+		return nil, repo.ErrInvalidCredentials
+	}
+	conn, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf(orDefault(p.conf.GetUserFilter(), DefaultLDAPUserFilter), ldap.EscapeFilter(username))
+	result, err := conn.Search(ldap.NewSearchRequest(p.conf.GetUserBaseDn(),
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		filter, p.userAttributes(), nil))
+	if err != nil {
+		return nil, errors.Join(errors.New("search ldap user failed"), err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, repo.ErrInvalidCredentials
+	}
+	user := p.toUser(result.Entries[0])
+
+	if err := conn.Bind(user.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, repo.ErrInvalidCredentials
+		}
+		return nil, errors.Join(errors.New("ldap user bind failed"), err)
+	}
+	if p.conf.GetGroupBaseDn() == "" {
+		return user, nil
+	}
+
+	// groups may not be readable by the user
+	if err := p.bindService(conn); err != nil {
+		return nil, err
+	}
+	groupAttr := orDefault(p.conf.GetGroupAttribute(), DefaultLDAPGroupAttribute)
+	filter = fmt.Sprintf(orDefault(p.conf.GetGroupFilter(), DefaultLDAPGroupFilter), ldap.EscapeFilter(user.DN))
+	groups, err := conn.Search(ldap.NewSearchRequest(p.conf.GetGroupBaseDn(),
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{groupAttr}, nil))
+	if err != nil {
+		return nil, errors.Join(errors.New("search ldap groups failed"), err)
+	}
+	for _, entry := range groups.Entries {
+		user.Groups = append(user.Groups, entry.GetAttributeValue(groupAttr))
+	}
+	return user, nil
+}
+
+func (p *LDAPProvider) ListUsers(ctx context.Context) ([]*repo.DirectoryUser, error) {
+	conn, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf(orDefault(p.conf.GetUserFilter(), DefaultLDAPUserFilter), "*")
+	result, err := conn.SearchWithPaging(ldap.NewSearchRequest(p.conf.GetUserBaseDn(),
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, p.userAttributes(), nil), ldapPageSize)
+	if err != nil {
+		return nil, errors.Join(errors.New("search ldap users failed"), err)
+	}
+	users := make([]*repo.DirectoryUser, 0, len(result.Entries))
+	byDN := map[string]*repo.DirectoryUser{}
+	for _, entry := range result.Entries {
+		user := p.toUser(entry)
+		if user.UserName == "" {
+			continue
+		}
+		users = append(users, user)
+		byDN[strings.ToLower(user.DN)] = user
+	}
+	if p.conf.GetGroupBaseDn() == "" {
+		return users, nil
+	}
+
+	groupAttr := orDefault(p.conf.GetGroupAttribute(), DefaultLDAPGroupAttribute)
+	memberAttr := orDefault(p.conf.GetMemberAttribute(), DefaultLDAPMemberAttribute)
+	filter = fmt.Sprintf(orDefault(p.conf.GetGroupFilter(), DefaultLDAPGroupFilter), "*")
+	groups, err := conn.SearchWithPaging(ldap.NewSearchRequest(p.conf.GetGroupBaseDn(),
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{groupAttr, memberAttr}, nil), ldapPageSize)
+	if err != nil {
+		return nil, errors.Join(errors.New("search ldap groups failed"), err)
+	}
+	for _, entry := range groups.Entries {
+		group := entry.GetAttributeValue(groupAttr)
+		for _, member := range entry.GetAttributeValues(memberAttr) {
+			if user, ok := byDN[strings.ToLower(member)]; ok {
+				user.Groups = append(user.Groups, group)
+			}
+		}
+	}
+	return users, nil
+}
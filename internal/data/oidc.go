@@ -0,0 +1,133 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	DefaultOIDCUsernameClaim = "preferred_username"
+	DefaultOIDCGroupsClaim   = "groups"
+)
+
+// OIDCProvider runs authorization code flow with PKCE against an OIDC provider.
+// the provider is discovered on first use, so the server starts when it is down.
+type OIDCProvider struct {
+	conf *conf.OIDC
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// NewOIDCProvider returns nil if oidc is not configured.
+func NewOIDCProvider(c *conf.Admin) repo.SSOProvider {
+	if c.GetOidc().GetIssuer() == "" {
+		return nil
+	}
+	return &OIDCProvider{conf: c.GetOidc()}
+}
+
+func (p *OIDCProvider) discover(ctx context.Context) (*oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider != nil {
+		return p.provider, nil
+	}
+	provider, err := oidc.NewProvider(ctx, p.conf.GetIssuer())
+	if err != nil {
+		return nil, errors.Join(errors.New("discover oidc provider failed"), err)
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+func (p *OIDCProvider) oauth2Config(provider *oidc.Provider, redirectURI string) *oauth2.Config {
+	scopes := p.conf.GetScopes()
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+	return &oauth2.Config{
+		ClientID:     p.conf.GetClientId(),
+		ClientSecret: p.conf.GetClientSecret(),
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  redirectURI,
+		Scopes:       scopes,
+	}
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(provider, redirectURI).AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, redirectURI, nonce string) (*repo.SSOIdentity, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.oauth2Config(provider, redirectURI).Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, errors.Join(errors.New("exchange code failed"), err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("no id_token in token response")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.conf.GetClientId()}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Join(errors.New("verify id_token failed"), err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	return p.identity(idToken.Subject, claims)
+}
+
+func (p *OIDCProvider) identity(subject string, claims map[string]interface{}) (*repo.SSOIdentity, error) {
+	usernameClaim := p.conf.GetUsernameClaim()
+	if usernameClaim == "" {
+		usernameClaim = DefaultOIDCUsernameClaim
+	}
+	groupsClaim := p.conf.GetGroupsClaim()
+	if groupsClaim == "" {
+		groupsClaim = DefaultOIDCGroupsClaim
+	}
+
+	identity := &repo.SSOIdentity{Subject: subject}
+	identity.UserName, _ = claims[usernameClaim].(string)
+	if identity.UserName == "" {
+		return nil, fmt.Errorf("claim %s is missing in id_token", usernameClaim)
+	}
+	identity.Email, _ = claims["email"].(string)
+	switch groups := claims[groupsClaim].(type) {
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				identity.Groups = append(identity.Groups, s)
+			}
+		}
+	case string:
+		identity.Groups = []string{groups}
+	}
+	return identity, nil
+}
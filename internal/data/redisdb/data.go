@@ -0,0 +1,55 @@
+package redisdb
+
+import (
+	"context"
+	"errors"
+
+	"opspillar/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyPrefix prefixes every key written by opspillar.
+const KeyPrefix = "opspillar:"
+
+var ErrEmptyRedis = errors.New("emptyRedisAddr")
+
+// DataRedis .
+type DataRedis struct {
+	Client redis.UniversalClient
+}
+
+// NewDataRedis .
+func NewDataRedis(c *conf.Data_Redis, logger log.Logger) (*DataRedis, func(), error) {
+	if c.GetAddr() == "" {
+		return nil, func() {}, ErrEmptyRedis
+	}
+	opts := &redis.Options{
+		Network: c.GetNetwork(),
+		Addr:    c.GetAddr(),
+	}
+	if c.GetReadTimeout() != nil {
+		opts.ReadTimeout = c.GetReadTimeout().AsDuration()
+	}
+	if c.GetWriteTimeout() != nil {
+		opts.WriteTimeout = c.GetWriteTimeout().AsDuration()
+	}
+	client := redis.NewClient(opts)
+	cleanup := func() {
+		log.NewHelper(logger).Info("closing the redis resources")
+		client.Close()
+	}
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+	return &DataRedis{Client: client}, cleanup, nil
+}
+
+func validateData(data *DataRedis) error {
+	if data == nil || data.Client == nil {
+		return ErrEmptyRedis
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package redisdb_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"opspillar/internal/data/redisdb"
+	"opspillar/internal/data/repo"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var logger = log.With(log.NewStdLogger(os.Stdout))
+
+func initTokenRevocationRepo(t *testing.T) (*miniredis.Miniredis, repo.TokenRevocationRepo) {
+	mr := miniredis.RunT(t)
+	data := &redisdb.DataRedis{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	r, err := redisdb.NewTokenRevocationRepoRedis(data, logger)
+	assert.NoError(t, err)
+	return mr, r
+}
+
+func TestTokenRevocationRepoRedis_RevokeToken(t *testing.T) {
+	mr, r := initTokenRevocationRepo(t)
+	ctx := context.Background()
+
+	revoked, err := r.IsTokenRevoked(ctx, "jti1")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	assert.NoError(t, r.RevokeToken(ctx, "jti1", time.Now().Add(time.Hour).Unix()))
+	revoked, err = r.IsTokenRevoked(ctx, "jti1")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// the key expires with the token
+	mr.FastForward(2 * time.Hour)
+	revoked, err = r.IsTokenRevoked(ctx, "jti1")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// expired token is not stored
+	assert.NoError(t, r.RevokeToken(ctx, "jti2", time.Now().Add(-time.Hour).Unix()))
+	assert.False(t, mr.Exists(redisdb.KeyPrefix+"revoked_token:jti2"))
+}
+
+func TestTokenRevocationRepoRedis_RevokeUser(t *testing.T) {
+	_, r := initTokenRevocationRepo(t)
+	ctx := context.Background()
+
+	revokedAt, err := r.UserRevokedAt(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), revokedAt)
+
+	assert.NoError(t, r.RevokeUser(ctx, "1", 2000))
+	revokedAt, err = r.UserRevokedAt(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), revokedAt)
+}
+
+func TestNewDataRedis_EmptyAddr(t *testing.T) {
+	_, _, err := redisdb.NewDataRedis(nil, logger)
+	assert.ErrorIs(t, err, redisdb.ErrEmptyRedis)
+}
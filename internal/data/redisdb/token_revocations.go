@@ -0,0 +1,73 @@
+package redisdb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	revokedTokenKey = KeyPrefix + "revoked_token:"
+	revokedUserKey  = KeyPrefix + "revoked_user:"
+)
+
+type TokenRevocationRepoRedis struct {
+	data *DataRedis
+	log  *log.Helper
+}
+
+func NewTokenRevocationRepoRedis(data *DataRedis, logger log.Logger) (repo.TokenRevocationRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	return &TokenRevocationRepoRedis{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// RevokeToken is. the key expires with the token.
+func (d *TokenRevocationRepoRedis) RevokeToken(ctx context.Context, jti string, expiresAt int64) error {
+
+	ttl := time.Until(time.Unix(expiresAt, 0))
+	if ttl <= 0 {
+		return nil
+	}
+	return d.data.Client.Set(ctx, revokedTokenKey+jti, expiresAt, ttl).Err()
+}
+
+// IsTokenRevoked is
+func (d *TokenRevocationRepoRedis) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+
+	n, err := d.data.Client.Exists(ctx, revokedTokenKey+jti).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// RevokeUser sets the cutoff of user in unix milliseconds, replacing an earlier one.
+func (d *TokenRevocationRepoRedis) RevokeUser(ctx context.Context, userId string, revokedAt int64) error {
+
+	return d.data.Client.Set(ctx, revokedUserKey+userId, revokedAt, 0).Err()
+}
+
+// UserRevokedAt returns the cutoff of user in unix milliseconds, 0 if tokens of user were never revoked.
+func (d *TokenRevocationRepoRedis) UserRevokedAt(ctx context.Context, userId string) (int64, error) {
+
+	v, err := d.data.Client.Get(ctx, revokedUserKey+userId).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
@@ -1,15 +1,82 @@
 package repo
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 const UserTable = "users"
 
+// UserSource is where a user comes from.
+const (
+	UserSourceLocal = "local"
+	UserSourceOIDC  = "oidc"
+	UserSourceLDAP  = "ldap"
+)
+
 type User struct {
 	Id       uint32 `gorm:"column:id;primaryKey;autoIncrement"`
 	UserName string `gorm:"column:user_name;not null;unique"`
 	Password string `gorm:"column:password;not null"`
 	Email    string `gorm:"column:email;unique"`
 	Phone    string `gorm:"column:phone;unique"`
+	// Source is local for password users, empty means local
+	Source string `gorm:"column:source;type:varchar(16);default:local"`
+	// ExternalId is the subject of user at the provider
+	ExternalId string `gorm:"column:external_id;type:varchar(255)"`
+	// Disabled users can not login, eg: removed from the directory
+	Disabled bool `gorm:"column:disabled;not null;default:false"`
+	// unix seconds, passwords expire by policy after PasswordChangedAt
+	PasswordChangedAt int64 `gorm:"column:password_changed_at;not null;default:0"`
+	// FailedLogins counts failed logins since the last success or lockout
+	FailedLogins int32 `gorm:"column:failed_logins;not null;default:0"`
+	// LockedUntil is unix seconds, 0 or past is not locked
+	LockedUntil int64 `gorm:"column:locked_until;not null;default:0"`
+	// MFASecret is the base32 TOTP secret, pending until MFAEnabled
+	MFASecret  string `gorm:"column:mfa_secret;type:varchar(64);not null;default:''"`
+	MFAEnabled bool   `gorm:"column:mfa_enabled;not null;default:false"`
+	// MFALastStep is the last accepted TOTP time step, codes are not accepted twice
+	MFALastStep int64 `gorm:"column:mfa_last_step;not null;default:0"`
+}
+
+const PasswordResetTokenTable = "password_reset_tokens"
+
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+// PasswordResetToken is a one-time token issued by admins to reset a password,
+// only sha256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID     uint32 `gorm:"primaryKey;autoIncrement"`
+	UserId uint32 `gorm:"index:idx_password_reset_token_user_id"`
+	Hash   string `gorm:"type:varchar(64);index:idx_password_reset_token_hash,unique"`
+	// unix seconds, UsedAt 0 never used
+	ExpiresAt int64
+	UsedAt    int64
+	CreatedBy string `gorm:"type:varchar(255);"`
+	CreatedAt int64
+}
+
+func (PasswordResetToken) TableName() string {
+	return PasswordResetTokenTable
+}
+
+const MFARecoveryCodeTable = "mfa_recovery_codes"
+
+var ErrInvalidRecoveryCode = errors.New("invalid or used recovery code")
+
+// MFARecoveryCode replaces a TOTP code once when the device is lost, only sha256 hash
+// of the code is stored.
+type MFARecoveryCode struct {
+	ID     uint32 `gorm:"primaryKey;autoIncrement"`
+	UserId uint32 `gorm:"index:idx_mfa_recovery_code_user_id"`
+	Hash   string `gorm:"type:varchar(64);index:idx_mfa_recovery_code_hash,unique"`
+	// unix seconds, UsedAt 0 never used
+	UsedAt    int64
+	CreatedAt int64
+}
+
+func (MFARecoveryCode) TableName() string {
+	return MFARecoveryCodeTable
 }
 
 type UsersFilter struct {
@@ -17,8 +84,10 @@ type UsersFilter struct {
 	UserName []string
 	Email    []string
 	Phone    []string
+	Sources  []string
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 }
 
 func (f *UsersFilter) GetIds() []uint32 {
@@ -33,4 +102,26 @@ type AdminRepo interface {
 	ListUsers(ctx context.Context, tx TX, filter *UsersFilter) ([]*User, error)
 	Logout(ctx context.Context, id uint32) error
 	CountUsers(ctx context.Context, tx TX, filter CountFilter) (int64, error)
+	DisableUsers(ctx context.Context, tx TX, ids []uint32, disabled bool) error
+	// SetPassword changes the password hash and unlocks the user.
+	SetPassword(ctx context.Context, tx TX, id uint32, hash string, changedAt int64) error
+	// AddFailedLogin counts a failed login, the user is locked until lockUntil and counting
+	// restarts when failures reach max. It returns whether the user is locked.
+	AddFailedLogin(ctx context.Context, tx TX, id uint32, max int32, lockUntil int64) (bool, error)
+	ResetFailedLogins(ctx context.Context, tx TX, id uint32) error
+	// CreatePasswordResetToken replaces unused tokens of the user.
+	CreatePasswordResetToken(ctx context.Context, tx TX, token *PasswordResetToken) error
+	// UsePasswordResetToken marks the token of hash used, ErrInvalidResetToken if it is
+	// used, expired at now or missing.
+	UsePasswordResetToken(ctx context.Context, tx TX, hash string, now int64) (*PasswordResetToken, error)
+	// SetMFA sets a pending TOTP secret and replaces recovery codes of the user, empty
+	// secret disables MFA.
+	SetMFA(ctx context.Context, tx TX, id uint32, secret string, codes []*MFARecoveryCode) error
+	// EnableMFA enables the pending secret, it fails if the secret has been replaced.
+	EnableMFA(ctx context.Context, tx TX, id uint32, secret string) error
+	// UseMFAStep accepts a TOTP time step once, it returns false if step is not after the last one.
+	UseMFAStep(ctx context.Context, tx TX, id uint32, step int64) (bool, error)
+	// UseMFARecoveryCode marks the code of hash used, ErrInvalidRecoveryCode if it is used
+	// or not of the user.
+	UseMFARecoveryCode(ctx context.Context, tx TX, id uint32, hash string, now int64) error
 }
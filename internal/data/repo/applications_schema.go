@@ -9,7 +9,8 @@ const ApplicationTable = "applications"
 type Application struct {
 	ChangeInfo
 	Id          uint32 `gorm:"primaryKey;autoIncrement"`
-	Name        string `gorm:"type:varchar(255);index:idx_app_name_env,unique"`
+	OrgId       uint32 `gorm:"index:idx_app_org_name,unique,priority:1"`
+	Name        string `gorm:"type:varchar(255);index:idx_app_org_name,unique,priority:2"`
 	Description string `gorm:"type:varchar(255);"`
 	OwnerId     uint32 `gorm:"column:owner_id"`
 	IsStateful  bool   `gorm:"type:tinyint(1);"`
@@ -20,11 +21,24 @@ type Application struct {
 type ApplicationsFilter struct {
 	Page       uint32
 	PageSize   uint32
+	LastId     uint32
 	Ids        []uint32
+	OrgIds     []uint32
 	Names      []string
 	IsStateful string
 	ProductsId []uint32
 	TeamsId    []uint32
+
+	ChangeFilter
+	NameMatch         string
+	Sort              ListSort
+	ExcludeTeamsId    []uint32
+	ExcludeProductsId []uint32
+	// NoTags and NoFeatures select rows without tags or features
+	NoTags     bool
+	NoFeatures bool
+	// Selections must all match
+	Selections []Selection
 }
 
 const IsStatefulTrue = "true"
@@ -0,0 +1,75 @@
+package repo
+
+import "context"
+
+const AttributeTable = "attributes"
+const AttributeValueTable = "attribute_values"
+
+// kinds of entities with custom attributes.
+const (
+	AttrKindApplication = "applications"
+	AttrKindHostgroup   = "hostgroups"
+)
+
+// types of custom attribute values.
+const (
+	AttrTypeString = "string"
+	AttrTypeInt    = "int"
+	AttrTypeBool   = "bool"
+	AttrTypeEnum   = "enum"
+)
+
+// Attribute defines a custom attribute of an entity kind, values are checked against it.
+type Attribute struct {
+	ID       uint32 `gorm:"primaryKey;autoIncrement"`
+	Kind     string `gorm:"type:varchar(64);index:idx_attr_kind_name,unique,priority:1"`
+	Name     string `gorm:"type:varchar(255);index:idx_attr_kind_name,unique,priority:2"`
+	Type     string `gorm:"type:varchar(32);"`
+	Required bool
+	// EnumValues are the allowed values of enum attributes separated by comma
+	EnumValues string `gorm:"type:varchar(1024);"`
+	// Pattern is a regular expression values must match
+	Pattern     string `gorm:"type:varchar(255);"`
+	Description string `gorm:"type:varchar(255);"`
+}
+
+type AttributesFilter struct {
+	Page     uint32
+	PageSize uint32
+	LastId   uint32
+	Ids      []uint32
+	Kinds    []string
+	Names    []string
+}
+
+func (f *AttributesFilter) GetIds() []uint32 {
+	return f.Ids
+}
+
+// AttributeValue is the value of a custom attribute of an entity.
+type AttributeValue struct {
+	ID       uint32 `gorm:"primaryKey;autoIncrement"`
+	Kind     string `gorm:"type:varchar(64);index:idx_attr_value_entity,unique,priority:1;index:idx_attr_value_name,priority:1"`
+	EntityId uint32 `gorm:"index:idx_attr_value_entity,unique,priority:2"`
+	Name     string `gorm:"type:varchar(255);index:idx_attr_value_entity,unique,priority:3;index:idx_attr_value_name,priority:2"`
+	Value    string `gorm:"type:varchar(255);index:idx_attr_value_name,priority:3"`
+}
+
+type AttributeValuesFilter struct {
+	Kind      string
+	EntityIds []uint32
+	Names     []string
+	Values    []string
+}
+
+type AttributesRepo interface {
+	CreateAttributes(ctx context.Context, tx TX, attrs []*Attribute) error
+	UpdateAttributes(ctx context.Context, tx TX, attrs []*Attribute) error
+	DeleteAttributes(ctx context.Context, tx TX, ids []uint32) error
+	GetAttributes(ctx context.Context, id uint32) (*Attribute, error)
+	ListAttributes(ctx context.Context, tx TX, filter *AttributesFilter) ([]*Attribute, error)
+	// SetAttributeValues replaces the values of entity of kind.
+	SetAttributeValues(ctx context.Context, tx TX, kind string, entityId uint32, values []*AttributeValue) error
+	ListAttributeValues(ctx context.Context, tx TX, filter *AttributeValuesFilter) ([]*AttributeValue, error)
+	DeleteAttributeValues(ctx context.Context, tx TX, filter *AttributeValuesFilter) error
+}
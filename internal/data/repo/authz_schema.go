@@ -6,7 +6,14 @@ import (
 	"strings"
 )
 
-const ActWrite = "write"
+const (
+	ActWrite = "write"
+	// ActRead is implied by ActWrite, see configs/rbac_model.conf.
+	ActRead = "read"
+)
+
+// SubEveryone as the subject of a rule matches every user and service account.
+const SubEveryone = "*"
 
 type Group struct {
 	User string
@@ -39,27 +46,48 @@ type AuthenRequest struct {
 	Action   string
 }
 
+// Explanation tells why a request is allowed. Rule is the matched rule, nil if denied.
+// Chain is the groups from the subject of request to the subject of Rule, Roles are
+// all roles of the subject.
+type Explanation struct {
+	Allowed bool
+	Rule    *Rule
+	Chain   []string
+	Roles   []string
+}
+
 type AuthzRepo interface {
 	CreateRule(ctx context.Context, tx TX, policy *Rule) error
 	DeleteRule(ctx context.Context, tx TX, policy *Rule) error
 	ListRule(ctx context.Context, tx TX, filter *RuleFilter) ([]*Rule, error)
 	Enforce(ctx context.Context, tx TX, request *AuthenRequest) (bool, error)
+	// BatchEnforce answers all requests with the same policies.
+	BatchEnforce(ctx context.Context, tx TX, requests []*AuthenRequest) ([]bool, error)
+	Explain(ctx context.Context, tx TX, request *AuthenRequest) (*Explanation, error)
 	CreateGroup(ctx context.Context, tx TX, role *Group) error
 	DeleteGroup(ctx context.Context, tx TX, role *Group) error
 	ListGroup(ctx context.Context, tx TX, filter *GroupFilter) ([]*Group, error)
 }
 
 // Resource4Sv1 is a resource string for casbin.
-// including 4 sections as resource type, team name, resource instance and user name
+// including 4 sections as resource type, team name, resource instance and user name,
+// scoped by the organization of the team
 type Resource4Sv1 struct {
+	OrgName  string
 	ResType  string
 	TeamName string
 	ResInst  string
 	UserName string
 }
 
+// NewResource4Sv1 is a resource of any organization, as lookups shared by organizations are.
 func NewResource4Sv1(resType, teamName, resInst, userName string) IResource {
+	return NewOrgResource4Sv1("", resType, teamName, resInst, userName)
+}
+
+func NewOrgResource4Sv1(orgName, resType, teamName, resInst, userName string) IResource {
 	return &Resource4Sv1{
+		OrgName:  orgName,
 		ResType:  resType,
 		TeamName: teamName,
 		ResInst:  resInst,
@@ -69,11 +97,17 @@ func NewResource4Sv1(resType, teamName, resInst, userName string) IResource {
 
 func (r *Resource4Sv1) ResourceStr() string {
 	str := &strings.Builder{}
-	if r.ResType != "" {
+	if r.OrgName != "" {
 		str.WriteString("v1/")
+		str.WriteString(r.OrgName)
+	} else {
+		str.WriteString("v1/{org}")
+	}
+	if r.ResType != "" {
+		str.WriteString("/")
 		str.WriteString(r.ResType)
 	} else {
-		str.WriteString("v1/{resource}")
+		str.WriteString("/{resource}")
 	}
 	if r.TeamName != "" {
 		str.WriteString("/")
@@ -97,22 +131,85 @@ func (r *Resource4Sv1) ResourceStr() string {
 }
 
 func (r *Resource4Sv1) ParseStr(raw string) error {
-	_raw := strings.TrimLeft(raw, "v1/")
+	_raw, ok := strings.CutPrefix(raw, "v1/")
+	if !ok {
+		return errors.New("invalid resource string")
+	}
 	parts := strings.Split(_raw, "/")
-	if len(parts) != 4 {
+	if len(parts) != 5 {
 		return errors.New("invalid resource string")
 	}
-	if parts[0] != "" && parts[0] != "{resource}" {
-		r.ResType = parts[0]
+	if parts[0] != "" && parts[0] != "{org}" {
+		r.OrgName = parts[0]
+	}
+	if parts[1] != "" && parts[1] != "{resource}" {
+		r.ResType = parts[1]
 	}
-	if parts[1] != "" && parts[1] != "{team}" {
-		r.TeamName = parts[1]
+	if parts[2] != "" && parts[2] != "{team}" {
+		r.TeamName = parts[2]
 	}
-	if parts[2] != "" && parts[2] != "{resource_id}" {
-		r.ResInst = parts[2]
+	if parts[3] != "" && parts[3] != "{resource_id}" {
+		r.ResInst = parts[3]
 	}
-	if parts[3] != "" && parts[3] != "{user}" {
-		r.UserName = parts[3]
+	if parts[4] != "" && parts[4] != "{user}" {
+		r.UserName = parts[4]
 	}
 	return nil
 }
+
+const AuthzRevisionTable = "authz_revisions"
+
+// AuthzRevision is the single row counting changes of rules and groups, instances
+// reload their policies when it moves.
+type AuthzRevision struct {
+	ID        uint32 `gorm:"primaryKey"`
+	Revision  int64
+	UpdatedAt int64
+}
+
+func (AuthzRevision) TableName() string {
+	return AuthzRevisionTable
+}
+
+const AuthzAuditTable = "authz_audits"
+
+// operations of authz audits
+const (
+	AuthzOpGrant       = "grant"
+	AuthzOpRevoke      = "revoke"
+	AuthzOpAddGroup    = "add_group"
+	AuthzOpRemoveGroup = "remove_group"
+)
+
+// AuthzAudit records a change of rules or groups. Role is set for group changes,
+// Resource and Action for rule changes.
+type AuthzAudit struct {
+	ID        uint32 `gorm:"primaryKey;autoIncrement"`
+	Operator  string `gorm:"type:varchar(255);"`
+	Operation string `gorm:"type:varchar(32);"`
+	Sub       string `gorm:"type:varchar(255);index:idx_authz_audit_sub"`
+	Resource  string `gorm:"type:varchar(255);"`
+	Action    string `gorm:"type:varchar(32);"`
+	Role      string `gorm:"type:varchar(255);"`
+	CreatedAt int64  `gorm:"index:idx_authz_audit_created_at"`
+}
+
+func (AuthzAudit) TableName() string {
+	return AuthzAuditTable
+}
+
+type AuthzAuditsFilter struct {
+	Subs      []string
+	Operators []string
+	// Since is unix seconds, 0 means all
+	Since    int64
+	Page     uint32
+	PageSize uint32
+	LastId   uint32
+}
+
+type AuthzAuditsRepo interface {
+	CreateAuthzAudits(ctx context.Context, tx TX, audits []*AuthzAudit) error
+	// ListAuthzAudits lists audits, newest first.
+	ListAuthzAudits(ctx context.Context, tx TX, filter *AuthzAuditsFilter) ([]*AuthzAudit, error)
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	BreakGlassTable      = "break_glass_credentials"
+	BreakGlassAuditTable = "break_glass_audits"
+)
+
+var ErrExpiredBreakGlass = errors.New("expired break-glass credential")
+
+// BreakGlass is a pre-issued emergency credential acting as Principal.
+// only sha256 hash of the secret is stored.
+type BreakGlass struct {
+	ID   uint32 `gorm:"primaryKey;autoIncrement"`
+	Name string `gorm:"type:varchar(255);index:idx_break_glass_name,unique"`
+	// Principal is the user name requests are made as
+	Principal string `gorm:"type:varchar(255);"`
+	Prefix    string `gorm:"type:varchar(16);"`
+	Hash      string `gorm:"type:varchar(64);index:idx_break_glass_hash,unique"`
+	// OneTime credential is valid for its first request only
+	OneTime bool
+	// ExpireMinutes expires credential minutes after its first use, 0 disables
+	ExpireMinutes uint32
+	Description   string `gorm:"type:varchar(255);"`
+	// unix seconds, ExpiresAt 0 never expires, FirstUsedAt 0 never used
+	ExpiresAt   int64
+	FirstUsedAt int64
+	CreatedBy   string `gorm:"type:varchar(255);"`
+	CreatedAt   int64
+}
+
+func (BreakGlass) TableName() string {
+	return BreakGlassTable
+}
+
+// BreakGlassAudit records every use of a break-glass credential, allowed or denied.
+type BreakGlassAudit struct {
+	ID           uint32 `gorm:"primaryKey;autoIncrement"`
+	CredentialId uint32 `gorm:"index:idx_break_glass_audit_credential_id"`
+	Name         string `gorm:"type:varchar(255);"`
+	Principal    string `gorm:"type:varchar(255);"`
+	Operation    string `gorm:"type:varchar(255);"`
+	IP           string `gorm:"column:ip;type:varchar(64);"`
+	Allowed      bool
+	// Reason of denial
+	Reason    string `gorm:"type:varchar(255);"`
+	CreatedAt int64  `gorm:"index:idx_break_glass_audit_created_at"`
+}
+
+func (BreakGlassAudit) TableName() string {
+	return BreakGlassAuditTable
+}
+
+type BreakGlassFilter struct {
+	Ids      []uint32
+	Names    []string
+	Hashes   []string
+	Page     uint32
+	PageSize uint32
+}
+
+type BreakGlassAuditsFilter struct {
+	CredentialIds []uint32
+	// Since is unix seconds, 0 means all
+	Since    int64
+	Page     uint32
+	PageSize uint32
+	LastId   uint32
+}
+
+type BreakGlassRepo interface {
+	CreateBreakGlass(ctx context.Context, tx TX, creds []*BreakGlass) error
+	DeleteBreakGlass(ctx context.Context, tx TX, ids []uint32) error
+	ListBreakGlass(ctx context.Context, tx TX, filter *BreakGlassFilter) ([]*BreakGlass, error)
+	// ActivateBreakGlass records the first use, false if it was used before.
+	ActivateBreakGlass(ctx context.Context, tx TX, id uint32, usedAt int64) (bool, error)
+	CreateBreakGlassAudits(ctx context.Context, tx TX, audits []*BreakGlassAudit) error
+	// ListBreakGlassAudits lists audits, newest first.
+	ListBreakGlassAudits(ctx context.Context, tx TX, filter *BreakGlassAuditsFilter) ([]*BreakGlassAudit, error)
+}
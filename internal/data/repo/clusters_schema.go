@@ -13,6 +13,7 @@ type Cluster struct {
 type ClustersFilter struct {
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 	Names    []string
 	Ids      []uint32
 }
@@ -13,6 +13,7 @@ type Datacenter struct {
 type DatacentersFilter struct {
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 	Ids      []uint32
 	Names    []string
 }
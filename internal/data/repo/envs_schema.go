@@ -13,6 +13,7 @@ type Env struct {
 type EnvsFilter struct {
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 	Names    []string
 	Ids      []uint32
 }
@@ -14,6 +14,7 @@ type Feature struct {
 type FeaturesFilter struct {
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 	Ids      []uint32
 	Names    []string
 	Kvs      []string
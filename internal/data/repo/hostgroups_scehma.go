@@ -9,7 +9,8 @@ const HostgroupTable = "hostgroups"
 type Hostgroup struct {
 	ChangeInfo
 	Id           uint32 `gorm:"primaryKey;autoIncrement"`
-	Name         string `gorm:"type:varchar(255);index:idx_hg_name,unique"`
+	OrgId        uint32 `gorm:"index:idx_hg_org_name,unique,priority:1"`
+	Name         string `gorm:"type:varchar(255);index:idx_hg_org_name,unique,priority:2"`
 	Description  string `gorm:"type:varchar(255);"`
 	ClusterId    uint32
 	DatacenterId uint32
@@ -21,13 +22,26 @@ type Hostgroup struct {
 type HostgroupsFilter struct {
 	Page          uint32
 	PageSize      uint32
+	LastId        uint32
 	Ids           []uint32
+	OrgIds        []uint32
 	Names         []string
 	ClustersId    []uint32
 	DatacentersId []uint32
 	EnvsId        []uint32
 	ProductsId    []uint32
 	TeamsId       []uint32
+
+	ChangeFilter
+	NameMatch         string
+	Sort              ListSort
+	ExcludeTeamsId    []uint32
+	ExcludeProductsId []uint32
+	// NoTags and NoFeatures select rows without tags or features
+	NoTags     bool
+	NoFeatures bool
+	// Selections must all match
+	Selections []Selection
 }
 
 func (f *HostgroupsFilter) GetIds() []uint32 {
@@ -31,10 +31,17 @@ const (
 	RequireCluster    RequireType = "cluster"
 	RequireApp        RequireType = "app"
 	RequireUser       RequireType = "user"
+	RequireOrg        RequireType = "org"
 )
 
 var ErrorRequireIds = errors.New("invalid require ids or types")
 
+// ErrNotFound, ErrDuplicated and ErrReferenced are matched by database errors of missing rows,
+// rows duplicating a unique key and rows breaking a foreign key.
+var ErrNotFound = errors.New("not found")
+var ErrDuplicated = errors.New("duplicated")
+var ErrReferenced = errors.New("referenced")
+
 type RequireCounter interface {
 	CountRequire(ctx context.Context, tx TX, need RequireType, ids []uint32) (int64, error)
 }
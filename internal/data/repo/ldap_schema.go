@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrDirectoryNotConfigured = errors.New("directory not configured")
+	ErrInvalidCredentials     = errors.New("invalid credentials")
+)
+
+// DirectoryUser is a user entry of LDAP or Active Directory.
+type DirectoryUser struct {
+	// DN is unique in the directory
+	DN       string
+	UserName string
+	Email    string
+	Groups   []string
+}
+
+// DirectoryProvider authenticates users against a directory and lists them for sync.
+type DirectoryProvider interface {
+	// Authenticate binds as the user, ErrInvalidCredentials if user is not found or password is wrong.
+	Authenticate(ctx context.Context, username, password string) (*DirectoryUser, error)
+	// ListUsers returns all users with their groups.
+	ListUsers(ctx context.Context) ([]*DirectoryUser, error)
+}
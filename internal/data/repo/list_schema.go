@@ -0,0 +1,52 @@
+package repo
+
+// Name matches of list filters, names contain the filter value when empty.
+const (
+	NameMatchPrefix = "prefix"
+	NameMatchExact  = "exact"
+)
+
+// Columns lists are sorted by, lists are sorted by id when empty.
+const (
+	SortName      = "name"
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+)
+
+// ListSort orders a list by a column, then by id.
+type ListSort struct {
+	By   string
+	Desc bool
+	// After is the By value of the last row of the previous page, LastId of the filter is its id
+	After interface{}
+}
+
+// ChangeFilter selects rows by change times, zero bounds are not checked. After bounds are
+// inclusive, before bounds are exclusive.
+type ChangeFilter struct {
+	CreatedAfter  int64
+	CreatedBefore int64
+	UpdatedAfter  int64
+	UpdatedBefore int64
+}
+
+// Fields of selections, label selectors of lists resolve to selections.
+const (
+	SelectTeam       = "team"
+	SelectProduct    = "product"
+	SelectCluster    = "cluster"
+	SelectDatacenter = "datacenter"
+	SelectEnv        = "env"
+	SelectHostgroup  = "hostgroup"
+	SelectTag        = "tag"
+	SelectFeature    = "feature"
+)
+
+// Selection selects rows whose Field is one of Ids, or none of them when Exclude. Fields of
+// related rows select rows related to any of Ids. An empty Ids selects nothing, or excludes
+// nothing.
+type Selection struct {
+	Field   string
+	Ids     []uint32
+	Exclude bool
+}
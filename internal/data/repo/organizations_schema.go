@@ -0,0 +1,40 @@
+package repo
+
+import "context"
+
+const OrganizationTable = "organizations"
+
+// the default organization owns teams and products created before organizations,
+// it can not be renamed or deleted.
+const (
+	DefaultOrgId   uint32 = 1
+	DefaultOrgName        = "default"
+)
+
+// Organization is a namespace of teams, products and their hostgroups and applications,
+// names of them are unique in an organization.
+type Organization struct {
+	ID          uint32 `gorm:"primaryKey;autoIncrement"`
+	Name        string `gorm:"type:varchar(255);index:idx_org_name,unique"`
+	Description string `gorm:"type:varchar(255);"`
+}
+
+type OrganizationsFilter struct {
+	Page     uint32
+	PageSize uint32
+	LastId   uint32
+	Names    []string
+	Ids      []uint32
+}
+
+func (f *OrganizationsFilter) GetIds() []uint32 {
+	return f.Ids
+}
+
+type OrganizationsRepo interface {
+	CreateOrganizations(ctx context.Context, tx TX, orgs []*Organization) error
+	UpdateOrganizations(ctx context.Context, tx TX, orgs []*Organization) error
+	DeleteOrganizations(ctx context.Context, tx TX, ids []uint32) error
+	GetOrganizations(ctx context.Context, id uint32) (*Organization, error)
+	ListOrganizations(ctx context.Context, tx TX, filter *OrganizationsFilter) ([]*Organization, error)
+}
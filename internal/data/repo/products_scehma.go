@@ -6,17 +6,20 @@ const ProductTable = "products"
 
 type Product struct {
 	ID          uint32 `gorm:"primaryKey;autoIncrement"`
-	Name        string `gorm:"type:varchar(255);index:idx_product_name,unique"`
-	Code        string `gorm:"type:varchar(255);index:idx_product_code,unique"`
+	OrgId       uint32 `gorm:"index:idx_product_org_name,unique,priority:1;index:idx_product_org_code,unique,priority:1"`
+	Name        string `gorm:"type:varchar(255);index:idx_product_org_name,unique,priority:2"`
+	Code        string `gorm:"type:varchar(255);index:idx_product_org_code,unique,priority:2"`
 	Description string `gorm:"type:varchar(255);"`
 }
 
 type ProductsFilter struct {
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 	Names    []string
 	Codes    []string
 	Ids      []uint32
+	OrgIds   []uint32
 }
 
 func (f *ProductsFilter) GetIds() []uint32 {
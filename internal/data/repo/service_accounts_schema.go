@@ -0,0 +1,34 @@
+package repo
+
+import "context"
+
+const ServiceAccountTable = "service_accounts"
+
+// ServiceAccount is a non-login principal for automation, authenticated by api keys.
+// it is bound to a team and optionally to a casbin role.
+type ServiceAccount struct {
+	ID          uint32 `gorm:"primaryKey;autoIncrement"`
+	Name        string `gorm:"type:varchar(255);index:idx_service_account_name,unique"`
+	TeamId      uint32 `gorm:"index:idx_service_account_team_id"`
+	Role        string `gorm:"type:varchar(255);"`
+	Description string `gorm:"type:varchar(255);"`
+	CreatedBy   string `gorm:"type:varchar(255);"`
+	CreatedAt   int64
+}
+
+type ServiceAccountsFilter struct {
+	Ids      []uint32
+	Names    []string
+	TeamIds  []uint32
+	Page     uint32
+	PageSize uint32
+	LastId   uint32
+}
+
+type ServiceAccountsRepo interface {
+	CreateServiceAccounts(ctx context.Context, tx TX, accounts []*ServiceAccount) error
+	DeleteServiceAccounts(ctx context.Context, tx TX, ids []uint32) error
+	GetServiceAccounts(ctx context.Context, tx TX, id uint32) (*ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context, tx TX, filter *ServiceAccountsFilter) ([]*ServiceAccount, error)
+	CountRequire(ctx context.Context, tx TX, need RequireType, ids []uint32) (int64, error)
+}
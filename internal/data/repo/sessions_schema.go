@@ -0,0 +1,39 @@
+package repo
+
+import "context"
+
+const SessionTable = "sessions"
+
+// Session is a login of a user, refreshed by a rotating refresh token.
+// only sha256 hashes of refresh tokens are stored.
+type Session struct {
+	Id       uint32 `gorm:"column:id;primaryKey;autoIncrement"`
+	UserId   uint32 `gorm:"column:user_id;index:idx_session_user_id"`
+	Hash     string `gorm:"column:hash;type:varchar(64);index:idx_session_hash,unique"`
+	PrevHash string `gorm:"column:prev_hash;type:varchar(64);index:idx_session_prev_hash"`
+	Device   string `gorm:"column:device;type:varchar(255)"`
+	IP       string `gorm:"column:ip;type:varchar(64)"`
+	// unix seconds
+	CreatedAt  int64 `gorm:"column:created_at"`
+	LastUsedAt int64 `gorm:"column:last_used_at"`
+	ExpiresAt  int64 `gorm:"column:expires_at"`
+}
+
+type SessionsFilter struct {
+	Ids     []uint32
+	UserIds []uint32
+	// Hashes matches current or previous refresh token hash
+	Hashes   []string
+	Page     uint32
+	PageSize uint32
+}
+
+type SessionsRepo interface {
+	CreateSessions(ctx context.Context, tx TX, sessions []*Session) error
+	UpdateSessions(ctx context.Context, tx TX, sessions []*Session) error
+	// RotateSession updates session only if its hash is still oldHash, false if it was rotated meanwhile
+	RotateSession(ctx context.Context, tx TX, session *Session, oldHash string) (bool, error)
+	DeleteSessions(ctx context.Context, tx TX, ids []uint32) error
+	DeleteUserSessions(ctx context.Context, tx TX, userIds []uint32) error
+	ListSessions(ctx context.Context, tx TX, filter *SessionsFilter) ([]*Session, error)
+}
@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrSSONotConfigured = errors.New("single sign-on not configured")
+
+// SSOIdentity is the user verified by a single sign-on provider.
+type SSOIdentity struct {
+	// Subject is unique and stable at the provider
+	Subject  string
+	UserName string
+	Email    string
+	Groups   []string
+}
+
+// SSOProvider runs authorization code flow with PKCE against an identity provider.
+type SSOProvider interface {
+	// AuthCodeURL returns the url to redirect user to.
+	AuthCodeURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems code for tokens and verifies the id token.
+	Exchange(ctx context.Context, code, codeVerifier, redirectURI, nonce string) (*SSOIdentity, error)
+}
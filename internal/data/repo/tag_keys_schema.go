@@ -0,0 +1,40 @@
+package repo
+
+import "context"
+
+const TagKeyTable = "tag_keys"
+
+// TagKey governs tags of a key, the values they may have and the entity kinds using them.
+type TagKey struct {
+	ID          uint32 `gorm:"primaryKey;autoIncrement"`
+	Key         string `gorm:"type:varchar(255);uniqueIndex:idx_tag_key"`
+	Description string `gorm:"type:varchar(255);"`
+	// AllowedValues are values of tags of the key separated by comma, any if empty
+	AllowedValues string `gorm:"type:varchar(1024);"`
+	// Pattern is a regular expression values must match
+	Pattern string `gorm:"type:varchar(255);"`
+	// Kinds are entity kinds tags of the key are used on separated by comma, all if empty
+	Kinds string `gorm:"type:varchar(255);"`
+	// Required tags of the key must be on entities of kinds
+	Required bool
+}
+
+type TagKeysFilter struct {
+	Page     uint32
+	PageSize uint32
+	LastId   uint32
+	Ids      []uint32
+	Keys     []string
+}
+
+func (f *TagKeysFilter) GetIds() []uint32 {
+	return f.Ids
+}
+
+type TagKeysRepo interface {
+	CreateTagKeys(ctx context.Context, tx TX, keys []*TagKey) error
+	UpdateTagKeys(ctx context.Context, tx TX, keys []*TagKey) error
+	DeleteTagKeys(ctx context.Context, tx TX, ids []uint32) error
+	GetTagKeys(ctx context.Context, id uint32) (*TagKey, error)
+	ListTagKeys(ctx context.Context, tx TX, filter *TagKeysFilter) ([]*TagKey, error)
+}
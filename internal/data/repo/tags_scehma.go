@@ -14,6 +14,7 @@ type Tag struct {
 type TagsFilter struct {
 	Page     uint32
 	PageSize uint32
+	LastId   uint32
 	Keys     []string
 	Kvs      []string
 	Ids      []uint32
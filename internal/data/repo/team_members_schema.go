@@ -0,0 +1,40 @@
+package repo
+
+import "context"
+
+const TeamMemberTable = "team_members"
+
+// roles of team members, a leader is also a maintainer and a maintainer is also a member.
+const (
+	TeamRoleMember     = "member"
+	TeamRoleMaintainer = "maintainer"
+	TeamRoleLeader     = "leader"
+)
+
+type TeamMember struct {
+	ID        uint32 `gorm:"primaryKey;autoIncrement"`
+	TeamId    uint32 `gorm:"index:idx_team_member_team_id_user_id,unique"`
+	UserId    uint32 `gorm:"index:idx_team_member_team_id_user_id,unique;index:idx_team_member_user_id"`
+	Role      string `gorm:"type:varchar(32);"`
+	CreatedAt int64
+}
+
+func (TeamMember) TableName() string {
+	return TeamMemberTable
+}
+
+type TeamMembersFilter struct {
+	TeamIds []uint32
+	UserIds []uint32
+	Roles   []string
+}
+
+type TeamMembersRepo interface {
+	RequireCounter
+	CreateTeamMembers(ctx context.Context, tx TX, members []*TeamMember) error
+	// UpdateTeamMembers updates roles of members.
+	UpdateTeamMembers(ctx context.Context, tx TX, members []*TeamMember) error
+	DeleteTeamMembers(ctx context.Context, tx TX, ids []uint32) error
+	DeleteTeamMembersByTeamId(ctx context.Context, tx TX, teamIds []uint32) error
+	ListTeamMembers(ctx context.Context, tx TX, filter *TeamMembersFilter) ([]*TeamMember, error)
+}
@@ -6,8 +6,9 @@ const TeamTable = "teams"
 
 type Team struct {
 	ID          uint32 `gorm:"primaryKey;autoIncrement"`
-	Name        string `gorm:"type:varchar(255);index:idx_team_name,unique"`
-	Code        string `gorm:"type:varchar(255);index:idx_team_code,unique"`
+	OrgId       uint32 `gorm:"index:idx_team_org_name,unique,priority:1;index:idx_team_org_code,unique,priority:1"`
+	Name        string `gorm:"type:varchar(255);index:idx_team_org_name,unique,priority:2"`
+	Code        string `gorm:"type:varchar(255);index:idx_team_org_code,unique,priority:2"`
 	LeaderId    uint32 `gorm:"index:idx_team_leader_id"`
 	Description string `gorm:"type:varchar(255);"`
 }
@@ -15,7 +16,9 @@ type Team struct {
 type TeamsFilter struct {
 	Page      uint32
 	PageSize  uint32
+	LastId    uint32
 	Ids       []uint32
+	OrgIds    []uint32
 	Names     []string
 	LeadersId []uint32
 	Codes     []string
@@ -13,21 +13,142 @@ var (
 	ErrExpiredToken = errors.New("expired token")
 )
 
-// type ApiKey struct {
-// 	ID          uint32 `gorm:"primaryKey;autoIncrement"`
-// 	Key         string `gorm:"type:varchar(255);unique"`
-// 	Description string `gorm:"type:varchar(255);"`
-// }
-
-// type ApiKeysFilter struct {
-// 	Ids      []uint32
-// 	Keys     []string
-// 	Page     uint32
-// 	PageSize uint32
-// }
+const ApiKeyTable = "api_keys"
+
+var ErrExpiredApiKey = errors.New("expired api key")
+var ErrApiKeyScope = errors.New("api key scope denied")
+
+// ApiKey is a credential of a service account. only sha256 hash of the key is stored.
+type ApiKey struct {
+	ID               uint32 `gorm:"primaryKey;autoIncrement"`
+	ServiceAccountId uint32 `gorm:"index:idx_api_key_service_account_id"`
+	Name             string `gorm:"type:varchar(255);"`
+	// Prefix is the beginning of the key to recognize it
+	Prefix string `gorm:"type:varchar(16);"`
+	Hash   string `gorm:"type:varchar(64);index:idx_api_key_hash,unique"`
+	// Scopes are separated by comma
+	Scopes      string `gorm:"type:varchar(1024);"`
+	Description string `gorm:"type:varchar(255);"`
+	// unix seconds, ExpiresAt 0 never expires
+	ExpiresAt  int64
+	LastUsedAt int64
+	CreatedAt  int64
+}
+
+type ApiKeysFilter struct {
+	Ids               []uint32
+	ServiceAccountIds []uint32
+	Hashes            []string
+	Page              uint32
+	PageSize          uint32
+}
+
+type ApiKeysRepo interface {
+	CreateApiKeys(ctx context.Context, tx TX, keys []*ApiKey) error
+	DeleteApiKeys(ctx context.Context, tx TX, ids []uint32) error
+	DeleteServiceAccountApiKeys(ctx context.Context, tx TX, serviceAccountIds []uint32) error
+	ListApiKeys(ctx context.Context, tx TX, filter *ApiKeysFilter) ([]*ApiKey, error)
+	// TouchApiKey records last used time
+	TouchApiKey(ctx context.Context, id uint32, lastUsedAt int64) error
+}
+
+const (
+	RevokedTokenTable = "revoked_tokens"
+	RevokedUserTable  = "revoked_users"
+)
+
+var ErrRevokedToken = errors.New("revoked token")
+
+// RevokedToken is a token revoked before expiration, keyed by jti.
+type RevokedToken struct {
+	Jti       string `gorm:"column:jti;type:varchar(64);primaryKey"`
+	ExpiresAt int64  `gorm:"column:expires_at;index:idx_revoked_token_expires_at"`
+}
+
+func (RevokedToken) TableName() string {
+	return RevokedTokenTable
+}
+
+// RevokedUser revokes all tokens of a user issued before RevokedAt (unix milliseconds).
+type RevokedUser struct {
+	UserId    string `gorm:"column:user_id;type:varchar(64);primaryKey"`
+	RevokedAt int64  `gorm:"column:revoked_at"`
+}
+
+func (RevokedUser) TableName() string {
+	return RevokedUserTable
+}
 
 type TokenRepo interface {
 	CreateToken(ctx context.Context, claims TokenClaims) (string, error)
 	DeleteToken(ctx context.Context, token string) error
 	ValidateToken(ctx context.Context, token string) (TokenClaims, error)
+	// RevokeUserTokens revokes all tokens issued to user before now.
+	RevokeUserTokens(ctx context.Context, userId string) error
+	// PublicKeys returns keys verifying tokens, empty if tokens are signed with a shared secret.
+	PublicKeys(ctx context.Context) ([]*JSONWebKey, error)
+}
+
+// TokenRevocationRepo stores revoked tokens until they expire.
+type TokenRevocationRepo interface {
+	// RevokeToken revokes token jti until expiresAt (unix seconds).
+	RevokeToken(ctx context.Context, jti string, expiresAt int64) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeUser revokes tokens of user issued before revokedAt (unix milliseconds).
+	RevokeUser(ctx context.Context, userId string, revokedAt int64) error
+	// UserRevokedAt returns 0 if tokens of user never revoked.
+	UserRevokedAt(ctx context.Context, userId string) (int64, error)
+}
+
+const SigningKeyTable = "signing_keys"
+
+// algorithms signing tokens
+const (
+	SigningAlgHS256 = "HS256"
+	SigningAlgRS256 = "RS256"
+	SigningAlgEdDSA = "EdDSA"
+)
+
+var ErrNoSigningKey = errors.New("no signing key")
+
+// SigningKey is an asymmetric key signing tokens, tokens carry its Kid in header.
+// the newest key signs, older keys verify tokens issued before until ExpiresAt.
+type SigningKey struct {
+	ID        uint32 `gorm:"primaryKey;autoIncrement"`
+	Kid       string `gorm:"type:varchar(64);index:idx_signing_key_kid,unique"`
+	Algorithm string `gorm:"type:varchar(16);"`
+	// PEM encoded PKCS #8 private key and PKIX public key
+	PrivateKey string `gorm:"type:text;"`
+	PublicKey  string `gorm:"type:text;"`
+	CreatedAt  int64
+	// unix seconds, ExpiresAt 0 is not rotated yet
+	ExpiresAt int64
+}
+
+func (SigningKey) TableName() string {
+	return SigningKeyTable
+}
+
+// JSONWebKey is a public key in JWKS, see RFC 7517.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP curve and public key
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type SigningKeysRepo interface {
+	CreateSigningKeys(ctx context.Context, tx TX, keys []*SigningKey) error
+	// ListSigningKeys lists keys not expired at now, newest first.
+	ListSigningKeys(ctx context.Context, tx TX, now int64) ([]*SigningKey, error)
+	// ExpireSigningKeys sets expiry of keys not rotated yet.
+	ExpireSigningKeys(ctx context.Context, tx TX, ids []uint32, expiresAt int64) error
+	// DeleteExpiredSigningKeys deletes keys expired before now.
+	DeleteExpiredSigningKeys(ctx context.Context, tx TX, now int64) error
 }
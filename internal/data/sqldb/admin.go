@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 
+	"gorm.io/gorm"
+
 	"github.com/go-kratos/kratos/v2/log"
 	//  TODO: modify project name
 	// biz "opspillar/internal/biz"
@@ -20,7 +22,13 @@ func NewAdminRepoGorm(data *DataGorm, logger log.Logger) (repo.AdminRepo, error)
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.User{}, repo.UserTable); err != nil {
+	if err := requireTable(data.DB, repo.UserTable); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.PasswordResetTokenTable); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.MFARecoveryCodeTable); err != nil {
 		return nil, err
 	}
 	return &AdminRepoGorm{
@@ -45,8 +53,21 @@ func (d *AdminRepoGorm) CreateUsers(ctx context.Context, tx repo.TX, users []*re
 	if len(users) == 0 {
 		return nil
 	}
-	return d.data.WithTX(tx).WithContext(ctx).Create(users).Error
-
+	db := d.data.WithTX(tx).WithContext(ctx)
+	for _, user := range users {
+		// empty email and phone are stored as NULL, not to conflict with unique index
+		var omit []string
+		if user.Email == "" {
+			omit = append(omit, "Email")
+		}
+		if user.Phone == "" {
+			omit = append(omit, "Phone")
+		}
+		if err := db.Omit(omit...).Create(user).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // UpdateUsers is
@@ -56,7 +77,7 @@ func (d *AdminRepoGorm) UpdateUsers(ctx context.Context, tx repo.TX, users []*re
 	}
 
 	for _, user := range users {
-		r := d.data.WithTX(tx).WithContext(ctx).Where("id=?", user.Id).Select("Email", "Phone", "Password").Updates(user)
+		r := d.data.WithTX(tx).WithContext(ctx).Where("id=?", user.Id).Select("Email", "Phone", "Password", "PasswordChangedAt").Updates(user)
 		if r.Error != nil {
 			return r.Error
 		}
@@ -86,7 +107,11 @@ func (d *AdminRepoGorm) GetUsers(ctx context.Context, tx repo.TX, id uint32) (*r
 
 // ListUsers is
 func (d *AdminRepoGorm) ListUsers(ctx context.Context, tx repo.TX, filter *repo.UsersFilter) ([]*repo.User, error) {
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
+	query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
+	if len(filter.Ids) > 0 {
+		query = query.Where("id IN ?", filter.Ids)
+	}
 	if len(filter.UserName) > 0 {
 		query = query.Where("user_name IN ?", filter.UserName)
 	}
@@ -96,6 +121,9 @@ func (d *AdminRepoGorm) ListUsers(ctx context.Context, tx repo.TX, filter *repo.
 	if len(filter.Phone) > 0 {
 		query = query.Where("phone IN ?", filter.Phone)
 	}
+	if len(filter.Sources) > 0 {
+		query = query.Where("source IN ?", filter.Sources)
+	}
 	users := make([]*repo.User, 0)
 	if err := query.Find(&users).Error; err != nil {
 		return nil, err
@@ -104,7 +132,152 @@ func (d *AdminRepoGorm) ListUsers(ctx context.Context, tx repo.TX, filter *repo.
 
 }
 
+// DisableUsers disables or enables users
+func (d *AdminRepoGorm) DisableUsers(ctx context.Context, tx repo.TX, ids []uint32, disabled bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).Where("id IN ?", ids).Update("disabled", disabled).Error
+}
+
 // Logout is
 func (d *AdminRepoGorm) Logout(ctx context.Context, id uint32) error {
 	return nil
 }
+
+// SetPassword is
+func (d *AdminRepoGorm) SetPassword(ctx context.Context, tx repo.TX, id uint32, hash string, changedAt int64) error {
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":            hash,
+		"password_changed_at": changedAt,
+		"failed_logins":       0,
+		"locked_until":        0,
+	})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected == 0 {
+		return ErrNoRowsAffected
+	}
+	return nil
+}
+
+// AddFailedLogin is
+func (d *AdminRepoGorm) AddFailedLogin(ctx context.Context, tx repo.TX, id uint32, max int32, lockUntil int64) (bool, error) {
+	db := d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).Where("id = ?", id)
+	// counted in database, concurrent failures are not lost
+	if err := db.Update("failed_logins", gorm.Expr("failed_logins + 1")).Error; err != nil {
+		return false, err
+	}
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).
+		Where("id = ? AND failed_logins >= ?", id, max).
+		Updates(map[string]interface{}{"failed_logins": 0, "locked_until": lockUntil})
+	if r.Error != nil {
+		return false, r.Error
+	}
+	return r.RowsAffected > 0, nil
+}
+
+// ResetFailedLogins is
+func (d *AdminRepoGorm) ResetFailedLogins(ctx context.Context, tx repo.TX, id uint32) error {
+	return d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).
+		Where("id = ? AND failed_logins > 0", id).Update("failed_logins", 0).Error
+}
+
+// CreatePasswordResetToken is
+func (d *AdminRepoGorm) CreatePasswordResetToken(ctx context.Context, tx repo.TX, token *repo.PasswordResetToken) error {
+	db := d.data.WithTX(tx).WithContext(ctx)
+	if err := db.Where("user_id = ? AND used_at = 0", token.UserId).Delete(&repo.PasswordResetToken{}).Error; err != nil {
+		return err
+	}
+	return db.Create(token).Error
+}
+
+// UsePasswordResetToken is
+func (d *AdminRepoGorm) UsePasswordResetToken(ctx context.Context, tx repo.TX, hash string, now int64) (*repo.PasswordResetToken, error) {
+	db := d.data.WithTX(tx).WithContext(ctx)
+	// marked in one statement, a token is used once under concurrent requests
+	r := db.Model(&repo.PasswordResetToken{}).
+		Where("hash = ? AND used_at = 0 AND expires_at > ?", hash, now).
+		Update("used_at", now)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	if r.RowsAffected == 0 {
+		return nil, repo.ErrInvalidResetToken
+	}
+	var token repo.PasswordResetToken
+	if err := db.Where("hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+
+// SetMFA is
+func (d *AdminRepoGorm) SetMFA(ctx context.Context, tx repo.TX, id uint32, secret string, codes []*repo.MFARecoveryCode) error {
+	// a savepoint in tx, secret and recovery codes change together
+	return d.data.WithTX(tx).WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		r := db.Model(&repo.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"mfa_secret":    secret,
+			"mfa_enabled":   false,
+			"mfa_last_step": 0,
+		})
+		if r.Error != nil {
+			return r.Error
+		}
+		if r.RowsAffected == 0 {
+			return ErrNoRowsAffected
+		}
+		if err := db.Where("user_id = ?", id).Delete(&repo.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		for _, c := range codes {
+			c.UserId = id
+		}
+		return db.Create(codes).Error
+	})
+}
+
+// EnableMFA is
+func (d *AdminRepoGorm) EnableMFA(ctx context.Context, tx repo.TX, id uint32, secret string) error {
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).
+		Where("id = ? AND mfa_secret = ? AND mfa_secret != ''", id, secret).
+		Update("mfa_enabled", true)
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected == 0 {
+		return ErrNoRowsAffected
+	}
+	return nil
+}
+
+// UseMFAStep is
+func (d *AdminRepoGorm) UseMFAStep(ctx context.Context, tx repo.TX, id uint32, step int64) (bool, error) {
+	// compared in one statement, a code is accepted once under concurrent logins
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.User{}).
+		Where("id = ? AND mfa_last_step < ?", id, step).
+		Update("mfa_last_step", step)
+	if r.Error != nil {
+		return false, r.Error
+	}
+	return r.RowsAffected > 0, nil
+}
+
+// UseMFARecoveryCode is
+func (d *AdminRepoGorm) UseMFARecoveryCode(ctx context.Context, tx repo.TX, id uint32, hash string, now int64) error {
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.MFARecoveryCode{}).
+		Where("user_id = ? AND hash = ? AND used_at = 0", id, hash).
+		Update("used_at", now)
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected == 0 {
+		return repo.ErrInvalidRecoveryCode
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type ApiKeysRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewApiKeysRepoGorm(data *DataGorm, logger log.Logger) (repo.ApiKeysRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.ApiKeyTable); err != nil {
+		return nil, err
+	}
+	return &ApiKeysRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateApiKeys is
+func (d *ApiKeysRepoGorm) CreateApiKeys(ctx context.Context, tx repo.TX, keys []*repo.ApiKey) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(keys)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// DeleteApiKeys is
+func (d *ApiKeysRepoGorm) DeleteApiKeys(ctx context.Context, tx repo.TX, ids []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.ApiKey{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete not equal expected. want %d. affected %d", len(ids), r.RowsAffected)
+	}
+	return nil
+}
+
+// DeleteServiceAccountApiKeys deletes all keys of service accounts
+func (d *ApiKeysRepoGorm) DeleteServiceAccountApiKeys(ctx context.Context, tx repo.TX, serviceAccountIds []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).
+		Where("service_account_id in (?)", serviceAccountIds).Delete(&repo.ApiKey{})
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// ListApiKeys is
+func (d *ApiKeysRepoGorm) ListApiKeys(ctx context.Context,
+	tx repo.TX,
+	filter *repo.ApiKeysFilter) ([]*repo.ApiKey, error) {
+
+	db_keys := []*repo.ApiKey{}
+	query := d.data.WithTX(tx).WithContext(ctx)
+	if filter != nil {
+		if filter.Page > 0 && filter.PageSize > 0 {
+			offset := int((filter.Page - 1) * filter.PageSize)
+			query = query.Offset(offset).Limit(int(filter.PageSize))
+		}
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.ServiceAccountIds) > 0 {
+			query = query.Where("service_account_id in (?)", filter.ServiceAccountIds)
+		}
+		if len(filter.Hashes) > 0 {
+			query = query.Where("hash in (?)", filter.Hashes)
+		}
+	}
+	r := query.Find(&db_keys)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return db_keys, nil
+}
+
+// TouchApiKey is
+func (d *ApiKeysRepoGorm) TouchApiKey(ctx context.Context, id uint32, lastUsedAt int64) error {
+
+	r := d.data.DB.WithContext(ctx).Model(&repo.ApiKey{}).
+		Where("id = ?", id).Update("last_used_at", lastUsedAt)
+	return r.Error
+}
@@ -16,7 +16,7 @@ func NewAppFeaturesRepoGorm(data *DataGorm, logger log.Logger) (repo.AppFeatures
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.AppFeature{}, repo.AppFeatureTable); err != nil {
+	if err := requireTable(data.DB, repo.AppFeatureTable); err != nil {
 		return nil, err
 	}
 	return &AppFeaturesRepoGorm{
@@ -66,8 +66,7 @@ func (d *AppFeaturesRepoGorm) ListAppFeatures(ctx context.Context,
 		query = query.Where("feature_id in (?)", filter.FeatureIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("app_id", "feature_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("app_id", "feature_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
@@ -16,7 +16,7 @@ func NewAppHostgroupsRepoGorm(data *DataGorm, logger log.Logger) (repo.AppHostgr
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.AppHostgroup{}, repo.AppHostgroupTable); err != nil {
+	if err := requireTable(data.DB, repo.AppHostgroupTable); err != nil {
 		return nil, err
 	}
 	return &AppHostgroupsRepoGorm{
@@ -66,8 +66,7 @@ func (d *AppHostgroupsRepoGorm) ListAppHostgroups(ctx context.Context,
 		query = query.Where("hostgroup_id in (?)", filter.HostgroupIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("app_id", "hostgroup_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("app_id", "hostgroup_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
@@ -16,7 +16,7 @@ func NewAppTagsRepoGorm(data *DataGorm, logger log.Logger) (repo.AppTagsRepo, er
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.AppTag{}, repo.AppTagTable); err != nil {
+	if err := requireTable(data.DB, repo.AppTagTable); err != nil {
 		return nil, err
 	}
 	return &AppTagsRepoGorm{
@@ -73,8 +73,7 @@ func (d *AppTagsRepoGorm) ListAppTags(ctx context.Context,
 		query = query.Where("tag_id in (?)", filter.TagIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("app_id", "tag_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("app_id", "tag_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
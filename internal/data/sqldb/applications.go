@@ -8,6 +8,15 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 )
 
+// appSelections are the selection fields of applications.
+var appSelections = map[string]selectTarget{
+	repo.SelectTeam:      {column: "team_id"},
+	repo.SelectProduct:   {column: "product_id"},
+	repo.SelectTag:       {relTable: repo.AppTagTable, refColumn: "app_id", column: "tag_id"},
+	repo.SelectFeature:   {relTable: repo.AppFeatureTable, refColumn: "app_id", column: "feature_id"},
+	repo.SelectHostgroup: {relTable: repo.AppHostgroupTable, refColumn: "app_id", column: "hostgroup_id"},
+}
+
 type ApplicationsRepoGorm struct {
 	data *DataGorm
 	log  *log.Helper
@@ -19,7 +28,7 @@ func NewApplicationsRepoGorm(data *DataGorm, logger log.Logger) (repo.Applicatio
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Application{}, repo.ApplicationTable); err != nil {
+	if err := requireTable(data.DB, repo.ApplicationTable); err != nil {
 		return nil, err
 	}
 
@@ -100,13 +109,11 @@ func (d *ApplicationsRepoGorm) ListApplications(ctx context.Context,
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
+		if len(filter.OrgIds) > 0 {
+			query = query.Where("org_id in (?)", filter.OrgIds)
+		}
 		if len(filter.Names) > 0 {
-			s_q := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(s_q, params...)
+			query = query.Where(matchNames("name", filter.NameMatch, filter.Names))
 		}
 		if len(filter.ProductsId) > 0 {
 			query = query.Where("product_id in (?)", filter.ProductsId)
@@ -121,10 +128,27 @@ func (d *ApplicationsRepoGorm) ListApplications(ctx context.Context,
 				query = query.Where("is_stateful = ?", false)
 			}
 		}
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset := int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
+		if len(filter.ExcludeTeamsId) > 0 {
+			query = query.Where("team_id not in (?)", filter.ExcludeTeamsId)
+		}
+		if len(filter.ExcludeProductsId) > 0 {
+			query = query.Where("product_id not in (?)", filter.ExcludeProductsId)
+		}
+		if filter.NoTags {
+			query = withoutRelated(query, repo.ApplicationTable, repo.AppTagTable, "app_id")
+		}
+		if filter.NoFeatures {
+			query = withoutRelated(query, repo.ApplicationTable, repo.AppFeatureTable, "app_id")
+		}
+		var err error
+		query, err = selectRows(query, repo.ApplicationTable, appSelections, filter.Selections)
+		if err != nil {
+			return nil, err
 		}
+		query = changedWithin(query, filter.ChangeFilter)
+		query = sortPage(query, filter.Sort, filter.Page, filter.PageSize, filter.LastId)
+	} else {
+		query = query.Order("id")
 	}
 	var apps []*repo.Application
 	if err := query.Find(&apps).Error; err != nil {
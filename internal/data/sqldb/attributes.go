@@ -0,0 +1,156 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type AttributesRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewAttributesRepoGorm(data *DataGorm, logger log.Logger) (repo.AttributesRepo, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	for _, table := range []string{repo.AttributeTable, repo.AttributeValueTable} {
+		if err := requireTable(data.DB, table); err != nil {
+			return nil, err
+		}
+	}
+	return &AttributesRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateAttributes is
+func (d *AttributesRepoGorm) CreateAttributes(ctx context.Context, tx repo.TX, attrs []*repo.Attribute) error {
+	return d.data.WithTX(tx).WithContext(ctx).Create(attrs).Error
+}
+
+// UpdateAttributes is
+func (d *AttributesRepoGorm) UpdateAttributes(ctx context.Context, tx repo.TX, attrs []*repo.Attribute) error {
+	return d.data.WithTX(tx).WithContext(ctx).Save(attrs).Error
+}
+
+// DeleteAttributes is
+func (d *AttributesRepoGorm) DeleteAttributes(ctx context.Context, tx repo.TX, ids []uint32) error {
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.Attribute{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete not equal expected. want %d. affected %d", len(ids), r.RowsAffected)
+	}
+	return nil
+}
+
+// GetAttributes is
+func (d *AttributesRepoGorm) GetAttributes(ctx context.Context, id uint32) (*repo.Attribute, error) {
+	attr := &repo.Attribute{}
+	if err := d.data.DB.WithContext(ctx).First(attr, id).Error; err != nil {
+		return nil, err
+	}
+	return attr, nil
+}
+
+// ListAttributes is
+func (d *AttributesRepoGorm) ListAttributes(ctx context.Context,
+	tx repo.TX,
+	filter *repo.AttributesFilter) ([]*repo.Attribute, error) {
+
+	attrs := []*repo.Attribute{}
+	query := d.data.WithTX(tx).WithContext(ctx)
+	if filter != nil {
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.Kinds) > 0 {
+			query = query.Where("kind in (?)", filter.Kinds)
+		}
+		if len(filter.Names) > 0 {
+			// values refer to attributes by name, so names match exactly
+			query = query.Where("name in (?)", filter.Names)
+		}
+	}
+	if err := query.Order("id").Find(&attrs).Error; err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// SetAttributeValues replaces the values of entity of kind.
+func (d *AttributesRepoGorm) SetAttributeValues(ctx context.Context,
+	tx repo.TX,
+	kind string,
+	entityId uint32,
+	values []*repo.AttributeValue) error {
+
+	db := d.data.WithTX(tx).WithContext(ctx)
+	r := db.Where("kind = ? AND entity_id = ?", kind, entityId).Delete(&repo.AttributeValue{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	for _, v := range values {
+		v.ID = 0
+		v.Kind = kind
+		v.EntityId = entityId
+	}
+	return db.Create(values).Error
+}
+
+// ListAttributeValues is
+func (d *AttributesRepoGorm) ListAttributeValues(ctx context.Context,
+	tx repo.TX,
+	filter *repo.AttributeValuesFilter) ([]*repo.AttributeValue, error) {
+
+	if filter == nil || filter.Kind == "" {
+		return nil, fmt.Errorf("kind of attribute values is required")
+	}
+	query := d.data.WithTX(tx).WithContext(ctx).Where("kind = ?", filter.Kind)
+	if len(filter.EntityIds) > 0 {
+		query = query.Where("entity_id in (?)", filter.EntityIds)
+	}
+	if len(filter.Names) > 0 {
+		query = query.Where("name in (?)", filter.Names)
+	}
+	if len(filter.Values) > 0 {
+		query = query.Where("value in (?)", filter.Values)
+	}
+	values := []*repo.AttributeValue{}
+	if err := query.Order("entity_id, name").Find(&values).Error; err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// DeleteAttributeValues deletes values of entities or attributes of kind.
+func (d *AttributesRepoGorm) DeleteAttributeValues(ctx context.Context,
+	tx repo.TX,
+	filter *repo.AttributeValuesFilter) error {
+
+	if filter == nil || filter.Kind == "" {
+		return fmt.Errorf("kind of attribute values is required")
+	}
+	if len(filter.EntityIds) == 0 && len(filter.Names) == 0 {
+		return fmt.Errorf("entities or names of attribute values are required")
+	}
+	query := d.data.WithTX(tx).WithContext(ctx).Where("kind = ?", filter.Kind)
+	if len(filter.EntityIds) > 0 {
+		query = query.Where("entity_id in (?)", filter.EntityIds)
+	}
+	if len(filter.Names) > 0 {
+		query = query.Where("name in (?)", filter.Names)
+	}
+	return query.Delete(&repo.AttributeValue{}).Error
+}
@@ -6,48 +6,311 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// AuthzRepoGorm keeps one enforcer with the policies in memory. Changes are written to
+// the database in the transaction of the caller and applied to memory after commit, a
+// transaction reads its own changes from a view loaded in it. Every change bumps the
+// revision, the watcher reloads policies changed by other instances.
 type AuthzRepoGorm struct {
-	data *DataGorm
-	log  *log.Helper
-	conf *conf.Authz
+	data     *DataGorm
+	log      *log.Helper
+	conf     *conf.Authz
+	model    model.Model
+	enforcer *casbin.SyncedCachedEnforcer
+	watcher  *authzWatcher
+
+	// mu serializes applying changes and reloads, revision is the one in memory
+	mu       sync.Mutex
+	revision int64
+	// pending are the changes of open transactions
+	pendingMu sync.Mutex
+	pending   map[*TxGorm]*authzTxState
+}
+
+// authzTxState is the changes of a transaction not committed yet.
+type authzTxState struct {
+	view     *casbin.Enforcer
+	ops      []policyOp
+	revision int64
 }
 
-func NewAuthzRepoGorm(conf *conf.Authz, data *DataGorm, logger log.Logger) (repo.AuthzRepo, error) {
+// policyOp is a change of a policy line, sec is "p" for rules and "g" for groups.
+type policyOp struct {
+	remove bool
+	sec    string
+	rule   []string
+}
+
+const DefaultPolicyPollInterval = 5 * time.Second
+
+func NewAuthzRepoGorm(conf *conf.Authz, data *DataGorm, logger log.Logger) (repo.AuthzRepo, func(), error) {
 
 	if err := validateData(data); err != nil {
-		return nil, err
+		return nil, func() {}, err
+	}
+	if err := requireTable(data.DB, repo.AuthzRevisionTable); err != nil {
+		return nil, func() {}, err
+	}
+	m, err := model.NewModelFromFile(conf.GetModelFile())
+	if err != nil {
+		return nil, func() {}, err
+	}
+	// creates casbin_rule with its unique index if missing
+	adapter, err := gormadapter.NewAdapterByDB(data.DB)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	enforcer, err := casbin.NewSyncedCachedEnforcer(m.Copy(), adapter)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	// changes are written in transactions of callers, the enforcer only keeps memory
+	enforcer.EnableAutoSave(false)
+
+	d := &AuthzRepoGorm{
+		data:     data,
+		conf:     conf,
+		log:      log.NewHelper(logger),
+		model:    m,
+		enforcer: enforcer,
+		pending:  map[*TxGorm]*authzTxState{},
+	}
+	if err := d.reload(context.Background()); err != nil {
+		return nil, func() {}, err
+	}
+	interval := conf.GetPolicyPollInterval().AsDuration()
+	if interval <= 0 {
+		interval = DefaultPolicyPollInterval
+	}
+	d.watcher = newAuthzWatcher(data, interval, d.log)
+	if err := enforcer.SetWatcher(d.watcher); err != nil {
+		return nil, func() {}, err
+	}
+	// SetWatcher sets a callback reloading without clearing the cache
+	if err := d.watcher.SetUpdateCallback(d.onRevision); err != nil {
+		return nil, func() {}, err
+	}
+	d.watcher.start()
+	return d, d.watcher.Close, nil
+}
+
+// reload loads all policies and the revision from the database.
+func (d *AuthzRepoGorm) reload(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.reloadLocked(ctx)
+}
+
+func (d *AuthzRepoGorm) reloadLocked(ctx context.Context) error {
+	// the revision is read first, a change committed meanwhile only reloads once more
+	rev, err := readRevision(d.data.DB.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if err := d.enforcer.LoadPolicy(); err != nil {
+		return err
+	}
+	d.revision = rev
+	return nil
+}
+
+// onRevision is called by the watcher with the revision in the database.
+func (d *AuthzRepoGorm) onRevision(revision string) {
+	rev, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil {
+		d.log.Errorf("invalid authz revision %q", revision)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if rev == d.revision {
+		return
+	}
+	if err := d.reloadLocked(context.Background()); err != nil {
+		d.log.Errorf("reload authz policies failed: %v", err)
+	}
+}
+
+// apply applies committed changes to memory, it reloads if changes of other
+// instances came before them.
+func (d *AuthzRepoGorm) apply(revision int64, ops []policyOp) {
+	if len(ops) == 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.revision != revision-int64(len(ops)) {
+		if err := d.reloadLocked(context.Background()); err != nil {
+			d.log.Errorf("reload authz policies failed: %v", err)
+		}
+		return
+	}
+	for _, op := range ops {
+		var err error
+		if op.remove {
+			_, err = d.enforcer.SelfRemovePolicy(op.sec, op.sec, op.rule)
+		} else {
+			_, err = d.enforcer.SelfAddPolicy(op.sec, op.sec, op.rule)
+		}
+		if err != nil {
+			d.log.Errorf("apply authz change failed, reloading: %v", err)
+			if err := d.reloadLocked(context.Background()); err != nil {
+				d.log.Errorf("reload authz policies failed: %v", err)
+			}
+			return
+		}
+	}
+	if err := d.enforcer.InvalidateCache(); err != nil {
+		d.log.Errorf("invalidate authz cache failed: %v", err)
 	}
-	return &AuthzRepoGorm{
-		data: data,
-		conf: conf,
-		log:  log.NewHelper(logger),
-	}, nil
+	d.revision = revision
 }
 
-func (d *AuthzRepoGorm) createEnforcer(ctx context.Context, tx repo.TX) (*casbin.Enforcer, error) {
+// write persists op in tx and applies it to memory once committed. It returns false if
+// nothing changed, the line existed for adding or was missing for removing.
+func (d *AuthzRepoGorm) write(ctx context.Context, tx repo.TX, op policyOp) (bool, error) {
+	if tx == nil {
+		var changed bool
+		var rev int64
+		err := d.data.DB.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+			var err error
+			changed, rev, err = persistPolicy(db, op)
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+		if changed {
+			d.apply(rev, []policyOp{op})
+		}
+		return changed, nil
+	}
+	gtx, ok := tx.(*TxGorm)
+	if !ok {
+		return false, fmt.Errorf("unsupported transaction %T", tx)
+	}
+	state, err := d.txState(ctx, gtx)
+	if err != nil {
+		return false, err
+	}
+	changed, rev, err := persistPolicy(gtx.tx.WithContext(ctx), op)
+	if err != nil || !changed {
+		return false, err
+	}
+	state.ops = append(state.ops, op)
+	state.revision = rev
+	if op.remove {
+		_, err = state.view.SelfRemovePolicy(op.sec, op.sec, op.rule)
+	} else {
+		_, err = state.view.SelfAddPolicy(op.sec, op.sec, op.rule)
+	}
+	return true, err
+}
 
-	db := d.data.WithTX(tx).WithContext(ctx)
+// txState returns the changes of gtx, the first call loads a view in it.
+func (d *AuthzRepoGorm) txState(ctx context.Context, gtx *TxGorm) (*authzTxState, error) {
+	d.pendingMu.Lock()
+	state, ok := d.pending[gtx]
+	d.pendingMu.Unlock()
+	if ok {
+		return state, nil
+	}
+	db := gtx.tx.Session(&gorm.Session{Context: ctx})
+	gormadapter.TurnOffAutoMigrate(db)
 	adapter, err := gormadapter.NewAdapterByDB(db)
 	if err != nil {
 		return nil, err
 	}
-	return casbin.NewEnforcer(d.conf.ModelFile, adapter)
+	view, err := casbin.NewEnforcer(d.model.Copy(), adapter)
+	if err != nil {
+		return nil, err
+	}
+	view.EnableAutoSave(false)
+	state = &authzTxState{view: view}
+	d.pendingMu.Lock()
+	d.pending[gtx] = state
+	d.pendingMu.Unlock()
+	gtx.OnFinish(func(committed bool) {
+		d.pendingMu.Lock()
+		delete(d.pending, gtx)
+		d.pendingMu.Unlock()
+		if committed {
+			d.apply(state.revision, state.ops)
+		}
+	})
+	return state, nil
 }
 
-func (d *AuthzRepoGorm) CreateRule(ctx context.Context, tx repo.TX, rule *repo.Rule) error {
+// reader is the view of tx if it changed policies, the shared enforcer otherwise.
+func (d *AuthzRepoGorm) reader(tx repo.TX) casbin.IEnforcer {
+	if gtx, ok := tx.(*TxGorm); ok {
+		d.pendingMu.Lock()
+		state, ok := d.pending[gtx]
+		d.pendingMu.Unlock()
+		if ok {
+			return state.view
+		}
+	}
+	return d.enforcer
+}
 
-	enforcer, err := d.createEnforcer(ctx, tx)
-	if err != nil {
-		return errors.Join(fmt.Errorf("CreatePolicy failed"), err)
+// persistPolicy writes op and bumps the revision if a line changed.
+func persistPolicy(db *gorm.DB, op policyOp) (bool, int64, error) {
+	line := gormadapter.CasbinRule{Ptype: op.sec}
+	fields := []*string{&line.V0, &line.V1, &line.V2, &line.V3, &line.V4, &line.V5}
+	for i, v := range op.rule {
+		*fields[i] = v
+	}
+	var r *gorm.DB
+	if op.remove {
+		r = db.Where(&line, "Ptype", "V0", "V1", "V2", "V3", "V4", "V5").Delete(&gormadapter.CasbinRule{})
+	} else {
+		r = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&line)
+	}
+	if r.Error != nil || r.RowsAffected == 0 {
+		return false, 0, r.Error
+	}
+	rev, err := bumpRevision(db)
+	return true, rev, err
+}
+
+func bumpRevision(db *gorm.DB) (int64, error) {
+	r := db.Model(&repo.AuthzRevision{}).Where("id = ?", 1).Updates(map[string]interface{}{
+		"revision":   gorm.Expr("revision + 1"),
+		"updated_at": time.Now().Unix(),
+	})
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	if r.RowsAffected == 0 {
+		return 0, errors.Join(ErrMissingRecords, errors.New("authz revision"))
 	}
+	return readRevision(db)
+}
+
+func readRevision(db *gorm.DB) (int64, error) {
+	var rev repo.AuthzRevision
+	if err := db.Where("id = ?", 1).First(&rev).Error; err != nil {
+		return 0, err
+	}
+	return rev.Revision, nil
+}
+
+func (d *AuthzRepoGorm) CreateRule(ctx context.Context, tx repo.TX, rule *repo.Rule) error {
+
 	res := rule.Resource.ResourceStr()
-	s, err := enforcer.AddPolicy(rule.Sub, res, rule.Action)
+	s, err := d.write(ctx, tx, policyOp{sec: "p", rule: []string{rule.Sub, res, rule.Action}})
 	if err != nil {
 		return errors.Join(fmt.Errorf("CreatePolicy failed"), err)
 	}
@@ -59,13 +322,9 @@ func (d *AuthzRepoGorm) CreateRule(ctx context.Context, tx repo.TX, rule *repo.R
 }
 
 func (d *AuthzRepoGorm) DeleteRule(ctx context.Context, tx repo.TX, rule *repo.Rule) error {
-	enforcer, err := d.createEnforcer(ctx, tx)
-	if err != nil {
-		return errors.Join(fmt.Errorf("DeletePolicy failed"), err)
-	}
 
 	res := rule.Resource.ResourceStr()
-	s, err := enforcer.RemovePolicy(rule.Sub, res, rule.Action)
+	s, err := d.write(ctx, tx, policyOp{remove: true, sec: "p", rule: []string{rule.Sub, res, rule.Action}})
 	if err != nil {
 		return errors.Join(fmt.Errorf("DeletePolicy failed with value %v", s), err)
 	}
@@ -74,12 +333,10 @@ func (d *AuthzRepoGorm) DeleteRule(ctx context.Context, tx repo.TX, rule *repo.R
 }
 
 func (d *AuthzRepoGorm) ListRule(ctx context.Context, tx repo.TX, filter *repo.RuleFilter) ([]*repo.Rule, error) {
-	enforcer, err := d.createEnforcer(ctx, tx)
-	if err != nil {
-		return nil, errors.Join(fmt.Errorf("ListPolicy failed"), err)
-	}
+	enforcer := d.reader(tx)
 
 	var _rules [][]string
+	var err error
 	if filter == nil || filter.Sub == "" {
 		_rules, err = enforcer.GetPolicy()
 	} else {
@@ -109,19 +366,90 @@ func (d *AuthzRepoGorm) ListRule(ctx context.Context, tx repo.TX, filter *repo.R
 }
 
 func (d *AuthzRepoGorm) Enforce(ctx context.Context, tx repo.TX, request *repo.AuthenRequest) (bool, error) {
-	enforcer, err := d.createEnforcer(ctx, tx)
-	if err != nil {
-		return false, errors.Join(fmt.Errorf("Enforce failed"), err)
+	return d.reader(tx).Enforce(request.Sub, request.Resource.ResourceStr(), request.Action)
+}
+
+func (d *AuthzRepoGorm) BatchEnforce(ctx context.Context, tx repo.TX, requests []*repo.AuthenRequest) ([]bool, error) {
+	if len(requests) == 0 {
+		return []bool{}, nil
 	}
-	return enforcer.Enforce(request.Sub, request.Resource.ResourceStr(), request.Action)
+	enforcer := d.reader(tx)
+	// one by one to use the cache of the shared enforcer, lists repeat the same requests
+	res := make([]bool, len(requests))
+	for i, r := range requests {
+		allowed, err := enforcer.Enforce(r.Sub, r.Resource.ResourceStr(), r.Action)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("BatchEnforce failed"), err)
+		}
+		res[i] = allowed
+	}
+	return res, nil
 }
 
-func (d *AuthzRepoGorm) CreateGroup(ctx context.Context, tx repo.TX, group *repo.Group) error {
-	enforcer, err := d.createEnforcer(ctx, tx)
+func (d *AuthzRepoGorm) Explain(ctx context.Context, tx repo.TX, request *repo.AuthenRequest) (*repo.Explanation, error) {
+	enforcer := d.reader(tx)
+	allowed, matched, err := enforcer.EnforceEx(request.Sub, request.Resource.ResourceStr(), request.Action)
 	if err != nil {
-		return errors.Join(fmt.Errorf("CreateGroup failed"), err)
+		return nil, errors.Join(fmt.Errorf("Explain failed"), err)
+	}
+	roles, err := enforcer.GetImplicitRolesForUser(request.Sub)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("Explain failed"), err)
+	}
+	res := &repo.Explanation{Allowed: allowed, Roles: roles}
+	if !allowed || len(matched) < 3 {
+		return res, nil
+	}
+	ires := &repo.Resource4Sv1{}
+	if err := ires.ParseStr(matched[1]); err != nil {
+		return nil, errors.Join(fmt.Errorf("Explain failed"), err)
+	}
+	res.Rule = &repo.Rule{Sub: matched[0], Resource: ires, Action: matched[2]}
+	groups, err := enforcer.GetGroupingPolicy()
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("Explain failed"), err)
+	}
+	res.Chain = groupChain(groups, request.Sub, matched[0])
+	return res, nil
+}
+
+// groupChain finds the shortest chain of groups from user to role, it is [user] if user is
+// role or role matches everyone.
+func groupChain(groups [][]string, user, role string) []string {
+	if user == role || role == repo.SubEveryone {
+		return []string{user}
+	}
+	roles := map[string][]string{}
+	for _, g := range groups {
+		if len(g) >= 2 {
+			roles[g[0]] = append(roles[g[0]], g[1])
+		}
 	}
-	existed, err := enforcer.AddGroupingPolicy(group.User, group.Role)
+	prev := map[string]string{user: ""}
+	queue := []string{user}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, r := range roles[cur] {
+			if _, seen := prev[r]; seen {
+				continue
+			}
+			prev[r] = cur
+			if r == role {
+				chain := []string{r}
+				for p := cur; p != ""; p = prev[p] {
+					chain = append([]string{p}, chain...)
+				}
+				return chain
+			}
+			queue = append(queue, r)
+		}
+	}
+	return []string{user}
+}
+
+func (d *AuthzRepoGorm) CreateGroup(ctx context.Context, tx repo.TX, group *repo.Group) error {
+	existed, err := d.write(ctx, tx, policyOp{sec: "g", rule: []string{group.User, group.Role}})
 	if err != nil {
 		return errors.Join(fmt.Errorf("CreateGroup failed"), err)
 	}
@@ -132,11 +460,7 @@ func (d *AuthzRepoGorm) CreateGroup(ctx context.Context, tx repo.TX, group *repo
 }
 
 func (d *AuthzRepoGorm) DeleteGroup(ctx context.Context, tx repo.TX, group *repo.Group) error {
-	enforcer, err := d.createEnforcer(ctx, tx)
-	if err != nil {
-		return errors.Join(fmt.Errorf("DeleteGroup failed"), err)
-	}
-	s, err := enforcer.RemoveGroupingPolicy(group.User, group.Role)
+	s, err := d.write(ctx, tx, policyOp{remove: true, sec: "g", rule: []string{group.User, group.Role}})
 	if err != nil || !s {
 		return errors.Join(fmt.Errorf("DeleteGroup failed with value %v", s), err)
 	}
@@ -144,12 +468,10 @@ func (d *AuthzRepoGorm) DeleteGroup(ctx context.Context, tx repo.TX, group *repo
 }
 
 func (d *AuthzRepoGorm) ListGroup(ctx context.Context, tx repo.TX, filter *repo.GroupFilter) ([]*repo.Group, error) {
-	enforcer, err := d.createEnforcer(ctx, tx)
-	if err != nil {
-		return nil, errors.Join(fmt.Errorf("ListGroup failed"), err)
-	}
+	enforcer := d.reader(tx)
 
 	var _groups [][]string
+	var err error
 	if filter == nil || filter.User == "" && filter.Role == "" {
 		_groups, err = enforcer.GetGroupingPolicy()
 	} else if filter.User != "" {
@@ -0,0 +1,64 @@
+package sqldb
+
+import (
+	"context"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type AuthzAuditsRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewAuthzAuditsRepoGorm(data *DataGorm, logger log.Logger) (repo.AuthzAuditsRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.AuthzAuditTable); err != nil {
+		return nil, err
+	}
+	return &AuthzAuditsRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateAuthzAudits is
+func (d *AuthzAuditsRepoGorm) CreateAuthzAudits(ctx context.Context, tx repo.TX, audits []*repo.AuthzAudit) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(audits)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// ListAuthzAudits is
+func (d *AuthzAuditsRepoGorm) ListAuthzAudits(ctx context.Context,
+	tx repo.TX,
+	filter *repo.AuthzAuditsFilter) ([]*repo.AuthzAudit, error) {
+
+	db_audits := []*repo.AuthzAudit{}
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id desc")
+	if filter != nil {
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, true)
+		if len(filter.Subs) > 0 {
+			query = query.Where("sub in (?)", filter.Subs)
+		}
+		if len(filter.Operators) > 0 {
+			query = query.Where("operator in (?)", filter.Operators)
+		}
+		if filter.Since > 0 {
+			query = query.Where("created_at >= ?", filter.Since)
+		}
+	}
+	r := query.Find(&db_audits)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return db_audits, nil
+}
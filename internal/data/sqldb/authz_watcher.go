@@ -0,0 +1,83 @@
+package sqldb
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _ persist.Watcher = (*authzWatcher)(nil)
+
+// authzWatcher polls the revision of authz policies, the callback is called with the
+// revision in the database and decides whether to reload.
+type authzWatcher struct {
+	data     *DataGorm
+	interval time.Duration
+	log      *log.Helper
+
+	mu       sync.Mutex
+	callback func(string)
+	stop     chan struct{}
+	once     sync.Once
+}
+
+func newAuthzWatcher(data *DataGorm, interval time.Duration, logger *log.Helper) *authzWatcher {
+	return &authzWatcher{
+		data:     data,
+		interval: interval,
+		log:      logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (w *authzWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+// Update bumps the revision, changes made by AuthzRepoGorm bump it in their transactions.
+func (w *authzWatcher) Update() error {
+	_, err := bumpRevision(w.data.DB)
+	return err
+}
+
+func (w *authzWatcher) Close() {
+	w.once.Do(func() { close(w.stop) })
+}
+
+func (w *authzWatcher) start() {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.poll()
+			}
+		}
+	}()
+}
+
+func (w *authzWatcher) poll() {
+	ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+	defer cancel()
+	rev, err := readRevision(w.data.DB.WithContext(ctx))
+	if err != nil {
+		w.log.Warnf("poll authz revision failed: %v", err)
+		return
+	}
+	w.mu.Lock()
+	callback := w.callback
+	w.mu.Unlock()
+	if callback != nil {
+		callback(strconv.FormatInt(rev, 10))
+	}
+}
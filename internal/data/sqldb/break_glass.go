@@ -0,0 +1,129 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type BreakGlassRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewBreakGlassRepoGorm(data *DataGorm, logger log.Logger) (repo.BreakGlassRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.BreakGlassTable); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.BreakGlassAuditTable); err != nil {
+		return nil, err
+	}
+	return &BreakGlassRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateBreakGlass is
+func (d *BreakGlassRepoGorm) CreateBreakGlass(ctx context.Context, tx repo.TX, creds []*repo.BreakGlass) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(creds)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// DeleteBreakGlass is
+func (d *BreakGlassRepoGorm) DeleteBreakGlass(ctx context.Context, tx repo.TX, ids []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.BreakGlass{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete not equal expected. want %d. affected %d", len(ids), r.RowsAffected)
+	}
+	return nil
+}
+
+// ListBreakGlass is
+func (d *BreakGlassRepoGorm) ListBreakGlass(ctx context.Context,
+	tx repo.TX,
+	filter *repo.BreakGlassFilter) ([]*repo.BreakGlass, error) {
+
+	db_creds := []*repo.BreakGlass{}
+	query := d.data.WithTX(tx).WithContext(ctx)
+	if filter != nil {
+		if filter.Page > 0 && filter.PageSize > 0 {
+			offset := int((filter.Page - 1) * filter.PageSize)
+			query = query.Offset(offset).Limit(int(filter.PageSize))
+		}
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.Names) > 0 {
+			query = query.Where("name in (?)", filter.Names)
+		}
+		if len(filter.Hashes) > 0 {
+			query = query.Where("hash in (?)", filter.Hashes)
+		}
+	}
+	r := query.Find(&db_creds)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return db_creds, nil
+}
+
+// ActivateBreakGlass sets first used time only if it is not set, so concurrent uses
+// of a one-time credential can not both succeed.
+func (d *BreakGlassRepoGorm) ActivateBreakGlass(ctx context.Context, tx repo.TX, id uint32, usedAt int64) (bool, error) {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.BreakGlass{}).
+		Where("id = ? AND first_used_at = 0", id).Update("first_used_at", usedAt)
+	if r.Error != nil {
+		return false, r.Error
+	}
+	return r.RowsAffected == 1, nil
+}
+
+// CreateBreakGlassAudits is
+func (d *BreakGlassRepoGorm) CreateBreakGlassAudits(ctx context.Context, tx repo.TX, audits []*repo.BreakGlassAudit) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(audits)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// ListBreakGlassAudits is
+func (d *BreakGlassRepoGorm) ListBreakGlassAudits(ctx context.Context,
+	tx repo.TX,
+	filter *repo.BreakGlassAuditsFilter) ([]*repo.BreakGlassAudit, error) {
+
+	db_audits := []*repo.BreakGlassAudit{}
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id desc")
+	if filter != nil {
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, true)
+		if len(filter.CredentialIds) > 0 {
+			query = query.Where("credential_id in (?)", filter.CredentialIds)
+		}
+		if filter.Since > 0 {
+			query = query.Where("created_at >= ?", filter.Since)
+		}
+	}
+	r := query.Find(&db_audits)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return db_audits, nil
+}
@@ -19,7 +19,7 @@ func NewClustersRepoGorm(data *DataGorm, logger log.Logger) (repo.ClustersRepo,
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Cluster{}, repo.ClusterTable); err != nil {
+	if err := requireTable(data.DB, repo.ClusterTable); err != nil {
 		return nil, err
 	}
 
@@ -79,23 +79,14 @@ func (d *ClustersRepoGorm) ListClusters(ctx context.Context,
 	tx repo.TX,
 	filter *repo.ClustersFilter) ([]*repo.Cluster, error) {
 	cs := []*repo.Cluster{}
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
 		if len(filter.Names) > 0 {
-			nameConditions := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(nameConditions, params...)
+			query = query.Where(matchNames("name", "", filter.Names))
 		}
 	}
 	r := query.Find(&cs)
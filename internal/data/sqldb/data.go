@@ -1,6 +1,7 @@
 package sqldb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"opspillar/internal/conf"
@@ -11,6 +12,7 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DataGorm .
@@ -25,6 +27,63 @@ func NewDataGorm(c *conf.Data, logger log.Logger) (*DataGorm, func(), error) {
 		log.NewHelper(logger).Info("closing the data resources")
 	}
 
+	data, err := OpenDataGorm(c)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	if err := checkSchema(data, c.GetDatabase().GetAutoMigrate(), logger); err != nil {
+		return nil, cleanup, err
+	}
+	return data, cleanup, nil
+}
+
+// dbError keeps the message of a database error and matches the repo error of its kind.
+type dbError struct {
+	err  error
+	kind error
+}
+
+func (e *dbError) Error() string   { return e.err.Error() }
+func (e *dbError) Unwrap() []error { return []error{e.err, e.kind} }
+
+// classifyErrors matches database errors of missing, duplicated and referenced rows with the
+// repo errors of their kind, so biz tells them apart from the others whatever the driver.
+func classifyErrors(db *gorm.DB) {
+	if db.Error == nil {
+		return
+	}
+	var kind error
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		kind = repo.ErrNotFound
+	} else if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		switch translator.Translate(db.Error) {
+		case gorm.ErrDuplicatedKey:
+			kind = repo.ErrDuplicated
+		case gorm.ErrForeignKeyViolated:
+			kind = repo.ErrReferenced
+		}
+	}
+	if kind != nil && !errors.Is(db.Error, kind) {
+		db.Error = &dbError{err: db.Error, kind: kind}
+	}
+}
+
+// RegisterErrorKinds classifies the errors of all statements of db with classifyErrors.
+func RegisterErrorKinds(db *gorm.DB) error {
+	const name = "opspillar:classify_errors"
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().After("*").Register(name, classifyErrors),
+		cb.Query().After("*").Register(name, classifyErrors),
+		cb.Update().After("*").Register(name, classifyErrors),
+		cb.Delete().After("*").Register(name, classifyErrors),
+		cb.Row().After("*").Register(name, classifyErrors),
+		cb.Raw().After("*").Register(name, classifyErrors),
+	)
+}
+
+// OpenDataGorm opens database without checking schema version.
+func OpenDataGorm(c *conf.Data) (*DataGorm, error) {
 	dsn := c.GetDatabase().GetSource()
 
 	driver := c.GetDatabase().GetDriver()
@@ -38,20 +97,41 @@ func NewDataGorm(c *conf.Data, logger log.Logger) (*DataGorm, func(), error) {
 			// 连接 SQLite 数据库
 			_db, err = gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 		} else {
-			return nil, cleanup, ErrUnsupportedDatabaseDriver
+			return nil, ErrUnsupportedDatabaseDriver
 		}
 	} else {
-		return nil, cleanup, ErrEmptyDatabase
+		return nil, ErrEmptyDatabase
 	}
 
 	if err != nil {
-		return nil, cleanup, err
+		return nil, err
+	}
+	if err := RegisterErrorKinds(_db); err != nil {
+		return nil, err
 	}
 
 	return &DataGorm{
 		DB:     _db,
 		Driver: driver,
-	}, cleanup, nil
+	}, nil
+}
+
+// checkSchema refuses a schema newer than this binary,
+// and applies pending migrations if autoMigrate.
+func checkSchema(data *DataGorm, autoMigrate bool, logger log.Logger) error {
+	m, err := NewMigrator(data, logger)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	err = m.Check(ctx)
+	if errors.Is(err, ErrPendingMigrations) {
+		if !autoMigrate {
+			return errors.Join(err, errors.New("run `opspillar migrate up` or set data.database.auto_migrate"))
+		}
+		_, err = m.Up(ctx, 0)
+	}
+	return err
 }
 
 func (d *DataGorm) WithTX(tx repo.TX) *gorm.DB {
@@ -81,6 +161,7 @@ const FilterKVSplit = ":"
 
 var ErrUnsupportedDatabaseDriver = errors.New("unsupportedDatabaseDriver")
 var ErrEmptyDatabase = errors.New("emptyDatabaseSource")
+var ErrMissingTable = errors.New("missing table")
 var ErrNoRowsAffected = errors.New("noRowsAffected")
 var ErrMissingRecords = errors.New("missing records")
 var ErrMissingTags = errors.New("missing Tag")
@@ -99,60 +180,162 @@ func validateData(data *DataGorm) error {
 	return nil
 }
 
-func initTable(db *gorm.DB, model interface{}, table string) error {
-	//m := db.Migrator()
-	err := db.AutoMigrate(model)
-	if err != nil {
-		return err
+// requireTable checks table created by schema migrations.
+func requireTable(db *gorm.DB, table string) error {
+	if !db.Migrator().HasTable(table) {
+		return errors.Join(ErrMissingTable, fmt.Errorf("table %s, run migrate up first", table))
 	}
+	return nil
+}
 
-	//if !m.HasTable(table) {
-	//	log.Warnf("missing table %s", table)
-	//	return db.AutoMigrate(model)
-	//}
+// likeEscaper escapes LIKE wildcards of filter values, conditions declare ! as the escape.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
 
-	//if !m.HasColumn(&repo.Application{}, "owner_id") {
-	//	m.AddColumn(&repo.Application{}, "owner_id")
-	//}
+// matchNames returns the condition of column matching any of values. values are contained in
+// the column unless match asks for prefixes or exact values, exact values are bound as one IN
+// list. wildcards in values match literally.
+func matchNames(column string, match string, values []string) clause.Expression {
+	col := clause.Column{Name: column}
+	if match == repo.NameMatchExact {
+		in := make([]interface{}, len(values))
+		for i, v := range values {
+			in[i] = v
+		}
+		return clause.IN{Column: col, Values: in}
+	}
+	likes := make([]clause.Expression, len(values))
+	for i, v := range values {
+		pattern := likeEscaper.Replace(v) + "%"
+		if match != repo.NameMatchPrefix {
+			pattern = "%" + pattern
+		}
+		likes[i] = clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []interface{}{col, pattern}}
+	}
+	return clause.Or(likes...)
+}
 
-	//if !m.HasColumn(&repo.Team{}, "leader_id") {
-	//	m.AddColumn(&repo.Team{}, "leader_id")
-	//}
+// matchKVs returns the condition of kname and vname matching a key:value of kvs, pairs are
+// bound as one IN list of rows.
+func matchKVs(kname string, vname string, kvs []string) clause.Expression {
+	rows := make([][]interface{}, 0, len(kvs))
+	for _, kv := range kvs {
+		_kvs := strings.Split(kv, FilterKVSplit)
+		if len(_kvs) == 2 {
+			rows = append(rows, []interface{}{_kvs[0], _kvs[1]})
+		}
+	}
+	if len(rows) == 0 {
+		// no valid pair matches nothing
+		return clause.Expr{SQL: "1 = 0"}
+	}
+	return clause.Expr{SQL: "(?, ?) IN ?",
+		Vars: []interface{}{clause.Column{Name: kname}, clause.Column{Name: vname}, rows}}
+}
 
-	log.Infof("migrate table %s", table)
-	return nil
+// changedWithin limits query to rows changed within the times of filter.
+func changedWithin(query *gorm.DB, filter repo.ChangeFilter) *gorm.DB {
+	if filter.CreatedAfter > 0 {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if filter.CreatedBefore > 0 {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+	if filter.UpdatedAfter > 0 {
+		query = query.Where("updated_at >= ?", filter.UpdatedAfter)
+	}
+	if filter.UpdatedBefore > 0 {
+		query = query.Where("updated_at < ?", filter.UpdatedBefore)
+	}
+	return query
 }
 
-// buildOrLike build or conditions.
-//
-//	return: "key LIKE ? OR key LIKE ? ..."
-func buildOrLike(key string, count int) string {
-	var builder strings.Builder
-	for i := 0; i < count; i++ {
-		if i > 0 {
-			builder.WriteString(" OR ")
-		}
-		builder.WriteString(fmt.Sprintf("%s LIKE ?", key))
+// paginate limits query to a page of rows ordered by id, desc when the list shows the newest
+// first. pages of a token start after the row of lastId, so rows changed meanwhile are not
+// skipped, others start at an offset.
+func paginate(query *gorm.DB, page, pageSize, lastId uint32, desc bool) *gorm.DB {
+	if pageSize == 0 || (page == 0 && lastId == 0) {
+		return query
+	}
+	if lastId == 0 {
+		return query.Offset(int((page - 1) * pageSize)).Limit(int(pageSize))
 	}
-	return builder.String()
+	if desc {
+		return query.Where("id < ?", lastId).Limit(int(pageSize))
+	}
+	return query.Where("id > ?", lastId).Limit(int(pageSize))
+}
+
+// withoutRelated limits query of table to rows without rows of relTable referencing their id
+// by column.
+func withoutRelated(query *gorm.DB, table string, relTable string, column string) *gorm.DB {
+	return query.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s WHERE %s.%s = %s.id)",
+		relTable, relTable, column, table))
+}
+
+// selectTarget is the column of a selection field, or the column of relTable rows referencing
+// listed rows by refColumn.
+type selectTarget struct {
+	column    string
+	relTable  string
+	refColumn string
 }
 
-// buildOrKV build or conditions with key and value.
-//
-//	return: "(k=? AND v=?) OR (k=? AND v=?) ... "
-//	return: [k1, v1, k2, v2, ...]
-func buildOrKV(kname string, vname string, kvstr []string) (string, []interface{}) {
-	var kv []interface{}
-	var builder strings.Builder
-	for i := 0; i < len(kvstr); i++ {
-		if i > 0 {
-			builder.WriteString(" OR ")
+// selectRows limits query of table to rows matching all selections, targets are the selection
+// fields of table.
+func selectRows(query *gorm.DB, table string, targets map[string]selectTarget,
+	selections []repo.Selection) (*gorm.DB, error) {
+
+	for _, sel := range selections {
+		target, ok := targets[sel.Field]
+		if !ok {
+			return nil, fmt.Errorf("invalid selection %s of %s", sel.Field, table)
 		}
-		_kvs := strings.Split(kvstr[i], FilterKVSplit)
-		if len(_kvs) == 2 {
-			kv = append(kv, _kvs[0], _kvs[1])
-			builder.WriteString(fmt.Sprintf("( %s = ? AND  %s = ? )", kname, vname))
+		if len(sel.Ids) == 0 {
+			if !sel.Exclude {
+				query = query.Where("1 = 0")
+			}
+			continue
+		}
+		if target.relTable == "" {
+			op := "IN"
+			if sel.Exclude {
+				op = "NOT IN"
+			}
+			query = query.Where(fmt.Sprintf("%s.%s %s ?", table, target.column, op), sel.Ids)
+			continue
 		}
+		exists := "EXISTS"
+		if sel.Exclude {
+			exists = "NOT EXISTS"
+		}
+		query = query.Where(fmt.Sprintf("%s (SELECT 1 FROM %s WHERE %s.%s = %s.id AND %s.%s IN ?)",
+			exists, target.relTable, target.relTable, target.refColumn, table,
+			target.relTable, target.column), sel.Ids)
+	}
+	return query, nil
+}
+
+// sortColumns are the columns lists may be sorted by.
+var sortColumns = map[string]bool{
+	repo.SortName:      true,
+	repo.SortCreatedAt: true,
+	repo.SortUpdatedAt: true,
+}
+
+// sortPage orders query by the column of sort then by id and limits it to a page. pages of a
+// token start after the row of lastId and sort.After, lists sorted by id are paged by paginate.
+func sortPage(query *gorm.DB, sort repo.ListSort, page, pageSize, lastId uint32) *gorm.DB {
+	dir, cmp := "", ">"
+	if sort.Desc {
+		dir, cmp = " desc", "<"
+	}
+	if !sortColumns[sort.By] {
+		return paginate(query.Order("id"+dir), page, pageSize, lastId, sort.Desc)
+	}
+	query = query.Order(sort.By + dir).Order("id" + dir)
+	if pageSize == 0 || lastId == 0 {
+		return paginate(query, page, pageSize, 0, sort.Desc)
 	}
-	return builder.String(), kv
+	cond := fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", sort.By, cmp, sort.By, cmp)
+	return query.Where(cond, sort.After, sort.After, lastId).Limit(int(pageSize))
 }
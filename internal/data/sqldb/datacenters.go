@@ -19,7 +19,7 @@ func NewDatacentersRepoGorm(data *DataGorm, logger log.Logger) (repo.Datacenters
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Datacenter{}, repo.DatacenterTable); err != nil {
+	if err := requireTable(data.DB, repo.DatacenterTable); err != nil {
 		return nil, err
 	}
 
@@ -90,23 +90,14 @@ func (d *DatacentersRepoGorm) ListDatacenters(ctx context.Context,
 	filter *repo.DatacentersFilter) ([]*repo.Datacenter, error) {
 
 	dcs := []*repo.Datacenter{}
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
 		if len(filter.Names) > 0 {
-			nameConditions := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, name := range filter.Names {
-				params[i] = "%" + name + "%"
-			}
-			query = query.Where(nameConditions, params)
+			query = query.Where(matchNames("name", "", filter.Names))
 		}
 	}
 	r := query.Find(&dcs)
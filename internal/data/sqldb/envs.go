@@ -18,7 +18,7 @@ func NewEnvsRepoGorm(data *DataGorm, logger log.Logger) (repo.EnvsRepo, error) {
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.Env{}, repo.EnvTable); err != nil {
+	if err := requireTable(data.DB, repo.EnvTable); err != nil {
 		return nil, err
 	}
 	return &EnvsRepoGorm{
@@ -82,23 +82,14 @@ func (d *EnvsRepoGorm) ListEnvs(ctx context.Context,
 	filter *repo.EnvsFilter) ([]*repo.Env, error) {
 
 	envs := []*repo.Env{}
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
 		if len(filter.Names) > 0 {
-			nameConditions := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(nameConditions, params...)
+			query = query.Where(matchNames("name", "", filter.Names))
 		}
 	}
 	r := query.Find(&envs)
@@ -20,19 +20,10 @@ func NewFeaturesRepoGorm(data *DataGorm, logger log.Logger) (repo.FeaturesRepo,
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Feature{}, repo.FeatureTable); err != nil {
+	if err := requireTable(data.DB, repo.FeatureTable); err != nil {
 		return nil, err
 	}
 
-	// add description column if not exists
-	exists, err := data.ColumnExists(repo.FeatureTable, "description")
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		data.DB.AutoMigrate(&repo.Feature{})
-	}
-
 	return &FeaturesRepoGorm{
 		data: data,
 		log:  log.NewHelper(logger),
@@ -97,27 +88,17 @@ func (d *FeaturesRepoGorm) ListFeatures(ctx context.Context,
 	features := []*repo.Feature{}
 
 	var r *gorm.DB
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
 		if len(filter.Names) > 0 {
-			nameConditions := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(nameConditions, params...)
+			query = query.Where(matchNames("name", "", filter.Names))
 		}
 		if len(filter.Kvs) > 0 {
-			kvConditions, kvs := buildOrKV("name", "value", filter.Kvs)
-			query = query.Where(kvConditions, kvs...)
+			query = query.Where(matchKVs("name", "value", filter.Kvs))
 		}
 	}
 	r = query.Find(&features)
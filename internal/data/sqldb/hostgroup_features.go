@@ -16,7 +16,7 @@ func NewHostgroupFeaturesRepoGorm(data *DataGorm, logger log.Logger) (repo.Hostg
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.HostgroupFeature{}, repo.HostgroupFeatureTable); err != nil {
+	if err := requireTable(data.DB, repo.HostgroupFeatureTable); err != nil {
 		return nil, err
 	}
 	return &HostgroupFeaturesRepoGorm{
@@ -66,8 +66,7 @@ func (d *HostgroupFeaturesRepoGorm) ListHostgroupFeatures(ctx context.Context,
 		query = query.Where("feature_id in (?)", filter.FeatureIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("hostgroup_id", "feature_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("hostgroup_id", "feature_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
@@ -16,7 +16,7 @@ func NewHostgroupProductsRepoGorm(data *DataGorm, logger log.Logger) (repo.Hostg
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.HostgroupProduct{}, repo.HostgroupProductTable); err != nil {
+	if err := requireTable(data.DB, repo.HostgroupProductTable); err != nil {
 		return nil, err
 	}
 	return &HostgroupProductsRepoGorm{
@@ -66,8 +66,7 @@ func (d *HostgroupProductsRepoGorm) ListHostgroupProducts(ctx context.Context,
 		query = query.Where("product_id in (?)", filter.ProductIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("hostgroup_id", "product_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("hostgroup_id", "product_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
@@ -16,7 +16,7 @@ func NewHostgroupTagsRepoGorm(data *DataGorm, logger log.Logger) (repo.Hostgroup
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.HostgroupTag{}, repo.HostgroupTagTable); err != nil {
+	if err := requireTable(data.DB, repo.HostgroupTagTable); err != nil {
 		return nil, err
 	}
 	return &HostgroupTagsRepoGorm{
@@ -66,8 +66,7 @@ func (d *HostgroupTagsRepoGorm) ListHostgroupTags(ctx context.Context,
 		query = query.Where("tag_id in (?)", filter.TagIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("hostgroup_id", "tag_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("hostgroup_id", "tag_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
@@ -16,7 +16,7 @@ func NewHostgroupTeamsRepoGorm(data *DataGorm, logger log.Logger) (repo.Hostgrou
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.HostgroupTeam{}, repo.HostgroupTeamTable); err != nil {
+	if err := requireTable(data.DB, repo.HostgroupTeamTable); err != nil {
 		return nil, err
 	}
 	return &HostgroupTeamsRepoGorm{
@@ -66,8 +66,7 @@ func (d *HostgroupTeamsRepoGorm) ListHostgroupTeams(ctx context.Context,
 		query = query.Where("team_id in (?)", filter.TeamIds)
 	}
 	if len(filter.KVs) > 0 {
-		s_q, kvs := buildOrKV("hostgroup_id", "team_id", filter.KVs)
-		query = query.Where(s_q, kvs...)
+		query = query.Where(matchKVs("hostgroup_id", "team_id", filter.KVs))
 	}
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := int(filter.PageSize * (filter.Page - 1))
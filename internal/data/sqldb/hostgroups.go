@@ -8,6 +8,17 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 )
 
+// hostgroupSelections are the selection fields of hostgroups.
+var hostgroupSelections = map[string]selectTarget{
+	repo.SelectTeam:       {column: "team_id"},
+	repo.SelectProduct:    {column: "product_id"},
+	repo.SelectCluster:    {column: "cluster_id"},
+	repo.SelectDatacenter: {column: "datacenter_id"},
+	repo.SelectEnv:        {column: "env_id"},
+	repo.SelectTag:        {relTable: repo.HostgroupTagTable, refColumn: "hostgroup_id", column: "tag_id"},
+	repo.SelectFeature:    {relTable: repo.HostgroupFeatureTable, refColumn: "hostgroup_id", column: "feature_id"},
+}
+
 type HostgroupsRepoGorm struct {
 	data *DataGorm
 	log  *log.Helper
@@ -18,7 +29,7 @@ func NewHostgroupsRepoGorm(data *DataGorm, logger log.Logger) (repo.HostgroupsRe
 	if err := validateData(data); err != nil {
 		return nil, err
 	}
-	if err := initTable(data.DB, &repo.Hostgroup{}, repo.HostgroupTable); err != nil {
+	if err := requireTable(data.DB, repo.HostgroupTable); err != nil {
 		return nil, err
 	}
 	return &HostgroupsRepoGorm{
@@ -89,13 +100,11 @@ func (d *HostgroupsRepoGorm) ListHostgroups(ctx context.Context,
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
+		if len(filter.OrgIds) > 0 {
+			query = query.Where("org_id in (?)", filter.OrgIds)
+		}
 		if len(filter.Names) > 0 {
-			s_q := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(s_q, params...)
+			query = query.Where(matchNames("name", filter.NameMatch, filter.Names))
 		}
 		if len(filter.ProductsId) > 0 {
 			query = query.Where("product_id in (?)", filter.ProductsId)
@@ -112,10 +121,27 @@ func (d *HostgroupsRepoGorm) ListHostgroups(ctx context.Context,
 		if len(filter.TeamsId) > 0 {
 			query = query.Where("team_id in (?)", filter.TeamsId)
 		}
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset := int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
+		if len(filter.ExcludeTeamsId) > 0 {
+			query = query.Where("team_id not in (?)", filter.ExcludeTeamsId)
+		}
+		if len(filter.ExcludeProductsId) > 0 {
+			query = query.Where("product_id not in (?)", filter.ExcludeProductsId)
+		}
+		if filter.NoTags {
+			query = withoutRelated(query, repo.HostgroupTable, repo.HostgroupTagTable, "hostgroup_id")
+		}
+		if filter.NoFeatures {
+			query = withoutRelated(query, repo.HostgroupTable, repo.HostgroupFeatureTable, "hostgroup_id")
+		}
+		var err error
+		query, err = selectRows(query, repo.HostgroupTable, hostgroupSelections, filter.Selections)
+		if err != nil {
+			return nil, err
 		}
+		query = changedWithin(query, filter.ChangeFilter)
+		query = sortPage(query, filter.Sort, filter.Page, filter.PageSize, filter.LastId)
+	} else {
+		query = query.Order("id")
 	}
 	var hgs []*repo.Hostgroup
 	r := query.Find(&hgs)
@@ -0,0 +1,390 @@
+package sqldb
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrationsFS embed.FS
+
+const SchemaMigrationTable = "schema_migrations"
+
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+var ErrPendingMigrations = errors.New("database has pending migrations")
+var ErrInvalidMigration = errors.New("invalid migration")
+var ErrDirtyMigration = errors.New("database has a migration failed halfway")
+
+// SchemaMigration is a row of schema_migrations, one per applied version.
+// Dirty is set while the version is being applied or reverted, a row left dirty means the
+// script failed halfway on a database that commits DDL implicitly, like MySQL.
+type SchemaMigration struct {
+	Version   uint32 `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string `gorm:"column:name;type:varchar(255)"`
+	AppliedAt int64  `gorm:"column:applied_at;type:bigint"`
+	Dirty     bool   `gorm:"column:dirty;not null;default:false"`
+}
+
+func (SchemaMigration) TableName() string {
+	return SchemaMigrationTable
+}
+
+// Migration is an embedded migration of one driver.
+// file names follow <version>_<name>.up.sql and <version>_<name>.down.sql
+type Migration struct {
+	Version uint32
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a migration with its applied state.
+type MigrationStatus struct {
+	Version   uint32
+	Name      string
+	Applied   bool
+	AppliedAt int64
+	// Unknown means the version is recorded in database but not embedded in this binary
+	Unknown bool
+	// Dirty means the version failed halfway, see Force
+	Dirty bool
+}
+
+type Migrator struct {
+	data       *DataGorm
+	log        *log.Helper
+	migrations []*Migration
+}
+
+// NewMigrator loads the embedded migrations of data.Driver.
+func NewMigrator(data *DataGorm, logger log.Logger) (*Migrator, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	migrations, err := loadMigrations(data.Driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		data:       data,
+		log:        log.NewHelper(log.With(logger, "module", "migrate")),
+		migrations: migrations,
+	}, nil
+}
+
+func loadMigrations(driver string) ([]*Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationsFS, dir)
+	if err != nil {
+		return nil, ErrUnsupportedDatabaseDriver
+	}
+	byVersion := make(map[uint32]*Migration)
+	for _, e := range entries {
+		fname := e.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(fname, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fname, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+		base := strings.TrimSuffix(fname, "."+direction+".sql")
+		verStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, errors.Join(ErrInvalidMigration, fmt.Errorf("bad file name %s", fname))
+		}
+		ver, err := strconv.ParseUint(verStr, 10, 32)
+		if err != nil || ver == 0 {
+			return nil, errors.Join(ErrInvalidMigration, fmt.Errorf("bad version in %s", fname))
+		}
+		content, err := fs.ReadFile(migrationsFS, path.Join(dir, fname))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[uint32(ver)]
+		if !ok {
+			m = &Migration{Version: uint32(ver), Name: name}
+			byVersion[uint32(ver)] = m
+		}
+		if m.Name != name {
+			return nil, errors.Join(ErrInvalidMigration, fmt.Errorf("version %d has two names", ver))
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, errors.Join(ErrInvalidMigration,
+				fmt.Errorf("version %d must have both up and down", m.Version))
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// splitStatements splits a migration script into statements ended with ';'.
+// lines starting with '--' are comments.
+func splitStatements(script string) []string {
+	var stmts []string
+	var builder strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimed := strings.TrimSpace(line)
+		if trimed == "" || strings.HasPrefix(trimed, "--") {
+			continue
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+		if strings.HasSuffix(trimed, ";") {
+			stmts = append(stmts, strings.TrimSpace(builder.String()))
+			builder.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(builder.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	return m.data.DB.WithContext(ctx).AutoMigrate(&SchemaMigration{})
+}
+
+func (m *Migrator) applied(ctx context.Context) ([]*SchemaMigration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	var rows []*SchemaMigration
+	if err := m.data.DB.WithContext(ctx).Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Latest is the newest version embedded in this binary.
+func (m *Migrator) Latest() uint32 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version is the newest version applied to database.
+func (m *Migrator) Version(ctx context.Context) (uint32, error) {
+	rows, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[len(rows)-1].Version, nil
+}
+
+// Status lists embedded migrations and unknown versions found in database.
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	rows, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[uint32]*SchemaMigration, len(rows))
+	for _, r := range rows {
+		appliedAt[r.Version] = r
+	}
+	var status []*MigrationStatus
+	for _, mg := range m.migrations {
+		st := &MigrationStatus{Version: mg.Version, Name: mg.Name}
+		if r, ok := appliedAt[mg.Version]; ok {
+			st.Applied = true
+			st.AppliedAt = r.AppliedAt
+			st.Dirty = r.Dirty
+			delete(appliedAt, mg.Version)
+		}
+		status = append(status, st)
+	}
+	for _, r := range appliedAt {
+		status = append(status, &MigrationStatus{
+			Version:   r.Version,
+			Name:      r.Name,
+			Applied:   true,
+			AppliedAt: r.AppliedAt,
+			Unknown:   true,
+			Dirty:     r.Dirty,
+		})
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Version < status[j].Version
+	})
+	return status, nil
+}
+
+// Check returns ErrDirtyMigration if a migration failed halfway, ErrSchemaTooNew if database
+// has versions unknown to this binary, or ErrPendingMigrations if some embedded migrations
+// are not applied.
+func (m *Migrator) Check(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, st := range status {
+		if st.Dirty {
+			return errors.Join(ErrDirtyMigration, fmt.Errorf(
+				"version %d %s failed halfway, finish or undo it by hand and run `opspillar migrate force <version>`",
+				st.Version, st.Name))
+		}
+	}
+	for _, st := range status {
+		if st.Unknown {
+			return errors.Join(ErrSchemaTooNew,
+				fmt.Errorf("unknown version %d %s, latest known %d", st.Version, st.Name, m.Latest()))
+		}
+	}
+	for _, st := range status {
+		if !st.Applied {
+			return errors.Join(ErrPendingMigrations,
+				fmt.Errorf("version %d %s not applied", st.Version, st.Name))
+		}
+	}
+	return nil
+}
+
+// Up applies pending migrations up to target version. target 0 means latest.
+// return applied versions.
+func (m *Migrator) Up(ctx context.Context, target uint32) ([]uint32, error) {
+	if err := m.Check(ctx); err != nil && !errors.Is(err, ErrPendingMigrations) {
+		return nil, err
+	}
+	rows, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[uint32]bool, len(rows))
+	for _, r := range rows {
+		done[r.Version] = true
+	}
+	var versions []uint32
+	for _, mg := range m.migrations {
+		if target > 0 && mg.Version > target {
+			break
+		}
+		if done[mg.Version] {
+			continue
+		}
+		// the version is recorded dirty before the script, which may commit on its own
+		row := &SchemaMigration{Version: mg.Version, Name: mg.Name, AppliedAt: time.Now().Unix(), Dirty: true}
+		if err := m.data.DB.WithContext(ctx).Create(row).Error; err != nil {
+			return versions, errors.Join(fmt.Errorf("migrate up %d %s failed", mg.Version, mg.Name), err)
+		}
+		err := m.data.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, stmt := range splitStatements(mg.Up) {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Model(row).Update("dirty", false).Error
+		})
+		if err != nil {
+			if m.transactionalDDL() {
+				// the script was rolled back, the version is simply not applied
+				err = errors.Join(err, m.data.DB.WithContext(ctx).Delete(row).Error)
+			}
+			return versions, errors.Join(fmt.Errorf("migrate up %d %s failed", mg.Version, mg.Name), err)
+		}
+		m.log.Infof("migrate up %d %s", mg.Version, mg.Name)
+		versions = append(versions, mg.Version)
+	}
+	return versions, nil
+}
+
+// Down reverts the newest steps applied migrations.
+// return reverted versions.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]uint32, error) {
+	if err := m.Check(ctx); err != nil && !errors.Is(err, ErrPendingMigrations) {
+		return nil, err
+	}
+	rows, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[uint32]*Migration, len(m.migrations))
+	for _, mg := range m.migrations {
+		known[mg.Version] = mg
+	}
+	var versions []uint32
+	for i := len(rows) - 1; i >= 0 && len(versions) < steps; i-- {
+		mg := known[rows[i].Version]
+		row := rows[i]
+		if err := m.data.DB.WithContext(ctx).Model(row).Update("dirty", true).Error; err != nil {
+			return versions, errors.Join(fmt.Errorf("migrate down %d %s failed", mg.Version, mg.Name), err)
+		}
+		err := m.data.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, stmt := range splitStatements(mg.Down) {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Delete(&SchemaMigration{}, mg.Version).Error
+		})
+		if err != nil {
+			if m.transactionalDDL() {
+				err = errors.Join(err, m.data.DB.WithContext(ctx).Model(row).Update("dirty", false).Error)
+			}
+			return versions, errors.Join(fmt.Errorf("migrate down %d %s failed", mg.Version, mg.Name), err)
+		}
+		m.log.Infof("migrate down %d %s", mg.Version, mg.Name)
+		versions = append(versions, mg.Version)
+	}
+	return versions, nil
+}
+
+// Force records version as the applied schema, clean. It is run by hand after finishing or
+// undoing a dirty migration: versions after version are forgotten, version 0 forgets all.
+func (m *Migrator) Force(ctx context.Context, version uint32) error {
+	var mg *Migration
+	for _, known := range m.migrations {
+		if known.Version == version {
+			mg = known
+		}
+	}
+	if version > 0 && mg == nil {
+		return errors.Join(ErrInvalidMigration, fmt.Errorf("unknown version %d", version))
+	}
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+	return m.data.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version > ?", version).Delete(&SchemaMigration{}).Error; err != nil {
+			return err
+		}
+		if mg == nil {
+			return nil
+		}
+		m.log.Infof("migrate force %d %s", mg.Version, mg.Name)
+		return tx.Save(&SchemaMigration{Version: mg.Version, Name: mg.Name, AppliedAt: time.Now().Unix()}).Error
+	})
+}
+
+// transactionalDDL tells whether scripts of the driver roll back as a whole. MySQL commits
+// each DDL statement implicitly, so a failed script may leave part of it applied.
+func (m *Migrator) transactionalDDL() bool {
+	return m.data.Driver == "sqlite"
+}
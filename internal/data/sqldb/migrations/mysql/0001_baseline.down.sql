@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS `users`;
+DROP TABLE IF EXISTS `hostgroup_features`;
+DROP TABLE IF EXISTS `hostgroup_tags`;
+DROP TABLE IF EXISTS `hostgroup_products`;
+DROP TABLE IF EXISTS `hostgroup_teams`;
+DROP TABLE IF EXISTS `app_hostgroups`;
+DROP TABLE IF EXISTS `app_features`;
+DROP TABLE IF EXISTS `app_tags`;
+DROP TABLE IF EXISTS `applications`;
+DROP TABLE IF EXISTS `hostgroups`;
+DROP TABLE IF EXISTS `datacenters`;
+DROP TABLE IF EXISTS `clusters`;
+DROP TABLE IF EXISTS `envs`;
+DROP TABLE IF EXISTS `products`;
+DROP TABLE IF EXISTS `teams`;
+DROP TABLE IF EXISTS `tags`;
+DROP TABLE IF EXISTS `features`;
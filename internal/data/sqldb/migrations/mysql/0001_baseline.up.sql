@@ -0,0 +1,166 @@
+-- baseline schema, equal to what AutoMigrate created before versioned migrations.
+-- every statement is idempotent so existing databases can be adopted as version 1.
+CREATE TABLE IF NOT EXISTS `features` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `value` varchar(255),
+  `description` text,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_feature_name_value` (`name`,`value`)
+);
+
+CREATE TABLE IF NOT EXISTS `tags` (
+  `id` int unsigned AUTO_INCREMENT,
+  `key` varchar(255),
+  `value` varchar(255),
+  `description` text,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_key_value` (`key`,`value`)
+);
+
+CREATE TABLE IF NOT EXISTS `teams` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `code` varchar(255),
+  `leader_id` int unsigned,
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_team_name` (`name`),
+  UNIQUE INDEX `idx_team_code` (`code`),
+  INDEX `idx_team_leader_id` (`leader_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `products` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `code` varchar(255),
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_product_name` (`name`),
+  UNIQUE INDEX `idx_product_code` (`code`)
+);
+
+CREATE TABLE IF NOT EXISTS `envs` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_env_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `clusters` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_cluster_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `datacenters` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_dc_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `hostgroups` (
+  `created_at,type:bigint` bigint,
+  `updated_at,type:bigint` bigint,
+  `created_by,type:varchar(255)` longtext,
+  `updated_by,type:varchar(255)` longtext,
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `description` varchar(255),
+  `cluster_id` int unsigned,
+  `datacenter_id` int unsigned,
+  `env_id` int unsigned,
+  `product_id` int unsigned,
+  `team_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_hg_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `applications` (
+  `created_at,type:bigint` bigint,
+  `updated_at,type:bigint` bigint,
+  `created_by,type:varchar(255)` longtext,
+  `updated_by,type:varchar(255)` longtext,
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `description` varchar(255),
+  `owner_id` int unsigned,
+  `is_stateful` tinyint(1),
+  `product_id` int unsigned,
+  `team_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_app_name_env` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `app_tags` (
+  `id` int unsigned AUTO_INCREMENT,
+  `app_id` int unsigned,
+  `tag_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_app_id_tag_id` (`app_id`,`tag_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `app_features` (
+  `id` int unsigned AUTO_INCREMENT,
+  `app_id` int unsigned,
+  `feature_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_app_id_feature_id` (`app_id`,`feature_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `app_hostgroups` (
+  `id` int unsigned AUTO_INCREMENT,
+  `app_id` int unsigned,
+  `hostgroup_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_app_id_hostgroup_id` (`app_id`,`hostgroup_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_teams` (
+  `id` int unsigned AUTO_INCREMENT,
+  `hostgroup_id` int unsigned,
+  `team_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_hostgroup_id_team_id` (`hostgroup_id`,`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_products` (
+  `id` int unsigned AUTO_INCREMENT,
+  `hostgroup_id` int unsigned,
+  `product_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_hostgroup_id_product_id` (`hostgroup_id`,`product_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_tags` (
+  `id` int unsigned AUTO_INCREMENT,
+  `hostgroup_id` int unsigned,
+  `tag_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_hostgroup_id_tag_id` (`hostgroup_id`,`tag_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_features` (
+  `id` int unsigned AUTO_INCREMENT,
+  `hostgroup_id` int unsigned,
+  `feature_id` int unsigned,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_hostgroup_id_feature_id` (`hostgroup_id`,`feature_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` int unsigned AUTO_INCREMENT,
+  `user_name` varchar(191) NOT NULL,
+  `password` longtext NOT NULL,
+  `email` varchar(191),
+  `phone` varchar(191),
+  PRIMARY KEY (`id`),
+  CONSTRAINT `uni_users_user_name` UNIQUE (`user_name`),
+  CONSTRAINT `uni_users_email` UNIQUE (`email`),
+  CONSTRAINT `uni_users_phone` UNIQUE (`phone`)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE `applications` RENAME COLUMN `updated_by` TO `updated_by,type:varchar(255)`;
+ALTER TABLE `applications` RENAME COLUMN `created_by` TO `created_by,type:varchar(255)`;
+ALTER TABLE `applications` RENAME COLUMN `updated_at` TO `updated_at,type:bigint`;
+ALTER TABLE `applications` RENAME COLUMN `created_at` TO `created_at,type:bigint`;
+ALTER TABLE `hostgroups` RENAME COLUMN `updated_by` TO `updated_by,type:varchar(255)`;
+ALTER TABLE `hostgroups` RENAME COLUMN `created_by` TO `created_by,type:varchar(255)`;
+ALTER TABLE `hostgroups` RENAME COLUMN `updated_at` TO `updated_at,type:bigint`;
+ALTER TABLE `hostgroups` RENAME COLUMN `created_at` TO `created_at,type:bigint`;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `revoked_users`;
+DROP TABLE IF EXISTS `revoked_tokens`;
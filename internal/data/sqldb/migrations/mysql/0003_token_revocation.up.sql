@@ -0,0 +1,14 @@
+-- tokens revoked by logout, kept until they expire.
+CREATE TABLE IF NOT EXISTS `revoked_tokens` (
+  `jti` varchar(64),
+  `expires_at` bigint,
+  PRIMARY KEY (`jti`),
+  INDEX `idx_revoked_token_expires_at` (`expires_at`)
+);
+
+-- tokens of a user issued before revoked_at are revoked.
+CREATE TABLE IF NOT EXISTS `revoked_users` (
+  `user_id` varchar(64),
+  `revoked_at` bigint,
+  PRIMARY KEY (`user_id`)
+);
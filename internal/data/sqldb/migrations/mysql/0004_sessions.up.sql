@@ -0,0 +1,16 @@
+-- login sessions with rotating refresh tokens.
+CREATE TABLE IF NOT EXISTS `sessions` (
+  `id` int unsigned AUTO_INCREMENT,
+  `user_id` int unsigned,
+  `hash` varchar(64),
+  `prev_hash` varchar(64),
+  `device` varchar(255),
+  `ip` varchar(64),
+  `created_at` bigint,
+  `last_used_at` bigint,
+  `expires_at` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_session_user_id` (`user_id`),
+  UNIQUE INDEX `idx_session_hash` (`hash`),
+  INDEX `idx_session_prev_hash` (`prev_hash`)
+);
@@ -0,0 +1,29 @@
+-- service accounts and their api keys.
+CREATE TABLE IF NOT EXISTS `service_accounts` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `team_id` int unsigned,
+  `role` varchar(255),
+  `description` varchar(255),
+  `created_by` varchar(255),
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_service_account_name` (`name`),
+  INDEX `idx_service_account_team_id` (`team_id`)
+);
+
+CREATE TABLE IF NOT EXISTS `api_keys` (
+  `id` int unsigned AUTO_INCREMENT,
+  `service_account_id` int unsigned,
+  `name` varchar(255),
+  `prefix` varchar(16),
+  `hash` varchar(64),
+  `scopes` varchar(1024),
+  `description` varchar(255),
+  `expires_at` bigint,
+  `last_used_at` bigint,
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_api_key_service_account_id` (`service_account_id`),
+  UNIQUE INDEX `idx_api_key_hash` (`hash`)
+);
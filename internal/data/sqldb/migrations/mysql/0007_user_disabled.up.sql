@@ -0,0 +1,2 @@
+-- users removed from the directory are disabled instead of deleted, to keep their history.
+ALTER TABLE `users` ADD COLUMN `disabled` boolean NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `break_glass_audits`;
+DROP TABLE IF EXISTS `break_glass_credentials`;
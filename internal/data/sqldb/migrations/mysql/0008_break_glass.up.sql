@@ -0,0 +1,33 @@
+-- break-glass credentials replace the emergency header, every use is audited.
+CREATE TABLE IF NOT EXISTS `break_glass_credentials` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `principal` varchar(255),
+  `prefix` varchar(16),
+  `hash` varchar(64),
+  `one_time` tinyint(1),
+  `expire_minutes` int unsigned,
+  `description` varchar(255),
+  `expires_at` bigint,
+  `first_used_at` bigint,
+  `created_by` varchar(255),
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_break_glass_name` (`name`),
+  UNIQUE INDEX `idx_break_glass_hash` (`hash`)
+);
+
+CREATE TABLE IF NOT EXISTS `break_glass_audits` (
+  `id` int unsigned AUTO_INCREMENT,
+  `credential_id` int unsigned,
+  `name` varchar(255),
+  `principal` varchar(255),
+  `operation` varchar(255),
+  `ip` varchar(64),
+  `allowed` tinyint(1),
+  `reason` varchar(255),
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_break_glass_audit_credential_id` (`credential_id`),
+  INDEX `idx_break_glass_audit_created_at` (`created_at`)
+);
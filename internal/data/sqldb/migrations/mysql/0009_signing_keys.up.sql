@@ -0,0 +1,12 @@
+-- asymmetric keys signing tokens, rotated by `opspillar keys rotate`.
+CREATE TABLE IF NOT EXISTS `signing_keys` (
+  `id` int unsigned AUTO_INCREMENT,
+  `kid` varchar(64),
+  `algorithm` varchar(16),
+  `private_key` text,
+  `public_key` text,
+  `created_at` bigint,
+  `expires_at` bigint,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_signing_key_kid` (`kid`)
+);
@@ -0,0 +1,14 @@
+-- changes of casbin rules and groups made through the authz api.
+CREATE TABLE IF NOT EXISTS `authz_audits` (
+  `id` int unsigned AUTO_INCREMENT,
+  `operator` varchar(255),
+  `operation` varchar(32),
+  `sub` varchar(255),
+  `resource` varchar(255),
+  `action` varchar(32),
+  `role` varchar(255),
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_authz_audit_sub` (`sub`),
+  INDEX `idx_authz_audit_created_at` (`created_at`)
+);
@@ -0,0 +1,14 @@
+-- team members with roles, leaders of existing teams become their first members.
+CREATE TABLE IF NOT EXISTS `team_members` (
+  `id` int unsigned AUTO_INCREMENT,
+  `team_id` int unsigned,
+  `user_id` int unsigned,
+  `role` varchar(32),
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_team_member_team_id_user_id` (`team_id`, `user_id`),
+  INDEX `idx_team_member_user_id` (`user_id`)
+);
+
+INSERT INTO `team_members` (`team_id`, `user_id`, `role`, `created_at`)
+SELECT `id`, `leader_id`, 'leader', 0 FROM `teams` WHERE `leader_id` > 0;
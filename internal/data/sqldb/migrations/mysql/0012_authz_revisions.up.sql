@@ -0,0 +1,9 @@
+-- revision of casbin rules, bumped with every change so other instances reload them.
+CREATE TABLE IF NOT EXISTS `authz_revisions` (
+  `id` int unsigned,
+  `revision` bigint NOT NULL DEFAULT 0,
+  `updated_at` bigint,
+  PRIMARY KEY (`id`)
+);
+
+INSERT INTO `authz_revisions` (`id`, `revision`, `updated_at`) VALUES (1, 0, 0);
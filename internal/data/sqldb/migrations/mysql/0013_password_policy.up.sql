@@ -0,0 +1,17 @@
+-- failed logins lock users for a while, passwords expire by policy and are reset with one-time tokens.
+ALTER TABLE `users` ADD COLUMN `password_changed_at` bigint NOT NULL DEFAULT 0;
+ALTER TABLE `users` ADD COLUMN `failed_logins` int NOT NULL DEFAULT 0;
+ALTER TABLE `users` ADD COLUMN `locked_until` bigint NOT NULL DEFAULT 0;
+UPDATE `users` SET `password_changed_at` = UNIX_TIMESTAMP();
+CREATE TABLE IF NOT EXISTS `password_reset_tokens` (
+  `id` int unsigned AUTO_INCREMENT,
+  `user_id` int unsigned,
+  `hash` varchar(64),
+  `expires_at` bigint,
+  `used_at` bigint NOT NULL DEFAULT 0,
+  `created_by` varchar(255),
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_password_reset_token_hash` (`hash`),
+  INDEX `idx_password_reset_token_user_id` (`user_id`)
+);
@@ -0,0 +1,14 @@
+-- TOTP multi-factor authentication of local users, recovery codes are stored hashed.
+ALTER TABLE `users` ADD COLUMN `mfa_secret` varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE `users` ADD COLUMN `mfa_enabled` boolean NOT NULL DEFAULT false;
+ALTER TABLE `users` ADD COLUMN `mfa_last_step` bigint NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS `mfa_recovery_codes` (
+  `id` int unsigned AUTO_INCREMENT,
+  `user_id` int unsigned,
+  `hash` varchar(64),
+  `used_at` bigint NOT NULL DEFAULT 0,
+  `created_at` bigint,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_mfa_recovery_code_hash` (`hash`),
+  INDEX `idx_mfa_recovery_code_user_id` (`user_id`)
+);
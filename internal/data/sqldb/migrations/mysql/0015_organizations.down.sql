@@ -0,0 +1,14 @@
+-- rules of other organizations than default lose their org section, names duplicated
+-- across organizations fail the unique indexes.
+UPDATE `casbin_rule` SET `v1` = CONCAT('v1/', SUBSTRING(`v1`, LOCATE('/', `v1`, 4) + 1)) WHERE `ptype` = 'p' AND `v1` LIKE 'v1/%';
+ALTER TABLE `applications` DROP INDEX `idx_app_org_name`, DROP COLUMN `org_id`,
+  ADD UNIQUE INDEX `idx_app_name_env` (`name`);
+ALTER TABLE `hostgroups` DROP INDEX `idx_hg_org_name`, DROP COLUMN `org_id`,
+  ADD UNIQUE INDEX `idx_hg_name` (`name`);
+ALTER TABLE `products` DROP INDEX `idx_product_org_name`, DROP INDEX `idx_product_org_code`, DROP COLUMN `org_id`,
+  ADD UNIQUE INDEX `idx_product_name` (`name`),
+  ADD UNIQUE INDEX `idx_product_code` (`code`);
+ALTER TABLE `teams` DROP INDEX `idx_team_org_name`, DROP INDEX `idx_team_org_code`, DROP COLUMN `org_id`,
+  ADD UNIQUE INDEX `idx_team_name` (`name`),
+  ADD UNIQUE INDEX `idx_team_code` (`code`);
+DROP TABLE IF EXISTS `organizations`;
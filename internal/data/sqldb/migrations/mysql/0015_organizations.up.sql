@@ -0,0 +1,40 @@
+-- organizations are namespaces of teams, products, hostgroups and applications, existing ones
+-- move to the default organization.
+CREATE TABLE IF NOT EXISTS `organizations` (
+  `id` int unsigned AUTO_INCREMENT,
+  `name` varchar(255),
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_org_name` (`name`)
+);
+INSERT INTO `organizations` (`id`, `name`, `description`) VALUES (1, 'default', 'default organization');
+ALTER TABLE `teams` ADD COLUMN `org_id` int unsigned NOT NULL DEFAULT 1,
+  DROP INDEX `idx_team_name`,
+  DROP INDEX `idx_team_code`,
+  ADD UNIQUE INDEX `idx_team_org_name` (`org_id`, `name`),
+  ADD UNIQUE INDEX `idx_team_org_code` (`org_id`, `code`);
+ALTER TABLE `products` ADD COLUMN `org_id` int unsigned NOT NULL DEFAULT 1,
+  DROP INDEX `idx_product_name`,
+  DROP INDEX `idx_product_code`,
+  ADD UNIQUE INDEX `idx_product_org_name` (`org_id`, `name`),
+  ADD UNIQUE INDEX `idx_product_org_code` (`org_id`, `code`);
+ALTER TABLE `hostgroups` ADD COLUMN `org_id` int unsigned NOT NULL DEFAULT 1,
+  DROP INDEX `idx_hg_name`,
+  ADD UNIQUE INDEX `idx_hg_org_name` (`org_id`, `name`);
+ALTER TABLE `applications` ADD COLUMN `org_id` int unsigned NOT NULL DEFAULT 1,
+  DROP INDEX `idx_app_name_env`,
+  ADD UNIQUE INDEX `idx_app_org_name` (`org_id`, `name`);
+-- casbin resources gain the org section after v1, rules naming a team are of the default
+-- organization. casbin_rule is created by the adapter on first start if missing.
+CREATE TABLE IF NOT EXISTS `casbin_rule` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `ptype` varchar(100),
+  `v0` varchar(100),
+  `v1` varchar(100),
+  `v2` varchar(100),
+  `v3` varchar(100),
+  `v4` varchar(100),
+  `v5` varchar(100),
+  PRIMARY KEY (`id`)
+);
+UPDATE `casbin_rule` SET `v1` = CONCAT(CASE WHEN `v1` LIKE 'v1/%/{team}/%' THEN 'v1/{org}/' ELSE 'v1/default/' END, SUBSTRING(`v1`, 4)) WHERE `ptype` = 'p' AND `v1` LIKE 'v1/%';
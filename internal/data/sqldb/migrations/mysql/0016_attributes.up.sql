@@ -0,0 +1,24 @@
+-- admin defined custom attributes of applications and hostgroups with their values.
+CREATE TABLE IF NOT EXISTS `attributes` (
+  `id` int unsigned AUTO_INCREMENT,
+  `kind` varchar(64),
+  `name` varchar(255),
+  `type` varchar(32),
+  `required` boolean,
+  `enum_values` varchar(1024),
+  `pattern` varchar(255),
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_attr_kind_name` (`kind`, `name`)
+);
+
+CREATE TABLE IF NOT EXISTS `attribute_values` (
+  `id` int unsigned AUTO_INCREMENT,
+  `kind` varchar(64),
+  `entity_id` int unsigned,
+  `name` varchar(255),
+  `value` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_attr_value_entity` (`kind`, `entity_id`, `name`),
+  INDEX `idx_attr_value_name` (`kind`, `name`, `value`)
+);
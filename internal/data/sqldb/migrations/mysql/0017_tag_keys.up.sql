@@ -0,0 +1,12 @@
+-- registry of governed tag keys with their allowed values and entity kinds.
+CREATE TABLE IF NOT EXISTS `tag_keys` (
+  `id` int unsigned AUTO_INCREMENT,
+  `key` varchar(255),
+  `description` varchar(255),
+  `allowed_values` varchar(1024),
+  `pattern` varchar(255),
+  `kinds` varchar(255),
+  `required` boolean,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_tag_key` (`key`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `applications` DROP INDEX `idx_app_created_at`, DROP INDEX `idx_app_updated_at`;
+ALTER TABLE `hostgroups` DROP INDEX `idx_hg_created_at`, DROP INDEX `idx_hg_updated_at`;
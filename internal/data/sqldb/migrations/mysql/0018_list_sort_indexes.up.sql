@@ -0,0 +1,7 @@
+-- hostgroups and applications are listed sorted or ranged by change times.
+ALTER TABLE `hostgroups`
+  ADD INDEX `idx_hg_created_at` (`created_at`),
+  ADD INDEX `idx_hg_updated_at` (`updated_at`);
+ALTER TABLE `applications`
+  ADD INDEX `idx_app_created_at` (`created_at`),
+  ADD INDEX `idx_app_updated_at` (`updated_at`);
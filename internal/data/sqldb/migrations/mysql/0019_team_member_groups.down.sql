@@ -0,0 +1 @@
+-- users stay members of their teams, the bare team groups are not restored.
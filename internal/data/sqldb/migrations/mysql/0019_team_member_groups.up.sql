@@ -0,0 +1,34 @@
+-- sso and directory sync grouped users into bare team names and granted teams write on their
+-- resources, users become members of the teams instead. groups of teams with rules of their
+-- own, as admin-team, are kept.
+DELETE FROM `casbin_rule` WHERE `ptype` = 'p' AND `v2` = 'write' AND `v0` IN (SELECT `name` FROM `teams` WHERE `org_id` = 1)
+  AND `v1` = CONCAT('v1/default/{resource}/', `v0`, '/{resource_id}/{user}');
+INSERT INTO `team_members` (`team_id`, `user_id`, `role`, `created_at`)
+SELECT `t`.`id`, `u`.`id`, 'member', UNIX_TIMESTAMP() FROM `casbin_rule` `g`
+  JOIN `teams` `t` ON `t`.`org_id` = 1 AND `t`.`name` = `g`.`v1`
+  JOIN `users` `u` ON `u`.`user_name` = `g`.`v0`
+  WHERE `g`.`ptype` = 'g' AND NOT EXISTS (SELECT 1 FROM `casbin_rule` `p` WHERE `p`.`ptype` = 'p' AND `p`.`v0` = `g`.`v1`)
+  AND NOT EXISTS (SELECT 1 FROM `team_members` `m` WHERE `m`.`team_id` = `t`.`id` AND `m`.`user_id` = `u`.`id`);
+INSERT INTO `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`)
+SELECT DISTINCT 'g', `u`.`user_name`, CONCAT(`t`.`name`, ':', `m`.`role`), '', '', '', '' FROM `casbin_rule` `g`
+  JOIN `teams` `t` ON `t`.`org_id` = 1 AND `t`.`name` = `g`.`v1`
+  JOIN `users` `u` ON `u`.`user_name` = `g`.`v0`
+  JOIN `team_members` `m` ON `m`.`team_id` = `t`.`id` AND `m`.`user_id` = `u`.`id`
+  WHERE `g`.`ptype` = 'g' AND NOT EXISTS (SELECT 1 FROM `casbin_rule` `p` WHERE `p`.`ptype` = 'p' AND `p`.`v0` = `g`.`v1`)
+  AND NOT EXISTS (SELECT 1 FROM `casbin_rule` `e` WHERE `e`.`ptype` = 'g' AND `e`.`v0` = `u`.`user_name` AND `e`.`v1` = CONCAT(`t`.`name`, ':', `m`.`role`));
+-- roles of the teams, as created by the team members api
+INSERT INTO `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`)
+SELECT `r`.`ptype`, `r`.`v0`, `r`.`v1`, `r`.`v2`, '', '', '' FROM (
+  SELECT `name` AS `team`, 'g' AS `ptype`, CONCAT(`name`, ':leader') AS `v0`, CONCAT(`name`, ':maintainer') AS `v1`, '' AS `v2` FROM `teams` WHERE `org_id` = 1
+  UNION SELECT `name`, 'g', CONCAT(`name`, ':maintainer'), CONCAT(`name`, ':member'), '' FROM `teams` WHERE `org_id` = 1
+  UNION SELECT `name`, 'p', CONCAT(`name`, ':maintainer'), CONCAT('v1/default/{resource}/', `name`, '/{resource_id}/{user}'), 'write' FROM `teams` WHERE `org_id` = 1
+  UNION SELECT `name`, 'p', CONCAT(`name`, ':member'), CONCAT('v1/default/{resource}/', `name`, '/{resource_id}/{user}'), 'read' FROM `teams` WHERE `org_id` = 1
+) `r` WHERE EXISTS (SELECT 1 FROM `casbin_rule` `g` JOIN `users` `u` ON `u`.`user_name` = `g`.`v0`
+    WHERE `g`.`ptype` = 'g' AND `g`.`v1` = `r`.`team`
+    AND NOT EXISTS (SELECT 1 FROM `casbin_rule` `p` WHERE `p`.`ptype` = 'p' AND `p`.`v0` = `g`.`v1`))
+  AND NOT EXISTS (SELECT 1 FROM `casbin_rule` `e` WHERE `e`.`ptype` = `r`.`ptype` AND `e`.`v0` = `r`.`v0` AND `e`.`v1` = `r`.`v1` AND `e`.`v2` = `r`.`v2`);
+DELETE `g` FROM `casbin_rule` `g`
+  JOIN `teams` `t` ON `t`.`org_id` = 1 AND `t`.`name` = `g`.`v1`
+  JOIN `users` `u` ON `u`.`user_name` = `g`.`v0`
+  LEFT JOIN `casbin_rule` `p` ON `p`.`ptype` = 'p' AND `p`.`v0` = `g`.`v1`
+  WHERE `g`.`ptype` = 'g' AND `p`.`id` IS NULL;
@@ -0,0 +1,54 @@
+-- baseline schema, equal to what AutoMigrate created before versioned migrations.
+-- every statement is idempotent so existing databases can be adopted as version 1.
+CREATE TABLE IF NOT EXISTS `features` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`value` varchar(255),`description` text);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_feature_name_value` ON `features`(`name`,`value`);
+
+CREATE TABLE IF NOT EXISTS `tags` (`id` integer PRIMARY KEY AUTOINCREMENT,`key` varchar(255),`value` varchar(255),`description` text);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_key_value` ON `tags`(`key`,`value`);
+
+CREATE TABLE IF NOT EXISTS `teams` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`code` varchar(255),`leader_id` integer,`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_code` ON `teams`(`code`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_name` ON `teams`(`name`);
+CREATE INDEX IF NOT EXISTS `idx_team_leader_id` ON `teams`(`leader_id`);
+
+CREATE TABLE IF NOT EXISTS `products` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`code` varchar(255),`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_product_name` ON `products`(`name`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_product_code` ON `products`(`code`);
+
+CREATE TABLE IF NOT EXISTS `envs` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_env_name` ON `envs`(`name`);
+
+CREATE TABLE IF NOT EXISTS `clusters` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_cluster_name` ON `clusters`(`name`);
+
+CREATE TABLE IF NOT EXISTS `datacenters` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_dc_name` ON `datacenters`(`name`);
+
+CREATE TABLE IF NOT EXISTS `hostgroups` (`created_at,type:bigint` integer,`updated_at,type:bigint` integer,`created_by,type:varchar(255)` text,`updated_by,type:varchar(255)` text,`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`description` varchar(255),`cluster_id` integer,`datacenter_id` integer,`env_id` integer,`product_id` integer,`team_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hg_name` ON `hostgroups`(`name`);
+
+CREATE TABLE IF NOT EXISTS `applications` (`created_at,type:bigint` integer,`updated_at,type:bigint` integer,`created_by,type:varchar(255)` text,`updated_by,type:varchar(255)` text,`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`description` varchar(255),`owner_id` integer,`is_stateful` tinyint(1),`product_id` integer,`team_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_app_name_env` ON `applications`(`name`);
+
+CREATE TABLE IF NOT EXISTS `app_tags` (`id` integer PRIMARY KEY AUTOINCREMENT,`app_id` integer,`tag_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_app_id_tag_id` ON `app_tags`(`app_id`,`tag_id`);
+
+CREATE TABLE IF NOT EXISTS `app_features` (`id` integer PRIMARY KEY AUTOINCREMENT,`app_id` integer,`feature_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_app_id_feature_id` ON `app_features`(`app_id`,`feature_id`);
+
+CREATE TABLE IF NOT EXISTS `app_hostgroups` (`id` integer PRIMARY KEY AUTOINCREMENT,`app_id` integer,`hostgroup_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_app_id_hostgroup_id` ON `app_hostgroups`(`app_id`,`hostgroup_id`);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_teams` (`id` integer PRIMARY KEY AUTOINCREMENT,`hostgroup_id` integer,`team_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hostgroup_id_team_id` ON `hostgroup_teams`(`hostgroup_id`,`team_id`);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_products` (`id` integer PRIMARY KEY AUTOINCREMENT,`hostgroup_id` integer,`product_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hostgroup_id_product_id` ON `hostgroup_products`(`hostgroup_id`,`product_id`);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_tags` (`id` integer PRIMARY KEY AUTOINCREMENT,`hostgroup_id` integer,`tag_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hostgroup_id_tag_id` ON `hostgroup_tags`(`hostgroup_id`,`tag_id`);
+
+CREATE TABLE IF NOT EXISTS `hostgroup_features` (`id` integer PRIMARY KEY AUTOINCREMENT,`hostgroup_id` integer,`feature_id` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hostgroup_id_feature_id` ON `hostgroup_features`(`hostgroup_id`,`feature_id`);
+
+CREATE TABLE IF NOT EXISTS `users` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_name` text NOT NULL,`password` text NOT NULL,`email` text,`phone` text,CONSTRAINT `uni_users_user_name` UNIQUE (`user_name`),CONSTRAINT `uni_users_email` UNIQUE (`email`),CONSTRAINT `uni_users_phone` UNIQUE (`phone`));
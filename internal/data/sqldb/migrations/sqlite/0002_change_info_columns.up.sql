@@ -0,0 +1,10 @@
+-- ChangeInfo columns were created with broken names like `created_at,type:bigint`
+-- because of a malformed gorm tag. rename them to the intended names.
+ALTER TABLE `hostgroups` RENAME COLUMN `created_at,type:bigint` TO `created_at`;
+ALTER TABLE `hostgroups` RENAME COLUMN `updated_at,type:bigint` TO `updated_at`;
+ALTER TABLE `hostgroups` RENAME COLUMN `created_by,type:varchar(255)` TO `created_by`;
+ALTER TABLE `hostgroups` RENAME COLUMN `updated_by,type:varchar(255)` TO `updated_by`;
+ALTER TABLE `applications` RENAME COLUMN `created_at,type:bigint` TO `created_at`;
+ALTER TABLE `applications` RENAME COLUMN `updated_at,type:bigint` TO `updated_at`;
+ALTER TABLE `applications` RENAME COLUMN `created_by,type:varchar(255)` TO `created_by`;
+ALTER TABLE `applications` RENAME COLUMN `updated_by,type:varchar(255)` TO `updated_by`;
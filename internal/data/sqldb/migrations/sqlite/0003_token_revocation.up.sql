@@ -0,0 +1,5 @@
+-- tokens revoked by logout, kept until they expire.
+CREATE TABLE IF NOT EXISTS `revoked_tokens` (`jti` varchar(64),`expires_at` integer,PRIMARY KEY (`jti`));
+CREATE INDEX IF NOT EXISTS `idx_revoked_token_expires_at` ON `revoked_tokens`(`expires_at`);
+-- tokens of a user issued before revoked_at are revoked.
+CREATE TABLE IF NOT EXISTS `revoked_users` (`user_id` varchar(64),`revoked_at` integer,PRIMARY KEY (`user_id`));
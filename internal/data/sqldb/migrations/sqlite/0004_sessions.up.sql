@@ -0,0 +1,5 @@
+-- login sessions with rotating refresh tokens.
+CREATE TABLE IF NOT EXISTS `sessions` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`hash` varchar(64),`prev_hash` varchar(64),`device` varchar(255),`ip` varchar(64),`created_at` integer,`last_used_at` integer,`expires_at` integer);
+CREATE INDEX IF NOT EXISTS `idx_session_user_id` ON `sessions`(`user_id`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_session_hash` ON `sessions`(`hash`);
+CREATE INDEX IF NOT EXISTS `idx_session_prev_hash` ON `sessions`(`prev_hash`);
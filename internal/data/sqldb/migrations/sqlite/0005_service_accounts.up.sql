@@ -0,0 +1,7 @@
+-- service accounts and their api keys.
+CREATE TABLE IF NOT EXISTS `service_accounts` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`team_id` integer,`role` varchar(255),`description` varchar(255),`created_by` varchar(255),`created_at` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_service_account_name` ON `service_accounts`(`name`);
+CREATE INDEX IF NOT EXISTS `idx_service_account_team_id` ON `service_accounts`(`team_id`);
+CREATE TABLE IF NOT EXISTS `api_keys` (`id` integer PRIMARY KEY AUTOINCREMENT,`service_account_id` integer,`name` varchar(255),`prefix` varchar(16),`hash` varchar(64),`scopes` varchar(1024),`description` varchar(255),`expires_at` integer,`last_used_at` integer,`created_at` integer);
+CREATE INDEX IF NOT EXISTS `idx_api_key_service_account_id` ON `api_keys`(`service_account_id`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_api_key_hash` ON `api_keys`(`hash`);
@@ -0,0 +1,3 @@
+-- users provisioned by single sign-on are linked by the subject at the provider.
+ALTER TABLE `users` ADD COLUMN `source` varchar(16) NOT NULL DEFAULT 'local';
+ALTER TABLE `users` ADD COLUMN `external_id` varchar(255) NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- break-glass credentials replace the emergency header, every use is audited.
+CREATE TABLE IF NOT EXISTS `break_glass_credentials` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`principal` varchar(255),`prefix` varchar(16),`hash` varchar(64),`one_time` tinyint(1),`expire_minutes` integer,`description` varchar(255),`expires_at` integer,`first_used_at` integer,`created_by` varchar(255),`created_at` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_break_glass_name` ON `break_glass_credentials`(`name`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_break_glass_hash` ON `break_glass_credentials`(`hash`);
+CREATE TABLE IF NOT EXISTS `break_glass_audits` (`id` integer PRIMARY KEY AUTOINCREMENT,`credential_id` integer,`name` varchar(255),`principal` varchar(255),`operation` varchar(255),`ip` varchar(64),`allowed` tinyint(1),`reason` varchar(255),`created_at` integer);
+CREATE INDEX IF NOT EXISTS `idx_break_glass_audit_credential_id` ON `break_glass_audits`(`credential_id`);
+CREATE INDEX IF NOT EXISTS `idx_break_glass_audit_created_at` ON `break_glass_audits`(`created_at`);
@@ -0,0 +1,3 @@
+-- asymmetric keys signing tokens, rotated by `opspillar keys rotate`.
+CREATE TABLE IF NOT EXISTS `signing_keys` (`id` integer PRIMARY KEY AUTOINCREMENT,`kid` varchar(64),`algorithm` varchar(16),`private_key` text,`public_key` text,`created_at` integer,`expires_at` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_signing_key_kid` ON `signing_keys`(`kid`);
@@ -0,0 +1,4 @@
+-- changes of casbin rules and groups made through the authz api.
+CREATE TABLE IF NOT EXISTS `authz_audits` (`id` integer PRIMARY KEY AUTOINCREMENT,`operator` varchar(255),`operation` varchar(32),`sub` varchar(255),`resource` varchar(255),`action` varchar(32),`role` varchar(255),`created_at` integer);
+CREATE INDEX IF NOT EXISTS `idx_authz_audit_sub` ON `authz_audits`(`sub`);
+CREATE INDEX IF NOT EXISTS `idx_authz_audit_created_at` ON `authz_audits`(`created_at`);
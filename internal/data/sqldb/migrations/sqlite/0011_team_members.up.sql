@@ -0,0 +1,5 @@
+-- team members with roles, leaders of existing teams become their first members.
+CREATE TABLE IF NOT EXISTS `team_members` (`id` integer PRIMARY KEY AUTOINCREMENT,`team_id` integer,`user_id` integer,`role` varchar(32),`created_at` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_member_team_id_user_id` ON `team_members`(`team_id`,`user_id`);
+CREATE INDEX IF NOT EXISTS `idx_team_member_user_id` ON `team_members`(`user_id`);
+INSERT INTO `team_members` (`team_id`, `user_id`, `role`, `created_at`) SELECT `id`, `leader_id`, 'leader', 0 FROM `teams` WHERE `leader_id` > 0;
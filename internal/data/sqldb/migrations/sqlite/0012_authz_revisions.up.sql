@@ -0,0 +1,3 @@
+-- revision of casbin rules, bumped with every change so other instances reload them.
+CREATE TABLE IF NOT EXISTS `authz_revisions` (`id` integer PRIMARY KEY,`revision` integer NOT NULL DEFAULT 0,`updated_at` integer);
+INSERT INTO `authz_revisions` (`id`, `revision`, `updated_at`) VALUES (1, 0, 0);
@@ -0,0 +1,8 @@
+-- failed logins lock users for a while, passwords expire by policy and are reset with one-time tokens.
+ALTER TABLE `users` ADD COLUMN `password_changed_at` integer NOT NULL DEFAULT 0;
+ALTER TABLE `users` ADD COLUMN `failed_logins` integer NOT NULL DEFAULT 0;
+ALTER TABLE `users` ADD COLUMN `locked_until` integer NOT NULL DEFAULT 0;
+UPDATE `users` SET `password_changed_at` = CAST(strftime('%s', 'now') AS integer);
+CREATE TABLE IF NOT EXISTS `password_reset_tokens` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`hash` varchar(64),`expires_at` integer,`used_at` integer NOT NULL DEFAULT 0,`created_by` varchar(255),`created_at` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_password_reset_token_hash` ON `password_reset_tokens`(`hash`);
+CREATE INDEX IF NOT EXISTS `idx_password_reset_token_user_id` ON `password_reset_tokens`(`user_id`);
@@ -0,0 +1,7 @@
+-- TOTP multi-factor authentication of local users, recovery codes are stored hashed.
+ALTER TABLE `users` ADD COLUMN `mfa_secret` varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE `users` ADD COLUMN `mfa_enabled` boolean NOT NULL DEFAULT false;
+ALTER TABLE `users` ADD COLUMN `mfa_last_step` integer NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS `mfa_recovery_codes` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`hash` varchar(64),`used_at` integer NOT NULL DEFAULT 0,`created_at` integer);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_mfa_recovery_code_hash` ON `mfa_recovery_codes`(`hash`);
+CREATE INDEX IF NOT EXISTS `idx_mfa_recovery_code_user_id` ON `mfa_recovery_codes`(`user_id`);
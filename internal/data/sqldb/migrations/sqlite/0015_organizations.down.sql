@@ -0,0 +1,20 @@
+-- rules of other organizations than default lose their org section, names duplicated
+-- across organizations fail the unique indexes.
+UPDATE `casbin_rule` SET `v1` = 'v1/' || substr(`v1`, instr(substr(`v1`, 4), '/') + 4) WHERE `ptype` = 'p' AND `v1` LIKE 'v1/%';
+DROP INDEX IF EXISTS `idx_app_org_name`;
+ALTER TABLE `applications` DROP COLUMN `org_id`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_app_name_env` ON `applications`(`name`);
+DROP INDEX IF EXISTS `idx_hg_org_name`;
+ALTER TABLE `hostgroups` DROP COLUMN `org_id`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hg_name` ON `hostgroups`(`name`);
+DROP INDEX IF EXISTS `idx_product_org_name`;
+DROP INDEX IF EXISTS `idx_product_org_code`;
+ALTER TABLE `products` DROP COLUMN `org_id`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_product_name` ON `products`(`name`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_product_code` ON `products`(`code`);
+DROP INDEX IF EXISTS `idx_team_org_name`;
+DROP INDEX IF EXISTS `idx_team_org_code`;
+ALTER TABLE `teams` DROP COLUMN `org_id`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_name` ON `teams`(`name`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_code` ON `teams`(`code`);
+DROP TABLE IF EXISTS `organizations`;
@@ -0,0 +1,25 @@
+-- organizations are namespaces of teams, products, hostgroups and applications, existing ones
+-- move to the default organization.
+CREATE TABLE IF NOT EXISTS `organizations` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255),`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_org_name` ON `organizations`(`name`);
+INSERT INTO `organizations` (`id`, `name`, `description`) VALUES (1, 'default', 'default organization');
+ALTER TABLE `teams` ADD COLUMN `org_id` integer NOT NULL DEFAULT 1;
+DROP INDEX IF EXISTS `idx_team_name`;
+DROP INDEX IF EXISTS `idx_team_code`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_org_name` ON `teams`(`org_id`,`name`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_team_org_code` ON `teams`(`org_id`,`code`);
+ALTER TABLE `products` ADD COLUMN `org_id` integer NOT NULL DEFAULT 1;
+DROP INDEX IF EXISTS `idx_product_name`;
+DROP INDEX IF EXISTS `idx_product_code`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_product_org_name` ON `products`(`org_id`,`name`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_product_org_code` ON `products`(`org_id`,`code`);
+ALTER TABLE `hostgroups` ADD COLUMN `org_id` integer NOT NULL DEFAULT 1;
+DROP INDEX IF EXISTS `idx_hg_name`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_hg_org_name` ON `hostgroups`(`org_id`,`name`);
+ALTER TABLE `applications` ADD COLUMN `org_id` integer NOT NULL DEFAULT 1;
+DROP INDEX IF EXISTS `idx_app_name_env`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_app_org_name` ON `applications`(`org_id`,`name`);
+-- casbin resources gain the org section after v1, rules naming a team are of the default
+-- organization. casbin_rule is created by the adapter on first start if missing.
+CREATE TABLE IF NOT EXISTS `casbin_rule` (`id` integer PRIMARY KEY AUTOINCREMENT,`ptype` text,`v0` text,`v1` text,`v2` text,`v3` text,`v4` text,`v5` text);
+UPDATE `casbin_rule` SET `v1` = CASE WHEN `v1` LIKE 'v1/%/{team}/%' THEN 'v1/{org}/' ELSE 'v1/default/' END || substr(`v1`, 4) WHERE `ptype` = 'p' AND `v1` LIKE 'v1/%';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `attribute_values`;
+DROP TABLE IF EXISTS `attributes`;
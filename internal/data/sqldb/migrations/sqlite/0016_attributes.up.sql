@@ -0,0 +1,6 @@
+-- admin defined custom attributes of applications and hostgroups with their values.
+CREATE TABLE IF NOT EXISTS `attributes` (`id` integer PRIMARY KEY AUTOINCREMENT,`kind` varchar(64),`name` varchar(255),`type` varchar(32),`required` boolean,`enum_values` varchar(1024),`pattern` varchar(255),`description` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_attr_kind_name` ON `attributes`(`kind`,`name`);
+CREATE TABLE IF NOT EXISTS `attribute_values` (`id` integer PRIMARY KEY AUTOINCREMENT,`kind` varchar(64),`entity_id` integer,`name` varchar(255),`value` varchar(255));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_attr_value_entity` ON `attribute_values`(`kind`,`entity_id`,`name`);
+CREATE INDEX IF NOT EXISTS `idx_attr_value_name` ON `attribute_values`(`kind`,`name`,`value`);
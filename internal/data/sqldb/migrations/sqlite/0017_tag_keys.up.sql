@@ -0,0 +1,3 @@
+-- registry of governed tag keys with their allowed values and entity kinds.
+CREATE TABLE IF NOT EXISTS `tag_keys` (`id` integer PRIMARY KEY AUTOINCREMENT,`key` varchar(255),`description` varchar(255),`allowed_values` varchar(1024),`pattern` varchar(255),`kinds` varchar(255),`required` boolean);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_tag_key` ON `tag_keys`(`key`);
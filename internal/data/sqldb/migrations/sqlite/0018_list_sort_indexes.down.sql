@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `idx_app_updated_at`;
+DROP INDEX IF EXISTS `idx_app_created_at`;
+DROP INDEX IF EXISTS `idx_hg_updated_at`;
+DROP INDEX IF EXISTS `idx_hg_created_at`;
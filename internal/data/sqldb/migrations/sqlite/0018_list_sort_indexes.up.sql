@@ -0,0 +1,5 @@
+-- hostgroups and applications are listed sorted or ranged by change times.
+CREATE INDEX IF NOT EXISTS `idx_hg_created_at` ON `hostgroups`(`created_at`);
+CREATE INDEX IF NOT EXISTS `idx_hg_updated_at` ON `hostgroups`(`updated_at`);
+CREATE INDEX IF NOT EXISTS `idx_app_created_at` ON `applications`(`created_at`);
+CREATE INDEX IF NOT EXISTS `idx_app_updated_at` ON `applications`(`updated_at`);
@@ -0,0 +1,100 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type OrganizationsRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewOrganizationsRepoGorm(data *DataGorm, logger log.Logger) (repo.OrganizationsRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.OrganizationTable); err != nil {
+		return nil, err
+	}
+	return &OrganizationsRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateOrganizations is
+func (d *OrganizationsRepoGorm) CreateOrganizations(ctx context.Context, tx repo.TX, orgs []*repo.Organization) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(orgs)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// UpdateOrganizations is
+func (d *OrganizationsRepoGorm) UpdateOrganizations(ctx context.Context, tx repo.TX, orgs []*repo.Organization) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Save(orgs)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// DeleteOrganizations is
+func (d *OrganizationsRepoGorm) DeleteOrganizations(
+	ctx context.Context,
+	tx repo.TX,
+	ids []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.Organization{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete failed. rows affected not equal wanted. affected %d. want %d",
+			r.RowsAffected, len(ids))
+	}
+	return nil
+}
+
+// GetOrganizations is
+func (d *OrganizationsRepoGorm) GetOrganizations(ctx context.Context, id uint32) (*repo.Organization, error) {
+
+	org := &repo.Organization{}
+	r := d.data.DB.WithContext(ctx).First(org, id)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return org, nil
+}
+
+// ListOrganizations is
+func (d *OrganizationsRepoGorm) ListOrganizations(ctx context.Context,
+	tx repo.TX,
+	filter *repo.OrganizationsFilter) ([]*repo.Organization, error) {
+
+	orgs := []*repo.Organization{}
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
+	if filter != nil {
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.Names) > 0 {
+			// organizations are selected by name, so names match exactly
+			query = query.Where("name in (?)", filter.Names)
+		}
+	}
+	r := query.Find(&orgs)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return orgs, nil
+}
@@ -19,7 +19,7 @@ func NewProductsRepoGorm(data *DataGorm, logger log.Logger) (repo.ProductsRepo,
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Product{}, repo.ProductTable); err != nil {
+	if err := requireTable(data.DB, repo.ProductTable); err != nil {
 		return nil, err
 	}
 
@@ -80,32 +80,21 @@ func (d *ProductsRepoGorm) ListProducts(ctx context.Context,
 	filter *repo.ProductsFilter) ([]*repo.Product, error) {
 
 	db_ps := []*repo.Product{}
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 		if len(filter.Names) > 0 {
-			nameConditions := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(nameConditions, params...)
+			query = query.Where(matchNames("name", "", filter.Names))
 		}
 		if len(filter.Codes) > 0 {
-			codeConditions := buildOrLike("code", len(filter.Codes))
-			params := make([]interface{}, len(filter.Codes))
-			for i, v := range filter.Codes {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(codeConditions, params...)
+			query = query.Where(matchNames("code", "", filter.Codes))
 		}
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
+		if len(filter.OrgIds) > 0 {
+			query = query.Where("org_id in (?)", filter.OrgIds)
+		}
 	}
 	r := query.Find(&db_ps)
 	if r.Error != nil {
@@ -143,7 +132,16 @@ func (d *ProductsRepoGorm) CountRequire(ctx context.Context,
 		return 0, repo.ErrorRequireIds
 	}
 
-	// require nothing
-	return 0, nil
+	// products require nothing but their organization
+	if need != repo.RequireOrg {
+		return 0, nil
+	}
+	var count int64
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.Product{}).
+		Where("org_id in (?)", ids).Count(&count)
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	return count, nil
 
 }
@@ -0,0 +1,116 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type ServiceAccountsRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewServiceAccountsRepoGorm(data *DataGorm, logger log.Logger) (repo.ServiceAccountsRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.ServiceAccountTable); err != nil {
+		return nil, err
+	}
+	return &ServiceAccountsRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateServiceAccounts is
+func (d *ServiceAccountsRepoGorm) CreateServiceAccounts(ctx context.Context, tx repo.TX, accounts []*repo.ServiceAccount) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(accounts)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// DeleteServiceAccounts is
+func (d *ServiceAccountsRepoGorm) DeleteServiceAccounts(ctx context.Context, tx repo.TX, ids []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.ServiceAccount{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete not equal expected. want %d. affected %d", len(ids), r.RowsAffected)
+	}
+	return nil
+}
+
+// GetServiceAccounts is
+// notfound return error
+func (d *ServiceAccountsRepoGorm) GetServiceAccounts(ctx context.Context, tx repo.TX, id uint32) (*repo.ServiceAccount, error) {
+
+	account := &repo.ServiceAccount{}
+	r := d.data.WithTX(tx).WithContext(ctx).First(account, id)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return account, nil
+}
+
+// ListServiceAccounts is
+func (d *ServiceAccountsRepoGorm) ListServiceAccounts(ctx context.Context,
+	tx repo.TX,
+	filter *repo.ServiceAccountsFilter) ([]*repo.ServiceAccount, error) {
+
+	db_accounts := []*repo.ServiceAccount{}
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
+	if filter != nil {
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.Names) > 0 {
+			query = query.Where("name in (?)", filter.Names)
+		}
+		if len(filter.TeamIds) > 0 {
+			query = query.Where("team_id in (?)", filter.TeamIds)
+		}
+	}
+	r := query.Find(&db_accounts)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return db_accounts, nil
+}
+
+func (d *ServiceAccountsRepoGorm) CountRequire(ctx context.Context,
+	tx repo.TX,
+	need repo.RequireType,
+	ids []uint32) (int64, error) {
+
+	if len(ids) == 0 {
+		return 0, repo.ErrorRequireIds
+	}
+
+	var condition string
+	switch need {
+	case repo.RequireTeam:
+		condition = "team_id in (?)"
+	default:
+		return 0, repo.ErrorRequireIds
+	}
+
+	var count int64
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.ServiceAccount{}).
+		Where(condition, ids).Count(&count)
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	return count, nil
+}
@@ -0,0 +1,116 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type SessionsRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewSessionsRepoGorm(data *DataGorm, logger log.Logger) (repo.SessionsRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.SessionTable); err != nil {
+		return nil, err
+	}
+	return &SessionsRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateSessions is
+func (d *SessionsRepoGorm) CreateSessions(ctx context.Context, tx repo.TX, sessions []*repo.Session) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(sessions)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// UpdateSessions is
+func (d *SessionsRepoGorm) UpdateSessions(ctx context.Context, tx repo.TX, sessions []*repo.Session) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Save(sessions)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// RotateSession writes the new refresh token of session with a conditional update on the old hash,
+// so of two refreshes with the same token only one updates a row.
+func (d *SessionsRepoGorm) RotateSession(ctx context.Context, tx repo.TX, session *repo.Session, oldHash string) (bool, error) {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.Session{}).
+		Where("id = ? AND hash = ?", session.Id, oldHash).
+		Select("*").Omit("id", "user_id", "created_at").
+		Updates(session)
+	if r.Error != nil {
+		return false, r.Error
+	}
+	return r.RowsAffected == 1, nil
+}
+
+// DeleteSessions is
+func (d *SessionsRepoGorm) DeleteSessions(ctx context.Context, tx repo.TX, ids []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.Session{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete not equal expected. want %d. affected %d", len(ids), r.RowsAffected)
+	}
+	return nil
+}
+
+// DeleteUserSessions deletes all sessions of users
+func (d *SessionsRepoGorm) DeleteUserSessions(ctx context.Context, tx repo.TX, userIds []uint32) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Where("user_id in (?)", userIds).Delete(&repo.Session{})
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// ListSessions is
+func (d *SessionsRepoGorm) ListSessions(ctx context.Context,
+	tx repo.TX,
+	filter *repo.SessionsFilter) ([]*repo.Session, error) {
+
+	db_sessions := []*repo.Session{}
+	query := d.data.WithTX(tx).WithContext(ctx)
+	if filter != nil {
+		if filter.Page > 0 && filter.PageSize > 0 {
+			offset := int((filter.Page - 1) * filter.PageSize)
+			query = query.Offset(offset).Limit(int(filter.PageSize))
+		}
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.UserIds) > 0 {
+			query = query.Where("user_id in (?)", filter.UserIds)
+		}
+		if len(filter.Hashes) > 0 {
+			query = query.Where("hash in (?) OR prev_hash in (?)", filter.Hashes, filter.Hashes)
+		}
+	}
+	r := query.Order("id").Find(&db_sessions)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+
+	return db_sessions, nil
+}
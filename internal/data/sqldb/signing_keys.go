@@ -0,0 +1,75 @@
+package sqldb
+
+import (
+	"context"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type SigningKeysRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewSigningKeysRepoGorm(data *DataGorm, logger log.Logger) (repo.SigningKeysRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.SigningKeyTable); err != nil {
+		return nil, err
+	}
+	return &SigningKeysRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateSigningKeys is
+func (d *SigningKeysRepoGorm) CreateSigningKeys(ctx context.Context, tx repo.TX, keys []*repo.SigningKey) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).Create(keys)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// ListSigningKeys is
+func (d *SigningKeysRepoGorm) ListSigningKeys(ctx context.Context, tx repo.TX, now int64) ([]*repo.SigningKey, error) {
+
+	db_keys := []*repo.SigningKey{}
+	r := d.data.WithTX(tx).WithContext(ctx).
+		Where("expires_at = 0 OR expires_at > ?", now).Order("id desc").Find(&db_keys)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return db_keys, nil
+}
+
+// ExpireSigningKeys only updates keys not rotated yet, so expiry of older keys is kept.
+func (d *SigningKeysRepoGorm) ExpireSigningKeys(ctx context.Context, tx repo.TX, ids []uint32, expiresAt int64) error {
+
+	if len(ids) == 0 {
+		return nil
+	}
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.SigningKey{}).
+		Where("id in (?) AND expires_at = 0", ids).Update("expires_at", expiresAt)
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// DeleteExpiredSigningKeys is
+func (d *SigningKeysRepoGorm) DeleteExpiredSigningKeys(ctx context.Context, tx repo.TX, now int64) error {
+
+	r := d.data.WithTX(tx).WithContext(ctx).
+		Where("expires_at > 0 AND expires_at <= ?", now).Delete(&repo.SigningKey{})
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
@@ -114,6 +114,34 @@ func TestAdminRepoGorm_ListUsers(t *testing.T) {
 	assert.Len(t, retrievedUsers, 2)
 }
 
+func TestAdminRepoGorm_DisableUsers(t *testing.T) {
+
+	initAdminRepo()
+	ctx := context.Background()
+
+	users := []*repo.User{
+		{UserName: "user1", Password: "password123"},
+		{UserName: "user2", Password: "!ldap", Source: repo.UserSourceLDAP, ExternalId: "uid=user2,dc=example"},
+	}
+	assert.NoError(t, adminRepo.CreateUsers(ctx, nil, users))
+
+	ldapUsers, err := adminRepo.ListUsers(ctx, nil, &repo.UsersFilter{Sources: []string{repo.UserSourceLDAP}})
+	assert.NoError(t, err)
+	assert.Len(t, ldapUsers, 1)
+	assert.Equal(t, "user2", ldapUsers[0].UserName)
+	assert.False(t, ldapUsers[0].Disabled)
+
+	assert.NoError(t, adminRepo.DisableUsers(ctx, nil, []uint32{users[1].Id}, true))
+	user, err := adminRepo.GetUsers(ctx, nil, users[1].Id)
+	assert.NoError(t, err)
+	assert.True(t, user.Disabled)
+
+	assert.NoError(t, adminRepo.DisableUsers(ctx, nil, []uint32{users[1].Id}, false))
+	user, err = adminRepo.GetUsers(ctx, nil, users[1].Id)
+	assert.NoError(t, err)
+	assert.False(t, user.Disabled)
+}
+
 func TestAdminRepoGorm_Logout(t *testing.T) {
 
 	initAdminRepo()
@@ -123,3 +151,112 @@ func TestAdminRepoGorm_Logout(t *testing.T) {
 	err := adminRepo.Logout(ctx, 1)
 	assert.NoError(t, err)
 }
+
+func TestAdminRepoGorm_FailedLogins(t *testing.T) {
+
+	initAdminRepo()
+
+	ctx := context.Background()
+
+	user := &repo.User{UserName: "user1", Password: "hash1"}
+	assert.NoError(t, adminRepo.CreateUsers(ctx, nil, []*repo.User{user}))
+
+	locked, err := adminRepo.AddFailedLogin(ctx, nil, user.Id, 2, 100)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+	locked, err = adminRepo.AddFailedLogin(ctx, nil, user.Id, 2, 100)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	got, err := adminRepo.GetUsers(ctx, nil, user.Id)
+	assert.NoError(t, err)
+	// locking starts counting again
+	assert.Equal(t, int32(0), got.FailedLogins)
+	assert.Equal(t, int64(100), got.LockedUntil)
+
+	_, _ = adminRepo.AddFailedLogin(ctx, nil, user.Id, 2, 100)
+	assert.NoError(t, adminRepo.ResetFailedLogins(ctx, nil, user.Id))
+	got, _ = adminRepo.GetUsers(ctx, nil, user.Id)
+	assert.Equal(t, int32(0), got.FailedLogins)
+
+	// setting password unlocks the user
+	_, _ = adminRepo.AddFailedLogin(ctx, nil, user.Id, 1, 100)
+	assert.NoError(t, adminRepo.SetPassword(ctx, nil, user.Id, "hash2", 200))
+	got, _ = adminRepo.GetUsers(ctx, nil, user.Id)
+	assert.Equal(t, "hash2", got.Password)
+	assert.Equal(t, int64(200), got.PasswordChangedAt)
+	assert.Equal(t, int32(0), got.FailedLogins)
+	assert.Equal(t, int64(0), got.LockedUntil)
+}
+
+func TestAdminRepoGorm_PasswordResetToken(t *testing.T) {
+
+	initAdminRepo()
+
+	ctx := context.Background()
+
+	user := &repo.User{UserName: "user1", Password: "hash1"}
+	assert.NoError(t, adminRepo.CreateUsers(ctx, nil, []*repo.User{user}))
+
+	assert.NoError(t, adminRepo.CreatePasswordResetToken(ctx, nil,
+		&repo.PasswordResetToken{UserId: user.Id, Hash: "old", ExpiresAt: 1000, CreatedBy: "admin"}))
+	// a new token replaces the unused one
+	assert.NoError(t, adminRepo.CreatePasswordResetToken(ctx, nil,
+		&repo.PasswordResetToken{UserId: user.Id, Hash: "new", ExpiresAt: 1000, CreatedBy: "admin"}))
+	_, err := adminRepo.UsePasswordResetToken(ctx, nil, "old", 500)
+	assert.ErrorIs(t, err, repo.ErrInvalidResetToken)
+
+	// expired
+	_, err = adminRepo.UsePasswordResetToken(ctx, nil, "new", 1000)
+	assert.ErrorIs(t, err, repo.ErrInvalidResetToken)
+
+	token, err := adminRepo.UsePasswordResetToken(ctx, nil, "new", 500)
+	assert.NoError(t, err)
+	assert.Equal(t, user.Id, token.UserId)
+	assert.Equal(t, int64(500), token.UsedAt)
+
+	// used once
+	_, err = adminRepo.UsePasswordResetToken(ctx, nil, "new", 600)
+	assert.ErrorIs(t, err, repo.ErrInvalidResetToken)
+}
+
+func TestAdminRepoGorm_MFA(t *testing.T) {
+
+	initAdminRepo()
+
+	ctx := context.Background()
+
+	user := &repo.User{UserName: "user1", Password: "hash1"}
+	assert.NoError(t, adminRepo.CreateUsers(ctx, nil, []*repo.User{user}))
+
+	codes := []*repo.MFARecoveryCode{{Hash: "code1"}, {Hash: "code2"}}
+	assert.NoError(t, adminRepo.SetMFA(ctx, nil, user.Id, "SECRET1", codes))
+	// replaced secret is not enabled
+	assert.Error(t, adminRepo.EnableMFA(ctx, nil, user.Id, "SECRET0"))
+	assert.NoError(t, adminRepo.EnableMFA(ctx, nil, user.Id, "SECRET1"))
+	got, _ := adminRepo.GetUsers(ctx, nil, user.Id)
+	assert.Equal(t, "SECRET1", got.MFASecret)
+	assert.True(t, got.MFAEnabled)
+
+	ok, err := adminRepo.UseMFAStep(ctx, nil, user.Id, 100)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	// same or earlier steps are replays
+	ok, _ = adminRepo.UseMFAStep(ctx, nil, user.Id, 100)
+	assert.False(t, ok)
+	ok, _ = adminRepo.UseMFAStep(ctx, nil, user.Id, 99)
+	assert.False(t, ok)
+
+	assert.NoError(t, adminRepo.UseMFARecoveryCode(ctx, nil, user.Id, "code1", 10))
+	assert.ErrorIs(t, adminRepo.UseMFARecoveryCode(ctx, nil, user.Id, "code1", 11), repo.ErrInvalidRecoveryCode)
+	assert.ErrorIs(t, adminRepo.UseMFARecoveryCode(ctx, nil, user.Id+1, "code2", 11), repo.ErrInvalidRecoveryCode)
+
+	// disabling removes recovery codes
+	assert.NoError(t, adminRepo.SetMFA(ctx, nil, user.Id, "", nil))
+	got, _ = adminRepo.GetUsers(ctx, nil, user.Id)
+	assert.Empty(t, got.MFASecret)
+	assert.False(t, got.MFAEnabled)
+	assert.Equal(t, int64(0), got.MFALastStep)
+	assert.ErrorIs(t, adminRepo.UseMFARecoveryCode(ctx, nil, user.Id, "code2", 11), repo.ErrInvalidRecoveryCode)
+	assert.Error(t, adminRepo.EnableMFA(ctx, nil, user.Id, ""))
+}
@@ -0,0 +1,78 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributes_CRUD(t *testing.T) {
+	ctx := context.Background()
+	attrs, err := sqldb.NewAttributesRepoGorm(getDataMem(), logger)
+	assert.NoError(t, err)
+
+	tier := &repo.Attribute{Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeEnum, EnumValues: "gold,silver"}
+	assert.NoError(t, attrs.CreateAttributes(ctx, nil, []*repo.Attribute{tier}))
+	assert.NotZero(t, tier.ID)
+	// names are unique per kind
+	assert.Error(t, attrs.CreateAttributes(ctx, nil, []*repo.Attribute{
+		{Kind: repo.AttrKindApplication, Name: "tier", Type: repo.AttrTypeString}}))
+	assert.NoError(t, attrs.CreateAttributes(ctx, nil, []*repo.Attribute{
+		{Kind: repo.AttrKindHostgroup, Name: "tier", Type: repo.AttrTypeString}}))
+
+	list, err := attrs.ListAttributes(ctx, nil, &repo.AttributesFilter{Kinds: []string{repo.AttrKindApplication}})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	tier.Required = true
+	assert.NoError(t, attrs.UpdateAttributes(ctx, nil, []*repo.Attribute{tier}))
+	got, err := attrs.GetAttributes(ctx, tier.ID)
+	assert.NoError(t, err)
+	assert.True(t, got.Required)
+
+	assert.NoError(t, attrs.DeleteAttributes(ctx, nil, []uint32{tier.ID}))
+	_, err = attrs.GetAttributes(ctx, tier.ID)
+	assert.Error(t, err)
+}
+
+func TestAttributes_Values(t *testing.T) {
+	ctx := context.Background()
+	attrs, _ := sqldb.NewAttributesRepoGorm(getDataMem(), logger)
+
+	assert.NoError(t, attrs.SetAttributeValues(ctx, nil, repo.AttrKindApplication, 1, []*repo.AttributeValue{
+		{Name: "tier", Value: "gold"}, {Name: "owner", Value: "ops"}}))
+	assert.NoError(t, attrs.SetAttributeValues(ctx, nil, repo.AttrKindApplication, 2, []*repo.AttributeValue{
+		{Name: "tier", Value: "silver"}}))
+	assert.NoError(t, attrs.SetAttributeValues(ctx, nil, repo.AttrKindHostgroup, 1, []*repo.AttributeValue{
+		{Name: "tier", Value: "gold"}}))
+
+	// values of a kind
+	vals, err := attrs.ListAttributeValues(ctx, nil, &repo.AttributeValuesFilter{
+		Kind: repo.AttrKindApplication, Names: []string{"tier"}, Values: []string{"gold"}})
+	assert.NoError(t, err)
+	assert.Len(t, vals, 1)
+	assert.Equal(t, uint32(1), vals[0].EntityId)
+	_, err = attrs.ListAttributeValues(ctx, nil, &repo.AttributeValuesFilter{})
+	assert.Error(t, err)
+
+	// set replaces values of the entity
+	assert.NoError(t, attrs.SetAttributeValues(ctx, nil, repo.AttrKindApplication, 1, []*repo.AttributeValue{
+		{Name: "tier", Value: "silver"}}))
+	vals, _ = attrs.ListAttributeValues(ctx, nil, &repo.AttributeValuesFilter{
+		Kind: repo.AttrKindApplication, EntityIds: []uint32{1}})
+	assert.Len(t, vals, 1)
+	assert.Equal(t, "silver", vals[0].Value)
+
+	// delete by attribute name keeps other kinds
+	assert.Error(t, attrs.DeleteAttributeValues(ctx, nil, &repo.AttributeValuesFilter{Kind: repo.AttrKindApplication}))
+	assert.NoError(t, attrs.DeleteAttributeValues(ctx, nil, &repo.AttributeValuesFilter{
+		Kind: repo.AttrKindApplication, Names: []string{"tier"}}))
+	vals, _ = attrs.ListAttributeValues(ctx, nil, &repo.AttributeValuesFilter{Kind: repo.AttrKindApplication})
+	assert.Len(t, vals, 0)
+	vals, _ = attrs.ListAttributeValues(ctx, nil, &repo.AttributeValuesFilter{Kind: repo.AttrKindHostgroup})
+	assert.Len(t, vals, 1)
+}
@@ -0,0 +1,50 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var authzAuditsRepo repo.AuthzAuditsRepo
+
+func initAuthzAuditsRepo() {
+	dataMem = getDataMem()
+	authzAuditsRepo, _ = sqldb.NewAuthzAuditsRepoGorm(dataMem, logger)
+}
+
+func TestAuthzAuditsRepoGorm(t *testing.T) {
+	initAuthzAuditsRepo()
+	ctx := context.Background()
+
+	audits := []*repo.AuthzAudit{
+		{Operator: "admin", Operation: repo.AuthzOpGrant, Sub: "ops", Resource: "v1/hostgroups/ops/{resource_id}/{user}", Action: repo.ActWrite, CreatedAt: 100},
+		{Operator: "admin", Operation: repo.AuthzOpAddGroup, Sub: "alice", Role: "ops", CreatedAt: 200},
+		{Operator: "bob", Operation: repo.AuthzOpRemoveGroup, Sub: "alice", Role: "ops", CreatedAt: 300},
+	}
+	assert.NoError(t, authzAuditsRepo.CreateAuthzAudits(ctx, nil, audits))
+
+	// newest first
+	all, err := authzAuditsRepo.ListAuthzAudits(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*repo.AuthzAudit{audits[2], audits[1], audits[0]}, all)
+
+	filtered, err := authzAuditsRepo.ListAuthzAudits(ctx, nil, &repo.AuthzAuditsFilter{
+		Subs: []string{"alice"}, Operators: []string{"admin"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, audits[1:2], filtered)
+
+	paged, err := authzAuditsRepo.ListAuthzAudits(ctx, nil, &repo.AuthzAuditsFilter{Since: 200, Page: 2, PageSize: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, audits[1:2], paged)
+
+	// newest first after the last audit of the previous page
+	paged, err = authzAuditsRepo.ListAuthzAudits(ctx, nil, &repo.AuthzAuditsFilter{Page: 1, PageSize: 1, LastId: audits[2].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, audits[1:2], paged)
+}
@@ -2,23 +2,35 @@ package sqldb_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"opspillar/internal/conf"
 	"opspillar/internal/data/repo"
 	"opspillar/internal/data/sqldb"
 
+	"github.com/casbin/casbin/v2"
+	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var authzRepo repo.AuthzRepo
+var authzData *sqldb.DataGorm
+var authzCleanup = func() {}
+
+const authzModelFile = "../../../../configs/rbac_model.conf"
 
 func initAuthzRepo() {
-	dataMem := getDataMem()
+	authzCleanup()
+	authzData = getDataMem()
 	config := &conf.Authz{
-		ModelFile: "../../../../configs/rbac_model.conf",
+		ModelFile:          authzModelFile,
+		PolicyPollInterval: durationpb.New(50 * time.Millisecond),
 	}
-	authzRepo, _ = sqldb.NewAuthzRepoGorm(config, dataMem, logger)
+	authzRepo, authzCleanup, _ = sqldb.NewAuthzRepoGorm(config, authzData, logger)
 }
 
 func Test__AuthzRepoGorm(t *testing.T) {
@@ -30,9 +42,13 @@ func Test__AuthzRepoGorm(t *testing.T) {
 		{"DeleteRule_Success", TestAuthzRepoGorm_DeleteRule},
 		{"ListRule_Success", TestAuthzRepoGorm_ListRule},
 		{"Enforce_Success", TestAuthzRepoGorm_Enforce},
+		{"BatchEnforce_Success", TestAuthzRepoGorm_BatchEnforce},
+		{"Explain_Success", TestAuthzRepoGorm_Explain},
 		{"CreateGroup_Success", TestAuthzRepoGorm_CreateGroup},
 		{"DeleteGroup_Success", TestAuthzRepoGorm_DeleteGroup},
 		{"ListGroup_Success", TestAuthzRepoGorm_ListGroup},
+		{"Transaction_Success", TestAuthzRepoGorm_Transaction},
+		{"Watcher_Success", TestAuthzRepoGorm_Watcher},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, tt.testFunc)
@@ -88,6 +104,58 @@ func TestAuthzRepoGorm_Enforce(t *testing.T) {
 	assert.True(t, allowed)
 }
 
+func TestAuthzRepoGorm_BatchEnforce(t *testing.T) {
+	initAuthzRepo()
+	ctx := context.Background()
+
+	// write implies read
+	err := authzRepo.CreateRule(ctx, nil, &repo.Rule{
+		Sub: "bob", Resource: repo.NewResource4Sv1("hostgroups", "team1", "", ""), Action: repo.ActWrite})
+	assert.NoError(t, err)
+	// everyone reads envs
+	err = authzRepo.CreateRule(ctx, nil, &repo.Rule{
+		Sub: repo.SubEveryone, Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead})
+	assert.NoError(t, err)
+
+	allowed, err := authzRepo.BatchEnforce(ctx, nil, []*repo.AuthenRequest{
+		{Sub: "bob", Resource: repo.NewResource4Sv1("hostgroups", "team1", "hg1", "lead"), Action: repo.ActRead},
+		{Sub: "bob", Resource: repo.NewResource4Sv1("hostgroups", "team2", "hg2", "lead"), Action: repo.ActRead},
+		{Sub: "carol", Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead},
+		{Sub: "carol", Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActWrite},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, false}, allowed)
+
+	allowed, err = authzRepo.BatchEnforce(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, allowed)
+}
+
+func TestAuthzRepoGorm_Explain(t *testing.T) {
+	initAuthzRepo()
+	ctx := context.Background()
+
+	assert.NoError(t, authzRepo.CreateRule(ctx, nil, &repo.Rule{
+		Sub: "web:maintainer", Resource: repo.NewResource4Sv1("", "web", "", ""), Action: repo.ActWrite}))
+	assert.NoError(t, authzRepo.CreateGroup(ctx, nil, &repo.Group{User: "web:leader", Role: "web:maintainer"}))
+	assert.NoError(t, authzRepo.CreateGroup(ctx, nil, &repo.Group{User: "dave", Role: "web:leader"}))
+
+	exp, err := authzRepo.Explain(ctx, nil, &repo.AuthenRequest{
+		Sub: "dave", Resource: repo.NewResource4Sv1("hostgroups", "web", "hg1", "lead"), Action: repo.ActRead})
+	assert.NoError(t, err)
+	assert.True(t, exp.Allowed)
+	assert.Equal(t, "web:maintainer", exp.Rule.Sub)
+	assert.Equal(t, repo.ActWrite, exp.Rule.Action)
+	assert.Equal(t, []string{"dave", "web:leader", "web:maintainer"}, exp.Chain)
+	assert.ElementsMatch(t, []string{"web:leader", "web:maintainer"}, exp.Roles)
+
+	exp, err = authzRepo.Explain(ctx, nil, &repo.AuthenRequest{
+		Sub: "dave", Resource: repo.NewResource4Sv1("hostgroups", "ops", "hg2", "lead"), Action: repo.ActRead})
+	assert.NoError(t, err)
+	assert.False(t, exp.Allowed)
+	assert.Nil(t, exp.Rule)
+}
+
 func TestAuthzRepoGorm_CreateGroup(t *testing.T) {
 	initAuthzRepo()
 	group := &repo.Group{User: "alice", Role: "admin"}
@@ -120,3 +188,152 @@ func TestAuthzRepoGorm_ListGroup(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, groups, 1)
 }
+
+func TestAuthzRepoGorm_Transaction(t *testing.T) {
+	initAuthzRepo()
+	ctx := context.Background()
+	txm := sqldb.NewTxManagerGorm(authzData, logger)
+	rule := &repo.Rule{Sub: "erin", Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActWrite}
+	request := &repo.AuthenRequest{Sub: "erin", Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead}
+
+	// changes are seen in the transaction only, and dropped with rollback
+	err := txm.RunInTX(func(tx repo.TX) error {
+		assert.NoError(t, authzRepo.CreateRule(ctx, tx, rule))
+		allowed, err := authzRepo.Enforce(ctx, tx, request)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, err = authzRepo.Enforce(ctx, nil, request)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	allowed, err := authzRepo.Enforce(ctx, nil, request)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	// committed changes are applied to memory
+	err = txm.RunInTX(func(tx repo.TX) error {
+		if err := authzRepo.CreateRule(ctx, tx, rule); err != nil {
+			return err
+		}
+		return authzRepo.CreateGroup(ctx, tx, &repo.Group{User: "frank", Role: "erin"})
+	})
+	assert.NoError(t, err)
+	allowed, err = authzRepo.Enforce(ctx, nil, &repo.AuthenRequest{
+		Sub: "frank", Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead})
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	// cached answers are dropped by changes
+	assert.NoError(t, authzRepo.DeleteGroup(ctx, nil, &repo.Group{User: "frank", Role: "erin"}))
+	allowed, err = authzRepo.Enforce(ctx, nil, &repo.AuthenRequest{
+		Sub: "frank", Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead})
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	// removing a missing group fails as before
+	assert.Error(t, authzRepo.DeleteGroup(ctx, nil, &repo.Group{User: "frank", Role: "erin"}))
+}
+
+func TestAuthzRepoGorm_Watcher(t *testing.T) {
+	initAuthzRepo()
+	ctx := context.Background()
+	// another instance on the same database
+	other, cleanup, err := sqldb.NewAuthzRepoGorm(&conf.Authz{
+		ModelFile:          authzModelFile,
+		PolicyPollInterval: durationpb.New(50 * time.Millisecond),
+	}, authzData, logger)
+	assert.NoError(t, err)
+	defer cleanup()
+
+	request := &repo.AuthenRequest{Sub: "gina", Resource: repo.NewResource4Sv1("clusters", "", "", ""), Action: repo.ActRead}
+	allowed, err := other.Enforce(ctx, nil, request)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	assert.NoError(t, authzRepo.CreateRule(ctx, nil, &repo.Rule{
+		Sub: "gina", Resource: repo.NewResource4Sv1("clusters", "", "", ""), Action: repo.ActRead}))
+	assert.Eventually(t, func() bool {
+		allowed, err := other.Enforce(ctx, nil, request)
+		return err == nil && allowed
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+// seedAuthzRules creates rules and groups like a deployment with n teams.
+func seedAuthzRules(b *testing.B, n int) {
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		team := fmt.Sprintf("team%d", i)
+		if err := authzRepo.CreateRule(ctx, nil, &repo.Rule{
+			Sub: team + ":maintainer", Resource: repo.NewResource4Sv1("", team, "", ""), Action: repo.ActWrite}); err != nil {
+			b.Fatal(err)
+		}
+		if err := authzRepo.CreateGroup(ctx, nil, &repo.Group{User: fmt.Sprintf("user%d", i), Role: team + ":maintainer"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAuthzRepoGorm_Enforce(b *testing.B) {
+	initAuthzRepo()
+	seedAuthzRules(b, 100)
+	ctx := context.Background()
+	request := &repo.AuthenRequest{Sub: "user42", Resource: repo.NewResource4Sv1("hostgroups", "team42", "hg", "lead"), Action: repo.ActRead}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := authzRepo.Enforce(ctx, nil, request); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAuthzRepoGorm_BatchEnforce(b *testing.B) {
+	initAuthzRepo()
+	seedAuthzRules(b, 100)
+	ctx := context.Background()
+	requests := make([]*repo.AuthenRequest, 50)
+	for i := range requests {
+		requests[i] = &repo.AuthenRequest{Sub: "user42", Resource: repo.NewResource4Sv1("hostgroups", fmt.Sprintf("team%d", i), "hg", "lead"), Action: repo.ActRead}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := authzRepo.BatchEnforce(ctx, nil, requests); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAuthzRepoGorm_EnforceReload is the former way, an enforcer loaded from the
+// database for every call.
+func BenchmarkAuthzRepoGorm_EnforceReload(b *testing.B) {
+	initAuthzRepo()
+	seedAuthzRules(b, 100)
+	request := &repo.AuthenRequest{Sub: "user42", Resource: repo.NewResource4Sv1("hostgroups", "team42", "hg", "lead"), Action: repo.ActRead}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		adapter, err := gormadapter.NewAdapterByDB(authzData.DB)
+		if err != nil {
+			b.Fatal(err)
+		}
+		enforcer, err := casbin.NewEnforcer(authzModelFile, adapter)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := enforcer.Enforce(request.Sub, request.Resource.ResourceStr(), request.Action); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAuthzRepoGorm_CreateRule(b *testing.B) {
+	initAuthzRepo()
+	seedAuthzRules(b, 100)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := authzRepo.CreateRule(ctx, nil, &repo.Rule{
+			Sub: fmt.Sprintf("bench%d", i), Resource: repo.NewResource4Sv1("envs", "", "", ""), Action: repo.ActRead}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var breakGlassRepo repo.BreakGlassRepo
+
+func initBreakGlassRepo() {
+	dataMem = getDataMem()
+	breakGlassRepo, _ = sqldb.NewBreakGlassRepoGorm(dataMem, logger)
+}
+
+func createBaseBreakGlassData(t *testing.T) []*repo.BreakGlass {
+	creds := []*repo.BreakGlass{
+		{Name: "oncall", Principal: "admin", Hash: "hash1", OneTime: true, CreatedAt: 100},
+		{Name: "dba", Principal: "dba", Hash: "hash2", ExpireMinutes: 30, CreatedAt: 100},
+	}
+	err := breakGlassRepo.CreateBreakGlass(context.Background(), nil, creds)
+	assert.NoError(t, err)
+	return creds
+}
+
+func TestBreakGlassRepoGorm_ListBreakGlass(t *testing.T) {
+	initBreakGlassRepo()
+	ctx := context.Background()
+	creds := createBaseBreakGlassData(t)
+
+	all, err := breakGlassRepo.ListBreakGlass(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, creds, all)
+
+	byHash, err := breakGlassRepo.ListBreakGlass(ctx, nil, &repo.BreakGlassFilter{Hashes: []string{"hash2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, creds[1:], byHash)
+
+	// name is unique
+	err = breakGlassRepo.CreateBreakGlass(ctx, nil, []*repo.BreakGlass{{Name: "oncall", Hash: "hash3"}})
+	assert.Error(t, err)
+
+	assert.NoError(t, breakGlassRepo.DeleteBreakGlass(ctx, nil, []uint32{creds[0].ID}))
+	assert.Error(t, breakGlassRepo.DeleteBreakGlass(ctx, nil, []uint32{creds[0].ID}))
+}
+
+func TestBreakGlassRepoGorm_ActivateBreakGlass(t *testing.T) {
+	initBreakGlassRepo()
+	ctx := context.Background()
+	creds := createBaseBreakGlassData(t)
+
+	activated, err := breakGlassRepo.ActivateBreakGlass(ctx, nil, creds[0].ID, 200)
+	assert.NoError(t, err)
+	assert.True(t, activated)
+
+	// only the first use activates
+	activated, err = breakGlassRepo.ActivateBreakGlass(ctx, nil, creds[0].ID, 300)
+	assert.NoError(t, err)
+	assert.False(t, activated)
+
+	used, err := breakGlassRepo.ListBreakGlass(ctx, nil, &repo.BreakGlassFilter{Ids: []uint32{creds[0].ID}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), used[0].FirstUsedAt)
+}
+
+func TestBreakGlassRepoGorm_Audits(t *testing.T) {
+	initBreakGlassRepo()
+	ctx := context.Background()
+
+	audits := []*repo.BreakGlassAudit{
+		{CredentialId: 1, Name: "oncall", Principal: "admin", Operation: "/op1", Allowed: true, CreatedAt: 100},
+		{CredentialId: 2, Name: "dba", Principal: "dba", Operation: "/op2", Reason: "expired", CreatedAt: 200},
+		{CredentialId: 1, Name: "oncall", Principal: "admin", Operation: "/op3", Allowed: true, CreatedAt: 300},
+	}
+	assert.NoError(t, breakGlassRepo.CreateBreakGlassAudits(ctx, nil, audits))
+
+	// newest first
+	all, err := breakGlassRepo.ListBreakGlassAudits(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*repo.BreakGlassAudit{audits[2], audits[1], audits[0]}, all)
+
+	filtered, err := breakGlassRepo.ListBreakGlassAudits(ctx, nil, &repo.BreakGlassAuditsFilter{
+		CredentialIds: []uint32{1}, Since: 200,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, audits[2:], filtered)
+}
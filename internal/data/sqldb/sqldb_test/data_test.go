@@ -1,6 +1,7 @@
 package sqldb_test
 
 import (
+	"context"
 	"opspillar/internal/data/sqldb"
 	"os"
 
@@ -14,5 +15,19 @@ var dataMem *sqldb.DataGorm
 
 func getDataMem() *sqldb.DataGorm {
 	_db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	return &sqldb.DataGorm{DB: _db, Driver: "sqlite"}
+	// every connection of :memory: is a new database
+	sqlDB, _ := _db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := sqldb.RegisterErrorKinds(_db); err != nil {
+		panic(err)
+	}
+	data := &sqldb.DataGorm{DB: _db, Driver: "sqlite"}
+	m, err := sqldb.NewMigrator(data, logger)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := m.Up(context.Background(), 0); err != nil {
+		panic(err)
+	}
+	return data
 }
@@ -113,6 +113,11 @@ func TestHostgroupsRepoGorm(t *testing.T) {
 		{"ListHostgroups_teamId_partial", testListHostgroups_teamId_partial},
 		{"ListHostgroups_envId_partial", testListHostgroups_envId_partial},
 		{"ListHostgroups_nil_all", testListHostgroups_nil_all},
+		{"ListHostgroups_name_match", testListHostgroups_name_match},
+		{"ListHostgroups_exclude_partial", testListHostgroups_exclude_partial},
+		{"ListHostgroups_sort_pages", testListHostgroups_sort_pages},
+		{"ListHostgroups_noTags_partial", testListHostgroups_noTags_partial},
+		{"ListHostgroups_selections_partial", testListHostgroups_selections_partial},
 	}
 
 	for _, tt := range tests {
@@ -277,3 +282,121 @@ func testListHostgroups_nil_all(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, fakeHostgroups, _data)
 }
+
+func testListHostgroups_name_match(t *testing.T) {
+	createBaseHostgroups(t, nil)
+	ctx := context.Background()
+	_data, err := hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+		Names: []string{"host"}, NameMatch: repo.NameMatchPrefix})
+	assert.NoError(t, err)
+	assert.Equal(t, fakeHostgroups, _data)
+
+	_data, err = hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+		Names: []string{"group"}, NameMatch: repo.NameMatchPrefix})
+	assert.NoError(t, err)
+	assert.Empty(t, _data)
+
+	_data, err = hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+		Names: []string{"hostgroup", "hostgroup2"}, NameMatch: repo.NameMatchExact})
+	assert.NoError(t, err)
+	assert.Equal(t, fakeHostgroups[1:2], _data)
+
+	// wildcards match literally
+	_data, err = hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{Names: []string{"host%1", "_ostgroup"}})
+	assert.NoError(t, err)
+	assert.Empty(t, _data)
+}
+
+func testListHostgroups_exclude_partial(t *testing.T) {
+	createBaseHostgroups(t, nil)
+	_data, err := hostgroupRepo.ListHostgroups(
+		context.Background(), nil, &repo.HostgroupsFilter{ExcludeTeamsId: []uint32{401}})
+	assert.NoError(t, err)
+	assert.Equal(t, fakeHostgroups[2:], _data)
+
+	_data, err = hostgroupRepo.ListHostgroups(
+		context.Background(), nil, &repo.HostgroupsFilter{ExcludeProductsId: []uint32{101, 403}})
+	assert.NoError(t, err)
+	assert.Empty(t, _data)
+}
+
+func testListHostgroups_sort_pages(t *testing.T) {
+	data := getFakeHostgroups()
+	data[0].UpdatedAt, data[1].UpdatedAt, data[2].UpdatedAt = 300, 100, 300
+	data[0].CreatedAt, data[1].CreatedAt, data[2].CreatedAt = 100, 200, 300
+	createBaseHostgroups(t, data)
+	ctx := context.Background()
+
+	sort := repo.ListSort{By: repo.SortUpdatedAt, Desc: true}
+	_data, err := hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{Sort: sort})
+	assert.NoError(t, err)
+	assert.Equal(t, []*repo.Hostgroup{data[2], data[0], data[1]}, _data)
+
+	// the page after data[2] starts at the row of the same time and a lower id
+	sort.After = data[2].UpdatedAt
+	_data, err = hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+		Page: 1, PageSize: 1, LastId: data[2].Id, Sort: sort})
+	assert.NoError(t, err)
+	assert.Equal(t, data[:1], _data)
+
+	_data, err = hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+		Sort:         repo.ListSort{By: repo.SortName, Desc: true},
+		ChangeFilter: repo.ChangeFilter{CreatedAfter: 200, CreatedBefore: 300},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, data[1:2], _data)
+}
+
+func testListHostgroups_noTags_partial(t *testing.T) {
+	dataMem := getDataMem()
+	hostgroupRepo, _ = sqldb.NewHostgroupsRepoGorm(dataMem, logger)
+	tagRepo, _ := sqldb.NewHostgroupTagsRepoGorm(dataMem, logger)
+	ctx := context.Background()
+	data := getFakeHostgroups()
+	assert.NoError(t, hostgroupRepo.CreateHostgroups(ctx, nil, data))
+	assert.NoError(t, tagRepo.CreateHostgroupTags(ctx, nil, []*repo.HostgroupTag{
+		{HostgroupID: data[1].Id, TagID: 1},
+	}))
+
+	_data, err := hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{NoTags: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []*repo.Hostgroup{data[0], data[2]}, _data)
+
+	_data, err = hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{NoFeatures: true})
+	assert.NoError(t, err)
+	assert.Equal(t, data, _data)
+}
+
+func testListHostgroups_selections_partial(t *testing.T) {
+	dataMem := getDataMem()
+	hostgroupRepo, _ = sqldb.NewHostgroupsRepoGorm(dataMem, logger)
+	tagRepo, _ := sqldb.NewHostgroupTagsRepoGorm(dataMem, logger)
+	ctx := context.Background()
+	data := getFakeHostgroups()
+	assert.NoError(t, hostgroupRepo.CreateHostgroups(ctx, nil, data))
+	assert.NoError(t, tagRepo.CreateHostgroupTags(ctx, nil, []*repo.HostgroupTag{
+		{HostgroupID: data[0].Id, TagID: 1},
+		{HostgroupID: data[1].Id, TagID: 2},
+	}))
+
+	list := func(selections ...repo.Selection) []*repo.Hostgroup {
+		_data, err := hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{Selections: selections})
+		assert.NoError(t, err)
+		return _data
+	}
+	assert.Equal(t, []*repo.Hostgroup{data[0], data[1]}, list(repo.Selection{Field: repo.SelectTag, Ids: []uint32{1, 2}}))
+	assert.Equal(t, []*repo.Hostgroup{data[0], data[2]}, list(repo.Selection{Field: repo.SelectTag, Ids: []uint32{2}, Exclude: true}))
+	assert.Equal(t, []*repo.Hostgroup{data[1]}, list(
+		repo.Selection{Field: repo.SelectTeam, Ids: []uint32{data[1].TeamId}},
+		repo.Selection{Field: repo.SelectTag, Ids: []uint32{2}},
+	))
+	assert.Empty(t, list(repo.Selection{Field: repo.SelectTeam, Ids: []uint32{data[0].TeamId}, Exclude: true},
+		repo.Selection{Field: repo.SelectTag, Ids: []uint32{1}}))
+	// unknown names select nothing and exclude nothing
+	assert.Empty(t, list(repo.Selection{Field: repo.SelectCluster}))
+	assert.Equal(t, data, list(repo.Selection{Field: repo.SelectEnv, Exclude: true}))
+
+	_, err := hostgroupRepo.ListHostgroups(ctx, nil, &repo.HostgroupsFilter{
+		Selections: []repo.Selection{{Field: repo.SelectHostgroup, Ids: []uint32{1}}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	data := getDataMem()
+	ctx := context.Background()
+	m, err := sqldb.NewMigrator(data, logger)
+	assert.NoError(t, err)
+
+	version, err := m.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, m.Latest(), version)
+	assert.NoError(t, m.Check(ctx))
+	assert.True(t, data.DB.Migrator().HasColumn(&repo.Hostgroup{}, "created_at"))
+
+	// up again is a no-op
+	versions, err := m.Up(ctx, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+
+	versions, err = m.Down(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{m.Latest()}, versions)
+	assert.ErrorIs(t, m.Check(ctx), sqldb.ErrPendingMigrations)
+
+	status, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.False(t, status[len(status)-1].Applied)
+
+	versions, err = m.Down(ctx, 100)
+	assert.NoError(t, err)
+	assert.Len(t, versions, len(status)-1)
+	assert.False(t, data.DB.Migrator().HasTable(repo.UserTable))
+
+	_, err = sqldb.NewAdminRepoGorm(data, logger)
+	assert.ErrorIs(t, err, sqldb.ErrMissingTable)
+
+	_, err = m.Up(ctx, 0)
+	assert.NoError(t, err)
+	_, err = sqldb.NewAdminRepoGorm(data, logger)
+	assert.NoError(t, err)
+}
+
+func TestMigrator_SchemaTooNew(t *testing.T) {
+	data := getDataMem()
+	ctx := context.Background()
+	m, err := sqldb.NewMigrator(data, logger)
+	assert.NoError(t, err)
+
+	err = data.DB.Create(&sqldb.SchemaMigration{Version: m.Latest() + 1, Name: "future"}).Error
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, m.Check(ctx), sqldb.ErrSchemaTooNew)
+	_, err = m.Up(ctx, 0)
+	assert.ErrorIs(t, err, sqldb.ErrSchemaTooNew)
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, sqldb.ErrSchemaTooNew)
+}
+
+func TestMigrator_Failed(t *testing.T) {
+	data := getDataMem()
+	ctx := context.Background()
+	m, err := sqldb.NewMigrator(data, logger)
+	assert.NoError(t, err)
+
+	// the migration indexing applications fails without the table
+	_, err = m.Down(ctx, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, data.DB.Exec("ALTER TABLE `applications` RENAME TO `applications_old`").Error)
+	_, err = m.Up(ctx, 0)
+	assert.Error(t, err)
+
+	// sqlite rolls back the whole script, the version is pending and not dirty
+	assert.ErrorIs(t, m.Check(ctx), sqldb.ErrPendingMigrations)
+	assert.False(t, data.DB.Migrator().HasIndex(&repo.Hostgroup{}, "idx_hg_created_at"))
+	assert.NoError(t, data.DB.Exec("ALTER TABLE `applications_old` RENAME TO `applications`").Error)
+	_, err = m.Up(ctx, 0)
+	assert.NoError(t, err)
+}
+
+func TestMigrator_TeamMemberGroups(t *testing.T) {
+	data := getDataMem()
+	ctx := context.Background()
+	m, err := sqldb.NewMigrator(data, logger)
+	assert.NoError(t, err)
+	_, err = m.Down(ctx, 1)
+	assert.NoError(t, err)
+
+	// alice and bob were grouped into ops-team by the directory sync, which granted the team write,
+	// carol into a team with rules of its own
+	for _, stmt := range []string{
+		"INSERT INTO `users` (`id`, `user_name`, `password`) VALUES (5, 'alice', ''), (6, 'bob', ''), (7, 'carol', '')",
+		"INSERT INTO `teams` (`id`, `name`, `code`, `org_id`) VALUES (20, 'ops-team', 'ops', 1), (21, 'sre-team', 'sre', 1)",
+		"INSERT INTO `team_members` (`team_id`, `user_id`, `role`, `created_at`) VALUES (20, 6, 'leader', 0)",
+		"INSERT INTO `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`) VALUES " +
+			"('p', 'ops-team', 'v1/default/{resource}/ops-team/{resource_id}/{user}', 'write', '', '', ''), " +
+			"('p', 'sre-team', 'v1/default/hostgroups/{team}/{resource_id}/{user}', 'read', '', '', ''), " +
+			"('g', 'alice', 'ops-team', '', '', '', ''), ('g', 'bob', 'ops-team', '', '', '', ''), " +
+			"('g', 'carol', 'sre-team', '', '', '', '')",
+	} {
+		assert.NoError(t, data.DB.Exec(stmt).Error)
+	}
+	_, err = m.Up(ctx, 0)
+	assert.NoError(t, err)
+
+	var members []*repo.TeamMember
+	assert.NoError(t, data.DB.Order("user_id").Find(&members).Error)
+	assert.Len(t, members, 2)
+	assert.Equal(t, []uint32{5, 6}, []uint32{members[0].UserId, members[1].UserId})
+	assert.Equal(t, repo.TeamRoleMember, members[0].Role)
+	assert.Equal(t, repo.TeamRoleLeader, members[1].Role)
+
+	var rules []string
+	assert.NoError(t, data.DB.Table("casbin_rule").Order("ptype, v0, v1").
+		Pluck("ptype || ',' || v0 || ',' || v1 || ',' || v2", &rules).Error)
+	assert.Equal(t, []string{
+		"g,alice,ops-team:member,",
+		"g,bob,ops-team:leader,",
+		"g,carol,sre-team,",
+		"g,ops-team:leader,ops-team:maintainer,",
+		"g,ops-team:maintainer,ops-team:member,",
+		"p,ops-team:maintainer,v1/default/{resource}/ops-team/{resource_id}/{user},write",
+		"p,ops-team:member,v1/default/{resource}/ops-team/{resource_id}/{user},read",
+		"p,sre-team,v1/default/hostgroups/{team}/{resource_id}/{user},read",
+	}, rules)
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	data := getDataMem()
+	ctx := context.Background()
+	m, err := sqldb.NewMigrator(data, logger)
+	assert.NoError(t, err)
+
+	// a migration failed halfway on a database committing DDL implicitly
+	err = data.DB.Model(&sqldb.SchemaMigration{Version: m.Latest()}).Update("dirty", true).Error
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, m.Check(ctx), sqldb.ErrDirtyMigration)
+	_, err = m.Up(ctx, 0)
+	assert.ErrorIs(t, err, sqldb.ErrDirtyMigration)
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, sqldb.ErrDirtyMigration)
+	status, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.True(t, status[len(status)-1].Dirty)
+
+	// the script was undone by hand, the schema is at the version before
+	assert.NoError(t, m.Force(ctx, m.Latest()-1))
+	assert.ErrorIs(t, m.Check(ctx), sqldb.ErrPendingMigrations)
+	assert.NoError(t, m.Force(ctx, m.Latest()))
+	assert.NoError(t, m.Check(ctx))
+
+	assert.ErrorIs(t, m.Force(ctx, m.Latest()+1), sqldb.ErrInvalidMigration)
+}
@@ -0,0 +1,81 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizations_DefaultAndCRUD(t *testing.T) {
+	ctx := context.Background()
+	data := getDataMem()
+	orgs, err := sqldb.NewOrganizationsRepoGorm(data, logger)
+	assert.NoError(t, err)
+
+	// the migration creates the default organization
+	def, err := orgs.GetOrganizations(ctx, repo.DefaultOrgId)
+	assert.NoError(t, err)
+	assert.Equal(t, repo.DefaultOrgName, def.Name)
+
+	acme := &repo.Organization{Name: "acme", Description: "acme corp"}
+	assert.NoError(t, orgs.CreateOrganizations(ctx, nil, []*repo.Organization{acme}))
+	assert.NotZero(t, acme.ID)
+	assert.Error(t, orgs.CreateOrganizations(ctx, nil, []*repo.Organization{{Name: "acme"}}))
+
+	list, err := orgs.ListOrganizations(ctx, nil, &repo.OrganizationsFilter{Names: []string{"acme"}})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	acme.Description = "updated"
+	assert.NoError(t, orgs.UpdateOrganizations(ctx, nil, []*repo.Organization{acme}))
+	got, err := orgs.GetOrganizations(ctx, acme.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", got.Description)
+
+	assert.NoError(t, orgs.DeleteOrganizations(ctx, nil, []uint32{acme.ID}))
+	_, err = orgs.GetOrganizations(ctx, acme.ID)
+	assert.Error(t, err)
+}
+
+func TestOrganizations_ScopedNames(t *testing.T) {
+	ctx := context.Background()
+	data := getDataMem()
+	orgs, _ := sqldb.NewOrganizationsRepoGorm(data, logger)
+	teams, _ := sqldb.NewTeamsRepoGorm(data, logger)
+	products, _ := sqldb.NewProductsRepoGorm(data, logger)
+
+	acme := &repo.Organization{Name: "acme"}
+	assert.NoError(t, orgs.CreateOrganizations(ctx, nil, []*repo.Organization{acme}))
+
+	// names are unique in an organization
+	assert.NoError(t, teams.CreateTeams(ctx, nil, []*repo.Team{
+		{Name: "web", Code: "web", LeaderId: 1, OrgId: repo.DefaultOrgId},
+		{Name: "web", Code: "web", LeaderId: 1, OrgId: acme.ID},
+	}))
+	assert.Error(t, teams.CreateTeams(ctx, nil, []*repo.Team{
+		{Name: "web", Code: "web2", LeaderId: 1, OrgId: acme.ID},
+	}))
+	assert.NoError(t, products.CreateProducts(ctx, nil, []*repo.Product{
+		{Name: "shop", Code: "shop", OrgId: repo.DefaultOrgId},
+		{Name: "shop", Code: "shop", OrgId: acme.ID},
+	}))
+	assert.Error(t, products.CreateProducts(ctx, nil, []*repo.Product{
+		{Name: "shop", Code: "shop2", OrgId: acme.ID},
+	}))
+
+	list, err := teams.ListTeams(ctx, nil, &repo.TeamsFilter{OrgIds: []uint32{acme.ID}})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// organizations with teams or products are required
+	c, err := teams.CountRequire(ctx, nil, repo.RequireOrg, []uint32{acme.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), c)
+	c, err = products.CountRequire(ctx, nil, repo.RequireOrg, []uint32{acme.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), c)
+}
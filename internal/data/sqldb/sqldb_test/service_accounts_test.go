@@ -0,0 +1,102 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var serviceAccountsRepo repo.ServiceAccountsRepo
+var apiKeysRepo repo.ApiKeysRepo
+
+func initServiceAccountsRepo() {
+	dataMem = getDataMem()
+	serviceAccountsRepo, _ = sqldb.NewServiceAccountsRepoGorm(dataMem, logger)
+	apiKeysRepo, _ = sqldb.NewApiKeysRepoGorm(dataMem, logger)
+}
+
+func createBaseServiceAccountsData(t *testing.T) []*repo.ServiceAccount {
+	accounts := []*repo.ServiceAccount{
+		{Name: "ci", TeamId: 1, CreatedBy: "admin"},
+		{Name: "deployer", TeamId: 1, Role: "admin", CreatedBy: "admin"},
+		{Name: "backup", TeamId: 2, CreatedBy: "admin"},
+	}
+	err := serviceAccountsRepo.CreateServiceAccounts(context.Background(), nil, accounts)
+	assert.NoError(t, err)
+	return accounts
+}
+
+func TestServiceAccountsRepoGorm(t *testing.T) {
+	initServiceAccountsRepo()
+	ctx := context.Background()
+	accounts := createBaseServiceAccountsData(t)
+
+	// name is unique
+	err := serviceAccountsRepo.CreateServiceAccounts(ctx, nil, []*repo.ServiceAccount{{Name: "ci", TeamId: 3}})
+	assert.Error(t, err)
+
+	sa, err := serviceAccountsRepo.GetServiceAccounts(ctx, nil, accounts[1].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, accounts[1], sa)
+
+	team1, err := serviceAccountsRepo.ListServiceAccounts(ctx, nil, &repo.ServiceAccountsFilter{TeamIds: []uint32{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, accounts[:2], team1)
+
+	byName, err := serviceAccountsRepo.ListServiceAccounts(ctx, nil, &repo.ServiceAccountsFilter{Names: []string{"backup"}})
+	assert.NoError(t, err)
+	assert.Equal(t, accounts[2:], byName)
+
+	c, err := serviceAccountsRepo.CountRequire(ctx, nil, repo.RequireTeam, []uint32{1, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), c)
+	_, err = serviceAccountsRepo.CountRequire(ctx, nil, repo.RequireTeam, nil)
+	assert.Error(t, err)
+
+	err = serviceAccountsRepo.DeleteServiceAccounts(ctx, nil, []uint32{accounts[0].ID})
+	assert.NoError(t, err)
+	err = serviceAccountsRepo.DeleteServiceAccounts(ctx, nil, []uint32{accounts[0].ID})
+	assert.Error(t, err)
+	_, err = serviceAccountsRepo.GetServiceAccounts(ctx, nil, accounts[0].ID)
+	assert.Error(t, err)
+}
+
+func TestApiKeysRepoGorm(t *testing.T) {
+	initServiceAccountsRepo()
+	ctx := context.Background()
+
+	keys := []*repo.ApiKey{
+		{ServiceAccountId: 1, Name: "k1", Prefix: "opk_aaaa", Hash: "hash1", Scopes: "*"},
+		{ServiceAccountId: 1, Name: "k2", Prefix: "opk_bbbb", Hash: "hash2", Scopes: "hostgroups:read"},
+		{ServiceAccountId: 2, Name: "k3", Prefix: "opk_cccc", Hash: "hash3", Scopes: "*", ExpiresAt: 100},
+	}
+	err := apiKeysRepo.CreateApiKeys(ctx, nil, keys)
+	assert.NoError(t, err)
+
+	// hash is unique
+	err = apiKeysRepo.CreateApiKeys(ctx, nil, []*repo.ApiKey{{ServiceAccountId: 3, Hash: "hash1"}})
+	assert.Error(t, err)
+
+	byHash, err := apiKeysRepo.ListApiKeys(ctx, nil, &repo.ApiKeysFilter{Hashes: []string{"hash2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, keys[1:2], byHash)
+
+	err = apiKeysRepo.TouchApiKey(ctx, keys[1].ID, 1234)
+	assert.NoError(t, err)
+	byId, err := apiKeysRepo.ListApiKeys(ctx, nil, &repo.ApiKeysFilter{Ids: []uint32{keys[1].ID}})
+	assert.NoError(t, err)
+	assert.Len(t, byId, 1)
+	assert.Equal(t, int64(1234), byId[0].LastUsedAt)
+
+	err = apiKeysRepo.DeleteApiKeys(ctx, nil, []uint32{keys[2].ID})
+	assert.NoError(t, err)
+	err = apiKeysRepo.DeleteServiceAccountApiKeys(ctx, nil, []uint32{1})
+	assert.NoError(t, err)
+	all, err := apiKeysRepo.ListApiKeys(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+}
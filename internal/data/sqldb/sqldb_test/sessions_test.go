@@ -0,0 +1,110 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sessionsRepo repo.SessionsRepo
+
+func initSessionsRepo() {
+	dataMem = getDataMem()
+	sessionsRepo, _ = sqldb.NewSessionsRepoGorm(dataMem, logger)
+}
+
+func createBaseSessionsData(t *testing.T) []*repo.Session {
+	sessions := []*repo.Session{
+		{UserId: 1, Hash: "hash1", Device: "cli", IP: "10.0.0.1", ExpiresAt: 100},
+		{UserId: 1, Hash: "hash2", PrevHash: "hash0", Device: "web", IP: "10.0.0.2", ExpiresAt: 100},
+		{UserId: 2, Hash: "hash3", Device: "cli", IP: "10.0.0.3", ExpiresAt: 100},
+	}
+	err := sessionsRepo.CreateSessions(context.Background(), nil, sessions)
+	assert.NoError(t, err)
+	return sessions
+}
+
+func TestSessionsRepoGorm_ListSessions(t *testing.T) {
+	initSessionsRepo()
+	ctx := context.Background()
+	sessions := createBaseSessionsData(t)
+
+	all, err := sessionsRepo.ListSessions(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	user1, err := sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{UserIds: []uint32{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, sessions[:2], user1)
+
+	// match current or previous hash
+	byHash, err := sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{Hashes: []string{"hash0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, sessions[1:2], byHash)
+	byHash, err = sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{Hashes: []string{"hash3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, sessions[2:], byHash)
+}
+
+func TestSessionsRepoGorm_CreateSessions_DuplicateHash(t *testing.T) {
+	initSessionsRepo()
+	createBaseSessionsData(t)
+
+	err := sessionsRepo.CreateSessions(context.Background(), nil, []*repo.Session{{UserId: 3, Hash: "hash1"}})
+	assert.Error(t, err)
+}
+
+func TestSessionsRepoGorm_UpdateSessions(t *testing.T) {
+	initSessionsRepo()
+	ctx := context.Background()
+	sessions := createBaseSessionsData(t)
+
+	sessions[0].PrevHash = sessions[0].Hash
+	sessions[0].Hash = "hash4"
+	assert.NoError(t, sessionsRepo.UpdateSessions(ctx, nil, sessions[:1]))
+
+	updated, err := sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{Hashes: []string{"hash4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, sessions[:1], updated)
+}
+
+func TestSessionsRepoGorm_RotateSession_Race(t *testing.T) {
+	initSessionsRepo()
+	ctx := context.Background()
+	sessions := createBaseSessionsData(t)
+
+	// two refreshes read the session with hash1 and rotate it
+	first, second := *sessions[0], *sessions[0]
+	first.PrevHash, first.Hash = "hash1", "hash4"
+	second.PrevHash, second.Hash = "hash1", "hash5"
+
+	rotated, err := sessionsRepo.RotateSession(ctx, nil, &first, "hash1")
+	assert.NoError(t, err)
+	assert.True(t, rotated)
+	rotated, err = sessionsRepo.RotateSession(ctx, nil, &second, "hash1")
+	assert.NoError(t, err)
+	assert.False(t, rotated)
+
+	// the winner's token is kept
+	current, err := sessionsRepo.ListSessions(ctx, nil, &repo.SessionsFilter{Ids: []uint32{first.Id}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*repo.Session{&first}, current)
+}
+
+func TestSessionsRepoGorm_DeleteSessions(t *testing.T) {
+	initSessionsRepo()
+	ctx := context.Background()
+	sessions := createBaseSessionsData(t)
+
+	assert.NoError(t, sessionsRepo.DeleteSessions(ctx, nil, []uint32{sessions[2].Id}))
+	assert.Error(t, sessionsRepo.DeleteSessions(ctx, nil, []uint32{sessions[2].Id}))
+
+	assert.NoError(t, sessionsRepo.DeleteUserSessions(ctx, nil, []uint32{1}))
+	all, err := sessionsRepo.ListSessions(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+}
@@ -0,0 +1,51 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var signingKeysRepo repo.SigningKeysRepo
+
+func initSigningKeysRepo() {
+	dataMem = getDataMem()
+	signingKeysRepo, _ = sqldb.NewSigningKeysRepoGorm(dataMem, logger)
+}
+
+func TestSigningKeysRepoGorm(t *testing.T) {
+	initSigningKeysRepo()
+	ctx := context.Background()
+
+	keys := []*repo.SigningKey{
+		{Kid: "k1", Algorithm: repo.SigningAlgRS256, PublicKey: "pub1", CreatedAt: 100},
+		{Kid: "k2", Algorithm: repo.SigningAlgRS256, PublicKey: "pub2", CreatedAt: 200},
+	}
+	assert.NoError(t, signingKeysRepo.CreateSigningKeys(ctx, nil, keys))
+	// kid is unique
+	assert.Error(t, signingKeysRepo.CreateSigningKeys(ctx, nil, []*repo.SigningKey{{Kid: "k1"}}))
+
+	listed, err := signingKeysRepo.ListSigningKeys(ctx, nil, 300)
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 2) {
+		assert.Equal(t, "k2", listed[0].Kid)
+	}
+
+	// expiry of a rotated key is kept
+	assert.NoError(t, signingKeysRepo.ExpireSigningKeys(ctx, nil, []uint32{keys[0].ID}, 400))
+	assert.NoError(t, signingKeysRepo.ExpireSigningKeys(ctx, nil, []uint32{keys[0].ID, keys[1].ID}, 500))
+	listed, err = signingKeysRepo.ListSigningKeys(ctx, nil, 450)
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 1) {
+		assert.Equal(t, int64(500), listed[0].ExpiresAt)
+	}
+
+	assert.NoError(t, signingKeysRepo.DeleteExpiredSigningKeys(ctx, nil, 450))
+	listed, err = signingKeysRepo.ListSigningKeys(ctx, nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
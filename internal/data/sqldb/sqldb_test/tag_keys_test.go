@@ -0,0 +1,42 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagKeys_CRUD(t *testing.T) {
+	ctx := context.Background()
+	keys, err := sqldb.NewTagKeysRepoGorm(getDataMem(), logger)
+	assert.NoError(t, err)
+
+	cc := &repo.TagKey{Key: "cost-center", Pattern: "cc[0-9]+", Kinds: "applications,hostgroups", Required: true}
+	assert.NoError(t, keys.CreateTagKeys(ctx, nil, []*repo.TagKey{cc}))
+	assert.NotZero(t, cc.ID)
+	// keys are unique
+	assert.Error(t, keys.CreateTagKeys(ctx, nil, []*repo.TagKey{{Key: "cost-center"}}))
+	assert.NoError(t, keys.CreateTagKeys(ctx, nil, []*repo.TagKey{{Key: "runtime", AllowedValues: "prod,dev"}}))
+
+	list, err := keys.ListTagKeys(ctx, nil, &repo.TagKeysFilter{Keys: []string{"runtime", "other"}})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "prod,dev", list[0].AllowedValues)
+	list, _ = keys.ListTagKeys(ctx, nil, nil)
+	assert.Len(t, list, 2)
+
+	cc.Required = false
+	assert.NoError(t, keys.UpdateTagKeys(ctx, nil, []*repo.TagKey{cc}))
+	got, err := keys.GetTagKeys(ctx, cc.ID)
+	assert.NoError(t, err)
+	assert.False(t, got.Required)
+
+	assert.NoError(t, keys.DeleteTagKeys(ctx, nil, []uint32{cc.ID}))
+	_, err = keys.GetTagKeys(ctx, cc.ID)
+	assert.Error(t, err)
+	assert.Error(t, keys.DeleteTagKeys(ctx, nil, []uint32{cc.ID}))
+}
@@ -48,6 +48,7 @@ func TestTagsRepoGorm(t *testing.T) {
 		{"ListTags_emptyFilter_all", testListTags_emptyFilter_all},
 		{"ListTags_id_partial", testListTags_id_partial},
 		{"ListTags_page_partial", testListTags_page_partial},
+		{"ListTags_lastId_partial", testListTags_lastId_partial},
 		{"ListTags_keys_partial", testListTags_keys_partial},
 		{"ListTags_kvs_partial", testListTags_kvs_partial},
 		{"ListTags_nil_all", testListTags_nil_all},
@@ -80,7 +81,7 @@ func testCreateTagsError(t *testing.T) {
 		{Key: "test", Value: "value"},
 	}
 	err := tagsRepo.CreateTags(context.Background(), nil, tags)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, repo.ErrDuplicated)
 }
 
 func testUpdateTagsSuccess(t *testing.T) {
@@ -141,7 +142,7 @@ func testGetTagsError(t *testing.T) {
 	}
 	createBaseTags(t, tags)
 	tag, err := tagsRepo.GetTags(context.Background(), 99)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
 	assert.Nil(t, tag)
 }
 
@@ -181,6 +182,28 @@ func testListTags_page_partial(t *testing.T) {
 	assert.Equal(t, tags[1:], _tags)
 }
 
+func testListTags_lastId_partial(t *testing.T) {
+	tags := []*repo.Tag{
+		{Key: "test", Value: "value"},
+		{Key: "test", Value: "value2"},
+		{Key: "test", Value: "value3"},
+	}
+	createBaseTags(t, tags)
+	filter := &repo.TagsFilter{
+		Page:     1,
+		PageSize: 1,
+		LastId:   tags[0].ID,
+	}
+	_tags, err := tagsRepo.ListTags(context.Background(), nil, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, tags[1:2], _tags)
+	// rows deleted before the page are not skipped
+	assert.NoError(t, tagsRepo.DeleteTags(context.Background(), nil, []uint32{tags[0].ID}))
+	_tags, err = tagsRepo.ListTags(context.Background(), nil, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, tags[1:2], _tags)
+}
+
 func testListTags_keys_partial(t *testing.T) {
 	tags := []*repo.Tag{
 		{Key: "test", Value: "value"},
@@ -0,0 +1,52 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var teamMembersRepo repo.TeamMembersRepo
+
+func initTeamMembersRepo() {
+	dataMem = getDataMem()
+	teamMembersRepo, _ = sqldb.NewTeamMembersRepoGorm(dataMem, logger)
+}
+
+func TestTeamMembersRepoGorm(t *testing.T) {
+	initTeamMembersRepo()
+	ctx := context.Background()
+
+	members := []*repo.TeamMember{
+		{TeamId: 1, UserId: 1, Role: repo.TeamRoleLeader},
+		{TeamId: 1, UserId: 2, Role: repo.TeamRoleMember},
+		{TeamId: 2, UserId: 2, Role: repo.TeamRoleMaintainer},
+	}
+	assert.NoError(t, teamMembersRepo.CreateTeamMembers(ctx, nil, members))
+	// a user is a member of a team once
+	assert.Error(t, teamMembersRepo.CreateTeamMembers(ctx, nil, []*repo.TeamMember{{TeamId: 1, UserId: 2}}))
+
+	listed, err := teamMembersRepo.ListTeamMembers(ctx, nil, &repo.TeamMembersFilter{TeamIds: []uint32{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, members[:2], listed)
+
+	members[1].Role = repo.TeamRoleMaintainer
+	assert.NoError(t, teamMembersRepo.UpdateTeamMembers(ctx, nil, members[1:2]))
+	listed, err = teamMembersRepo.ListTeamMembers(ctx, nil, &repo.TeamMembersFilter{Roles: []string{repo.TeamRoleMaintainer}})
+	assert.NoError(t, err)
+	assert.Equal(t, members[1:], listed)
+
+	count, err := teamMembersRepo.CountRequire(ctx, nil, repo.RequireUser, []uint32{2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	assert.NoError(t, teamMembersRepo.DeleteTeamMembers(ctx, nil, []uint32{members[0].ID}))
+	assert.NoError(t, teamMembersRepo.DeleteTeamMembersByTeamId(ctx, nil, []uint32{2}))
+	listed, err = teamMembersRepo.ListTeamMembers(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, members[1:2], listed)
+}
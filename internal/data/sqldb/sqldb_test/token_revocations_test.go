@@ -0,0 +1,65 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var revocationRepo repo.TokenRevocationRepo
+
+func initTokenRevocationRepo() {
+	dataMem = getDataMem()
+	revocationRepo, _ = sqldb.NewTokenRevocationRepoGorm(dataMem, logger)
+}
+
+func TestTokenRevocationRepoGorm_RevokeToken(t *testing.T) {
+	initTokenRevocationRepo()
+	ctx := context.Background()
+
+	revoked, err := revocationRepo.IsTokenRevoked(ctx, "jti1")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	assert.NoError(t, revocationRepo.RevokeToken(ctx, "jti1", expiresAt))
+	// revoke twice
+	assert.NoError(t, revocationRepo.RevokeToken(ctx, "jti1", expiresAt))
+
+	revoked, err = revocationRepo.IsTokenRevoked(ctx, "jti1")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestTokenRevocationRepoGorm_PurgeExpired(t *testing.T) {
+	initTokenRevocationRepo()
+	ctx := context.Background()
+
+	assert.NoError(t, revocationRepo.RevokeToken(ctx, "expired", time.Now().Add(-time.Hour).Unix()))
+	assert.NoError(t, revocationRepo.RevokeToken(ctx, "jti2", time.Now().Add(time.Hour).Unix()))
+
+	var count int64
+	dataMem.DB.Model(&repo.RevokedToken{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTokenRevocationRepoGorm_RevokeUser(t *testing.T) {
+	initTokenRevocationRepo()
+	ctx := context.Background()
+
+	revokedAt, err := revocationRepo.UserRevokedAt(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), revokedAt)
+
+	assert.NoError(t, revocationRepo.RevokeUser(ctx, "1", 1000))
+	assert.NoError(t, revocationRepo.RevokeUser(ctx, "1", 2000))
+
+	revokedAt, err = revocationRepo.UserRevokedAt(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), revokedAt)
+}
@@ -0,0 +1,82 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type TagKeysRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewTagKeysRepoGorm(data *DataGorm, logger log.Logger) (repo.TagKeysRepo, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.TagKeyTable); err != nil {
+		return nil, err
+	}
+	return &TagKeysRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// CreateTagKeys is
+func (d *TagKeysRepoGorm) CreateTagKeys(ctx context.Context, tx repo.TX, keys []*repo.TagKey) error {
+	return d.data.WithTX(tx).WithContext(ctx).Create(keys).Error
+}
+
+// UpdateTagKeys is
+func (d *TagKeysRepoGorm) UpdateTagKeys(ctx context.Context, tx repo.TX, keys []*repo.TagKey) error {
+	return d.data.WithTX(tx).WithContext(ctx).Save(keys).Error
+}
+
+// DeleteTagKeys is
+func (d *TagKeysRepoGorm) DeleteTagKeys(ctx context.Context, tx repo.TX, ids []uint32) error {
+	r := d.data.WithTX(tx).WithContext(ctx).Where("id in (?)", ids).Delete(&repo.TagKey{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("delete not equal expected. want %d. affected %d", len(ids), r.RowsAffected)
+	}
+	return nil
+}
+
+// GetTagKeys is
+func (d *TagKeysRepoGorm) GetTagKeys(ctx context.Context, id uint32) (*repo.TagKey, error) {
+	key := &repo.TagKey{}
+	if err := d.data.DB.WithContext(ctx).First(key, id).Error; err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ListTagKeys is
+func (d *TagKeysRepoGorm) ListTagKeys(ctx context.Context,
+	tx repo.TX,
+	filter *repo.TagKeysFilter) ([]*repo.TagKey, error) {
+
+	keys := []*repo.TagKey{}
+	query := d.data.WithTX(tx).WithContext(ctx)
+	if filter != nil {
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
+		if len(filter.Ids) > 0 {
+			query = query.Where("id in (?)", filter.Ids)
+		}
+		if len(filter.Keys) > 0 {
+			// tags refer to registered keys, so keys match exactly
+			query = query.Where("`key` in (?)", filter.Keys)
+		}
+	}
+	if err := query.Order("id").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
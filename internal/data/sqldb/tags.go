@@ -20,19 +20,10 @@ func NewTagsRepoGorm(data *DataGorm, logger log.Logger) (repo.TagsRepo, error) {
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Tag{}, repo.TagTable); err != nil {
+	if err := requireTable(data.DB, repo.TagTable); err != nil {
 		return nil, err
 	}
 
-	// add description column if not exists
-	exists, err := data.ColumnExists(repo.TagTable, "description")
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		data.DB.AutoMigrate(&repo.Tag{})
-	}
-
 	return &TagsRepoGorm{
 		data: data,
 		log:  log.NewHelper(logger),
@@ -92,27 +83,17 @@ func (d *TagsRepoGorm) ListTags(ctx context.Context,
 	tags := []*repo.Tag{}
 
 	var r *gorm.DB
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
 		if len(filter.Keys) > 0 {
-			keyConditions := buildOrLike("key", len(filter.Keys))
-			params := make([]interface{}, len(filter.Keys))
-			for i, v := range filter.Keys {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(keyConditions, params...)
+			query = query.Where(matchNames("key", "", filter.Keys))
 		}
 		if len(filter.Kvs) > 0 {
-			kvConditions, kvs := buildOrKV("key", "value", filter.Kvs)
-			query = query.Where(kvConditions, kvs...)
+			query = query.Where(matchKVs("key", "value", filter.Kvs))
 		}
 	}
 	r = query.Find(&tags)
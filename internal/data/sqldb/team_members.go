@@ -0,0 +1,107 @@
+package sqldb
+
+import (
+	"context"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type TeamMembersRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewTeamMembersRepoGorm(data *DataGorm, logger log.Logger) (repo.TeamMembersRepo, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.TeamMemberTable); err != nil {
+		return nil, err
+	}
+	return &TeamMembersRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+func (d *TeamMembersRepoGorm) CreateTeamMembers(ctx context.Context, tx repo.TX, members []*repo.TeamMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	return d.data.WithTX(tx).WithContext(ctx).Create(members).Error
+}
+
+func (d *TeamMembersRepoGorm) UpdateTeamMembers(ctx context.Context, tx repo.TX, members []*repo.TeamMember) error {
+	db := d.data.WithTX(tx).WithContext(ctx)
+	for _, m := range members {
+		r := db.Model(&repo.TeamMember{}).Where("id = ?", m.ID).Update("role", m.Role)
+		if r.Error != nil {
+			return r.Error
+		}
+	}
+	return nil
+}
+
+func (d *TeamMembersRepoGorm) DeleteTeamMembers(ctx context.Context, tx repo.TX, ids []uint32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.data.WithTX(tx).WithContext(ctx).Delete(&repo.TeamMember{}, ids).Error
+}
+
+func (d *TeamMembersRepoGorm) DeleteTeamMembersByTeamId(ctx context.Context, tx repo.TX, teamIds []uint32) error {
+	if len(teamIds) == 0 {
+		return nil
+	}
+	return d.data.WithTX(tx).WithContext(ctx).Where("team_id in (?)", teamIds).Delete(&repo.TeamMember{}).Error
+}
+
+func (d *TeamMembersRepoGorm) ListTeamMembers(ctx context.Context, tx repo.TX, filter *repo.TeamMembersFilter) ([]*repo.TeamMember, error) {
+	query := d.data.WithTX(tx).WithContext(ctx).Model(&repo.TeamMember{})
+	if filter != nil {
+		if len(filter.TeamIds) > 0 {
+			query = query.Where("team_id in (?)", filter.TeamIds)
+		}
+		if len(filter.UserIds) > 0 {
+			query = query.Where("user_id in (?)", filter.UserIds)
+		}
+		if len(filter.Roles) > 0 {
+			query = query.Where("role in (?)", filter.Roles)
+		}
+	}
+	var members []*repo.TeamMember
+	if err := query.Order("id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (d *TeamMembersRepoGorm) CountRequire(ctx context.Context,
+	tx repo.TX,
+	need repo.RequireType,
+	ids []uint32) (int64, error) {
+
+	if len(ids) == 0 {
+		return 0, repo.ErrorRequireIds
+	}
+
+	var condition string
+	switch need {
+	case repo.RequireTeam:
+		condition = "team_id in (?)"
+	case repo.RequireUser:
+		condition = "user_id in (?)"
+	default:
+		return 0, repo.ErrorRequireIds
+	}
+
+	var count int64
+	r := d.data.WithTX(tx).WithContext(ctx).Model(&repo.TeamMember{}).
+		Where(condition, ids).Count(&count)
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	return count, nil
+}
@@ -19,7 +19,7 @@ func NewTeamsRepoGorm(data *DataGorm, logger log.Logger) (repo.TeamsRepo, error)
 		return nil, err
 	}
 
-	if err := initTable(data.DB, &repo.Team{}, repo.TeamTable); err != nil {
+	if err := requireTable(data.DB, repo.TeamTable); err != nil {
 		return nil, err
 	}
 
@@ -84,35 +84,24 @@ func (d *TeamsRepoGorm) ListTeams(ctx context.Context,
 	filter *repo.TeamsFilter) ([]*repo.Team, error) {
 
 	db_teams := []*repo.Team{}
-	query := d.data.WithTX(tx).WithContext(ctx)
+	query := d.data.WithTX(tx).WithContext(ctx).Order("id")
 	if filter != nil {
-		var offset int
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset = int((filter.Page - 1) * filter.PageSize)
-			query = query.Offset(offset).Limit(int(filter.PageSize))
-		}
+		query = paginate(query, filter.Page, filter.PageSize, filter.LastId, false)
 
 		if len(filter.Ids) > 0 {
 			query = query.Where("id in (?)", filter.Ids)
 		}
+		if len(filter.OrgIds) > 0 {
+			query = query.Where("org_id in (?)", filter.OrgIds)
+		}
 		if len(filter.Codes) > 0 {
-			codeConditions := buildOrLike("code", len(filter.Codes))
-			params := make([]interface{}, len(filter.Codes))
-			for i, v := range filter.Codes {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(codeConditions, params...)
+			query = query.Where(matchNames("code", "", filter.Codes))
 		}
 		if len(filter.LeadersId) > 0 {
 			query = query.Where("leader_id in (?)", filter.LeadersId)
 		}
 		if len(filter.Names) > 0 {
-			nameConditions := buildOrLike("name", len(filter.Names))
-			params := make([]interface{}, len(filter.Names))
-			for i, v := range filter.Names {
-				params[i] = "%" + v + "%"
-			}
-			query = query.Where(nameConditions, params...)
+			query = query.Where(matchNames("name", "", filter.Names))
 		}
 	}
 	r := query.Find(&db_teams)
@@ -154,6 +143,8 @@ func (d *TeamsRepoGorm) CountRequire(ctx context.Context,
 	switch need {
 	case repo.RequireUser:
 		condition = "leader_id in (?)"
+	case repo.RequireOrg:
+		condition = "org_id in (?)"
 	default:
 		return 0, repo.ErrorRequireIds
 	}
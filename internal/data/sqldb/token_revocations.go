@@ -0,0 +1,88 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRevocationRepoGorm struct {
+	data *DataGorm
+	log  *log.Helper
+}
+
+func NewTokenRevocationRepoGorm(data *DataGorm, logger log.Logger) (repo.TokenRevocationRepo, error) {
+
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.RevokedTokenTable); err != nil {
+		return nil, err
+	}
+	if err := requireTable(data.DB, repo.RevokedUserTable); err != nil {
+		return nil, err
+	}
+	return &TokenRevocationRepoGorm{
+		data: data,
+		log:  log.NewHelper(logger),
+	}, nil
+}
+
+// RevokeToken is
+func (d *TokenRevocationRepoGorm) RevokeToken(ctx context.Context, jti string, expiresAt int64) error {
+
+	db := d.data.DB.WithContext(ctx)
+	// purge expired tokens, they are rejected by expiration anyway
+	if r := db.Where("expires_at < ?", time.Now().Unix()).Delete(&repo.RevokedToken{}); r.Error != nil {
+		d.log.Warnf("purge revoked tokens failed: %v", r.Error)
+	}
+	r := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&repo.RevokedToken{
+		Jti:       jti,
+		ExpiresAt: expiresAt,
+	})
+	return r.Error
+}
+
+// IsTokenRevoked is
+func (d *TokenRevocationRepoGorm) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+
+	var count int64
+	r := d.data.DB.WithContext(ctx).Model(&repo.RevokedToken{}).Where("jti = ?", jti).Count(&count)
+	if r.Error != nil {
+		return false, r.Error
+	}
+	return count > 0, nil
+}
+
+// RevokeUser sets the cutoff of user in unix milliseconds, replacing an earlier one.
+func (d *TokenRevocationRepoGorm) RevokeUser(ctx context.Context, userId string, revokedAt int64) error {
+
+	r := d.data.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_at"}),
+	}).Create(&repo.RevokedUser{
+		UserId:    userId,
+		RevokedAt: revokedAt,
+	})
+	return r.Error
+}
+
+// UserRevokedAt returns the cutoff of user in unix milliseconds, 0 if tokens of user were never revoked.
+func (d *TokenRevocationRepoGorm) UserRevokedAt(ctx context.Context, userId string) (int64, error) {
+
+	user := &repo.RevokedUser{}
+	r := d.data.DB.WithContext(ctx).Where("user_id = ?", userId).First(user)
+	if errors.Is(r.Error, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	return user.RevokedAt, nil
+}
@@ -28,31 +28,50 @@ func (tm *TxManagerGorm) RunInTX(fn func(tx repo.TX) error) (err error) {
 		return tx.Error
 	}
 
+	gtx := &TxGorm{tx: tx, log: tm.log}
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
+			gtx.finish(false)
 			tm.log.Errorf("panic occurred, rolling back transaction: %v", r)
 			err = fmt.Errorf("panic in transaction: %v", r)
 		}
 	}()
 
-	gtx := &TxGorm{tx: tx, log: tm.log}
 	if err := fn(gtx); err != nil {
 		tx.Rollback()
+		gtx.finish(false)
 		return err
 	}
 
 	if err := tx.Commit().Error; err != nil {
+		gtx.finish(false)
 		tm.log.Errorf("failed to commit transaction: %v", err)
 		return err
 	}
+	gtx.finish(true)
 
 	return nil
 }
 
 type TxGorm struct {
-	tx  *gorm.DB
-	log *log.Helper
+	tx       *gorm.DB
+	log      *log.Helper
+	onFinish []func(committed bool)
+}
+
+// OnFinish registers fn to run after the transaction is committed or rolled back,
+// state kept in memory follows the database there.
+func (gtx *TxGorm) OnFinish(fn func(committed bool)) {
+	gtx.onFinish = append(gtx.onFinish, fn)
+}
+
+func (gtx *TxGorm) finish(committed bool) {
+	fns := gtx.onFinish
+	gtx.onFinish = nil
+	for _, fn := range fns {
+		fn(committed)
+	}
 }
 
 func (gtx *TxGorm) GetDB() interface{} {
@@ -0,0 +1,29 @@
+package data
+
+import (
+	"opspillar/internal/conf"
+	"opspillar/internal/data/redisdb"
+	"opspillar/internal/data/repo"
+	"opspillar/internal/data/sqldb"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// NewTokenRevocationRepo stores revoked tokens in redis if data.redis.addr is configured,
+// otherwise in database.
+func NewTokenRevocationRepo(c *conf.Data, data *sqldb.DataGorm, logger log.Logger) (repo.TokenRevocationRepo, func(), error) {
+	if c.GetRedis().GetAddr() == "" {
+		r, err := sqldb.NewTokenRevocationRepoGorm(data, logger)
+		return r, func() {}, err
+	}
+	rdata, cleanup, err := redisdb.NewDataRedis(c.GetRedis(), logger)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	r, err := redisdb.NewTokenRevocationRepoRedis(rdata, logger)
+	if err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+	return r, cleanup, nil
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"opspillar/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type statusCode struct {
+	http int
+	grpc codes.Code
+}
+
+// statusCodes of the kinds of domain errors, kratos derives gRPC codes from HTTP ones and would
+// lose AlreadyExists, FailedPrecondition and Aborted.
+var statusCodes = map[biz.ErrorKind]statusCode{
+	biz.KindNotFound:           {http.StatusNotFound, codes.NotFound},
+	biz.KindAlreadyExists:      {http.StatusConflict, codes.AlreadyExists},
+	biz.KindPermissionDenied:   {http.StatusForbidden, codes.PermissionDenied},
+	biz.KindInvalidArgument:    {http.StatusBadRequest, codes.InvalidArgument},
+	biz.KindFailedPrecondition: {http.StatusBadRequest, codes.FailedPrecondition},
+	biz.KindConflict:           {http.StatusConflict, codes.Aborted},
+}
+
+// statusError is a kratos error replied with its own gRPC code, HTTP replies find the kratos
+// error by Unwrap.
+type statusError struct {
+	err  *errors.Error
+	code codes.Code
+}
+
+func (e *statusError) Error() string { return e.err.Error() }
+
+func (e *statusError) Unwrap() error { return e.err }
+
+func (e *statusError) GRPCStatus() *status.Status {
+	s := e.err.GRPCStatus().Proto()
+	s.Code = int32(e.code)
+	return status.FromProto(s)
+}
+
+// Errors replies the domain errors returned by handlers with the status codes of their kinds,
+// the reason is the kind and the metadata maps the fields at fault to what is wrong with them.
+// Other errors are replied as they are, unknown ones with code 500.
+func Errors() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req)
+			if err == nil {
+				return reply, nil
+			}
+			e, ok := biz.AsError(err)
+			if !ok {
+				return reply, err
+			}
+			code := statusCodes[e.Kind]
+			return reply, &statusError{
+				err:  errors.New(code.http, string(e.Kind), e.Error()).WithMetadata(e.Fields).WithCause(err),
+				code: code.grpc,
+			}
+		}
+	}
+}
@@ -1,21 +1,103 @@
 package middleware
 
 import (
-	"opspillar/internal/data"
 	"context"
+	"errors"
+	"net"
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+	"strconv"
 	"strings"
 
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
-	"github.com/golang-jwt/jwt"
+	"github.com/go-kratos/kratos/v2/transport/http"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 type JWTMiddlewareOption struct {
-	Secret          string
-	EmergencyHeader string
-	DefaultSecret   string
+	// TokenRepo validates signature, expiry and revocation of tokens
+	TokenRepo repo.TokenRepo
+	// ApiKeys accepts api keys of service accounts if set
+	ApiKeys ApiKeyAuthenticator
+	// BreakGlass accepts break-glass credentials if set
+	BreakGlass BreakGlassAuthenticator
+	// Logger logs the errors hidden from callers, the default logger if not set
+	Logger log.Logger
+}
+
+// ApiKeyHeader carries an api key, api keys are also accepted as Bearer token.
+const ApiKeyHeader = "X-API-Key"
+
+// ApiKeyPrefix tells api keys from JWT in Bearer scheme.
+const ApiKeyPrefix = "opk_"
+
+// ApiKeyAuthenticator authenticates api key for the operation and returns its subject.
+type ApiKeyAuthenticator interface {
+	AuthenticateApiKey(ctx context.Context, key, operation string) (string, error)
+}
+
+// BreakGlassHeader carries a break-glass credential for emergency access.
+const BreakGlassHeader = "X-Break-Glass"
+
+// BreakGlassAuthenticator verifies and audits break-glass credential, and returns its principal user.
+type BreakGlassAuthenticator interface {
+	AuthenticateBreakGlass(ctx context.Context, secret, operation, ip string) (string, uint32, error)
+}
+
+// ClientIP returns the peer address of http or grpc request without port.
+func ClientIP(ctx context.Context) string {
+	var ip string
+	if req, ok := http.RequestFromServerContext(ctx); ok {
+		ip = req.RemoteAddr
+	} else if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+	}
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
+
+func authenticateBreakGlass(ctx context.Context, opt JWTMiddlewareOption, tr transport.Transporter, secret string) (context.Context, error) {
+	if opt.BreakGlass == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "break-glass not supported")
+	}
+	username, userId, err := opt.BreakGlass.AuthenticateBreakGlass(ctx, secret, tr.Operation(), ClientIP(ctx))
+	if err != nil {
+		if errors.Is(err, repo.ErrExpiredBreakGlass) {
+			return nil, status.Errorf(codes.Unauthenticated, "expired break-glass credential")
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid break-glass credential")
+	}
+	ctx = context.WithValue(ctx, data.CtxUserName, username)
+	return context.WithValue(ctx, data.CtxUserId, strconv.Itoa(int(userId))), nil
+}
+
+func authenticateApiKey(ctx context.Context, opt JWTMiddlewareOption, tr transport.Transporter, key string) (context.Context, error) {
+	if opt.ApiKeys == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "api key not supported")
+	}
+	sub, err := opt.ApiKeys.AuthenticateApiKey(ctx, key, tr.Operation())
+	if err != nil {
+		if errors.Is(err, repo.ErrExpiredApiKey) {
+			return nil, status.Errorf(codes.Unauthenticated, "expired api key")
+		}
+		if errors.Is(err, repo.ErrApiKeyScope) {
+			return nil, status.Errorf(codes.PermissionDenied, "api key scope denied")
+		}
+		// malformed, unknown and revoked keys or failed lookups look the same to callers
+		logger := opt.Logger
+		if logger == nil {
+			logger = log.GetLogger()
+		}
+		log.NewHelper(logger).Warnf("api key of %s on %s rejected: %v", ClientIP(ctx), tr.Operation(), err)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid api key")
+	}
+	return context.WithValue(ctx, data.CtxUserName, sub), nil
 }
 
 // JWTMiddleware returns a middleware that validates JWT tokens
@@ -25,7 +107,12 @@ func JWTMiddleware(opt JWTMiddlewareOption) middleware.Middleware {
 
 			// Skip JWT check for login endpoint
 			if tr, ok := transport.FromServerContext(ctx); ok {
-				if tr.Operation() == "/api.opspillar.v1.Admin/Login" {
+				if tr.Operation() == "/api.opspillar.v1.Admin/Login" ||
+					tr.Operation() == "/api.opspillar.v1.Admin/RefreshToken" ||
+					tr.Operation() == "/api.opspillar.v1.Admin/SSOAuthURL" ||
+					tr.Operation() == "/api.opspillar.v1.Admin/SSOLogin" ||
+					tr.Operation() == "/api.opspillar.v1.Admin/ChangePassword" ||
+					tr.Operation() == "/api.opspillar.v1.Admin/ResetPassword" {
 					return handler(ctx, req)
 				}
 			}
@@ -35,7 +122,19 @@ func JWTMiddleware(opt JWTMiddlewareOption) middleware.Middleware {
 				return nil, status.Errorf(codes.Unauthenticated, "context error")
 			}
 
-			if header.RequestHeader().Get(opt.EmergencyHeader) != "" {
+			if secret := header.RequestHeader().Get(BreakGlassHeader); secret != "" {
+				ctx, err := authenticateBreakGlass(ctx, opt, header, secret)
+				if err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}
+
+			if apiKey := header.RequestHeader().Get(ApiKeyHeader); apiKey != "" {
+				ctx, err := authenticateApiKey(ctx, opt, header, apiKey)
+				if err != nil {
+					return nil, err
+				}
 				return handler(ctx, req)
 			}
 
@@ -52,33 +151,32 @@ func JWTMiddleware(opt JWTMiddlewareOption) middleware.Middleware {
 					return nil, status.Errorf(codes.Unauthenticated, "empty JWT token")
 				}
 
-				// Validate JWT
-				token, err := jwt.Parse(jwtToken, func(token *jwt.Token) (interface{}, error) {
-					// Get secret from config
-					if opt.Secret == "" {
-						return []byte(opt.DefaultSecret), nil
+				if strings.HasPrefix(jwtToken, ApiKeyPrefix) {
+					ctx, err := authenticateApiKey(ctx, opt, header, jwtToken)
+					if err != nil {
+						return nil, err
 					}
-					return []byte(opt.Secret), nil
-				})
+					return handler(ctx, req)
+				}
 
+				// Validate JWT
+				if opt.TokenRepo == nil {
+					return nil, status.Errorf(codes.Unauthenticated, "JWT not supported")
+				}
+				claims, err := opt.TokenRepo.ValidateToken(ctx, jwtToken)
 				if err != nil {
+					if errors.Is(err, repo.ErrRevokedToken) {
+						return nil, status.Errorf(codes.Unauthenticated, "revoked JWT")
+					}
 					return nil, status.Errorf(codes.Unauthenticated, "failed to validate JWT")
 				}
 
-				if !token.Valid {
-					return nil, status.Errorf(codes.Unauthenticated, "invalid JWT")
-				}
-
 				ctx = context.WithValue(ctx, data.CtxUserTokenKey, jwtToken)
 				// If JWT is valid, proceed with request
-				if claims, ok := token.Claims.(jwt.MapClaims); ok {
-					// Add token to context
-					if username, ok := claims[string(data.CtxUserName)].(string); ok {
-						ctx = context.WithValue(ctx, data.CtxUserName, username)
-					}
-					// Add user ID to context
-					if userId, ok := claims[string(data.CtxUserId)].(string); ok {
-						ctx = context.WithValue(ctx, data.CtxUserId, userId)
+				// Add user name, user ID and session ID to context
+				for _, key := range []data.ContextKey{data.CtxUserName, data.CtxUserId, data.CtxSessionId} {
+					if v, ok := claims[string(key)]; ok {
+						ctx = context.WithValue(ctx, key, v)
 					}
 				}
 
@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/middleware"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func callErrors(err error) error {
+	next := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, err }
+	_, err = middleware.Errors()(next)(context.Background(), nil)
+	return err
+}
+
+func TestErrors_Kinds(t *testing.T) {
+	cases := []struct {
+		err  error
+		http int
+		grpc codes.Code
+	}{
+		{biz.NotFound("tag 9 not found"), 404, codes.NotFound},
+		{biz.AlreadyExists("user name is taken by a local user"), 409, codes.AlreadyExists},
+		{biz.ErrPermissionDenied, 403, codes.PermissionDenied},
+		{biz.ErrEmptyIds, 400, codes.InvalidArgument},
+		{biz.FailedPrecondition("some tag requires"), 400, codes.FailedPrecondition},
+		{biz.Conflict("name is taken"), 409, codes.Aborted},
+	}
+	for _, c := range cases {
+		err := callErrors(c.err)
+		se := errors.FromError(err)
+		assert.Equal(t, c.http, int(se.Code), c.err)
+		assert.Equal(t, c.err.Error(), se.Message)
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, c.grpc, s.Code(), c.err)
+		assert.Equal(t, c.err.Error(), s.Message())
+	}
+}
+
+func TestErrors_Fields(t *testing.T) {
+	err := callErrors(stderrors.Join(stderrors.New("UpdateTags failed"), biz.ErrEmptyIds))
+
+	se := errors.FromError(err)
+	assert.Equal(t, string(biz.KindInvalidArgument), se.Reason)
+	assert.Equal(t, map[string]string{"ids": "must not be empty"}, se.Metadata)
+	assert.Equal(t, "UpdateTags failed\nEmptyIds", se.Message)
+
+	// clients of gRPC read the reason and fields from the details
+	s, _ := status.FromError(err)
+	se = errors.FromError(s.Err())
+	assert.Equal(t, string(biz.KindInvalidArgument), se.Reason)
+	assert.Equal(t, map[string]string{"ids": "must not be empty"}, se.Metadata)
+}
+
+func TestErrors_Unclassified(t *testing.T) {
+	err := stderrors.New("disk full")
+	assert.Equal(t, err, callErrors(err))
+	assert.Nil(t, callErrors(nil))
+
+	// errors of other middlewares keep their codes
+	limited := errors.New(429, middleware.ErrRateLimited, "too many requests")
+	assert.Equal(t, limited, callErrors(limited))
+}
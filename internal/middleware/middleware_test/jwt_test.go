@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"opspillar/internal/data"
+	"opspillar/internal/data/repo"
+	"opspillar/internal/middleware"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeApiKeys struct{ err error }
+
+func (k *fakeApiKeys) AuthenticateApiKey(ctx context.Context, key, operation string) (string, error) {
+	return "sa:deploy", k.err
+}
+
+func callApiKey(err error) (interface{}, error) {
+	tr := &fakeTransport{
+		operation: "/api.opspillar.v1.Tags/ListTags",
+		request:   headerCarrier{middleware.ApiKeyHeader: "opk_key"},
+		reply:     headerCarrier{},
+	}
+	ctx := transport.NewServerContext(context.Background(), tr)
+	next := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return ctx.Value(data.CtxUserName), nil
+	}
+	return middleware.JWTMiddleware(middleware.JWTMiddlewareOption{ApiKeys: &fakeApiKeys{err: err}})(next)(ctx, nil)
+}
+
+func TestJWTMiddleware_ApiKeyErrors(t *testing.T) {
+	sub, err := callApiKey(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "sa:deploy", sub)
+
+	cases := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		// causes of invalid keys are logged, not returned
+		{stderrors.New("no such table: api_keys"), codes.Unauthenticated, "invalid api key"},
+		{stderrors.Join(stderrors.New("invalid api key"), repo.ErrNotFound), codes.Unauthenticated, "invalid api key"},
+		{repo.ErrExpiredApiKey, codes.Unauthenticated, "expired api key"},
+		{repo.ErrApiKeyScope, codes.PermissionDenied, "api key scope denied"},
+	}
+	for _, c := range cases {
+		_, err := callApiKey(c.err)
+		s, ok := status.FromError(err)
+		assert.True(t, ok, c.err)
+		assert.Equal(t, c.code, s.Code(), c.err)
+		assert.Equal(t, c.message, s.Message(), c.err)
+	}
+}
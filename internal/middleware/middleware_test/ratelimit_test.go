@@ -0,0 +1,130 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"opspillar/internal/middleware"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+type headerCarrier map[string]string
+
+func (h headerCarrier) Get(key string) string      { return h[key] }
+func (h headerCarrier) Set(key, value string)      { h[key] = value }
+func (h headerCarrier) Add(key, value string)      { h[key] = value }
+func (h headerCarrier) Keys() []string             { return nil }
+func (h headerCarrier) Values(key string) []string { return []string{h[key]} }
+
+type fakeTransport struct {
+	operation string
+	request   headerCarrier
+	reply     headerCarrier
+}
+
+func (t *fakeTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *fakeTransport) Endpoint() string                { return "" }
+func (t *fakeTransport) Operation() string               { return t.operation }
+func (t *fakeTransport) RequestHeader() transport.Header { return t.request }
+func (t *fakeTransport) ReplyHeader() transport.Header   { return t.reply }
+
+type loginReq struct{ userName string }
+
+func (r *loginReq) GetUserName() string { return r.userName }
+
+func call(l *middleware.RateLimiter, operation, ip, principal string, req interface{}) (*fakeTransport, error) {
+	tr := &fakeTransport{operation: operation, reply: headerCarrier{}}
+	ctx := transport.NewServerContext(context.Background(), tr)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 5000}})
+	if principal != "" {
+		ctx = context.WithValue(ctx, data.CtxUserName, principal)
+	}
+	next := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := l.Client()(l.Principal()(next))(ctx, req)
+	return tr, err
+}
+
+func TestRateLimiter_Defaults(t *testing.T) {
+	l := middleware.NewRateLimiter(&conf.RateLimit{
+		Defaults: &conf.RateLimit_Limits{Ip: &conf.RateLimit_Bucket{Rate: 0.5, Burst: 2}},
+	})
+	for i := 0; i < 2; i++ {
+		_, err := call(l, "/api.opspillar.v1.Tags/ListTags", "10.0.0.1", "", nil)
+		assert.NoError(t, err)
+	}
+	tr, err := call(l, "/api.opspillar.v1.Tags/ListTags", "10.0.0.1", "", nil)
+	assert.Equal(t, int32(429), errors.FromError(err).Code)
+	assert.Equal(t, middleware.ErrRateLimited, errors.Reason(err))
+	assert.Equal(t, "2", errors.FromError(err).Metadata["retry-after"])
+	assert.Equal(t, "2", tr.reply.Get(middleware.RetryAfterHeader))
+
+	// the default bucket of an ip is shared by operations, other ips have their own
+	_, err = call(l, "/api.opspillar.v1.Teams/ListTeams", "10.0.0.1", "", nil)
+	assert.Error(t, err)
+	_, err = call(l, "/api.opspillar.v1.Tags/ListTags", "10.0.0.2", "", nil)
+	assert.NoError(t, err)
+}
+
+func TestRateLimiter_Login(t *testing.T) {
+	l := middleware.NewRateLimiter(nil)
+	login := "/api.opspillar.v1.Admin/Login"
+	// usernames are limited across ips, in any case
+	for i := 0; i < 5; i++ {
+		_, err := call(l, login, "10.0.0.1", "", &loginReq{userName: "alice"})
+		assert.NoError(t, err)
+	}
+	_, err := call(l, login, "10.0.0.9", "", &loginReq{userName: "Alice"})
+	assert.Equal(t, int32(429), errors.FromError(err).Code)
+	_, err = call(l, login, "10.0.0.9", "", &loginReq{userName: "bob"})
+	assert.NoError(t, err)
+
+	// the rejected request took no token of its ip, ten are allowed per ip
+	for i := 0; i < 9; i++ {
+		_, err = call(l, login, "10.0.0.9", "", &loginReq{userName: "user" + string(rune('a'+i))})
+		assert.NoError(t, err)
+	}
+	_, err = call(l, login, "10.0.0.9", "", &loginReq{userName: "carol"})
+	assert.Error(t, err)
+
+	// login buckets do not limit other operations
+	_, err = call(l, "/api.opspillar.v1.Tags/ListTags", "10.0.0.1", "", nil)
+	assert.NoError(t, err)
+}
+
+func TestRateLimiter_Operations(t *testing.T) {
+	l := middleware.NewRateLimiter(&conf.RateLimit{
+		Operations: map[string]*conf.RateLimit_Limits{
+			"/api.opspillar.v1.Tags/CreateTags": {Principal: &conf.RateLimit_Bucket{Rate: 1}},
+			"/api.opspillar.v1.Admin/Login":     {Ip: &conf.RateLimit_Bucket{Rate: 0}},
+		},
+	})
+	_, err := call(l, "/api.opspillar.v1.Tags/CreateTags", "10.0.0.1", "alice", nil)
+	assert.NoError(t, err)
+	_, err = call(l, "/api.opspillar.v1.Tags/CreateTags", "10.0.0.2", "alice", nil)
+	assert.Error(t, err)
+	_, err = call(l, "/api.opspillar.v1.Tags/ListTags", "10.0.0.1", "alice", nil)
+	assert.NoError(t, err)
+
+	// rate 0 is unlimited, buckets not overridden keep the defaults of login
+	for i := 0; i < 20; i++ {
+		_, err = call(l, "/api.opspillar.v1.Admin/Login", "10.0.0.3", "", &loginReq{userName: "user" + string(rune('a'+i))})
+		assert.NoError(t, err)
+	}
+	for i := 0; i < 6; i++ {
+		_, err = call(l, "/api.opspillar.v1.Admin/Login", "10.0.0.3", "", &loginReq{userName: "bob"})
+	}
+	assert.Error(t, err)
+
+	l = middleware.NewRateLimiter(&conf.RateLimit{Disabled: true})
+	for i := 0; i < 100; i++ {
+		_, err = call(l, "/api.opspillar.v1.Admin/Login", "10.0.0.1", "", &loginReq{userName: "alice"})
+	}
+	assert.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"opspillar/internal/data"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// OrgHeader selects the organization of a request by name, lists are filtered by it and
+// teams and products are created in it.
+const OrgHeader = "X-Org"
+
+// Org puts the organization selected by OrgHeader in the context, requests without it
+// work on all organizations.
+func Org() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				if org := strings.TrimSpace(tr.RequestHeader().Get(OrgHeader)); org != "" {
+					ctx = context.WithValue(ctx, data.CtxOrgName, org)
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
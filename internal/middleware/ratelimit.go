@@ -0,0 +1,227 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"opspillar/internal/conf"
+	"opspillar/internal/data"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// RetryAfterHeader tells rejected clients the seconds to wait, it is also in error metadata.
+const RetryAfterHeader = "Retry-After"
+
+// ErrRateLimited is the reason of rejected requests.
+const ErrRateLimited = "RATE_LIMITED"
+
+var defaultLimits = &conf.RateLimit_Limits{
+	Ip:        &conf.RateLimit_Bucket{Rate: 20, Burst: 40},
+	Principal: &conf.RateLimit_Bucket{Rate: 20, Burst: 40},
+}
+
+// loginLimits slow down password guessing, a user may retype a password a few times a minute.
+var loginLimits = &conf.RateLimit_Limits{
+	Ip:       &conf.RateLimit_Bucket{Rate: 10.0 / 60, Burst: 10},
+	Username: &conf.RateLimit_Bucket{Rate: 5.0 / 60, Burst: 5},
+}
+
+var defaultOperationLimits = map[string]*conf.RateLimit_Limits{
+	"/api.opspillar.v1.Admin/Login":          loginLimits,
+	"/api.opspillar.v1.Admin/SSOLogin":       loginLimits,
+	"/api.opspillar.v1.Admin/ChangePassword": loginLimits,
+	"/api.opspillar.v1.Admin/ResetPassword":  loginLimits,
+}
+
+// sweepInterval drops buckets refilled to full, they are the same as new ones.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64
+	burst  float64
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// RateLimiter limits requests with token buckets keyed by client ip, username of login
+// requests and authenticated principal. Buckets are in memory of each server.
+type RateLimiter struct {
+	disabled   bool
+	defaults   *conf.RateLimit_Limits
+	operations map[string]*conf.RateLimit_Limits
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter merges c into the default limits, c may be nil.
+func NewRateLimiter(c *conf.RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		disabled:   c.GetDisabled(),
+		defaults:   mergeLimits(defaultLimits, c.GetDefaults()),
+		operations: make(map[string]*conf.RateLimit_Limits),
+		buckets:    make(map[string]*bucket),
+		now:        time.Now,
+	}
+	for op, limits := range defaultOperationLimits {
+		l.operations[op] = limits
+	}
+	for op, limits := range c.GetOperations() {
+		l.operations[op] = mergeLimits(l.operations[op], limits)
+	}
+	l.lastSweep = l.now()
+	return l
+}
+
+func mergeLimits(base, override *conf.RateLimit_Limits) *conf.RateLimit_Limits {
+	merged := &conf.RateLimit_Limits{Ip: base.GetIp(), Username: base.GetUsername(), Principal: base.GetPrincipal()}
+	if override.GetIp() != nil {
+		merged.Ip = override.GetIp()
+	}
+	if override.GetUsername() != nil {
+		merged.Username = override.GetUsername()
+	}
+	if override.GetPrincipal() != nil {
+		merged.Principal = override.GetPrincipal()
+	}
+	return merged
+}
+
+type limitKey struct {
+	key    string
+	bucket *conf.RateLimit_Bucket
+}
+
+// limit returns the bucket of kind for operation, its key is scoped to the operation if
+// the operation sets the bucket.
+func (l *RateLimiter) limit(operation, kind, value string, get func(*conf.RateLimit_Limits) *conf.RateLimit_Bucket) *limitKey {
+	if value == "" {
+		return nil
+	}
+	scope := "*"
+	b := get(l.defaults)
+	if ob := get(l.operations[operation]); ob != nil {
+		scope, b = operation, ob
+	}
+	if b.GetRate() <= 0 {
+		return nil
+	}
+	return &limitKey{key: scope + "|" + kind + "|" + value, bucket: b}
+}
+
+// take takes a token of every key, or none of them. It returns how long to wait if
+// any bucket is empty.
+func (l *RateLimiter) take(keys ...*limitKey) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		for k, b := range l.buckets {
+			if b.refill(now); b.tokens >= b.burst {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	var wait time.Duration
+	taken := make([]*bucket, 0, len(keys))
+	for _, k := range keys {
+		if k == nil {
+			continue
+		}
+		b, ok := l.buckets[k.key]
+		if !ok {
+			burst := float64(k.bucket.GetBurst())
+			if burst <= 0 {
+				burst = math.Max(1, math.Ceil(k.bucket.GetRate()))
+			}
+			b = &bucket{tokens: burst, last: now, burst: burst}
+			l.buckets[k.key] = b
+		}
+		// rate may change with config reload of a new server, burst of a bucket does not
+		b.rate = k.bucket.GetRate()
+		b.refill(now)
+		if b.tokens < 1 {
+			if w := time.Duration((1 - b.tokens) / b.rate * float64(time.Second)); w > wait {
+				wait = w
+			}
+			continue
+		}
+		taken = append(taken, b)
+	}
+	if wait > 0 {
+		return wait
+	}
+	for _, b := range taken {
+		b.tokens--
+	}
+	return 0
+}
+
+func rateLimited(ctx context.Context, wait time.Duration) error {
+	seconds := strconv.Itoa(int(math.Ceil(wait.Seconds())))
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		tr.ReplyHeader().Set(RetryAfterHeader, seconds)
+	}
+	return errors.New(429, ErrRateLimited, fmt.Sprintf("too many requests, retry after %ss", seconds)).
+		WithMetadata(map[string]string{strings.ToLower(RetryAfterHeader): seconds})
+}
+
+// Client limits requests by client ip and username of login requests, it runs before
+// authentication so that failed ones are counted.
+func (l *RateLimiter) Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if l.disabled || !ok {
+				return handler(ctx, req)
+			}
+			op := tr.Operation()
+			var username string
+			if r, ok := req.(interface{ GetUserName() string }); ok {
+				username = strings.ToLower(r.GetUserName())
+			}
+			wait := l.take(
+				l.limit(op, "ip", ClientIP(ctx), (*conf.RateLimit_Limits).GetIp),
+				l.limit(op, "username", username, (*conf.RateLimit_Limits).GetUsername),
+			)
+			if wait > 0 {
+				return nil, rateLimited(ctx, wait)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Principal limits requests by the authenticated user or service account, it runs after
+// authentication.
+func (l *RateLimiter) Principal() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if l.disabled || !ok {
+				return handler(ctx, req)
+			}
+			principal, _ := ctx.Value(data.CtxUserName).(string)
+			if wait := l.take(l.limit(tr.Operation(), "principal", principal, (*conf.RateLimit_Limits).GetPrincipal)); wait > 0 {
+				return nil, rateLimited(ctx, wait)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// DirectorySyncServer syncs users and teams from LDAP periodically,
+// it runs with the app like grpc and http servers.
+type DirectorySyncServer struct {
+	interval time.Duration
+	admin    *biz.AdminUsecase
+	log      *log.Helper
+	stop     chan struct{}
+}
+
+// NewDirectorySyncServer new a directory sync server, it does nothing if ldap or sync interval is not configured.
+func NewDirectorySyncServer(c *conf.Admin, admin *biz.AdminUsecase, logger log.Logger) *DirectorySyncServer {
+	s := &DirectorySyncServer{
+		admin: admin,
+		log:   log.NewHelper(logger),
+		stop:  make(chan struct{}),
+	}
+	if c.GetLdap().GetUrl() != "" && c.GetLdap().GetSyncInterval() != nil {
+		s.interval = c.GetLdap().GetSyncInterval().AsDuration()
+	}
+	return s
+}
+
+func (s *DirectorySyncServer) Start(ctx context.Context) error {
+	if s.interval <= 0 {
+		return nil
+	}
+	s.log.Infof("[LDAP] sync every %s", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		// errors are logged only, the next sync may succeed
+		if err := s.admin.SyncDirectory(ctx); err != nil {
+			s.log.Errorf("[LDAP] sync failed: %v", err)
+		}
+		select {
+		case <-s.stop:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DirectorySyncServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	return nil
+}
@@ -3,6 +3,7 @@ package server
 import (
 	apiv1 "opspillar/api/opspillar/v1"
 	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
 	"opspillar/internal/middleware"
 	"opspillar/internal/service"
 
@@ -13,29 +14,43 @@ import (
 
 // NewGRPCServer new a gRPC server.
 func NewGRPCServer(c *conf.Server,
-	adminConf *conf.Admin,
+	tokenRepo repo.TokenRepo,
+	limiter *middleware.RateLimiter,
+	apiKeys middleware.ApiKeyAuthenticator,
+	breakGlass middleware.BreakGlassAuthenticator,
 	tags *service.TagsService,
 	features *service.FeaturesService,
 	teams *service.TeamsService,
 	products *service.ProductsService,
 	envs *service.EnvsService,
+	organizations *service.OrganizationsService,
 	clusters *service.ClustersService,
 	datacenters *service.DatacentersService,
 	hostgroups *service.HostgroupsService,
 	applications *service.ApplicationsService,
+	attributes *service.AttributesService,
+	tagKeys *service.TagKeysService,
 	adminService *service.AdminService,
+	serviceAccounts *service.ServiceAccountsService,
+	breakGlassService *service.BreakGlassService,
+	authzService *service.AuthzService,
 	logger log.Logger) *grpc.Server {
 
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			limiter.Client(),
 			middleware.JWTMiddleware(
 				middleware.JWTMiddlewareOption{
-					Secret:          adminConf.GetJwtSecret(),
-					EmergencyHeader: adminConf.GetEmergencyHeader(),
-					DefaultSecret:   DefaultSecret,
+					TokenRepo:  tokenRepo,
+					ApiKeys:    apiKeys,
+					BreakGlass: breakGlass,
+					Logger:     logger,
 				},
 			),
+			limiter.Principal(),
+			middleware.Org(),
+			middleware.Errors(),
 		),
 	}
 	if c.Grpc.Network != "" {
@@ -53,10 +68,16 @@ func NewGRPCServer(c *conf.Server,
 	apiv1.RegisterTeamsServer(srv, teams)
 	apiv1.RegisterProductsServer(srv, products)
 	apiv1.RegisterEnvsServer(srv, envs)
+	apiv1.RegisterOrganizationsServer(srv, organizations)
 	apiv1.RegisterClustersServer(srv, clusters)
 	apiv1.RegisterDatacentersServer(srv, datacenters)
 	apiv1.RegisterHostgroupsServer(srv, hostgroups)
 	apiv1.RegisterApplicationsServer(srv, applications)
+	apiv1.RegisterAttributesServer(srv, attributes)
+	apiv1.RegisterTagKeysServer(srv, tagKeys)
 	apiv1.RegisterAdminServer(srv, adminService)
+	apiv1.RegisterServiceAccountsServer(srv, serviceAccounts)
+	apiv1.RegisterBreakGlassServer(srv, breakGlassService)
+	apiv1.RegisterAuthzServer(srv, authzService)
 	return srv
 }
@@ -3,6 +3,7 @@ package server
 import (
 	appv1 "opspillar/api/opspillar/v1"
 	"opspillar/internal/conf"
+	"opspillar/internal/data/repo"
 	"opspillar/internal/middleware"
 	"opspillar/internal/service"
 
@@ -13,30 +14,44 @@ import (
 
 // NewHTTPServer new an HTTP server.
 func NewHTTPServer(c *conf.Server,
-	adminConf *conf.Admin,
+	tokenRepo repo.TokenRepo,
+	limiter *middleware.RateLimiter,
+	apiKeys middleware.ApiKeyAuthenticator,
+	breakGlass middleware.BreakGlassAuthenticator,
 	tags *service.TagsService,
 	features *service.FeaturesService,
 	teams *service.TeamsService,
 	products *service.ProductsService,
 	envs *service.EnvsService,
+	organizations *service.OrganizationsService,
 	clusters *service.ClustersService,
 	datacenters *service.DatacentersService,
 	hostgroups *service.HostgroupsService,
 	applications *service.ApplicationsService,
+	attributes *service.AttributesService,
+	tagKeys *service.TagKeysService,
 	adminService *service.AdminService,
+	serviceAccounts *service.ServiceAccountsService,
+	breakGlassService *service.BreakGlassService,
+	authzService *service.AuthzService,
 	logger log.Logger) *http.Server {
 
 	var opts = []http.ServerOption{
+		// options of middleware replace each other, the chain must be in one
 		http.Middleware(
 			recovery.Recovery(),
-		),
-		http.Middleware(middleware.JWTMiddleware(
-			middleware.JWTMiddlewareOption{
-				Secret:          adminConf.GetJwtSecret(),
-				EmergencyHeader: adminConf.GetEmergencyHeader(),
-				DefaultSecret:   DefaultSecret,
-			},
-		),
+			limiter.Client(),
+			middleware.JWTMiddleware(
+				middleware.JWTMiddlewareOption{
+					TokenRepo:  tokenRepo,
+					ApiKeys:    apiKeys,
+					BreakGlass: breakGlass,
+					Logger:     logger,
+				},
+			),
+			limiter.Principal(),
+			middleware.Org(),
+			middleware.Errors(),
 		),
 	}
 	if c.Http.Network != "" {
@@ -49,15 +64,22 @@ func NewHTTPServer(c *conf.Server,
 		opts = append(opts, http.Timeout(c.Http.Timeout.AsDuration()))
 	}
 	srv := http.NewServer(opts...)
+	srv.HandleFunc(JWKSPath, NewJWKSHandler(tokenRepo, logger))
 	appv1.RegisterTagsHTTPServer(srv, tags)
 	appv1.RegisterFeaturesHTTPServer(srv, features)
 	appv1.RegisterTeamsHTTPServer(srv, teams)
 	appv1.RegisterProductsHTTPServer(srv, products)
 	appv1.RegisterEnvsHTTPServer(srv, envs)
+	appv1.RegisterOrganizationsHTTPServer(srv, organizations)
 	appv1.RegisterClustersHTTPServer(srv, clusters)
 	appv1.RegisterDatacentersHTTPServer(srv, datacenters)
 	appv1.RegisterHostgroupsHTTPServer(srv, hostgroups)
 	appv1.RegisterApplicationsHTTPServer(srv, applications)
+	appv1.RegisterAttributesHTTPServer(srv, attributes)
+	appv1.RegisterTagKeysHTTPServer(srv, tagKeys)
 	appv1.RegisterAdminHTTPServer(srv, adminService)
+	appv1.RegisterServiceAccountsHTTPServer(srv, serviceAccounts)
+	appv1.RegisterBreakGlassHTTPServer(srv, breakGlassService)
+	appv1.RegisterAuthzHTTPServer(srv, authzService)
 	return srv
 }
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"opspillar/internal/data/repo"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// JWKSPath publishes public keys verifying tokens, so other services need not share jwt_secret.
+const JWKSPath = "/.well-known/jwks.json"
+
+// jwksMaxAge is how long clients cache keys, a rotated key is used after keys are reloaded.
+const jwksMaxAge = "max-age=60"
+
+type jwks struct {
+	Keys []*repo.JSONWebKey `json:"keys"`
+}
+
+// NewJWKSHandler serves JWKS without authentication, it is empty if tokens are signed with HS256.
+func NewJWKSHandler(tokenRepo repo.TokenRepo, logger log.Logger) nethttp.HandlerFunc {
+	helper := log.NewHelper(logger)
+	return func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodGet && r.Method != nethttp.MethodHead {
+			w.WriteHeader(nethttp.StatusMethodNotAllowed)
+			return
+		}
+		keys, err := tokenRepo.PublicKeys(r.Context())
+		if err != nil {
+			helper.Errorf("list public keys failed: %v", err)
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", jwksMaxAge)
+		if err := json.NewEncoder(w).Encode(jwks{Keys: keys}); err != nil {
+			helper.Errorf("write jwks failed: %v", err)
+		}
+	}
+}
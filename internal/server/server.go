@@ -1,12 +1,19 @@
 package server
 
 import (
+	"opspillar/internal/biz"
+	"opspillar/internal/conf"
+	"opspillar/internal/middleware"
+
 	"github.com/google/wire"
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer)
+var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer, NewDirectorySyncServer, NewRateLimiter,
+	wire.Bind(new(middleware.ApiKeyAuthenticator), new(*biz.ServiceAccountsUsecase)),
+	wire.Bind(new(middleware.BreakGlassAuthenticator), new(*biz.BreakGlassUsecase)))
 
-const (
-	DefaultSecret = "secret"
-)
+// NewRateLimiter shares the buckets between the gRPC and HTTP servers.
+func NewRateLimiter(c *conf.Server) *middleware.RateLimiter {
+	return middleware.NewRateLimiter(c.GetRateLimit())
+}
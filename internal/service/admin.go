@@ -2,13 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
 
 	//  TODO: modify project name
 	biz "opspillar/internal/biz"
+	"opspillar/internal/middleware"
 )
 
 type AdminService struct {
@@ -49,12 +52,49 @@ func toBizUser(user *pb.User) (*biz.User, error) {
 
 func toPbUser(user *biz.User) *pb.User {
 	return &pb.User{
-		Id:       user.Id,
-		UserName: user.UserName,
-		Password: user.Password,
-		Email:    user.Email,
-		Phone:    user.Phone,
-		Token:    user.Token,
+		Id:           user.Id,
+		UserName:     user.UserName,
+		Password:     user.Password,
+		Email:        user.Email,
+		Phone:        user.Phone,
+		Token:        user.Token,
+		RefreshToken: user.RefreshToken,
+		Source:       user.Source,
+		Disabled:     user.Disabled,
+
+		PasswordChangedAt: user.PasswordChangedAt,
+		LockedUntil:       user.LockedUntil,
+		MfaEnabled:        user.MFAEnabled,
+	}
+}
+
+// storedUser reads the user with id back as stored.
+func (s *AdminService) storedUser(ctx context.Context, id uint32) (*pb.User, error) {
+	current, err := s.usecase.GetUsers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbUser(current), nil
+}
+
+// committedUser reads the user with id back as stored, without its password and tokens.
+func (s *AdminService) committedUser(ctx context.Context, id uint32) (*pb.User, error) {
+	user, err := s.storedUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user.Password, user.Token, user.RefreshToken = "", "", ""
+	return user, nil
+}
+
+func toPbMFAEnrollment(enrollment *biz.MFAEnrollment) *pb.MFAEnrollment {
+	if enrollment == nil {
+		return nil
+	}
+	return &pb.MFAEnrollment{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+		RecoveryCodes:   enrollment.RecoveryCodes,
 	}
 }
 
@@ -74,6 +114,8 @@ func ToBizUsersFilter(filter *pb.ListUsersRequest) *biz.ListUsersFilter {
 		Emails:    filter.Emails,
 		Phones:    filter.Phones,
 		Ids:       filter.Ids,
+		PageToken: filter.PageToken,
+		WithTotal: filter.WithTotal,
 	}
 }
 
@@ -83,13 +125,22 @@ func (s *AdminService) CreateUsers(ctx context.Context, req *pb.CreateUsersReque
 		return nil, err
 	}
 
+	users, errs, err := commitItems(req.Partial, bizUsers, func(batch []*biz.User) error {
+		return s.usecase.CreateUsers(ctx, batch)
+	}, func(v *biz.User) (*pb.User, error) {
+		return s.committedUser(ctx, v.Id)
+	})
 	reply := &pb.CreateUsersReply{
 		Action:  "CreateUsers",
 		Code:    0,
 		Message: "success",
+		Users:   users,
+		Errors:  errs,
 	}
-	err = s.usecase.CreateUsers(ctx, bizUsers)
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -98,18 +149,33 @@ func (s *AdminService) CreateUsers(ctx context.Context, req *pb.CreateUsersReque
 	return reply, nil
 }
 func (s *AdminService) UpdateUsers(ctx context.Context, req *pb.UpdateUsersRequest) (*pb.UpdateUsersReply, error) {
+	err := maskUpdates(req.UpdateMask, req.Users, func(user *pb.User) (*pb.User, error) {
+		return s.storedUser(ctx, user.Id)
+	})
+	if err != nil {
+		return nil, err
+	}
 	bizUsers, err := toBizUsers(req.Users)
 	if err != nil {
 		return nil, err
 	}
 
+	users, errs, err := commitItems(req.Partial, bizUsers, func(batch []*biz.User) error {
+		return s.usecase.UpdateUsers(ctx, batch)
+	}, func(v *biz.User) (*pb.User, error) {
+		return s.committedUser(ctx, v.Id)
+	})
 	reply := &pb.UpdateUsersReply{
 		Action:  "UpdateUsers",
 		Code:    0,
 		Message: "success",
+		Users:   users,
+		Errors:  errs,
 	}
-	err = s.usecase.UpdateUsers(ctx, bizUsers)
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -125,9 +191,7 @@ func (s *AdminService) DeleteUsers(ctx context.Context, req *pb.DeleteUsersReque
 	}
 	err := s.usecase.DeleteUsers(ctx, nil, req.Ids)
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 
 	return reply, nil
@@ -141,9 +205,7 @@ func (s *AdminService) GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*
 	}
 	user, err := s.usecase.GetUsers(ctx, uint32(req.Id))
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	reply.User = toPbUser(user)
 
@@ -156,12 +218,11 @@ func (s *AdminService) ListUsers(ctx context.Context, req *pb.ListUsersRequest)
 		Code:    0,
 		Message: "success",
 	}
-	users, err := s.usecase.ListUsers(ctx, nil, ToBizUsersFilter(req))
+	users, page, err := s.usecase.ListUsers(ctx, nil, ToBizUsersFilter(req))
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	reply.Users = toPbUsers(users)
 
 	return reply, nil
@@ -173,8 +234,14 @@ func (s *AdminService) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginRe
 		Code:    0,
 		Message: "success",
 	}
-	user, err := s.usecase.Login(ctx, req.UserName, req.Password)
+	user, err := s.usecase.Login(ctx, req.UserName, req.Password, req.MfaCode, clientInfo(ctx, req.Device))
 	if err != nil {
+		var mfaErr *biz.MFARequiredError
+		// a second factor is asked in the reply, the client prompts for it and retries
+		if !errors.As(err, &mfaErr) {
+			return nil, err
+		}
+		reply.MfaRequired = true
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -183,6 +250,34 @@ func (s *AdminService) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginRe
 
 	return reply, nil
 }
+func (s *AdminService) SSOAuthURL(ctx context.Context, req *pb.SSOAuthURLReq) (*pb.SSOAuthURLReply, error) {
+	reply := &pb.SSOAuthURLReply{
+		Action:  "SSOAuthURL",
+		Code:    0,
+		Message: "success",
+	}
+	url, err := s.usecase.SSOAuthURL(ctx, req.RedirectUri, req.State, req.Nonce, req.CodeChallenge)
+	if err != nil {
+		return nil, err
+	}
+	reply.Url = url
+	return reply, nil
+}
+
+func (s *AdminService) SSOLogin(ctx context.Context, req *pb.SSOLoginReq) (*pb.LoginReply, error) {
+	reply := &pb.LoginReply{
+		Action:  "SSOLogin",
+		Code:    0,
+		Message: "success",
+	}
+	user, err := s.usecase.SSOLogin(ctx, req.Code, req.CodeVerifier, req.RedirectUri, req.Nonce, clientInfo(ctx, req.Device))
+	if err != nil {
+		return nil, err
+	}
+	reply.User = toPbUser(user)
+	return reply, nil
+}
+
 func (s *AdminService) Logout(ctx context.Context, req *pb.LogoutReq) (*pb.LogoutReply, error) {
 	reply := &pb.LogoutReply{
 		Action:  "Logout",
@@ -192,9 +287,167 @@ func (s *AdminService) Logout(ctx context.Context, req *pb.LogoutReq) (*pb.Logou
 
 	err := s.usecase.Logout(ctx, req.Id)
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *AdminService) RevokeSessions(ctx context.Context, req *pb.RevokeSessionsReq) (*pb.RevokeSessionsReply, error) {
+	reply := &pb.RevokeSessionsReply{
+		Action:  "RevokeSessions",
+		Code:    0,
+		Message: "success",
+	}
+
+	err := s.usecase.RevokeSessions(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *AdminService) ChangePassword(ctx context.Context, req *pb.ChangePasswordReq) (*pb.ChangePasswordReply, error) {
+	reply := &pb.ChangePasswordReply{
+		Action:  "ChangePassword",
+		Code:    0,
+		Message: "success",
+	}
+
+	err := s.usecase.ChangePassword(ctx, req.UserName, req.OldPassword, req.NewPassword, req.MfaCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *AdminService) CreatePasswordResetToken(ctx context.Context, req *pb.CreatePasswordResetTokenReq) (*pb.CreatePasswordResetTokenReply, error) {
+	reply := &pb.CreatePasswordResetTokenReply{
+		Action:  "CreatePasswordResetToken",
+		Code:    0,
+		Message: "success",
+	}
+
+	token, err := s.usecase.CreatePasswordResetToken(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	reply.Token = token.Token
+	reply.UserName = token.UserName
+	reply.ExpiresAt = token.ExpiresAt
+
+	return reply, nil
+}
+
+func (s *AdminService) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq) (*pb.ResetPasswordReply, error) {
+	reply := &pb.ResetPasswordReply{
+		Action:  "ResetPassword",
+		Code:    0,
+		Message: "success",
+	}
+
+	err := s.usecase.ResetPassword(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *AdminService) RefreshToken(ctx context.Context, req *pb.RefreshTokenReq) (*pb.RefreshTokenReply, error) {
+	reply := &pb.RefreshTokenReply{
+		Action:  "RefreshToken",
+		Code:    0,
+		Message: "success",
+	}
+	user, err := s.usecase.RefreshToken(ctx, req.RefreshToken, clientInfo(ctx, req.Device))
+	if err != nil {
+		return nil, err
+	}
+	reply.User = toPbUser(user)
+
+	return reply, nil
+}
+
+func (s *AdminService) ListSessions(ctx context.Context, req *pb.ListSessionsReq) (*pb.ListSessionsReply, error) {
+	reply := &pb.ListSessionsReply{
+		Action:  "ListSessions",
+		Code:    0,
+		Message: "success",
+	}
+	sessions, err := s.usecase.ListSessions(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		reply.Sessions = append(reply.Sessions, &pb.Session{
+			Id:         session.Id,
+			UserId:     session.UserId,
+			Device:     session.Device,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.Current,
+		})
+	}
+
+	return reply, nil
+}
+
+// clientInfo gets peer address and device of request. device defaults to User-Agent.
+func clientInfo(ctx context.Context, device string) *biz.ClientInfo {
+	client := &biz.ClientInfo{Device: device}
+	if tr, ok := transport.FromServerContext(ctx); ok && client.Device == "" {
+		client.Device = tr.RequestHeader().Get("User-Agent")
+	}
+	client.IP = middleware.ClientIP(ctx)
+	return client
+}
+
+func (s *AdminService) EnrollMFA(ctx context.Context, req *pb.EnrollMFAReq) (*pb.EnrollMFAReply, error) {
+	reply := &pb.EnrollMFAReply{
+		Action:  "EnrollMFA",
+		Code:    0,
+		Message: "success",
+	}
+
+	enrollment, err := s.usecase.EnrollMFA(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	reply.Enrollment = toPbMFAEnrollment(enrollment)
+
+	return reply, nil
+}
+
+func (s *AdminService) ConfirmMFA(ctx context.Context, req *pb.ConfirmMFAReq) (*pb.ConfirmMFAReply, error) {
+	reply := &pb.ConfirmMFAReply{
+		Action:  "ConfirmMFA",
+		Code:    0,
+		Message: "success",
+	}
+
+	err := s.usecase.ConfirmMFA(ctx, req.MfaCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *AdminService) DisableMFA(ctx context.Context, req *pb.DisableMFAReq) (*pb.DisableMFAReply, error) {
+	reply := &pb.DisableMFAReply{
+		Action:  "DisableMFA",
+		Code:    0,
+		Message: "success",
+	}
+
+	err := s.usecase.DisableMFA(ctx, req.Id, req.MfaCode)
+	if err != nil {
+		return nil, err
 	}
 
 	return reply, nil
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -39,6 +39,7 @@ func toBizApp(a *pb.Application) (*biz.Application, error) {
 		FeaturesId:   a.FeaturesId,
 		TagsId:       a.TagsId,
 		HostgroupsId: a.HostgroupsId,
+		Attributes:   a.Attributes,
 	}, nil
 }
 
@@ -73,9 +74,20 @@ func toPbApp(a *biz.Application) (*pb.Application, error) {
 		CreatedBy:    a.CreatedBy,
 		UpdatedAt:    a.UpdatedAt,
 		UpdatedBy:    a.UpdatedBy,
+		OrgId:        a.OrgId,
+		Attributes:   a.Attributes,
 	}, nil
 }
 
+// storedApp reads the application with id back as stored.
+func (s *ApplicationsService) storedApp(ctx context.Context, id uint32) (*pb.Application, error) {
+	current, err := s.usecase.GetApplications(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbApp(current)
+}
+
 func toPbApps(apps []*biz.Application) ([]*pb.Application, error) {
 	pbApps := make([]*pb.Application, len(apps))
 	for i, a := range apps {
@@ -90,18 +102,29 @@ func toPbApps(apps []*biz.Application) ([]*pb.Application, error) {
 
 func (s *ApplicationsService) CreateApplications(ctx context.Context, req *pb.CreateApplicationsRequest) (*pb.CreateApplicationsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
+	var items []*pb.Application
+	var errs map[uint32]string
 	_bizApps, err := toBizApps(req.Apps)
 	if err == nil {
-		err = s.usecase.CreateApplications(ctx, _bizApps)
+		items, errs, err = commitItems(req.Partial, _bizApps, func(batch []*biz.Application) error {
+			return s.usecase.CreateApplications(ctx, batch)
+		}, func(v *biz.Application) (*pb.Application, error) {
+			return s.storedApp(ctx, v.Id)
+		})
 	}
 	reply := &pb.CreateApplicationsReply{
 		Action:  "createApplications",
 		Code:    0,
 		Message: "success",
+		Apps:    items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -111,18 +134,27 @@ func (s *ApplicationsService) CreateApplications(ctx context.Context, req *pb.Cr
 
 func (s *ApplicationsService) UpdateApplications(ctx context.Context, req *pb.UpdateApplicationsRequest) (*pb.UpdateApplicationsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
-	_bizApps, err := toBizApps(req.Apps)
-	if err == nil {
-		err = s.usecase.UpdateApplications(ctx, _bizApps)
+	var items []*pb.Application
+	var errs map[uint32]string
+	var err error
+	if len(req.Ids) > 0 || req.Add != nil || req.Remove != nil {
+		items, errs, err = s.updateApplicationsRelations(ctx, req)
+	} else {
+		items, errs, err = s.updateApplications(ctx, req)
 	}
 	reply := &pb.UpdateApplicationsReply{
 		Action:  "updateApplications",
 		Code:    0,
 		Message: "success",
+		Apps:    items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -130,9 +162,52 @@ func (s *ApplicationsService) UpdateApplications(ctx context.Context, req *pb.Up
 	return reply, nil
 }
 
+func (s *ApplicationsService) updateApplications(ctx context.Context,
+	req *pb.UpdateApplicationsRequest) ([]*pb.Application, map[uint32]string, error) {
+	err := maskUpdates(req.UpdateMask, req.Apps, func(app *pb.Application) (*pb.Application, error) {
+		return s.storedApp(ctx, app.Id)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	_bizApps, err := toBizApps(req.Apps)
+	if err != nil {
+		return nil, nil, err
+	}
+	return commitItems(req.Partial, _bizApps, func(batch []*biz.Application) error {
+		return s.usecase.UpdateApplications(ctx, batch)
+	}, func(v *biz.Application) (*pb.Application, error) {
+		return s.storedApp(ctx, v.Id)
+	})
+}
+
+func (s *ApplicationsService) updateApplicationsRelations(ctx context.Context,
+	req *pb.UpdateApplicationsRequest) ([]*pb.Application, map[uint32]string, error) {
+	if len(req.Apps) > 0 || req.UpdateMask != nil {
+		return nil, nil, ErrUpdateRelationsExclusive
+	}
+	add, remove := toBizAppRelations(req.Add), toBizAppRelations(req.Remove)
+	return commitItems(req.Partial, req.Ids, func(batch []uint32) error {
+		return s.usecase.UpdateApplicationsRelations(ctx, batch, add, remove)
+	}, func(id uint32) (*pb.Application, error) {
+		return s.storedApp(ctx, id)
+	})
+}
+
+func toBizAppRelations(r *pb.AppRelations) *biz.AppRelations {
+	if r == nil {
+		return nil
+	}
+	return &biz.AppRelations{
+		TagsId:       r.TagsId,
+		FeaturesId:   r.FeaturesId,
+		HostgroupsId: r.HostgroupsId,
+	}
+}
+
 func (s *ApplicationsService) DeleteApplications(ctx context.Context, req *pb.DeleteApplicationsRequest) (*pb.DeleteApplicationsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	err := s.usecase.DeleteApplications(ctx, req.Ids)
 	reply := &pb.DeleteApplicationsReply{
@@ -141,15 +216,13 @@ func (s *ApplicationsService) DeleteApplications(ctx context.Context, req *pb.De
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	return reply, nil
 }
 func (s *ApplicationsService) GetApplications(ctx context.Context, req *pb.GetApplicationsRequest) (*pb.GetApplicationsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	bizApp, err := s.usecase.GetApplications(ctx, req.Id)
 	reply := &pb.GetApplicationsReply{
@@ -162,9 +235,7 @@ func (s *ApplicationsService) GetApplications(ctx context.Context, req *pb.GetAp
 		reply.App = papp
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *ApplicationsService) ListApplications(ctx context.Context, req *pb.ListApplicationsRequest) (*pb.ListApplicationsReply, error) {
@@ -182,6 +253,8 @@ func (s *ApplicationsService) ListApplications(ctx context.Context, req *pb.List
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 		if req.IsStateful != biz.IsStatefulNone {
 			filter.IsStateful = req.IsStateful
 		}
@@ -200,26 +273,43 @@ func (s *ApplicationsService) ListApplications(ctx context.Context, req *pb.List
 		if len(req.HostgroupsId) > 0 {
 			filter.HostgroupsId = req.HostgroupsId
 		}
+		if len(req.OrgIds) > 0 {
+			filter.OrgIds = req.OrgIds
+		}
+		if len(req.Attributes) > 0 {
+			filter.Attributes = req.Attributes
+		}
+		filter.NameMatch = req.NameMatch
+		filter.SortBy = req.SortBy
+		filter.SortDesc = req.SortDesc
+		filter.CreatedAfter = req.CreatedAfter
+		filter.CreatedBefore = req.CreatedBefore
+		filter.UpdatedAfter = req.UpdatedAfter
+		filter.UpdatedBefore = req.UpdatedBefore
+		filter.ExcludeTeamsId = req.ExcludeTeamsId
+		filter.ExcludeProductsId = req.ExcludeProductsId
+		filter.NoTags = req.NoTags
+		filter.NoFeatures = req.NoFeatures
+		filter.Selector = req.Selector
 	}
 
-	apps, err := s.usecase.ListApplications(ctx, filter)
+	apps, page, err := s.usecase.ListApplications(ctx, filter)
 	reply := &pb.ListApplicationsReply{
 		Action:  "listApplications",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	if err == nil {
 		reply.Apps, _ = toPbApps(apps)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *ApplicationsService) MatchAppHostgroups(ctx context.Context, req *pb.MatchAppHostgroupsRequest) (*pb.MatchAppHostgroupsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	ids, err := s.usecase.MatchHostgroups(ctx, nil, &biz.MatchAppHostgroupsFilter{
 		FeaturesId: req.FeaturesId,
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	biz "opspillar/internal/biz"
+)
+
+type AttributesService struct {
+	pb.UnimplementedAttributesServer
+	usecase *biz.AttributesUsecase
+	log     *log.Helper
+}
+
+func NewAttributesService(uc *biz.AttributesUsecase, logger log.Logger) *AttributesService {
+	return &AttributesService{
+		usecase: uc,
+		log:     log.NewHelper(logger),
+	}
+}
+
+func toBizAttribute(attr *pb.Attribute) (*biz.Attribute, error) {
+	if attr == nil {
+		return nil, nil
+	}
+	return &biz.Attribute{
+		Id:          attr.Id,
+		Kind:        attr.Kind,
+		Name:        attr.Name,
+		Type:        attr.Type,
+		Required:    attr.Required,
+		EnumValues:  attr.EnumValues,
+		Pattern:     attr.Pattern,
+		Description: attr.Description,
+	}, nil
+}
+
+func toBizAttributes(attrs []*pb.Attribute) ([]*biz.Attribute, error) {
+	_bizattrs := make([]*biz.Attribute, len(attrs))
+	var err error
+	for i, e := range attrs {
+		if _bizattrs[i], err = toBizAttribute(e); err != nil {
+			return nil, err
+		}
+	}
+	return _bizattrs, nil
+}
+
+func (s *AttributesService) CreateAttributes(ctx context.Context, req *pb.CreateAttributesRequest) (*pb.CreateAttributesReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+
+	var items []*pb.Attribute
+	var errs map[uint32]string
+	_bizattrs, err := toBizAttributes(req.Attributes)
+	if err == nil {
+		items, errs, err = commitItems(req.Partial, _bizattrs, func(batch []*biz.Attribute) error {
+			return s.usecase.CreateAttributes(ctx, batch)
+		}, func(v *biz.Attribute) (*pb.Attribute, error) {
+			return s.storedAttribute(ctx, v.Id)
+		})
+	}
+
+	reply := &pb.CreateAttributesReply{
+		Action:     "CreateAttributes",
+		Code:       0,
+		Message:    "success",
+		Attributes: items,
+		Errors:     errs,
+	}
+
+	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
+		reply.Code = 1
+		reply.Message = err.Error()
+		return reply, nil
+	}
+
+	return reply, nil
+}
+
+func (s *AttributesService) UpdateAttributes(ctx context.Context, req *pb.UpdateAttributesRequest) (*pb.UpdateAttributesReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	var items []*pb.Attribute
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Attributes, func(attr *pb.Attribute) (*pb.Attribute, error) {
+		return s.storedAttribute(ctx, attr.Id)
+	})
+	if err == nil {
+		var _bizattrs []*biz.Attribute
+		_bizattrs, err = toBizAttributes(req.Attributes)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, _bizattrs, func(batch []*biz.Attribute) error {
+				return s.usecase.UpdateAttributes(ctx, batch)
+			}, func(v *biz.Attribute) (*pb.Attribute, error) {
+				return s.storedAttribute(ctx, v.Id)
+			})
+		}
+	}
+	reply := &pb.UpdateAttributesReply{
+		Action:     "UpdateAttributes",
+		Code:       0,
+		Message:    "success",
+		Attributes: items,
+		Errors:     errs,
+	}
+	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
+		reply.Code = 1
+		reply.Message = err.Error()
+		return reply, nil
+	}
+	return reply, nil
+}
+
+func (s *AttributesService) DeleteAttributes(ctx context.Context, req *pb.DeleteAttributesRequest) (*pb.DeleteAttributesReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	err := s.usecase.DeleteAttributes(ctx, req.Ids)
+	reply := &pb.DeleteAttributesReply{
+		Action:  "DeleteAttributes",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *AttributesService) GetAttributes(ctx context.Context, req *pb.GetAttributesRequest) (*pb.GetAttributesReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	attr, err := s.usecase.GetAttributes(ctx, req.Id)
+	reply := &pb.GetAttributesReply{
+		Action:  "GetAttributes",
+		Code:    0,
+		Message: "success",
+	}
+	if err == nil {
+		reply.Attribute = toPbAttribute(attr)
+
+		return reply, nil
+	}
+	return nil, err
+}
+
+func (s *AttributesService) ListAttributes(ctx context.Context, req *pb.ListAttributesRequest) (*pb.ListAttributesReply, error) {
+
+	filter := biz.DefaultAttributesFilter()
+	if req != nil {
+		if len(req.Ids) > 0 {
+			filter.Ids = req.Ids
+		}
+		if len(req.Kinds) > 0 {
+			filter.Kinds = req.Kinds
+		}
+		if len(req.Names) > 0 {
+			filter.Names = req.Names
+		}
+		if req.PageSize > 0 {
+			filter.PageSize = req.PageSize
+		}
+		if req.Page > 0 {
+			filter.Page = req.Page
+		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+	}
+
+	attrs, page, err := s.usecase.ListAttributes(ctx, filter)
+	reply := &pb.ListAttributesReply{
+		Action:  "ListAttributes",
+		Code:    0,
+		Message: "success",
+	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
+	if err == nil {
+		reply.Attributes = toPbAttributes(attrs)
+		return reply, nil
+	}
+	return nil, err
+}
+
+func toPbAttribute(attr *biz.Attribute) *pb.Attribute {
+	if attr == nil {
+		return nil
+	}
+	return &pb.Attribute{
+		Id:          attr.Id,
+		Kind:        attr.Kind,
+		Name:        attr.Name,
+		Type:        attr.Type,
+		Required:    attr.Required,
+		EnumValues:  attr.EnumValues,
+		Pattern:     attr.Pattern,
+		Description: attr.Description,
+	}
+}
+
+// storedAttribute reads the attribute with id back as stored.
+func (s *AttributesService) storedAttribute(ctx context.Context, id uint32) (*pb.Attribute, error) {
+	current, err := s.usecase.GetAttributes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbAttribute(current), nil
+}
+
+func toPbAttributes(attrs []*biz.Attribute) []*pb.Attribute {
+	if attrs == nil {
+		return nil
+	}
+	var res []*pb.Attribute
+	for _, e := range attrs {
+		if e != nil {
+			res = append(res, toPbAttribute(e))
+		}
+	}
+	return res
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	biz "opspillar/internal/biz"
+)
+
+type AuthzService struct {
+	pb.UnimplementedAuthzServer
+	usecase *biz.AuthzUsecase
+	log     *log.Helper
+}
+
+func NewAuthzService(uc *biz.AuthzUsecase, logger log.Logger) *AuthzService {
+	return &AuthzService{
+		usecase: uc,
+		log:     log.NewHelper(logger),
+	}
+}
+
+func toBizRules(rules []*pb.AuthzRule) []*biz.Rule {
+	res := make([]*biz.Rule, 0, len(rules))
+	for _, r := range rules {
+		if r == nil {
+			res = append(res, nil)
+			continue
+		}
+		res = append(res, &biz.Rule{
+			Sub:     r.Sub,
+			Org:     r.Org,
+			ResType: r.ResType,
+			Team:    r.Team,
+			ResInst: r.ResInst,
+			User:    r.User,
+			Action:  r.Action,
+		})
+	}
+	return res
+}
+
+func toPbRule(r *biz.Rule) *pb.AuthzRule {
+	if r == nil {
+		return nil
+	}
+	return &pb.AuthzRule{
+		Sub:      r.Sub,
+		ResType:  r.ResType,
+		Team:     r.Team,
+		ResInst:  r.ResInst,
+		User:     r.User,
+		Action:   r.Action,
+		Resource: r.Resource,
+		Org:      r.Org,
+	}
+}
+
+func toBizGroups(groups []*pb.AuthzGroup) []*biz.Group {
+	res := make([]*biz.Group, 0, len(groups))
+	for _, g := range groups {
+		if g == nil {
+			res = append(res, nil)
+			continue
+		}
+		res = append(res, &biz.Group{User: g.User, Role: g.Role})
+	}
+	return res
+}
+
+func (s *AuthzService) ListRules(ctx context.Context, req *pb.ListRulesRequest) (*pb.ListRulesReply, error) {
+	filter := &biz.ListRulesFilter{}
+	if req != nil {
+		filter.Sub = req.Sub
+	}
+	rules, err := s.usecase.ListRules(ctx, filter)
+	reply := &pb.ListRulesReply{
+		Action:  "ListRules",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		reply.Rules = append(reply.Rules, toPbRule(r))
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) GrantRules(ctx context.Context, req *pb.GrantRulesRequest) (*pb.GrantRulesReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.GrantRules(ctx, toBizRules(req.Rules))
+	reply := &pb.GrantRulesReply{
+		Action:  "GrantRules",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) RevokeRules(ctx context.Context, req *pb.RevokeRulesRequest) (*pb.RevokeRulesReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.RevokeRules(ctx, toBizRules(req.Rules))
+	reply := &pb.RevokeRulesReply{
+		Action:  "RevokeRules",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) ListGroups(ctx context.Context, req *pb.ListGroupsRequest) (*pb.ListGroupsReply, error) {
+	filter := &biz.ListGroupsFilter{}
+	if req != nil {
+		filter.User = req.User
+		filter.Role = req.Role
+	}
+	groups, err := s.usecase.ListGroups(ctx, filter)
+	reply := &pb.ListGroupsReply{
+		Action:  "ListGroups",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		reply.Groups = append(reply.Groups, &pb.AuthzGroup{User: g.User, Role: g.Role})
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) AddGroups(ctx context.Context, req *pb.AddGroupsRequest) (*pb.AddGroupsReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.AddGroups(ctx, toBizGroups(req.Groups))
+	reply := &pb.AddGroupsReply{
+		Action:  "AddGroups",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) RemoveGroups(ctx context.Context, req *pb.RemoveGroupsRequest) (*pb.RemoveGroupsReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.RemoveGroups(ctx, toBizGroups(req.Groups))
+	reply := &pb.RemoveGroupsReply{
+		Action:  "RemoveGroups",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) ListAuthzAudits(ctx context.Context, req *pb.ListAuthzAuditsRequest) (*pb.ListAuthzAuditsReply, error) {
+	filter := biz.DefaultAuthzAuditsFilter()
+	if req != nil {
+		if req.Page > 0 {
+			filter.Page = req.Page
+		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+		if req.PageSize > 0 {
+			filter.PageSize = req.PageSize
+		}
+		filter.Subs = req.Subs
+		filter.Operators = req.Operators
+		filter.Since = req.Since
+	}
+	audits, page, err := s.usecase.ListAuthzAudits(ctx, filter)
+	reply := &pb.ListAuthzAuditsReply{
+		Action:  "ListAuthzAudits",
+		Code:    0,
+		Message: "success",
+	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range audits {
+		reply.Audits = append(reply.Audits, &pb.AuthzAudit{
+			Id:        a.Id,
+			Operator:  a.Operator,
+			Operation: a.Operation,
+			Sub:       a.Sub,
+			Resource:  a.Resource,
+			Action:    a.Action,
+			Role:      a.Role,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	return reply, nil
+}
+
+func (s *AuthzService) CanI(ctx context.Context, req *pb.CanIRequest) (*pb.CanIReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	answer, err := s.usecase.CanI(ctx, &biz.CanIRequest{
+		Action: req.Action,
+		Kind:   req.Kind,
+		Name:   req.Name,
+		As:     req.As,
+	})
+	reply := &pb.CanIReply{
+		Action:  "CanI",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	reply.Allowed = answer.Allowed
+	reply.Sub = answer.Sub
+	reply.Resource = answer.Resource
+	reply.Rule = toPbRule(answer.Rule)
+	reply.Chain = answer.Chain
+	reply.Roles = answer.Roles
+	return reply, nil
+}
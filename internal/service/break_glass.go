@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	biz "opspillar/internal/biz"
+)
+
+type BreakGlassService struct {
+	pb.UnimplementedBreakGlassServer
+	usecase *biz.BreakGlassUsecase
+	log     *log.Helper
+}
+
+func NewBreakGlassService(uc *biz.BreakGlassUsecase, logger log.Logger) *BreakGlassService {
+	return &BreakGlassService{
+		usecase: uc,
+		log:     log.NewHelper(logger),
+	}
+}
+
+func toPbBreakGlass(bg *biz.BreakGlass) *pb.BreakGlassCredential {
+	if bg == nil {
+		return nil
+	}
+	return &pb.BreakGlassCredential{
+		Id:            bg.Id,
+		Name:          bg.Name,
+		Principal:     bg.Principal,
+		Secret:        bg.Secret,
+		Prefix:        bg.Prefix,
+		OneTime:       bg.OneTime,
+		ExpireMinutes: bg.ExpireMinutes,
+		Description:   bg.Description,
+		ExpiresAt:     bg.ExpiresAt,
+		FirstUsedAt:   bg.FirstUsedAt,
+		CreatedBy:     bg.CreatedBy,
+		CreatedAt:     bg.CreatedAt,
+	}
+}
+
+func (s *BreakGlassService) CreateBreakGlass(ctx context.Context, req *pb.CreateBreakGlassRequest) (*pb.CreateBreakGlassReply, error) {
+	if req == nil || req.Credential == nil {
+		return nil, ErrRequestNil
+	}
+	bg, err := s.usecase.CreateBreakGlass(ctx, &biz.BreakGlass{
+		Name:          req.Credential.Name,
+		Principal:     req.Credential.Principal,
+		OneTime:       req.Credential.OneTime,
+		ExpireMinutes: req.Credential.ExpireMinutes,
+		ExpireDays:    req.Credential.ExpireDays,
+		Description:   req.Credential.Description,
+	})
+	reply := &pb.CreateBreakGlassReply{
+		Action:  "CreateBreakGlass",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	reply.Credential = toPbBreakGlass(bg)
+	return reply, nil
+}
+
+func (s *BreakGlassService) ListBreakGlass(ctx context.Context, req *pb.ListBreakGlassRequest) (*pb.ListBreakGlassReply, error) {
+	creds, err := s.usecase.ListBreakGlass(ctx)
+	reply := &pb.ListBreakGlassReply{
+		Action:  "ListBreakGlass",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, bg := range creds {
+		reply.Credentials = append(reply.Credentials, toPbBreakGlass(bg))
+	}
+	return reply, nil
+}
+
+func (s *BreakGlassService) DeleteBreakGlass(ctx context.Context, req *pb.DeleteBreakGlassRequest) (*pb.DeleteBreakGlassReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.DeleteBreakGlass(ctx, req.Ids)
+	reply := &pb.DeleteBreakGlassReply{
+		Action:  "DeleteBreakGlass",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *BreakGlassService) ListBreakGlassAudits(ctx context.Context, req *pb.ListBreakGlassAuditsRequest) (*pb.ListBreakGlassAuditsReply, error) {
+	filter := biz.DefaultBreakGlassAuditsFilter()
+	if req != nil {
+		if req.Page > 0 {
+			filter.Page = req.Page
+		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+		if req.PageSize > 0 {
+			filter.PageSize = req.PageSize
+		}
+		filter.CredentialIds = req.CredentialIds
+		filter.Since = req.Since
+	}
+	audits, page, err := s.usecase.ListBreakGlassAudits(ctx, filter)
+	reply := &pb.ListBreakGlassAuditsReply{
+		Action:  "ListBreakGlassAudits",
+		Code:    0,
+		Message: "success",
+	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range audits {
+		reply.Audits = append(reply.Audits, &pb.BreakGlassAudit{
+			Id:           a.Id,
+			CredentialId: a.CredentialId,
+			Name:         a.Name,
+			Principal:    a.Principal,
+			Operation:    a.Operation,
+			Ip:           a.IP,
+			Allowed:      a.Allowed,
+			Reason:       a.Reason,
+			CreatedAt:    a.CreatedAt,
+		})
+	}
+	return reply, nil
+}
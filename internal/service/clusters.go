@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -35,6 +35,23 @@ func toBizCluster(c *pb.Cluster) (*biz.Cluster, error) {
 	}, nil
 }
 
+func toPbCluster(c *biz.Cluster) *pb.Cluster {
+	return &pb.Cluster{
+		Id:          c.Id,
+		Name:        c.Name,
+		Description: c.Description,
+	}
+}
+
+// storedCluster reads the cluster with id back as stored.
+func (s *ClustersService) storedCluster(ctx context.Context, id uint32) (*pb.Cluster, error) {
+	current, err := s.usecase.GetClusters(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbCluster(current), nil
+}
+
 func toBizClusters(cs []*pb.Cluster) ([]*biz.Cluster, error) {
 	bizClusters := make([]*biz.Cluster, len(cs))
 	for i, c := range cs {
@@ -49,19 +66,30 @@ func toBizClusters(cs []*pb.Cluster) ([]*biz.Cluster, error) {
 
 func (s *ClustersService) CreateClusters(ctx context.Context, req *pb.CreateClustersRequest) (*pb.CreateClustersReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
+	var items []*pb.Cluster
+	var errs map[uint32]string
 	_bizclusters, err := toBizClusters(req.Clusters)
 	if err == nil {
-		err = s.usecase.CreateClusters(ctx, _bizclusters)
+		items, errs, err = commitItems(req.Partial, _bizclusters, func(batch []*biz.Cluster) error {
+			return s.usecase.CreateClusters(ctx, batch)
+		}, func(v *biz.Cluster) (*pb.Cluster, error) {
+			return s.storedCluster(ctx, v.Id)
+		})
 	}
 
 	reply := &pb.CreateClustersReply{
-		Action:  "CreateClusters",
-		Code:    0,
-		Message: "success",
+		Action:   "CreateClusters",
+		Code:     0,
+		Message:  "success",
+		Clusters: items,
+		Errors:   errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -72,18 +100,35 @@ func (s *ClustersService) CreateClusters(ctx context.Context, req *pb.CreateClus
 
 func (s *ClustersService) UpdateClusters(ctx context.Context, req *pb.UpdateClustersRequest) (*pb.UpdateClustersReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
-	_bizclusters, err := toBizClusters(req.Clusters)
+	var items []*pb.Cluster
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Clusters, func(c *pb.Cluster) (*pb.Cluster, error) {
+		return s.storedCluster(ctx, c.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateClusters(ctx, _bizclusters)
+		var _bizclusters []*biz.Cluster
+		_bizclusters, err = toBizClusters(req.Clusters)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, _bizclusters, func(batch []*biz.Cluster) error {
+				return s.usecase.UpdateClusters(ctx, batch)
+			}, func(v *biz.Cluster) (*pb.Cluster, error) {
+				return s.storedCluster(ctx, v.Id)
+			})
+		}
 	}
 	reply := &pb.UpdateClustersReply{
-		Action:  "UpdateClusters",
-		Code:    0,
-		Message: "success",
+		Action:   "UpdateClusters",
+		Code:     0,
+		Message:  "success",
+		Clusters: items,
+		Errors:   errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -93,7 +138,7 @@ func (s *ClustersService) UpdateClusters(ctx context.Context, req *pb.UpdateClus
 
 func (s *ClustersService) DeleteClusters(ctx context.Context, req *pb.DeleteClustersRequest) (*pb.DeleteClustersReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	err := s.usecase.DeleteClusters(ctx, req.Ids)
 	reply := &pb.DeleteClustersReply{
@@ -102,16 +147,14 @@ func (s *ClustersService) DeleteClusters(ctx context.Context, req *pb.DeleteClus
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	return reply, nil
 }
 
 func (s *ClustersService) GetClusters(ctx context.Context, req *pb.GetClustersRequest) (*pb.GetClustersReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	bizCluster, err := s.usecase.GetClusters(ctx, req.Id)
 	reply := &pb.GetClustersReply{
@@ -120,16 +163,10 @@ func (s *ClustersService) GetClusters(ctx context.Context, req *pb.GetClustersRe
 		Message: "success",
 	}
 	if err == nil {
-		reply.Cluster = &pb.Cluster{
-			Id:          bizCluster.Id,
-			Name:        bizCluster.Name,
-			Description: bizCluster.Description,
-		}
+		reply.Cluster = toPbCluster(bizCluster)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *ClustersService) ListClusters(ctx context.Context, req *pb.ListClustersRequest) (*pb.ListClustersReply, error) {
@@ -147,14 +184,17 @@ func (s *ClustersService) ListClusters(ctx context.Context, req *pb.ListClusters
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 	}
 
-	cs, err := s.usecase.ListClusters(ctx, filter)
+	cs, page, err := s.usecase.ListClusters(ctx, filter)
 	reply := &pb.ListClustersReply{
 		Action:  "listClusters",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 
 	if err == nil {
 		reply.Clusters = make([]*pb.Cluster, len(cs))
@@ -167,7 +207,5 @@ func (s *ClustersService) ListClusters(ctx context.Context, req *pb.ListClusters
 		}
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -49,18 +49,29 @@ func toBizDatacenters(cs []*pb.Datacenter) ([]*biz.Datacenter, error) {
 
 func (s *DatacentersService) CreateDatacenters(ctx context.Context, req *pb.CreateDatacentersRequest) (*pb.CreateDatacentersReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
+	var items []*pb.Datacenter
+	var errs map[uint32]string
 	bizDatacenters, err := toBizDatacenters(req.Datacenters)
 	if err == nil {
-		err = s.usecase.CreateDatacenters(ctx, bizDatacenters)
+		items, errs, err = commitItems(req.Partial, bizDatacenters, func(batch []*biz.Datacenter) error {
+			return s.usecase.CreateDatacenters(ctx, batch)
+		}, func(v *biz.Datacenter) (*pb.Datacenter, error) {
+			return s.storedDatacenter(ctx, v.Id)
+		})
 	}
 	reply := &pb.CreateDatacentersReply{
-		Action:  "CreateDatacenters",
-		Code:    0,
-		Message: "success",
+		Action:      "CreateDatacenters",
+		Code:        0,
+		Message:     "success",
+		Datacenters: items,
+		Errors:      errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -72,18 +83,35 @@ func (s *DatacentersService) UpdateDatacenters(ctx context.Context,
 	req *pb.UpdateDatacentersRequest) (*pb.UpdateDatacentersReply, error) {
 
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
-	bizDatacenters, err := toBizDatacenters(req.Datacenters)
+	var items []*pb.Datacenter
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Datacenters, func(dc *pb.Datacenter) (*pb.Datacenter, error) {
+		return s.storedDatacenter(ctx, dc.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateDatacenters(ctx, bizDatacenters)
+		var bizDatacenters []*biz.Datacenter
+		bizDatacenters, err = toBizDatacenters(req.Datacenters)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, bizDatacenters, func(batch []*biz.Datacenter) error {
+				return s.usecase.UpdateDatacenters(ctx, batch)
+			}, func(v *biz.Datacenter) (*pb.Datacenter, error) {
+				return s.storedDatacenter(ctx, v.Id)
+			})
+		}
 	}
 	reply := &pb.UpdateDatacentersReply{
-		Action:  "UpdateDatacenters",
-		Code:    0,
-		Message: "success",
+		Action:      "UpdateDatacenters",
+		Code:        0,
+		Message:     "success",
+		Datacenters: items,
+		Errors:      errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -95,7 +123,7 @@ func (s *DatacentersService) DeleteDatacenters(ctx context.Context,
 	req *pb.DeleteDatacentersRequest) (*pb.DeleteDatacentersReply, error) {
 
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	err := s.usecase.DeleteDatacenters(ctx, req.Ids)
 	reply := &pb.DeleteDatacentersReply{
@@ -104,9 +132,7 @@ func (s *DatacentersService) DeleteDatacenters(ctx context.Context,
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	return reply, nil
 }
@@ -115,7 +141,7 @@ func (s *DatacentersService) GetDatacenters(ctx context.Context,
 	req *pb.GetDatacentersRequest) (*pb.GetDatacentersReply, error) {
 
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	datacenter, err := s.usecase.GetDatacenters(ctx, req.Id)
 	reply := &pb.GetDatacentersReply{
@@ -127,9 +153,7 @@ func (s *DatacentersService) GetDatacenters(ctx context.Context,
 		reply.Datacenter = toPbDatacenter(datacenter)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *DatacentersService) ListDatacenters(ctx context.Context,
@@ -149,21 +173,22 @@ func (s *DatacentersService) ListDatacenters(ctx context.Context,
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 	}
 
-	datacenters, err := s.usecase.ListDatacenters(ctx, filter)
+	datacenters, page, err := s.usecase.ListDatacenters(ctx, filter)
 	reply := &pb.ListDatacentersReply{
 		Action:  "listDatacenters",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	if err == nil {
 		reply.Datacenters = toPbDatacenters(datacenters)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func toPbDatacenter(d *biz.Datacenter) *pb.Datacenter {
@@ -177,6 +202,15 @@ func toPbDatacenter(d *biz.Datacenter) *pb.Datacenter {
 	}
 }
 
+// storedDatacenter reads the datacenter with id back as stored.
+func (s *DatacentersService) storedDatacenter(ctx context.Context, id uint32) (*pb.Datacenter, error) {
+	current, err := s.usecase.GetDatacenters(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbDatacenter(current), nil
+}
+
 func toPbDatacenters(ds []*biz.Datacenter) []*pb.Datacenter {
 	if ds == nil {
 		return nil
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -48,22 +48,32 @@ func toBizEnvs(envs []*pb.Env) ([]*biz.Env, error) {
 
 func (s *EnvsService) CreateEnvs(ctx context.Context, req *pb.CreateEnvsRequest) (*pb.CreateEnvsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 
+	var items []*pb.Env
+	var errs map[uint32]string
 	_bizenvs, err := toBizEnvs(req.Envs)
-
 	if err == nil {
-		err = s.usecase.CreateEnvs(ctx, _bizenvs)
+		items, errs, err = commitItems(req.Partial, _bizenvs, func(batch []*biz.Env) error {
+			return s.usecase.CreateEnvs(ctx, batch)
+		}, func(v *biz.Env) (*pb.Env, error) {
+			return s.storedEnv(ctx, v.Id)
+		})
 	}
 
 	reply := &pb.CreateEnvsReply{
 		Action:  "CreateEnvs",
 		Code:    0,
 		Message: "success",
+		Envs:    items,
+		Errors:  errs,
 	}
 
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -74,18 +84,35 @@ func (s *EnvsService) CreateEnvs(ctx context.Context, req *pb.CreateEnvsRequest)
 
 func (s *EnvsService) UpdateEnvs(ctx context.Context, req *pb.UpdateEnvsRequest) (*pb.UpdateEnvsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
-	_bizenvs, err := toBizEnvs(req.Envs)
+	var items []*pb.Env
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Envs, func(env *pb.Env) (*pb.Env, error) {
+		return s.storedEnv(ctx, env.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateEnvs(ctx, _bizenvs)
+		var _bizenvs []*biz.Env
+		_bizenvs, err = toBizEnvs(req.Envs)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, _bizenvs, func(batch []*biz.Env) error {
+				return s.usecase.UpdateEnvs(ctx, batch)
+			}, func(v *biz.Env) (*pb.Env, error) {
+				return s.storedEnv(ctx, v.Id)
+			})
+		}
 	}
 	reply := &pb.UpdateEnvsReply{
 		Action:  "UpdateEnvs",
 		Code:    0,
 		Message: "success",
+		Envs:    items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -95,7 +122,7 @@ func (s *EnvsService) UpdateEnvs(ctx context.Context, req *pb.UpdateEnvsRequest)
 
 func (s *EnvsService) DeleteEnvs(ctx context.Context, req *pb.DeleteEnvsRequest) (*pb.DeleteEnvsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	err := s.usecase.DeleteEnvs(ctx, req.Ids)
 	reply := &pb.DeleteEnvsReply{
@@ -104,15 +131,14 @@ func (s *EnvsService) DeleteEnvs(ctx context.Context, req *pb.DeleteEnvsRequest)
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
+		return nil, err
 	}
 	return reply, nil
 }
 
 func (s *EnvsService) GetEnvs(ctx context.Context, req *pb.GetEnvsRequest) (*pb.GetEnvsReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 	env, err := s.usecase.GetEnvs(ctx, req.Id)
 	reply := &pb.GetEnvsReply{
@@ -125,9 +151,7 @@ func (s *EnvsService) GetEnvs(ctx context.Context, req *pb.GetEnvsRequest) (*pb.
 
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *EnvsService) ListEnvs(ctx context.Context, req *pb.ListEnvsRequest) (*pb.ListEnvsReply, error) {
@@ -146,21 +170,22 @@ func (s *EnvsService) ListEnvs(ctx context.Context, req *pb.ListEnvsRequest) (*p
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 	}
 
-	envs, err := s.usecase.ListEnvs(ctx, filter)
+	envs, page, err := s.usecase.ListEnvs(ctx, filter)
 	reply := &pb.ListEnvsReply{
 		Action:  "ListEnvs",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	if err == nil {
 		reply.Envs = toPbEnvs(envs)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func toPbEnv(env *biz.Env) *pb.Env {
@@ -174,6 +199,15 @@ func toPbEnv(env *biz.Env) *pb.Env {
 	}
 }
 
+// storedEnv reads the env with id back as stored.
+func (s *EnvsService) storedEnv(ctx context.Context, id uint32) (*pb.Env, error) {
+	current, err := s.usecase.GetEnvs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbEnv(current), nil
+}
+
 func toPbEnvs(envs []*biz.Env) []*pb.Env {
 	if envs == nil {
 		return nil
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 	biz "opspillar/internal/biz"
@@ -46,6 +46,15 @@ func toPbFeature(feature *biz.Feature) *pb.Feature {
 	}
 }
 
+// storedFeature reads the feature with id back as stored.
+func (s *FeaturesService) storedFeature(ctx context.Context, id uint32) (*pb.Feature, error) {
+	current, err := s.usecase.GetFeatures(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbFeature(current), nil
+}
+
 func toBizFeatures(features []*pb.Feature) ([]*biz.Feature, error) {
 	_bizfeatures := make([]*biz.Feature, len(features))
 	var err error
@@ -59,21 +68,32 @@ func toBizFeatures(features []*pb.Feature) ([]*biz.Feature, error) {
 
 func (s *FeaturesService) CreateFeatures(ctx context.Context, req *pb.CreateFeaturesRequest) (*pb.CreateFeaturesReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 
+	var items []*pb.Feature
+	var errs map[uint32]string
 	features, err := toBizFeatures(req.Features)
 	if err == nil {
-		err = s.usecase.CreateFeatures(ctx, features)
+		items, errs, err = commitItems(req.Partial, features, func(batch []*biz.Feature) error {
+			return s.usecase.CreateFeatures(ctx, batch)
+		}, func(v *biz.Feature) (*pb.Feature, error) {
+			return s.storedFeature(ctx, v.Id)
+		})
 	}
 
 	reply := &pb.CreateFeaturesReply{
-		Action:  "CreateFeatures",
-		Code:    0,
-		Message: "success",
+		Action:   "CreateFeatures",
+		Code:     0,
+		Message:  "success",
+		Features: items,
+		Errors:   errs,
 	}
 
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -84,20 +104,37 @@ func (s *FeaturesService) CreateFeatures(ctx context.Context, req *pb.CreateFeat
 
 func (s *FeaturesService) UpdateFeatures(ctx context.Context, req *pb.UpdateFeaturesRequest) (*pb.UpdateFeaturesReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
-	features, err := toBizFeatures(req.Features)
+	var items []*pb.Feature
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Features, func(f *pb.Feature) (*pb.Feature, error) {
+		return s.storedFeature(ctx, f.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateFeatures(ctx, features)
+		var features []*biz.Feature
+		features, err = toBizFeatures(req.Features)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, features, func(batch []*biz.Feature) error {
+				return s.usecase.UpdateFeatures(ctx, batch)
+			}, func(v *biz.Feature) (*pb.Feature, error) {
+				return s.storedFeature(ctx, v.Id)
+			})
+		}
 	}
 
 	reply := &pb.UpdateFeaturesReply{
-		Action:  "UpdateFeatures",
-		Code:    0,
-		Message: "success",
+		Action:   "UpdateFeatures",
+		Code:     0,
+		Message:  "success",
+		Features: items,
+		Errors:   errs,
 	}
 
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -107,7 +144,7 @@ func (s *FeaturesService) UpdateFeatures(ctx context.Context, req *pb.UpdateFeat
 
 func (s *FeaturesService) DeleteFeatures(ctx context.Context, req *pb.DeleteFeaturesRequest) (*pb.DeleteFeaturesReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 
 	err := s.usecase.DeleteFeatures(ctx, req.Ids)
@@ -117,9 +154,7 @@ func (s *FeaturesService) DeleteFeatures(ctx context.Context, req *pb.DeleteFeat
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 
 	return reply, nil
@@ -127,7 +162,7 @@ func (s *FeaturesService) DeleteFeatures(ctx context.Context, req *pb.DeleteFeat
 
 func (s *FeaturesService) GetFeatures(ctx context.Context, req *pb.GetFeaturesRequest) (*pb.GetFeaturesReply, error) {
 	if req == nil {
-		return nil, fmt.Errorf("req is nil")
+		return nil, biz.InvalidArgument("req is nil")
 	}
 
 	feature, err := s.usecase.GetFeatures(ctx, req.Id)
@@ -141,9 +176,7 @@ func (s *FeaturesService) GetFeatures(ctx context.Context, req *pb.GetFeaturesRe
 		reply.Feature = toPbFeature(feature)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *FeaturesService) ListFeatures(ctx context.Context,
@@ -165,15 +198,18 @@ func (s *FeaturesService) ListFeatures(ctx context.Context,
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 	}
 
-	features, err := s.usecase.ListFeatures(ctx, filter)
+	features, page, err := s.usecase.ListFeatures(ctx, filter)
 
 	reply := &pb.ListFeaturesReply{
 		Action:  "ListFeatures",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 
 	if err == nil {
 		reply.Features = make([]*pb.Feature, len(features))
@@ -183,9 +219,6 @@ func (s *FeaturesService) ListFeatures(ctx context.Context,
 		return reply, nil
 	}
 
-	reply.Code = 1
-	reply.Message = err.Error()
-
-	return reply, nil
+	return nil, err
 
 }
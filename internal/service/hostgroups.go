@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -40,6 +41,7 @@ func toBizHostgroup(p *pb.Hostgroup) (*biz.Hostgroup, error) {
 		TagsId:          p.TagsId,
 		ShareProductsId: p.ShareProductsId,
 		ShareTeamsId:    p.ShareTeamsId,
+		Attributes:      p.Attributes,
 	}, nil
 }
 
@@ -62,16 +64,27 @@ func (s *HostgroupsService) CreateHostgroups(ctx context.Context, req *pb.Create
 	if req == nil {
 		return nil, ErrRequestNil
 	}
+	var items []*pb.Hostgroup
+	var errs map[uint32]string
 	bizHostgroups, err := toBizHostgroups(req.Hostgroups)
 	if err == nil {
-		err = s.usecase.CreateHostgroups(ctx, bizHostgroups)
+		items, errs, err = commitItems(req.Partial, bizHostgroups, func(batch []*biz.Hostgroup) error {
+			return s.usecase.CreateHostgroups(ctx, batch)
+		}, func(v *biz.Hostgroup) (*pb.Hostgroup, error) {
+			return s.storedHostgroup(ctx, v.Id)
+		})
 	}
 	reply := &pb.CreateHostgroupsReply{
-		Action:  "CreateHostgroups",
-		Code:    0,
-		Message: "success",
+		Action:     "CreateHostgroups",
+		Code:       0,
+		Message:    "success",
+		Hostgroups: items,
+		Errors:     errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -83,16 +96,25 @@ func (s *HostgroupsService) UpdateHostgroups(ctx context.Context, req *pb.Update
 	if req == nil {
 		return nil, ErrRequestNil
 	}
-	bizHostgroups, err := toBizHostgroups(req.Hostgroups)
-	if err == nil {
-		err = s.usecase.UpdateHostgroups(ctx, bizHostgroups)
+	var items []*pb.Hostgroup
+	var errs map[uint32]string
+	var err error
+	if len(req.Ids) > 0 || req.Add != nil || req.Remove != nil {
+		items, errs, err = s.updateHostgroupsRelations(ctx, req)
+	} else {
+		items, errs, err = s.updateHostgroups(ctx, req)
 	}
 	reply := &pb.UpdateHostgroupsReply{
-		Action:  "UpdateHostgroups",
-		Code:    0,
-		Message: "success",
+		Action:     "UpdateHostgroups",
+		Code:       0,
+		Message:    "success",
+		Hostgroups: items,
+		Errors:     errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -100,6 +122,50 @@ func (s *HostgroupsService) UpdateHostgroups(ctx context.Context, req *pb.Update
 	return reply, nil
 }
 
+func (s *HostgroupsService) updateHostgroups(ctx context.Context,
+	req *pb.UpdateHostgroupsRequest) ([]*pb.Hostgroup, map[uint32]string, error) {
+	err := maskUpdates(req.UpdateMask, req.Hostgroups, func(hg *pb.Hostgroup) (*pb.Hostgroup, error) {
+		return s.storedHostgroup(ctx, hg.Id)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	bizHostgroups, err := toBizHostgroups(req.Hostgroups)
+	if err != nil {
+		return nil, nil, err
+	}
+	return commitItems(req.Partial, bizHostgroups, func(batch []*biz.Hostgroup) error {
+		return s.usecase.UpdateHostgroups(ctx, batch)
+	}, func(v *biz.Hostgroup) (*pb.Hostgroup, error) {
+		return s.storedHostgroup(ctx, v.Id)
+	})
+}
+
+func (s *HostgroupsService) updateHostgroupsRelations(ctx context.Context,
+	req *pb.UpdateHostgroupsRequest) ([]*pb.Hostgroup, map[uint32]string, error) {
+	if len(req.Hostgroups) > 0 || req.UpdateMask != nil {
+		return nil, nil, ErrUpdateRelationsExclusive
+	}
+	add, remove := toBizHostgroupRelations(req.Add), toBizHostgroupRelations(req.Remove)
+	return commitItems(req.Partial, req.Ids, func(batch []uint32) error {
+		return s.usecase.UpdateHostgroupsRelations(ctx, batch, add, remove)
+	}, func(id uint32) (*pb.Hostgroup, error) {
+		return s.storedHostgroup(ctx, id)
+	})
+}
+
+func toBizHostgroupRelations(r *pb.HostgroupRelations) *biz.HostgroupRelations {
+	if r == nil {
+		return nil
+	}
+	return &biz.HostgroupRelations{
+		TagsId:          r.TagsId,
+		FeaturesId:      r.FeaturesId,
+		ShareProductsId: r.ShareProductsId,
+		ShareTeamsId:    r.ShareTeamsId,
+	}
+}
+
 func (s *HostgroupsService) DeleteHostgroups(ctx context.Context, req *pb.DeleteHostgroupsRequest) (*pb.DeleteHostgroupsReply, error) {
 	if req == nil {
 		return nil, ErrRequestNil
@@ -111,9 +177,7 @@ func (s *HostgroupsService) DeleteHostgroups(ctx context.Context, req *pb.Delete
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	return reply, nil
 }
@@ -129,9 +193,7 @@ func (s *HostgroupsService) GetHostgroups(ctx context.Context, req *pb.GetHostgr
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	reply.Hostgroup = toPbHostgroup(bizhostgroups)
 	return reply, nil
@@ -167,6 +229,12 @@ func (s *HostgroupsService) ListHostgroups(ctx context.Context, req *pb.ListHost
 		if len(req.TeamsId) > 0 {
 			filter.TeamsId = req.TeamsId
 		}
+		if len(req.OrgIds) > 0 {
+			filter.OrgIds = req.OrgIds
+		}
+		if len(req.Attributes) > 0 {
+			filter.Attributes = req.Attributes
+		}
 		if len(req.FeaturesId) > 0 {
 			filter.FeaturesId = req.FeaturesId
 		}
@@ -179,17 +247,30 @@ func (s *HostgroupsService) ListHostgroups(ctx context.Context, req *pb.ListHost
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+		filter.NameMatch = req.NameMatch
+		filter.SortBy = req.SortBy
+		filter.SortDesc = req.SortDesc
+		filter.CreatedAfter = req.CreatedAfter
+		filter.CreatedBefore = req.CreatedBefore
+		filter.UpdatedAfter = req.UpdatedAfter
+		filter.UpdatedBefore = req.UpdatedBefore
+		filter.ExcludeTeamsId = req.ExcludeTeamsId
+		filter.ExcludeProductsId = req.ExcludeProductsId
+		filter.NoTags = req.NoTags
+		filter.NoFeatures = req.NoFeatures
+		filter.Selector = req.Selector
 	}
-	hgs, err := s.usecase.ListHostgroups(ctx, filter)
+	hgs, page, err := s.usecase.ListHostgroups(ctx, filter)
 	reply := &pb.ListHostgroupsReply{
 		Action:  "ListHostgroups",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	reply.Hostgroups = toPbHostgroups(hgs)
 	return reply, nil
@@ -216,7 +297,18 @@ func toPbHostgroup(bizHostgroup *biz.Hostgroup) *pb.Hostgroup {
 		CreatedBy:       bizHostgroup.CreatedBy,
 		UpdatedAt:       bizHostgroup.UpdatedAt,
 		UpdatedBy:       bizHostgroup.UpdatedBy,
+		OrgId:           bizHostgroup.OrgId,
+		Attributes:      bizHostgroup.Attributes,
+	}
+}
+
+// storedHostgroup reads the hostgroup with id back as stored.
+func (s *HostgroupsService) storedHostgroup(ctx context.Context, id uint32) (*pb.Hostgroup, error) {
+	current, err := s.usecase.GetHostgroups(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	return toPbHostgroup(current), nil
 }
 
 func toPbHostgroups(bizHostgroups []*biz.Hostgroup) []*pb.Hostgroup {
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	biz "opspillar/internal/biz"
+)
+
+type OrganizationsService struct {
+	pb.UnimplementedOrganizationsServer
+	usecase *biz.OrganizationsUsecase
+	log     *log.Helper
+}
+
+func NewOrganizationsService(uc *biz.OrganizationsUsecase, logger log.Logger) *OrganizationsService {
+	return &OrganizationsService{
+		usecase: uc,
+		log:     log.NewHelper(logger),
+	}
+}
+
+func toBizOrganization(org *pb.Organization) (*biz.Organization, error) {
+	if org == nil {
+		return nil, nil
+	}
+	return &biz.Organization{
+		Description: org.Description,
+		Id:          org.Id,
+		Name:        org.Name,
+		Admins:      org.Admins,
+	}, nil
+}
+
+func toBizOrganizations(orgs []*pb.Organization) ([]*biz.Organization, error) {
+	_bizorgs := make([]*biz.Organization, len(orgs))
+	var err error
+	for i, e := range orgs {
+		if _bizorgs[i], err = toBizOrganization(e); err != nil {
+			return nil, err
+		}
+	}
+	return _bizorgs, nil
+}
+
+func (s *OrganizationsService) CreateOrganizations(ctx context.Context, req *pb.CreateOrganizationsRequest) (*pb.CreateOrganizationsReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+
+	var items []*pb.Organization
+	var errs map[uint32]string
+	_bizorgs, err := toBizOrganizations(req.Organizations)
+	if err == nil {
+		items, errs, err = commitItems(req.Partial, _bizorgs, func(batch []*biz.Organization) error {
+			return s.usecase.CreateOrganizations(ctx, batch)
+		}, func(v *biz.Organization) (*pb.Organization, error) {
+			return s.storedOrganization(ctx, v.Id)
+		})
+	}
+
+	reply := &pb.CreateOrganizationsReply{
+		Action:        "CreateOrganizations",
+		Code:          0,
+		Message:       "success",
+		Organizations: items,
+		Errors:        errs,
+	}
+
+	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
+		reply.Code = 1
+		reply.Message = err.Error()
+		return reply, nil
+	}
+
+	return reply, nil
+}
+
+func (s *OrganizationsService) UpdateOrganizations(ctx context.Context, req *pb.UpdateOrganizationsRequest) (*pb.UpdateOrganizationsReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	var items []*pb.Organization
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Organizations, func(org *pb.Organization) (*pb.Organization, error) {
+		return s.storedOrganization(ctx, org.Id)
+	})
+	if err == nil {
+		var _bizorgs []*biz.Organization
+		_bizorgs, err = toBizOrganizations(req.Organizations)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, _bizorgs, func(batch []*biz.Organization) error {
+				return s.usecase.UpdateOrganizations(ctx, batch)
+			}, func(v *biz.Organization) (*pb.Organization, error) {
+				return s.storedOrganization(ctx, v.Id)
+			})
+		}
+	}
+	reply := &pb.UpdateOrganizationsReply{
+		Action:        "UpdateOrganizations",
+		Code:          0,
+		Message:       "success",
+		Organizations: items,
+		Errors:        errs,
+	}
+	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
+		reply.Code = 1
+		reply.Message = err.Error()
+		return reply, nil
+	}
+	return reply, nil
+}
+
+func (s *OrganizationsService) DeleteOrganizations(ctx context.Context, req *pb.DeleteOrganizationsRequest) (*pb.DeleteOrganizationsReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	err := s.usecase.DeleteOrganizations(ctx, req.Ids)
+	reply := &pb.DeleteOrganizationsReply{
+		Action:  "DeleteOrganizations",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *OrganizationsService) GetOrganizations(ctx context.Context, req *pb.GetOrganizationsRequest) (*pb.GetOrganizationsReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	org, err := s.usecase.GetOrganizations(ctx, req.Id)
+	reply := &pb.GetOrganizationsReply{
+		Action:  "GetOrganizations",
+		Code:    0,
+		Message: "success",
+	}
+	if err == nil {
+		reply.Organization = toPbOrganization(org)
+
+		return reply, nil
+	}
+	return nil, err
+}
+
+func (s *OrganizationsService) ListOrganizations(ctx context.Context, req *pb.ListOrganizationsRequest) (*pb.ListOrganizationsReply, error) {
+
+	filter := biz.DefaultOrganizationsFilter()
+	if req != nil {
+		if len(req.Ids) > 0 {
+			filter.Ids = req.Ids
+		}
+		if len(req.Names) > 0 {
+			filter.Names = req.Names
+		}
+		if req.PageSize > 0 {
+			filter.PageSize = req.PageSize
+		}
+		if req.Page > 0 {
+			filter.Page = req.Page
+		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+	}
+
+	orgs, page, err := s.usecase.ListOrganizations(ctx, filter)
+	reply := &pb.ListOrganizationsReply{
+		Action:  "ListOrganizations",
+		Code:    0,
+		Message: "success",
+	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
+	if err == nil {
+		reply.Organizations = toPbOrganizations(orgs)
+		return reply, nil
+	}
+	return nil, err
+}
+
+func toPbOrganization(org *biz.Organization) *pb.Organization {
+	if org == nil {
+		return nil
+	}
+	return &pb.Organization{
+		Id:          org.Id,
+		Name:        org.Name,
+		Description: org.Description,
+		Admins:      org.Admins,
+	}
+}
+
+// storedOrganization reads the organization with id back as stored.
+func (s *OrganizationsService) storedOrganization(ctx context.Context, id uint32) (*pb.Organization, error) {
+	current, err := s.usecase.GetOrganizations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbOrganization(current), nil
+}
+
+func toPbOrganizations(orgs []*biz.Organization) []*pb.Organization {
+	if orgs == nil {
+		return nil
+	}
+	var res []*pb.Organization
+	for _, e := range orgs {
+		if e != nil {
+			res = append(res, toPbOrganization(e))
+		}
+	}
+	return res
+}
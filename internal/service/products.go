@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -32,6 +33,7 @@ func toBizProduct(p *pb.Product) (*biz.Product, error) {
 		Name:        p.Name,
 		Code:        p.Code,
 		Description: p.Description,
+		OrgId:       p.OrgId,
 	}, nil
 }
 
@@ -55,16 +57,27 @@ func (s *ProductsService) CreateProducts(ctx context.Context, req *pb.CreateProd
 		return nil, ErrRequestNil
 	}
 
+	var items []*pb.Product
+	var errs map[uint32]string
 	ps, err := toBizProducts(req.Products)
 	if err == nil {
-		err = s.usecase.CreateProducts(ctx, ps)
+		items, errs, err = commitItems(req.Partial, ps, func(batch []*biz.Product) error {
+			return s.usecase.CreateProducts(ctx, batch)
+		}, func(v *biz.Product) (*pb.Product, error) {
+			return s.storedProduct(ctx, v.Id)
+		})
 	}
 	reply := &pb.CreateProductsReply{
-		Action:  "createProducts",
-		Code:    0,
-		Message: "success",
+		Action:   "createProducts",
+		Code:     0,
+		Message:  "success",
+		Products: items,
+		Errors:   errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -76,17 +89,34 @@ func (s *ProductsService) UpdateProducts(ctx context.Context, req *pb.UpdateProd
 	if req == nil {
 		return nil, ErrRequestNil
 	}
-	ps, err := toBizProducts(req.Products)
+	var items []*pb.Product
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Products, func(p *pb.Product) (*pb.Product, error) {
+		return s.storedProduct(ctx, p.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateProducts(ctx, ps)
+		var ps []*biz.Product
+		ps, err = toBizProducts(req.Products)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, ps, func(batch []*biz.Product) error {
+				return s.usecase.UpdateProducts(ctx, batch)
+			}, func(v *biz.Product) (*pb.Product, error) {
+				return s.storedProduct(ctx, v.Id)
+			})
+		}
 	}
 
 	reply := &pb.UpdateProductsReply{
-		Action:  "updateProducts",
-		Code:    0,
-		Message: "success",
+		Action:   "updateProducts",
+		Code:     0,
+		Message:  "success",
+		Products: items,
+		Errors:   errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -105,9 +135,7 @@ func (s *ProductsService) DeleteProducts(ctx context.Context, req *pb.DeleteProd
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	return reply, nil
 }
@@ -123,9 +151,7 @@ func (s *ProductsService) GetProducts(ctx context.Context, req *pb.GetProductsRe
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	reply.Product = toPbProduct(p)
 	return reply, nil
@@ -142,24 +168,28 @@ func (s *ProductsService) ListProducts(ctx context.Context, req *pb.ListProducts
 		if len(req.Ids) > 0 {
 			filter.Ids = req.Ids
 		}
+		if len(req.OrgIds) > 0 {
+			filter.OrgIds = req.OrgIds
+		}
 		if req.PageSize > 0 {
 			filter.PageSize = req.PageSize
 		}
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 	}
 
-	ps, err := s.usecase.ListProducts(ctx, filter)
+	ps, page, err := s.usecase.ListProducts(ctx, filter)
 	reply := &pb.ListProductsReply{
 		Action:  "ListProducts",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	reply.Products = toPbProducts(ps)
 	return reply, nil
@@ -175,7 +205,17 @@ func toPbProduct(p *biz.Product) *pb.Product {
 		Description: p.Description,
 		Id:          p.Id,
 		Name:        p.Name,
+		OrgId:       p.OrgId,
+	}
+}
+
+// storedProduct reads the product with id back as stored.
+func (s *ProductsService) storedProduct(ctx context.Context, id uint32) (*pb.Product, error) {
+	current, err := s.usecase.GetProducts(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	return toPbProduct(current), nil
 }
 
 func toPbProducts(ps []*biz.Product) []*pb.Product {
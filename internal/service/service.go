@@ -2,8 +2,15 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+
+	"opspillar/internal/biz"
 
 	"github.com/google/wire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // ProviderSet is service providers.
@@ -13,11 +20,102 @@ var ProviderSet = wire.NewSet(
 	NewTeamsService,
 	NewProductsService,
 	NewEnvsService,
+	NewOrganizationsService,
 	NewClustersService,
 	NewDatacentersService,
 	NewHostgroupsService,
 	NewApplicationsService,
+	NewAttributesService,
+	NewTagKeysService,
 	NewAdminService,
+	NewServiceAccountsService,
+	NewBreakGlassService,
+	NewAuthzService,
 )
 
-var ErrRequestNil = errors.New("requestIsNil")
+var ErrRequestNil = biz.InvalidArgument("requestIsNil")
+var ErrUpdateMaskInvalid = biz.InvalidArgument("update mask invalid").WithField("update_mask", "must name fields of the entities")
+var ErrUpdateRelationsExclusive = biz.InvalidArgument("ids, add and remove are exclusive with entities and update_mask")
+var ErrItemsFailed = errors.New("items failed")
+
+// toPbPageInfo returns the next page token and the total count of a list reply
+func toPbPageInfo(page *biz.PageInfo) (string, int64) {
+	if page == nil {
+		return "", 0
+	}
+	var total int64
+	if page.TotalCount != nil {
+		total = *page.TotalCount
+	}
+	return page.NextPageToken, total
+}
+
+// maskUpdates replaces each of updates by its stored value, loaded by current, with the fields
+// of mask set from the update. updates are kept as they are when mask is empty.
+func maskUpdates[T proto.Message](mask *fieldmaskpb.FieldMask, updates []T, current func(T) (T, error)) error {
+	if len(mask.GetPaths()) == 0 || len(updates) == 0 {
+		return nil
+	}
+	fields := updates[0].ProtoReflect().Descriptor().Fields()
+	for _, path := range mask.GetPaths() {
+		// fields are replaced as a whole, maps and lists included
+		if strings.Contains(path, ".") || fields.ByName(protoreflect.Name(path)) == nil {
+			return fmt.Errorf("%w: unknown field %s", ErrUpdateMaskInvalid, path)
+		}
+	}
+	for i, update := range updates {
+		stored, err := current(update)
+		if err != nil {
+			return err
+		}
+		src, dst := update.ProtoReflect(), stored.ProtoReflect()
+		for _, path := range mask.GetPaths() {
+			fd := fields.ByName(protoreflect.Name(path))
+			if src.Has(fd) {
+				dst.Set(fd, src.Get(fd))
+			} else {
+				dst.Clear(fd)
+			}
+		}
+		updates[i] = stored
+	}
+	return nil
+}
+
+// commitItems commits items by commit, all in one batch or in partial mode each alone, and
+// returns the committed items read back by stored. Errors of the items not committed in partial
+// mode are returned by their index in items, together with the committed ones.
+func commitItems[T any, P any](partial bool, items []T, commit func([]T) error,
+	stored func(T) (P, error)) ([]P, map[uint32]string, error) {
+
+	committed := items
+	var errs map[uint32]string
+	if partial {
+		committed = make([]T, 0, len(items))
+		for i, item := range items {
+			if err := commit([]T{item}); err != nil {
+				if errs == nil {
+					errs = make(map[uint32]string)
+				}
+				errs[uint32(i)] = err.Error()
+				continue
+			}
+			committed = append(committed, item)
+		}
+	} else if err := commit(items); err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]P, 0, len(committed))
+	for _, item := range committed {
+		result, err := stored(item)
+		if err != nil {
+			return nil, errs, err
+		}
+		results = append(results, result)
+	}
+	if len(errs) > 0 {
+		return results, errs, fmt.Errorf("%w: %d of %d", ErrItemsFailed, len(errs), len(items))
+	}
+	return results, nil, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	biz "opspillar/internal/biz"
+)
+
+type ServiceAccountsService struct {
+	pb.UnimplementedServiceAccountsServer
+	usecase *biz.ServiceAccountsUsecase
+	log     *log.Helper
+}
+
+func NewServiceAccountsService(uc *biz.ServiceAccountsUsecase, logger log.Logger) *ServiceAccountsService {
+	return &ServiceAccountsService{
+		usecase: uc,
+		log:     log.NewHelper(logger),
+	}
+}
+
+func toBizServiceAccounts(accounts []*pb.ServiceAccount) []*biz.ServiceAccount {
+	var biz_accounts = make([]*biz.ServiceAccount, len(accounts))
+	for i, sa := range accounts {
+		biz_accounts[i] = &biz.ServiceAccount{
+			Id:          sa.Id,
+			Name:        sa.Name,
+			TeamId:      sa.TeamId,
+			Role:        sa.Role,
+			Description: sa.Description,
+		}
+	}
+	return biz_accounts
+}
+
+func toPbServiceAccounts(accounts []*biz.ServiceAccount) []*pb.ServiceAccount {
+	var res []*pb.ServiceAccount
+	for _, sa := range accounts {
+		if sa == nil {
+			continue
+		}
+		res = append(res, &pb.ServiceAccount{
+			Id:          sa.Id,
+			Name:        sa.Name,
+			TeamId:      sa.TeamId,
+			Role:        sa.Role,
+			Description: sa.Description,
+			CreatedBy:   sa.CreatedBy,
+			CreatedAt:   sa.CreatedAt,
+		})
+	}
+	return res
+}
+
+func toPbApiKey(k *biz.ApiKey) *pb.ApiKey {
+	if k == nil {
+		return nil
+	}
+	return &pb.ApiKey{
+		Id:               k.Id,
+		ServiceAccountId: k.ServiceAccountId,
+		Name:             k.Name,
+		Key:              k.Key,
+		Prefix:           k.Prefix,
+		Scopes:           k.Scopes,
+		Description:      k.Description,
+		ExpiresAt:        k.ExpiresAt,
+		LastUsedAt:       k.LastUsedAt,
+		CreatedAt:        k.CreatedAt,
+	}
+}
+
+func (s *ServiceAccountsService) CreateServiceAccounts(ctx context.Context, req *pb.CreateServiceAccountsRequest) (*pb.CreateServiceAccountsReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.CreateServiceAccounts(ctx, toBizServiceAccounts(req.ServiceAccounts))
+	reply := &pb.CreateServiceAccountsReply{
+		Action:  "CreateServiceAccounts",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *ServiceAccountsService) DeleteServiceAccounts(ctx context.Context, req *pb.DeleteServiceAccountsRequest) (*pb.DeleteServiceAccountsReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.DeleteServiceAccounts(ctx, req.Ids)
+	reply := &pb.DeleteServiceAccountsReply{
+		Action:  "DeleteServiceAccounts",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *ServiceAccountsService) ListServiceAccounts(ctx context.Context, req *pb.ListServiceAccountsRequest) (*pb.ListServiceAccountsReply, error) {
+	filter := biz.DefaultServiceAccountsFilter()
+	if req != nil {
+		if req.Page > 0 {
+			filter.Page = req.Page
+		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+		if req.PageSize > 0 {
+			filter.PageSize = req.PageSize
+		}
+		filter.Ids = req.Ids
+		filter.Names = req.Names
+		filter.TeamIds = req.TeamIds
+	}
+	accounts, page, err := s.usecase.ListServiceAccounts(ctx, filter)
+	reply := &pb.ListServiceAccountsReply{
+		Action:  "ListServiceAccounts",
+		Code:    0,
+		Message: "success",
+	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
+	if err != nil {
+		return nil, err
+	}
+	reply.ServiceAccounts = toPbServiceAccounts(accounts)
+	return reply, nil
+}
+
+func (s *ServiceAccountsService) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyReply, error) {
+	if req == nil || req.ApiKey == nil {
+		return nil, ErrRequestNil
+	}
+	k, err := s.usecase.CreateApiKey(ctx, &biz.ApiKey{
+		ServiceAccountId: req.ApiKey.ServiceAccountId,
+		Name:             req.ApiKey.Name,
+		Scopes:           req.ApiKey.Scopes,
+		Description:      req.ApiKey.Description,
+		ExpireDays:       req.ApiKey.ExpireDays,
+	})
+	reply := &pb.CreateApiKeyReply{
+		Action:  "CreateApiKey",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	reply.ApiKey = toPbApiKey(k)
+	return reply, nil
+}
+
+func (s *ServiceAccountsService) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	keys, err := s.usecase.ListApiKeys(ctx, req.ServiceAccountIds)
+	reply := &pb.ListApiKeysReply{
+		Action:  "ListApiKeys",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		reply.ApiKeys = append(reply.ApiKeys, toPbApiKey(k))
+	}
+	return reply, nil
+}
+
+func (s *ServiceAccountsService) RevokeApiKeys(ctx context.Context, req *pb.RevokeApiKeysRequest) (*pb.RevokeApiKeysReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.RevokeApiKeys(ctx, req.Ids)
+	reply := &pb.RevokeApiKeysReply{
+		Action:  "RevokeApiKeys",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
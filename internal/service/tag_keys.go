@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	pb "opspillar/api/opspillar/v1"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	biz "opspillar/internal/biz"
+)
+
+type TagKeysService struct {
+	pb.UnimplementedTagKeysServer
+	usecase *biz.TagKeysUsecase
+	log     *log.Helper
+}
+
+func NewTagKeysService(uc *biz.TagKeysUsecase, logger log.Logger) *TagKeysService {
+	return &TagKeysService{
+		usecase: uc,
+		log:     log.NewHelper(logger),
+	}
+}
+
+func toBizTagKey(key *pb.TagKey) (*biz.TagKey, error) {
+	if key == nil {
+		return nil, nil
+	}
+	return &biz.TagKey{
+		Id:            key.Id,
+		Key:           key.Key,
+		Description:   key.Description,
+		AllowedValues: key.AllowedValues,
+		Pattern:       key.Pattern,
+		Kinds:         key.Kinds,
+		Required:      key.Required,
+	}, nil
+}
+
+func toBizTagKeys(keys []*pb.TagKey) ([]*biz.TagKey, error) {
+	_bizkeys := make([]*biz.TagKey, len(keys))
+	var err error
+	for i, e := range keys {
+		if _bizkeys[i], err = toBizTagKey(e); err != nil {
+			return nil, err
+		}
+	}
+	return _bizkeys, nil
+}
+
+func (s *TagKeysService) CreateTagKeys(ctx context.Context, req *pb.CreateTagKeysRequest) (*pb.CreateTagKeysReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+
+	var items []*pb.TagKey
+	var errs map[uint32]string
+	_bizkeys, err := toBizTagKeys(req.TagKeys)
+	if err == nil {
+		items, errs, err = commitItems(req.Partial, _bizkeys, func(batch []*biz.TagKey) error {
+			return s.usecase.CreateTagKeys(ctx, batch)
+		}, func(v *biz.TagKey) (*pb.TagKey, error) {
+			return s.storedTagKey(ctx, v.Id)
+		})
+	}
+
+	reply := &pb.CreateTagKeysReply{
+		Action:  "CreateTagKeys",
+		Code:    0,
+		Message: "success",
+		TagKeys: items,
+		Errors:  errs,
+	}
+
+	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
+		reply.Code = 1
+		reply.Message = err.Error()
+		return reply, nil
+	}
+
+	return reply, nil
+}
+
+func (s *TagKeysService) UpdateTagKeys(ctx context.Context, req *pb.UpdateTagKeysRequest) (*pb.UpdateTagKeysReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	var items []*pb.TagKey
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.TagKeys, func(key *pb.TagKey) (*pb.TagKey, error) {
+		return s.storedTagKey(ctx, key.Id)
+	})
+	if err == nil {
+		var _bizkeys []*biz.TagKey
+		_bizkeys, err = toBizTagKeys(req.TagKeys)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, _bizkeys, func(batch []*biz.TagKey) error {
+				return s.usecase.UpdateTagKeys(ctx, batch)
+			}, func(v *biz.TagKey) (*pb.TagKey, error) {
+				return s.storedTagKey(ctx, v.Id)
+			})
+		}
+	}
+	reply := &pb.UpdateTagKeysReply{
+		Action:  "UpdateTagKeys",
+		Code:    0,
+		Message: "success",
+		TagKeys: items,
+		Errors:  errs,
+	}
+	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
+		reply.Code = 1
+		reply.Message = err.Error()
+		return reply, nil
+	}
+	return reply, nil
+}
+
+func (s *TagKeysService) DeleteTagKeys(ctx context.Context, req *pb.DeleteTagKeysRequest) (*pb.DeleteTagKeysReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	err := s.usecase.DeleteTagKeys(ctx, req.Ids)
+	reply := &pb.DeleteTagKeysReply{
+		Action:  "DeleteTagKeys",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *TagKeysService) GetTagKeys(ctx context.Context, req *pb.GetTagKeysRequest) (*pb.GetTagKeysReply, error) {
+	if req == nil {
+		return nil, biz.InvalidArgument("req is nil")
+	}
+	key, err := s.usecase.GetTagKeys(ctx, req.Id)
+	reply := &pb.GetTagKeysReply{
+		Action:  "GetTagKeys",
+		Code:    0,
+		Message: "success",
+	}
+	if err == nil {
+		reply.TagKey = toPbTagKey(key)
+
+		return reply, nil
+	}
+	return nil, err
+}
+
+func (s *TagKeysService) ListTagKeys(ctx context.Context, req *pb.ListTagKeysRequest) (*pb.ListTagKeysReply, error) {
+
+	filter := biz.DefaultTagKeysFilter()
+	if req != nil {
+		if len(req.Ids) > 0 {
+			filter.Ids = req.Ids
+		}
+		if len(req.Keys) > 0 {
+			filter.Keys = req.Keys
+		}
+		if req.PageSize > 0 {
+			filter.PageSize = req.PageSize
+		}
+		if req.Page > 0 {
+			filter.Page = req.Page
+		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
+	}
+
+	keys, page, err := s.usecase.ListTagKeys(ctx, filter)
+	reply := &pb.ListTagKeysReply{
+		Action:  "ListTagKeys",
+		Code:    0,
+		Message: "success",
+	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
+	if err == nil {
+		reply.TagKeys = toPbTagKeys(keys)
+		return reply, nil
+	}
+	return nil, err
+}
+
+func toPbTagKey(key *biz.TagKey) *pb.TagKey {
+	if key == nil {
+		return nil
+	}
+	return &pb.TagKey{
+		Id:            key.Id,
+		Key:           key.Key,
+		Description:   key.Description,
+		AllowedValues: key.AllowedValues,
+		Pattern:       key.Pattern,
+		Kinds:         key.Kinds,
+		Required:      key.Required,
+	}
+}
+
+// storedTagKey reads the tag key with id back as stored.
+func (s *TagKeysService) storedTagKey(ctx context.Context, id uint32) (*pb.TagKey, error) {
+	current, err := s.usecase.GetTagKeys(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbTagKey(current), nil
+}
+
+func toPbTagKeys(keys []*biz.TagKey) []*pb.TagKey {
+	if keys == nil {
+		return nil
+	}
+	var res []*pb.TagKey
+	for _, e := range keys {
+		if e != nil {
+			res = append(res, toPbTagKey(e))
+		}
+	}
+	return res
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 	biz "opspillar/internal/biz"
@@ -45,6 +46,15 @@ func toPbTag(t *biz.Tag) *pb.Tag {
 	}
 }
 
+// storedTag reads the tag with id back as stored.
+func (s *TagsService) storedTag(ctx context.Context, id uint32) (*pb.Tag, error) {
+	current, err := s.usecase.GetTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPbTag(current), nil
+}
+
 func toBizTags(ts []*pb.Tag) ([]*biz.Tag, error) {
 	tags := make([]*biz.Tag, len(ts))
 	for i, t := range ts {
@@ -63,17 +73,28 @@ func (s *TagsService) CreateTags(ctx context.Context, req *pb.CreateTagsRequest)
 		return nil, ErrRequestNil
 	}
 
+	var items []*pb.Tag
+	var errs map[uint32]string
 	tags, err := toBizTags(req.Tags)
 	if err == nil {
-		err = s.usecase.CreateTags(ctx, tags)
+		items, errs, err = commitItems(req.Partial, tags, func(batch []*biz.Tag) error {
+			return s.usecase.CreateTags(ctx, batch)
+		}, func(v *biz.Tag) (*pb.Tag, error) {
+			return s.storedTag(ctx, v.Id)
+		})
 	}
 
 	reply := &pb.CreateTagsReply{
 		Action:  "CreateTags",
 		Code:    0,
 		Message: "success",
+		Tags:    items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -87,17 +108,34 @@ func (s *TagsService) UpdateTags(ctx context.Context, req *pb.UpdateTagsRequest)
 		return nil, ErrRequestNil
 	}
 
-	tags, err := toBizTags(req.Tags)
+	var items []*pb.Tag
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Tags, func(t *pb.Tag) (*pb.Tag, error) {
+		return s.storedTag(ctx, t.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateTags(ctx, tags)
+		var tags []*biz.Tag
+		tags, err = toBizTags(req.Tags)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, tags, func(batch []*biz.Tag) error {
+				return s.usecase.UpdateTags(ctx, batch)
+			}, func(v *biz.Tag) (*pb.Tag, error) {
+				return s.storedTag(ctx, v.Id)
+			})
+		}
 	}
 
 	reply := &pb.UpdateTagsReply{
 		Action:  "UpdateTags",
 		Code:    0,
 		Message: "success",
+		Tags:    items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -119,9 +157,7 @@ func (s *TagsService) DeleteTags(ctx context.Context, req *pb.DeleteTagsRequest)
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 
 	return reply, nil
@@ -143,9 +179,7 @@ func (s *TagsService) GetTags(ctx context.Context, req *pb.GetTagsRequest) (*pb.
 		reply.Tag = toPbTag(tag)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *TagsService) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsReply, error) {
@@ -154,6 +188,8 @@ func (s *TagsService) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*p
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 		if req.PageSize > 0 {
 			filter.PageSize = req.PageSize
 		}
@@ -168,13 +204,14 @@ func (s *TagsService) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*p
 		}
 	}
 
-	tags, err := s.usecase.ListTags(ctx, filter)
+	tags, page, err := s.usecase.ListTags(ctx, filter)
 
 	reply := &pb.ListTagsReply{
 		Action:  "ListTags",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 
 	if err == nil {
 		reply.Tags = make([]*pb.Tag, len(tags))
@@ -184,8 +221,5 @@ func (s *TagsService) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*p
 		return reply, nil
 	}
 
-	reply.Code = 1
-	reply.Message = err.Error()
-
-	return reply, nil
+	return nil, err
 }
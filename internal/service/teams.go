@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	pb "opspillar/api/opspillar/v1"
 
@@ -30,6 +31,7 @@ func toBizTeam(t *pb.Team) (*biz.Team, error) {
 		Description: t.Description,
 		LeaderId:    t.LeaderId,
 		Name:        t.Name,
+		OrgId:       t.OrgId,
 	}, nil
 }
 
@@ -51,17 +53,28 @@ func (s *TeamsService) CreateTeams(ctx context.Context, req *pb.CreateTeamsReque
 		return nil, ErrRequestNil
 	}
 
+	var items []*pb.Team
+	var errs map[uint32]string
 	ts, err := toBizTeams(req.Teams)
 	if err == nil {
-		err = s.usecase.CreateTeams(ctx, ts)
+		items, errs, err = commitItems(req.Partial, ts, func(batch []*biz.Team) error {
+			return s.usecase.CreateTeams(ctx, batch)
+		}, func(v *biz.Team) (*pb.Team, error) {
+			return s.storedTeam(ctx, v.Id)
+		})
 	}
 
 	reply := &pb.CreateTeamsReply{
 		Action:  "CreateTeams",
 		Code:    0,
 		Message: "success",
+		Teams:   items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -74,16 +87,33 @@ func (s *TeamsService) UpdateTeams(ctx context.Context, req *pb.UpdateTeamsReque
 	if req == nil {
 		return nil, ErrRequestNil
 	}
-	ts, err := toBizTeams(req.Teams)
+	var items []*pb.Team
+	var errs map[uint32]string
+	err := maskUpdates(req.UpdateMask, req.Teams, func(t *pb.Team) (*pb.Team, error) {
+		return s.storedTeam(ctx, t.Id)
+	})
 	if err == nil {
-		err = s.usecase.UpdateTeams(ctx, ts)
+		var ts []*biz.Team
+		ts, err = toBizTeams(req.Teams)
+		if err == nil {
+			items, errs, err = commitItems(req.Partial, ts, func(batch []*biz.Team) error {
+				return s.usecase.UpdateTeams(ctx, batch)
+			}, func(v *biz.Team) (*pb.Team, error) {
+				return s.storedTeam(ctx, v.Id)
+			})
+		}
 	}
 	reply := &pb.UpdateTeamsReply{
 		Action:  "UpdateTeams",
 		Code:    0,
 		Message: "success",
+		Teams:   items,
+		Errors:  errs,
 	}
 	if err != nil {
+		if !errors.Is(err, ErrItemsFailed) {
+			return nil, err
+		}
 		reply.Code = 1
 		reply.Message = err.Error()
 		return reply, nil
@@ -102,9 +132,7 @@ func (s *TeamsService) DeleteTeams(ctx context.Context, req *pb.DeleteTeamsReque
 		Message: "success",
 	}
 	if err != nil {
-		reply.Code = 1
-		reply.Message = err.Error()
-		return reply, nil
+		return nil, err
 	}
 	return reply, nil
 }
@@ -123,9 +151,7 @@ func (s *TeamsService) GetTeams(ctx context.Context, req *pb.GetTeamsRequest) (*
 		reply.Team = toPbTeam(t)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func (s *TeamsService) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsReply, error) {
@@ -134,6 +160,8 @@ func (s *TeamsService) ListTeams(ctx context.Context, req *pb.ListTeamsRequest)
 		if req.Page > 0 {
 			filter.Page = req.Page
 		}
+		filter.PageToken = req.PageToken
+		filter.WithTotal = req.WithTotal
 		if req.PageSize > 0 {
 			filter.PageSize = req.PageSize
 		}
@@ -146,24 +174,26 @@ func (s *TeamsService) ListTeams(ctx context.Context, req *pb.ListTeamsRequest)
 		if len(req.Names) > 0 {
 			filter.Names = req.Names
 		}
+		if len(req.OrgIds) > 0 {
+			filter.OrgIds = req.OrgIds
+		}
 		if len(req.LeadersId) > 0 {
 			filter.LeadersId = req.LeadersId
 		}
 	}
 
-	ts, err := s.usecase.ListTeams(ctx, filter)
+	ts, page, err := s.usecase.ListTeams(ctx, filter)
 	reply := &pb.ListTeamsReply{
 		Action:  "ListTeams",
 		Code:    0,
 		Message: "success",
 	}
+	reply.NextPageToken, reply.TotalCount = toPbPageInfo(page)
 	if err == nil {
 		reply.Teams = toPbTeams(ts)
 		return reply, nil
 	}
-	reply.Code = 1
-	reply.Message = err.Error()
-	return reply, nil
+	return nil, err
 }
 
 func toPbTeam(t *biz.Team) *pb.Team {
@@ -176,7 +206,17 @@ func toPbTeam(t *biz.Team) *pb.Team {
 		Description: t.Description,
 		LeaderId:    t.LeaderId,
 		Name:        t.Name,
+		OrgId:       t.OrgId,
+	}
+}
+
+// storedTeam reads the team with id back as stored.
+func (s *TeamsService) storedTeam(ctx context.Context, id uint32) (*pb.Team, error) {
+	current, err := s.usecase.GetTeams(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	return toPbTeam(current), nil
 }
 
 func toPbTeams(ts []*biz.Team) []*pb.Team {
@@ -188,3 +228,67 @@ func toPbTeams(ts []*biz.Team) []*pb.Team {
 	}
 	return res
 }
+
+func (s *TeamsService) ListTeamMembers(ctx context.Context, req *pb.ListTeamMembersRequest) (*pb.ListTeamMembersReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	members, err := s.usecase.ListTeamMembers(ctx, req.TeamId)
+	reply := &pb.ListTeamMembersReply{
+		Action:  "ListTeamMembers",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		reply.Members = append(reply.Members, &pb.TeamMember{
+			UserId:    m.UserId,
+			UserName:  m.UserName,
+			Role:      m.Role,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+	return reply, nil
+}
+
+func (s *TeamsService) AddTeamMembers(ctx context.Context, req *pb.AddTeamMembersRequest) (*pb.AddTeamMembersReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	members := make([]*biz.TeamMember, 0, len(req.Members))
+	for _, m := range req.Members {
+		if m == nil {
+			members = append(members, nil)
+			continue
+		}
+		members = append(members, &biz.TeamMember{TeamId: req.TeamId, UserId: m.UserId, Role: m.Role})
+	}
+	err := s.usecase.AddTeamMembers(ctx, req.TeamId, members)
+	reply := &pb.AddTeamMembersReply{
+		Action:  "AddTeamMembers",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *TeamsService) RemoveTeamMembers(ctx context.Context, req *pb.RemoveTeamMembersRequest) (*pb.RemoveTeamMembersReply, error) {
+	if req == nil {
+		return nil, ErrRequestNil
+	}
+	err := s.usecase.RemoveTeamMembers(ctx, req.TeamId, req.UserIds)
+	reply := &pb.RemoveTeamMembersReply{
+		Action:  "RemoveTeamMembers",
+		Code:    0,
+		Message: "success",
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}